@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// accentDescriptions explains each accent policy in the command's output.
+var accentDescriptions = map[puzzle.AccentPolicy]string{
+	puzzle.AccentsAuto: "auto (strip accents unless the puzzle has accented letters)",
+	puzzle.AccentsFold: "fold (é is entered as E)",
+	puzzle.AccentsKeep: "keep (é is entered as É)",
+}
+
+// newAccentsCmd returns a command that shows or sets how typed accented
+// letters are entered.
+func newAccentsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "accents [auto|fold|keep]",
+		Short: "Show or set how accented letters you type are entered",
+		Long: "Show or set how accented letters typed while playing are entered. International\n" +
+			"keyboards often produce é, ñ or ö through option, AltGr or dead keys:\n\n" +
+			"  fold  strips the accent, so é is entered as E\n" +
+			"  keep  enters the accented letter, so é is entered as É\n" +
+			"  auto  folds, unless the puzzle has accented letters of its own (the default)\n\n" +
+			"Lower case is always accepted and entered as upper case.",
+		Example: "  # Show the current setting\n" +
+			"  unquote accents\n\n" +
+			"  # Always type plain letters, whatever the keyboard sends\n" +
+			"  unquote accents fold",
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: []string{"auto", string(puzzle.AccentsFold), string(puzzle.AccentsKeep)},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadOrNewConfig()
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 1 {
+				policy, ok := puzzle.ParseAccentPolicy(args[0])
+				if !ok {
+					return fmt.Errorf("unknown accent policy %q: expected auto, fold or keep", args[0])
+				}
+				cfg.Accents = string(policy)
+				if err := config.Save(cfg); err != nil {
+					return fmt.Errorf("saving config: %w", err)
+				}
+			}
+
+			policy, _ := puzzle.ParseAccentPolicy(cfg.Accents)
+			fmt.Fprintf(cmd.OutOrStdout(), "Accents: %s\n", accentDescriptions[policy])
+			return nil
+		},
+	}
+}
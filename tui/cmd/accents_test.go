@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+func TestAccentsCmd_SetAndShow(t *testing.T) {
+	setConfigHome(t)
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492", StatsEnabled: true}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+
+	if output, err := executeCommand(NewRootCmd(), "accents"); err != nil || !strings.Contains(output, "Accents: auto") {
+		t.Errorf("accents = %q, %v; want the auto default", output, err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "accents", "keep")
+	if err != nil || !strings.Contains(output, "Accents: keep") {
+		t.Fatalf("accents keep = %q, %v", output, err)
+	}
+	cfg, _ := config.Load()
+	if cfg == nil || cfg.Accents != "keep" || cfg.ClaimCode != "TIGER-MAPLE-7492" {
+		t.Errorf("config = %+v, want the policy saved alongside the claim code", cfg)
+	}
+
+	if _, err := executeCommand(NewRootCmd(), "accents", "auto"); err != nil {
+		t.Fatalf("accents auto: %v", err)
+	}
+	if cfg, _ := config.Load(); cfg == nil || cfg.Accents != "" {
+		t.Errorf("config = %+v, want auto stored as the empty default", cfg)
+	}
+}
+
+func TestAccentsCmd_RejectsUnknownPolicy(t *testing.T) {
+	setConfigHome(t)
+
+	if _, err := executeCommand(NewRootCmd(), "accents", "strip"); err == nil {
+		t.Error("accents strip should fail")
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// backgroundDescriptions explains each background setting in the command's
+// output.
+var backgroundDescriptions = map[ui.Background]string{
+	ui.BackgroundAuto:  "auto (follow the terminal's background)",
+	ui.BackgroundDark:  "dark (light colors for a dark background)",
+	ui.BackgroundLight: "light (dark colors for a light background)",
+}
+
+// newBackgroundCmd returns a command that shows or sets which background the
+// colors are chosen for.
+func newBackgroundCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "background [auto|light|dark]",
+		Short: "Show or set whether colors suit a light or dark terminal",
+		Long: "Show or set which terminal background the colors are chosen for. By default\n" +
+			"unquote asks the terminal; terminals that don't answer are taken to be dark.\n" +
+			"Set light or dark when the colors come out unreadable.",
+		Example: "  # Show the current setting\n" +
+			"  unquote background\n\n" +
+			"  # Use the palette for light terminal themes\n" +
+			"  unquote background light",
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: []string{"auto", string(ui.BackgroundLight), string(ui.BackgroundDark)},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadOrNewConfig()
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 1 {
+				background, ok := ui.ParseBackground(args[0])
+				if !ok {
+					return fmt.Errorf("unknown background %q: expected auto, light or dark", args[0])
+				}
+				cfg.Background = string(background)
+				if err := config.Save(cfg); err != nil {
+					return fmt.Errorf("saving config: %w", err)
+				}
+			}
+
+			background, _ := ui.ParseBackground(cfg.Background)
+			fmt.Fprintf(cmd.OutOrStdout(), "Background: %s\n", backgroundDescriptions[background])
+			return nil
+		},
+	}
+}
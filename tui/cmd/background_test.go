@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+func TestBackgroundCmd_SetAndShow(t *testing.T) {
+	setConfigHome(t)
+
+	if output, err := executeCommand(NewRootCmd(), "background"); err != nil || !strings.Contains(output, "Background: auto") {
+		t.Errorf("background = %q, %v; want the auto default", output, err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "background", "light")
+	if err != nil || !strings.Contains(output, "Background: light") {
+		t.Fatalf("background light = %q, %v", output, err)
+	}
+	if cfg, _ := config.Load(); cfg == nil || cfg.Background != "light" {
+		t.Errorf("config = %+v, want light saved", cfg)
+	}
+
+	if _, err := executeCommand(NewRootCmd(), "background", "auto"); err != nil {
+		t.Fatalf("background auto: %v", err)
+	}
+	if cfg, _ := config.Load(); cfg == nil || cfg.Background != "" {
+		t.Errorf("config = %+v, want auto stored as the empty default", cfg)
+	}
+
+	if _, err := executeCommand(NewRootCmd(), "background", "sepia"); err == nil {
+		t.Error("background sepia should fail")
+	}
+}
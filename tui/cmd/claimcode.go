@@ -8,15 +8,38 @@ import (
 	"github.com/bojanrajkovic/unquote/tui/internal/config"
 )
 
+// claimCodeOutput is the JSON form of the claim-code command. ClaimCode is
+// empty when this device has not registered.
+type claimCodeOutput struct {
+	ClaimCode  string `json:"claimCode"`
+	Registered bool   `json:"registered"`
+}
+
 // newClaimCodeCmd returns a command that displays the stored claim code.
-func newClaimCodeCmd() *cobra.Command {
+func newClaimCodeCmd(output *outputFormat) *cobra.Command {
 	return &cobra.Command{
 		Use:   "claim-code",
 		Short: "Display your stored claim code",
+		Long: "Display the claim code stored on this device, to link another device\n" +
+			"with 'unquote link'.",
+		Example: "  unquote claim-code\n" +
+			"  unquote claim-code --output json",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			cfg, err := config.Load()
 			if err != nil {
-				return fmt.Errorf("loading config: %w", err)
+				err = fmt.Errorf("loading config: %w", err)
+				if *output == outputJSON {
+					return writeJSONError(cmd.OutOrStdout(), "claim-code", err)
+				}
+				return err
+			}
+
+			if *output == outputJSON {
+				var out claimCodeOutput
+				if cfg != nil && cfg.ClaimCode != "" {
+					out = claimCodeOutput{ClaimCode: cfg.ClaimCode, Registered: true}
+				}
+				return writeJSON(cmd.OutOrStdout(), "claim-code", out)
 			}
 
 			if cfg == nil || cfg.ClaimCode == "" {
@@ -43,3 +43,30 @@ func TestClaimCodeCmd_NoConfig(t *testing.T) {
 		t.Errorf("expected output to suggest 'unquote register', got: %q", output)
 	}
 }
+
+func TestClaimCodeCmd_JSON(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+
+	output, err := executeCommand(NewRootCmd(), "claim-code", "--output", "json")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	data, _ := decodeEnvelope(t, output)["data"].(map[string]any)
+	if data["registered"] != false || data["claimCode"] != "" {
+		t.Errorf("unregistered data = %v", data)
+	}
+
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492", StatsEnabled: true}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+
+	output, err = executeCommand(NewRootCmd(), "claim-code", "--output", "json")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	data, _ = decodeEnvelope(t, output)["data"].(map[string]any)
+	if data["registered"] != true || data["claimCode"] != "TIGER-MAPLE-7492" {
+		t.Errorf("registered data = %v", data)
+	}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/pack"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// newCompletionCmd returns a command that prints a shell completion script.
+// It replaces cobra's default completion command so the supported shells and
+// install instructions live in one place.
+func newCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish|powershell",
+		Short: "Generate a shell completion script",
+		Long: "Generate a shell completion script for unquote.\n\n" +
+			"Load completions in the current shell:\n" +
+			"  bash:        source <(unquote completion bash)\n" +
+			"  zsh:         source <(unquote completion zsh)\n" +
+			"  fish:        unquote completion fish | source\n" +
+			"  powershell:  unquote completion powershell | Out-String | Invoke-Expression\n\n" +
+			"To load them in every session, write the script to your shell's completion directory.",
+		Example: "  unquote completion zsh > \"${fpath[1]}/_unquote\"\n" +
+			"  unquote completion fish > ~/.config/fish/completions/unquote.fish",
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			out := cmd.OutOrStdout()
+
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(out, true)
+			case "zsh":
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, true)
+			case "powershell":
+				return root.GenPowerShellCompletionWithDesc(out)
+			default:
+				return fmt.Errorf("unsupported shell %q", args[0])
+			}
+		},
+	}
+}
+
+// completePackNames completes the first argument with the slugs of installed
+// packs, described by their names. Later arguments are completed as files, for
+// the quote files 'pack export' takes.
+func completePackNames(_ *cobra.Command, args []string, _ string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+
+	packs, err := pack.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	completions := make([]cobra.Completion, 0, len(packs))
+	for _, p := range packs {
+		// Pack names are untrusted; keep them to one clean line
+		name := strings.Join(strings.Fields(ui.SanitizeString(p.Name)), " ")
+		completions = append(completions, cobra.CompletionWithDesc(p.Slug(), name))
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/pack"
+)
+
+func TestCompletionCmd_Shells(t *testing.T) {
+	for shell, want := range map[string]string{
+		"bash":       "bash completion V2 for unquote",
+		"zsh":        "#compdef unquote",
+		"fish":       "fish completion for unquote",
+		"powershell": "powershell completion for unquote",
+	} {
+		output, err := executeCommand(NewRootCmd(), "completion", shell)
+		if err != nil {
+			t.Errorf("completion %s: unexpected error: %v", shell, err)
+			continue
+		}
+		if !strings.Contains(output, want) {
+			t.Errorf("completion %s output missing %q", shell, want)
+		}
+	}
+}
+
+func TestCompletionCmd_RejectsUnknownShell(t *testing.T) {
+	if _, err := executeCommand(NewRootCmd(), "completion", "tcsh"); err == nil {
+		t.Error("expected an error for an unsupported shell")
+	}
+	if _, err := executeCommand(NewRootCmd(), "completion"); err == nil {
+		t.Error("expected an error without a shell")
+	}
+}
+
+func TestCompletion_PackNames(t *testing.T) {
+	setPackHomes(t)
+
+	p := &pack.Pack{
+		Format:  pack.FormatVersion,
+		Name:    "Stoic\nSayings",
+		Puzzles: []pack.Entry{{Quote: "The obstacle is the way", Author: "Marcus Aurelius"}},
+	}
+	if err := pack.Install(p); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "__complete", "pack", "play", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "stoic-sayings\tStoic Sayings") {
+		t.Errorf("pack play should complete installed packs, got:\n%s", output)
+	}
+
+	// Quote files after the pack name complete as paths
+	output, err = executeCommand(NewRootCmd(), "__complete", "pack", "export", "stoic-sayings", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(output, "stoic-sayings") || !strings.Contains(output, ":0") {
+		t.Errorf("export's quote files should complete as files, got:\n%s", output)
+	}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// recoverable is a model that can hand over its game in progress; app.Model
+// is one.
+type recoverable interface {
+	tea.Model
+	PendingSession() (storage.Namespace, *storage.GameSession)
+}
+
+// crashGuard wraps the app's model so a panic doesn't lose the game in
+// progress. Session saves run as commands, so the last moves may not be on
+// disk when the program dies; the guard keeps the latest model and flushes
+// its game before letting the panic carry on to Bubble Tea, which restores
+// the terminal.
+type crashGuard struct {
+	model recoverable
+	saved bool // the game was flushed; a crash only saves once
+}
+
+func (g *crashGuard) Init() tea.Cmd {
+	return g.model.Init()
+}
+
+func (g *crashGuard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer g.recover()
+	model, cmd := g.model.Update(msg)
+	g.model = model.(recoverable)
+	return g, cmd
+}
+
+func (g *crashGuard) View() tea.View {
+	defer g.recover()
+	return g.model.View()
+}
+
+// recover saves the game and re-panics when called deferred during a panic.
+func (g *crashGuard) recover() {
+	if r := recover(); r != nil {
+		g.save(fmt.Sprint(r))
+		panic(r)
+	}
+}
+
+// save flushes the game in progress, if any, and reports whether it did. A
+// game the next start can load again goes to the recovery file, which the
+// next start offers back; any other is saved as an ordinary session.
+func (g *crashGuard) save(reason string) bool {
+	if g.saved {
+		return true
+	}
+	namespace, session := g.model.PendingSession()
+	if session == nil {
+		return false
+	}
+
+	r := &storage.Recovery{CrashedAt: time.Now(), Reason: reason, Namespace: namespace, Session: *session}
+	var err error
+	if r.Restorable() {
+		err = storage.SaveRecovery(r)
+	} else {
+		err = namespace.SaveSession(session)
+	}
+	g.saved = err == nil
+	return g.saved
+}
+
+// crashed finishes up after the program stopped on err. Panics in commands
+// never pass through the guard, so the game is flushed here if it wasn't
+// already, and the error tells the player their game is safe.
+func (g *crashGuard) crashed(err error) error {
+	if !errors.Is(err, tea.ErrProgramPanic) {
+		return err
+	}
+	if g.save("panic in a command") {
+		return fmt.Errorf("%w; your game was saved and is offered back the next time unquote starts", err)
+	}
+	return err
+}
+
+// loadRecovery returns the game saved when unquote last crashed, for the app
+// to offer back, or nil. A recovery file that can't be read is dropped, since
+// it would only fail again; safe mode leaves it for a later start.
+func loadRecovery(safeMode bool) *storage.Recovery {
+	if safeMode {
+		return nil
+	}
+	r, err := storage.LoadRecovery()
+	if err != nil {
+		_ = storage.ClearRecovery()
+		return nil
+	}
+	return r
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// panickyModel plays one game and panics on any string message.
+type panickyModel struct {
+	namespace storage.Namespace
+	session   *storage.GameSession
+}
+
+func (m panickyModel) Init() tea.Cmd { return nil }
+
+func (m panickyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if s, ok := msg.(string); ok {
+		panic(s)
+	}
+	return m, nil
+}
+
+func (m panickyModel) View() tea.View { return tea.NewView("") }
+
+func (m panickyModel) PendingSession() (storage.Namespace, *storage.GameSession) {
+	return m.namespace, m.session
+}
+
+// setStateHome points the state directory at a temp dir.
+func setStateHome(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", dir)
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+	return dir
+}
+
+func TestCrashGuard_SavesRecoveryAndRepanics(t *testing.T) {
+	storagetest.UseMemory(t)
+	guard := &crashGuard{model: panickyModel{
+		namespace: storage.Daily,
+		session:   &storage.GameSession{GameID: "game-001", Inputs: map[string]string{"X": "T"}},
+	}}
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recovered %v, want the original panic to carry on", r)
+			}
+		}()
+		guard.Update("boom")
+	}()
+
+	r, err := storage.LoadRecovery()
+	if err != nil || r == nil {
+		t.Fatalf("LoadRecovery() = %v, %v; want the game saved", r, err)
+	}
+	if r.Reason != "boom" || r.Namespace != storage.Daily || r.Session.Inputs["X"] != "T" {
+		t.Errorf("recovery = %+v, want game-001 with the panic's reason", r)
+	}
+}
+
+func TestCrashGuard_SavesUnrestorableAsSession(t *testing.T) {
+	storagetest.UseMemory(t)
+	guard := &crashGuard{model: panickyModel{
+		namespace: storage.Custom,
+		session:   &storage.GameSession{GameID: "local-abc", Inputs: map[string]string{"X": "T"}},
+	}}
+
+	if !guard.save("boom") {
+		t.Fatal("save() = false, want the custom game saved")
+	}
+	if r, err := storage.LoadRecovery(); err != nil || r != nil {
+		t.Errorf("LoadRecovery() = %v, %v; a custom puzzle can't be offered back", r, err)
+	}
+	if s, err := storage.Custom.LoadSession("local-abc"); err != nil || s == nil || s.Inputs["X"] != "T" {
+		t.Errorf("Custom.LoadSession() = %v, %v; want the game saved as a session", s, err)
+	}
+}
+
+func TestCrashGuard_Crashed(t *testing.T) {
+	storagetest.UseMemory(t)
+
+	playing := &crashGuard{model: panickyModel{namespace: storage.Daily, session: &storage.GameSession{GameID: "game-001"}}}
+	err := playing.crashed(tea.ErrProgramPanic)
+	if !errors.Is(err, tea.ErrProgramPanic) || !strings.Contains(err.Error(), "your game was saved") {
+		t.Errorf("crashed() = %v, want the panic with a note that the game was saved", err)
+	}
+	if r, _ := storage.LoadRecovery(); r == nil {
+		t.Error("a panic in a command should still save the game")
+	}
+
+	idle := &crashGuard{model: panickyModel{namespace: storage.Daily}}
+	if err := idle.crashed(tea.ErrProgramPanic); strings.Contains(err.Error(), "saved") {
+		t.Errorf("crashed() with no game = %v, want no claim it was saved", err)
+	}
+	if err := idle.crashed(nil); err != nil {
+		t.Errorf("crashed(nil) = %v, want nil", err)
+	}
+}
+
+func TestLoadRecovery(t *testing.T) {
+	dir := setStateHome(t)
+	if err := storage.SaveRecovery(&storage.Recovery{Namespace: storage.Daily, Session: storage.GameSession{GameID: "game-001"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if r := loadRecovery(true); r != nil {
+		t.Errorf("loadRecovery(safe mode) = %+v, want nil", r)
+	}
+	if r := loadRecovery(false); r == nil || r.Session.GameID != "game-001" {
+		t.Errorf("loadRecovery() = %+v, want the saved game", r)
+	}
+
+	// An unreadable file is dropped rather than failing every start
+	path := filepath.Join(dir, "unquote", "recovery.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if r := loadRecovery(false); r != nil {
+		t.Errorf("loadRecovery() on a corrupt file = %+v, want nil", r)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("corrupt recovery file still there: %v", err)
+	}
+}
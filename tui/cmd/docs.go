@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/versioninfo"
+)
+
+// newDocsCmd returns a hidden command that writes man pages for the whole
+// command tree, for packagers to ship alongside the binary.
+func newDocsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "docs [dir]",
+		Short: "Generate man pages",
+		Long: "Generate a section 1 man page for unquote and each of its subcommands.\n\n" +
+			"Pages are written to dir (default: ./man), which is created if needed.",
+		Example: "  unquote docs ./man\n" +
+			"  man ./man/unquote-pack-play.1",
+		Hidden: true,
+		Args:   cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "man"
+			if len(args) > 0 {
+				dir = args[0]
+			}
+
+			if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gosec // man pages are meant to be readable
+				return fmt.Errorf("creating %s: %w", dir, err)
+			}
+
+			root := cmd.Root()
+			// A generated-on footer would make every build's pages differ
+			root.DisableAutoGenTag = true
+			escapeUsePlaceholders(root)
+			header := &doc.GenManHeader{
+				Title:   "UNQUOTE",
+				Section: "1",
+				Source:  "unquote " + versioninfo.Get().Version,
+				Manual:  "Unquote Manual",
+			}
+			if err := doc.GenManTree(root, header, dir); err != nil {
+				return fmt.Errorf("generating man pages: %w", err)
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Wrote man pages to %s\n", dir)
+			return nil
+		},
+	}
+}
+
+// escapeUsePlaceholders escapes the angle brackets around argument names like
+// "<pack>" so the man page converter doesn't drop them as HTML tags.
+func escapeUsePlaceholders(cmd *cobra.Command) {
+	cmd.Use = strings.NewReplacer("<", `\<`, ">", `\>`).Replace(cmd.Use)
+	for _, child := range cmd.Commands() {
+		escapeUsePlaceholders(child)
+	}
+}
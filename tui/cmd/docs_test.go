@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestDocsCmd_WritesManPages(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "man")
+
+	if _, err := executeCommand(NewRootCmd(), "docs", dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"unquote.1", "unquote-stats.1", "unquote-pack-play.1"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected man page %s: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "unquote-docs.1")); !os.IsNotExist(err) {
+		t.Error("the hidden docs command should not get a man page")
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "unquote-pack-play.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"unquote pack play <pack>", ".SH EXAMPLE", "unquote pack play stoic-sayings"} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("pack play man page missing %q:\n%s", want, page)
+		}
+	}
+}
+
+func TestDocsCmd_Hidden(t *testing.T) {
+	output, err := executeCommand(NewRootCmd(), "--help")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(output, "docs") {
+		t.Error("docs should not be listed in --help")
+	}
+}
+
+// Every user-facing command documents itself well enough for a man page.
+func TestCommands_HaveLongAndExample(t *testing.T) {
+	var check func(cmd *cobra.Command)
+	check = func(cmd *cobra.Command) {
+		if cmd.Long == "" || cmd.Example == "" {
+			t.Errorf("%q needs both Long and Example", cmd.CommandPath())
+		}
+		for _, child := range cmd.Commands() {
+			if child.IsAvailableCommand() {
+				check(child)
+			}
+		}
+	}
+	check(NewRootCmd())
+}
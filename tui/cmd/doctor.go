@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// Config states reported by the doctor command.
+const (
+	configOK         = "ok"
+	configMissing    = "missing"
+	configUnreadable = "unreadable"
+)
+
+// doctorOutput is the JSON form of the doctor command.
+type doctorOutput struct {
+	Recovery    *recoveryOutput   `json:"recovery"` // null when no crashed game is waiting
+	LastRun     *lastRunOutput    `json:"lastRun"`  // null before the first run that recorded one
+	Network     networkOutput     `json:"network"`
+	Config      string            `json:"config"`
+	ConfigError string            `json:"configError,omitempty"`
+	Sessions    []namespaceOutput `json:"sessions"`
+}
+
+// namespaceOutput is the health of one directory of saved sessions.
+type namespaceOutput struct {
+	Name        string `json:"name"`
+	CorruptDir  string `json:"corruptDir"` // where damaged files are moved; may not exist
+	Error       string `json:"error,omitempty"`
+	Sessions    int    `json:"sessions"`
+	Quarantined int    `json:"quarantined"` // damaged files moved to corruptDir, recovered or not
+}
+
+// recoveryOutput is the game saved when unquote last crashed.
+type recoveryOutput struct {
+	CrashedAt time.Time `json:"crashedAt"`
+	GameID    string    `json:"gameId"`
+	Date      string    `json:"date,omitempty"`
+}
+
+// lastRunOutput is when the TUI last ran and whether it shut down cleanly.
+type lastRunOutput struct {
+	StartedAt  time.Time  `json:"startedAt"`
+	ShutdownAt *time.Time `json:"shutdownAt,omitempty"`
+	Clean      bool       `json:"clean"` // false when it was killed, or is still running
+}
+
+// namespaceNames are the names the doctor command shows for each namespace.
+var namespaceNames = map[storage.Namespace]string{
+	storage.Daily:    "daily",
+	storage.Practice: "practice",
+	storage.Custom:   "custom",
+	storage.Duel:     "duel",
+}
+
+// newDoctorCmd returns a command that checks the local config and saved
+// games, quarantining damaged session files as it reads them, and how the
+// API answers.
+func newDoctorCmd(insecure *bool, output *outputFormat) *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check your config and saved games for problems",
+		Long: "Check that the config file can be read and read every saved game, moving\n" +
+			"damaged session files aside to a corrupt/ directory. Files cut off partway\n" +
+			"through a save are repaired where possible; the damaged original is kept\n" +
+			"either way, and quarantine.log says what happened to each. Last, a few requests\n" +
+			"to the API show whether it can be reached and how fast it answers; everything\n" +
+			"else works offline.",
+		Example: "  unquote doctor\n\n" +
+			"  # Count quarantined files, for a support request\n" +
+			"  unquote doctor --output json | jq '[.data.sessions[].quarantined] | add'\n\n" +
+			"  # API latencies, for a report that unquote feels slow\n" +
+			"  unquote doctor --output json | jq .data.network",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			out := runDoctor(*insecure)
+			if *output == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), "doctor", out)
+			}
+			printDoctor(cmd.OutOrStdout(), out)
+			return nil
+		},
+	}
+}
+
+// runDoctor checks the config, every session namespace, the crash recovery
+// file, how the last run ended and the API. Problems are reported in the
+// output, not returned.
+func runDoctor(insecure bool) doctorOutput {
+	out := doctorOutput{Config: configOK}
+	switch cfg, err := config.Load(); {
+	case err != nil:
+		out.Config, out.ConfigError = configUnreadable, err.Error()
+	case cfg == nil:
+		out.Config = configMissing
+	}
+
+	for _, n := range storage.Namespaces {
+		ns := namespaceOutput{Name: namespaceNames[n]}
+		// Listing reads every file, which quarantines the damaged ones first
+		sessions, err := n.ListSessions()
+		if err != nil {
+			ns.Error = err.Error()
+		}
+		ns.Sessions = len(sessions)
+		if quarantined, err := n.Quarantined(); err == nil {
+			ns.Quarantined = len(quarantined)
+		} else if ns.Error == "" {
+			ns.Error = err.Error()
+		}
+		ns.CorruptDir, _ = n.CorruptDir()
+		out.Sessions = append(out.Sessions, ns)
+	}
+
+	if r, err := storage.LoadRecovery(); err == nil && r != nil {
+		out.Recovery = &recoveryOutput{CrashedAt: r.CrashedAt, GameID: r.Session.GameID, Date: r.Session.Date}
+	}
+
+	if r, err := storage.LoadRunMarker(); err == nil && r != nil {
+		out.LastRun = &lastRunOutput{StartedAt: r.StartedAt, ShutdownAt: r.ShutdownAt, Clean: r.Clean()}
+	}
+
+	if client, err := api.NewClient(insecure); err != nil {
+		out.Network = newNetworkOutput("", nil, err)
+	} else {
+		err := probeNetwork(client, 1)
+		out.Network = newNetworkOutput(client.BaseURL(), api.DefaultMetrics().Snapshot(), err)
+	}
+	return out
+}
+
+// printDoctor writes the doctor command's text output.
+func printDoctor(w io.Writer, out doctorOutput) {
+	switch out.Config {
+	case configMissing:
+		fmt.Fprintln(w, "Config: not created yet (run 'unquote' to set up)")
+	case configUnreadable:
+		fmt.Fprintf(w, "Config: unreadable: %s\n", out.ConfigError)
+	default:
+		fmt.Fprintln(w, "Config: ok")
+	}
+
+	fmt.Fprintln(w, "Saved games:")
+	var quarantined []namespaceOutput
+	for _, ns := range out.Sessions {
+		line := fmt.Sprintf("  %-9s %d saved", ns.Name, ns.Sessions)
+		if ns.Quarantined > 0 {
+			line += fmt.Sprintf(", %d quarantined", ns.Quarantined)
+			quarantined = append(quarantined, ns)
+		}
+		if ns.Error != "" {
+			line += " (error: " + ns.Error + ")"
+		}
+		fmt.Fprintln(w, line)
+	}
+	if len(quarantined) > 0 {
+		fmt.Fprintln(w, "Damaged session files were moved aside; quarantine.log in each directory says why:")
+		for _, ns := range quarantined {
+			fmt.Fprintf(w, "  %s\n", ns.CorruptDir)
+		}
+	}
+
+	printDoctorNetwork(w, out.Network)
+
+	switch {
+	case out.LastRun == nil:
+	case out.LastRun.Clean:
+		fmt.Fprintf(w, "Last run: started %s, shut down cleanly\n", out.LastRun.StartedAt.Local().Format("2006-01-02 15:04"))
+	default:
+		fmt.Fprintf(w, "Last run: started %s, didn't shut down cleanly (killed, or still running)\n",
+			out.LastRun.StartedAt.Local().Format("2006-01-02 15:04"))
+	}
+
+	if out.Recovery == nil {
+		fmt.Fprintln(w, "Crash recovery: nothing waiting")
+		return
+	}
+	game := out.Recovery.GameID
+	if out.Recovery.Date != "" {
+		game = "the " + out.Recovery.Date + " puzzle"
+	}
+	fmt.Fprintf(w, "Crash recovery: %s, saved when unquote crashed at %s, is offered back on the next start\n",
+		game, out.Recovery.CrashedAt.Local().Format("2006-01-02 15:04"))
+}
+
+// printDoctorNetwork writes the doctor command's network line: whether the
+// API answered and its slowest endpoint.
+func printDoctorNetwork(w io.Writer, network networkOutput) {
+	switch {
+	case network.Error != "":
+		fmt.Fprintf(w, "Network: trouble reaching the API: %s\n", network.Error)
+	case len(network.Endpoints) == 0:
+		fmt.Fprintf(w, "Network: %s not checked\n", network.URL)
+	default:
+		slowest := slices.MaxFunc(network.Endpoints, func(a, b endpointOutput) int { return cmp.Compare(a.MaxMs, b.MaxMs) })
+		fmt.Fprintf(w, "Network: %s answered; slowest was %s at %s\n", network.URL, slowest.Endpoint,
+			roundLatency(time.Duration(slowest.MaxMs*float64(time.Millisecond))))
+	}
+	fmt.Fprintln(w, "  Per-endpoint latencies: unquote stats --network")
+}
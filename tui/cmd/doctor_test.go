@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+func TestDoctorCmd_AllWell(t *testing.T) {
+	setStatusHomes(t)
+	networkServer(t, true)
+	if err := config.Save(&config.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.SaveSession(&storage.GameSession{GameID: "game-001"}); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "doctor")
+	if err != nil {
+		t.Fatalf("doctor error = %v", err)
+	}
+	for _, want := range []string{"Config: ok", "daily     1 saved", "practice  0 saved", "Network: http://127.0.0.1", "answered; slowest was", "Crash recovery: nothing waiting"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "quarantined") {
+		t.Errorf("output reports quarantined files with none damaged:\n%s", output)
+	}
+}
+
+func TestDoctorCmd_QuarantinesDamagedSessions(t *testing.T) {
+	setStatusHomes(t)
+	networkServer(t, true)
+	if err := storage.SaveSession(&storage.GameSession{GameID: "game-001"}); err != nil {
+		t.Fatal(err)
+	}
+	practice := filepath.Join(xdg.StateHome, "unquote", string(storage.Practice))
+	if err := os.MkdirAll(practice, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(practice, "broken.json"), []byte("\x00\x00"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "doctor")
+	if err != nil {
+		t.Fatalf("doctor error = %v", err)
+	}
+	for _, want := range []string{"Config: not created yet", "daily     1 saved", "practice  0 saved, 1 quarantined", filepath.Join(practice, "corrupt")} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(practice, "broken.json")); !os.IsNotExist(err) {
+		t.Errorf("damaged file still in place: %v", err)
+	}
+}
+
+func TestDoctorCmd_JSON(t *testing.T) {
+	setStatusHomes(t)
+	networkServer(t, true)
+	crashedAt := time.Date(2026, 10, 15, 21, 0, 0, 0, time.UTC)
+	if err := storage.SaveRecovery(&storage.Recovery{
+		CrashedAt: crashedAt,
+		Namespace: storage.Daily,
+		Session:   storage.GameSession{GameID: "game-001", Date: "2026-10-15"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.SaveRunMarker(&storage.RunMarker{StartedAt: crashedAt, PID: 42}); err != nil {
+		t.Fatal(err)
+	}
+	configDir := filepath.Join(xdg.ConfigHome, "unquote")
+	if err := os.MkdirAll(configDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.json"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "doctor", "--output", "json")
+	if err != nil {
+		t.Fatalf("doctor error = %v", err)
+	}
+	var env struct {
+		Data doctorOutput `json:"data"`
+		OK   bool         `json:"ok"`
+	}
+	if err := json.Unmarshal([]byte(output), &env); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, output)
+	}
+	if !env.OK || env.Data.Config != configUnreadable || env.Data.ConfigError == "" {
+		t.Errorf("config = %q (%q), want it reported unreadable", env.Data.Config, env.Data.ConfigError)
+	}
+	if len(env.Data.Sessions) != len(storage.Namespaces) {
+		t.Errorf("sessions = %+v, want one entry per namespace", env.Data.Sessions)
+	}
+	if r := env.Data.Recovery; r == nil || r.GameID != "game-001" || !r.CrashedAt.Equal(crashedAt) {
+		t.Errorf("recovery = %+v, want the crashed game", r)
+	}
+	if r := env.Data.LastRun; r == nil || r.Clean || !r.StartedAt.Equal(crashedAt) {
+		t.Errorf("lastRun = %+v, want the run that never shut down", r)
+	}
+	if n := env.Data.Network; n.Error != "" || len(n.Endpoints) != 2 || n.Endpoints[0].Requests != 1 {
+		t.Errorf("network = %+v, want one health check and one puzzle request", n)
+	}
+}
+
+func TestDoctorCmd_ReportsNetworkTrouble(t *testing.T) {
+	setStatusHomes(t)
+	networkServer(t, false)
+
+	output, err := executeCommand(NewRootCmd(), "doctor")
+	if err != nil {
+		t.Fatalf("doctor error = %v", err)
+	}
+	if !strings.Contains(output, "Network: trouble reaching the API:") {
+		t.Errorf("output should report the API failing:\n%s", output)
+	}
+}
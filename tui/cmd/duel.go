@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/app"
+)
+
+// duelRoomAlphabet leaves out letters and digits that are easy to mix up when
+// reading a room code aloud (0/O, 1/I/L).
+const duelRoomAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// duelRoomLength is the length of generated room codes.
+const duelRoomLength = 6
+
+var duelRoomPattern = regexp.MustCompile(`^[A-Z0-9-]{4,32}$`)
+
+// newDuelRoom generates a random room code. Codes come from crypto/rand so
+// strangers can't guess their way into a room.
+func newDuelRoom() string {
+	size := big.NewInt(int64(len(duelRoomAlphabet)))
+	code := make([]byte, duelRoomLength)
+	for i := range code {
+		// crypto/rand.Int never fails on supported platforms
+		n, _ := rand.Int(rand.Reader, size)
+		code[i] = duelRoomAlphabet[n.Int64()]
+	}
+	return string(code)
+}
+
+// parseDuelRoom normalizes a room code typed by the player. Codes are case
+// insensitive.
+func parseDuelRoom(arg string) (string, error) {
+	room := strings.ToUpper(strings.TrimSpace(arg))
+	if !duelRoomPattern.MatchString(room) {
+		return "", fmt.Errorf("invalid room code %q: use 4 to 32 letters, digits or dashes", arg)
+	}
+	return room, nil
+}
+
+// newDuelCmd returns a command that races a friend on today's puzzle.
+func newDuelCmd(insecure *bool) *cobra.Command {
+	var accessible bool
+
+	cmd := &cobra.Command{
+		Use:   "duel [room]",
+		Short: "Race a friend on today's puzzle",
+		Long: "Race a friend on today's puzzle.\n\n" +
+			"Without a room code, a new room is opened; share the code shown on screen.\n" +
+			"The clock starts for both players once the second one joins, and each side\n" +
+			"sees the other's progress. Duels never count toward your stats.",
+		Example: "  # Open a room and wait for a friend\n" +
+			"  unquote duel\n\n" +
+			"  # Join a friend's room\n" +
+			"  unquote duel K7PX2M",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			room := newDuelRoom()
+			if len(args) == 1 {
+				var err error
+				if room, err = parseDuelRoom(args[0]); err != nil {
+					return err
+				}
+			}
+
+			return runTUI(cmd, app.Options{
+				Duel:       room,
+				Insecure:   *insecure,
+				Accessible: accessible,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&accessible, "accessible", false, "screen-reader friendly output: plain text, no color-only cues")
+
+	return cmd
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDuelCmd_Registered(t *testing.T) {
+	root := NewRootCmd()
+	var found bool
+	for _, sub := range root.Commands() {
+		if sub.Name() == "duel" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("expected 'duel' subcommand to be registered")
+	}
+}
+
+func TestParseDuelRoom(t *testing.T) {
+	tests := []struct {
+		arg     string
+		want    string
+		wantErr bool
+	}{
+		{arg: "k7px2m", want: "K7PX2M"},
+		{arg: " team-42 ", want: "TEAM-42"},
+		{arg: "abc", wantErr: true},
+		{arg: "room/../x", wantErr: true},
+		{arg: strings.Repeat("A", 33), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDuelRoom(tt.arg)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseDuelRoom(%q) = %q, %v", tt.arg, got, err)
+		}
+	}
+}
+
+func TestNewDuelRoom(t *testing.T) {
+	room := newDuelRoom()
+	if _, err := parseDuelRoom(room); err != nil || len(room) != duelRoomLength {
+		t.Errorf("newDuelRoom() = %q, want a valid %d-character code", room, duelRoomLength)
+	}
+	if strings.ContainsAny(room, "01ILO") {
+		t.Errorf("newDuelRoom() = %q, want no easily confused characters", room)
+	}
+}
+
+func TestDuelCmd_RejectsInvalidRoom(t *testing.T) {
+	_, err := executeCommand(NewRootCmd(), "duel", "no")
+	if err == nil || !strings.Contains(err.Error(), "invalid room code") {
+		t.Errorf("error = %v, want an invalid room code error", err)
+	}
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// icalLineLimit is the longest content line iCalendar allows, in octets,
+// before it must be folded (RFC 5545 §3.1).
+const icalLineLimit = 75
+
+// historyEntry is one solved daily puzzle in the solve history.
+type historyEntry struct {
+	SolvedAt         time.Time           `json:"solvedAt"`
+	LongestPause     *letterSpan         `json:"longestPause,omitempty"`    // the letter the player was stuck on longest before placing it
+	LongestRevision  *letterSpan         `json:"longestRevision,omitempty"` // the letter that took longest to settle
+	Date             string              `json:"date"`                      // the puzzle's day, or the solve's local day for older sessions
+	GameID           string              `json:"gameId"`
+	Author           string              `json:"author,omitempty"`
+	Category         string              `json:"category,omitempty"`
+	Note             string              `json:"note,omitempty"`
+	AssistLevel      storage.AssistLevel `json:"assistLevel,omitempty"` // empty for clean solves
+	CompletionTimeMs int64               `json:"completionTimeMs"`
+	Difficulty       int                 `json:"difficulty,omitempty"`
+}
+
+// letterSpan is a cipher letter and the time spent on it.
+type letterSpan struct {
+	Letter string `json:"letter"`
+	Ms     int64  `json:"ms"`
+}
+
+// letterBreakdown returns where the player got stuck on a solve, from its
+// per-letter timings, leaving out spans too short to call out like the
+// solved screen does. Sessions saved without timings have none.
+func letterBreakdown(times storage.LetterTimes) (pause, revision *letterSpan) {
+	if letter, d := times.LongestPause(); d >= storage.MinPause {
+		pause = &letterSpan{Letter: letter, Ms: d.Milliseconds()}
+	}
+	if letter, d := times.LongestRevision(); d >= storage.MinRevision {
+		revision = &letterSpan{Letter: letter, Ms: d.Milliseconds()}
+	}
+	return pause, revision
+}
+
+// solveHistory lists the daily puzzles solved on this device, oldest first.
+// Sessions saved before they recorded their date fall on the local day they
+// were solved, in loc.
+func solveHistory(loc *time.Location) ([]historyEntry, error) {
+	// Not ListSolvedSessions, which leaves out solves already uploaded
+	sessions, err := storage.Daily.ListSessions()
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	history := make([]historyEntry, 0, len(sessions))
+	for _, s := range sessions {
+		solvedAt, ok := s.SolveTime()
+		if !s.Solved || !ok {
+			continue
+		}
+		date := s.Date
+		if date == "" {
+			date = solvedAt.In(loc).Format(time.DateOnly)
+		}
+		pause, revision := letterBreakdown(s.LetterTimes)
+		history = append(history, historyEntry{
+			SolvedAt:         solvedAt,
+			LongestPause:     pause,
+			LongestRevision:  revision,
+			Date:             date,
+			GameID:           s.GameID,
+			Author:           s.Author,
+			Category:         s.Category,
+			Note:             s.Note,
+			AssistLevel:      cmp.Or(s.AssistLevel, s.Assists.Level()),
+			CompletionTimeMs: s.CompletionTime.Milliseconds(),
+			Difficulty:       s.Difficulty,
+		})
+	}
+	slices.SortFunc(history, func(a, b historyEntry) int {
+		return cmp.Or(strings.Compare(a.Date, b.Date), a.SolvedAt.Compare(b.SolvedAt))
+	})
+	return history, nil
+}
+
+// newExportCmd returns a command that writes the solve history as a calendar
+// or JSON. --output json is the same as --format json.
+func newExportCmd(output *outputFormat) *cobra.Command {
+	var file, format string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export your solve history as a calendar or JSON",
+		Long: "Export the daily puzzles solved on this device. The iCalendar format (the\n" +
+			"default) has an all-day event for each solved day with the solve time in its\n" +
+			"description; import or subscribe to the file in a calendar app to see your\n" +
+			"puzzle habit next to everything else. JSON lists the same solves.\n\n" +
+			"Practice, custom and duel games are not included. Works offline.",
+		Example: "  # A calendar of your solves\n" +
+			"  unquote export -f unquote.ics\n\n" +
+			"  # The same history for scripts\n" +
+			"  unquote export --format json",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *output == outputJSON {
+				if cmd.Flags().Changed("format") && format != "json" {
+					return fmt.Errorf("--format %s can't be combined with --output json", format)
+				}
+				format = "json"
+			}
+			history, err := solveHistory(playerLocation())
+			if err != nil {
+				return err
+			}
+
+			var buf bytes.Buffer
+			switch format {
+			case "ical", "ics":
+				writeHistoryICal(&buf, history, time.Now())
+			case "json":
+				data, err := json.MarshalIndent(history, "", "  ")
+				if err != nil {
+					return fmt.Errorf("marshaling history: %w", err)
+				}
+				buf.Write(append(data, '\n'))
+			default:
+				return fmt.Errorf("unknown format %q: expected ical or json", format)
+			}
+
+			if file == "" || file == "-" {
+				_, err = cmd.OutOrStdout().Write(buf.Bytes())
+				return err
+			}
+			if err := os.WriteFile(file, buf.Bytes(), 0o644); err != nil { //nolint:gosec // an export is meant to be read by other apps
+				return fmt.Errorf("writing history file: %w", err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %d solves to %s\n", len(history), file)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file to write (default: stdout)")
+	cmd.Flags().StringVar(&format, "format", "ical", "export format: ical or json (--output json also picks json)")
+
+	return cmd
+}
+
+// writeHistoryICal writes the history as an iCalendar with one all-day event
+// per solve, stamped with now. Event UIDs are the game IDs, so re-importing
+// an export updates the events rather than duplicating them.
+func writeHistoryICal(w io.Writer, history []historyEntry, now time.Time) {
+	const stampFormat = "20060102T150405Z"
+	line := func(s string) {
+		_, _ = io.WriteString(w, foldICalLine(s)+"\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Unquote//Solve history//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:Unquote")
+	for _, h := range history {
+		day, err := time.Parse(time.DateOnly, h.Date)
+		if err != nil {
+			continue
+		}
+		solveTime := formatMs(float64(h.CompletionTimeMs))
+		description := "Solved in " + solveTime
+		if h.Author != "" {
+			description += "\nQuote by " + h.Author
+		}
+		if h.Category != "" {
+			description += "\nCategory: " + h.Category
+		}
+		if h.Note != "" {
+			description += "\nNote: " + h.Note
+		}
+
+		line("BEGIN:VEVENT")
+		line("UID:" + escapeICalText(h.GameID) + "@playunquote.com")
+		line("DTSTAMP:" + now.UTC().Format(stampFormat))
+		line("DTSTART;VALUE=DATE:" + day.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + day.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escapeICalText("Unquote solved in "+solveTime))
+		line("DESCRIPTION:" + escapeICalText(description))
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+}
+
+// escapeICalText escapes an iCalendar TEXT value (RFC 5545 §3.3.11).
+var escapeICalText = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace
+
+// foldICalLine splits a content line longer than icalLineLimit octets into
+// continuation lines, which start with a space. It never splits a UTF-8
+// sequence.
+func foldICalLine(s string) string {
+	if len(s) <= icalLineLimit {
+		return s
+	}
+	var b strings.Builder
+	limit := icalLineLimit
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = icalLineLimit - 1 // the leading space counts
+	}
+	b.WriteString(s)
+	return b.String()
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// saveHistory stores two solved daily puzzles, one already uploaded, plus an
+// unfinished one and a practice solve, neither of which is history.
+func saveHistory(t *testing.T) {
+	t.Helper()
+	setConfigHome(t)
+	storagetest.UseMemory(t)
+	solvedAt := time.Date(2026, 1, 16, 8, 30, 0, 0, time.UTC)
+	for _, s := range []*storage.GameSession{
+		{GameID: "game-0116", Date: "2026-01-16", Author: "Wilde, Oscar", CompletionTime: 128 * time.Second, SolvedAt: &solvedAt, Solved: true},
+		{GameID: "game-0115", Date: "2026-01-15", CompletionTime: 75 * time.Second, Solved: true, Uploaded: true},
+		{GameID: "game-0117", Date: "2026-01-17"},
+	} {
+		if err := storage.SaveSession(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := storage.Practice.SaveSession(&storage.GameSession{GameID: "practice-1", Date: "2026-01-14", Solved: true}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExportCmd_ICal(t *testing.T) {
+	saveHistory(t)
+
+	output, err := executeCommand(NewRootCmd(), "export")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(output, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(output, "END:VCALENDAR\r\n") {
+		t.Errorf("output is not a calendar:\n%s", output)
+	}
+	if n := strings.Count(output, "BEGIN:VEVENT"); n != 2 {
+		t.Errorf("calendar has %d events, want one per solved daily puzzle:\n%s", n, output)
+	}
+	for _, want := range []string{
+		"UID:game-0115@playunquote.com\r\nDTSTAMP:",
+		"DTSTART;VALUE=DATE:20260116\r\nDTEND;VALUE=DATE:20260117\r\n",
+		"SUMMARY:Unquote solved in 2:08\r\n",
+		`DESCRIPTION:Solved in 2:08\nQuote by Wilde\, Oscar`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("calendar missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Index(output, "20260115") > strings.Index(output, "20260116") {
+		t.Error("events should be oldest first")
+	}
+}
+
+func TestExportCmd_JSONToFile(t *testing.T) {
+	saveHistory(t)
+
+	path := filepath.Join(t.TempDir(), "history.json")
+	output, err := executeCommand(NewRootCmd(), "export", "--format", "json", "-f", path)
+	if err != nil || !strings.Contains(output, "Wrote 2 solves to "+path) {
+		t.Fatalf("export = %q, %v", output, err)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // the test wrote it
+	if err != nil {
+		t.Fatal(err)
+	}
+	var history []historyEntry
+	if err := json.Unmarshal(data, &history); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, data)
+	}
+	if len(history) != 2 || history[0].GameID != "game-0115" || history[1].CompletionTimeMs != 128000 {
+		t.Errorf("history = %+v, want both solves oldest first", history)
+	}
+}
+
+// --output json picks the JSON format; it is never taken for a file name.
+func TestExportCmd_OutputJSON(t *testing.T) {
+	saveHistory(t)
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	output, err := executeCommand(NewRootCmd(), "export", "--output", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var history []historyEntry
+	if err := json.Unmarshal([]byte(output), &history); err != nil || len(history) != 2 {
+		t.Errorf("export --output json = %q, %v; want both solves as JSON", output, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "json")); !os.IsNotExist(err) {
+		t.Errorf("export --output json wrote a file called json: %v", err)
+	}
+
+	if _, err := executeCommand(NewRootCmd(), "export", "--output", "json", "--format", "ical"); err == nil {
+		t.Error("--format ical with --output json should fail")
+	}
+}
+
+func TestExportCmd_UnknownFormat(t *testing.T) {
+	saveHistory(t)
+
+	if _, err := executeCommand(NewRootCmd(), "export", "--format", "csv"); err == nil {
+		t.Error("an unknown format should fail")
+	}
+}
+
+func TestFoldICalLine(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("é", 60)
+	folded := foldICalLine(line)
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > icalLineLimit {
+			t.Errorf("line of %d octets, want at most %d", len(part), icalLineLimit)
+		}
+		if !strings.HasPrefix(part, "DESCRIPTION:") && !strings.HasPrefix(part, " ") {
+			t.Errorf("continuation line %q should start with a space", part)
+		}
+	}
+	if got := strings.ReplaceAll(folded, "\r\n ", ""); got != line {
+		t.Errorf("unfolding gives %q, want %q", got, line)
+	}
+}
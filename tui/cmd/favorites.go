@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// favoriteOutput is one bookmarked quote in the JSON output and the JSON
+// export. It is kept separate from storage.Favorite so the CLI's output
+// stays stable if the file format changes.
+type favoriteOutput struct {
+	AddedAt  time.Time `json:"addedAt"`
+	GameID   string    `json:"gameId"`
+	Date     string    `json:"date,omitempty"`
+	Text     string    `json:"text"`
+	Author   string    `json:"author,omitempty"`
+	Category string    `json:"category,omitempty"`
+}
+
+func newFavoritesOutput(favorites []storage.Favorite) []favoriteOutput {
+	out := make([]favoriteOutput, 0, len(favorites))
+	for _, f := range favorites {
+		out = append(out, favoriteOutput{
+			AddedAt:  f.AddedAt,
+			GameID:   f.GameID,
+			Date:     f.Date,
+			Text:     f.Text,
+			Author:   f.Author,
+			Category: f.Category,
+		})
+	}
+	return out
+}
+
+// favoriteByline is the attribution printed under a favorite quote: its
+// author and puzzle date, whichever it has.
+func favoriteByline(f storage.Favorite) string {
+	var parts []string
+	if f.Author != "" {
+		parts = append(parts, f.Author)
+	}
+	if f.Date != "" {
+		parts = append(parts, f.Date)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// newFavoritesCmd returns a command that lists the quotes bookmarked with *
+// on the solved screen, with a subcommand to export them.
+func newFavoritesCmd(output *outputFormat) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "favorites",
+		Short: "List the quotes you bookmarked",
+		Long: "List the quotes you bookmarked, oldest first, with their author and puzzle date.\n\n" +
+			"Press * on the solved screen to bookmark a quote, and again to remove it.",
+		Example: "  # List your favorite quotes\n" +
+			"  unquote favorites\n\n" +
+			"  # Save them as a Markdown file\n" +
+			"  unquote favorites export -f favorites.md",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			favorites, err := storage.LoadFavorites()
+			if *output == outputJSON {
+				if err != nil {
+					return writeJSONError(cmd.OutOrStdout(), "favorites", err)
+				}
+				return writeJSON(cmd.OutOrStdout(), "favorites", newFavoritesOutput(favorites))
+			}
+			if err != nil {
+				return fmt.Errorf("loading favorites: %w", err)
+			}
+
+			out := cmd.OutOrStdout()
+			if len(favorites) == 0 {
+				fmt.Fprintln(out, "No favorites yet. Press * on the solved screen to bookmark a quote.")
+				return nil
+			}
+			for i, f := range favorites {
+				if i > 0 {
+					fmt.Fprintln(out)
+				}
+				fmt.Fprintf(out, "“%s”\n", f.Text)
+				if byline := favoriteByline(f); byline != "" {
+					fmt.Fprintf(out, "  — %s\n", byline)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newFavoritesExportCmd(output))
+
+	return cmd
+}
+
+// newFavoritesExportCmd returns a command that writes the favorites as
+// Markdown, CSV or JSON. --output json is the same as --format json.
+func newFavoritesExportCmd(output *outputFormat) *cobra.Command {
+	var file, format string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export your favorite quotes",
+		Long: "Export your favorite quotes as Markdown (the default), CSV or JSON, to stdout\n" +
+			"or a file.",
+		Example: "  # A Markdown page of your favorites\n" +
+			"  unquote favorites export -f favorites.md\n\n" +
+			"  # A spreadsheet\n" +
+			"  unquote favorites export --format csv -f favorites.csv",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *output == outputJSON {
+				if cmd.Flags().Changed("format") && format != "json" {
+					return fmt.Errorf("--format %s can't be combined with --output json", format)
+				}
+				format = "json"
+			}
+			favorites, err := storage.LoadFavorites()
+			if err != nil {
+				return fmt.Errorf("loading favorites: %w", err)
+			}
+
+			var buf bytes.Buffer
+			switch format {
+			case "markdown", "md":
+				writeFavoritesMarkdown(&buf, favorites)
+			case "csv":
+				if err := writeFavoritesCSV(&buf, favorites); err != nil {
+					return err
+				}
+			case "json":
+				data, err := json.MarshalIndent(newFavoritesOutput(favorites), "", "  ")
+				if err != nil {
+					return fmt.Errorf("marshaling favorites: %w", err)
+				}
+				buf.Write(append(data, '\n'))
+			default:
+				return fmt.Errorf("unknown format %q: expected markdown, csv or json", format)
+			}
+
+			if file == "" || file == "-" {
+				_, err = cmd.OutOrStdout().Write(buf.Bytes())
+				return err
+			}
+			if err := os.WriteFile(file, buf.Bytes(), 0o644); err != nil { //nolint:gosec // an export is meant to be read and shared
+				return fmt.Errorf("writing favorites file: %w", err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %d favorites to %s\n", len(favorites), file)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file to write (default: stdout)")
+	cmd.Flags().StringVar(&format, "format", "markdown", "export format: markdown, csv or json (--output json also picks json)")
+
+	return cmd
+}
+
+// writeFavoritesMarkdown writes each favorite as a block quote with its
+// byline.
+func writeFavoritesMarkdown(w io.Writer, favorites []storage.Favorite) {
+	fmt.Fprintln(w, "# Favorite quotes")
+	for _, f := range favorites {
+		fmt.Fprintf(w, "\n> %s\n", f.Text)
+		if byline := favoriteByline(f); byline != "" {
+			fmt.Fprintf(w, ">\n> — %s\n", byline)
+		}
+	}
+}
+
+// writeFavoritesCSV writes one row per favorite under a header row.
+func writeFavoritesCSV(w io.Writer, favorites []storage.Favorite) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"date", "author", "category", "text", "game_id", "added_at"})
+	for _, f := range favorites {
+		_ = cw.Write([]string{f.Date, f.Author, f.Category, f.Text, f.GameID, f.AddedAt.Format(time.RFC3339)})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// setFavorites points the XDG state directory at a temp dir and bookmarks
+// favorites there.
+func setFavorites(t *testing.T, favorites ...storage.Favorite) {
+	t.Helper()
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+	for _, f := range favorites {
+		if _, err := storage.ToggleFavorite(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+var testFavorites = []storage.Favorite{
+	{
+		AddedAt: time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC), GameID: "game-0115", Date: "2026-01-15",
+		Text: "THE SECRET OF GETTING AHEAD IS GETTING STARTED.", Author: "Mark Twain", Category: "Wisdom",
+	},
+	{
+		AddedAt: time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC), GameID: "local-abc",
+		Text: "SAY \"CHEESE\", PLEASE.", Author: "Grandma",
+	},
+}
+
+func TestFavoritesCmd_List(t *testing.T) {
+	setFavorites(t)
+	output, err := executeCommand(NewRootCmd(), "favorites")
+	if err != nil || !strings.Contains(output, "No favorites yet") {
+		t.Fatalf("favorites with none = %q, %v", output, err)
+	}
+
+	setFavorites(t, testFavorites...)
+	output, err = executeCommand(NewRootCmd(), "favorites")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "“THE SECRET OF GETTING AHEAD IS GETTING STARTED.”\n  — Mark Twain, 2026-01-15\n\n" +
+		"“SAY \"CHEESE\", PLEASE.”\n  — Grandma\n"
+	if output != want {
+		t.Errorf("favorites =\n%s\nwant\n%s", output, want)
+	}
+}
+
+func TestFavoritesCmd_JSON(t *testing.T) {
+	setFavorites(t, testFavorites...)
+	output, err := executeCommand(NewRootCmd(), "favorites", "--output", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env struct {
+		Data    []favoriteOutput `json:"data"`
+		Command string           `json:"command"`
+		OK      bool             `json:"ok"`
+	}
+	if err := json.Unmarshal([]byte(output), &env); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, output)
+	}
+	if !env.OK || env.Command != "favorites" || len(env.Data) != 2 || env.Data[0].Author != "Mark Twain" {
+		t.Errorf("envelope = %+v", env)
+	}
+}
+
+func TestFavoritesExportCmd(t *testing.T) {
+	setFavorites(t, testFavorites...)
+
+	output, err := executeCommand(NewRootCmd(), "favorites", "export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# Favorite quotes", "> THE SECRET OF GETTING AHEAD IS GETTING STARTED.\n>\n> — Mark Twain, 2026-01-15"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("markdown export is missing %q:\n%s", want, output)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "favorites.csv")
+	output, err = executeCommand(NewRootCmd(), "favorites", "export", "--format", "csv", "-f", path)
+	if err != nil || !strings.Contains(output, "Wrote 2 favorites to "+path) {
+		t.Fatalf("csv export = %q, %v", output, err)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // the test wrote it
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil || len(rows) != 3 {
+		t.Fatalf("csv = %q, %v; want a header and two rows", data, err)
+	}
+	if rows[2][1] != "Grandma" || rows[2][3] != `SAY "CHEESE", PLEASE.` {
+		t.Errorf("csv row = %q, want the quote intact", rows[2])
+	}
+
+	output, err = executeCommand(NewRootCmd(), "favorites", "export", "--format", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exported []favoriteOutput
+	if err := json.Unmarshal([]byte(output), &exported); err != nil || len(exported) != 2 || exported[1].GameID != "local-abc" {
+		t.Errorf("json export = %s, %v", output, err)
+	}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	output, err = executeCommand(NewRootCmd(), "favorites", "export", "--output", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(output), &exported); err != nil || len(exported) != 2 {
+		t.Errorf("export --output json = %s, %v; want the favorites as JSON", output, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "json")); !os.IsNotExist(err) {
+		t.Errorf("export --output json wrote a file called json: %v", err)
+	}
+
+	if _, err := executeCommand(NewRootCmd(), "favorites", "export", "--format", "pdf"); err == nil {
+		t.Error("an unknown format should fail")
+	}
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+// claimCodePattern matches the server's ADJECTIVE-NOUN-NNNN claim codes.
+var claimCodePattern = regexp.MustCompile(`^[A-Z]+-[A-Z]+-[0-9]{4}$`)
+
+// parseClaimCode normalizes a claim code typed by the player.
+func parseClaimCode(arg string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(arg))
+	if !claimCodePattern.MatchString(code) {
+		return "", fmt.Errorf("invalid claim code %q: expected a code like TIGER-MAPLE-7492", arg)
+	}
+	return code, nil
+}
+
+// loadOrNewConfig loads the config, starting an empty one if none exists yet.
+func loadOrNewConfig() (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	return cfg, nil
+}
+
+// newFriendsCmd returns the parent command for managing the friends compared
+// against on the stats screen.
+func newFriendsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "friends",
+		Short: "Manage the friends you compare stats with",
+		Long: "Manage the friends you compare stats with.\n\n" +
+			"Friends are listed by claim code. The stats screen's Friends tab compares\n" +
+			"your streak and average time against theirs.",
+		Example: "  unquote friends add FOX-RIVER-0412\n" +
+			"  unquote friends list\n" +
+			"  unquote friends remove FOX-RIVER-0412",
+	}
+
+	cmd.AddCommand(newFriendsAddCmd())
+	cmd.AddCommand(newFriendsRemoveCmd())
+	cmd.AddCommand(newFriendsListCmd())
+
+	return cmd
+}
+
+// newFriendsAddCmd returns a command that adds a friend's claim code to the config.
+// Like 'link', the code is not checked against the server; a code that doesn't
+// exist shows up as unavailable on the stats screen.
+func newFriendsAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <claim-code>",
+		Short: "Add a friend by their claim code",
+		Long: "Add a friend by their claim code.\n\n" +
+			"Ask your friend to run 'unquote claim-code'. The code is not checked until\n" +
+			"the stats screen loads their stats.",
+		Example: "  unquote friends add FOX-RIVER-0412",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			code, err := parseClaimCode(args[0])
+			if err != nil {
+				return err
+			}
+
+			cfg, err := loadOrNewConfig()
+			if err != nil {
+				return err
+			}
+			if code == cfg.ClaimCode {
+				return errors.New("that's your own claim code")
+			}
+			if !cfg.AddFriend(code) {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s is already a friend\n", code)
+				return nil
+			}
+			if err := config.Save(cfg); err != nil {
+				return fmt.Errorf("saving config: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Added friend: %s\n", code)
+			return nil
+		},
+	}
+}
+
+// newFriendsRemoveCmd returns a command that removes a friend's claim code.
+func newFriendsRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <claim-code>",
+		Short:   "Remove a friend",
+		Long:    "Remove a friend, so the stats screen no longer compares against them.",
+		Example: "  unquote friends remove FOX-RIVER-0412",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			code := strings.ToUpper(strings.TrimSpace(args[0]))
+
+			cfg, err := loadOrNewConfig()
+			if err != nil {
+				return err
+			}
+			if !cfg.RemoveFriend(code) {
+				return fmt.Errorf("%s is not a friend", code)
+			}
+			if err := config.Save(cfg); err != nil {
+				return fmt.Errorf("saving config: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed friend: %s\n", code)
+			return nil
+		},
+	}
+}
+
+// newFriendsListCmd returns a command that prints the friends' claim codes.
+func newFriendsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List your friends",
+		Long:    "List your friends' claim codes, in the order they were added.",
+		Example: "  unquote friends list",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadOrNewConfig()
+			if err != nil {
+				return err
+			}
+			if len(cfg.Friends) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No friends yet. Add one with 'unquote friends add <claim-code>'.")
+				return nil
+			}
+			for _, code := range cfg.Friends {
+				fmt.Fprintln(cmd.OutOrStdout(), code)
+			}
+			return nil
+		},
+	}
+}
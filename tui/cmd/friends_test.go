@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+func setConfigHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+}
+
+func TestFriendsCmd_AddListRemove(t *testing.T) {
+	setConfigHome(t)
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492", StatsEnabled: true}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "friends", "add", "fox-river-0412")
+	if err != nil || !strings.Contains(output, "Added friend: FOX-RIVER-0412") {
+		t.Fatalf("add = %q, %v", output, err)
+	}
+	output, err = executeCommand(NewRootCmd(), "friends", "add", "FOX-RIVER-0412")
+	if err != nil || !strings.Contains(output, "already a friend") {
+		t.Errorf("repeat add = %q, %v", output, err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil || cfg == nil {
+		t.Fatalf("Load() = %v, %v", cfg, err)
+	}
+	if cfg.ClaimCode != "TIGER-MAPLE-7492" || len(cfg.Friends) != 1 {
+		t.Errorf("config = %+v, want the claim code kept and one friend", cfg)
+	}
+
+	output, err = executeCommand(NewRootCmd(), "friends", "list")
+	if err != nil || strings.TrimSpace(output) != "FOX-RIVER-0412" {
+		t.Errorf("list = %q, %v", output, err)
+	}
+
+	if _, err := executeCommand(NewRootCmd(), "friends", "remove", "FOX-RIVER-0412"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := executeCommand(NewRootCmd(), "friends", "remove", "FOX-RIVER-0412"); err == nil {
+		t.Error("removing someone who isn't a friend should fail")
+	}
+	output, _ = executeCommand(NewRootCmd(), "friends", "list")
+	if !strings.Contains(output, "No friends yet") {
+		t.Errorf("list after removal = %q", output)
+	}
+}
+
+func TestFriendsCmd_AddRejects(t *testing.T) {
+	setConfigHome(t)
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492"}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+
+	tests := []struct {
+		code string
+		want string
+	}{
+		{code: "not a code", want: "invalid claim code"},
+		{code: "FOX/../0412", want: "invalid claim code"},
+		{code: "tiger-maple-7492", want: "your own claim code"},
+	}
+	for _, tt := range tests {
+		_, err := executeCommand(NewRootCmd(), "friends", "add", tt.code)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("add %q error = %v, want it to mention %q", tt.code, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/goal"
+)
+
+// newGoalCmd returns a command that sets the weekly solve goal.
+func newGoalCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "goal <days>",
+		Short: "Set how many days a week you aim to solve",
+		Long: "Set how many days a week you aim to solve, from 1 to 7; 0 clears the goal.\n\n" +
+			"Weeks run Monday to Sunday in your time zone (see 'unquote timezone').\n" +
+			"Progress is counted from the daily puzzles solved on this device and shown by\n" +
+			"'unquote status' and on the stats screen.",
+		Example: "  # Solve at least five days a week\n" +
+			"  unquote goal 5\n\n" +
+			"  # Stop tracking a goal\n" +
+			"  unquote goal 0",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			days, err := strconv.Atoi(args[0])
+			if err != nil || days < 0 || days > goal.DaysPerWeek {
+				return fmt.Errorf("goal must be a number of days from 0 to %d", goal.DaysPerWeek)
+			}
+
+			cfg, err := loadOrNewConfig()
+			if err != nil {
+				return err
+			}
+			cfg.WeeklyGoal = days
+			if err := config.Save(cfg); err != nil {
+				return fmt.Errorf("saving config: %w", err)
+			}
+
+			if days == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "Weekly goal cleared")
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Weekly goal set: %d of %d days\n", days, goal.DaysPerWeek)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+func TestGoalCmd_SetAndClear(t *testing.T) {
+	setConfigHome(t)
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492", StatsEnabled: true}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "goal", "5")
+	if err != nil || !strings.Contains(output, "5 of 7 days") {
+		t.Fatalf("goal 5 = %q, %v", output, err)
+	}
+	cfg, _ := config.Load()
+	if cfg == nil || cfg.WeeklyGoal != 5 || cfg.ClaimCode != "TIGER-MAPLE-7492" {
+		t.Errorf("config = %+v, want the goal saved alongside the claim code", cfg)
+	}
+
+	if output, err := executeCommand(NewRootCmd(), "goal", "0"); err != nil || !strings.Contains(output, "cleared") {
+		t.Errorf("goal 0 = %q, %v", output, err)
+	}
+	if cfg, _ := config.Load(); cfg == nil || cfg.WeeklyGoal != 0 {
+		t.Errorf("config = %+v, want the goal cleared", cfg)
+	}
+}
+
+func TestGoalCmd_RejectsOutOfRange(t *testing.T) {
+	setConfigHome(t)
+
+	for _, arg := range []string{"8", "-1", "five"} {
+		if _, err := executeCommand(NewRootCmd(), "goal", arg); err == nil {
+			t.Errorf("goal %s should fail", arg)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// newHistoryCmd returns a command that lists the daily puzzles solved on this
+// device.
+func newHistoryCmd(output *outputFormat) *cobra.Command {
+	var detail bool
+	solves := solvesAll
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the daily puzzles you solved",
+		Long: "List the daily puzzles solved on this device, oldest first, with the solve time\n" +
+			"and the quote's author. --detail adds each puzzle's category and difficulty, the\n" +
+			"letter you were stuck on longest and the one that took longest to settle, and\n" +
+			"the note you left on the solve. Solves you had help with, such as suggested\n" +
+			"words, are tagged assisted; --solves clean or --solves assisted lists only those.\n\n" +
+			"Press N on the solved screen to note a solve. Works offline.",
+		Example: "  # Your solves at a glance\n" +
+			"  unquote history\n\n" +
+			"  # With categories, difficulty, where you got stuck and notes\n" +
+			"  unquote history --detail\n\n" +
+			"  # Only the solves you had no help with\n" +
+			"  unquote history --solves clean",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			history, err := solveHistory(playerLocation())
+			history = slices.DeleteFunc(history, func(h historyEntry) bool {
+				return !solves.keeps(h.AssistLevel != storage.AssistNone)
+			})
+			if *output == outputJSON {
+				if err != nil {
+					return writeJSONError(cmd.OutOrStdout(), "history", err)
+				}
+				return writeJSON(cmd.OutOrStdout(), "history", history)
+			}
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if len(history) == 0 {
+				fmt.Fprintln(out, "No solves yet.")
+				return nil
+			}
+			for _, h := range history {
+				writeHistoryEntry(out, h, detail)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&detail, "detail", false, "also show category, difficulty, where you got stuck and notes")
+	cmd.Flags().Var(&solves, "solves", solveFilterUsage)
+
+	return cmd
+}
+
+// writeHistoryEntry writes one solve as "2026-01-16  2:08  Oscar Wilde",
+// tagged "(assisted)" when the player had help, and, with detail, indented
+// lines for what else is known about it.
+func writeHistoryEntry(w io.Writer, h historyEntry, detail bool) {
+	line := strings.TrimRight(fmt.Sprintf("%s  %5s  %s", h.Date, formatMs(float64(h.CompletionTimeMs)), h.Author), " ")
+	if h.AssistLevel != storage.AssistNone {
+		line += "  (assisted)"
+	}
+	fmt.Fprintln(w, line)
+	if !detail {
+		return
+	}
+	if h.Category != "" {
+		fmt.Fprintf(w, "  Category: %s\n", h.Category)
+	}
+	if h.Difficulty > 0 {
+		fmt.Fprintf(w, "  Difficulty: %s (%d)\n", puzzle.DifficultyText(h.Difficulty), h.Difficulty)
+	}
+	if h.LongestPause != nil {
+		fmt.Fprintf(w, "  Stuck: %s before placing %s\n", formatMs(float64(h.LongestPause.Ms)), h.LongestPause.Letter)
+	}
+	if h.LongestRevision != nil {
+		fmt.Fprintf(w, "  Settled last: %s, %s between first and last guess\n", h.LongestRevision.Letter, formatMs(float64(h.LongestRevision.Ms)))
+	}
+	if h.Note != "" {
+		fmt.Fprintf(w, "  Note: %s\n", h.Note)
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+func TestHistoryCmd(t *testing.T) {
+	saveHistory(t)
+
+	output, err := executeCommand(NewRootCmd(), "history")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "2026-01-15   1:15\n2026-01-16   2:08  Wilde, Oscar\n"
+	if output != want {
+		t.Errorf("history =\n%s\nwant\n%s", output, want)
+	}
+}
+
+func TestHistoryCmd_DetailShowsNotes(t *testing.T) {
+	saveHistory(t)
+	if err := storage.Daily.SetNote("game-0116", "solved on the train"); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "history", "--detail")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "2026-01-16   2:08  Wilde, Oscar\n  Note: solved on the train\n") {
+		t.Errorf("history --detail should show the note under its solve:\n%s", output)
+	}
+
+	output, err = executeCommand(NewRootCmd(), "history", "--output", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var env struct {
+		Data []historyEntry `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(output), &env); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	if len(env.Data) != 2 || env.Data[1].Note != "solved on the train" {
+		t.Errorf("history = %+v, want both solves with the note", env.Data)
+	}
+}
+
+func TestHistoryCmd_DetailShowsLetterTimes(t *testing.T) {
+	saveHistory(t)
+	solvedAt := time.Date(2026, 1, 18, 9, 0, 0, 0, time.UTC)
+	timed := &storage.GameSession{
+		GameID: "game-0118", Date: "2026-01-18", CompletionTime: 2 * time.Minute, SolvedAt: &solvedAt, Solved: true,
+		LetterTimes: storage.LetterTimes{
+			"A": {First: 5 * time.Second, Last: 5 * time.Second},
+			"Q": {First: 67 * time.Second, Last: 68 * time.Second},
+			"B": {First: 10 * time.Second, Last: 105 * time.Second},
+		},
+	}
+	if err := storage.SaveSession(timed); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "history", "--detail")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "2026-01-18   2:00\n  Stuck: 0:57 before placing Q\n  Settled last: B, 1:35 between first and last guess\n"
+	if !strings.HasSuffix(output, want) {
+		t.Errorf("history --detail should show where the player got stuck:\n%s", output)
+	}
+	if strings.Contains(output, "2026-01-16   2:08  Wilde, Oscar\n  Stuck") {
+		t.Errorf("a solve saved without timings should have no breakdown:\n%s", output)
+	}
+
+	output, err = executeCommand(NewRootCmd(), "history", "--output", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var env struct {
+		Data []historyEntry `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(output), &env); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	last := env.Data[len(env.Data)-1]
+	if last.LongestPause == nil || *last.LongestPause != (letterSpan{Letter: "Q", Ms: 57000}) {
+		t.Errorf("longestPause = %+v, want Q after 57s", last.LongestPause)
+	}
+	if last.LongestRevision == nil || *last.LongestRevision != (letterSpan{Letter: "B", Ms: 95000}) {
+		t.Errorf("longestRevision = %+v, want B over 95s", last.LongestRevision)
+	}
+}
+
+func TestHistoryCmd_Solves(t *testing.T) {
+	saveHistory(t)
+	solvedAt := time.Date(2026, 1, 18, 9, 0, 0, 0, time.UTC)
+	assisted := &storage.GameSession{
+		GameID: "game-0118", Date: "2026-01-18", CompletionTime: 90 * time.Second, SolvedAt: &solvedAt, Solved: true,
+		AssistLevel: storage.AssistLight, Assists: storage.Assists{SuggestionsUsed: true},
+	}
+	if err := storage.SaveSession(assisted); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		solves string
+		want   string
+	}{
+		{"all", "2026-01-15   1:15\n2026-01-16   2:08  Wilde, Oscar\n2026-01-18   1:30  (assisted)\n"},
+		{"clean", "2026-01-15   1:15\n2026-01-16   2:08  Wilde, Oscar\n"},
+		{"assisted", "2026-01-18   1:30  (assisted)\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.solves, func(t *testing.T) {
+			output, err := executeCommand(NewRootCmd(), "history", "--solves", tt.solves)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if output != tt.want {
+				t.Errorf("history --solves %s =\n%s\nwant\n%s", tt.solves, output, tt.want)
+			}
+		})
+	}
+
+	if _, err := executeCommand(NewRootCmd(), "history", "--solves", "some"); err == nil {
+		t.Error("history --solves some should be rejected")
+	}
+}
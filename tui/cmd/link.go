@@ -15,7 +15,11 @@ func newLinkCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "link <claim-code>",
 		Short: "Link an existing claim code to this device",
-		Args:  cobra.ExactArgs(1),
+		Long: "Link an existing claim code to this device.\n\n" +
+			"Solves on this device then count toward the same stats as the device that\n" +
+			"registered the code. The code is not checked until the next API call.",
+		Example: "  unquote link TIGER-MAPLE-7492",
+		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			claimCode := args[0]
 
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/app"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// traceMsgsUsage describes the --trace-msgs flag.
+const traceMsgsUsage = "log every message, state change, command and API request to $" + envDebugLog + ", for debugging"
+
+// msgTraceTime formats log timestamps to the millisecond, so messages that
+// race each other keep their order.
+const msgTraceTime = "2006-01-02T15:04:05.000Z07:00"
+
+// traced is a model whose messages can be traced; app.Model is one.
+type traced interface {
+	recoverable
+	State() app.State
+}
+
+// msgTracer wraps the app's model for --trace-msgs and logs every message it
+// receives, the state before and after, and the command it returns. Commands
+// are wrapped so each logs what it returned when it finishes; those running
+// in a batch or sequence are wrapped in turn, so every command in the log is
+// named.
+type msgTracer struct {
+	model traced
+	mu    sync.Mutex // commands log from their own goroutines
+	log   io.Writer
+	now   func() time.Time
+}
+
+// newMsgTracer returns a tracer around model that logs to log.
+func newMsgTracer(model traced, log io.Writer) *msgTracer {
+	return &msgTracer{model: model, log: log, now: time.Now}
+}
+
+func (t *msgTracer) Init() tea.Cmd {
+	cmd := t.model.Init()
+	t.logf("init state=%s cmd=%s", t.model.State(), cmdName(cmd))
+	return t.wrap(cmd)
+}
+
+func (t *msgTracer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	before := t.model.State()
+	model, cmd := t.model.Update(msg)
+	t.model = model.(traced)
+
+	state := before.String()
+	if after := t.model.State(); after != before {
+		state += "->" + after.String()
+	}
+	t.logf("msg %s state=%s cmd=%s", describeMsg(msg), state, cmdName(cmd))
+	return t, t.wrap(cmd)
+}
+
+func (t *msgTracer) View() tea.View {
+	return t.model.View()
+}
+
+// PendingSession hands over the traced model's game, so the crash guard can
+// wrap the tracer.
+func (t *msgTracer) PendingSession() (storage.Namespace, *storage.GameSession) {
+	return t.model.PendingSession()
+}
+
+// wrap returns cmd logging what it returned under its name. Batches and
+// sequences come back as a slice of commands, which are wrapped in place
+// before Bubble Tea runs them.
+func (t *msgTracer) wrap(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	name := cmdName(cmd)
+	return func() tea.Msg {
+		msg := cmd()
+		if v := reflect.ValueOf(msg); v.Kind() == reflect.Slice && v.Type().Elem() == reflect.TypeFor[tea.Cmd]() {
+			names := make([]string, v.Len())
+			for i := range v.Len() {
+				sub := v.Index(i).Interface().(tea.Cmd)
+				names[i] = cmdName(sub)
+				v.Index(i).Set(reflect.ValueOf(t.wrap(sub)))
+			}
+			t.logf("cmd %s runs %s", name, strings.Join(names, ", "))
+			return msg
+		}
+		t.logf("cmd %s returned %s", name, describeMsg(msg))
+		return msg
+	}
+}
+
+// logf writes one timestamped line to the log. Write errors are dropped;
+// tracing never stops the game.
+func (t *msgTracer) logf(format string, args ...any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.log, "%s %s\n", t.now().Format(msgTraceTime), fmt.Sprintf(format, args...))
+}
+
+// describeMsg names msg by its type, with the key for key presses.
+func describeMsg(msg tea.Msg) string {
+	switch msg := msg.(type) {
+	case nil:
+		return "nil"
+	case tea.KeyPressMsg:
+		return fmt.Sprintf("%T %q", msg, msg.String())
+	default:
+		return fmt.Sprintf("%T", msg)
+	}
+}
+
+// cmdName names cmd by the function it is, package-qualified and without the
+// closure suffixes, e.g. app.Model.fetchCmd for a closure fetchCmd returns.
+func cmdName(cmd tea.Cmd) string {
+	if cmd == nil {
+		return "none"
+	}
+	f := runtime.FuncForPC(reflect.ValueOf(cmd).Pointer())
+	if f == nil {
+		return "unknown"
+	}
+	name := f.Name()
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i] // type parameters of a generic function
+	}
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		pkg, rest, _ := strings.Cut(name[i+1:], ".")
+		if isMajorVersion(pkg) {
+			pkg = name[strings.LastIndexByte(name[:i], '/')+1 : i] // bubbletea/v2 is bubbletea
+		}
+		name = pkg + "." + rest
+	}
+	name = strings.TrimSuffix(name, "-fm")
+	for {
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 || !strings.HasPrefix(name[i+1:], "func") {
+			return name
+		}
+		name = name[:i]
+	}
+}
+
+// isMajorVersion reports whether a path element is a module's major version
+// suffix, like v2.
+func isMajorVersion(elem string) bool {
+	if len(elem) < 2 || elem[0] != 'v' {
+		return false
+	}
+	for _, r := range elem[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// openMsgTrace opens the debug log for --trace-msgs, appending to it.
+func openMsgTrace() (*os.File, error) {
+	path := os.Getenv(envDebugLog)
+	if path == "" {
+		return nil, errors.New("--trace-msgs writes to the debug log; set " + envDebugLog + " to the file to write")
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening debug log: %w", err)
+	}
+	return f, nil
+}
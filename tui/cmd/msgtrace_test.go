@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/app"
+)
+
+type pingMsg struct{}
+
+func pingCmd() tea.Msg { return pingMsg{} }
+
+func pongCmd() tea.Msg { return nil }
+
+// newTestTracer traces a bare app model into a buffer, with every line
+// stamped at the same time.
+func newTestTracer() (*msgTracer, *bytes.Buffer) {
+	var log bytes.Buffer
+	tr := newMsgTracer(app.NewWithClient(nil), &log)
+	tr.now = func() time.Time { return time.Date(2026, 1, 15, 9, 30, 0, 0, time.UTC) }
+	return tr, &log
+}
+
+func TestCmdName(t *testing.T) {
+	tests := []struct {
+		cmd  tea.Cmd
+		want string
+	}{
+		{nil, "none"},
+		{pingCmd, "cmd.pingCmd"},
+		{tea.Quit, "bubbletea.Quit"},
+		{tea.Batch(pingCmd, pongCmd), "bubbletea.compactCmds"},
+		{func() tea.Msg { return nil }, "cmd.TestCmdName"},
+	}
+	for _, tt := range tests {
+		if got := cmdName(tt.cmd); got != tt.want {
+			t.Errorf("cmdName() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestMsgTracer_Update(t *testing.T) {
+	tr, log := newTestTracer()
+
+	model, cmd := tr.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if model != tr || cmd != nil {
+		t.Errorf("Update() = %v, %v; want the tracer and no command", model, cmd)
+	}
+	tr.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+
+	want := "2026-01-15T09:30:00.000Z msg tea.WindowSizeMsg state=Loading cmd=none\n" +
+		"2026-01-15T09:30:00.000Z msg tea.KeyPressMsg \"esc\" state=Loading cmd=bubbletea.Quit\n"
+	if log.String() != want {
+		t.Errorf("log =\n%s\nwant\n%s", log, want)
+	}
+}
+
+func TestMsgTracer_WrapsBatches(t *testing.T) {
+	tr, log := newTestTracer()
+
+	batch, ok := tr.wrap(tea.Batch(pingCmd, pongCmd))().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("wrapped batch returned %v, want a BatchMsg of two commands", batch)
+	}
+	if _, ok := batch[0]().(pingMsg); !ok {
+		t.Error("wrapped command should return the message it always did")
+	}
+	batch[1]()
+
+	for _, want := range []string{
+		"cmd bubbletea.compactCmds runs cmd.pingCmd, cmd.pongCmd\n",
+		"cmd cmd.pingCmd returned cmd.pingMsg\n",
+		"cmd cmd.pongCmd returned nil\n",
+	} {
+		if !strings.Contains(log.String(), want) {
+			t.Errorf("log missing %q:\n%s", want, log)
+		}
+	}
+}
+
+func TestRunTUI_TraceMsgsNeedsLog(t *testing.T) {
+	setStatusHomes(t)
+	t.Setenv(envDebugLog, "")
+
+	_, err := executeCommand(NewRootCmd(), "--trace-msgs", "--force-tty")
+	if err == nil || !strings.Contains(err.Error(), envDebugLog) {
+		t.Errorf("--trace-msgs without a debug log error = %v, want it to name %s", err, envDebugLog)
+	}
+	_, err = executeCommand(NewRootCmd(), "--trace-msgs", "--ephemeral")
+	if err == nil || !strings.Contains(err.Error(), "--ephemeral") {
+		t.Errorf("--trace-msgs --ephemeral error = %v, want it refused", err)
+	}
+}
+
+func TestOpenMsgTrace_Appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.log")
+	if err := os.WriteFile(path, []byte("earlier\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(envDebugLog, path)
+
+	f, err := openMsgTrace()
+	if err != nil {
+		t.Fatalf("openMsgTrace() error = %v", err)
+	}
+	newMsgTracer(app.NewWithClient(nil), f).logf("msg %s", "x")
+	_ = f.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "earlier\n") || !strings.HasSuffix(string(data), " msg x\n") {
+		t.Errorf("debug log = %q, want the trace appended", data)
+	}
+}
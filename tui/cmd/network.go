@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+// envDebugLog names a file the TUI appends its network metrics to on exit.
+const envDebugLog = "UNQUOTE_DEBUG_LOG"
+
+// networkProbeRounds is how many times stats --network requests each endpoint.
+const networkProbeRounds = 5
+
+// networkOutput is the JSON form of the network metrics.
+type networkOutput struct {
+	URL       string           `json:"url"`
+	Error     string           `json:"error,omitempty"` // the first request that failed, if any did
+	Endpoints []endpointOutput `json:"endpoints"`
+}
+
+// endpointOutput is one endpoint in networkOutput. Latencies are in
+// milliseconds.
+type endpointOutput struct {
+	Endpoint string  `json:"endpoint"`
+	P50Ms    float64 `json:"p50Ms"`
+	P90Ms    float64 `json:"p90Ms"`
+	P99Ms    float64 `json:"p99Ms"`
+	MaxMs    float64 `json:"maxMs"`
+	Requests int     `json:"requests"`
+	Failures int     `json:"failures"`
+}
+
+// newNetworkOutput converts metrics for the API at url, plus the first error
+// a probe hit, to their JSON form.
+func newNetworkOutput(url string, metrics []api.EndpointMetrics, err error) networkOutput {
+	out := networkOutput{URL: url, Endpoints: make([]endpointOutput, 0, len(metrics))}
+	if err != nil {
+		out.Error = err.Error()
+	}
+	ms := func(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+	for _, e := range metrics {
+		out.Endpoints = append(out.Endpoints, endpointOutput{
+			Endpoint: e.Endpoint,
+			P50Ms:    ms(e.P50),
+			P90Ms:    ms(e.P90),
+			P99Ms:    ms(e.P99),
+			MaxMs:    ms(e.Max),
+			Requests: e.Requests,
+			Failures: e.Failures,
+		})
+	}
+	return out
+}
+
+// probeNetwork requests the API's health check, today's puzzle and, for a
+// registered player, their stats, rounds times over, recording each in
+// api.DefaultMetrics. Failed requests are recorded too; the first failure is
+// returned.
+func probeNetwork(client *api.Client, rounds int) error {
+	var claimCode string
+	if cfg, err := config.Load(); err == nil && cfg != nil {
+		claimCode = cfg.ClaimCode
+	}
+
+	var first error
+	keep := func(err error) {
+		if first == nil && err != nil {
+			first = err
+		}
+	}
+	for range rounds {
+		keep(client.CheckHealth())
+		_, err := client.FetchTodaysPuzzle()
+		keep(err)
+		if claimCode != "" {
+			_, err := client.FetchStats(claimCode)
+			keep(err)
+		}
+	}
+	return first
+}
+
+// writeNetworkMetrics writes metrics as a table, one endpoint per row.
+func writeNetworkMetrics(w io.Writer, metrics []api.EndpointMetrics) {
+	if len(metrics) == 0 {
+		fmt.Fprintln(w, "No requests made yet.")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Endpoint\tRequests\tFailed\tp50\tp90\tp99\tmax")
+	for _, e := range metrics {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%s\n", e.Endpoint, e.Requests, e.Failures,
+			roundLatency(e.P50), roundLatency(e.P90), roundLatency(e.P99), roundLatency(e.Max))
+	}
+	_ = tw.Flush()
+}
+
+// runNetworkDiagnostics probes the API and writes how each endpoint did, for
+// stats --network.
+func runNetworkDiagnostics(w io.Writer, insecure bool, output outputFormat) error {
+	client, err := api.NewClient(insecure)
+	if err != nil {
+		err = fmt.Errorf("creating API client: %w", err)
+		if output == outputJSON {
+			return writeJSONError(w, "stats network", err)
+		}
+		return err
+	}
+	err = probeNetwork(client, networkProbeRounds)
+	flushNetworkMetrics()
+	metrics := api.DefaultMetrics().Snapshot()
+	if output == outputJSON {
+		return writeJSON(w, "stats network", newNetworkOutput(client.BaseURL(), metrics, err))
+	}
+
+	fmt.Fprintf(w, "%d rounds against %s\n\n", networkProbeRounds, client.BaseURL())
+	writeNetworkMetrics(w, metrics)
+	if err != nil {
+		fmt.Fprintf(w, "\nFirst failure: %v\n", err)
+	}
+	return nil
+}
+
+// roundLatency rounds d to the millisecond for display.
+func roundLatency(d time.Duration) time.Duration {
+	return d.Round(time.Millisecond)
+}
+
+// flushNetworkMetrics appends this process's network metrics to the file
+// named by UNQUOTE_DEBUG_LOG, when it is set and any requests were made.
+// Best-effort: a log that can't be written is skipped.
+func flushNetworkMetrics() {
+	path := os.Getenv(envDebugLog)
+	metrics := api.DefaultMetrics().Snapshot()
+	if path == "" || len(metrics) == 0 {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	now := time.Now().Format(time.RFC3339)
+	for _, e := range metrics {
+		fmt.Fprintf(f, "%s network %q requests=%d failures=%d p50=%s p90=%s p99=%s max=%s\n", now, e.Endpoint,
+			e.Requests, e.Failures, roundLatency(e.P50), roundLatency(e.P90), roundLatency(e.P99), roundLatency(e.Max))
+	}
+}
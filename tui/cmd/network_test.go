@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+// networkServer points the API at a test server, healthy or answering every
+// request with a 503, and starts the metrics afresh.
+func networkServer(t *testing.T, healthy bool) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		switch {
+		case r.URL.Path == "/health/live":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/game/today":
+			_ = json.NewEncoder(w).Encode(api.Puzzle{
+				ID: "game-0115", Date: "2026-01-15", EncryptedText: "XM, MX", Author: "Anon", Difficulty: 10,
+			})
+		case strings.HasSuffix(r.URL.Path, "/stats"):
+			_ = json.NewEncoder(w).Encode(api.PlayerStatsResponse{})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("UNQUOTE_API_URL", srv.URL)
+
+	api.DefaultMetrics().Reset()
+	t.Cleanup(api.DefaultMetrics().Reset)
+}
+
+func TestStatsNetwork_Text(t *testing.T) {
+	setStatusHomes(t)
+	networkServer(t, true)
+
+	output, err := executeCommand(NewRootCmd(), "stats", "--network")
+	if err != nil {
+		t.Fatalf("stats --network error = %v", err)
+	}
+	for _, want := range []string{"5 rounds against http://127.0.0.1", "Endpoint", "GET /game/today", "GET /health/live"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "/stats") || strings.Contains(output, "First failure") {
+		t.Errorf("output should have no stats requests without a claim code, and no failures:\n%s", output)
+	}
+}
+
+func TestStatsNetwork_JSON(t *testing.T) {
+	setStatusHomes(t)
+	networkServer(t, true)
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492"}); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "stats", "--network", "--output", "json")
+	if err != nil {
+		t.Fatalf("stats --network error = %v", err)
+	}
+	var env struct {
+		Data networkOutput `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(output), &env); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, output)
+	}
+	if len(env.Data.Endpoints) != 3 || env.Data.Error != "" {
+		t.Fatalf("network = %+v, want health, puzzle and stats without errors", env.Data)
+	}
+	for _, e := range env.Data.Endpoints {
+		if e.Requests != networkProbeRounds || e.Failures != 0 {
+			t.Errorf("%s: %d requests, %d failed; want %d, none failed", e.Endpoint, e.Requests, e.Failures, networkProbeRounds)
+		}
+	}
+	if got := env.Data.Endpoints[1].Endpoint; got != "GET /health/live" {
+		t.Errorf("endpoints[1] = %q, want endpoints sorted by name", got)
+	}
+}
+
+func TestStatsNetwork_FlushesToDebugLog(t *testing.T) {
+	setStatusHomes(t)
+	networkServer(t, false)
+	path := filepath.Join(t.TempDir(), "debug.log")
+	t.Setenv(envDebugLog, path)
+
+	output, err := executeCommand(NewRootCmd(), "stats", "--network")
+	if err != nil {
+		t.Fatalf("stats --network error = %v", err)
+	}
+	if !strings.Contains(output, "First failure:") {
+		t.Errorf("output should report the failure:\n%s", output)
+	}
+	log, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(log), `network "GET /health/live" requests=5 failures=5`) {
+		t.Errorf("debug log = %q, want the health check's metrics", log)
+	}
+}
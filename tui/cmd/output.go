@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// outputSchemaVersion is bumped whenever a field in the JSON output is
+// renamed, removed or changes meaning. Adding fields does not bump it.
+const outputSchemaVersion = 1
+
+// outputFormat is the value of the global --output flag. It implements
+// pflag.Value so unknown formats are rejected while flags are parsed.
+type outputFormat string
+
+const (
+	outputText outputFormat = "text"
+	outputJSON outputFormat = "json"
+)
+
+func (f *outputFormat) String() string { return string(*f) }
+
+func (f *outputFormat) Set(s string) error {
+	switch outputFormat(s) {
+	case outputText, outputJSON:
+		*f = outputFormat(s)
+		return nil
+	default:
+		return fmt.Errorf("must be %q or %q", outputText, outputJSON)
+	}
+}
+
+func (f *outputFormat) Type() string { return "format" }
+
+// outputEnvelope wraps every JSON document the CLI prints, so scripts can
+// check ok and the schema version before reading data.
+type outputEnvelope struct {
+	Data    any    `json:"data,omitempty"`
+	Command string `json:"command"`
+	Error   string `json:"error,omitempty"`
+	Schema  int    `json:"schema"`
+	OK      bool   `json:"ok"`
+}
+
+// writeJSON prints data for the named command in the shared envelope.
+func writeJSON(w io.Writer, command string, data any) error {
+	return encodeEnvelope(w, outputEnvelope{Schema: outputSchemaVersion, Command: command, OK: true, Data: data})
+}
+
+// writeJSONError prints err for the named command in the shared envelope and
+// returns it, so the command still exits non-zero.
+func writeJSONError(w io.Writer, command string, err error) error {
+	if encErr := encodeEnvelope(w, outputEnvelope{Schema: outputSchemaVersion, Command: command, Error: err.Error()}); encErr != nil {
+		return encErr
+	}
+	return err
+}
+
+func encodeEnvelope(w io.Writer, env outputEnvelope) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(env); err != nil {
+		return fmt.Errorf("writing JSON: %w", err)
+	}
+	return nil
+}
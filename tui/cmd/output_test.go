@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// decodeEnvelope parses a JSON document printed by the CLI, failing the test
+// if it isn't a single envelope.
+func decodeEnvelope(t *testing.T, output string) map[string]any {
+	t.Helper()
+	var env map[string]any
+	if err := json.Unmarshal([]byte(output), &env); err != nil {
+		t.Fatalf("output is not a JSON document: %v\n%s", err, output)
+	}
+	return env
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, "version", map[string]string{"version": "1.2.3"}); err != nil {
+		t.Fatal(err)
+	}
+
+	env := decodeEnvelope(t, buf.String())
+	if env["schema"] != float64(outputSchemaVersion) || env["command"] != "version" || env["ok"] != true {
+		t.Errorf("envelope = %v", env)
+	}
+	if _, ok := env["error"]; ok {
+		t.Error("a successful envelope should have no error")
+	}
+	if data, _ := env["data"].(map[string]any); data["version"] != "1.2.3" {
+		t.Errorf("data = %v", env["data"])
+	}
+}
+
+func TestWriteJSONError(t *testing.T) {
+	var buf bytes.Buffer
+	want := errors.New("boom")
+	if err := writeJSONError(&buf, "stats", want); !errors.Is(err, want) {
+		t.Errorf("writeJSONError() = %v, want the original error back", err)
+	}
+
+	env := decodeEnvelope(t, buf.String())
+	if env["ok"] != false || env["error"] != "boom" || env["command"] != "stats" {
+		t.Errorf("envelope = %v", env)
+	}
+	if _, ok := env["data"]; ok {
+		t.Error("a failed envelope should have no data")
+	}
+}
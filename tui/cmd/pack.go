@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/app"
+	"github.com/bojanrajkovic/unquote/tui/internal/pack"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// newPackCmd returns the parent command for sharing and playing puzzle packs.
+func newPackCmd(insecure *bool) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pack",
+		Short: "Import, export and play themed puzzle packs offline",
+		Long: "Import, export and play themed puzzle packs offline.\n\n" +
+			"Packs are " + pack.FileName + " files holding a collection of quotes.\n" +
+			"Pack puzzles never count toward your stats.",
+		Example: "  unquote pack import stoic-sayings.json\n" +
+			"  unquote pack list\n" +
+			"  unquote pack play stoic-sayings",
+	}
+
+	cmd.AddCommand(newPackImportCmd())
+	cmd.AddCommand(newPackExportCmd())
+	cmd.AddCommand(newPackListCmd())
+	cmd.AddCommand(newPackPlayCmd(insecure))
+
+	return cmd
+}
+
+// newPackImportCmd returns a command that validates and installs a pack file.
+func newPackImportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import <file>",
+		Short: "Install a puzzle pack from a file",
+		Long: "Install a puzzle pack from a file.\n\n" +
+			"The pack is checked before it is installed; installing a pack with the same\n" +
+			"name replaces it.",
+		Example: "  unquote pack import " + pack.FileName,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("reading pack file: %w", err)
+			}
+
+			p, err := pack.Parse(data)
+			if err != nil {
+				return fmt.Errorf("invalid pack: %w", err)
+			}
+
+			if err := pack.Install(p); err != nil {
+				return fmt.Errorf("installing pack: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Installed %q (%d puzzles). Play it with: unquote pack play %s\n",
+				ui.SanitizeString(p.Name), len(p.Puzzles), p.Slug())
+			return nil
+		},
+	}
+}
+
+// newPackExportCmd returns a command that writes a pack file, either for an
+// installed pack or built from the player's own quote files.
+func newPackExportCmd() *cobra.Command {
+	var file string
+	var hints int
+
+	cmd := &cobra.Command{
+		Use:   "export <pack> [quote files...]",
+		Short: "Write a pack file to share",
+		Long: "Write a pack file to share.\n\n" +
+			"With just a pack name, exports that installed pack. With quote files (the same\n" +
+			"format as 'unquote play --file'), builds a new pack with that name from them.",
+		Example: "  # Share an installed pack\n" +
+			"  unquote pack export stoic-sayings -f stoic-sayings.json\n\n" +
+			"  # Build a pack from your own quotes\n" +
+			"  unquote pack export \"Family Sayings\" grandma.txt grandpa.txt --hints 1 -f family.json",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completePackNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, files := args[0], args[1:]
+
+			var p *pack.Pack
+			if len(files) == 0 {
+				installed, err := pack.Load(name)
+				if err != nil {
+					return fmt.Errorf("loading pack: %w", err)
+				}
+				if installed == nil {
+					return fmt.Errorf("no installed pack named %q; run 'unquote pack list'", name)
+				}
+				p = installed
+			} else {
+				quotes := make([]string, 0, len(files))
+				for _, file := range files {
+					data, err := os.ReadFile(file)
+					if err != nil {
+						return fmt.Errorf("reading quote file: %w", err)
+					}
+					quotes = append(quotes, string(data))
+				}
+
+				built, err := pack.FromQuotes(name, quotes, hints)
+				if err != nil {
+					return fmt.Errorf("building pack: %w", err)
+				}
+				p = built
+			}
+
+			data, err := p.Marshal()
+			if err != nil {
+				return err
+			}
+
+			if file == "" || file == "-" {
+				_, err = cmd.OutOrStdout().Write(data)
+				return err
+			}
+			if err := os.WriteFile(file, data, 0o644); err != nil { //nolint:gosec // pack files are meant to be shared
+				return fmt.Errorf("writing pack file: %w", err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %d puzzles to %s\n", len(p.Puzzles), file)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file to write (default: stdout)")
+	cmd.Flags().IntVar(&hints, "hints", 0, "cipher letters to give away in each puzzle built from quote files")
+
+	return cmd
+}
+
+// newPackListCmd returns a command that lists installed packs and the
+// player's progress through each.
+func newPackListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List installed puzzle packs",
+		Long:    "List installed puzzle packs and how many of each pack's puzzles you have solved.",
+		Example: "  unquote pack list",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			packs, err := pack.List()
+			if err != nil {
+				return fmt.Errorf("listing packs: %w", err)
+			}
+
+			out := cmd.OutOrStdout()
+			if len(packs) == 0 {
+				fmt.Fprintln(out, "No packs installed. Add one with 'unquote pack import "+pack.FileName+"'.")
+				return nil
+			}
+
+			for _, p := range packs {
+				fmt.Fprintf(out, "%-24s %s (%d/%d solved)\n",
+					p.Slug(), ui.SanitizeString(p.Name), solvedCount(p), len(p.Puzzles))
+			}
+			return nil
+		},
+	}
+}
+
+// solvedCount returns how many of the pack's puzzles the player has solved.
+// Progress is best-effort; unreadable sessions count as unsolved.
+func solvedCount(p *pack.Pack) int {
+	solved := 0
+	for _, entry := range p.Puzzles {
+		generated, err := entry.Generate()
+		if err != nil {
+			continue
+		}
+		session, err := storage.Custom.LoadSession(generated.ID)
+		if err == nil && session != nil && session.Solved {
+			solved++
+		}
+	}
+	return solved
+}
+
+// newPackPlayCmd returns a command that opens an installed pack on the
+// archive screen.
+func newPackPlayCmd(insecure *bool) *cobra.Command {
+	var accessible bool
+	var target time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "play <pack>",
+		Short: "Browse and play an installed pack's puzzles",
+		Long: "Browse and play an installed pack's puzzles.\n\n" +
+			"Opens the pack's archive screen, showing your progress on each puzzle.",
+		Example:           "  unquote pack play stoic-sayings",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completePackNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := pack.Load(args[0])
+			if err != nil {
+				return fmt.Errorf("loading pack: %w", err)
+			}
+			if p == nil {
+				return fmt.Errorf("no installed pack named %q; run 'unquote pack list'", args[0])
+			}
+
+			return runTUI(cmd, app.Options{
+				Pack:       p,
+				Target:     target,
+				Insecure:   *insecure,
+				Accessible: accessible,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&accessible, "accessible", false, "screen-reader friendly output: plain text, no color-only cues")
+	cmd.Flags().DurationVar(&target, "target", 0, "speed run: count down to a target time (e.g. 3m) and record per-word splits")
+
+	return cmd
+}
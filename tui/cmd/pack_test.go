@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/pack"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// setPackHomes points the XDG data and state directories at temp dirs so
+// installed packs and progress are isolated per test.
+func setPackHomes(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPackCmd_Registered(t *testing.T) {
+	root := NewRootCmd()
+	packCmd, _, err := root.Find([]string{"pack"})
+	if err != nil || packCmd.Use != "pack" {
+		t.Fatal("expected 'pack' subcommand to be registered")
+	}
+
+	for _, use := range []string{"import", "export", "list", "play"} {
+		if sub, _, err := root.Find([]string{"pack", use}); err != nil || sub == packCmd {
+			t.Errorf("expected 'pack %s' subcommand to be registered", use)
+		}
+	}
+}
+
+func TestPackImport_ListAndExport(t *testing.T) {
+	setPackHomes(t)
+
+	file := writeFile(t, pack.FileName, `{
+		"format": 1,
+		"name": "Stoic Sayings",
+		"puzzles": [
+			{"quote": "We suffer more in imagination than in reality", "author": "Seneca"},
+			{"quote": "The obstacle is the way", "author": "Marcus Aurelius", "hints": 1}
+		]
+	}`)
+
+	output, err := executeCommand(NewRootCmd(), "pack", "import", file)
+	if err != nil {
+		t.Fatalf("import error: %v", err)
+	}
+	if !strings.Contains(output, `Installed "Stoic Sayings" (2 puzzles)`) || !strings.Contains(output, "unquote pack play stoic-sayings") {
+		t.Errorf("import output = %q", output)
+	}
+
+	// Solve one puzzle so list reports progress
+	p, _ := pack.Load("stoic-sayings")
+	solved, _ := p.Puzzles[0].Generate()
+	if err := storage.Custom.SaveSession(&storage.GameSession{GameID: solved.ID, Solved: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err = executeCommand(NewRootCmd(), "pack", "list")
+	if err != nil {
+		t.Fatalf("list error: %v", err)
+	}
+	if !strings.Contains(output, "stoic-sayings") || !strings.Contains(output, "(1/2 solved)") {
+		t.Errorf("list output = %q, want the pack and its progress", output)
+	}
+
+	output, err = executeCommand(NewRootCmd(), "pack", "export", "Stoic Sayings")
+	if err != nil {
+		t.Fatalf("export error: %v", err)
+	}
+	exported, err := pack.Parse([]byte(output))
+	if err != nil {
+		t.Fatalf("exported pack does not parse: %v\n%s", err, output)
+	}
+	if exported.Name != "Stoic Sayings" || len(exported.Puzzles) != 2 {
+		t.Errorf("exported pack = %+v", exported)
+	}
+}
+
+func TestPackImport_RejectsInvalid(t *testing.T) {
+	setPackHomes(t)
+
+	file := writeFile(t, pack.FileName, `{"format": 9, "name": "Future", "puzzles": [{"quote": "Hi"}]}`)
+	_, err := executeCommand(NewRootCmd(), "pack", "import", file)
+	if err == nil || !strings.Contains(err.Error(), "invalid pack") {
+		t.Errorf("expected an invalid pack error, got %v", err)
+	}
+}
+
+func TestPackExport_FromQuoteFiles(t *testing.T) {
+	setPackHomes(t)
+
+	first := writeFile(t, "one.txt", "Carpe diem\n— Horace")
+	second := writeFile(t, "two.txt", "Veni, vidi, vici")
+	out := filepath.Join(t.TempDir(), pack.FileName)
+
+	if _, err := executeCommand(NewRootCmd(), "pack", "export", "Latin", first, second, "--hints", "1", "-f", out); err != nil {
+		t.Fatalf("export error: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("reading exported pack: %v", err)
+	}
+	p, err := pack.Parse(data)
+	if err != nil {
+		t.Fatalf("exported pack does not parse: %v", err)
+	}
+	if p.Name != "Latin" || len(p.Puzzles) != 2 || p.Puzzles[0].Author != "Horace" || p.Puzzles[1].Hints != 1 {
+		t.Errorf("exported pack = %+v", p)
+	}
+}
+
+func TestPackExport_UnknownPack(t *testing.T) {
+	setPackHomes(t)
+
+	_, err := executeCommand(NewRootCmd(), "pack", "export", "missing")
+	if err == nil || !strings.Contains(err.Error(), "no installed pack") {
+		t.Errorf("expected a missing pack error, got %v", err)
+	}
+}
+
+func TestPackList_Empty(t *testing.T) {
+	setPackHomes(t)
+
+	output, err := executeCommand(NewRootCmd(), "pack", "list")
+	if err != nil || !strings.Contains(output, "No packs installed") {
+		t.Errorf("list output = %q, %v; want the empty message", output, err)
+	}
+}
+
+func TestPackPlay_UnknownPack(t *testing.T) {
+	setPackHomes(t)
+
+	_, err := executeCommand(NewRootCmd(), "pack", "play", "missing")
+	if err == nil || !strings.Contains(err.Error(), "no installed pack") {
+		t.Errorf("expected a missing pack error, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// useOutputPalette sets the ui palette for styled output printed to out: for
+// Config.Background and Config.Theme, in the colors out can show. Piped
+// output gets no colors.
+func useOutputPalette(out io.Writer) {
+	var background ui.Background
+	var theme *ui.CustomTheme
+	if cfg, err := config.Load(); err == nil && cfg != nil {
+		background, _ = ui.ParseBackground(cfg.Background)
+		if cfg.Theme != "" {
+			// A theme that can't be used leaves the built-in colors
+			theme, _ = loadCustomTheme(cfg.Theme)
+		}
+	}
+	useThemePalette(out, background, theme)
+}
+
+// useThemePalette sets the ui palette for output printed to out: theme's
+// colors, which may be nil for the built-in ones, for background, or for the
+// theme's or terminal's background when that is auto.
+func useThemePalette(out io.Writer, background ui.Background, theme *ui.CustomTheme) {
+	background = ui.PickBackground(background, theme)
+	dark := background.IsDark(true)
+	if background == ui.BackgroundAuto {
+		// Answers dark when the terminal can't be asked
+		dark = lipgloss.HasDarkBackground(os.Stdin, os.Stdout)
+	}
+	ui.UsePalette(dark, colorprofile.Detect(out, os.Environ()), theme)
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/app"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
+)
+
+var gameIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// parseGameID checks a game ID given on the command line. Custom puzzles'
+// IDs only mean something on the machine that made them, so they're refused.
+func parseGameID(arg string) (string, error) {
+	if !gameIDPattern.MatchString(arg) {
+		return "", fmt.Errorf("invalid game ID %q: use up to 64 letters, digits, dashes or underscores", arg)
+	}
+	if puzzlegen.IsLocalID(arg) {
+		return "", fmt.Errorf("game ID %q is a custom puzzle, which can only be played from its quote file", arg)
+	}
+	return arg, nil
+}
+
+// newPlayCmd returns a command that plays one specific puzzle: a quote from a
+// file turned into a puzzle and played fully offline, or an archived puzzle
+// by its game ID.
+func newPlayCmd(insecure *bool) *cobra.Command {
+	var file string
+	var gameID string
+	var author string
+	var hints int
+	var accessible bool
+	var safeMode bool
+	var ephemeral bool
+	var target time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "play",
+		Short: "Play a puzzle made from your own quote, or one by its game ID",
+		Long: "Play a puzzle made from your own quote, offline, or an archived puzzle by its\n" +
+			"game ID.\n\n" +
+			"With --file, the file holds the quote; a last line starting with \"—\" or \"--\"\n" +
+			"names the author. Custom puzzles never count toward your stats.\n\n" +
+			"With --id, the puzzle is fetched from the server, so a friend can try the exact\n" +
+			"puzzle you played. It counts toward your stats like any daily puzzle.",
+		Example: "  # Encipher a quote from a file\n" +
+			"  unquote play --file quote.txt\n\n" +
+			"  # Give away two letters and credit the author\n" +
+			"  unquote play -f quote.txt --hints 2 --author \"Ada Lovelace\"\n\n" +
+			"  # Play a puzzle a friend shared\n" +
+			"  unquote play --id IeEvSBy6",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if gameID != "" {
+				id, err := parseGameID(gameID)
+				if err != nil {
+					return err
+				}
+				return runTUI(cmd, app.Options{
+					GameID:     id,
+					Target:     target,
+					Insecure:   *insecure,
+					Accessible: accessible,
+					SafeMode:   safeMode,
+					Ephemeral:  ephemeral,
+				})
+			}
+			if file == "" {
+				return errors.New("--file or --id is required")
+			}
+
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("reading quote file: %w", err)
+			}
+
+			quote, fileAuthor := puzzlegen.ParseQuote(string(data))
+			if author == "" {
+				author = fileAuthor
+			}
+
+			local, err := puzzlegen.Generate(quote, author, hints)
+			if err != nil {
+				return fmt.Errorf("generating puzzle: %w", err)
+			}
+
+			return runTUI(cmd, app.Options{
+				Local:      local,
+				Target:     target,
+				Insecure:   *insecure,
+				Accessible: accessible,
+				SafeMode:   safeMode,
+				Ephemeral:  ephemeral,
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file containing the quote to encipher")
+	cmd.Flags().StringVar(&gameID, "id", "", "game ID of an archived puzzle to play")
+	cmd.Flags().StringVar(&author, "author", "", "author to show under the puzzle (overrides the file)")
+	cmd.Flags().IntVar(&hints, "hints", 0, "number of cipher letters to give away")
+	cmd.Flags().BoolVar(&accessible, "accessible", false, "screen-reader friendly output: plain text, no color-only cues")
+	cmd.Flags().DurationVar(&target, "target", 0, "speed run: count down to a target time (e.g. 3m) and record per-word splits")
+	cmd.Flags().BoolVar(&safeMode, "safe-mode", false, safeModeUsage)
+	cmd.Flags().BoolVar(&ephemeral, "ephemeral", false, ephemeralUsage)
+
+	cmd.MarkFlagsMutuallyExclusive("id", "file")
+	cmd.MarkFlagsMutuallyExclusive("id", "author")
+	cmd.MarkFlagsMutuallyExclusive("id", "hints")
+
+	return cmd
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPlayCmd_Registered(t *testing.T) {
+	root := NewRootCmd()
+	var found bool
+	for _, sub := range root.Commands() {
+		if sub.Use == "play" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("expected 'play' subcommand to be registered")
+	}
+}
+
+func TestPlayCmd_RequiresFile(t *testing.T) {
+	_, err := executeCommand(NewRootCmd(), "play")
+	if err == nil || !strings.Contains(err.Error(), "--file or --id") {
+		t.Errorf("expected an error asking for --file or --id, got %v", err)
+	}
+}
+
+func TestPlayCmd_MissingFile(t *testing.T) {
+	_, err := executeCommand(NewRootCmd(), "play", "--file", filepath.Join(t.TempDir(), "missing.txt"))
+	if err == nil || !strings.Contains(err.Error(), "reading quote file") {
+		t.Errorf("expected a read error, got %v", err)
+	}
+}
+
+func TestPlayCmd_RejectsUnencipherableQuote(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quote.txt")
+	if err := os.WriteFile(path, []byte("Crème brûlée\n— Chef"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := executeCommand(NewRootCmd(), "play", "--file", path)
+	if err == nil || !strings.Contains(err.Error(), "generating puzzle") {
+		t.Errorf("expected a generation error, got %v", err)
+	}
+}
+
+func TestParseGameID(t *testing.T) {
+	if id, err := parseGameID("IeEvSBy6"); err != nil || id != "IeEvSBy6" {
+		t.Errorf("parseGameID(IeEvSBy6) = %q, %v", id, err)
+	}
+	for _, bad := range []string{"", "../etc", "has space", "local-abc123"} {
+		if _, err := parseGameID(bad); err == nil {
+			t.Errorf("parseGameID(%q) should fail", bad)
+		}
+	}
+}
+
+func TestPlayCmd_IDExcludesQuoteFlags(t *testing.T) {
+	_, err := executeCommand(NewRootCmd(), "play", "--id", "IeEvSBy6", "--file", "quote.txt")
+	if err == nil || !strings.Contains(err.Error(), "none of the others can be") {
+		t.Errorf("expected a mutually exclusive flags error, got %v", err)
+	}
+
+	_, err = executeCommand(NewRootCmd(), "play", "--id", "../etc")
+	if err == nil || !strings.Contains(err.Error(), "invalid game ID") {
+		t.Errorf("expected an invalid game ID error, got %v", err)
+	}
+}
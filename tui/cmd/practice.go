@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/app"
+)
+
+// newPracticeCmd returns a command that plays random archived puzzles without
+// touching the player's history or stats, optionally from one category.
+func newPracticeCmd(insecure *bool, category *string) *cobra.Command {
+	var accessible bool
+	var safeMode bool
+	var ephemeral bool
+	var target time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "practice",
+		Short: "Play random archived puzzles that don't count toward your stats",
+		Long: "Play random archived puzzles that don't count toward your stats.\n\n" +
+			"Practice games are saved separately, so they never show up in your history\n" +
+			"or get uploaded.",
+		Example: "  unquote practice\n" +
+			"  unquote practice --target 2m\n" +
+			"  unquote practice --category history",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTUI(cmd, app.Options{
+				Insecure:   *insecure,
+				Random:     true,
+				Category:   *category,
+				Accessible: accessible,
+				Practice:   true,
+				Target:     target,
+				SafeMode:   safeMode,
+				Ephemeral:  ephemeral,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&accessible, "accessible", false, "screen-reader friendly output: plain text, no color-only cues")
+	cmd.Flags().DurationVar(&target, "target", 0, "speed run: count down to a target time (e.g. 3m) and record per-word splits")
+	cmd.Flags().BoolVar(&safeMode, "safe-mode", false, safeModeUsage)
+	cmd.Flags().BoolVar(&ephemeral, "ephemeral", false, ephemeralUsage)
+
+	return cmd
+}
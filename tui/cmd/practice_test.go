@@ -0,0 +1,28 @@
+package cmd
+
+import "testing"
+
+func TestPracticeCmd_Registered(t *testing.T) {
+	root := NewRootCmd()
+	var found bool
+	for _, sub := range root.Commands() {
+		if sub.Use == "practice" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("expected 'practice' subcommand to be registered")
+	}
+}
+
+func TestPracticeCmd_AccessibleFlagRegistered(t *testing.T) {
+	cmd := newPracticeCmd(new(bool), new(string))
+	flag := cmd.Flags().Lookup("accessible")
+	if flag == nil {
+		t.Fatal("expected --accessible flag to be registered on practice")
+	}
+	if flag.DefValue != "false" {
+		t.Errorf("expected --accessible default to be %q, got %q", "false", flag.DefValue)
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+)
+
+// maxPrefetchDays caps how far ahead prefetch will download in one run.
+const maxPrefetchDays = 30
+
+// newPrefetchCmd returns a command that downloads upcoming daily puzzles so
+// they can be played without a connection.
+func newPrefetchCmd(insecure *bool) *cobra.Command {
+	var days int
+
+	cmd := &cobra.Command{
+		Use:   "prefetch",
+		Short: "Download upcoming daily puzzles for offline play",
+		Long: "Download upcoming daily puzzles for offline play.\n\n" +
+			"When the API can't be reached, unquote plays today's puzzle from this cache.\n" +
+			"Puzzles are dropped from the cache a week after their day has passed.",
+		Example: "  # Cache the next week\n" +
+			"  unquote prefetch\n\n" +
+			"  # Cache the next two weeks before a trip\n" +
+			"  unquote prefetch --days 14",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if days < 1 || days > maxPrefetchDays {
+				return fmt.Errorf("--days must be between 1 and %d", maxPrefetchDays)
+			}
+
+			client, err := api.NewClient(*insecure)
+			if err != nil {
+				return fmt.Errorf("creating API client: %w", err)
+			}
+
+			now := time.Now().In(playerLocation())
+			if _, err := cache.Prune(now); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not clean up expired puzzles: %v\n", err)
+			}
+
+			added, err := cache.Prefetch(client, now, days)
+			if err != nil && added == 0 {
+				return fmt.Errorf("prefetching puzzles: %w", err)
+			}
+
+			dates := cache.Dates(now, days)
+			fmt.Fprintf(cmd.OutOrStdout(), "Cached %d new puzzles for %s to %s.\n", added, dates[0], dates[len(dates)-1])
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: some puzzles could not be downloaded:\n%v\n", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 7, "number of days to download, starting today")
+
+	return cmd
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+)
+
+func TestPrefetchCmd_Registered(t *testing.T) {
+	root := NewRootCmd()
+	var found bool
+	for _, sub := range root.Commands() {
+		if sub.Use == "prefetch" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("expected 'prefetch' subcommand to be registered")
+	}
+}
+
+func TestPrefetchCmd_RejectsBadDays(t *testing.T) {
+	for _, days := range []string{"0", "31"} {
+		_, err := executeCommand(NewRootCmd(), "prefetch", "--days", days)
+		if err == nil || !strings.Contains(err.Error(), "--days") {
+			t.Errorf("--days %s: expected a range error, got %v", days, err)
+		}
+	}
+}
+
+func TestPrefetchCmd_CachesPuzzles(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if id, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/game/"), "/solution"); ok {
+			_ = json.NewEncoder(w).Encode(api.SolutionResponse{Solution: "ANSWER " + id})
+			return
+		}
+		date := strings.TrimPrefix(r.URL.Path, "/game/")
+		_ = json.NewEncoder(w).Encode(api.Puzzle{ID: "id-" + date, Date: date, EncryptedText: "XYZ"})
+	}))
+	defer srv.Close()
+	t.Setenv("UNQUOTE_API_URL", srv.URL)
+
+	output, err := executeCommand(NewRootCmd(), "prefetch", "--days", "3")
+	if err != nil {
+		t.Fatalf("prefetch error: %v", err)
+	}
+	if !strings.Contains(output, "Cached 3 new puzzles") {
+		t.Errorf("output = %q, want 3 puzzles cached", output)
+	}
+
+	now := time.Now()
+	for _, date := range cache.Dates(now, 3) {
+		if entry, _ := cache.Load(date, now); entry == nil || entry.Solution != "ANSWER id-"+date {
+			t.Errorf("%s: cached entry = %+v, want the puzzle and its answer", date, entry)
+		}
+	}
+
+	// A second run only fetches what's missing
+	output, err = executeCommand(NewRootCmd(), "prefetch", "--days", "3")
+	if err != nil || !strings.Contains(output, "Cached 0 new puzzles") {
+		t.Errorf("second run = %q, %v; want nothing new", output, err)
+	}
+}
+
+func TestPrefetchCmd_Unreachable(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+	t.Setenv("UNQUOTE_API_URL", "http://localhost:19999")
+
+	_, err := executeCommand(NewRootCmd(), "prefetch", "--days", "1")
+	if err == nil || !strings.Contains(err.Error(), "prefetching puzzles") {
+		t.Errorf("expected a prefetch error, got %v", err)
+	}
+}
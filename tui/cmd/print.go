@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/render/print"
+)
+
+// minPrintWidth is the narrowest --width that still fits a long word.
+const minPrintWidth = 20
+
+// newPrintCmd returns a command that renders a daily puzzle for solving on
+// paper.
+func newPrintCmd(insecure *bool) *cobra.Command {
+	var file, format string
+	var width int
+
+	cmd := &cobra.Command{
+		Use:   "print [date]",
+		Short: "Print a puzzle to solve on paper",
+		Long: "Print a daily puzzle to solve on paper: the cipher text with a blank over each\n" +
+			"letter, the clues and the difficulty. Without a date, prints today's puzzle.\n\n" +
+			"Text and Markdown wrap at --width columns. HTML is a standalone page; open it in\n" +
+			"a browser to print it or save it as a PDF. A puzzle cached by 'unquote prefetch'\n" +
+			"prints without a connection.",
+		Example: "  # Today's puzzle, to print from the terminal\n" +
+			"  unquote print | lpr\n\n" +
+			"  # An archived puzzle as a page to print or save as PDF\n" +
+			"  unquote print 2026-01-15 --format html -f puzzle.html",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := print.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+			if width < minPrintWidth {
+				return fmt.Errorf("--width must be at least %d", minPrintWidth)
+			}
+
+			now := time.Now().In(playerLocation())
+			date := cache.Today(now)
+			if len(args) == 1 {
+				if _, err := time.Parse(time.DateOnly, args[0]); err != nil {
+					return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", args[0])
+				}
+				date = args[0]
+			}
+
+			p, err := fetchPrintPuzzle(*insecure, date, now)
+			if err != nil {
+				return err
+			}
+			sanitizePuzzle(p)
+
+			var buf bytes.Buffer
+			if err := print.Render(&buf, p, f, width); err != nil {
+				return fmt.Errorf("rendering puzzle: %w", err)
+			}
+
+			if file == "" || file == "-" {
+				_, err = cmd.OutOrStdout().Write(buf.Bytes())
+				return err
+			}
+			if err := os.WriteFile(file, buf.Bytes(), 0o644); err != nil { //nolint:gosec // a printout is meant to be shared
+				return fmt.Errorf("writing puzzle file: %w", err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Wrote the puzzle for %s to %s\n", p.Date, file)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file to write (default: stdout)")
+	cmd.Flags().StringVar(&format, "format", string(print.Text), "output format: text, markdown or html")
+	cmd.Flags().IntVar(&width, "width", print.DefaultWidth, "columns to wrap text and Markdown at")
+
+	return cmd
+}
+
+// fetchPrintPuzzle fetches the daily puzzle for date, falling back to the
+// offline cache when the API can't be reached.
+func fetchPrintPuzzle(insecure bool, date string, now time.Time) (*api.Puzzle, error) {
+	client, err := api.NewClient(insecure)
+	if err != nil {
+		return nil, fmt.Errorf("creating API client: %w", err)
+	}
+	p, err := client.FetchPuzzleByDate(date)
+	if err == nil {
+		return p, nil
+	}
+	if entry, cacheErr := cache.Load(date, now); cacheErr == nil && entry != nil {
+		return entry.Puzzle, nil
+	}
+	return nil, fmt.Errorf("fetching the puzzle for %s: %w", date, err)
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+)
+
+// printServer serves one archived puzzle, for 2026-01-15.
+func printServer(t *testing.T) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/game/2026-01-15" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.Puzzle{
+			ID: "game-0115", Date: "2026-01-15", EncryptedText: "XM, MX", Author: "Anon", Difficulty: 10,
+			Hints: []api.Hint{{CipherLetter: "X", PlainLetter: "A"}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Setenv("UNQUOTE_API_URL", srv.URL)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+}
+
+func TestPrintCmd_Text(t *testing.T) {
+	printServer(t)
+
+	output, err := executeCommand(NewRootCmd(), "print", "--insecure", "2026-01-15")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"UNQUOTE · 2026-01-15", "Difficulty: Easy (10)", "A _ ,   _ A\nX M     M X", "— Anon", "Clues: X = A"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestPrintCmd_HTMLToFile(t *testing.T) {
+	printServer(t)
+
+	path := filepath.Join(t.TempDir(), "puzzle.html")
+	output, err := executeCommand(NewRootCmd(), "print", "--insecure", "2026-01-15", "--format", "html", "-f", path)
+	if err != nil || !strings.Contains(output, "Wrote the puzzle for 2026-01-15 to "+path) {
+		t.Fatalf("print = %q, %v", output, err)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // the test wrote it
+	if err != nil || !strings.HasPrefix(string(data), "<!DOCTYPE html>") {
+		t.Errorf("file = %.60q, %v; want an HTML page", data, err)
+	}
+}
+
+func TestPrintCmd_FromCache(t *testing.T) {
+	printServer(t)
+	// The server only has 2026-01-15; the cache has a later day
+	date := cache.Today(time.Now())
+	if err := cache.Save(&cache.Entry{Puzzle: &api.Puzzle{ID: "game-today", Date: date, EncryptedText: "QZ"}, Solution: "HI"}); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "print", "--insecure", date, "--format", "md")
+	if err != nil || !strings.Contains(output, "# Unquote · "+date) || !strings.Contains(output, "_ _\nQ Z") {
+		t.Errorf("print from cache = %q, %v", output, err)
+	}
+}
+
+func TestPrintCmd_Rejects(t *testing.T) {
+	printServer(t)
+
+	for _, args := range [][]string{
+		{"print", "15/01/2026"},
+		{"print", "--format", "pdf"},
+		{"print", "--width", "5"},
+	} {
+		if _, err := executeCommand(NewRootCmd(), args...); err == nil {
+			t.Errorf("%v should fail", args)
+		}
+	}
+	if _, err := executeCommand(NewRootCmd(), "print", "--insecure", "2026-01-16"); err == nil {
+		t.Error("a puzzle neither the server nor the cache has should fail")
+	}
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+// Environment variables standing in for --stats and --claim-code, for
+// machines set up without a terminal to answer on.
+const (
+	envStats     = "UNQUOTE_STATS"
+	envClaimCode = "UNQUOTE_CLAIM_CODE"
+)
+
+// statsSetting is the value of the --stats flag: "on", "off", or empty when
+// not given. It implements pflag.Value so unknown values are rejected while
+// flags are parsed.
+type statsSetting string
+
+const (
+	statsOn  statsSetting = "on"
+	statsOff statsSetting = "off"
+)
+
+func (s *statsSetting) String() string { return string(*s) }
+
+func (s *statsSetting) Set(v string) error {
+	switch statsSetting(v) {
+	case statsOn, statsOff:
+		*s = statsSetting(v)
+		return nil
+	default:
+		return fmt.Errorf("must be %q or %q", statsOn, statsOff)
+	}
+}
+
+func (s *statsSetting) Type() string { return "on|off" }
+
+// provisioning answers the first-run stats question without asking, so the
+// TUI skips its onboarding form: --stats turns stats tracking on or off and
+// --claim-code links an existing code, which implies --stats on.
+type provisioning struct {
+	stats     statsSetting
+	claimCode string
+}
+
+// fromEnv fills in what wasn't given as a flag from UNQUOTE_STATS and
+// UNQUOTE_CLAIM_CODE.
+func (p *provisioning) fromEnv() error {
+	if v := os.Getenv(envStats); p.stats == "" && v != "" {
+		if err := p.stats.Set(v); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", envStats, v, err)
+		}
+	}
+	if p.claimCode == "" {
+		p.claimCode = os.Getenv(envClaimCode)
+	}
+	return nil
+}
+
+// apply writes the provisioned settings to the config, merged into what is
+// already there, and does nothing when none were given. Turning stats on
+// without a claim code registers one unless the device has it already;
+// turning them off unlinks the device's code, naming it on out so it can be
+// linked again.
+func (p provisioning) apply(insecure bool, out io.Writer) error {
+	if p.stats == "" && p.claimCode == "" {
+		return nil
+	}
+	if p.stats == statsOff && p.claimCode != "" {
+		return errors.New("--claim-code can't be combined with --stats off")
+	}
+
+	cfg, err := loadOrNewConfig()
+	if err != nil {
+		return err
+	}
+	switch {
+	case p.claimCode != "":
+		code, err := parseClaimCode(p.claimCode)
+		if err != nil {
+			return err
+		}
+		cfg.ClaimCode = code
+		cfg.StatsEnabled = true
+	case p.stats == statsOff:
+		if cfg.ClaimCode != "" {
+			fmt.Fprintf(out, "Unlinked claim code %s; 'unquote link %s' links it again.\n", cfg.ClaimCode, cfg.ClaimCode)
+		}
+		cfg.ClaimCode = ""
+		cfg.StatsEnabled = false
+	case cfg.ClaimCode == "":
+		client, err := api.NewClient(insecure)
+		if err != nil {
+			return fmt.Errorf("creating API client: %w", err)
+		}
+		resp, err := client.RegisterPlayer()
+		if err != nil {
+			return fmt.Errorf("registering player: %w", err)
+		}
+		cfg.ClaimCode = resp.ClaimCode
+		cfg.StatsEnabled = true
+		fmt.Fprintf(out, "Registered! Your claim code is: %s\n", resp.ClaimCode)
+	default:
+		cfg.StatsEnabled = true
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+	return nil
+}
+
+// provisionHook returns the root command's PersistentPreRunE, which applies
+// p before any command runs. Settings can't be written by an ephemeral run,
+// which leaves nothing on disk.
+func provisionHook(p *provisioning, insecure *bool) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		if err := p.fromEnv(); err != nil {
+			return err
+		}
+		if p.stats == "" && p.claimCode == "" {
+			return nil
+		}
+		if ephemeral, _ := cmd.Flags().GetBool("ephemeral"); ephemeral {
+			return errors.New("--stats and --claim-code save settings; they can't be combined with --ephemeral")
+		}
+		return p.apply(*insecure, cmd.ErrOrStderr())
+	}
+}
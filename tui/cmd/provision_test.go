@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+func TestProvision_ClaimCode(t *testing.T) {
+	setConfigHome(t)
+	if err := config.Save(&config.Config{WeeklyGoal: 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := executeCommand(NewRootCmd(), "--claim-code", "tiger-maple-7492", "version"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cfg, err := config.Load()
+	if err != nil || cfg == nil {
+		t.Fatalf("config.Load() = %v, %v", cfg, err)
+	}
+	if cfg.ClaimCode != "TIGER-MAPLE-7492" || !cfg.StatsEnabled || cfg.WeeklyGoal != 5 {
+		t.Errorf("config = %+v, want the code linked and the goal kept", cfg)
+	}
+}
+
+func TestProvision_Env(t *testing.T) {
+	setConfigHome(t)
+	t.Setenv(envClaimCode, "FOX-RIVER-0412")
+
+	if _, err := executeCommand(NewRootCmd(), "version"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg, _ := config.Load(); cfg == nil || cfg.ClaimCode != "FOX-RIVER-0412" {
+		t.Errorf("config = %+v, want the code from %s", cfg, envClaimCode)
+	}
+
+	t.Setenv(envStats, "maybe")
+	if _, err := executeCommand(NewRootCmd(), "version"); err == nil || !strings.Contains(err.Error(), envStats) {
+		t.Errorf("error = %v, want %s rejected", err, envStats)
+	}
+}
+
+func TestProvision_StatsOff(t *testing.T) {
+	setConfigHome(t)
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492", StatsEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "--stats", "off", "version")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "unquote link TIGER-MAPLE-7492") {
+		t.Errorf("output should say how to link the code again:\n%s", output)
+	}
+	if cfg, _ := config.Load(); cfg == nil || cfg.ClaimCode != "" || cfg.StatsEnabled {
+		t.Errorf("config = %+v, want stats off and no code", cfg)
+	}
+}
+
+func TestProvision_StatsOnRegisters(t *testing.T) {
+	setConfigHome(t)
+	registrations := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/player" {
+			registrations++
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]string{"claimCode": "TIGER-MAPLE-7492"})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+	t.Setenv("UNQUOTE_API_URL", server.URL)
+
+	for range 2 {
+		if _, err := executeCommand(NewRootCmd(), "--stats", "on", "version"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if cfg, _ := config.Load(); cfg == nil || cfg.ClaimCode != "TIGER-MAPLE-7492" || !cfg.StatsEnabled {
+		t.Errorf("config = %+v, want the registered code", cfg)
+	}
+	if registrations != 1 {
+		t.Errorf("%d registrations, want the code kept once registered", registrations)
+	}
+}
+
+func TestProvision_Rejects(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"unknown setting", []string{"--stats", "maybe", "version"}},
+		{"code with stats off", []string{"--stats", "off", "--claim-code", "TIGER-MAPLE-7492", "version"}},
+		{"malformed code", []string{"--claim-code", "tiger", "version"}},
+		{"ephemeral", []string{"play", "--ephemeral", "--stats", "off"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConfigHome(t)
+			if _, err := executeCommand(NewRootCmd(), tt.args...); err == nil {
+				t.Errorf("%v should be rejected", tt.args)
+			}
+			if cfg, _ := config.Load(); cfg != nil {
+				t.Errorf("config = %+v, want nothing written", cfg)
+			}
+		})
+	}
+}
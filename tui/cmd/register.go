@@ -15,6 +15,11 @@ func newRegisterCmd(insecure *bool) *cobra.Command {
 	return &cobra.Command{
 		Use:   "register",
 		Short: "Register for stats tracking and get a claim code",
+		Long: "Register for stats tracking and get a claim code.\n\n" +
+			"The claim code identifies your stats; no personal information is stored.\n" +
+			"Keep it to link other devices with 'unquote link'. If this device already\n" +
+			"has a claim code, it is shown instead.",
+		Example: "  unquote register",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			existing, err := config.Load()
 			if err != nil {
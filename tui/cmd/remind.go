@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/goal"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// newRemindCmd returns a command that shows or sets the daily reminder and,
+// with --check, reminds the player of an unsolved daily puzzle.
+func newRemindCmd() *cobra.Command {
+	var check bool
+
+	cmd := &cobra.Command{
+		Use:   "remind [HH:MM|off]",
+		Short: "Show or set a daily reminder to solve the puzzle",
+		Long: "Show or set the time of day, in your time zone (see 'unquote timezone'), from\n" +
+			"which today's puzzle is worth a reminder until it is solved or revealed.\n\n" +
+			"unquote doesn't run in the background, so the reminder goes off when something\n" +
+			"runs 'unquote remind --check': a shell prompt, a tmux status line or a cron\n" +
+			"job. It prints the reminder, with weekly goal progress when a goal is set, and\n" +
+			"sends a desktop notification when output is a terminal. When the reminder\n" +
+			"isn't due it prints nothing. 'unquote status' shows it too.",
+		Example: "  # Remind me from 7:30 in the evening\n" +
+			"  unquote remind 19:30\n\n" +
+			"  # Check from a shell prompt\n" +
+			"  unquote remind --check\n\n" +
+			"  # Send the reminder from cron\n" +
+			"  unquote remind --check | xargs -r notify-send Unquote",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if check {
+				if len(args) > 0 {
+					return errors.New("--check can't be combined with a reminder time")
+				}
+				return checkReminder(cmd.OutOrStdout(), time.Now().In(playerLocation()))
+			}
+
+			cfg, err := loadOrNewConfig()
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 1 {
+				if err := setReminder(cfg, args[0]); err != nil {
+					return err
+				}
+				if err := config.Save(cfg); err != nil {
+					return fmt.Errorf("saving config: %w", err)
+				}
+			}
+
+			if cfg.Reminder == "" {
+				fmt.Fprintln(cmd.OutOrStdout(), "Reminder: off")
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Reminder: %s\n", cfg.Reminder)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "print a reminder if today's puzzle is unsolved and the reminder time has passed")
+
+	return cmd
+}
+
+// setReminder sets cfg's reminder from a "HH:MM" time, or clears it for "off".
+func setReminder(cfg *config.Config, at string) error {
+	if at == "off" {
+		cfg.Reminder = ""
+		return nil
+	}
+	reminder, err := goal.ParseReminder(at)
+	if err != nil {
+		return err
+	}
+	cfg.Reminder = reminder.String()
+	return nil
+}
+
+// checkReminder prints the reminder when it is due at now, adding the
+// weekly goal's progress when a goal is set, and sends it as a desktop
+// notification when w is a terminal.
+func checkReminder(w io.Writer, now time.Time) error {
+	out, progress, err := buildStatus(now)
+	if err != nil {
+		return err
+	}
+	if out.Reminder == nil || !out.Reminder.Due {
+		return nil
+	}
+
+	text := "Today's puzzle is still waiting."
+	if out.Goal != nil && !out.Goal.Met {
+		text += " " + progress.Summary()
+	}
+	if f, ok := w.(*os.File); ok && term.IsTerminal(f.Fd()) {
+		fmt.Fprint(w, ui.NotifySequence("Unquote: "+text))
+	}
+	fmt.Fprintln(w, text)
+	return nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+func TestRemindCmd_SetShowAndClear(t *testing.T) {
+	setStatusHomes(t)
+
+	output, err := executeCommand(NewRootCmd(), "remind")
+	if err != nil || output != "Reminder: off\n" {
+		t.Errorf("remind with nothing set = %q, %v; want off", output, err)
+	}
+
+	output, err = executeCommand(NewRootCmd(), "remind", "7pm")
+	if err == nil {
+		t.Errorf("remind 7pm = %q, want an error for a time not in HH:MM", output)
+	}
+
+	output, err = executeCommand(NewRootCmd(), "remind", "19:30")
+	if err != nil || output != "Reminder: 19:30\n" {
+		t.Errorf("remind 19:30 = %q, %v", output, err)
+	}
+	cfg, err := config.Load()
+	if err != nil || cfg.Reminder != "19:30" {
+		t.Fatalf("saved reminder = %+v, %v; want 19:30", cfg, err)
+	}
+
+	output, err = executeCommand(NewRootCmd(), "remind", "off")
+	if err != nil || output != "Reminder: off\n" {
+		t.Errorf("remind off = %q, %v", output, err)
+	}
+}
+
+func TestCheckReminder(t *testing.T) {
+	setStatusHomes(t)
+	if err := config.Save(&config.Config{Reminder: "19:30", WeeklyGoal: 3}); err != nil {
+		t.Fatal(err)
+	}
+	evening := time.Date(2026, 1, 21, 20, 0, 0, 0, time.Local)
+
+	check := func(now time.Time) string {
+		t.Helper()
+		var out bytes.Buffer
+		if err := checkReminder(&out, now); err != nil {
+			t.Fatalf("checkReminder: %v", err)
+		}
+		return out.String()
+	}
+
+	if got := check(evening.Add(-time.Hour)); got != "" {
+		t.Errorf("before the reminder time: %q, want nothing", got)
+	}
+	got := check(evening)
+	if !strings.HasPrefix(got, "Today's puzzle is still waiting.") || !strings.Contains(got, "0 of 3 days this week") {
+		t.Errorf("after the reminder time: %q, want the reminder with goal progress", got)
+	}
+	if strings.Contains(got, "\x1b]9;") {
+		t.Errorf("reminder to a buffer should not carry a notification sequence: %q", got)
+	}
+
+	solvedAt := evening.Add(-2 * time.Hour)
+	if err := storage.SaveSession(&storage.GameSession{
+		GameID: "game-today", Date: cache.Today(evening), Solved: true, SolvedAt: &solvedAt,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got := check(evening); got != "" {
+		t.Errorf("with today's puzzle solved: %q, want nothing", got)
+	}
+}
+
+func TestStatusCmd_ShowsReminder(t *testing.T) {
+	setStatusHomes(t)
+	if err := config.Save(&config.Config{Reminder: "00:00"}); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "status")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "Reminder: 00:00, today's puzzle is still waiting") {
+		t.Errorf("status should show the due reminder:\n%s", output)
+	}
+
+	output, err = executeCommand(NewRootCmd(), "status", "--output", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := decodeEnvelope(t, output)["data"].(map[string]any)
+	reminder, _ := data["reminder"].(map[string]any)
+	if reminder["at"] != "00:00" || reminder["due"] != true {
+		t.Errorf("reminder = %v, want due at 00:00", data["reminder"])
+	}
+}
@@ -2,11 +2,22 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
 	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/term"
 	zone "github.com/lrstanley/bubblezone/v2"
 	"github.com/spf13/cobra"
 
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
 	"github.com/bojanrajkovic/unquote/tui/internal/app"
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/telemetry"
 )
 
 // NewRootCmd returns a fresh root command for the unquote CLI.
@@ -15,43 +26,200 @@ import (
 func NewRootCmd() *cobra.Command {
 	var insecure bool
 	var random bool
+	var category string
+	var accessible bool
+	var safeMode bool
+	var ephemeral bool
+	var target time.Duration
+	var provision provisioning
+	output := outputText
 
 	rootCmd := &cobra.Command{
-		Use:          "unquote",
-		Short:        "Play cryptoquip puzzles in your terminal",
+		Use:   "unquote",
+		Short: "Play cryptoquip puzzles in your terminal",
+		Long: "Play cryptoquip puzzles in your terminal.\n\n" +
+			"Each letter of a famous quote is swapped for another; work out the substitution\n" +
+			"to reveal it. With no subcommand, unquote opens today's daily puzzle.",
+		Example: "  # Play today's puzzle\n" +
+			"  unquote\n\n" +
+			"  # Play a random archived puzzle against a three-minute target\n" +
+			"  unquote --random --target 3m\n\n" +
+			"  # Play a random pun\n" +
+			"  unquote --category puns\n\n" +
+			"  # Use plain-text output for screen readers\n" +
+			"  unquote --accessible\n\n" +
+			"  # Play on a shared machine without leaving anything behind\n" +
+			"  unquote --ephemeral\n\n" +
+			"  # Set up a new machine without the first-run stats question\n" +
+			"  unquote --claim-code TIGER-MAPLE-7492 status",
 		SilenceUsage: true,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			zone.NewGlobal()
-
-			opts := app.Options{
-				Insecure: insecure,
-				Random:   random,
-			}
-
-			model, err := app.New(opts)
-			if err != nil {
-				return err
-			}
-
-			p := tea.NewProgram(model)
-			_, err = p.Run()
-			return err
+		// --stats and --claim-code are written before any command runs
+		PersistentPreRunE: provisionHook(&provision, &insecure),
+		// The explicit completion command below replaces cobra's default
+		CompletionOptions: cobra.CompletionOptions{DisableDefaultCmd: true},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTUI(cmd, app.Options{
+				Insecure:   insecure,
+				Random:     random || category != "",
+				Category:   category,
+				Accessible: accessible,
+				Target:     target,
+				SafeMode:   safeMode,
+				Ephemeral:  ephemeral,
+			})
 		},
 	}
 
 	rootCmd.PersistentFlags().BoolVar(&insecure, "insecure", false, "allow insecure HTTP connections to non-localhost hosts")
 	rootCmd.PersistentFlags().BoolVar(&random, "random", false, "play a random puzzle instead of today's")
+	rootCmd.PersistentFlags().StringVar(&category, "category", "", "play random puzzles from one category, e.g. quotes, puns or history (implies --random)")
+	rootCmd.PersistentFlags().Var(&output, "output", "output format for stats, status, doctor, claim-code, favorites, solve, export, history, sync and version: text or json")
+	rootCmd.PersistentFlags().Var(&provision.stats, "stats", "turn stats tracking on or off without asking, registering if needed (or $"+envStats+")")
+	rootCmd.PersistentFlags().StringVar(&provision.claimCode, "claim-code", "", "link this claim code without asking; implies --stats on (or $"+envClaimCode+")")
+	rootCmd.PersistentFlags().Bool("force-tty", false, "start the interactive UI even when output is not a terminal")
+	rootCmd.PersistentFlags().Bool("trace-msgs", false, traceMsgsUsage)
+	rootCmd.Flags().BoolVar(&accessible, "accessible", false, "screen-reader friendly output: plain text, no color-only cues")
+	rootCmd.Flags().DurationVar(&target, "target", 0, "speed run: count down to a target time (e.g. 3m) and record per-word splits")
+	rootCmd.Flags().BoolVar(&safeMode, "safe-mode", false, safeModeUsage)
+	rootCmd.Flags().BoolVar(&ephemeral, "ephemeral", false, ephemeralUsage)
 
-	rootCmd.AddCommand(newVersionCmd())
+	rootCmd.AddCommand(newVersionCmd(&output))
 	rootCmd.AddCommand(newRegisterCmd(&insecure))
 	rootCmd.AddCommand(newLinkCmd())
-	rootCmd.AddCommand(newClaimCodeCmd())
-	rootCmd.AddCommand(newStatsCmd(&insecure))
+	rootCmd.AddCommand(newClaimCodeCmd(&output))
+	rootCmd.AddCommand(newStatsCmd(&insecure, &output))
+	rootCmd.AddCommand(newFriendsCmd())
+	rootCmd.AddCommand(newFavoritesCmd(&output))
+	rootCmd.AddCommand(newStatusCmd(&output))
+	rootCmd.AddCommand(newDoctorCmd(&insecure, &output))
+	rootCmd.AddCommand(newGoalCmd())
+	rootCmd.AddCommand(newRemindCmd())
+	rootCmd.AddCommand(newTimezoneCmd())
+	rootCmd.AddCommand(newAccentsCmd())
+	rootCmd.AddCommand(newBackgroundCmd())
+	rootCmd.AddCommand(newThemeCmd())
+	rootCmd.AddCommand(newTipsCmd())
+	rootCmd.AddCommand(newPracticeCmd(&insecure, &category))
+	rootCmd.AddCommand(newTutorialCmd(&insecure))
+	rootCmd.AddCommand(newDuelCmd(&insecure))
+	rootCmd.AddCommand(newPlayCmd(&insecure))
+	rootCmd.AddCommand(newPackCmd(&insecure))
+	rootCmd.AddCommand(newPrefetchCmd(&insecure))
+	rootCmd.AddCommand(newSolveCmd(&insecure, &output))
+	rootCmd.AddCommand(newPrintCmd(&insecure))
+	rootCmd.AddCommand(newShareCmd(&insecure))
+	rootCmd.AddCommand(newExportCmd(&output))
+	rootCmd.AddCommand(newHistoryCmd(&output))
+	rootCmd.AddCommand(newSyncCmd(&insecure, &output))
+	rootCmd.AddCommand(newSummaryCmd(&insecure))
+	rootCmd.AddCommand(newCompletionCmd())
+	rootCmd.AddCommand(newDocsCmd())
 
 	return rootCmd
 }
 
-// Execute creates a root command and runs it, returning any error.
+// safeModeUsage describes the --safe-mode flag the puzzle-playing commands share.
+const safeModeUsage = "start puzzles fresh, without restoring saved games or the game from a crash"
+
+// ephemeralUsage describes the --ephemeral flag the puzzle-playing commands share.
+const ephemeralUsage = "write nothing to disk: games and settings last only until unquote exits"
+
+// notTerminalText explains why the interactive UI won't start with output
+// piped or captured, and what works there instead.
+const notTerminalText = "the interactive UI needs a terminal, and output is not one.\n" +
+	"For scripts and CI, try:\n" +
+	"  unquote stats          your stats\n" +
+	"  unquote status         today's puzzle and your weekly goal\n" +
+	"  unquote solve --stdin  check an answer for today's puzzle\n" +
+	"Pass --force-tty to start it anyway."
+
+// checkTerminal refuses to start the full-screen UI when stdout is not a
+// terminal, rather than writing its escape codes into a pipe or log, unless
+// --force-tty is given.
+func checkTerminal(cmd *cobra.Command) error {
+	if force, _ := cmd.Flags().GetBool("force-tty"); force || term.IsTerminal(os.Stdout.Fd()) {
+		return nil
+	}
+	return errors.New(notTerminalText)
+}
+
+// runTUI starts the interactive puzzle UI for cmd with the given options. An
+// ephemeral run keeps its sessions in memory, so there is no crash recovery
+// to load or save, and turns the offline puzzle cache off. With --trace-msgs the model runs inside a msgTracer. Once
+// the program stops, shutdown flushes what the app still holds.
+func runTUI(cmd *cobra.Command, opts app.Options) error {
+	traceMsgs, _ := cmd.Flags().GetBool("trace-msgs")
+	if traceMsgs && opts.Ephemeral {
+		return errors.New("--trace-msgs writes a log; it can't be combined with --ephemeral")
+	}
+	if err := checkTerminal(cmd); err != nil {
+		return err
+	}
+	zone.NewGlobal()
+
+	if opts.Ephemeral {
+		defer storage.Use(storage.NewMemory())()
+		defer cache.Disable()()
+	} else {
+		opts.Recovery = loadRecovery(opts.SafeMode)
+	}
+	appModel, err := app.New(opts)
+	if err != nil {
+		return err
+	}
+	var model recoverable = appModel
+	if traceMsgs {
+		log, err := openMsgTrace()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = log.Close() }()
+		model = newMsgTracer(appModel, log)
+		// Requests made along the way go in the same log
+		api.SetRequestLog(log)
+		defer api.SetRequestLog(nil)
+	}
+
+	run := startRun()
+	if opts.Ephemeral {
+		final, err := tea.NewProgram(model).Run()
+		endTrace(final)
+		shutdown(final, run, err)
+		return err
+	}
+	defer flushNetworkMetrics()
+	guard := &crashGuard{model: model}
+	p := tea.NewProgram(guard)
+	_, err = p.Run()
+	endTrace(guard.model)
+	shutdown(guard.model, run, err)
+	return guard.crashed(err)
+}
+
+// endTrace ends the trace of the puzzle still open when the TUI exited.
+func endTrace(model tea.Model) {
+	if t, ok := model.(*msgTracer); ok {
+		model = t.model
+	}
+	if m, ok := model.(app.Model); ok {
+		m.EndTrace()
+	}
+}
+
+// traceFlushTimeout bounds how long exiting waits to send the last spans.
+const traceFlushTimeout = 5 * time.Second
+
+// Execute creates a root command and runs it, returning any error. Tracing
+// is set up first when the OTEL_* environment asks for it, and flushed after.
 func Execute() error {
+	shutdown, err := telemetry.Setup(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Tracing is off: %v\n", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+		defer cancel()
+		_ = shutdown(ctx)
+	}()
 	return NewRootCmd().Execute()
 }
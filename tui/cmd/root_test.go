@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"bytes"
+	"os"
+	"strings"
 	"testing"
 
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 )
 
@@ -50,6 +53,17 @@ func TestNewRootCmd_RandomFlagRegistered(t *testing.T) {
 	}
 }
 
+func TestNewRootCmd_CategoryFlagRegistered(t *testing.T) {
+	cmd := NewRootCmd()
+	flag := cmd.PersistentFlags().Lookup("category")
+	if flag == nil {
+		t.Fatal("expected --category persistent flag to be registered")
+	}
+	if flag.DefValue != "" {
+		t.Errorf("expected --category default to be empty, got %q", flag.DefValue)
+	}
+}
+
 func TestNewRootCmd_InsecureFlagRegistered(t *testing.T) {
 	cmd := NewRootCmd()
 	flag := cmd.PersistentFlags().Lookup("insecure")
@@ -81,3 +95,55 @@ func TestNewRootCmd_VersionSubcommandRegistered(t *testing.T) {
 		t.Error("expected 'version' subcommand to be registered")
 	}
 }
+
+func TestNewRootCmd_AccessibleFlagRegistered(t *testing.T) {
+	cmd := NewRootCmd()
+	flag := cmd.Flags().Lookup("accessible")
+	if flag == nil {
+		t.Fatal("expected --accessible flag to be registered")
+	}
+	if flag.DefValue != "false" {
+		t.Errorf("expected --accessible default to be %q, got %q", "false", flag.DefValue)
+	}
+}
+
+func TestNewRootCmd_TargetFlagRegistered(t *testing.T) {
+	cmd := NewRootCmd()
+	flag := cmd.Flags().Lookup("target")
+	if flag == nil {
+		t.Fatal("expected --target flag to be registered")
+	}
+	if flag.DefValue != "0s" {
+		t.Errorf("expected --target default to be %q, got %q", "0s", flag.DefValue)
+	}
+}
+
+func TestNewRootCmd_OutputFlagRegistered(t *testing.T) {
+	cmd := NewRootCmd()
+	flag := cmd.PersistentFlags().Lookup("output")
+	if flag == nil {
+		t.Fatal("expected --output persistent flag to be registered")
+	}
+	if flag.DefValue != "text" {
+		t.Errorf("expected --output default to be %q, got %q", "text", flag.DefValue)
+	}
+}
+
+func TestNewRootCmd_OutputFlagRejectsUnknownFormat(t *testing.T) {
+	_, err := executeCommand(NewRootCmd(), "version", "--output", "yaml")
+	if err == nil {
+		t.Error("expected an error for an unknown output format")
+	}
+}
+
+func TestRunTUI_RefusesWithoutTerminal(t *testing.T) {
+	if term.IsTerminal(os.Stdout.Fd()) {
+		t.Skip("stdout is a terminal")
+	}
+	for _, args := range [][]string{nil, {"practice"}, {"play", "--id", "game-001"}} {
+		_, err := executeCommand(NewRootCmd(), args...)
+		if err == nil || !strings.Contains(err.Error(), "solve --stdin") || !strings.Contains(err.Error(), "--force-tty") {
+			t.Errorf("unquote %v error = %v, want the UI refused with other commands suggested", args, err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/share"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// solvedSession finds the daily puzzle for date among the sessions solved on
+// this device.
+func solvedSession(date string) (*storage.GameSession, error) {
+	sessions, err := storage.Daily.ListSessions()
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	for i := range sessions {
+		s := &sessions[i]
+		if s.Date != date {
+			continue
+		}
+		switch {
+		case s.Revealed:
+			return nil, fmt.Errorf("you revealed the puzzle for %s, so there is no solve to share", date)
+		case !s.Solved:
+			return nil, fmt.Errorf("the puzzle for %s isn't solved yet", date)
+		case s.EncryptedText == "":
+			return nil, fmt.Errorf("the puzzle for %s was saved by an older version without its text", date)
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("no puzzle for %s was played on this device", date)
+}
+
+// sessionCells rebuilds a session's grid from its puzzle text, hints
+// and inputs.
+func sessionCells(s *storage.GameSession) []puzzle.Cell {
+	hints := make(map[rune]rune, len(s.Hints))
+	for cipher, plain := range s.Hints {
+		c, _ := utf8.DecodeRuneInString(cipher)
+		p, _ := utf8.DecodeRuneInString(plain)
+		if c != utf8.RuneError && p != utf8.RuneError {
+			hints[c] = p
+		}
+	}
+	cells := puzzle.BuildCells(s.EncryptedText, hints)
+	for i := range cells {
+		if cells[i].Kind != puzzle.CellLetter {
+			continue
+		}
+		if input, ok := s.Inputs[string(cells[i].Char)]; ok && input != "" {
+			r, _ := utf8.DecodeRuneInString(input)
+			puzzle.SetInput(cells, i, r)
+		}
+	}
+	return cells
+}
+
+// newShareCmd returns a command that shares a solved daily puzzle as text or
+// as an image of the solved grid.
+func newShareCmd(insecure *bool) *cobra.Command {
+	var image string
+
+	cmd := &cobra.Command{
+		Use:   "share [date]",
+		Short: "Share a solved puzzle as text or an image",
+		Long: "Share a daily puzzle you solved on this device. Without a date, shares today's.\n\n" +
+			"By default, copies the spoiler-free share text to the clipboard. With --image,\n" +
+			"writes a picture of the solved grid, your time and streak instead, as a PNG or,\n" +
+			"for a file ending in .svg, an SVG. The image shows the answer. Your streak is\n" +
+			"included when you have a claim code and the server can be reached.",
+		Example: "  # Copy today's result to the clipboard\n" +
+			"  unquote share\n\n" +
+			"  # An image of yesterday's solved grid\n" +
+			"  unquote share 2026-01-19 --image solved.png",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			date := cache.Today(time.Now().In(playerLocation()))
+			if len(args) == 1 {
+				if _, err := time.Parse(time.DateOnly, args[0]); err != nil {
+					return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", args[0])
+				}
+				date = args[0]
+			}
+			ext := strings.ToLower(filepath.Ext(image))
+			if image != "" && ext != ".png" && ext != ".svg" {
+				return errors.New("--image must name a .png or .svg file")
+			}
+
+			session, err := solvedSession(date)
+			if err != nil {
+				return err
+			}
+			data := share.SessionShareData{
+				PuzzleNumber: date,
+				Cells:        sessionCells(session),
+				CompletionMs: session.CompletionTime.Milliseconds(),
+				Solved:       true,
+			}
+			// The streak is a nicety; share without it when offline or unregistered
+			if stats, err := fetchStats(*insecure); err == nil {
+				data.Streak = stats.CurrentStreak
+			}
+
+			if image == "" {
+				if share.CopyToClipboard(share.FormatSessionText(data), cmd.OutOrStdout()) {
+					fmt.Fprintln(cmd.ErrOrStderr(), "Copied to clipboard!")
+				}
+				return nil
+			}
+
+			var buf bytes.Buffer
+			if ext == ".svg" {
+				buf.WriteString(share.GridCardSVG(data, ui.ActiveTheme()))
+			} else if err := png.Encode(&buf, share.GenerateGridCard(data, ui.ActiveTheme())); err != nil {
+				return fmt.Errorf("encoding image: %w", err)
+			}
+			if err := os.WriteFile(image, buf.Bytes(), 0o644); err != nil { //nolint:gosec // the image is meant to be shared
+				return fmt.Errorf("writing image: %w", err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %s\n", image)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&image, "image", "", "write an image of the solved grid to this .png or .svg file")
+
+	return cmd
+}
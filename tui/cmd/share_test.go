@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// saveSharedSession stores a daily session for 2026-01-15 as solved, or as
+// revealed when revealed is set.
+func saveSharedSession(t *testing.T, revealed bool) {
+	t.Helper()
+	setConfigHome(t)
+	storagetest.UseMemory(t)
+	err := storage.SaveSession(&storage.GameSession{
+		GameID:         "game-0115",
+		Date:           "2026-01-15",
+		EncryptedText:  "XM, MX",
+		Hints:          map[string]string{"X": "A"},
+		Inputs:         map[string]string{"M": "B"},
+		CompletionTime: 128 * time.Second,
+		Solved:         !revealed,
+		Revealed:       revealed,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestShareCmd_PNG(t *testing.T) {
+	saveSharedSession(t, false)
+
+	path := filepath.Join(t.TempDir(), "solved.png")
+	output, err := executeCommand(NewRootCmd(), "share", "2026-01-15", "--image", path)
+	if err != nil || !strings.Contains(output, "Wrote "+path) {
+		t.Fatalf("share = %q, %v", output, err)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // the test wrote it
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("the image is not a PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 1200 {
+		t.Errorf("image width = %d, want 1200", b.Dx())
+	}
+}
+
+func TestShareCmd_SVG(t *testing.T) {
+	saveSharedSession(t, false)
+
+	path := filepath.Join(t.TempDir(), "solved.svg")
+	if _, err := executeCommand(NewRootCmd(), "share", "2026-01-15", "--image", path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // the test wrote it
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"<svg", ">Solved in 2:08</text>", ">B</text>"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("SVG is missing %s", want)
+		}
+	}
+}
+
+func TestShareCmd_Rejects(t *testing.T) {
+	saveSharedSession(t, true)
+	dir := t.TempDir()
+
+	for _, args := range [][]string{
+		{"share", "15/01/2026"},
+		{"share", "2026-01-15", "--image", filepath.Join(dir, "solved.gif")},
+		{"share", "2026-01-15", "--image", filepath.Join(dir, "revealed.png")},
+		{"share", "2026-01-16", "--image", filepath.Join(dir, "unplayed.png")},
+	} {
+		if _, err := executeCommand(NewRootCmd(), args...); err == nil {
+			t.Errorf("%v should fail", args)
+		}
+	}
+}
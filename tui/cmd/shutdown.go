@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/app"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// startRun records that the TUI is starting, so a run that never gets to
+// shut down cleanly shows up in 'unquote doctor'. Best-effort: a marker that
+// can't be written never stops the game.
+func startRun() *storage.RunMarker {
+	r := &storage.RunMarker{StartedAt: time.Now(), PID: os.Getpid()}
+	_ = storage.SaveRunMarker(r)
+	return r
+}
+
+// shutdown finishes up after the program stopped on err, whether the player
+// quit or it got SIGINT or SIGTERM: the app saves its game, drains pending
+// uploads for a bounded time and closes its connections, then the run is
+// marked clean. A panic skips all of it; the crash guard saves the game, and
+// the run stays unclean.
+func shutdown(model tea.Model, run *storage.RunMarker, err error) {
+	if errors.Is(err, tea.ErrProgramPanic) {
+		return
+	}
+	if t, ok := model.(*msgTracer); ok {
+		model = t.model
+	}
+	if m, ok := model.(app.Model); ok {
+		_ = m.Shutdown(app.ShutdownUploadTimeout)
+	}
+	now := time.Now()
+	run.ShutdownAt = &now
+	_ = storage.SaveRunMarker(run)
+}
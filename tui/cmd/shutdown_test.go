@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+func TestShutdown_MarksRunClean(t *testing.T) {
+	setStatusHomes(t)
+	networkServer(t, true)
+
+	run := startRun()
+	if r, err := storage.LoadRunMarker(); err != nil || r == nil || r.Clean() || r.PID != os.Getpid() {
+		t.Fatalf("LoadRunMarker() after start = %+v, %v; want this run, not yet clean", r, err)
+	}
+	output, err := executeCommand(NewRootCmd(), "doctor")
+	if err != nil {
+		t.Fatalf("doctor error = %v", err)
+	}
+	if !strings.Contains(output, "didn't shut down cleanly") {
+		t.Errorf("doctor output missing the unclean run:\n%s", output)
+	}
+
+	shutdown(panickyModel{}, run, tea.ErrInterrupted)
+	if r, err := storage.LoadRunMarker(); err != nil || r == nil || !r.Clean() {
+		t.Fatalf("LoadRunMarker() after shutdown = %+v, %v; want the run marked clean", r, err)
+	}
+	output, err = executeCommand(NewRootCmd(), "doctor")
+	if err != nil {
+		t.Fatalf("doctor error = %v", err)
+	}
+	if !strings.Contains(output, "shut down cleanly") || strings.Contains(output, "didn't") {
+		t.Errorf("doctor output missing the clean run:\n%s", output)
+	}
+}
+
+// A panic leaves the run unclean; the crash guard has saved the game.
+func TestShutdown_SkipsPanics(t *testing.T) {
+	setStateHome(t)
+
+	run := startRun()
+	shutdown(panickyModel{}, run, fmt.Errorf("%w: boom", tea.ErrProgramPanic))
+	if r, err := storage.LoadRunMarker(); err != nil || r == nil || r.Clean() {
+		t.Errorf("LoadRunMarker() = %+v, %v; want the run left unclean", r, err)
+	}
+}
@@ -0,0 +1,392 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// mappingLine matches one cipher-to-plain letter pair, like "E=X", "e -> x" or
+// "E X": two letters separated by anything that isn't a letter.
+var mappingLine = regexp.MustCompile(`^([\pL])[^\pL]+([\pL])$`)
+
+// errIncorrectSolution is returned when the API rejects a solution.
+var errIncorrectSolution = errors.New("solution is incorrect")
+
+// puzzleOutput is the JSON form of the puzzle 'solve' prints without --stdin.
+type puzzleOutput struct {
+	GameID        string       `json:"gameId"`
+	Date          string       `json:"date"`
+	EncryptedText string       `json:"encryptedText"`
+	Author        string       `json:"author"`
+	Category      string       `json:"category"`
+	Hints         []hintOutput `json:"hints"`
+	Difficulty    int          `json:"difficulty"`
+}
+
+// hintOutput is one revealed letter in puzzleOutput.
+type hintOutput struct {
+	Cipher string `json:"cipher"`
+	Plain  string `json:"plain"`
+}
+
+// solveResult is the JSON form of a solution 'solve --stdin' accepted.
+// Recorded is false when the solve is waiting to sync or wasn't uploaded.
+type solveResult struct {
+	GameID           string `json:"gameId"`
+	Date             string `json:"date"`
+	CompletionTimeMs int64  `json:"completionTimeMs"`
+	AlreadySolved    bool   `json:"alreadySolved"`
+	Recorded         bool   `json:"recorded"`
+}
+
+// newSolveCmd returns a command that solves today's puzzle without the
+// interactive UI, for external solvers, screen-reader workflows and scripted
+// tests against staging servers.
+func newSolveCmd(insecure *bool, output *outputFormat) *cobra.Command {
+	var stdin bool
+
+	cmd := &cobra.Command{
+		Use:   "solve",
+		Short: "Solve today's puzzle from a script, without the interactive UI",
+		Long: "Solve today's puzzle from a script, without the interactive UI.\n\n" +
+			"Without --stdin, print the puzzle. With --stdin, read a solution and check it.\n" +
+			"The solution is either one cipher-to-plain pair per line (\"E=X\", \"E -> X\" or\n" +
+			"\"E X\"; hint letters may be left out), or the full plaintext, whose letters are\n" +
+			"matched to the puzzle's in order. A correct solve is saved like one made in the\n" +
+			"interactive UI and, with a claim code and stats enabled, recorded to your stats.\n" +
+			"Since the time it takes isn't a solving time, it is saved and recorded as an\n" +
+			"assisted solve, and shows up as one in history and stats.",
+		Example: "  # Show today's puzzle\n" +
+			"  unquote solve\n\n" +
+			"  # Check a letter mapping\n" +
+			"  printf 'E=T\\nQ=H\\n' | unquote solve --stdin\n\n" +
+			"  # Check a plaintext guess against a staging server\n" +
+			"  echo 'Hello, world' | UNQUOTE_API_URL=https://staging.example.com unquote solve --stdin",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			out := cmd.OutOrStdout()
+			fail := func(err error) error {
+				if *output == outputJSON {
+					return writeJSONError(out, "solve", err)
+				}
+				return err
+			}
+
+			client, err := api.NewClient(*insecure)
+			if err != nil {
+				return fail(fmt.Errorf("creating API client: %w", err))
+			}
+
+			started := time.Now()
+			p, err := client.FetchPuzzleByDate(cache.Today(started.In(playerLocation())))
+			if err != nil {
+				return fail(fmt.Errorf("fetching today's puzzle: %w", err))
+			}
+			sanitizePuzzle(p)
+
+			if !stdin {
+				if *output == outputJSON {
+					return writeJSON(out, "solve", newPuzzleOutput(p))
+				}
+				printPuzzle(out, p)
+				return nil
+			}
+
+			input, err := io.ReadAll(cmd.InOrStdin())
+			if err != nil {
+				return fail(fmt.Errorf("reading stdin: %w", err))
+			}
+
+			cells := puzzle.BuildCells(p.EncryptedText, hintMap(p))
+			if err := fillCells(cells, string(input)); err != nil {
+				return fail(err)
+			}
+
+			check, err := client.CheckSolution(p.ID, puzzle.AssembleSolution(cells))
+			if err != nil {
+				return fail(fmt.Errorf("checking solution: %w", err))
+			}
+			if !check.Correct {
+				return fail(errIncorrectSolution)
+			}
+
+			result, err := recordSolve(client, p, cells, time.Since(started), cmd.ErrOrStderr())
+			if err != nil {
+				return fail(err)
+			}
+
+			if *output == outputJSON {
+				return writeJSON(out, "solve", result)
+			}
+			printSolveResult(out, result)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&stdin, "stdin", false, "read a solution from stdin and check it")
+
+	return cmd
+}
+
+// sanitizePuzzle strips terminal escape sequences from the API's text fields.
+func sanitizePuzzle(p *api.Puzzle) {
+	p.Author = ui.SanitizeString(p.Author)
+	p.Category = ui.SanitizeString(p.Category)
+	p.EncryptedText = ui.SanitizeString(p.EncryptedText)
+	for i := range p.Hints {
+		p.Hints[i].CipherLetter = ui.SanitizeString(p.Hints[i].CipherLetter)
+		p.Hints[i].PlainLetter = ui.SanitizeString(p.Hints[i].PlainLetter)
+	}
+}
+
+// hintMap converts the puzzle's hints to the cipher-to-plain map BuildCells takes.
+func hintMap(p *api.Puzzle) map[rune]rune {
+	hints := make(map[rune]rune, len(p.Hints))
+	for _, h := range p.Hints {
+		if cipher, plain, ok := h.Letters(); ok {
+			hints[cipher] = plain
+		}
+	}
+	return hints
+}
+
+func newPuzzleOutput(p *api.Puzzle) puzzleOutput {
+	hints := make([]hintOutput, 0, len(p.Hints))
+	for _, h := range p.Hints {
+		hints = append(hints, hintOutput{Cipher: h.CipherLetter, Plain: h.PlainLetter})
+	}
+	return puzzleOutput{
+		GameID:        p.ID,
+		Date:          p.Date,
+		EncryptedText: p.EncryptedText,
+		Author:        p.Author,
+		Category:      p.Category,
+		Hints:         hints,
+		Difficulty:    p.Difficulty,
+	}
+}
+
+func printPuzzle(w io.Writer, p *api.Puzzle) {
+	fmt.Fprintf(w, "Puzzle for %s (%s)\n\n", p.Date, p.ID)
+	fmt.Fprintln(w, p.EncryptedText)
+	fmt.Fprintf(w, "— %s\n", p.Author)
+	if len(p.Hints) > 0 {
+		pairs := make([]string, 0, len(p.Hints))
+		for _, h := range p.Hints {
+			pairs = append(pairs, h.CipherLetter+"="+h.PlainLetter)
+		}
+		fmt.Fprintf(w, "\nHints: %s\n", strings.Join(pairs, ", "))
+	}
+	fmt.Fprintln(w, "\nPipe a solution to 'unquote solve --stdin' to check it.")
+}
+
+// fillCells fills the puzzle's letter cells from a solution: cipher-to-plain
+// pairs when every non-blank line is one, the full plaintext otherwise.
+func fillCells(cells []puzzle.Cell, input string) error {
+	var lines []string
+	for line := range strings.Lines(input) {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return errors.New("no solution on stdin")
+	}
+
+	var err error
+	if slices.IndexFunc(lines, func(l string) bool { return !mappingLine.MatchString(l) }) < 0 {
+		err = applyMapping(cells, lines)
+	} else {
+		err = applyPlaintext(cells, input)
+	}
+	if err != nil {
+		return err
+	}
+
+	if missing := unfilledLetters(cells); len(missing) > 0 {
+		return fmt.Errorf("no plain letter for %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// applyMapping fills cells from "E=X"-style lines. Pairs for hint letters are
+// ignored; the hint already fixes them.
+func applyMapping(cells []puzzle.Cell, lines []string) error {
+	for _, line := range lines {
+		m := mappingLine.FindStringSubmatch(line)
+		cipher, plain := []rune(m[1])[0], []rune(m[2])[0]
+
+		idx := slices.IndexFunc(cells, func(c puzzle.Cell) bool {
+			return c.Kind != puzzle.CellPunctuation && puzzle.NormalizeLetter(c.Char) == puzzle.NormalizeLetter(cipher)
+		})
+		if idx < 0 {
+			return fmt.Errorf("%q is not a letter in this puzzle", cipher)
+		}
+		if cells[idx].Kind == puzzle.CellHint {
+			continue
+		}
+		puzzle.SetInput(cells, idx, plain)
+	}
+	return nil
+}
+
+// applyPlaintext fills cells from the plaintext's letters, in order.
+// Punctuation and spacing in the plaintext don't have to match the puzzle's.
+func applyPlaintext(cells []puzzle.Cell, text string) error {
+	var letters []rune
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters = append(letters, puzzle.NormalizeLetter(r))
+		}
+	}
+
+	want := 0
+	for _, c := range cells {
+		if c.Kind != puzzle.CellPunctuation {
+			want++
+		}
+	}
+	if len(letters) != want {
+		return fmt.Errorf("plaintext has %d letters, the puzzle has %d", len(letters), want)
+	}
+
+	i := 0
+	for j := range cells {
+		if cells[j].Kind == puzzle.CellPunctuation {
+			continue
+		}
+		if cells[j].Kind == puzzle.CellLetter {
+			cells[j].Input = letters[i]
+		}
+		i++
+	}
+	return nil
+}
+
+// unfilledLetters lists the cipher letters that still have no input, in the
+// order they first appear.
+func unfilledLetters(cells []puzzle.Cell) []string {
+	var missing []string
+	for _, c := range cells {
+		if c.Kind == puzzle.CellLetter && c.Input == 0 && !slices.Contains(missing, string(c.Char)) {
+			missing = append(missing, string(c.Char))
+		}
+	}
+	return missing
+}
+
+// recordSolve saves a correct solve like the interactive UI does and uploads
+// it when a claim code is stored and stats are enabled. The answer came from
+// stdin, so the solve is marked Headless: an assisted solve, kept out of the
+// clean times its sub-second duration would top. A puzzle already solved on
+// this device is left as it was.
+func recordSolve(client *api.Client, p *api.Puzzle, cells []puzzle.Cell, elapsed time.Duration, warn io.Writer) (solveResult, error) {
+	result := solveResult{GameID: p.ID, Date: p.Date}
+
+	// Time spent on this puzzle in the interactive UI counts toward the solve
+	existing, err := storage.LoadSession(p.ID)
+	if err != nil {
+		return result, fmt.Errorf("loading session: %w", err)
+	}
+	if existing != nil {
+		if existing.Solved {
+			result.AlreadySolved = true
+			result.CompletionTimeMs = existing.CompletionTime.Milliseconds()
+			return result, nil
+		}
+		elapsed += existing.ElapsedTime
+	}
+	result.CompletionTimeMs = elapsed.Milliseconds()
+
+	inputs := make(map[string]string)
+	for _, c := range cells {
+		if c.Kind == puzzle.CellLetter && c.Input != 0 {
+			inputs[string(c.Char)] = string(c.Input)
+		}
+	}
+	hints := make(map[string]string, len(p.Hints))
+	for cipher, plain := range hintMap(p) {
+		hints[string(cipher)] = string(plain)
+	}
+	solvedAt := time.Now()
+	assists := storage.Assists{Headless: true}
+	if existing != nil {
+		// Help had in the interactive UI counts too
+		assists = existing.Assists
+		assists.Headless = true
+	}
+	session := &storage.GameSession{
+		GameID:         p.ID,
+		Date:           p.Date,
+		EncryptedText:  p.EncryptedText,
+		Author:         p.Author,
+		Category:       p.Category,
+		Difficulty:     p.Difficulty,
+		Hints:          hints,
+		Inputs:         inputs,
+		ElapsedTime:    elapsed,
+		CompletionTime: elapsed,
+		SolvedAt:       &solvedAt,
+		Solved:         true,
+		AssistLevel:    assists.Level(),
+		Assists:        assists,
+	}
+	if err := storage.SaveSession(session); err != nil {
+		return result, fmt.Errorf("saving session: %w", err)
+	}
+
+	result.Recorded = uploadSolve(client, p.ID, elapsed, solvedAt, assists, warn)
+	return result, nil
+}
+
+// uploadSolve records a solve saved by recordSolve to the player's stats and
+// marks it sent through the storage journal. It reports whether the server
+// accepted it; failures are reported on warn and left for the next
+// interactive run to sync.
+func uploadSolve(client *api.Client, gameID string, elapsed time.Duration, solvedAt time.Time, assists storage.Assists, warn io.Writer) bool {
+	cfg, err := config.Load()
+	if err != nil || cfg == nil || cfg.ClaimCode == "" || !cfg.StatsEnabled {
+		return false
+	}
+	upload := api.Assists{
+		AssistLevel:     api.AssistLevel(assists.Level()),
+		HintsUsed:       assists.HintsUsed,
+		AutoCheckUsed:   assists.AutoCheckUsed,
+		RevealUsed:      assists.RevealUsed,
+		SuggestionsUsed: assists.SuggestionsUsed,
+	}
+	if _, err := client.RecordSession(cfg.ClaimCode, gameID, elapsed.Milliseconds(), solvedAt, upload); err != nil {
+		fmt.Fprintf(warn, "Warning: could not record the solve, it will sync the next time you play: %v\n", err)
+		return false
+	}
+	if err := storage.MarkUploaded(gameID); err != nil {
+		fmt.Fprintf(warn, "Warning: the solve was recorded but couldn't be marked as sent, it will sync the next time you play: %v\n", err)
+	}
+	return true
+}
+
+func printSolveResult(w io.Writer, r solveResult) {
+	solveTime := formatMs(float64(r.CompletionTimeMs))
+	switch {
+	case r.AlreadySolved:
+		fmt.Fprintf(w, "Correct! You already solved this puzzle in %s; nothing new was recorded.\n", solveTime)
+	case r.Recorded:
+		fmt.Fprintf(w, "Solved in %s! Recorded to your stats as an assisted solve.\n", solveTime)
+	default:
+		fmt.Fprintf(w, "Solved in %s!\n", solveTime)
+	}
+}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// solveRecorder counts the sessions recorded to solveServer and keeps the
+// last one.
+type solveRecorder struct {
+	last  api.RecordSessionRequest
+	mu    sync.Mutex
+	count atomic.Int32
+}
+
+// Load returns how many sessions were recorded.
+func (r *solveRecorder) Load() int32 {
+	return r.count.Load()
+}
+
+// lastRequest returns the last session recorded.
+func (r *solveRecorder) lastRequest() api.RecordSessionRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
+
+// solveServer serves today's puzzle "XM, MX" (answer "AB, BA") and records the
+// sessions recorded to it.
+func solveServer(t *testing.T) *solveRecorder {
+	t.Helper()
+	recorded := &solveRecorder{}
+	today := cache.Today(time.Now())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/game/" + today:
+			json.NewEncoder(w).Encode(api.Puzzle{ID: "game-1", Date: "2026-01-20", EncryptedText: "XM, MX", Author: "Anon"})
+		case "/game/game-1/check":
+			var req api.CheckRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(api.CheckResponse{Correct: puzzle.SolutionMatches("AB, BA", req.Solution)})
+		case "/player/TIGER-MAPLE-7492/session":
+			recorded.mu.Lock()
+			json.NewDecoder(r.Body).Decode(&recorded.last)
+			recorded.mu.Unlock()
+			recorded.count.Add(1)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(api.RecordSessionResponse{Status: "created"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Setenv("UNQUOTE_API_URL", srv.URL)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+	return recorded
+}
+
+// executeSolve runs 'solve --stdin' with the given input.
+func executeSolve(input string, args ...string) (string, error) {
+	root := NewRootCmd()
+	root.SetIn(strings.NewReader(input))
+	return executeCommand(root, append([]string{"solve", "--insecure", "--stdin"}, args...)...)
+}
+
+func TestSolveCmd_PrintsPuzzle(t *testing.T) {
+	solveServer(t)
+
+	output, err := executeCommand(NewRootCmd(), "solve", "--insecure")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"2026-01-20", "XM, MX", "— Anon", "solve --stdin"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestSolveCmd_MappingRecordsSession(t *testing.T) {
+	recorded := solveServer(t)
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492", StatsEnabled: true}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+
+	output, err := executeSolve("X=A\nm -> b\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Recorded to your stats") {
+		t.Errorf("output = %q", output)
+	}
+	if recorded.Load() != 1 {
+		t.Errorf("recorded %d sessions, want 1", recorded.Load())
+	}
+
+	session, err := storage.LoadSession("game-1")
+	if err != nil || session == nil || !session.Solved || !session.Uploaded || session.Inputs["X"] != "A" {
+		t.Errorf("LoadSession() = %+v, %v; want a solved, uploaded session", session, err)
+	}
+
+	// A second solve of the same puzzle changes nothing
+	output, err = executeSolve("AB BA")
+	if err != nil || !strings.Contains(output, "already solved") {
+		t.Errorf("second solve = %q, %v", output, err)
+	}
+	if recorded.Load() != 1 {
+		t.Errorf("recorded %d sessions after a repeat solve, want 1", recorded.Load())
+	}
+}
+
+// A recorded solve that can't be journaled as sent is still a success; the
+// next interactive run syncs it.
+func TestSolveCmd_WarnsWhenMarkingUploadedFails(t *testing.T) {
+	solveServer(t)
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492", StatsEnabled: true}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+	// A directory where the upload journal goes makes appending to it fail
+	if err := os.MkdirAll(filepath.Join(xdg.StateHome, "unquote", "uploads.journal"), 0o755); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	output, err := executeSolve("AB, BA")
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, output)
+	}
+	for _, want := range []string{"Recorded to your stats", "couldn't be marked as sent"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestSolveCmd_RecordsPipedSolveAsAssisted(t *testing.T) {
+	recorded := solveServer(t)
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492", StatsEnabled: true}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+
+	if output, err := executeSolve("AB, BA"); err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, output)
+	}
+	// Piping the answer in takes milliseconds; that's no clean solve time
+	if req := recorded.lastRequest(); req.AssistLevel != api.AssistHeavy {
+		t.Errorf("uploaded %+v, want the %dms solve marked %q", req, req.CompletionTime, api.AssistHeavy)
+	}
+
+	session, err := storage.LoadSession("game-1")
+	if err != nil || session == nil || !session.Headless || session.AssistLevel != storage.AssistHeavy {
+		t.Errorf("LoadSession() = %+v, %v; want a headless, assisted session", session, err)
+	}
+}
+
+func TestSolveCmd_StatsDisabledDoesNotUpload(t *testing.T) {
+	recorded := solveServer(t)
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492"}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+
+	output, err := executeSolve("AB, BA")
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, output)
+	}
+	if recorded.Load() != 0 || strings.Contains(output, "Recorded") {
+		t.Errorf("recorded %d sessions with stats disabled, want none:\n%s", recorded.Load(), output)
+	}
+}
+
+func TestSolveCmd_PlaintextWithoutClaimCode(t *testing.T) {
+	recorded := solveServer(t)
+
+	output, err := executeSolve("ab, ba", "--output", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, output)
+	}
+
+	data, _ := decodeEnvelope(t, output)["data"].(map[string]any)
+	if data["gameId"] != "game-1" || data["recorded"] != false || data["alreadySolved"] != false {
+		t.Errorf("data = %v", data)
+	}
+	if recorded.Load() != 0 {
+		t.Error("a solve without a claim code should not be recorded")
+	}
+	if session, _ := storage.LoadSession("game-1"); session == nil || !session.Solved {
+		t.Error("the solve should still be saved locally")
+	}
+}
+
+func TestSolveCmd_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "wrong answer", input: "BA BA", want: "solution is incorrect"},
+		{name: "missing letter", input: "X=A", want: "no plain letter for M"},
+		{name: "unknown letter", input: "X=A\nQ=B", want: `'Q' is not a letter`},
+		{name: "wrong length", input: "ABC", want: "plaintext has 3 letters, the puzzle has 4"},
+		{name: "empty", input: "\n", want: "no solution on stdin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solveServer(t)
+
+			_, err := executeSolve(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.want)
+			}
+			if session, _ := storage.LoadSession("game-1"); session != nil {
+				t.Error("a failed solve should not be saved")
+			}
+		})
+	}
+}
+
+func TestFillCells_SkipsHints(t *testing.T) {
+	cells := puzzle.BuildCells("XMQ", map[rune]rune{'Q': 'C'})
+
+	if err := fillCells(cells, "X=A\nM=B\nQ=Z\n"); err != nil {
+		t.Fatal(err)
+	}
+	if got := puzzle.AssembleSolution(cells); got != "ABC" {
+		t.Errorf("mapping filled %q, want the hint kept", got)
+	}
+
+	puzzle.ClearAllInput(cells)
+	if err := fillCells(cells, "a b z"); err != nil {
+		t.Fatal(err)
+	}
+	if got := puzzle.AssembleSolution(cells); got != "ABC" {
+		t.Errorf("plaintext filled %q, want the hint kept", got)
+	}
+}
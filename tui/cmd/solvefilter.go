@@ -0,0 +1,43 @@
+package cmd
+
+import "fmt"
+
+// solveFilter is the value of the --solves flag of history and stats, which
+// keeps only clean or only assisted solves. It implements pflag.Value so
+// unknown values are rejected while flags are parsed.
+type solveFilter string
+
+const (
+	solvesAll      solveFilter = "all"
+	solvesClean    solveFilter = "clean"
+	solvesAssisted solveFilter = "assisted"
+)
+
+func (f *solveFilter) String() string { return string(*f) }
+
+func (f *solveFilter) Set(s string) error {
+	switch solveFilter(s) {
+	case solvesAll, solvesClean, solvesAssisted:
+		*f = solveFilter(s)
+		return nil
+	default:
+		return fmt.Errorf("must be %q, %q or %q", solvesAll, solvesClean, solvesAssisted)
+	}
+}
+
+func (f *solveFilter) Type() string { return "solves" }
+
+// keeps reports whether a solve passes the filter.
+func (f solveFilter) keeps(assisted bool) bool {
+	switch f {
+	case solvesClean:
+		return !assisted
+	case solvesAssisted:
+		return assisted
+	default:
+		return true
+	}
+}
+
+// solveFilterUsage describes --solves.
+const solveFilterUsage = "which solves to include: all, clean (no assists) or assisted"
@@ -3,8 +3,9 @@ package cmd
 import (
 	"errors"
 	"fmt"
-	"math"
+	"slices"
 	"strings"
+	"time"
 
 	"charm.land/lipgloss/v2"
 	"github.com/guptarohit/asciigraph"
@@ -13,6 +14,7 @@ import (
 	"github.com/bojanrajkovic/unquote/tui/internal/api"
 	"github.com/bojanrajkovic/unquote/tui/internal/config"
 	"github.com/bojanrajkovic/unquote/tui/internal/share"
+	"github.com/bojanrajkovic/unquote/tui/internal/statsdiff"
 	"github.com/bojanrajkovic/unquote/tui/internal/ui"
 )
 
@@ -30,34 +32,101 @@ func formatOptMs(ms *float64) string {
 	return formatMs(*ms)
 }
 
+// statsOutput is the JSON form of the stats command. It is kept separate from
+// the API response so the CLI's output stays stable if the API changes.
+type statsOutput struct {
+	BestTimeMs    *float64      `json:"bestTimeMs"`
+	AverageTimeMs *float64      `json:"averageTimeMs"`
+	CleanSolves   *int          `json:"cleanSolves,omitempty"`
+	ClaimCode     string        `json:"claimCode"`
+	RecentSolves  []solveOutput `json:"recentSolves"`
+	WinRate       float64       `json:"winRate"`
+	GamesPlayed   int           `json:"gamesPlayed"`
+	GamesSolved   int           `json:"gamesSolved"`
+	CurrentStreak int           `json:"currentStreak"`
+	BestStreak    int           `json:"bestStreak"`
+}
+
+// solveOutput is one recent solve in statsOutput.
+type solveOutput struct {
+	Date             string          `json:"date"`
+	AssistLevel      api.AssistLevel `json:"assistLevel,omitempty"` // empty for clean solves
+	CompletionTimeMs float64         `json:"completionTimeMs"`
+}
+
+func newStatsOutput(stats *api.PlayerStatsResponse) statsOutput {
+	solves := make([]solveOutput, 0, len(stats.RecentSolves))
+	for _, s := range stats.RecentSolves {
+		solves = append(solves, solveOutput{Date: s.Date, AssistLevel: s.AssistLevel, CompletionTimeMs: s.CompletionTime})
+	}
+	return statsOutput{
+		ClaimCode:     stats.ClaimCode,
+		GamesPlayed:   stats.GamesPlayed,
+		GamesSolved:   stats.GamesSolved,
+		WinRate:       stats.WinRate,
+		CurrentStreak: stats.CurrentStreak,
+		BestStreak:    stats.BestStreak,
+		BestTimeMs:    stats.BestTime,
+		AverageTimeMs: stats.AverageTime,
+		CleanSolves:   stats.CleanSolves,
+		RecentSolves:  solves,
+	}
+}
+
+// filterRecentSolves drops the recent solves filter leaves out. The totals
+// come from the server and count every solve either way.
+func filterRecentSolves(stats *api.PlayerStatsResponse, filter solveFilter) {
+	stats.RecentSolves = slices.DeleteFunc(stats.RecentSolves, func(s api.RecentSolve) bool {
+		return !filter.keeps(s.AssistLevel != api.AssistNone)
+	})
+}
+
 // newStatsCmd returns a command that fetches and prints player stats to stdout.
-func newStatsCmd(insecure *bool) *cobra.Command {
+func newStatsCmd(insecure *bool, output *outputFormat) *cobra.Command {
 	var shareFlag bool
 	var imageFlag bool
+	var networkFlag bool
+	solves := solvesAll
 
 	cmd := &cobra.Command{
 		Use:   "stats",
 		Short: "View your player statistics",
+		Long: "View your player statistics: games played and solved, streaks, best and\n" +
+			"average times, and a graph of recent solve times. Requires a claim code\n" +
+			"from 'unquote register' or 'unquote link'. --solves clean or --solves assisted\n" +
+			"limits the recent solves and the graph to solves without or with assists.",
+		Example: "  # Print your stats\n" +
+			"  unquote stats\n\n" +
+			"  # Copy a shareable summary and image card to the clipboard\n" +
+			"  unquote stats --share --image\n\n" +
+			"  # Current streak, for a status bar widget\n" +
+			"  unquote stats --output json | jq .data.currentStreak\n\n" +
+			"  # Graph only the solves you had no help with\n" +
+			"  unquote stats --solves clean",
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			cfg, err := config.Load()
-			if err != nil {
-				return fmt.Errorf("loading config: %w", err)
+			if networkFlag {
+				return runNetworkDiagnostics(cmd.OutOrStdout(), *insecure, *output)
 			}
-
-			if cfg == nil || cfg.ClaimCode == "" {
-				fmt.Fprintln(cmd.ErrOrStderr(), "No claim code found. Run 'unquote register' first.")
-				return errors.New("no claim code")
+			if *output == outputJSON {
+				if shareFlag {
+					return errors.New("--share can't be combined with --output json")
+				}
+				stats, err := fetchStats(*insecure)
+				if err != nil {
+					return writeJSONError(cmd.OutOrStdout(), "stats", err)
+				}
+				filterRecentSolves(stats, solves)
+				return writeJSON(cmd.OutOrStdout(), "stats", newStatsOutput(stats))
 			}
 
-			client, err := api.NewClient(*insecure)
-			if err != nil {
-				return fmt.Errorf("creating API client: %w", err)
+			stats, err := fetchStats(*insecure)
+			if errors.Is(err, errNoClaimCode) {
+				fmt.Fprintln(cmd.ErrOrStderr(), "No claim code found. Run 'unquote register' first.")
 			}
-
-			stats, err := client.FetchStats(cfg.ClaimCode)
 			if err != nil {
-				return fmt.Errorf("fetching stats: %w", err)
+				return err
 			}
+			filterRecentSolves(stats, solves)
 
 			if shareFlag {
 				text := share.FormatStatsText(stats)
@@ -78,6 +147,7 @@ func newStatsCmd(insecure *bool) *cobra.Command {
 			}
 
 			out := cmd.OutOrStdout()
+			useOutputPalette(out)
 			fmt.Fprintln(out, renderStatsOutput(stats))
 			return nil
 		},
@@ -85,14 +155,292 @@ func newStatsCmd(insecure *bool) *cobra.Command {
 
 	cmd.Flags().BoolVar(&shareFlag, "share", false, "Copy stats as shareable text to clipboard")
 	cmd.Flags().BoolVar(&imageFlag, "image", false, "Generate and copy branded PNG image (use with --share)")
+	// A diagnostic for bug reports, not part of the stats proper
+	cmd.Flags().BoolVar(&networkFlag, "network", false, "probe the API and report request counts and latencies per endpoint")
+	_ = cmd.Flags().MarkHidden("network")
+	cmd.Flags().Var(&solves, "solves", solveFilterUsage)
+
+	cmd.AddCommand(newStatsCompareCmd(insecure, output))
 
 	return cmd
 }
 
+// compareOutput is the JSON form of the stats compare command, one entry per
+// claim code in the order given.
+type compareOutput struct {
+	Players []comparePlayerOutput `json:"players"`
+}
+
+// comparePlayerOutput is one player in compareOutput. Stats is null and
+// Error set when their stats failed to load.
+type comparePlayerOutput struct {
+	Stats     *statsOutput `json:"stats"`
+	ClaimCode string       `json:"claimCode"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// newStatsCompareCmd returns a command that compares two players' stats side
+// by side.
+func newStatsCompareCmd(insecure *bool, output *outputFormat) *cobra.Command {
+	return &cobra.Command{
+		Use:   "compare [code1] <code2>",
+		Short: "Compare two players' stats side by side",
+		Long: "Compare two players' stats side by side, with the difference between them and\n" +
+			"both players' recent solve times on one graph. With one claim code, compares\n" +
+			"your own stats with that player's; no friends list needed.\n\n" +
+			"When only one player's stats load, they are shown with a warning.",
+		Example: "  # You against a rival\n" +
+			"  unquote stats compare OTTER-RIVER-1234\n\n" +
+			"  # Two other players\n" +
+			"  unquote stats compare TIGER-MAPLE-7492 OTTER-RIVER-1234",
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			codes, err := compareCodes(args)
+			if err != nil {
+				return err
+			}
+			client, err := api.NewClient(*insecure)
+			if err != nil {
+				return fmt.Errorf("creating API client: %w", err)
+			}
+			stats, fetchErr := client.FetchStatsForCodes(codes)
+
+			players := make([]comparePlayerOutput, len(codes))
+			for i, code := range codes {
+				players[i].ClaimCode = code
+				if stats[i] == nil {
+					players[i].Error = "couldn't load stats"
+					continue
+				}
+				out := newStatsOutput(stats[i])
+				players[i].Stats = &out
+			}
+			if stats[0] == nil && stats[1] == nil {
+				err := fmt.Errorf("fetching stats: %w", fetchErr)
+				if *output == outputJSON {
+					return writeJSONError(cmd.OutOrStdout(), "stats compare", err)
+				}
+				return err
+			}
+
+			if *output == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), "stats compare", compareOutput{Players: players})
+			}
+			if fetchErr != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: couldn't load all stats: %v\n", fetchErr)
+			}
+			useOutputPalette(cmd.OutOrStdout())
+			fmt.Fprintln(cmd.OutOrStdout(), renderStatsComparison(codes, stats, time.Now()))
+			return nil
+		},
+	}
+}
+
+// compareCodes returns the two claim codes to compare. With one argument the
+// first is this device's own.
+func compareCodes(args []string) ([]string, error) {
+	codes := make([]string, 0, 2)
+	if len(args) == 1 {
+		cfg, err := config.Load()
+		if err != nil {
+			return nil, fmt.Errorf("loading config: %w", err)
+		}
+		if cfg == nil || cfg.ClaimCode == "" {
+			return nil, errors.New("no claim code of your own to compare with; pass two claim codes or run 'unquote register'")
+		}
+		codes = append(codes, cfg.ClaimCode)
+	}
+	for _, arg := range args {
+		code, err := parseClaimCode(arg)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	if codes[0] == codes[1] {
+		return nil, fmt.Errorf("can't compare %s with itself", codes[0])
+	}
+	return codes, nil
+}
+
+// renderStatsComparison renders two players' stats side by side with the
+// first player's difference from the second, then their recent solve times
+// on one graph. A player whose stats failed to load is nil and shows dashes.
+func renderStatsComparison(codes []string, stats []*api.PlayerStatsResponse, now time.Time) string {
+	labelStyle := lipgloss.NewStyle().Foreground(ui.ColorMuted).Width(16)
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorText)
+	width := max(lipgloss.Width(codes[0]), lipgloss.Width(codes[1]))
+	columnStyles := []lipgloss.Style{
+		lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Width(width),
+		lipgloss.NewStyle().Foreground(ui.ColorSecondary).Bold(true).Width(width),
+	}
+	a, b := stats[0], stats[1]
+
+	value := func(s *api.PlayerStatsResponse, f func(*api.PlayerStatsResponse) string) string {
+		if s == nil {
+			return "—"
+		}
+		return f(s)
+	}
+	count := func(f func(*api.PlayerStatsResponse) int) compareRow {
+		r := compareRow{
+			a: value(a, func(s *api.PlayerStatsResponse) string { return fmt.Sprintf("%d", f(s)) }),
+			b: value(b, func(s *api.PlayerStatsResponse) string { return fmt.Sprintf("%d", f(s)) }),
+		}
+		if a != nil && b != nil {
+			r.delta = fmt.Sprintf("%+d", f(a)-f(b))
+		}
+		return r
+	}
+	duration := func(f func(*api.PlayerStatsResponse) *float64) compareRow {
+		r := compareRow{
+			a: value(a, func(s *api.PlayerStatsResponse) string { return formatOptMs(f(s)) }),
+			b: value(b, func(s *api.PlayerStatsResponse) string { return formatOptMs(f(s)) }),
+		}
+		if a != nil && b != nil {
+			r.delta = timeDelta(f(a), f(b))
+		}
+		return r
+	}
+
+	winRate := compareRow{
+		a: value(a, func(s *api.PlayerStatsResponse) string { return fmt.Sprintf("%.1f%%", s.WinRate*100) }),
+		b: value(b, func(s *api.PlayerStatsResponse) string { return fmt.Sprintf("%.1f%%", s.WinRate*100) }),
+	}
+	if a != nil && b != nil {
+		winRate.delta = fmt.Sprintf("%+.1f%%", (a.WinRate-b.WinRate)*100)
+	}
+	rows := []struct {
+		label string
+		compareRow
+	}{
+		{"Games Played", count(func(s *api.PlayerStatsResponse) int { return s.GamesPlayed })},
+		{"Games Solved", count(func(s *api.PlayerStatsResponse) int { return s.GamesSolved })},
+		{"Win Rate", winRate},
+		{"Current Streak", count(func(s *api.PlayerStatsResponse) int { return s.CurrentStreak })},
+		{"Best Streak", count(func(s *api.PlayerStatsResponse) int { return s.BestStreak })},
+		{"Best Time", duration(func(s *api.PlayerStatsResponse) *float64 { return s.BestTime })},
+		{"Avg Time", duration(func(s *api.PlayerStatsResponse) *float64 { return s.AverageTime })},
+	}
+
+	var out strings.Builder
+	out.WriteString(headerStyle.Render("CRYPTO-QUIP STATS: " + codes[0] + " VS " + codes[1]))
+	out.WriteString("\n\n")
+	fmt.Fprintf(&out, "  %s  %s  %s  %s\n", labelStyle.Render(""),
+		columnStyles[0].Render(codes[0]), columnStyles[1].Render(codes[1]), labelStyle.Render("Difference"))
+	for _, r := range rows {
+		fmt.Fprintf(&out, "  %s  %s  %s  %s\n", labelStyle.Render(r.label),
+			columnStyles[0].Render(r.a), columnStyles[1].Render(r.b), r.delta)
+	}
+
+	// Both players' solve times over the same 30 days, so the lines line up
+	const dayWindow = 30
+	end := latestSolveDay(now, stats)
+	var series [][]float64
+	var legends []string
+	var colors []asciigraph.AnsiColor
+	for i, s := range stats {
+		if s == nil {
+			continue
+		}
+		if points, hasData := statsdiff.DailySolveMinutes(s.RecentSolves, end, dayWindow); hasData {
+			series = append(series, points)
+			legends = append(legends, codes[i])
+			colors = append(colors, compareSeriesColors[i])
+		}
+	}
+	if len(series) > 0 {
+		plot := asciigraph.PlotMany(
+			series,
+			asciigraph.Height(8),
+			asciigraph.Width(50),
+			asciigraph.Precision(1),
+			asciigraph.LowerBound(0),
+			asciigraph.SeriesColors(colors...),
+			asciigraph.SeriesLegends(legends...),
+			asciigraph.Caption("Solve Times (last 30 days, minutes)"),
+		)
+		out.WriteString("\n")
+		out.WriteString(plot)
+		out.WriteString("\n")
+	}
+
+	return out.String()
+}
+
+// compareSeriesColors are the graph colors of the first and second player,
+// matching their columns (ANSI 63 and 86, ui's primary and secondary).
+var compareSeriesColors = []asciigraph.AnsiColor{asciigraph.AnsiColor(63), asciigraph.AnsiColor(86)}
+
+// compareRow is one statistic for both players and the difference between
+// them; the difference is empty unless both loaded.
+type compareRow struct {
+	a, b, delta string
+}
+
+// timeDelta describes the first of two times relative to the second, e.g.
+// "0:25 faster". Empty when either is missing.
+func timeDelta(a, b *float64) string {
+	if a == nil || b == nil {
+		return ""
+	}
+	switch diff := *b - *a; {
+	case diff > 0:
+		return formatMs(diff) + " faster"
+	case diff < 0:
+		return formatMs(-diff) + " slower"
+	default:
+		return "even"
+	}
+}
+
+// latestSolveDay returns the later of now and the last day any of the
+// players solved, so both graphs end on the same day.
+func latestSolveDay(now time.Time, stats []*api.PlayerStatsResponse) time.Time {
+	end := now
+	for _, s := range stats {
+		if s == nil {
+			continue
+		}
+		for _, solve := range s.RecentSolves {
+			if d, err := time.Parse(time.DateOnly, solve.Date); err == nil && d.After(end) {
+				end = d
+			}
+		}
+	}
+	return end
+}
+
+// errNoClaimCode is returned by fetchStats when this device has not registered.
+var errNoClaimCode = errors.New("no claim code")
+
+// fetchStats loads the stored claim code and fetches its stats from the API.
+func fetchStats(insecure bool) (*api.PlayerStatsResponse, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	if cfg == nil || cfg.ClaimCode == "" {
+		return nil, errNoClaimCode
+	}
+
+	client, err := api.NewClient(insecure)
+	if err != nil {
+		return nil, fmt.Errorf("creating API client: %w", err)
+	}
+
+	stats, err := client.FetchStats(cfg.ClaimCode)
+	if err != nil {
+		return nil, fmt.Errorf("fetching stats: %w", err)
+	}
+	return stats, nil
+}
+
 func renderStatsOutput(stats *api.PlayerStatsResponse) string {
 	labelStyle := lipgloss.NewStyle().Foreground(ui.ColorMuted)
 	valueStyle := lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true)
-	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorWhite)
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorText)
 
 	var b strings.Builder
 
@@ -109,6 +457,9 @@ func renderStatsOutput(stats *api.PlayerStatsResponse) string {
 		{"Best Time", formatOptMs(stats.BestTime)},
 		{"Avg Time", formatOptMs(stats.AverageTime)},
 	}
+	if stats.CleanSolves != nil {
+		rows = append(rows, struct{ label, value string }{"Clean Solves", fmt.Sprintf("%d of %d", *stats.CleanSolves, stats.GamesSolved)})
+	}
 
 	for _, r := range rows {
 		fmt.Fprintf(
@@ -118,31 +469,17 @@ func renderStatsOutput(stats *api.PlayerStatsResponse) string {
 		)
 	}
 
-	// Solve-time graph (last 30 days)
+	// Solve-time graph (last 30 calendar days)
 	const dayWindow = 30
-	n := len(stats.RecentSolves)
-	if n > 0 {
-		if n > dayWindow {
-			n = dayWindow
-		}
-
-		points := make([]float64, dayWindow)
-		for i := range dayWindow {
-			points[i] = math.NaN()
-		}
-
-		offset := dayWindow - n
-		for i := range n {
-			points[offset+i] = stats.RecentSolves[len(stats.RecentSolves)-n+i].CompletionTime / 60000.0
-		}
-
+	points, hasData := statsdiff.DailySolveMinutes(stats.RecentSolves, time.Now(), dayWindow)
+	if hasData {
 		plot := asciigraph.Plot(
 			points,
 			asciigraph.Height(8),
 			asciigraph.Width(50),
 			asciigraph.Precision(1),
 			asciigraph.LowerBound(0),
-			asciigraph.Caption("Solve Times (last 30 days, minutes)"),
+			asciigraph.Caption(statsdiff.SolveTimesCaption(stats.RecentSolves, dayWindow)),
 		)
 
 		b.WriteString("\n")
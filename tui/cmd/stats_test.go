@@ -1,11 +1,13 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/adrg/xdg"
 
@@ -76,7 +78,7 @@ func TestStatsCmd_PrintsStats(t *testing.T) {
 		BestTime:      &bestTime,
 		AverageTime:   &avgTime,
 		RecentSolves: []api.RecentSolve{
-			{Date: "2026-02-15", CompletionTime: 128000},
+			{Date: time.Now().Format("2006-01-02"), CompletionTime: 128000},
 		},
 	}
 
@@ -110,6 +112,78 @@ func TestStatsCmd_PrintsStats(t *testing.T) {
 	}
 }
 
+// TestStatsCmd_JSON verifies --output json prints the stats in the shared envelope.
+func TestStatsCmd_JSON(t *testing.T) {
+	bestTime := 128000.0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(api.PlayerStatsResponse{
+			ClaimCode:     "TIGER-MAPLE-7492",
+			GamesPlayed:   42,
+			CurrentStreak: 5,
+			BestTime:      &bestTime,
+			RecentSolves: []api.RecentSolve{
+				{Date: "2026-01-19", AssistLevel: api.AssistHeavy, CompletionTime: 60000},
+				{Date: "2026-01-20", CompletionTime: 128000},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	t.Setenv("UNQUOTE_API_URL", srv.URL)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+
+	if err := config.Save(&config.Config{StatsEnabled: true, ClaimCode: "TIGER-MAPLE-7492"}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "stats", "--insecure", "--output", "json", "--solves", "clean")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	env := decodeEnvelope(t, output)
+	data, _ := env["data"].(map[string]any)
+	if env["command"] != "stats" || data["gamesPlayed"] != 42.0 || data["currentStreak"] != 5.0 {
+		t.Errorf("envelope = %v", env)
+	}
+	if data["bestTimeMs"] != 128000.0 || data["averageTimeMs"] != nil {
+		t.Errorf("times = %v, %v; want 128000 and null", data["bestTimeMs"], data["averageTimeMs"])
+	}
+	solves, _ := data["recentSolves"].([]any)
+	if len(solves) != 1 || solves[0].(map[string]any)["completionTimeMs"] != 128000.0 {
+		t.Errorf("recentSolves = %v", data["recentSolves"])
+	}
+}
+
+// TestStatsCmd_JSONNoClaimCode verifies errors are reported in the envelope on stdout.
+func TestStatsCmd_JSONNoClaimCode(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+
+	var stdout, stderr bytes.Buffer
+	root := NewRootCmd()
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	root.SetArgs([]string{"stats", "--output", "json"})
+	if err := root.Execute(); err == nil {
+		t.Error("expected error when no claim code exists")
+	}
+
+	env := decodeEnvelope(t, stdout.String())
+	if env["ok"] != false || env["error"] != "no claim code" {
+		t.Errorf("envelope = %v", env)
+	}
+}
+
+// TestStatsCmd_JSONRejectsShare verifies --share and --output json can't be combined.
+func TestStatsCmd_JSONRejectsShare(t *testing.T) {
+	if _, err := executeCommand(NewRootCmd(), "stats", "--share", "--output", "json"); err == nil {
+		t.Error("expected an error for --share with --output json")
+	}
+}
+
 // TestRenderStatsOutput verifies the output rendering function.
 func TestRenderStatsOutput(t *testing.T) {
 	bestTime := 128000.0
@@ -140,3 +214,98 @@ func TestRenderStatsOutput_NilTimes(t *testing.T) {
 		t.Errorf("expected '—' for nil times, got: %q", output)
 	}
 }
+
+// compareServer serves stats for TIGER-MAPLE-7492 and OTTER-RIVER-1234; any
+// other player is not found.
+func compareServer(t *testing.T) {
+	t.Helper()
+	best, avg := 128000.0, 195000.0
+	rivalBest, rivalAvg := 100000.0, 205000.0
+	today := time.Now().Format(time.DateOnly)
+	players := map[string]api.PlayerStatsResponse{
+		"TIGER-MAPLE-7492": {
+			ClaimCode: "TIGER-MAPLE-7492", GamesPlayed: 42, GamesSolved: 40, WinRate: 0.95, CurrentStreak: 5, BestStreak: 12,
+			BestTime: &best, AverageTime: &avg, RecentSolves: []api.RecentSolve{{Date: today, CompletionTime: 128000}},
+		},
+		"OTTER-RIVER-1234": {
+			ClaimCode: "OTTER-RIVER-1234", GamesPlayed: 30, GamesSolved: 30, WinRate: 1, CurrentStreak: 7, BestStreak: 7,
+			BestTime: &rivalBest, AverageTime: &rivalAvg, RecentSolves: []api.RecentSolve{{Date: today, CompletionTime: 100000}},
+		},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/player/"), "/stats")
+		stats, ok := players[code]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(stats)
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Setenv("UNQUOTE_API_URL", srv.URL)
+	setConfigHome(t)
+	if err := config.Save(&config.Config{StatsEnabled: true, ClaimCode: "TIGER-MAPLE-7492"}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+}
+
+func TestStatsCompareCmd_DefaultsToOwnCode(t *testing.T) {
+	compareServer(t)
+
+	output, err := executeCommand(NewRootCmd(), "stats", "compare", "--insecure", "otter-river-1234")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"TIGER-MAPLE-7492 VS OTTER-RIVER-1234", "Difference", "+12", "-2", "-5.0%", "0:28 slower", "0:10 faster", "Solve Times"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestStatsCompareCmd_PartialFailure(t *testing.T) {
+	compareServer(t)
+
+	output, err := executeCommand(NewRootCmd(), "stats", "compare", "--insecure", "TIGER-MAPLE-7492", "GHOST-HOUSE-0000")
+	if err != nil {
+		t.Fatalf("one player failing should still compare: %v", err)
+	}
+	if !strings.Contains(output, "couldn't load all stats") || !strings.Contains(output, "—") || !strings.Contains(output, "42") {
+		t.Errorf("output should show the loaded player and warn about the other:\n%s", output)
+	}
+
+	output, err = executeCommand(NewRootCmd(), "stats", "compare", "--insecure", "--output", "json", "TIGER-MAPLE-7492", "GHOST-HOUSE-0000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var env struct {
+		Data compareOutput `json:"data"`
+		OK   bool          `json:"ok"`
+	}
+	if err := json.Unmarshal([]byte(output), &env); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	if !env.OK || len(env.Data.Players) != 2 || env.Data.Players[0].Stats == nil || env.Data.Players[1].Stats != nil || env.Data.Players[1].Error == "" {
+		t.Errorf("JSON = %+v, want the first player's stats and the second's error", env)
+	}
+
+	if _, err := executeCommand(NewRootCmd(), "stats", "compare", "--insecure", "GHOST-HOUSE-0000", "GHOST-HOUSE-0001"); err == nil {
+		t.Error("both players failing should fail")
+	}
+}
+
+func TestStatsCompareCmd_Rejects(t *testing.T) {
+	setConfigHome(t)
+
+	for _, args := range [][]string{
+		{"stats", "compare", "OTTER-RIVER-1234"}, // no claim code of your own
+		{"stats", "compare", "not-a-code", "OTTER-RIVER-1234"},
+		{"stats", "compare", "OTTER-RIVER-1234", "otter-river-1234"},
+	} {
+		if _, err := executeCommand(NewRootCmd(), args...); err == nil {
+			t.Errorf("%v should fail", args)
+		}
+	}
+}
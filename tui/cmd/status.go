@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/goal"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// Today's puzzle states reported by the status command.
+const (
+	todayNotStarted = "not-started"
+	todayInProgress = "in-progress"
+	todaySolved     = "solved"
+	todayRevealed   = "revealed"
+)
+
+// statusOutput is the JSON form of the status command.
+type statusOutput struct {
+	Goal     *goalOutput     `json:"goal"`     // null when no goal is set
+	Reminder *reminderOutput `json:"reminder"` // null when no reminder is set
+	Today    todayOutput     `json:"today"`
+}
+
+// reminderOutput is the daily reminder and whether it is going off: its time
+// has passed today and today's puzzle is neither solved nor revealed.
+type reminderOutput struct {
+	At  string `json:"at"` // HH:MM in the player's zone
+	Due bool   `json:"due"`
+}
+
+// todayOutput is the player's progress on today's puzzle on this device.
+type todayOutput struct {
+	Date             string `json:"date"`
+	State            string `json:"state"`
+	CompletionTimeMs int64  `json:"completionTimeMs,omitempty"`
+	// Progress is how much of an in-progress puzzle is filled in; null for
+	// other states and sessions saved without the puzzle's text.
+	Progress *progressOutput `json:"progress,omitempty"`
+}
+
+// progressOutput is how much of a puzzle's grid is filled in.
+type progressOutput struct {
+	Filled      int `json:"filled"`
+	Letters     int `json:"letters"`
+	WordsFilled int `json:"wordsFilled"`
+	Words       int `json:"words"`
+	Percent     int `json:"percent"`
+}
+
+// goalOutput is this week's progress toward the weekly goal.
+type goalOutput struct {
+	WeekStart string `json:"weekStart"`
+	Days      []bool `json:"days"` // Monday first
+	Goal      int    `json:"goal"`
+	Solved    int    `json:"solved"`
+	DaysLeft  int    `json:"daysLeft"`
+	Met       bool   `json:"met"`
+}
+
+// todayStatus looks up today's daily puzzle among the sessions saved on this
+// device. Sessions saved before they recorded their date are not found.
+func todayStatus(now time.Time) (todayOutput, error) {
+	today := todayOutput{Date: cache.Today(now), State: todayNotStarted}
+
+	sessions, err := storage.Daily.ListSessions()
+	if err != nil {
+		return today, fmt.Errorf("listing sessions: %w", err)
+	}
+	for _, s := range sessions {
+		if s.Date != today.Date {
+			continue
+		}
+		switch {
+		case s.Solved:
+			today.State = todaySolved
+			today.CompletionTimeMs = s.CompletionTime.Milliseconds()
+		case s.Revealed:
+			today.State = todayRevealed
+		default:
+			today.State = todayInProgress
+			if s.EncryptedText != "" {
+				p := ui.MeasureProgress(sessionCells(&s))
+				today.Progress = &progressOutput{
+					Filled:      p.Filled,
+					Letters:     p.Letters,
+					WordsFilled: p.WordsFilled,
+					Words:       p.Words,
+					Percent:     p.Percent(),
+				}
+			}
+		}
+		break
+	}
+	return today, nil
+}
+
+// newStatusCmd returns a command that summarizes today's puzzle and the
+// weekly goal from local data, without contacting the API.
+func newStatusCmd(output *outputFormat) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show today's puzzle and your weekly goal progress",
+		Long: "Show whether today's puzzle is solved on this device, the daily reminder set\n" +
+			"with 'unquote remind' and, when a weekly goal is set with 'unquote goal', how\n" +
+			"this week is going. Works offline.",
+		Example: "  unquote status\n\n" +
+			"  # Goal progress, for a status bar widget\n" +
+			"  unquote status --output json | jq .data.goal.solved",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			out, progress, err := buildStatus(time.Now().In(playerLocation()))
+			if err != nil {
+				if *output == outputJSON {
+					return writeJSONError(cmd.OutOrStdout(), "status", err)
+				}
+				return err
+			}
+
+			if *output == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), "status", out)
+			}
+
+			w := cmd.OutOrStdout()
+			switch out.Today.State {
+			case todaySolved:
+				fmt.Fprintf(w, "Today's puzzle (%s): solved in %s\n", out.Today.Date, formatMs(float64(out.Today.CompletionTimeMs)))
+			case todayRevealed:
+				fmt.Fprintf(w, "Today's puzzle (%s): revealed\n", out.Today.Date)
+			case todayInProgress:
+				if p := out.Today.Progress; p != nil {
+					progress := ui.Progress{Filled: p.Filled, Letters: p.Letters, WordsFilled: p.WordsFilled, Words: p.Words}
+					fmt.Fprintf(w, "Today's puzzle (%s): in progress, %s\n", out.Today.Date, progress.Summary())
+				} else {
+					fmt.Fprintf(w, "Today's puzzle (%s): in progress\n", out.Today.Date)
+				}
+			default:
+				fmt.Fprintf(w, "Today's puzzle (%s): not started\n", out.Today.Date)
+			}
+
+			if r := out.Reminder; r != nil && r.Due {
+				fmt.Fprintf(w, "Reminder: %s, today's puzzle is still waiting\n", r.At)
+			} else if r != nil {
+				fmt.Fprintf(w, "Reminder: %s\n", r.At)
+			}
+
+			if out.Goal == nil {
+				fmt.Fprintln(w, "Weekly goal: not set (try 'unquote goal 5')")
+				return nil
+			}
+			fmt.Fprintf(w, "Weekly goal: %s\n%s\n", progress.Summary(), progress.Calendar())
+			return nil
+		},
+	}
+}
+
+// buildStatus gathers the status command's output for the given time.
+func buildStatus(now time.Time) (statusOutput, goal.Progress, error) {
+	var out statusOutput
+	var err error
+	if out.Today, err = todayStatus(now); err != nil {
+		return out, goal.Progress{}, err
+	}
+
+	cfg, err := loadOrNewConfig()
+	if err != nil {
+		return out, goal.Progress{}, err
+	}
+	out.Reminder = reminderStatus(cfg.Reminder, out.Today, now)
+	if cfg.WeeklyGoal == 0 {
+		return out, goal.Progress{}, nil
+	}
+
+	progress, err := goal.Load(cfg.WeeklyGoal, now)
+	if err != nil {
+		return out, progress, err
+	}
+	out.Goal = &goalOutput{
+		WeekStart: progress.WeekStart.Format(time.DateOnly),
+		Days:      progress.Solved[:],
+		Goal:      progress.Goal,
+		Solved:    progress.Count(),
+		DaysLeft:  progress.DaysLeft(),
+		Met:       progress.Met(),
+	}
+	return out, progress, nil
+}
+
+// reminderStatus reports the reminder set for at, or nil when none is. A
+// time that doesn't parse, from a hand-edited config, counts as none.
+func reminderStatus(at string, today todayOutput, now time.Time) *reminderOutput {
+	reminder, err := goal.ParseReminder(at)
+	if at == "" || err != nil {
+		return nil
+	}
+	unfinished := today.State == todayNotStarted || today.State == todayInProgress
+	return &reminderOutput{At: reminder.String(), Due: unfinished && reminder.Due(now)}
+}
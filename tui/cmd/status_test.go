@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// setStatusHomes points the config and state directories at temp dirs.
+func setStatusHomes(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+}
+
+func TestStatusCmd_NothingYet(t *testing.T) {
+	setStatusHomes(t)
+
+	output, err := executeCommand(NewRootCmd(), "status")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"not started", "Weekly goal: not set"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestStatusCmd_SolvedWithGoal(t *testing.T) {
+	setStatusHomes(t)
+	if err := config.Save(&config.Config{WeeklyGoal: 3}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+	now := time.Now()
+	if err := storage.SaveSession(&storage.GameSession{
+		GameID: "game-today", Date: cache.Today(now), Solved: true, SolvedAt: &now, CompletionTime: 192 * time.Second,
+	}); err != nil {
+		t.Fatalf("setup: failed to save session: %v", err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "status")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"solved in 3:12", "1 of 3 days this week", "M T W T F S S"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	output, err = executeCommand(NewRootCmd(), "status", "--output", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := decodeEnvelope(t, output)["data"].(map[string]any)
+	today, _ := data["today"].(map[string]any)
+	goal, _ := data["goal"].(map[string]any)
+	if today["state"] != "solved" || today["completionTimeMs"] != float64(192000) {
+		t.Errorf("today = %v", today)
+	}
+	if goal["goal"] != float64(3) || goal["solved"] != float64(1) || goal["met"] != false {
+		t.Errorf("goal = %v", goal)
+	}
+	if days, _ := goal["days"].([]any); len(days) != 7 {
+		t.Errorf("days = %v, want one entry per weekday", goal["days"])
+	}
+}
+
+func TestStatusCmd_InProgressShowsProgress(t *testing.T) {
+	setStatusHomes(t)
+	if err := storage.SaveSession(&storage.GameSession{
+		GameID:        "game-today",
+		Date:          cache.Today(time.Now()),
+		EncryptedText: "XLI UYMGO",
+		Hints:         map[string]string{"X": "T"},
+		Inputs:        map[string]string{"L": "H", "I": "E"},
+	}); err != nil {
+		t.Fatalf("setup: failed to save session: %v", err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "status")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "in progress, 3/8 letters · 1/2 words (37%)"; !strings.Contains(output, want) {
+		t.Errorf("output missing %q:\n%s", want, output)
+	}
+
+	output, err = executeCommand(NewRootCmd(), "status", "--output", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := decodeEnvelope(t, output)["data"].(map[string]any)
+	today, _ := data["today"].(map[string]any)
+	progress, _ := today["progress"].(map[string]any)
+	if progress["filled"] != float64(3) || progress["letters"] != float64(8) || progress["wordsFilled"] != float64(1) || progress["percent"] != float64(37) {
+		t.Errorf("progress = %v", today["progress"])
+	}
+}
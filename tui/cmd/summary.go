@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/goal"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// weekRecap is one week of daily puzzles, Monday to Sunday, from the local
+// solve history and, when they could be fetched, the player's stats.
+type weekRecap struct {
+	start   time.Time     // local midnight on Monday
+	best    *historyEntry // fastest solve; nil when nothing was solved
+	hardest *historyEntry // highest difficulty solved on this device; nil when none is known
+	streak  string        // how the streak moved; empty without stats or for past weeks
+	solved  int
+}
+
+// newWeekRecap sums up the week containing day. Solves the stats report
+// from other devices count toward the days solved and the best time, but
+// only local sessions know a puzzle's difficulty. The streak is reported for
+// the current week only, since the stats only know today's streak.
+func newWeekRecap(history []historyEntry, stats *api.PlayerStatsResponse, day, today time.Time) weekRecap {
+	r := weekRecap{start: goal.WeekStart(day)}
+	first := r.start.Format(time.DateOnly)
+	last := r.start.AddDate(0, 0, goal.DaysPerWeek-1).Format(time.DateOnly)
+	inWeek := func(date string) bool { return date >= first && date <= last }
+
+	solvedDays := r.tally(weekSolves(history, stats, inWeek))
+
+	if stats != nil && inWeek(today.Format(time.DateOnly)) {
+		r.streak = streakMovement(solvedDays, stats.CurrentStreak, first, today)
+	}
+	return r
+}
+
+// weekSolves returns the solves in history and the stats' recent solves that
+// fall in the week.
+func weekSolves(history []historyEntry, stats *api.PlayerStatsResponse, inWeek func(date string) bool) []historyEntry {
+	solves := make([]historyEntry, 0, len(history))
+	for _, h := range history {
+		if inWeek(h.Date) {
+			solves = append(solves, h)
+		}
+	}
+	if stats != nil {
+		for _, s := range stats.RecentSolves {
+			if inWeek(s.Date) {
+				solves = append(solves, historyEntry{Date: s.Date, CompletionTimeMs: int64(s.CompletionTime)})
+			}
+		}
+	}
+	return solves
+}
+
+// tally counts the days solved and picks the best and hardest of solves,
+// returning the days solved. Of two solves equally hard, the slower one is
+// the harder.
+func (r *weekRecap) tally(solves []historyEntry) map[string]bool {
+	solvedDays := make(map[string]bool, goal.DaysPerWeek)
+	for i := range solves {
+		h := &solves[i]
+		solvedDays[h.Date] = true
+		if r.best == nil || h.CompletionTimeMs < r.best.CompletionTimeMs {
+			r.best = h
+		}
+		if h.Difficulty > 0 && (r.hardest == nil || h.Difficulty > r.hardest.Difficulty ||
+			h.Difficulty == r.hardest.Difficulty && h.CompletionTimeMs > r.hardest.CompletionTimeMs) {
+			r.hardest = h
+		}
+	}
+	r.solved = len(solvedDays)
+	return solvedDays
+}
+
+// streakMovement describes how the current streak changed this week, given
+// the days solved this week, the current streak and the week's first day.
+// A streak still open today may end yesterday.
+func streakMovement(solvedDays map[string]bool, current int, first string, today time.Time) string {
+	if current == 0 {
+		return "none going"
+	}
+	end := today
+	if !solvedDays[end.Format(time.DateOnly)] {
+		end = end.AddDate(0, 0, -1)
+	}
+	run := 0
+	for d := end; d.Format(time.DateOnly) >= first && solvedDays[d.Format(time.DateOnly)]; d = d.AddDate(0, 0, -1) {
+		run++
+	}
+
+	days := fmt.Sprintf("%d days", current)
+	if current == 1 {
+		days = "1 day"
+	}
+	before := current - run
+	switch {
+	case run > 0 && end.AddDate(0, 0, -run).Format(time.DateOnly) < first && before > 0:
+		return fmt.Sprintf("%s, up from %d", days, before)
+	case run > 0:
+		return days + ", started this week"
+	default:
+		return days
+	}
+}
+
+// rows returns the recap's lines as labels and values.
+func (r weekRecap) rows() [][2]string {
+	rows := [][2]string{{"Puzzles solved", fmt.Sprintf("%d of %d days", r.solved, goal.DaysPerWeek)}}
+	if r.best != nil {
+		rows = append(rows, [2]string{"Best time", fmt.Sprintf("%s on %s", formatMs(float64(r.best.CompletionTimeMs)), recapDay(r.best.Date))})
+	}
+	if r.hardest != nil {
+		rows = append(rows, [2]string{"Hardest puzzle", fmt.Sprintf("%s (%d) on %s, solved in %s",
+			puzzle.DifficultyText(r.hardest.Difficulty), r.hardest.Difficulty, recapDay(r.hardest.Date),
+			formatMs(float64(r.hardest.CompletionTimeMs)))})
+	}
+	if r.streak != "" {
+		rows = append(rows, [2]string{"Streak", r.streak})
+	}
+	return rows
+}
+
+// title returns the recap's heading with its dates, e.g.
+// "Unquote weekly recap: Jan 12 – Jan 18, 2026".
+func (r weekRecap) title() string {
+	end := r.start.AddDate(0, 0, goal.DaysPerWeek-1)
+	return fmt.Sprintf("Unquote weekly recap: %s – %s", r.start.Format("Jan 2"), end.Format("Jan 2, 2006"))
+}
+
+// recapDay formats a YYYY-MM-DD date as "Wed Jan 14".
+func recapDay(date string) string {
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return date
+	}
+	return day.Format("Mon Jan 2")
+}
+
+// writeRecapText writes the recap as aligned plain text, for mail.
+func writeRecapText(w io.Writer, r weekRecap) {
+	fmt.Fprintf(w, "%s\n\n", r.title())
+	for _, row := range r.rows() {
+		fmt.Fprintf(w, "%-16s%s\n", row[0], row[1])
+	}
+}
+
+// writeRecapMarkdown writes the recap as a Markdown list under a heading.
+func writeRecapMarkdown(w io.Writer, r weekRecap) {
+	fmt.Fprintf(w, "# %s\n\n", r.title())
+	for _, row := range r.rows() {
+		fmt.Fprintf(w, "- **%s:** %s\n", row[0], row[1])
+	}
+}
+
+// newSummaryCmd returns a command that writes a recap of a week of play.
+func newSummaryCmd(insecure *bool) *cobra.Command {
+	var week, format string
+
+	cmd := &cobra.Command{
+		Use:   "summary",
+		Short: "Write a recap of your week",
+		Long: "Write a recap of a week of daily puzzles, Monday to Sunday: how many days you\n" +
+			"solved, your best time and the hardest puzzle you solved, ready to pipe to mail\n" +
+			"or paste into a post. Without a date, --week recaps this week.\n\n" +
+			"The recap comes from the puzzles solved on this device. When you have a claim\n" +
+			"code and the server can be reached, solves from other devices count too, and\n" +
+			"this week's recap says how your streak moved.",
+		Example: "  # Mail yourself this week's recap\n" +
+			"  unquote summary --week | mail -s \"My Unquote week\" me@example.com\n\n" +
+			"  # Last week's, as Markdown\n" +
+			"  unquote summary --week=2026-01-12 --format markdown",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			write := writeRecapText
+			switch format {
+			case "text":
+			case "markdown", "md":
+				write = writeRecapMarkdown
+			default:
+				return fmt.Errorf("unknown format %q: expected text or markdown", format)
+			}
+
+			loc := playerLocation()
+			today := time.Now().In(loc)
+			day := today
+			if week != "today" {
+				d, err := time.ParseInLocation(time.DateOnly, week, loc)
+				if err != nil {
+					return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", week)
+				}
+				day = d
+			}
+
+			history, err := solveHistory(loc)
+			if err != nil {
+				return err
+			}
+			// Stats fill in other devices and the streak; recap without them when offline or unregistered
+			stats, err := fetchStats(*insecure)
+			if err != nil {
+				stats = nil
+			}
+
+			write(cmd.OutOrStdout(), newWeekRecap(history, stats, day, today))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&week, "week", "today", "recap the week containing this date, YYYY-MM-DD")
+	cmd.Flags().Lookup("week").NoOptDefVal = "today"
+	cmd.Flags().StringVar(&format, "format", "text", "recap format: text or markdown")
+
+	return cmd
+}
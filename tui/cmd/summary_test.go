@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+func TestSummaryCmd_PastWeek(t *testing.T) {
+	setConfigHome(t)
+	storagetest.UseMemory(t)
+	for _, s := range []*storage.GameSession{
+		{GameID: "game-0112", Date: "2026-01-12", Difficulty: 30, CompletionTime: 200 * time.Second, Solved: true},
+		{GameID: "game-0114", Date: "2026-01-14", Difficulty: 72, CompletionTime: 242 * time.Second, Solved: true, Uploaded: true},
+		{GameID: "game-0115", Date: "2026-01-15", Difficulty: 10, CompletionTime: 75 * time.Second, Solved: true},
+		{GameID: "game-0119", Date: "2026-01-19", Difficulty: 90, CompletionTime: 60 * time.Second, Solved: true}, // the next week
+	} {
+		if err := storage.SaveSession(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output, err := executeCommand(NewRootCmd(), "summary", "--week=2026-01-14")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "Unquote weekly recap: Jan 12 – Jan 18, 2026\n\n" +
+		"Puzzles solved  3 of 7 days\n" +
+		"Best time       1:15 on Thu Jan 15\n" +
+		"Hardest puzzle  Hard (72) on Wed Jan 14, solved in 4:02\n"
+	if output != want {
+		t.Errorf("summary =\n%s\nwant\n%s", output, want)
+	}
+
+	output, err = executeCommand(NewRootCmd(), "summary", "--week=2026-01-14", "--format", "md")
+	if err != nil || !strings.Contains(output, "# Unquote weekly recap") || !strings.Contains(output, "- **Best time:** 1:15 on Thu Jan 15\n") {
+		t.Errorf("Markdown summary = %q, %v", output, err)
+	}
+}
+
+func TestSummaryCmd_Rejects(t *testing.T) {
+	setConfigHome(t)
+	storagetest.UseMemory(t)
+
+	for _, args := range [][]string{
+		{"summary", "--week=14/01/2026"},
+		{"summary", "--format", "html"},
+	} {
+		if _, err := executeCommand(NewRootCmd(), args...); err == nil {
+			t.Errorf("%v should fail", args)
+		}
+	}
+}
+
+func TestNewWeekRecap_WithStats(t *testing.T) {
+	today := time.Date(2026, 1, 15, 20, 0, 0, 0, time.UTC) // a Thursday
+	history := []historyEntry{
+		{Date: "2026-01-13", CompletionTimeMs: 90000, Difficulty: 40},
+		{Date: "2026-01-14", CompletionTimeMs: 80000, Difficulty: 40},
+	}
+	stats := &api.PlayerStatsResponse{
+		CurrentStreak: 9,
+		RecentSolves: []api.RecentSolve{
+			{Date: "2026-01-12", CompletionTime: 50000}, // solved on another device
+			{Date: "2026-01-14", CompletionTime: 80000},
+			{Date: "2026-01-05", CompletionTime: 10000}, // an earlier week
+		},
+	}
+
+	r := newWeekRecap(history, stats, today, today)
+	if r.solved != 3 {
+		t.Errorf("solved = %d, want 3 days", r.solved)
+	}
+	if r.best == nil || r.best.Date != "2026-01-12" {
+		t.Errorf("best = %+v, want the other device's solve on Monday", r.best)
+	}
+	if r.hardest == nil || r.hardest.Date != "2026-01-13" {
+		t.Errorf("hardest = %+v, want the slower of the equally hard puzzles", r.hardest)
+	}
+	// Monday to Wednesday, still open today: up 3 from 6
+	if r.streak != "9 days, up from 6" {
+		t.Errorf("streak = %q", r.streak)
+	}
+
+	if r := newWeekRecap(history, stats, today.AddDate(0, 0, -7), today); r.streak != "" {
+		t.Errorf("a past week's streak = %q, want none", r.streak)
+	}
+}
+
+func TestStreakMovement(t *testing.T) {
+	today := time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC) // a Thursday
+	days := func(dates ...string) map[string]bool {
+		m := make(map[string]bool)
+		for _, d := range dates {
+			m[d] = true
+		}
+		return m
+	}
+
+	tests := []struct {
+		solved  map[string]bool
+		name    string
+		want    string
+		current int
+	}{
+		{name: "no streak", solved: days(), current: 0, want: "none going"},
+		{name: "started this week", solved: days("2026-01-14", "2026-01-15"), current: 2, want: "2 days, started this week"},
+		{name: "carried in", solved: days("2026-01-12", "2026-01-13", "2026-01-14", "2026-01-15"), current: 10, want: "10 days, up from 6"},
+		{name: "nothing this week", solved: days(), current: 1, want: "1 day"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := streakMovement(tt.solved, tt.current, "2026-01-12", today); got != tt.want {
+				t.Errorf("streakMovement() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/app"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+// errStatsDisabled is returned by sync when stats tracking is turned off.
+var errStatsDisabled = errors.New("stats tracking is off")
+
+// newSyncCmd returns a command that sends the server the solves, attempts and
+// ratings this device hasn't sent yet, as the interactive UI does when it
+// starts.
+func newSyncCmd(insecure *bool, output *outputFormat) *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync",
+		Short: "Upload solves that haven't reached your stats yet",
+		Long: "Upload the solves this device hasn't sent to your stats yet, report puzzles\n" +
+			"started but not solved (unless skipped in the config), and send difficulty\n" +
+			"ratings queued while offline. The interactive UI does the same when it\n" +
+			"starts. Requires a claim code and stats tracking turned on.",
+		Example: "  unquote sync\n\n" +
+			"  # Solves still waiting to upload, for a status bar widget\n" +
+			"  unquote sync --output json | jq .data.pending",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			result, uploadErr, err := runSync(*insecure)
+			if *output == outputJSON {
+				if err != nil {
+					return writeJSONError(cmd.OutOrStdout(), "sync", err)
+				}
+				return writeJSON(cmd.OutOrStdout(), "sync", result)
+			}
+
+			switch {
+			case errors.Is(err, errNoClaimCode):
+				fmt.Fprintln(cmd.ErrOrStderr(), "No claim code found. Run 'unquote register' first.")
+			case errors.Is(err, errStatsDisabled):
+				fmt.Fprintln(cmd.ErrOrStderr(), "Stats tracking is off. Run 'unquote --stats on' to turn it on.")
+			}
+			if err != nil {
+				return err
+			}
+			if uploadErr != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: some solves could not be uploaded: %v\n", uploadErr)
+			}
+			printSyncResult(cmd.OutOrStdout(), result)
+			return nil
+		},
+	}
+}
+
+// runSync loads the config and reconciles this device's sessions with the
+// server. Solves that fail to upload don't fail the sync: they count as
+// pending, and uploadErr is the first failure.
+func runSync(insecure bool) (result app.SyncResult, uploadErr, err error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return result, nil, fmt.Errorf("loading config: %w", err)
+	}
+	switch {
+	case cfg == nil || cfg.ClaimCode == "":
+		return result, nil, errNoClaimCode
+	case !cfg.StatsEnabled:
+		return result, nil, errStatsDisabled
+	}
+
+	client, err := api.NewClient(insecure)
+	if err != nil {
+		return result, nil, fmt.Errorf("creating API client: %w", err)
+	}
+	result, uploadErr = app.Sync(client, cfg)
+	return result, uploadErr, nil
+}
+
+func printSyncResult(w io.Writer, r app.SyncResult) {
+	switch {
+	case r.Pending > 0:
+		fmt.Fprintf(w, "Uploaded %d of %d solves; %d will be tried again on the next sync.\n", r.Uploaded, r.Uploaded+r.Pending, r.Pending)
+	case r.Uploaded+r.Replayed == 0:
+		fmt.Fprintln(w, "Everything is already synced.")
+	default:
+		fmt.Fprintf(w, "Synced %d solves.\n", r.Uploaded+r.Replayed)
+	}
+}
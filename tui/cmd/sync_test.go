@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+func TestSyncCmd_UploadsPendingSolves(t *testing.T) {
+	recorded := solveServer(t)
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492", StatsEnabled: true, SkipAttempts: true}); err != nil {
+		t.Fatal(err)
+	}
+	session := &storage.GameSession{GameID: "game-1", Date: "2026-01-20", Solved: true, CompletionTime: 90 * time.Second}
+	if err := storage.SaveSession(session); err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "sync", "--output", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var env struct {
+		Data struct {
+			Uploaded int `json:"uploaded"`
+			Pending  int `json:"pending"`
+		} `json:"data"`
+		OK bool `json:"ok"`
+	}
+	if err := json.Unmarshal([]byte(output), &env); err != nil {
+		t.Fatalf("sync --output json is not JSON: %v\n%s", err, output)
+	}
+	if !env.OK || env.Data.Uploaded != 1 || env.Data.Pending != 0 {
+		t.Errorf("sync --output json = %s, want 1 uploaded and none pending", output)
+	}
+	if recorded.Load() != 1 {
+		t.Errorf("sessions recorded = %d, want 1", recorded.Load())
+	}
+
+	loaded, err := storage.LoadSession("game-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Uploaded {
+		t.Error("the synced session should be marked uploaded")
+	}
+
+	output, err = executeCommand(NewRootCmd(), "sync")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output != "Everything is already synced.\n" {
+		t.Errorf("second sync = %q, want nothing left to sync", output)
+	}
+}
+
+func TestSyncCmd_RequiresStats(t *testing.T) {
+	recorded := solveServer(t)
+
+	output, err := executeCommand(NewRootCmd(), "sync", "--output", "json")
+	if err == nil || !strings.Contains(output, `"error": "no claim code"`) {
+		t.Errorf("sync without a claim code = %v\n%s\nwant a no claim code error", err, output)
+	}
+
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.SaveSession(&storage.GameSession{GameID: "game-1", Solved: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := executeCommand(NewRootCmd(), "sync"); err == nil {
+		t.Error("sync with stats turned off should fail")
+	}
+	if recorded.Load() != 0 {
+		t.Errorf("sessions recorded = %d, want none with stats turned off", recorded.Load())
+	}
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"embed"
+	"fmt"
+	"path/filepath"
+
+	"charm.land/lipgloss/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/app"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// themeFiles are the theme schema and example themes 'theme init' writes.
+//
+//go:embed themes/*.json
+var themeFiles embed.FS
+
+// defaultTheme names the built-in palette; 'theme use default' clears
+// Config.Theme.
+const defaultTheme = "default"
+
+// loadCustomTheme reads and checks the theme file for name.
+func loadCustomTheme(name string) (*ui.CustomTheme, error) {
+	file, err := config.LoadTheme(name)
+	if err != nil {
+		return nil, err
+	}
+	theme, err := ui.ParseCustomTheme(file.Background, file.Colors)
+	if err != nil {
+		return nil, fmt.Errorf("theme %s: %w", name, err)
+	}
+	return theme, nil
+}
+
+// newThemeCmd returns the parent command for custom color themes.
+func newThemeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "theme",
+		Short: "Show, choose or create custom color themes",
+		Long: "Show, choose or create custom color themes.\n\n" +
+			"A theme is a JSON file in the themes directory under the config directory\n" +
+			"(~/.config/unquote/themes/<name>.json) setting colors by role: primary,\n" +
+			"secondary, success, error, muted, white, warning, text, cursor, tint and\n" +
+			"on_warning, each \"#RRGGBB\" or an ANSI color number. Roles it leaves out keep\n" +
+			"the built-in colors. 'unquote theme init' writes the schema and two examples.\n" +
+			"With no subcommand, shows the theme in use.",
+		Example: "  unquote theme init\n" +
+			"  unquote theme list\n" +
+			"  unquote theme use paper",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadOrNewConfig()
+			if err != nil {
+				return err
+			}
+			if cfg.Theme == "" {
+				fmt.Fprintln(cmd.OutOrStdout(), "Theme: default (the built-in colors)")
+				return nil
+			}
+			path, err := config.ThemePath(cfg.Theme)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Theme: %s (%s)\n", cfg.Theme, path)
+			if _, err := loadCustomTheme(cfg.Theme); err != nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "It can't be used, so the built-in colors are: %v\n", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newThemeUseCmd())
+	cmd.AddCommand(newThemeListCmd())
+	cmd.AddCommand(newThemeInitCmd())
+	cmd.AddCommand(newThemePreviewCmd())
+
+	return cmd
+}
+
+// newThemeUseCmd returns a command that selects a theme, checking its file
+// first so a broken theme is never saved.
+func newThemeUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name|default>",
+		Short: "Use a custom theme, or the built-in colors",
+		Long: "Use the custom theme named, checking its file first, or 'default' for the\n" +
+			"built-in colors. The next game started uses it.",
+		Example: "  unquote theme use paper\n" +
+			"  unquote theme use default",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if name != defaultTheme {
+				if _, err := loadCustomTheme(name); err != nil {
+					return err
+				}
+			}
+
+			cfg, err := loadOrNewConfig()
+			if err != nil {
+				return err
+			}
+			cfg.Theme = name
+			if name == defaultTheme {
+				cfg.Theme = ""
+			}
+			if err := config.Save(cfg); err != nil {
+				return fmt.Errorf("saving config: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Theme: %s\n", name)
+			return nil
+		},
+	}
+}
+
+// newThemeListCmd returns a command that lists the themes in the themes
+// directory, marking the one in use and any that can't be used.
+func newThemeListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the custom themes",
+		Long: "List the built-in colors and the custom themes in the themes directory with\n" +
+			"their descriptions. The theme in use is marked with *, and themes whose files\n" +
+			"can't be used say why.",
+		Example: "  unquote theme list",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadOrNewConfig()
+			if err != nil {
+				return err
+			}
+			names, err := config.ThemeNames()
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			mark := func(name string) string {
+				if name == cfg.Theme || (name == defaultTheme && cfg.Theme == "") {
+					return "* "
+				}
+				return "  "
+			}
+			fmt.Fprintf(out, "%s%s  the built-in colors\n", mark(defaultTheme), defaultTheme)
+			for _, name := range names {
+				file, err := config.LoadTheme(name)
+				if err == nil {
+					_, err = ui.ParseCustomTheme(file.Background, file.Colors)
+				}
+				if err != nil {
+					fmt.Fprintf(out, "%s%s  can't be used: %v\n", mark(name), name, err)
+					continue
+				}
+				fmt.Fprintf(out, "%s%s  %s\n", mark(name), name, ui.SanitizeString(file.Description))
+			}
+			if len(names) == 0 {
+				fmt.Fprintln(out, "No custom themes yet; 'unquote theme init' writes two examples.")
+			}
+			return nil
+		},
+	}
+}
+
+// newThemeInitCmd returns a command that writes the theme schema and the
+// example themes, leaving any already there alone.
+func newThemeInitCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "init",
+		Short: "Write the theme schema and two example themes",
+		Long: "Write the theme schema (theme.schema.json) and two example themes, paper for\n" +
+			"light terminals and midnight for dark ones, to the themes directory. Files\n" +
+			"already there are kept, so edits are never overwritten.",
+		Example: "  unquote theme init\n" +
+			"  unquote theme use midnight",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			entries, err := themeFiles.ReadDir("themes")
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			for _, entry := range entries {
+				data, err := themeFiles.ReadFile("themes/" + entry.Name())
+				if err != nil {
+					return err
+				}
+				written, err := config.WriteThemesFile(entry.Name(), data)
+				if err != nil {
+					return err
+				}
+				if written {
+					fmt.Fprintf(out, "Wrote %s\n", entry.Name())
+				} else {
+					fmt.Fprintf(out, "Kept %s, which is already there\n", entry.Name())
+				}
+			}
+			path, err := config.ThemePath("paper")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Themes are in %s\n", filepath.Dir(path))
+			return nil
+		},
+	}
+}
+
+// newThemePreviewCmd returns a command that prints the sample screens the
+// golden-frame tests render, in a theme's colors.
+func newThemePreviewCmd() *cobra.Command {
+	var width, height int
+
+	cmd := &cobra.Command{
+		Use:   "preview [name|default]",
+		Short: "Show a sample board, stats panel and claim-code card in a theme",
+		Long: "Print a sample board, stats panel and claim-code card in the colors of the\n" +
+			"theme named, 'default' for the built-in colors, or the theme in use when no\n" +
+			"name is given, so themes can be compared without starting a game. The\n" +
+			"background setting applies as it does in the game. Piped output has no colors.",
+		Example: "  unquote theme preview\n" +
+			"  unquote theme preview midnight\n" +
+			"  unquote theme preview paper --width 100 --height 30",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if width < app.MinTerminalWidth || height < app.MinTerminalHeight {
+				return fmt.Errorf("--width and --height must be at least %d and %d", app.MinTerminalWidth, app.MinTerminalHeight)
+			}
+			cfg, err := loadOrNewConfig()
+			if err != nil {
+				return err
+			}
+			name := cfg.Theme
+			if len(args) == 1 {
+				name = args[0]
+			}
+			var theme *ui.CustomTheme
+			if name != "" && name != defaultTheme {
+				if theme, err = loadCustomTheme(name); err != nil {
+					return err
+				}
+			}
+			background, _ := ui.ParseBackground(cfg.Background)
+
+			out := cmd.OutOrStdout()
+			useThemePalette(out, background, theme)
+			// The screens mark clickable zones as the TUI's do
+			zone.NewGlobal()
+			headerStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorText)
+			for i, screen := range app.PreviewScreens(width, height) {
+				if i > 0 {
+					fmt.Fprintln(out)
+				}
+				fmt.Fprintln(out, headerStyle.Render(screen.Title))
+				fmt.Fprintln(out, screen.Content)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&width, "width", 80, "columns to render the screens at")
+	cmd.Flags().IntVar(&height, "height", 24, "rows to render the screens at")
+
+	return cmd
+}
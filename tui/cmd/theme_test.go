@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/colorprofile"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+func TestThemeCmd_ExamplesLoad(t *testing.T) {
+	setConfigHome(t)
+	if _, err := executeCommand(NewRootCmd(), "theme", "init"); err != nil {
+		t.Fatalf("theme init: %v", err)
+	}
+
+	for _, name := range []string{"paper", "midnight"} {
+		theme, err := loadCustomTheme(name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(theme.Colors) != len(ui.Roles) {
+			t.Errorf("%s sets %d colors, want every role", name, len(theme.Colors))
+		}
+	}
+}
+
+func TestThemeCmd_InitUseList(t *testing.T) {
+	setConfigHome(t)
+
+	if output, err := executeCommand(NewRootCmd(), "theme"); err != nil || !strings.Contains(output, "Theme: default") {
+		t.Errorf("theme = %q, %v; want the default", output, err)
+	}
+	if _, err := executeCommand(NewRootCmd(), "theme", "use", "paper"); err == nil {
+		t.Error("theme use paper should fail before 'theme init'")
+	}
+
+	output, err := executeCommand(NewRootCmd(), "theme", "init")
+	if err != nil || !strings.Contains(output, "Wrote paper.json") || !strings.Contains(output, "Wrote theme.schema.json") {
+		t.Fatalf("theme init = %q, %v", output, err)
+	}
+	if output, err := executeCommand(NewRootCmd(), "theme", "init"); err != nil || !strings.Contains(output, "Kept paper.json") {
+		t.Errorf("theme init again = %q, %v; want the files kept", output, err)
+	}
+
+	if _, err := executeCommand(NewRootCmd(), "theme", "use", "paper"); err != nil {
+		t.Fatalf("theme use paper: %v", err)
+	}
+	if cfg, _ := config.Load(); cfg == nil || cfg.Theme != "paper" {
+		t.Errorf("config = %+v, want paper saved", cfg)
+	}
+
+	if _, err := config.WriteThemesFile("broken.json", []byte(`{"colors": {"primary": "red"}}`)); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	if _, err := executeCommand(NewRootCmd(), "theme", "use", "broken"); err == nil {
+		t.Error("theme use broken should fail")
+	}
+	output, err = executeCommand(NewRootCmd(), "theme", "list")
+	if err != nil {
+		t.Fatalf("theme list: %v", err)
+	}
+	for _, want := range []string{"  default", "  broken  can't be used", "  midnight  ", "* paper  Ink on paper"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("theme list = %q, want %q", output, want)
+		}
+	}
+
+	if _, err := executeCommand(NewRootCmd(), "theme", "use", "default"); err != nil {
+		t.Fatalf("theme use default: %v", err)
+	}
+	if cfg, _ := config.Load(); cfg == nil || cfg.Theme != "" {
+		t.Errorf("config = %+v, want the theme cleared", cfg)
+	}
+}
+
+func TestThemeCmd_Preview(t *testing.T) {
+	setConfigHome(t)
+	t.Cleanup(func() { ui.UsePalette(true, colorprofile.ANSI256, nil) })
+	if _, err := executeCommand(NewRootCmd(), "theme", "init"); err != nil {
+		t.Fatalf("theme init: %v", err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "theme", "preview", "midnight")
+	if err != nil {
+		t.Fatalf("theme preview midnight: %v", err)
+	}
+	for _, want := range []string{"Board", "Stats", "Claim code", "Clues: X = T", "TIGER-MAPLE-7492"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("theme preview = %q, want %q", output, want)
+		}
+	}
+
+	if _, err := executeCommand(NewRootCmd(), "theme", "preview", "sepia"); err == nil {
+		t.Error("theme preview sepia should fail without a sepia theme")
+	}
+	if _, err := executeCommand(NewRootCmd(), "theme", "preview", "--width", "20"); err == nil {
+		t.Error("theme preview --width 20 should fail")
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // resolve configured zones on systems without a zoneinfo database
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+// localTimezone is the argument that clears the time zone setting.
+const localTimezone = "local"
+
+// playerLocation returns the time zone that decides which daily puzzle is
+// today. A missing or unreadable config means local time.
+func playerLocation() *time.Location {
+	cfg, err := config.Load()
+	if err != nil {
+		return time.Local
+	}
+	return cfg.Location()
+}
+
+// newTimezoneCmd returns a command that shows or sets the time zone the
+// puzzle day follows.
+func newTimezoneCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "timezone [zone]",
+		Short: "Show or set the time zone that decides today's puzzle",
+		Long: "Show or set the time zone that decides which day's puzzle is today. Today's\n" +
+			"puzzle changes at midnight in this zone, and weekly goals count days in it.\n\n" +
+			"Zones are IANA names such as Europe/Belgrade or America/New_York. By default,\n" +
+			"and after 'unquote timezone local', the system's local time zone is used. Set\n" +
+			"it explicitly to keep the same puzzle day while travelling.",
+		Example: "  # Show the current setting and today's puzzle date\n" +
+			"  unquote timezone\n\n" +
+			"  # Follow New York's day wherever you are\n" +
+			"  unquote timezone America/New_York\n\n" +
+			"  # Go back to the system time zone\n" +
+			"  unquote timezone local",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadOrNewConfig()
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 1 {
+				zone := args[0]
+				if strings.EqualFold(zone, localTimezone) {
+					zone = ""
+				} else if _, err := time.LoadLocation(zone); err != nil || zone == "" {
+					return fmt.Errorf("unknown time zone %q: expected an IANA name like Europe/Belgrade, or %q", args[0], localTimezone)
+				}
+				cfg.Timezone = zone
+				if err := config.Save(cfg); err != nil {
+					return fmt.Errorf("saving config: %w", err)
+				}
+			}
+
+			loc := cfg.Location()
+			name := loc.String()
+			if cfg.Timezone == "" {
+				name = "local (" + name + ")"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Time zone: %s\nToday's puzzle: %s\n", name, cache.Today(time.Now().In(loc)))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+func TestTimezoneCmd_SetShowAndClear(t *testing.T) {
+	setConfigHome(t)
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492"}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+
+	output, err := executeCommand(NewRootCmd(), "timezone", "Pacific/Auckland")
+	if err != nil || !strings.Contains(output, "Time zone: Pacific/Auckland") {
+		t.Fatalf("timezone Pacific/Auckland = %q, %v", output, err)
+	}
+	auckland, _ := time.LoadLocation("Pacific/Auckland")
+	if today := cache.Today(time.Now().In(auckland)); !strings.Contains(output, "Today's puzzle: "+today) {
+		t.Errorf("output = %q, want Auckland's date %s", output, today)
+	}
+	cfg, _ := config.Load()
+	if cfg == nil || cfg.Timezone != "Pacific/Auckland" || cfg.ClaimCode != "TIGER-MAPLE-7492" {
+		t.Errorf("config = %+v, want the zone saved alongside the claim code", cfg)
+	}
+	if got := playerLocation(); got.String() != "Pacific/Auckland" {
+		t.Errorf("playerLocation() = %v, want the configured zone", got)
+	}
+
+	if output, err := executeCommand(NewRootCmd(), "timezone"); err != nil || !strings.Contains(output, "Time zone: Pacific/Auckland") {
+		t.Errorf("timezone = %q, %v; want the current setting", output, err)
+	}
+
+	if output, err := executeCommand(NewRootCmd(), "timezone", "local"); err != nil || !strings.Contains(output, "Time zone: local") {
+		t.Errorf("timezone local = %q, %v", output, err)
+	}
+	if cfg, _ := config.Load(); cfg == nil || cfg.Timezone != "" {
+		t.Errorf("config = %+v, want the zone cleared", cfg)
+	}
+}
+
+func TestTimezoneCmd_RejectsUnknownZone(t *testing.T) {
+	setConfigHome(t)
+
+	for _, arg := range []string{"Mars/Olympus", ""} {
+		if _, err := executeCommand(NewRootCmd(), "timezone", arg); err == nil {
+			t.Errorf("timezone %q should fail", arg)
+		}
+	}
+	if cfg, _ := config.Load(); cfg != nil {
+		t.Errorf("config = %+v, want nothing saved", cfg)
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+// newTipsCmd returns a command that shows, turns off or resets the one-time
+// tips shown while playing.
+func newTipsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tips [on|off|reset]",
+		Short: "Show or set whether tips appear while playing",
+		Long: "Show or set whether tips appear while playing. The first time you do something\n" +
+			"a tip helps with, such as typing a letter that appears more than once, a short\n" +
+			"tip shows in the status line. Each tip appears only once.\n\n" +
+			"  on     show tips not yet seen (the default)\n" +
+			"  off    never show tips\n" +
+			"  reset  turn tips on and show every tip again",
+		Example: "  # Show the current setting\n" +
+			"  unquote tips\n\n" +
+			"  # See every tip again\n" +
+			"  unquote tips reset",
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: []string{"on", "off", "reset"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadOrNewConfig()
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 1 {
+				switch args[0] {
+				case "on":
+					cfg.NoTips = false
+				case "off":
+					cfg.NoTips = true
+				case "reset":
+					cfg.NoTips = false
+					cfg.HintsSeen = nil
+				default:
+					return fmt.Errorf("unknown setting %q: expected on, off or reset", args[0])
+				}
+				if err := config.Save(cfg); err != nil {
+					return fmt.Errorf("saving config: %w", err)
+				}
+			}
+
+			switch {
+			case cfg.NoTips:
+				fmt.Fprintln(cmd.OutOrStdout(), "Tips: off")
+			case len(cfg.HintsSeen) == 0:
+				fmt.Fprintln(cmd.OutOrStdout(), "Tips: on, none seen yet")
+			default:
+				fmt.Fprintf(cmd.OutOrStdout(), "Tips: on, %d seen\n", len(cfg.HintsSeen))
+			}
+			return nil
+		},
+	}
+}
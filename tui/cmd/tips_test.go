@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+func TestTipsCmd_OffAndReset(t *testing.T) {
+	setConfigHome(t)
+	if err := config.Save(&config.Config{ClaimCode: "TIGER-MAPLE-7492", HintsSeen: []string{"propagate"}}); err != nil {
+		t.Fatalf("setup: failed to save config: %v", err)
+	}
+
+	if output, err := executeCommand(NewRootCmd(), "tips"); err != nil || !strings.Contains(output, "Tips: on, 1 seen") {
+		t.Errorf("tips = %q, %v; want on with one seen", output, err)
+	}
+
+	if output, err := executeCommand(NewRootCmd(), "tips", "off"); err != nil || !strings.Contains(output, "Tips: off") {
+		t.Fatalf("tips off = %q, %v", output, err)
+	}
+	if cfg, _ := config.Load(); cfg == nil || !cfg.NoTips || cfg.ClaimCode != "TIGER-MAPLE-7492" {
+		t.Errorf("config = %+v, want tips off alongside the claim code", cfg)
+	}
+
+	if output, err := executeCommand(NewRootCmd(), "tips", "reset"); err != nil || !strings.Contains(output, "none seen yet") {
+		t.Fatalf("tips reset = %q, %v", output, err)
+	}
+	if cfg, _ := config.Load(); cfg == nil || cfg.NoTips || len(cfg.HintsSeen) != 0 {
+		t.Errorf("config = %+v, want tips on with none seen", cfg)
+	}
+}
+
+func TestTipsCmd_RejectsUnknownSetting(t *testing.T) {
+	setConfigHome(t)
+
+	if _, err := executeCommand(NewRootCmd(), "tips", "maybe"); err == nil {
+		t.Error("tips maybe should fail")
+	}
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/app"
+)
+
+// newTutorialCmd returns a command that walks through the tutorial puzzle
+// shown after onboarding.
+func newTutorialCmd(insecure *bool) *cobra.Command {
+	var accessible bool
+
+	cmd := &cobra.Command{
+		Use:   "tutorial",
+		Short: "Walk through how to play on a small practice puzzle",
+		Long: "Walk through how to play on a small built-in puzzle: what the cipher row is,\n" +
+			"how to type a guess, what a conflict looks like and how to clear it. New\n" +
+			"players see it once after setup; run this to see it again.\n\n" +
+			"The tutorial works offline, saves nothing and doesn't count toward your stats.",
+		Example: "  unquote tutorial\n" +
+			"  unquote tutorial --accessible",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTUI(cmd, app.Options{
+				Insecure:   *insecure,
+				Accessible: accessible,
+				Tutorial:   true,
+				Ephemeral:  true,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&accessible, "accessible", false, "screen-reader friendly output: plain text, no color-only cues")
+
+	return cmd
+}
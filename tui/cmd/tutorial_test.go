@@ -0,0 +1,22 @@
+package cmd
+
+import "testing"
+
+func TestTutorialCmd_Registered(t *testing.T) {
+	root := NewRootCmd()
+	for _, sub := range root.Commands() {
+		if sub.Use == "tutorial" {
+			if sub.Flags().Lookup("accessible") == nil {
+				t.Error("expected --accessible flag to be registered on tutorial")
+			}
+			return
+		}
+	}
+	t.Error("expected 'tutorial' subcommand to be registered")
+}
+
+func TestTutorialCmd_RejectsArgs(t *testing.T) {
+	if _, err := executeCommand(NewRootCmd(), "tutorial", "extra"); err == nil {
+		t.Error("tutorial takes no arguments")
+	}
+}
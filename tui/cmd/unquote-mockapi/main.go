@@ -0,0 +1,74 @@
+// Command unquote-mockapi serves a mock Unquote API for playing and
+// developing the TUI offline:
+//
+//	go run ./cmd/unquote-mockapi -addr 127.0.0.1:8787 -seed 42
+//	UNQUOTE_API_URL=http://127.0.0.1:8787 unquote
+//
+// Puzzles come from built-in quotes, picked per date by the seed. Players,
+// sessions and duels live in memory until the server stops.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/mockapi"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var opts mockapi.Options
+	addr := flag.String("addr", "127.0.0.1:8787", "address to listen on")
+	quiet := flag.Bool("quiet", false, "don't log requests")
+	flag.Uint64Var(&opts.Seed, "seed", 0, "seed for the puzzle calendar, claim codes and injected failures")
+	flag.IntVar(&opts.Hints, "hints", 2, "hints given away per puzzle")
+	flag.DurationVar(&opts.Latency, "latency", 0, "delay before every response (e.g. 300ms)")
+	flag.Float64Var(&opts.FailRate, "fail-rate", 0, "share of requests (0-1) answered with a 500")
+	flag.Float64Var(&opts.RateLimitRate, "rate-limit-rate", 0, "share of requests (0-1) answered with a 429")
+	flag.DurationVar(&opts.RetryAfter, "retry-after", 10*time.Second, "Retry-After sent with a 429 (0 leaves it out)")
+	flag.BoolVar(&opts.Legacy, "legacy", false, "act like an older API: no /capabilities and none of the optional endpoints")
+	flag.Parse()
+
+	if opts.FailRate < 0 || opts.RateLimitRate < 0 || opts.FailRate+opts.RateLimitRate > 1 {
+		return errors.New("-fail-rate and -rate-limit-rate must be at least 0 and add up to at most 1")
+	}
+	if !*quiet {
+		opts.Logf = log.Printf
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", *addr, err)
+	}
+	srv := &http.Server{Handler: mockapi.New(opts), ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdown)
+	}()
+
+	url := "http://" + listener.Addr().String()
+	fmt.Fprintf(os.Stderr, "Mock API listening on %s\nPlay against it with: UNQUOTE_API_URL=%s unquote\n", url, url)
+	if err := srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving: %w", err)
+	}
+	return nil
+}
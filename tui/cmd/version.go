@@ -9,12 +9,20 @@ import (
 )
 
 // newVersionCmd returns a command that prints the build version information.
-func newVersionCmd() *cobra.Command {
+func newVersionCmd(output *outputFormat) *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
 		Short: "Print version information",
-		Run: func(cmd *cobra.Command, _ []string) {
-			fmt.Fprintln(cmd.OutOrStdout(), versioninfo.Get())
+		Long:  "Print the version, branch, commit, build date and Go version of this build.",
+		Example: "  unquote version\n" +
+			"  unquote version --output json",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			info := versioninfo.Get()
+			if *output == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), "version", info)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), info)
+			return nil
 		},
 	}
 }
@@ -34,3 +34,21 @@ func TestVersionCmd_NoError(t *testing.T) {
 		t.Errorf("expected no error from version subcommand, got: %v", err)
 	}
 }
+
+func TestVersionCmd_JSON(t *testing.T) {
+	output, err := executeCommand(NewRootCmd(), "version", "--output", "json")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	env := decodeEnvelope(t, output)
+	data, _ := env["data"].(map[string]any)
+	if env["command"] != "version" || data["version"] != versioninfo.Get().Version {
+		t.Errorf("envelope = %v", env)
+	}
+	for _, key := range []string{"branch", "commit", "date", "goVersion", "modified"} {
+		if _, ok := data[key]; !ok {
+			t.Errorf("data missing %q", key)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// Optional features an API deployment may serve, as named in its
+// capabilities. Deployments older than the capabilities endpoint serve none.
+const (
+	FeatureHints       = "hints"       // GET /game/{id}/solution, for give-up reveals and offline answers
+	FeatureLeaderboard = "leaderboard" // GET /stats/{date}, every player's solves of a day
+	FeatureDuel        = "duel"        // PUT /duel/{room} and its event stream
+	FeatureArchive     = "archive"     // GET /game/{id} and /game/search, past puzzles by ID or author
+)
+
+// ErrUnsupported is returned, wrapped with the feature's name, by calls the
+// API has said it doesn't serve. No request is sent.
+var ErrUnsupported = errors.New("not supported by this server")
+
+// Capabilities is what an API deployment says about itself.
+type Capabilities struct {
+	Version  string   `json:"version"`  // the API's version; empty for deployments without the endpoint
+	Features []string `json:"features"` // optional features served, e.g. FeatureDuel
+}
+
+// Has reports whether the API serves feature. Nil capabilities are not yet
+// known and have every feature, so callers try and handle errors as before.
+func (c *Capabilities) Has(feature string) bool {
+	return c == nil || slices.Contains(c.Features, feature)
+}
+
+// capabilityCache holds a client's capabilities once fetched. It is shared
+// by the client's copies (see WithContext).
+type capabilityCache struct {
+	mu   sync.Mutex
+	caps *Capabilities
+}
+
+// FetchCapabilities asks the API which optional features it serves. The
+// answer is kept for the life of the client, so only the first call sends a
+// request. A deployment without the endpoint (404) has no optional features.
+// Other failures are returned and not kept, so a later call tries again.
+func (c *Client) FetchCapabilities() (*Capabilities, error) {
+	c.capabilities.mu.Lock()
+	defer c.capabilities.mu.Unlock()
+	if c.capabilities.caps != nil {
+		return c.capabilities.caps, nil
+	}
+
+	resp, err := c.httpClient.Get(c.baseURL + "/capabilities")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch capabilities: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var caps Capabilities
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&caps); err != nil {
+			return nil, fmt.Errorf("failed to parse capabilities response: %w", err)
+		}
+	case http.StatusNotFound:
+		// Older deployment: only the endpoints every API has
+	default:
+		return nil, newAPIError(resp)
+	}
+
+	c.capabilities.caps = &caps
+	return &caps, nil
+}
+
+// Supports reports whether the API serves feature, as far as the client
+// knows: true until FetchCapabilities has an answer.
+func (c *Client) Supports(feature string) bool {
+	c.capabilities.mu.Lock()
+	defer c.capabilities.mu.Unlock()
+	return c.capabilities.caps.Has(feature)
+}
+
+// require returns an ErrUnsupported error when the API is known not to
+// serve feature.
+func (c *Client) require(feature string) error {
+	if c.Supports(feature) {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", feature, ErrUnsupported)
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchCapabilities_AskedOnce(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/capabilities" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version":"1.4.0","features":["duel","archive"]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !client.Supports(FeatureLeaderboard) {
+		t.Error("Supports() before fetching = false, want every feature assumed")
+	}
+	caps, err := client.FetchCapabilities()
+	if err != nil {
+		t.Fatalf("FetchCapabilities() error: %v", err)
+	}
+	if caps.Version != "1.4.0" || !caps.Has(FeatureDuel) || caps.Has(FeatureHints) {
+		t.Errorf("FetchCapabilities() = %+v, want version 1.4.0 with duel but not hints", caps)
+	}
+	if _, err := client.WithContext(t.Context()).FetchCapabilities(); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("%d requests, want the answer kept and shared with copies", requests)
+	}
+	if client.Supports(FeatureLeaderboard) {
+		t.Error("Supports(leaderboard) = true, want false once the API has said")
+	}
+}
+
+func TestFetchCapabilities_OlderAPI(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caps, err := client.FetchCapabilities()
+	if err != nil || len(caps.Features) != 0 {
+		t.Fatalf("FetchCapabilities() = %+v, %v; want no optional features from an API without the endpoint", caps, err)
+	}
+
+	if _, err := client.FetchGlobalStats("2026-01-20"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("FetchGlobalStats() error = %v, want ErrUnsupported", err)
+	}
+	if _, err := client.SearchPuzzles("Mark Twain"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("SearchPuzzles() error = %v, want ErrUnsupported", err)
+	}
+	if len(paths) != 1 {
+		t.Errorf("requests to %v, want only the capabilities lookup", paths)
+	}
+}
+
+func TestFetchCapabilities_FailuresNotKept(t *testing.T) {
+	healthy := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"version":"1.4.0","features":["hints"]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.FetchCapabilities(); !errors.Is(err, ErrServerUnavailable) {
+		t.Fatalf("FetchCapabilities() error = %v, want ErrServerUnavailable", err)
+	}
+	if !client.Supports(FeatureDuel) {
+		t.Error("Supports() after a failed fetch = false, want features still assumed")
+	}
+
+	healthy = true
+	if caps, err := client.FetchCapabilities(); err != nil || !caps.Has(FeatureHints) {
+		t.Errorf("FetchCapabilities() retry = %+v, %v; want the server's answer", caps, err)
+	}
+}
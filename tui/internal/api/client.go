@@ -3,16 +3,21 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
+	"sync"
 	"time"
 )
 
 const (
 	defaultBaseURL   = "https://unquote.gaur-kardashev.ts.net"
+	latestReleaseURL = "https://api.github.com/repos/bojanrajkovic/unquote/releases/latest"
+	wikiSummaryURL   = "https://en.wikipedia.org/api/rest_v1/page/summary/"
 	defaultTimeout   = 5 * time.Second
 	envAPIURL        = "UNQUOTE_API_URL"
 	maxResponseBytes = 128 * 1024 // 128KB
@@ -20,8 +25,11 @@ const (
 
 // Client handles communication with the Unquote API
 type Client struct {
-	httpClient *http.Client
-	baseURL    string
+	httpClient   *http.Client
+	capabilities *capabilityCache // optional features, once FetchCapabilities has asked
+	baseURL      string
+	releaseURL   string // GitHub "latest release" endpoint for update checks
+	wikiURL      string // Wikipedia page-summary endpoint, for quote context the API lacks
 }
 
 // NewClient creates a new API client with configuration from environment
@@ -36,13 +44,11 @@ func NewClient(insecure bool) (*Client, error) {
 	}
 
 	return &Client{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: defaultTimeout,
-			CheckRedirect: func(*http.Request, []*http.Request) error {
-				return http.ErrUseLastResponse
-			},
-		},
+		baseURL:      baseURL,
+		releaseURL:   latestReleaseURL,
+		wikiURL:      wikiSummaryURL,
+		httpClient:   newHTTPClient(baseURL, os.Getenv(envAPIToken)),
+		capabilities: &capabilityCache{},
 	}, nil
 }
 
@@ -53,16 +59,56 @@ func NewClientWithURL(baseURL string, insecure bool) (*Client, error) {
 	}
 
 	return &Client{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: defaultTimeout,
-			CheckRedirect: func(*http.Request, []*http.Request) error {
-				return http.ErrUseLastResponse
-			},
-		},
+		baseURL:      baseURL,
+		releaseURL:   latestReleaseURL,
+		wikiURL:      wikiSummaryURL,
+		httpClient:   newHTTPClient(baseURL, os.Getenv(envAPIToken)),
+		capabilities: &capabilityCache{},
 	}, nil
 }
 
+// BaseURL returns the API base URL the client sends requests to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// CloseIdleConnections closes the connections kept open for later
+// requests, for when the program exits. Requests still in flight carry on.
+func (c *Client) CloseIdleConnections() {
+	c.httpClient.CloseIdleConnections()
+}
+
+// closeIdle closes rt's idle connections, when it keeps any. The client's
+// own transports pass it on, so it reaches the one holding the connections.
+func closeIdle(rt http.RoundTripper) {
+	if c, ok := rt.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+// newHTTPClient returns the HTTP client for an API at baseURL. Redirects are
+// not followed, and every request goes through the client's middleware: it is
+// traced, carries the User-Agent and any token, is recorded in DefaultMetrics,
+// is sent again after a gateway error when safe to, and each attempt goes in
+// the request log.
+func newHTTPClient(baseURL, token string) *http.Client {
+	base := parseBaseURL(baseURL)
+	return &http.Client{
+		Timeout: defaultTimeout,
+		Transport: chain(http.DefaultTransport,
+			withTracing(base),
+			withUserAgent(),
+			withAuth(token, base),
+			withMetrics(defaultMetrics, base),
+			withRetries(defaultRetries, retryDelay),
+			withLogging(base),
+		),
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
 // validateURL checks that the URL is secure unless insecure is true.
 // Returns an error if insecure is false and the URL uses HTTP with a non-localhost host.
 func validateURL(rawURL string, insecure bool) error {
@@ -94,14 +140,16 @@ func (c *Client) FetchTodaysPuzzle() (*Puzzle, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("server returned %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
 	}
 
 	var puzzle Puzzle
 	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&puzzle); err != nil {
 		return nil, fmt.Errorf("failed to parse puzzle response: %w", err)
 	}
+	if err := Validate(&puzzle); err != nil {
+		return nil, err
+	}
 
 	return &puzzle, nil
 }
@@ -117,41 +165,140 @@ func (c *Client) FetchPuzzleByDate(date string) (*Puzzle, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("server returned %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
 	}
 
 	var puzzle Puzzle
 	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&puzzle); err != nil {
 		return nil, fmt.Errorf("failed to parse puzzle response: %w", err)
 	}
+	if err := Validate(&puzzle); err != nil {
+		return nil, err
+	}
 
 	return &puzzle, nil
 }
 
-// FetchRandomPuzzle retrieves a random puzzle
-func (c *Client) FetchRandomPuzzle() (*Puzzle, error) {
-	url := fmt.Sprintf("%s/game/random", c.baseURL)
+// FetchPuzzleByID retrieves a puzzle by its game ID
+func (c *Client) FetchPuzzleByID(gameID string) (*Puzzle, error) {
+	if err := c.require(FeatureArchive); err != nil {
+		return nil, err
+	}
 
-	resp, err := c.httpClient.Get(url)
+	reqURL := fmt.Sprintf("%s/game/%s", c.baseURL, url.PathEscape(gameID))
+
+	resp, err := c.httpClient.Get(reqURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch puzzle: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrGameNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var puzzle Puzzle
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&puzzle); err != nil {
+		return nil, fmt.Errorf("failed to parse puzzle response: %w", err)
+	}
+	if err := Validate(&puzzle); err != nil {
+		return nil, err
+	}
+
+	return &puzzle, nil
+}
+
+// maxConcurrentFetches limits how many requests FetchPuzzlesByDate has in flight
+const maxConcurrentFetches = 4
+
+// FetchPuzzlesByDate retrieves the puzzles for several dates, a few at a time.
+// The result lines up with dates; a date that failed to fetch is nil in the
+// result and its error is joined into the returned error, so callers can keep
+// whatever did arrive.
+func (c *Client) FetchPuzzlesByDate(dates []string) ([]*Puzzle, error) {
+	puzzles := make([]*Puzzle, len(dates))
+	errs := make([]error, len(dates))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentFetches)
+	for i, date := range dates {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			puzzle, err := c.FetchPuzzleByDate(date)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", date, err)
+				return
+			}
+			puzzles[i] = puzzle
+		})
+	}
+	wg.Wait()
+
+	return puzzles, errors.Join(errs...)
+}
+
+// FetchRandomPuzzle retrieves a random puzzle. A non-empty category asks the
+// server for a puzzle in that category; servers that don't filter ignore it,
+// so callers should still check the returned puzzle's Category.
+func (c *Client) FetchRandomPuzzle(category string) (*Puzzle, error) {
+	reqURL := fmt.Sprintf("%s/game/random", c.baseURL)
+	if category != "" {
+		reqURL += "?" + url.Values{"category": {category}}.Encode()
+	}
+
+	resp, err := c.httpClient.Get(reqURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch puzzle: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("server returned %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
 	}
 
 	var puzzle Puzzle
 	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&puzzle); err != nil {
 		return nil, fmt.Errorf("failed to parse puzzle response: %w", err)
 	}
+	if err := Validate(&puzzle); err != nil {
+		return nil, err
+	}
 
 	return &puzzle, nil
 }
 
+// SearchPuzzles finds archived puzzles by an author, newest first
+func (c *Client) SearchPuzzles(author string) ([]PuzzleSummary, error) {
+	if err := c.require(FeatureArchive); err != nil {
+		return nil, err
+	}
+
+	reqURL := fmt.Sprintf("%s/game/search?%s", c.baseURL, url.Values{"author": {author}}.Encode())
+
+	resp, err := c.httpClient.Get(reqURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search puzzles: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result SearchPuzzlesResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse search response: %w", err)
+	}
+
+	return result.Puzzles, nil
+}
+
 // RegisterPlayer registers a new player and returns a claim code
 func (c *Client) RegisterPlayer() (*RegisterPlayerResponse, error) {
 	url := fmt.Sprintf("%s/player", c.baseURL)
@@ -168,8 +315,7 @@ func (c *Client) RegisterPlayer() (*RegisterPlayerResponse, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("server returned %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
 	}
 
 	var result RegisterPlayerResponse
@@ -180,11 +326,59 @@ func (c *Client) RegisterPlayer() (*RegisterPlayerResponse, error) {
 	return &result, nil
 }
 
-// RecordSession records a game session for a player
-func (c *Client) RecordSession(claimCode, gameID string, completionTimeMs int64, solvedAt time.Time) error {
+// RecordSession records a game session for a player, solved at solvedAt with
+// the given assists. A zero solvedAt leaves it to the server, which uses the
+// time of recording.
+// The response body is informational (status, percentile); a missing or
+// unparseable body still counts as a successful recording and yields an
+// empty response.
+func (c *Client) RecordSession(claimCode, gameID string, completionTimeMs int64, solvedAt time.Time, assists Assists) (*RecordSessionResponse, error) {
 	url := fmt.Sprintf("%s/player/%s/session", c.baseURL, claimCode)
 
-	reqBody := RecordSessionRequest{GameID: gameID, CompletionTime: completionTimeMs, SolvedAt: solvedAt.UTC().Format(time.RFC3339)}
+	reqBody := RecordSessionRequest{Assists: assists, GameID: gameID, CompletionTime: completionTimeMs}
+	if !solvedAt.IsZero() {
+		reqBody.SolvedAt = solvedAt.UTC().Format(time.RFC3339)
+	}
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record session: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrPlayerNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, newAPIError(resp)
+	}
+
+	var result RecordSessionResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&result); err != nil {
+		return &RecordSessionResponse{}, nil
+	}
+
+	return &result, nil
+}
+
+// RecordAttempt records a puzzle the player started without solving, so the
+// server's win rate counts it. A later RecordSession for the same game turns
+// it into a solve. Recording the same attempt again is harmless.
+func (c *Client) RecordAttempt(claimCode, gameID string, attemptedAt time.Time, revealed bool) error {
+	url := fmt.Sprintf("%s/player/%s/attempt", c.baseURL, claimCode)
+
+	reqBody := RecordAttemptRequest{GameID: gameID, AttemptedAt: attemptedAt.UTC().Format(time.RFC3339), Revealed: revealed}
 	jsonBody, err := json.Marshal(reqBody)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request: %w", err)
@@ -198,17 +392,16 @@ func (c *Client) RecordSession(claimCode, gameID string, completionTimeMs int64,
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to record session: %w", err)
+		return fmt.Errorf("failed to record attempt: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return fmt.Errorf("player not found: invalid claim code")
+		return ErrPlayerNotFound
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("server returned %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp)
 	}
 
 	return nil
@@ -252,12 +445,11 @@ func (c *Client) FetchStats(claimCode string) (*PlayerStatsResponse, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("player not found: invalid claim code")
+		return nil, ErrPlayerNotFound
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("server returned %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
 	}
 
 	var result PlayerStatsResponse
@@ -268,6 +460,97 @@ func (c *Client) FetchStats(claimCode string) (*PlayerStatsResponse, error) {
 	return &result, nil
 }
 
+// FetchGlobalStats retrieves how every player did on the daily puzzle for
+// date (YYYY-MM-DD)
+func (c *Client) FetchGlobalStats(date string) (*GlobalStats, error) {
+	if err := c.require(FeatureLeaderboard); err != nil {
+		return nil, err
+	}
+
+	reqURL := fmt.Sprintf("%s/stats/%s", c.baseURL, url.PathEscape(date))
+
+	resp, err := c.httpClient.Get(reqURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch global stats: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result GlobalStats
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse global stats response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// RateDifficulty sends how hard a puzzle felt to the player, from
+// MinDifficultyRating (easy) to MaxDifficultyRating (very hard). Ratings are
+// anonymous, so the server can't tell a repeat from another player's.
+func (c *Client) RateDifficulty(gameID string, rating int) error {
+	if rating < MinDifficultyRating || rating > MaxDifficultyRating {
+		return fmt.Errorf("rating %d is out of range %d-%d", rating, MinDifficultyRating, MaxDifficultyRating)
+	}
+	reqURL := fmt.Sprintf("%s/game/%s/rating", c.baseURL, url.PathEscape(gameID))
+
+	jsonBody, err := json.Marshal(RateDifficultyRequest{Rating: rating})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", reqURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to rate difficulty: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrGameNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
+// FetchStatsForCodes retrieves statistics for several players, a few at a
+// time. The result lines up with claimCodes; a player whose stats failed to
+// load is nil in the result and its error is joined into the returned error.
+func (c *Client) FetchStatsForCodes(claimCodes []string) ([]*PlayerStatsResponse, error) {
+	stats := make([]*PlayerStatsResponse, len(claimCodes))
+	errs := make([]error, len(claimCodes))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentFetches)
+	for i, code := range claimCodes {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			s, err := c.FetchStats(code)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", code, err)
+				return
+			}
+			stats[i] = s
+		})
+	}
+	wg.Wait()
+
+	return stats, errors.Join(errs...)
+}
+
 // CheckSolution validates the user's solution against the API
 func (c *Client) CheckSolution(gameID, solution string) (*CheckResponse, error) {
 	url := fmt.Sprintf("%s/game/%s/check", c.baseURL, gameID)
@@ -291,12 +574,11 @@ func (c *Client) CheckSolution(gameID, solution string) (*CheckResponse, error)
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("game not found: invalid game ID")
+		return nil, ErrGameNotFound
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("server returned %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
 	}
 
 	var result CheckResponse
@@ -306,3 +588,202 @@ func (c *Client) CheckSolution(gameID, solution string) (*CheckResponse, error)
 
 	return &result, nil
 }
+
+// FetchSolution retrieves the plaintext solution for a puzzle, used when the
+// player gives up and reveals the answer
+func (c *Client) FetchSolution(gameID string) (*SolutionResponse, error) {
+	if err := c.require(FeatureHints); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/game/%s/solution", c.baseURL, gameID)
+
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch solution: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrGameNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result SolutionResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse solution response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// UpdateDuel reports the player's progress in a duel room, creating the room
+// on first use, and returns everyone's progress in it.
+func (c *Client) UpdateDuel(room string, progress DuelProgressRequest) (*DuelRoom, error) {
+	if err := c.require(FeatureDuel); err != nil {
+		return nil, err
+	}
+
+	escaped := url.PathEscape(room)
+	url := fmt.Sprintf("%s/duel/%s", c.baseURL, escaped)
+
+	jsonBody, err := json.Marshal(progress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update duel: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusConflict {
+		return nil, ErrDuelRoomFull
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result DuelRoom
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse duel response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// CheckHealth reports whether the API is reachable, using its liveness probe
+func (c *Client) CheckHealth() error {
+	url := fmt.Sprintf("%s/health/live", c.baseURL)
+
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to reach API: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+	return nil
+}
+
+// FetchQuoteContext retrieves background on a puzzle's quote from the API.
+// When the API has none (or predates the endpoint), it falls back to
+// Wikipedia's summary of the author. An empty gameID skips the API, for
+// puzzles it doesn't know. Returns ErrNoQuoteContext when neither has
+// anything.
+func (c *Client) FetchQuoteContext(gameID, author string) (*QuoteContext, error) {
+	if gameID != "" {
+		quoteCtx, err := c.fetchAPIQuoteContext(gameID)
+		if err == nil || !errors.Is(err, ErrNotFound) {
+			return quoteCtx, err
+		}
+	}
+	if strings.TrimSpace(author) == "" {
+		return nil, ErrNoQuoteContext
+	}
+	return c.fetchWikiQuoteContext(author)
+}
+
+// fetchAPIQuoteContext asks the API for a quote's context.
+func (c *Client) fetchAPIQuoteContext(gameID string) (*QuoteContext, error) {
+	url := fmt.Sprintf("%s/game/%s/context", c.baseURL, gameID)
+
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quote context: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result QuoteContext
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse quote context response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// fetchWikiQuoteContext builds a quote's context from the Wikipedia article
+// about its author. Ambiguous names and missing articles are ErrNoQuoteContext.
+func (c *Client) fetchWikiQuoteContext(author string) (*QuoteContext, error) {
+	title := url.PathEscape(strings.ReplaceAll(strings.TrimSpace(author), " ", "_"))
+	req, err := http.NewRequest("GET", c.wikiURL+title, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch author summary: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNoQuoteContext
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var summary wikiSummary
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&summary); err != nil {
+		return nil, fmt.Errorf("failed to parse author summary: %w", err)
+	}
+	if summary.Type == "disambiguation" || strings.TrimSpace(summary.Extract) == "" {
+		return nil, ErrNoQuoteContext
+	}
+
+	return &QuoteContext{
+		Description: summary.Description,
+		Bio:         summary.Extract,
+		URL:         summary.ContentURLs.Desktop.Page,
+	}, nil
+}
+
+// FetchLatestVersion returns the version of the newest published release,
+// without the tag's leading "v" (e.g. "0.9.0"), as reported by GitHub.
+func (c *Client) FetchLatestVersion() (string, error) {
+	req, err := http.NewRequest("GET", c.releaseURL, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch latest release: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", newAPIError(resp)
+	}
+
+	var release latestRelease
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&release); err != nil {
+		return "", fmt.Errorf("failed to parse release response: %w", err)
+	}
+	if release.TagName == "" {
+		return "", errors.New("release has no tag")
+	}
+
+	return strings.TrimPrefix(release.TagName, "v"), nil
+}
@@ -2,8 +2,13 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -104,6 +109,171 @@ func TestFetchPuzzleByDate(t *testing.T) {
 	}
 }
 
+func TestFetchPuzzleByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/game/IeEvSBy6" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Puzzle{ID: "IeEvSBy6", Date: "2025-06-01", EncryptedText: "XYZ"})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	puzzle, err := client.FetchPuzzleByID("IeEvSBy6")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if puzzle.ID != "IeEvSBy6" || puzzle.Date != "2025-06-01" {
+		t.Errorf("puzzle = %+v, want IeEvSBy6 from 2025-06-01", puzzle)
+	}
+
+	if _, err := client.FetchPuzzleByID("missing"); err == nil || !strings.Contains(err.Error(), "game not found") {
+		t.Errorf("expected a game not found error, got %v", err)
+	}
+}
+
+func TestSearchPuzzles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/game/search" || r.URL.Query().Get("author") != "Oscar Wilde" {
+			t.Errorf("request = %s, want a search for Oscar Wilde", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(SearchPuzzlesResponse{Puzzles: []PuzzleSummary{
+			{ID: "wilde-1", Date: "2025-06-01", Author: "Oscar Wilde", Category: "Humor", Difficulty: 40},
+		}})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	results, err := client.SearchPuzzles("Oscar Wilde")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "wilde-1" || results[0].Category != "Humor" {
+		t.Errorf("results = %+v, want wilde-1", results)
+	}
+}
+
+func TestFetchRandomPuzzle_Category(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/game/random" {
+			t.Errorf("expected path /game/random, got %s", r.URL.Path)
+		}
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Puzzle{ID: "random-game-id", Date: "2025-06-01", EncryptedText: "XYZ", Category: "Puns"})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	for _, category := range []string{"", "puns & jokes"} {
+		if _, err := client.FetchRandomPuzzle(category); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	want := []string{"", "category=puns+%26+jokes"}
+	if !slices.Equal(queries, want) {
+		t.Errorf("queries = %q, want %q", queries, want)
+	}
+}
+
+func TestFetchPuzzlesByDate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		date := strings.TrimPrefix(r.URL.Path, "/game/")
+		if date == "2026-01-16" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Puzzle{ID: "id-" + date, Date: date, EncryptedText: "XYZ"})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	dates := []string{"2026-01-15", "2026-01-16", "2026-01-17", "2026-01-18", "2026-01-19", "2026-01-20"}
+	puzzles, err := client.FetchPuzzlesByDate(dates)
+	if err == nil || !strings.Contains(err.Error(), "2026-01-16") {
+		t.Errorf("expected an error naming the failed date, got %v", err)
+	}
+	if len(puzzles) != len(dates) {
+		t.Fatalf("expected %d results, got %d", len(dates), len(puzzles))
+	}
+	for i, date := range dates {
+		switch {
+		case date == "2026-01-16" && puzzles[i] != nil:
+			t.Errorf("expected nil for the failed date, got %+v", puzzles[i])
+		case date != "2026-01-16" && (puzzles[i] == nil || puzzles[i].Date != date):
+			t.Errorf("puzzles[%d] = %+v, want the puzzle for %s", i, puzzles[i], date)
+		}
+	}
+}
+
+func TestFetchStatsForCodes(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		code := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/player/"), "/stats")
+		if code == "GONE-CODE-0000" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PlayerStatsResponse{ClaimCode: code})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	codes := []string{"FOX-A-0001", "GONE-CODE-0000", "FOX-B-0002", "FOX-C-0003", "FOX-D-0004", "FOX-E-0005"}
+	stats, err := client.FetchStatsForCodes(codes)
+	if err == nil || !strings.Contains(err.Error(), "GONE-CODE-0000") {
+		t.Errorf("expected an error naming the failed code, got %v", err)
+	}
+	if len(stats) != len(codes) {
+		t.Fatalf("expected %d results, got %d", len(codes), len(stats))
+	}
+	for i, code := range codes {
+		switch {
+		case code == "GONE-CODE-0000" && stats[i] != nil:
+			t.Errorf("expected nil for the failed code, got %+v", stats[i])
+		case code != "GONE-CODE-0000" && (stats[i] == nil || stats[i].ClaimCode != code):
+			t.Errorf("stats[%d] = %+v, want the stats for %s", i, stats[i], code)
+		}
+	}
+	if peak.Load() > maxConcurrentFetches {
+		t.Errorf("%d requests in flight, want at most %d", peak.Load(), maxConcurrentFetches)
+	}
+}
+
 func TestCheckSolution_Correct(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/game/test-id/check" {
@@ -181,6 +351,51 @@ func TestCheckSolution_NotFound(t *testing.T) {
 	}
 }
 
+func TestFetchSolution(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/game/test-id/solution" {
+			t.Errorf("expected path /game/test-id/solution, got %s", r.URL.Path)
+		}
+		if r.Method != "GET" {
+			t.Errorf("expected GET method, got %s", r.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(SolutionResponse{Solution: "HELLO WORLD"})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	result, err := client.FetchSolution("test-id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Solution != "HELLO WORLD" {
+		t.Errorf("expected solution 'HELLO WORLD', got %q", result.Solution)
+	}
+}
+
+func TestFetchSolution_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("game not found"))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	_, err = client.FetchSolution("invalid-id")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
 func TestNewClient_DefaultURL(t *testing.T) {
 	// Default URL is HTTPS, so insecure=false should work
 	client, err := NewClient(false)
@@ -324,7 +539,7 @@ func TestRecordSession_Created(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error creating client: %v", err)
 	}
-	err = client.RecordSession("ABCD-1234", "test-game-id", 12345, time.Now())
+	_, err = client.RecordSession("ABCD-1234", "test-game-id", 12345, time.Now(), Assists{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -348,7 +563,7 @@ func TestRecordSession_SendsSolvedAtTimestamp(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error creating client: %v", err)
 	}
-	if err = client.RecordSession("ABCD-1234", "test-game-id", 12345, solvedAt); err != nil {
+	if _, err = client.RecordSession("ABCD-1234", "test-game-id", 12345, solvedAt, Assists{}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -358,6 +573,94 @@ func TestRecordSession_SendsSolvedAtTimestamp(t *testing.T) {
 	}
 }
 
+func TestRecordSession_OmitsZeroSolvedAt(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	if _, err = client.RecordSession("ABCD-1234", "test-game-id", 12345, time.Time{}, Assists{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := body["solvedAt"]; ok {
+		t.Errorf("request = %v, want no solvedAt for a zero time", body)
+	}
+}
+
+func TestRecordSession_SendsAssists(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	assists := Assists{HintsUsed: 2, RevealUsed: true}
+	if _, err = client.RecordSession("ABCD-1234", "test-game-id", 12345, time.Now(), assists); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["hintsUsed"] != 2.0 || body["autoCheckUsed"] != false || body["revealUsed"] != true {
+		t.Errorf("request = %v, want hintsUsed 2, autoCheckUsed false, revealUsed true", body)
+	}
+}
+
+func TestRecordAttempt(t *testing.T) {
+	attemptedAt := time.Date(2026, 1, 15, 10, 30, 0, 0, time.FixedZone("CET", 60*60))
+	var got RecordAttemptRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/player/ABCD-1234/attempt" {
+			t.Errorf("request = %s %s, want POST /player/ABCD-1234/attempt", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	if err := client.RecordAttempt("ABCD-1234", "test-game-id", attemptedAt, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := RecordAttemptRequest{GameID: "test-game-id", AttemptedAt: "2026-01-15T09:30:00Z", Revealed: true}
+	if got != want {
+		t.Errorf("request body = %+v, want %+v", got, want)
+	}
+}
+
+func TestRecordAttempt_Errors(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusInternalServerError} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(status)
+		}))
+		client, err := NewClientWithURL(server.URL, true)
+		if err != nil {
+			t.Fatalf("unexpected error creating client: %v", err)
+		}
+		if err := client.RecordAttempt("ABCD-1234", "test-game-id", time.Now(), false); err == nil {
+			t.Errorf("status %d: expected an error", status)
+		}
+		server.Close()
+	}
+}
+
 func TestRecordSession_AlreadyRecorded(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -368,12 +671,55 @@ func TestRecordSession_AlreadyRecorded(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error creating client: %v", err)
 	}
-	err = client.RecordSession("ABCD-1234", "test-game-id", 12345, time.Now())
+	_, err = client.RecordSession("ABCD-1234", "test-game-id", 12345, time.Now(), Assists{})
 	if err != nil {
 		t.Fatalf("unexpected error on already recorded: %v", err)
 	}
 }
 
+func TestRecordSession_ParsesPercentile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"status":"created","percentile":72.5}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	resp, err := client.RecordSession("ABCD-1234", "test-game-id", 12345, time.Now(), Assists{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Status != "created" {
+		t.Errorf("expected status %q, got %q", "created", resp.Status)
+	}
+	if resp.Percentile == nil || *resp.Percentile != 72.5 {
+		t.Errorf("expected percentile 72.5, got %v", resp.Percentile)
+	}
+}
+
+func TestRecordSession_EmptyBodyIsSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	resp, err := client.RecordSession("ABCD-1234", "test-game-id", 12345, time.Now(), Assists{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp == nil || resp.Percentile != nil {
+		t.Errorf("expected empty response without percentile, got %+v", resp)
+	}
+}
+
 func TestRecordSession_PlayerNotFound(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
@@ -385,7 +731,7 @@ func TestRecordSession_PlayerNotFound(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error creating client: %v", err)
 	}
-	err = client.RecordSession("INVALID", "test-game-id", 12345, time.Now())
+	_, err = client.RecordSession("INVALID", "test-game-id", 12345, time.Now(), Assists{})
 	if err == nil {
 		t.Fatal("expected error for player not found, got nil")
 	}
@@ -402,7 +748,7 @@ func TestRecordSession_ServerError(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error creating client: %v", err)
 	}
-	err = client.RecordSession("ABCD-1234", "test-game-id", 12345, time.Now())
+	_, err = client.RecordSession("ABCD-1234", "test-game-id", 12345, time.Now(), Assists{})
 	if err == nil {
 		t.Fatal("expected error on server error, got nil")
 	}
@@ -526,6 +872,69 @@ func TestFetchStats_NullableFields(t *testing.T) {
 	}
 }
 
+func TestFetchGlobalStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stats/2026-02-15" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"date":"2026-02-15","solveCount":1234,"averageTime":192000,"medianTime":170000,"playedDifficulty":68}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	result, err := client.FetchGlobalStats("2026-02-15")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.SolveCount != 1234 || result.AverageTime == nil || *result.AverageTime != 192000 ||
+		result.MedianTime == nil || *result.MedianTime != 170000 || result.PlayedDifficulty == nil || *result.PlayedDifficulty != 68 {
+		t.Errorf("FetchGlobalStats() = %+v", result)
+	}
+
+	if _, err := client.FetchGlobalStats("2026-02-16"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FetchGlobalStats() for an unknown day error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRateDifficulty(t *testing.T) {
+	var got RateDifficultyRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/game/game-1/rating" {
+			http.NotFound(w, r)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	if err := client.RateDifficulty("game-1", 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Rating != 4 {
+		t.Errorf("sent rating %d, want 4", got.Rating)
+	}
+
+	if err := client.RateDifficulty("game-2", 3); !errors.Is(err, ErrGameNotFound) {
+		t.Errorf("RateDifficulty() for an unknown game error = %v, want ErrGameNotFound", err)
+	}
+	if err := client.RateDifficulty("game-1", 6); err == nil {
+		t.Error("RateDifficulty() should reject a rating out of range")
+	}
+}
+
 func TestClient_DoesNotFollowRedirects(t *testing.T) {
 	redirectTarget := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -622,3 +1031,224 @@ func TestGetSession_NetworkError(t *testing.T) {
 		t.Errorf("expected nil result on network error, got %v", result)
 	}
 }
+
+func TestCheckHealth(t *testing.T) {
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health/live" {
+			t.Errorf("expected path /health/live, got %s", r.URL.Path)
+		}
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	if err := client.CheckHealth(); err != nil {
+		t.Errorf("CheckHealth() error = %v, want nil", err)
+	}
+
+	healthy = false
+	if err := client.CheckHealth(); err == nil {
+		t.Error("CheckHealth() should fail when the API is unhealthy")
+	}
+}
+
+func TestFetchLatestVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/vnd.github+json" {
+			t.Errorf("expected GitHub JSON accept header, got %q", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tag_name": "v0.9.0", "name": "v0.9.0"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.releaseURL = server.URL
+
+	version, err := client.FetchLatestVersion()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != "0.9.0" {
+		t.Errorf("expected version 0.9.0, got %q", version)
+	}
+}
+
+func TestUpdateDuel(t *testing.T) {
+	full := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/duel/FOX-4821" {
+			t.Errorf("expected PUT /duel/FOX-4821, got %s %s", r.Method, r.URL.Path)
+		}
+		if full {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		var req DuelProgressRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.Player != "me" || req.GameID != "game-1" || req.Progress != 0.5 || req.SolvedAt != nil {
+			t.Errorf("unexpected request: %+v", req)
+		}
+
+		solvedAt := "2026-01-20T10:00:00Z"
+		_ = json.NewEncoder(w).Encode(DuelRoom{Players: []DuelPlayer{
+			{Player: "me", Progress: 0.5},
+			{Player: "them", Progress: 1, SolvedAt: &solvedAt},
+		}})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	room, err := client.UpdateDuel("FOX-4821", DuelProgressRequest{GameID: "game-1", Player: "me", Progress: 0.5})
+	if err != nil {
+		t.Fatalf("UpdateDuel() error = %v", err)
+	}
+	if len(room.Players) != 2 || room.Players[1].SolvedAt == nil {
+		t.Errorf("UpdateDuel() = %+v", room)
+	}
+
+	full = true
+	if _, err := client.UpdateDuel("FOX-4821", DuelProgressRequest{Player: "me"}); !errors.Is(err, ErrDuelRoomFull) {
+		t.Errorf("UpdateDuel() error = %v, want ErrDuelRoomFull", err)
+	}
+}
+
+func TestFetchQuoteContext_FromAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/game/game-1/context" {
+			t.Errorf("expected path /game/game-1/context, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"source": "Walden", "year": 1854, "bio": "American naturalist."}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.wikiURL = "http://wikipedia.invalid/"
+
+	quoteCtx, err := client.FetchQuoteContext("game-1", "Henry David Thoreau")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if quoteCtx.Source != "Walden" || quoteCtx.Year != 1854 || quoteCtx.Bio != "American naturalist." {
+		t.Errorf("unexpected context: %+v", quoteCtx)
+	}
+}
+
+func TestFetchQuoteContext_FallsBackToWikipedia(t *testing.T) {
+	var wikiPaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/wiki/") {
+			// An API without the context endpoint
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		wikiPaths = append(wikiPaths, r.URL.EscapedPath())
+		if r.Header.Get("User-Agent") != userAgent {
+			t.Errorf("expected User-Agent %q, got %q", userAgent, r.Header.Get("User-Agent"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/wiki/Mark_Twain":
+			_, _ = w.Write([]byte(`{"type": "standard", "description": "American writer",
+				"extract": "Samuel Langhorne Clemens, known by the pen name Mark Twain, was an American writer.",
+				"content_urls": {"desktop": {"page": "https://en.wikipedia.org/wiki/Mark_Twain"}}}`))
+		case "/wiki/Smith":
+			_, _ = w.Write([]byte(`{"type": "disambiguation", "extract": "Smith may refer to:"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.wikiURL = server.URL + "/wiki/"
+
+	quoteCtx, err := client.FetchQuoteContext("game-1", "Mark Twain")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if quoteCtx.Description != "American writer" || !strings.HasPrefix(quoteCtx.Bio, "Samuel Langhorne Clemens") ||
+		quoteCtx.URL != "https://en.wikipedia.org/wiki/Mark_Twain" || quoteCtx.Source != "" {
+		t.Errorf("unexpected context: %+v", quoteCtx)
+	}
+
+	for _, author := range []string{"Smith", "Nobody In Particular", "  "} {
+		if _, err := client.FetchQuoteContext("", author); !errors.Is(err, ErrNoQuoteContext) {
+			t.Errorf("FetchQuoteContext(%q) error = %v, want ErrNoQuoteContext", author, err)
+		}
+	}
+	if want := []string{"/wiki/Mark_Twain", "/wiki/Smith", "/wiki/Nobody_In_Particular"}; !slices.Equal(wikiPaths, want) {
+		t.Errorf("Wikipedia asked for %v, want %v", wikiPaths, want)
+	}
+}
+
+func TestFetchQuoteContext_ServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	client.wikiURL = server.URL + "/wiki/"
+
+	if _, err := client.FetchQuoteContext("game-1", "Mark Twain"); errors.Is(err, ErrNoQuoteContext) || err == nil {
+		t.Errorf("expected the server error, got %v", err)
+	}
+}
+
+func TestCloseIdleConnections(t *testing.T) {
+	closed := make(chan struct{}, 1)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closed <- struct{}{}
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	client, err := NewClientWithURL(srv.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CheckHealth(); err != nil {
+		t.Fatalf("CheckHealth() error = %v", err)
+	}
+
+	client.CloseIdleConnections()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Error("the idle connection was not closed")
+	}
+}
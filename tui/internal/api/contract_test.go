@@ -0,0 +1,150 @@
+package api
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api/vcr"
+)
+
+// The contract tests call the real API through the client and check only
+// what the TUI relies on, so they keep passing as the server adds fields.
+// By default they replay the cassettes in testdata/cassettes. With
+// UNQUOTE_CONTRACT_URL set they run against that server instead, and with
+// -record as well they rewrite the cassettes from it:
+//
+//	UNQUOTE_CONTRACT_URL=https://... go test ./internal/api -run Contract -record
+//
+// They only read, so running them against production creates no players or
+// sessions.
+
+var record = flag.Bool("record", false, "re-record contract cassettes from UNQUOTE_CONTRACT_URL")
+
+const (
+	envContractURL = "UNQUOTE_CONTRACT_URL"
+
+	// contractDate is an archived puzzle every server has.
+	contractDate = "2026-01-15"
+)
+
+// contractClient returns a client for the named cassette: replaying it,
+// live against UNQUOTE_CONTRACT_URL, or live while recording it.
+func contractClient(t *testing.T, cassette string) *Client {
+	t.Helper()
+	path := filepath.Join("testdata", "cassettes", cassette+".json")
+	liveURL := os.Getenv(envContractURL)
+
+	if liveURL == "" {
+		if *record {
+			t.Fatalf("-record needs %s", envContractURL)
+		}
+		c, err := vcr.Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client, err := NewClientWithURL("http://replay.invalid", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		replayer := vcr.NewReplayer(c)
+		client.httpClient.Transport = replayer
+		t.Cleanup(func() {
+			if left := replayer.Unplayed(); len(left) > 0 {
+				t.Errorf("%d recorded interactions were never requested, starting with %s %s",
+					len(left), left[0].Request.Method, left[0].Request.URL)
+			}
+		})
+		return client
+	}
+
+	client, err := NewClientWithURL(liveURL, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *record {
+		recorder := &vcr.Recorder{}
+		client.httpClient.Transport = recorder
+		t.Cleanup(func() {
+			if t.Failed() {
+				return
+			}
+			if err := recorder.Cassette().Save(path); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	return client
+}
+
+func TestContract_Health(t *testing.T) {
+	if err := contractClient(t, "health").CheckHealth(); err != nil {
+		t.Fatalf("CheckHealth() error: %v", err)
+	}
+}
+
+func TestContract_Puzzle(t *testing.T) {
+	client := contractClient(t, "puzzle")
+
+	p, err := client.FetchPuzzleByDate(contractDate)
+	if err != nil {
+		t.Fatalf("FetchPuzzleByDate() error: %v", err)
+	}
+	if p.ID == "" || p.Date != contractDate || p.Author == "" {
+		t.Errorf("puzzle = %+v, want an ID, the date asked for and an author", p)
+	}
+
+	byID, err := client.FetchPuzzleByID(p.ID)
+	if err != nil {
+		t.Fatalf("FetchPuzzleByID() error: %v", err)
+	}
+	if byID.EncryptedText != p.EncryptedText {
+		t.Errorf("by ID got %q, by date %q", byID.EncryptedText, p.EncryptedText)
+	}
+
+	check, err := client.CheckSolution(p.ID, "NOT THE ANSWER")
+	if err != nil {
+		t.Fatalf("CheckSolution() error: %v", err)
+	}
+	if check.Correct {
+		t.Error("a wrong answer was accepted")
+	}
+}
+
+func TestContract_RandomAndSearch(t *testing.T) {
+	client := contractClient(t, "random-and-search")
+
+	p, err := client.FetchRandomPuzzle("")
+	if err != nil {
+		t.Fatalf("FetchRandomPuzzle() error: %v", err)
+	}
+	if p.ID == "" || p.Author == "" {
+		t.Errorf("random puzzle = %+v, want an ID and an author", p)
+	}
+
+	found, err := client.SearchPuzzles(p.Author)
+	if err != nil {
+		t.Fatalf("SearchPuzzles() error: %v", err)
+	}
+	if len(found) == 0 {
+		t.Errorf("searching for %q found nothing, want at least the random puzzle", p.Author)
+	}
+	for _, s := range found {
+		if s.ID == "" || s.Date == "" {
+			t.Errorf("search result %+v, want an ID and a date", s)
+		}
+	}
+}
+
+func TestContract_UnknownPlayer(t *testing.T) {
+	client := contractClient(t, "unknown-player")
+
+	if _, err := client.FetchStats(vcr.Placeholder); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("FetchStats(unknown) error = %v, want ErrPlayerNotFound", err)
+	}
+	if got := client.GetSession(vcr.Placeholder, "no-such-game"); got != nil {
+		t.Errorf("GetSession(unknown) = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxErrorBodyBytes caps how much of an error response is kept for the message.
+const maxErrorBodyBytes = 4 * 1024
+
+// maxRetryAfter caps the wait a Retry-After header can ask for.
+const maxRetryAfter = 24 * time.Hour
+
+// Errors for classes of failed responses. Check for them with errors.Is; an
+// *APIError matches the one its status belongs to.
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrRateLimited       = errors.New("rate limited")
+	ErrServerUnavailable = errors.New("server unavailable")
+)
+
+// Not-found errors for the resources the client looks up. Both match ErrNotFound.
+var (
+	ErrGameNotFound   = fmt.Errorf("game %w: invalid game ID", ErrNotFound)
+	ErrPlayerNotFound = fmt.Errorf("player %w: invalid claim code", ErrNotFound)
+)
+
+// ErrNoQuoteContext is returned by FetchQuoteContext when neither the API nor
+// Wikipedia has anything about the quote.
+var ErrNoQuoteContext = errors.New("no context for this quote")
+
+// ErrDuelRoomFull is returned by UpdateDuel when two other players already
+// hold the room.
+var ErrDuelRoomFull = errors.New("duel room is full")
+
+// APIError is a response with a status the request didn't expect.
+type APIError struct {
+	Body   string // start of the response body, trimmed; may be empty
+	Status int    // HTTP status code
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("server returned %d", e.Status)
+	}
+	return fmt.Sprintf("server returned %d: %s", e.Status, e.Body)
+}
+
+// Is matches the error class for the response's status: ErrNotFound for 404,
+// ErrRateLimited for 429, and ErrServerUnavailable for 502, 503 and 504.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	case ErrServerUnavailable:
+		return e.Status == http.StatusBadGateway || e.Status == http.StatusServiceUnavailable || e.Status == http.StatusGatewayTimeout
+	default:
+		return false
+	}
+}
+
+// RateLimitedError is a 429 response. It matches ErrRateLimited and unwraps
+// to its *APIError.
+type RateLimitedError struct {
+	APIError
+	RetryAfter time.Duration // wait the server asked for; 0 when it didn't say
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.RetryAfter <= 0 {
+		return "server is busy, try again later"
+	}
+	return fmt.Sprintf("server is busy, try again in %s", e.RetryAfter)
+}
+
+func (e *RateLimitedError) Unwrap() error {
+	return &e.APIError
+}
+
+// parseRetryAfter reads a Retry-After header, given either as seconds or as
+// an HTTP date. Returns 0 when the header is missing, malformed or in the past.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(min(max(seconds, 0), int(maxRetryAfter/time.Second))) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return min(max(at.Sub(now), 0), maxRetryAfter)
+	}
+	return 0
+}
+
+// newAPIError reads the start of an unexpected response's body into an
+// *APIError, or a *RateLimitedError for a 429.
+func newAPIError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	apiErr := APIError{Status: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitedError{APIError: apiErr, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+	}
+	return &apiErr
+}
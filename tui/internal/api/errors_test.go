@@ -0,0 +1,119 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{status: http.StatusNotFound, want: ErrNotFound},
+		{status: http.StatusTooManyRequests, want: ErrRateLimited},
+		{status: http.StatusBadGateway, want: ErrServerUnavailable},
+		{status: http.StatusServiceUnavailable, want: ErrServerUnavailable},
+		{status: http.StatusGatewayTimeout, want: ErrServerUnavailable},
+	}
+	for _, tt := range tests {
+		err := fmt.Errorf("wrapped: %w", &APIError{Status: tt.status})
+		for _, class := range []error{ErrNotFound, ErrRateLimited, ErrServerUnavailable} {
+			if got := errors.Is(err, class); got != (class == tt.want) {
+				t.Errorf("status %d: errors.Is(%v) = %v", tt.status, class, got)
+			}
+		}
+	}
+
+	err := fmt.Errorf("wrapped: %w", &APIError{Status: http.StatusInternalServerError})
+	if errors.Is(err, ErrServerUnavailable) || errors.Is(err, ErrNotFound) {
+		t.Error("a 500 should only be a generic APIError")
+	}
+	if !errors.Is(ErrGameNotFound, ErrNotFound) || !errors.Is(ErrPlayerNotFound, ErrNotFound) {
+		t.Error("game and player not found should match ErrNotFound")
+	}
+}
+
+func TestAPIError_FromResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/game/2026-01-15":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("  maintenance\n"))
+		case "/player/ABCD-1234/stats":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	_, err = client.FetchPuzzleByDate("2026-01-15")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusServiceUnavailable || apiErr.Body != "maintenance" {
+		t.Errorf("FetchPuzzleByDate() error = %#v, want a 503 APIError with the trimmed body", err)
+	}
+	if !errors.Is(err, ErrServerUnavailable) || err.Error() != "server returned 503: maintenance" {
+		t.Errorf("FetchPuzzleByDate() error = %v, want server unavailable", err)
+	}
+
+	if _, err = client.FetchStats("ABCD-1234"); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("FetchStats() error = %v, want ErrPlayerNotFound", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "12", want: 12 * time.Second},
+		{value: " 0 ", want: 0},
+		{value: "-5", want: 0},
+		{value: "999999999999", want: maxRetryAfter},
+		{value: "Thu, 15 Jan 2026 10:01:30 GMT", want: 90 * time.Second},
+		{value: "Thu, 15 Jan 2026 09:00:00 GMT", want: 0},
+		{value: "soon", want: 0},
+		{value: "", want: 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimitedError_FromResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "12")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte("<html>too many requests</html>"))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	_, err = client.FetchTodaysPuzzle()
+	var limited *RateLimitedError
+	if !errors.As(err, &limited) || limited.RetryAfter != 12*time.Second {
+		t.Fatalf("FetchTodaysPuzzle() error = %#v, want a RateLimitedError asking for 12s", err)
+	}
+	if !errors.Is(err, ErrRateLimited) || err.Error() != "server is busy, try again in 12s" {
+		t.Errorf("error = %q, want a rate limit without the body", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusTooManyRequests {
+		t.Errorf("a rate limit should unwrap to its APIError, got %#v", apiErr)
+	}
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// metricsSampleLimit is how many recent latencies each endpoint keeps for its
+// percentiles.
+const metricsSampleLimit = 512
+
+// endpointWords are the fixed path segments kept in endpoint names. Any other
+// segment after the first is an ID, date or claim code and is shown as "*",
+// so every game's requests count toward the same endpoint.
+var endpointWords = map[string]bool{
+	"attempt":  true,
+	"check":    true,
+	"context":  true,
+	"events":   true,
+	"live":     true,
+	"random":   true,
+	"rating":   true,
+	"search":   true,
+	"session":  true,
+	"solution": true,
+	"stats":    true,
+	"today":    true,
+}
+
+// EndpointMetrics is how requests to one endpoint have gone in this process.
+// Latencies are over the most recent requests; failures are requests that
+// got no response or a 5xx.
+type EndpointMetrics struct {
+	Endpoint string // method and path pattern, e.g. "GET /game/*"; other hosts are named by host
+	P50      time.Duration
+	P90      time.Duration
+	P99      time.Duration
+	Max      time.Duration
+	Requests int
+	Failures int
+}
+
+// endpointStats is the running tally behind EndpointMetrics.
+type endpointStats struct {
+	latencies []time.Duration // ring of the last metricsSampleLimit latencies
+	requests  int
+	failures  int
+}
+
+// Metrics counts requests and their latencies per endpoint. It is safe for
+// concurrent use; every Client in the process records into DefaultMetrics.
+type Metrics struct {
+	endpoints map[string]*endpointStats
+	mu        sync.Mutex
+}
+
+var defaultMetrics = &Metrics{}
+
+// DefaultMetrics returns the metrics every Client records into.
+func DefaultMetrics() *Metrics {
+	return defaultMetrics
+}
+
+// record adds one request to endpoint's tally.
+func (m *Metrics) record(endpoint string, latency time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.endpoints == nil {
+		m.endpoints = make(map[string]*endpointStats)
+	}
+	s := m.endpoints[endpoint]
+	if s == nil {
+		s = &endpointStats{}
+		m.endpoints[endpoint] = s
+	}
+	if len(s.latencies) < metricsSampleLimit {
+		s.latencies = append(s.latencies, latency)
+	} else {
+		s.latencies[s.requests%metricsSampleLimit] = latency
+	}
+	s.requests++
+	if failed {
+		s.failures++
+	}
+}
+
+// Snapshot returns the metrics for every endpoint requested so far, by
+// endpoint name.
+func (m *Metrics) Snapshot() []EndpointMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]EndpointMetrics, 0, len(m.endpoints))
+	for name, s := range m.endpoints {
+		sorted := slices.Clone(s.latencies)
+		slices.Sort(sorted)
+		out = append(out, EndpointMetrics{
+			Endpoint: name,
+			P50:      percentile(sorted, 50),
+			P90:      percentile(sorted, 90),
+			P99:      percentile(sorted, 99),
+			Max:      sorted[len(sorted)-1],
+			Requests: s.requests,
+			Failures: s.failures,
+		})
+	}
+	slices.SortFunc(out, func(a, b EndpointMetrics) int { return strings.Compare(a.Endpoint, b.Endpoint) })
+	return out
+}
+
+// Reset forgets every request recorded so far.
+func (m *Metrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.endpoints = nil
+}
+
+// percentile returns the nearest-rank pth percentile of sorted, which must
+// not be empty.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// metricsTransport times every request it sends and records it in metrics.
+type metricsTransport struct {
+	next    http.RoundTripper
+	metrics *Metrics
+	base    *url.URL // the API's base URL; requests elsewhere are named by host
+}
+
+// parseBaseURL parses the API's base URL for endpointName. NewClient has
+// already validated it; an unparsable one names every endpoint by host.
+func parseBaseURL(baseURL string) *url.URL {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return &url.URL{}
+	}
+	return base
+}
+
+func (t *metricsTransport) CloseIdleConnections() {
+	closeIdle(t.next)
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+	t.metrics.record(endpointName(req, t.base), time.Since(start), failed)
+	return resp, err
+}
+
+// endpointName names the endpoint req is for, e.g. "POST /player/*/session".
+// Requests to hosts other than base's are named by host.
+func endpointName(req *http.Request, base *url.URL) string {
+	if req.URL.Host != base.Host {
+		return req.Method + " " + req.URL.Host
+	}
+	path := strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(base.Path, "/"))
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, seg := range segments {
+		if i > 0 && !endpointWords[seg] {
+			segments[i] = "*"
+		}
+	}
+	return req.Method + " /" + strings.Join(segments, "/")
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMetrics_Percentiles(t *testing.T) {
+	var m Metrics
+	for i := 1; i <= 100; i++ {
+		m.record("GET /game/today", time.Duration(i)*time.Millisecond, i == 100)
+	}
+
+	got := m.Snapshot()
+	want := []EndpointMetrics{{
+		Endpoint: "GET /game/today",
+		P50:      50 * time.Millisecond,
+		P90:      90 * time.Millisecond,
+		P99:      99 * time.Millisecond,
+		Max:      100 * time.Millisecond,
+		Requests: 100,
+		Failures: 1,
+	}}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Errorf("Snapshot() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMetrics_KeepsRecentSamples(t *testing.T) {
+	var m Metrics
+	for range metricsSampleLimit {
+		m.record("GET /health/live", time.Second, false)
+	}
+	for range metricsSampleLimit {
+		m.record("GET /health/live", time.Millisecond, false)
+	}
+
+	got := m.Snapshot()[0]
+	if got.Requests != 2*metricsSampleLimit || got.Max != time.Millisecond {
+		t.Errorf("Snapshot() = %+v, want every request counted and only recent latencies kept", got)
+	}
+
+	m.Reset()
+	if got := m.Snapshot(); len(got) != 0 {
+		t.Errorf("Snapshot() after Reset = %+v, want none", got)
+	}
+}
+
+func TestEndpointName(t *testing.T) {
+	base := parseBaseURL("https://unquote.example/api")
+	tests := []struct {
+		method string
+		url    string
+		want   string
+	}{
+		{"GET", "https://unquote.example/api/game/today", "GET /game/today"},
+		{"GET", "https://unquote.example/api/game/abc123", "GET /game/*"},
+		{"GET", "https://unquote.example/api/game/search?author=Twain", "GET /game/search"},
+		{"POST", "https://unquote.example/api/player/TIGER-MAPLE-7492/session", "POST /player/*/session"},
+		{"GET", "https://unquote.example/api/player/TIGER-MAPLE-7492/session/abc123", "GET /player/*/session/*"},
+		{"GET", "https://unquote.example/api/stats/2026-01-20", "GET /stats/*"},
+		{"GET", "https://en.wikipedia.org/api/rest_v1/page/summary/Mark_Twain", "GET en.wikipedia.org"},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, tt.url, http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := endpointName(req, base); got != tt.want {
+			t.Errorf("endpointName(%s %s) = %q, want %q", tt.method, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestMetricsTransport_RecordsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health/live" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	metrics := &Metrics{}
+	client := &http.Client{Transport: withMetrics(metrics, parseBaseURL(server.URL))(http.DefaultTransport)}
+	for _, path := range []string{"/health/live", "/game/missing"} {
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	got := metrics.Snapshot()
+	if len(got) != 2 || got[0].Endpoint != "GET /game/*" || got[0].Failures != 0 ||
+		got[1].Endpoint != "GET /health/live" || got[1].Failures != 1 {
+		t.Errorf("Snapshot() = %+v, want the 503 counted as a failure and the 404 not", got)
+	}
+}
+
+func TestMetricsTransport_RecordsTransportErrors(t *testing.T) {
+	metrics := &Metrics{}
+	failing := roundTripFunc(func(*http.Request) (*http.Response, error) { return nil, errors.New("connection refused") })
+	client := &http.Client{Transport: withMetrics(metrics, parseBaseURL("http://localhost"))(failing)}
+	if _, err := client.Get("http://localhost/health/live"); err == nil {
+		t.Fatal("expected the transport error")
+	}
+
+	if got := metrics.Snapshot(); len(got) != 1 || got[0].Requests != 1 || got[0].Failures != 1 {
+		t.Errorf("Snapshot() = %+v, want one failed request", got)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,217 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/versioninfo"
+)
+
+const (
+	// envAPIToken names a bearer token sent to the API, for deployments
+	// behind a proxy that asks for one.
+	envAPIToken = "UNQUOTE_API_TOKEN"
+	// defaultRetries is how many times a GET or HEAD is sent again after a
+	// gateway error.
+	defaultRetries = 1
+	// retryDelay is the wait before sending a request again.
+	retryDelay = 250 * time.Millisecond
+)
+
+// userAgent identifies the client and its version to the API, GitHub and
+// Wikipedia, which asks API clients to say who they are.
+var userAgent = "unquote-tui/" + versioninfo.Get().Version + " (https://github.com/bojanrajkovic/unquote)"
+
+// middleware adds one concern to every request the client sends by wrapping
+// the transport below it. Transports it returns should pass
+// CloseIdleConnections on with closeIdle.
+type middleware func(next http.RoundTripper) http.RoundTripper
+
+// chain stacks middleware over base. The first one listed sees each request
+// first and its response last.
+func chain(base http.RoundTripper, mws ...middleware) http.RoundTripper {
+	rt := base
+	for i := len(mws) - 1; i >= 0; i-- {
+		rt = mws[i](rt)
+	}
+	return rt
+}
+
+// withTracing sends each request in a client span; see tracingTransport.
+func withTracing(base *url.URL) middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &tracingTransport{next: next, base: base}
+	}
+}
+
+// withMetrics records each request in metrics; see metricsTransport.
+func withMetrics(metrics *Metrics, base *url.URL) middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &metricsTransport{next: next, metrics: metrics, base: base}
+	}
+}
+
+// withHeader sets a header on requests that don't have it, to the API's
+// host only when apiOnly is set. The request is cloned first, since a
+// RoundTripper must not change the one it is given.
+func withHeader(name, value string, base *url.URL, apiOnly bool) middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &headerTransport{next: next, name: name, value: value, base: base, apiOnly: apiOnly}
+	}
+}
+
+// headerTransport sets one header on the requests it sends; see withHeader.
+type headerTransport struct {
+	next    http.RoundTripper
+	base    *url.URL
+	name    string
+	value   string
+	apiOnly bool
+}
+
+func (t *headerTransport) CloseIdleConnections() {
+	closeIdle(t.next)
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get(t.name) != "" || (t.apiOnly && req.URL.Host != t.base.Host) {
+		return t.next.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set(t.name, t.value)
+	return t.next.RoundTrip(req)
+}
+
+// withUserAgent sends userAgent with every request.
+func withUserAgent() middleware {
+	return withHeader("User-Agent", userAgent, nil, false)
+}
+
+// withAuth sends token as a bearer token to the API, never to other hosts.
+// An empty token sends nothing.
+func withAuth(token string, base *url.URL) middleware {
+	if token == "" {
+		return func(next http.RoundTripper) http.RoundTripper { return next }
+	}
+	return withHeader("Authorization", "Bearer "+token, base, true)
+}
+
+// withRetries sends a request again after a gateway error; see retryTransport.
+func withRetries(retries int, delay time.Duration) middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &retryTransport{next: next, retries: retries, delay: delay}
+	}
+}
+
+// retryTransport sends a GET or HEAD again, up to retries times, when a
+// gateway in front of the API answers 502, 503 or 504 without a Retry-After,
+// which is usually over in a moment. Other methods aren't sent again, since
+// the server may have acted on them, and neither are requests that got no
+// answer, which have usually spent the client's timeout.
+type retryTransport struct {
+	next    http.RoundTripper
+	retries int
+	delay   time.Duration
+}
+
+func (t *retryTransport) CloseIdleConnections() {
+	closeIdle(t.next)
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if err != nil || attempt >= t.retries || !retryable(req, resp) {
+			return resp, err
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(t.delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable reports whether resp is a gateway error worth sending req again for.
+func retryable(req *http.Request, resp *http.Response) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return resp.Header.Get("Retry-After") == ""
+	default:
+		return false
+	}
+}
+
+// requestLog is where loggingTransport writes; nil while logging is off.
+var requestLog atomic.Pointer[lockedWriter]
+
+// lockedWriter serializes writes from requests in flight at once.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// SetRequestLog makes every client log each request it sends to w, one line
+// each: its endpoint (named like the metrics', so claim codes stay out),
+// status or error and how long it took. A nil w turns logging off.
+func SetRequestLog(w io.Writer) {
+	if w == nil {
+		requestLog.Store(nil)
+		return
+	}
+	requestLog.Store(&lockedWriter{w: w})
+}
+
+// withLogging logs each request to the request log; see SetRequestLog.
+func withLogging(base *url.URL) middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &loggingTransport{next: next, base: base}
+	}
+}
+
+// loggingTransport writes each request it sends to the request log, when
+// one is set.
+type loggingTransport struct {
+	next http.RoundTripper
+	base *url.URL // the API's base URL; requests elsewhere are named by host
+}
+
+func (t *loggingTransport) CloseIdleConnections() {
+	closeIdle(t.next)
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	log := requestLog.Load()
+	if log == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	outcome := fmt.Sprintf("error=%q", fmt.Sprint(err))
+	if err == nil {
+		outcome = fmt.Sprintf("status=%d", resp.StatusCode)
+	}
+	log.mu.Lock()
+	fmt.Fprintf(log.w, "%s request %q %s took=%s\n", start.Format(time.RFC3339), endpointName(req, t.base), outcome, roundMillis(time.Since(start)))
+	log.mu.Unlock()
+	return resp, err
+}
+
+// roundMillis rounds d to the millisecond for the request log.
+func roundMillis(d time.Duration) time.Duration {
+	return d.Round(time.Millisecond)
+}
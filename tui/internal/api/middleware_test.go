@@ -0,0 +1,184 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestChain_FirstMiddlewareRunsFirst(t *testing.T) {
+	var order []string
+	mark := func(name string) middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		order = append(order, "base")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/health/live", http.NoBody)
+	if _, err := chain(base, mark("outer"), mark("inner")).RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(order, " "); got != "outer inner base" {
+		t.Errorf("order = %q, want outer inner base", got)
+	}
+}
+
+func TestClient_SendsUserAgentAndToken(t *testing.T) {
+	t.Setenv(envAPIToken, "s3cret")
+	var agent, auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agent, auth = r.Header.Get("User-Agent"), r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CheckHealth(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(agent, "unquote-tui/") || agent != userAgent {
+		t.Errorf("User-Agent = %q, want %q", agent, userAgent)
+	}
+	if auth != "Bearer s3cret" {
+		t.Errorf("Authorization = %q, want the token from %s", auth, envAPIToken)
+	}
+}
+
+func TestWithAuth_OnlyToTheAPI(t *testing.T) {
+	var auth string
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		auth = req.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	rt := withAuth("s3cret", parseBaseURL("https://api.example"))(base)
+
+	req := httptest.NewRequest(http.MethodGet, "https://en.wikipedia.org/api/rest_v1/page/summary/Mark_Twain", http.NoBody)
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if auth != "" {
+		t.Errorf("Authorization = %q sent to another host, want none", auth)
+	}
+	if req.Header.Get("Authorization") != "" {
+		t.Error("the caller's request should not be changed")
+	}
+
+	rt = withAuth("", parseBaseURL("https://api.example"))(base)
+	if _, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, "https://api.example/health/live", http.NoBody)); err != nil {
+		t.Fatal(err)
+	}
+	if auth != "" {
+		t.Errorf("Authorization = %q without a token, want none", auth)
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		retryAfter string
+		status     int
+		wantCalls  int32
+	}{
+		{"GET after 503", http.MethodGet, "", http.StatusServiceUnavailable, 2},
+		{"GET after 504", http.MethodGet, "", http.StatusGatewayTimeout, 2},
+		{"GET after 500", http.MethodGet, "", http.StatusInternalServerError, 1},
+		{"GET with Retry-After", http.MethodGet, "30", http.StatusServiceUnavailable, 1},
+		{"POST after 503", http.MethodPost, "", http.StatusServiceUnavailable, 1},
+		{"GET after 200", http.MethodGet, "", http.StatusOK, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: withRetries(defaultRetries, 0)(http.DefaultTransport)}
+			req, err := http.NewRequest(tt.method, server.URL+"/health/live", http.NoBody)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want the last answer's %d", resp.StatusCode, tt.status)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("server saw %d requests, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_RecoversAfterGatewayError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CheckHealth(); err != nil {
+		t.Errorf("CheckHealth() = %v, want the retry to succeed", err)
+	}
+}
+
+func TestSetRequestLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var log bytes.Buffer
+	SetRequestLog(&log)
+	t.Cleanup(func() { SetRequestLog(nil) })
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = client.FetchStats("TIGER-MAPLE-7492")
+
+	line := log.String()
+	if !strings.Contains(line, `request "GET /player/*/stats" status=404 took=`) {
+		t.Errorf("request log = %q, want the endpoint and status", line)
+	}
+	if strings.Contains(line, "TIGER-MAPLE-7492") {
+		t.Error("the request log should not contain claim codes")
+	}
+
+	SetRequestLog(nil)
+	log.Reset()
+	_ = client.CheckHealth()
+	if log.Len() != 0 {
+		t.Errorf("request log = %q after turning it off, want nothing", log.String())
+	}
+}
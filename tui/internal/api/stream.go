@@ -0,0 +1,194 @@
+package api
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reconnect delays for event streams. The delay doubles after each failed
+// attempt and resets once a connection is established.
+const (
+	streamMinBackoff = time.Second
+	streamMaxBackoff = 30 * time.Second
+)
+
+// Event is one server-sent event.
+type Event struct {
+	Type string // event name; "message" when the server gives none
+	ID   string // last event ID seen on the stream, sent back on reconnect
+	Data string // payload, with multi-line data joined by "\n"
+}
+
+// Decode unmarshals the event's JSON payload into v.
+func (e Event) Decode(v any) error {
+	if err := json.Unmarshal([]byte(e.Data), v); err != nil {
+		return fmt.Errorf("failed to parse %s event: %w", e.Type, err)
+	}
+	return nil
+}
+
+// Stream is a server-sent event subscription. It reconnects with backoff
+// until closed, resuming from the last event ID it saw.
+type Stream struct {
+	events chan Event
+	cancel context.CancelFunc
+}
+
+// Events returns the channel events are delivered on. It is closed once the
+// stream is closed.
+func (s *Stream) Events() <-chan Event {
+	return s.events
+}
+
+// Close stops the stream and closes its events channel. Safe to call more
+// than once.
+func (s *Stream) Close() {
+	s.cancel()
+}
+
+// Subscribe opens a server-sent event stream at the given API path (e.g.
+// "/duel/FOX-4821/events"). Connection errors are never returned: the stream
+// keeps retrying in the background until closed.
+func (c *Client) Subscribe(path string) *Stream {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Stream{events: make(chan Event), cancel: cancel}
+	go c.runStream(ctx, c.baseURL+path, s.events)
+	return s
+}
+
+// SubscribeDuel streams updates to a duel room. Each "room" event carries a
+// DuelRoom with every player's progress.
+func (c *Client) SubscribeDuel(room string) *Stream {
+	return c.Subscribe(fmt.Sprintf("/duel/%s/events", url.PathEscape(room)))
+}
+
+// streamState is what carries over between reconnects of one stream.
+type streamState struct {
+	lastID  string
+	backoff time.Duration // delay before the next reconnect
+	retry   time.Duration // base delay requested by the server; 0 uses streamMinBackoff
+}
+
+// runStream connects, reads and reconnects until ctx is canceled, then
+// closes events.
+func (c *Client) runStream(ctx context.Context, streamURL string, events chan<- Event) {
+	defer close(events)
+
+	// Streams stay open indefinitely, so they can't share the client's timeout
+	httpClient := &http.Client{
+		Transport:     c.httpClient.Transport,
+		CheckRedirect: c.httpClient.CheckRedirect,
+	}
+
+	state := streamState{backoff: streamMinBackoff}
+	for {
+		connected := readStream(ctx, httpClient, streamURL, &state, events)
+		if ctx.Err() != nil {
+			return
+		}
+
+		base := cmp.Or(state.retry, streamMinBackoff)
+		if connected {
+			state.backoff = base
+		}
+
+		timer := time.NewTimer(state.backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		state.backoff = min(max(state.backoff*2, base), streamMaxBackoff)
+	}
+}
+
+// readStream makes one connection and delivers its events until the server
+// closes it, the connection drops, or ctx is canceled. Reports whether the
+// server accepted the connection; failures are retried, so they aren't returned.
+func readStream(ctx context.Context, httpClient *http.Client, streamURL string, state *streamState, events chan<- Event) bool {
+	req, err := http.NewRequestWithContext(ctx, "GET", streamURL, http.NoBody)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if state.lastID != "" {
+		req.Header.Set("Last-Event-ID", state.lastID)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	mediaType, _, _ := strings.Cut(resp.Header.Get("Content-Type"), ";")
+	if resp.StatusCode != http.StatusOK || strings.TrimSpace(mediaType) != "text/event-stream" {
+		return false
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 4096), maxResponseBytes)
+
+	var pending pendingEvent
+	for scanner.Scan() {
+		event, ok := pending.parseLine(scanner.Text(), state)
+		if !ok {
+			continue
+		}
+		select {
+		case events <- event:
+		case <-ctx.Done():
+			return true
+		}
+	}
+
+	return true
+}
+
+// pendingEvent collects the fields of the event being read, until a blank
+// line dispatches it.
+type pendingEvent struct {
+	eventType string
+	data      []string
+}
+
+// parseLine applies one line of the stream to the pending event, or to state
+// for the id and retry fields. It returns the event a blank line dispatched;
+// one without data is dropped.
+func (p *pendingEvent) parseLine(line string, state *streamState) (Event, bool) {
+	if line == "" {
+		event := Event{Type: cmp.Or(p.eventType, "message"), ID: state.lastID, Data: strings.Join(p.data, "\n")}
+		dispatch := len(p.data) > 0
+		*p = pendingEvent{}
+		return event, dispatch
+	}
+
+	field, value, _ := strings.Cut(line, ":")
+	value = strings.TrimPrefix(value, " ")
+	switch field {
+	case "event":
+		p.eventType = value
+	case "data":
+		p.data = append(p.data, value)
+	case "id":
+		if !strings.ContainsRune(value, 0) {
+			state.lastID = value
+		}
+	case "retry":
+		if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+			state.retry = time.Duration(ms) * time.Millisecond
+		}
+	}
+	// Lines starting with ":" are comments (keep-alives) and unknown fields are ignored
+	return Event{}, false
+}
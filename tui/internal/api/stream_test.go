@@ -0,0 +1,110 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// nextEvent waits for the stream's next event.
+func nextEvent(t *testing.T, s *Stream) Event {
+	t.Helper()
+	select {
+	case event, ok := <-s.Events():
+		if !ok {
+			t.Fatal("stream closed early")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return Event{}
+}
+
+func TestSubscribe_ParsesAndReconnects(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/duel/FOX-4821/events" || r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("unexpected request: %s %s", r.URL.Path, r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		switch connections.Add(1) {
+		case 1:
+			// Reconnect quickly, then drop the connection after two events
+			fmt.Fprint(w, "retry: 10\n\nid: 1\nevent: room\ndata: {\"players\":[{\"player\":\"them\",\"progress\":0.5}]}\n\n")
+			fmt.Fprint(w, ": keep-alive\n\ndata: a\ndata: b\n\n")
+		default:
+			if got := r.Header.Get("Last-Event-ID"); got != "1" {
+				t.Errorf("Last-Event-ID = %q, want 1", got)
+			}
+			fmt.Fprint(w, "id: 2\nevent: room\ndata: {}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	stream := client.SubscribeDuel("FOX-4821")
+
+	event := nextEvent(t, stream)
+	var room DuelRoom
+	if err := event.Decode(&room); err != nil || event.Type != "room" || event.ID != "1" {
+		t.Fatalf("first event = %+v, decode error %v", event, err)
+	}
+	if len(room.Players) != 1 || room.Players[0].Progress != 0.5 {
+		t.Errorf("decoded room = %+v", room)
+	}
+
+	if event := nextEvent(t, stream); event.Type != "message" || event.Data != "a\nb" {
+		t.Errorf("second event = %+v, want a default-typed event with joined data", event)
+	}
+
+	if event := nextEvent(t, stream); event.ID != "2" {
+		t.Errorf("event after reconnect = %+v", event)
+	}
+
+	stream.Close()
+	stream.Close()
+	select {
+	case _, ok := <-stream.Events():
+		if ok {
+			t.Error("expected no more events after Close")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("events channel was not closed")
+	}
+}
+
+func TestSubscribe_RetriesRejectedConnections(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if connections.Add(1) == 1 {
+			http.Error(w, "not yet", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		fmt.Fprint(w, "data: hello\n\n")
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	stream := client.Subscribe("/events")
+	defer stream.Close()
+
+	if event := nextEvent(t, stream); event.Data != "hello" {
+		t.Errorf("event = %+v", event)
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the client's spans.
+const tracerName = "github.com/bojanrajkovic/unquote/tui/internal/api"
+
+// tracingTransport sends each request in a client span named for its
+// endpoint and passes the trace on in the headers of requests to the API;
+// other hosts, like Wikipedia, aren't sent it. Until tracing is set up the
+// global tracer and propagator are no-ops, so this adds nothing to the
+// request.
+type tracingTransport struct {
+	next http.RoundTripper
+	base *url.URL // the API's base URL; requests elsewhere are named by host
+}
+
+func (t *tracingTransport) CloseIdleConnections() {
+	closeIdle(t.next)
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := endpointName(req, t.base)
+	ctx, span := otel.Tracer(tracerName).Start(req.Context(), endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Hostname()),
+			// The path pattern, not the path: paths carry claim codes
+			attribute.String("url.template", endpoint),
+		))
+	defer span.End()
+
+	req = req.Clone(ctx)
+	if req.URL.Host == t.base.Host {
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case resp.StatusCode >= http.StatusInternalServerError:
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		span.SetStatus(codes.Error, fmt.Sprintf("server returned %d", resp.StatusCode))
+	default:
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	}
+	return resp, err
+}
+
+// parentTransport starts each request under the span in ctx, unless the
+// request carries a span of its own. See Client.WithContext.
+type parentTransport struct {
+	ctx  context.Context
+	next http.RoundTripper
+}
+
+func (t *parentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !trace.SpanContextFromContext(req.Context()).IsValid() {
+		req = req.WithContext(trace.ContextWithSpan(req.Context(), trace.SpanFromContext(t.ctx)))
+	}
+	return t.next.RoundTrip(req)
+}
+
+// WithContext returns a copy of the client whose requests are traced as
+// children of the span in ctx, e.g. the phase of play they belong to.
+// Cancellation and deadlines in ctx are not applied.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	httpClient := *c.httpClient
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	httpClient.Transport = &parentTransport{ctx: ctx, next: next}
+	clone.httpClient = &httpClient
+	return &clone
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordSpans installs a tracer provider and propagator that keep every span
+// for the test, and no-ops after. The globals can't go back to their
+// defaults, which forward to whatever was set first.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+	return recorder
+}
+
+// spanAttr returns the span's attribute named key.
+func spanAttr(span sdktrace.ReadOnlySpan, key string) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracing_SpanPerRequest(t *testing.T) {
+	recorder := recordSpans(t)
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CheckHealth(); err == nil {
+		t.Fatal("expected the 503 to fail the health check")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want one per request", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "GET /health/live" || span.Status().Code != codes.Error {
+		t.Errorf("span %q, status %v; want the endpoint's name, failed", span.Name(), span.Status())
+	}
+	if got := spanAttr(span, "http.response.status_code").AsInt64(); got != http.StatusServiceUnavailable {
+		t.Errorf("status code attribute = %d, want 503", got)
+	}
+	if traceparent == "" || traceparent[3:35] != span.SpanContext().TraceID().String() {
+		t.Errorf("traceparent = %q, want the request's trace passed to the API", traceparent)
+	}
+}
+
+func TestTracing_NoHeadersWhenOff(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CheckHealth(); err != nil {
+		t.Fatal(err)
+	}
+	if traceparent != "" {
+		t.Errorf("traceparent = %q, want nothing sent with tracing off", traceparent)
+	}
+}
+
+func TestTracing_NoHeadersToOtherHosts(t *testing.T) {
+	recordSpans(t)
+	var traceparent string
+	wiki := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer wiki.Close()
+
+	client, err := NewClientWithURL("http://localhost:1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.wikiURL = wiki.URL + "/"
+	_, _ = client.fetchWikiQuoteContext("Mark Twain")
+	if traceparent != "" {
+		t.Errorf("traceparent = %q, want the trace kept from other hosts", traceparent)
+	}
+}
+
+func TestWithContext_ParentsRequests(t *testing.T) {
+	recorder := recordSpans(t)
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "solve")
+	if err := client.WithContext(ctx).CheckHealth(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CheckHealth(); err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want two requests and the parent", len(spans))
+	}
+	if got := spans[0].Parent().SpanID(); got != parent.SpanContext().SpanID() {
+		t.Errorf("request through WithContext has parent %v, want %v", got, parent.SpanContext().SpanID())
+	}
+	if spans[1].Parent().IsValid() {
+		t.Error("the original client's requests should stay unparented")
+	}
+}
@@ -1,11 +1,33 @@
 package api
 
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
 // Hint represents a single cipher-to-plain letter mapping hint
 type Hint struct {
 	CipherLetter string `json:"cipherLetter"`
 	PlainLetter  string `json:"plainLetter"`
 }
 
+// Letters returns the hint's cipher and plain letters as runes. ok is false
+// when either is anything but a single letter.
+func (h Hint) Letters() (cipher, plain rune, ok bool) {
+	cipher, cipherOK := singleLetter(h.CipherLetter)
+	plain, plainOK := singleLetter(h.PlainLetter)
+	return cipher, plain, cipherOK && plainOK
+}
+
+// singleLetter returns the letter s holds when s is exactly one letter.
+func singleLetter(s string) (rune, bool) {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || size != len(s) || !unicode.IsLetter(r) {
+		return 0, false
+	}
+	return r, true
+}
+
 // Puzzle represents the puzzle response from the API
 type Puzzle struct {
 	ID            string `json:"id"`
@@ -17,6 +39,20 @@ type Puzzle struct {
 	Difficulty    int    `json:"difficulty"`
 }
 
+// PuzzleSummary describes a puzzle in search results, without its text
+type PuzzleSummary struct {
+	ID         string `json:"id"`
+	Date       string `json:"date"`
+	Author     string `json:"author"`
+	Category   string `json:"category"`
+	Difficulty int    `json:"difficulty"`
+}
+
+// SearchPuzzlesResponse represents the response from the puzzle search endpoint
+type SearchPuzzlesResponse struct {
+	Puzzles []PuzzleSummary `json:"puzzles"`
+}
+
 // CheckRequest represents the request body for checking a solution
 type CheckRequest struct {
 	Solution string `json:"solution"`
@@ -27,21 +63,73 @@ type CheckResponse struct {
 	Correct bool `json:"correct"`
 }
 
+// SolutionResponse is the response from the solution reveal endpoint
+type SolutionResponse struct {
+	Solution string `json:"solution"` // decoded plaintext, aligned with the puzzle's encrypted text
+}
+
 // RegisterPlayerResponse represents the response from the register player endpoint
 type RegisterPlayerResponse struct {
 	ClaimCode string `json:"claimCode"`
 }
 
+// Assists is the help a player had on a solve, so stats can tell clean solves
+// from assisted ones.
+type Assists struct {
+	AssistLevel     AssistLevel `json:"assistLevel,omitempty"` // the fields below summed up
+	HintsUsed       int         `json:"hintsUsed"`             // letters given on request, beyond the puzzle's own hints
+	AutoCheckUsed   bool        `json:"autoCheckUsed"`         // wrong letters were flagged while solving
+	RevealUsed      bool        `json:"revealUsed"`            // part of the answer was revealed
+	SuggestionsUsed bool        `json:"suggestionsUsed"`       // letters were typed while dictionary words were suggested
+}
+
+// AssistLevel sums up the help a player had on a solve: empty for none,
+// "light" for pointers such as suggested words, "heavy" for answers given.
+type AssistLevel string
+
+const (
+	AssistNone  AssistLevel = ""
+	AssistLight AssistLevel = "light"
+	AssistHeavy AssistLevel = "heavy"
+)
+
+// Any reports whether the player had any help.
+func (a Assists) Any() bool {
+	return a.AssistLevel != AssistNone || a.HintsUsed > 0 || a.AutoCheckUsed || a.RevealUsed || a.SuggestionsUsed
+}
+
 // RecordSessionRequest represents the request body for recording a game session
 type RecordSessionRequest struct {
+	Assists
 	GameID         string `json:"gameId"`
-	SolvedAt       string `json:"solvedAt"`       // RFC3339 timestamp when the puzzle was solved
-	CompletionTime int64  `json:"completionTime"` // milliseconds
+	SolvedAt       string `json:"solvedAt,omitempty"` // RFC3339 timestamp when the puzzle was solved; when omitted, the server uses the time of recording
+	CompletionTime int64  `json:"completionTime"`     // milliseconds
+}
+
+// RecordAttemptRequest represents the request body for recording a puzzle the
+// player started but hasn't solved
+type RecordAttemptRequest struct {
+	GameID      string `json:"gameId"`
+	AttemptedAt string `json:"attemptedAt"` // RFC3339 timestamp of the player's last move
+	Revealed    bool   `json:"revealed"`    // the player gave up and revealed the answer
+}
+
+// The range of a difficulty rating, how hard a puzzle felt to the player
+const (
+	MinDifficultyRating = 1 // easy
+	MaxDifficultyRating = 5 // very hard
+)
+
+// RateDifficultyRequest represents the request body for rating how hard a
+// puzzle felt
+type RateDifficultyRequest struct {
+	Rating int `json:"rating"` // MinDifficultyRating to MaxDifficultyRating
 }
 
 // RecordSessionResponse represents the response from the record session endpoint
 type RecordSessionResponse struct {
-	Status string `json:"status"` // "created" or "recorded"
+	Percentile *float64 `json:"percentile,omitempty"` // share of players this solve beat (0-100), nullable
+	Status     string   `json:"status"`               // "created" or "recorded"
 }
 
 // SessionLookupResponse represents the response from the session lookup endpoint
@@ -50,17 +138,28 @@ type SessionLookupResponse struct {
 	CompletionTime float64 `json:"completionTime"` // milliseconds
 }
 
+// GlobalStats is how every player did on one day's puzzle
+type GlobalStats struct {
+	AverageTime      *float64 `json:"averageTime"`                // milliseconds, nullable: null until someone solves
+	MedianTime       *float64 `json:"medianTime"`                 // milliseconds, nullable
+	PlayedDifficulty *int     `json:"playedDifficulty,omitempty"` // 0-100 like Puzzle.Difficulty, from how players fared against other puzzles; nil when the server can't tell yet
+	Date             string   `json:"date"`                       // YYYY-MM-DD
+	SolveCount       int      `json:"solveCount"`
+}
+
 // RecentSolve represents a single recent solve entry in player stats
 type RecentSolve struct {
-	Date           string  `json:"date"`           // YYYY-MM-DD
-	CompletionTime float64 `json:"completionTime"` // milliseconds
+	Date           string      `json:"date"`                  // YYYY-MM-DD
+	AssistLevel    AssistLevel `json:"assistLevel,omitempty"` // empty for clean solves, and from servers that don't track assists
+	CompletionTime float64     `json:"completionTime"`        // milliseconds
 }
 
 // PlayerStatsResponse represents the response from the player stats endpoint
 type PlayerStatsResponse struct {
 	ClaimCode     string        `json:"claimCode"`
-	BestTime      *float64      `json:"bestTime"`    // milliseconds, nullable
-	AverageTime   *float64      `json:"averageTime"` // milliseconds, nullable
+	BestTime      *float64      `json:"bestTime"`              // milliseconds, nullable
+	AverageTime   *float64      `json:"averageTime"`           // milliseconds, nullable
+	CleanSolves   *int          `json:"cleanSolves,omitempty"` // solves without assists; nil when the server doesn't separate them
 	RecentSolves  []RecentSolve `json:"recentSolves"`
 	WinRate       float64       `json:"winRate"` // 0.0-1.0
 	GamesPlayed   int           `json:"gamesPlayed"`
@@ -68,3 +167,53 @@ type PlayerStatsResponse struct {
 	CurrentStreak int           `json:"currentStreak"`
 	BestStreak    int           `json:"bestStreak"`
 }
+
+// DuelProgressRequest represents the request body for reporting progress in a duel room
+type DuelProgressRequest struct {
+	SolvedAt *string `json:"solvedAt,omitempty"` // RFC3339 timestamp; set once the player has solved
+	GameID   string  `json:"gameId"`
+	Player   string  `json:"player"`   // anonymous per-run ID, never the claim code
+	Progress float64 `json:"progress"` // 0.0-1.0 share of letters filled
+}
+
+// DuelPlayer is one player's progress in a duel room
+type DuelPlayer struct {
+	SolvedAt *string `json:"solvedAt"` // RFC3339 timestamp, nullable until solved
+	Player   string  `json:"player"`
+	Progress float64 `json:"progress"` // 0.0-1.0
+}
+
+// DuelRoom represents the response from the duel endpoint: everyone who has
+// reported progress in the room, including the caller
+type DuelRoom struct {
+	Players []DuelPlayer `json:"players"`
+}
+
+// QuoteContext is background on a puzzle's quote: the work it comes from and
+// its year, when the server knows them, and a short bio of the author. Any
+// field may be empty
+type QuoteContext struct {
+	Source      string `json:"source,omitempty"`      // work the quote is from
+	Description string `json:"description,omitempty"` // the author in a few words, e.g. "American writer"
+	Bio         string `json:"bio,omitempty"`         // a paragraph about the author
+	URL         string `json:"url,omitempty"`         // where to read more
+	Year        int    `json:"year,omitempty"`        // year of the source; 0 when unknown
+}
+
+// wikiSummary is the part of Wikipedia's page-summary response the quote
+// context fallback needs
+type wikiSummary struct {
+	Type        string `json:"type"` // "standard", or "disambiguation" for an ambiguous name
+	Description string `json:"description"`
+	Extract     string `json:"extract"`
+	ContentURLs struct {
+		Desktop struct {
+			Page string `json:"page"`
+		} `json:"desktop"`
+	} `json:"content_urls"`
+}
+
+// latestRelease is the part of GitHub's latest-release response the update check needs
+type latestRelease struct {
+	TagName string `json:"tag_name"`
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Difficulty scores the API assigns, inclusive.
+const (
+	minDifficulty = 0
+	maxDifficulty = 100
+)
+
+// ErrInvalidPuzzle is wrapped by the error Validate returns.
+var ErrInvalidPuzzle = errors.New("invalid puzzle")
+
+// Validate checks that a puzzle can be played: it has an ID, a YYYY-MM-DD
+// date, encrypted text with letters in it, a difficulty in range, and hints
+// that each give one letter for a single cipher letter that appears in the
+// text. The error wraps ErrInvalidPuzzle and lists every problem found.
+func Validate(p *Puzzle) error {
+	var problems []string
+	if p.ID == "" {
+		problems = append(problems, "missing game ID")
+	}
+	if _, err := time.Parse(time.DateOnly, p.Date); err != nil {
+		problems = append(problems, fmt.Sprintf("date %q is not YYYY-MM-DD", p.Date))
+	}
+	if p.Difficulty < minDifficulty || p.Difficulty > maxDifficulty {
+		problems = append(problems, fmt.Sprintf("difficulty %d is outside %d-%d", p.Difficulty, minDifficulty, maxDifficulty))
+	}
+
+	letters := make(map[rune]bool)
+	for _, r := range p.EncryptedText {
+		if unicode.IsLetter(r) {
+			letters[unicode.ToUpper(r)] = true
+		}
+	}
+	if len(letters) == 0 {
+		problems = append(problems, "encrypted text has no letters to solve")
+	}
+
+	hinted := make(map[rune]bool, len(p.Hints))
+	for i, h := range p.Hints {
+		cipher, cipherOK := singleLetter(h.CipherLetter)
+		_, plainOK := singleLetter(h.PlainLetter)
+		switch {
+		case !cipherOK:
+			problems = append(problems, fmt.Sprintf("hint %d: cipher %q is not a single letter", i+1, h.CipherLetter))
+		case !plainOK:
+			problems = append(problems, fmt.Sprintf("hint %d: answer %q is not a single letter", i+1, h.PlainLetter))
+		case !letters[unicode.ToUpper(cipher)]:
+			problems = append(problems, fmt.Sprintf("hint %d: %s does not appear in the puzzle", i+1, h.CipherLetter))
+		case hinted[unicode.ToUpper(cipher)]:
+			problems = append(problems, fmt.Sprintf("hint %d: %s is hinted more than once", i+1, h.CipherLetter))
+		default:
+			hinted[unicode.ToUpper(cipher)] = true
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w %q: %s", ErrInvalidPuzzle, p.ID, strings.Join(problems, "; "))
+}
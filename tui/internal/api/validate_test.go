@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func validPuzzle() *Puzzle {
+	return &Puzzle{
+		ID:            "game-0120",
+		Date:          "2026-01-20",
+		EncryptedText: "XBCCW TWOCK",
+		Difficulty:    42,
+		Hints:         []Hint{{CipherLetter: "X", PlainLetter: "H"}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(validPuzzle()); err != nil {
+		t.Fatalf("Validate(valid) = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *Puzzle)
+		want   string
+	}{
+		{name: "missing ID", modify: func(p *Puzzle) { p.ID = "" }, want: "missing game ID"},
+		{name: "bad date", modify: func(p *Puzzle) { p.Date = "01/20/2026" }, want: `date "01/20/2026" is not YYYY-MM-DD`},
+		{name: "missing date", modify: func(p *Puzzle) { p.Date = "" }, want: `date "" is not YYYY-MM-DD`},
+		{name: "difficulty too high", modify: func(p *Puzzle) { p.Difficulty = 101 }, want: "difficulty 101 is outside 0-100"},
+		{name: "negative difficulty", modify: func(p *Puzzle) { p.Difficulty = -1 }, want: "difficulty -1 is outside 0-100"},
+		{name: "no letters", modify: func(p *Puzzle) { p.EncryptedText, p.Hints = " 123 !", nil }, want: "encrypted text has no letters"},
+		{name: "hint letter not in grid", modify: func(p *Puzzle) { p.Hints[0].CipherLetter = "Q" }, want: "hint 1: Q does not appear in the puzzle"},
+		{name: "hint cipher not a letter", modify: func(p *Puzzle) { p.Hints[0].CipherLetter = "XB" }, want: `hint 1: cipher "XB" is not a single letter`},
+		{name: "hint answer not a letter", modify: func(p *Puzzle) { p.Hints[0].PlainLetter = "" }, want: `hint 1: answer "" is not a single letter`},
+		{
+			name:   "duplicate hint",
+			modify: func(p *Puzzle) { p.Hints = append(p.Hints, Hint{CipherLetter: "X", PlainLetter: "H"}) },
+			want:   "hint 2: X is hinted more than once",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := validPuzzle()
+			tt.modify(p)
+			err := Validate(p)
+			if !errors.Is(err, ErrInvalidPuzzle) {
+				t.Fatalf("Validate() = %v, want ErrInvalidPuzzle", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %q, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_ListsEveryProblem(t *testing.T) {
+	err := Validate(&Puzzle{ID: "broken", Date: "2026-01-20", Difficulty: 500})
+	if err == nil {
+		t.Fatal("Validate() = nil, want an error")
+	}
+	for _, want := range []string{"difficulty 500", "no letters"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %q, want it to mention %q", err, want)
+		}
+	}
+}
+
+func TestFetchTodaysPuzzle_RejectsInvalidPuzzle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "game-0120", "date": "2026-01-20", "encryptedText": "", "difficulty": 10}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	puzzle, err := client.FetchTodaysPuzzle()
+	if !errors.Is(err, ErrInvalidPuzzle) || puzzle != nil {
+		t.Errorf("FetchTodaysPuzzle() = %v, %v; want ErrInvalidPuzzle", puzzle, err)
+	}
+}
+
+func TestHintLetters(t *testing.T) {
+	tests := []struct {
+		hint          Hint
+		cipher, plain rune
+		ok            bool
+	}{
+		{hint: Hint{CipherLetter: "X", PlainLetter: "H"}, cipher: 'X', plain: 'H', ok: true},
+		{hint: Hint{CipherLetter: "Ж", PlainLetter: "é"}, cipher: 'Ж', plain: 'é', ok: true},
+		{hint: Hint{CipherLetter: "XY", PlainLetter: "H"}},
+		{hint: Hint{CipherLetter: "X", PlainLetter: ""}},
+		{hint: Hint{CipherLetter: "1", PlainLetter: "H"}},
+		{hint: Hint{CipherLetter: "\xc3", PlainLetter: "H"}},
+	}
+	for _, tt := range tests {
+		cipher, plain, ok := tt.hint.Letters()
+		if ok != tt.ok || (ok && (cipher != tt.cipher || plain != tt.plain)) {
+			t.Errorf("%+v.Letters() = %c, %c, %v; want %c, %c, %v", tt.hint, cipher, plain, ok, tt.cipher, tt.plain, tt.ok)
+		}
+	}
+}
+
+func TestValidate_MultiByteHints(t *testing.T) {
+	p := &Puzzle{
+		ID:            "game-ru",
+		Date:          "2026-01-20",
+		EncryptedText: "ЖИЗНЬ — ЭТО ЧУДО",
+		Hints:         []Hint{{CipherLetter: "ж", PlainLetter: "С"}},
+	}
+	if err := Validate(p); err != nil {
+		t.Errorf("Validate() = %v, want a Cyrillic hint to match its letter in any case", err)
+	}
+}
@@ -0,0 +1,229 @@
+// Package vcr records HTTP interactions to cassette files and replays them,
+// so API client tests can run against real server responses without the
+// server. Cassettes are sanitized as they are recorded: only the request
+// line and body and the response status, body and a few headers are kept,
+// and claim codes are replaced with a placeholder.
+package vcr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// Placeholder is what claim codes are replaced with in cassettes. Tests that
+// replay a cassette send it as their claim code.
+const Placeholder = "CLAIM-CODE-0000"
+
+// maxBody caps how much of a body is recorded.
+const maxBody = 256 * 1024
+
+// claimCode matches the server's ADJECTIVE-NOUN-NNNN claim codes.
+var claimCode = regexp.MustCompile(`\b[A-Z]+-[A-Z]+-[0-9]{4}\b`)
+
+// keptHeaders are the response headers worth replaying; the rest (dates,
+// server names, rate-limit counters, cookies) only make cassettes noisy or
+// leak details.
+var keptHeaders = []string{"Content-Type", "Retry-After"}
+
+// Sanitize replaces every claim code in s with Placeholder.
+func Sanitize(s string) string {
+	return claimCode.ReplaceAllString(s, Placeholder)
+}
+
+// Interaction is one recorded request and the response it got.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the part of a request a replay matches on. URL is the path and
+// query, without the host, so a cassette replays against any base URL.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	Header map[string]string `json:"header,omitempty"`
+	Body   string            `json:"body,omitempty"`
+	Status int               `json:"status"`
+}
+
+// Cassette is a sequence of interactions, in the order they happened.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Load reads a cassette file.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // cassettes are test fixtures chosen by the test
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path, creating its directory.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gosec // fixtures are checked in
+		return fmt.Errorf("creating cassette directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil { //nolint:gosec // fixtures are checked in
+		return fmt.Errorf("writing cassette: %w", err)
+	}
+	return nil
+}
+
+// requestOf reads the sanitized request line and body of req, leaving its
+// body readable for whoever sends it on.
+func requestOf(req *http.Request) (Request, error) {
+	recorded := Request{Method: req.Method, URL: Sanitize(req.URL.RequestURI())}
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxBody))
+	_ = req.Body.Close()
+	if err != nil {
+		return Request{}, fmt.Errorf("reading request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	recorded.Body = Sanitize(string(body))
+	return recorded, nil
+}
+
+// Recorder is an http.RoundTripper that sends requests on to Next and keeps
+// a sanitized copy of each interaction. It is safe for concurrent use.
+type Recorder struct {
+	Next http.RoundTripper // nil uses http.DefaultTransport
+
+	cassette Cassette
+	mu       sync.Mutex
+}
+
+// RoundTrip sends req and records what came back. Failed round trips are
+// not recorded.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := requestOf(req)
+	if err != nil {
+		return nil, err
+	}
+
+	next := r.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBody))
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := make(map[string]string)
+	for _, name := range keptHeaders {
+		if v := resp.Header.Get(name); v != "" {
+			header[name] = v
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: Response{Status: resp.StatusCode, Header: header, Body: Sanitize(string(body))},
+	})
+	return resp, nil
+}
+
+// Cassette returns what has been recorded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Replayer is an http.RoundTripper that answers requests from a cassette
+// instead of the network. Each interaction is played once: a request gets the
+// first unplayed interaction with the same method, URL and body, so repeated
+// requests replay in the order they were recorded. A request the cassette
+// doesn't have fails. It is safe for concurrent use.
+type Replayer struct {
+	played []bool
+	tape   []Interaction
+	mu     sync.Mutex
+}
+
+// NewReplayer returns a Replayer for c.
+func NewReplayer(c *Cassette) *Replayer {
+	return &Replayer{tape: c.Interactions, played: make([]bool, len(c.Interactions))}
+}
+
+// RoundTrip answers req from the cassette.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	want, err := requestOf(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, it := range r.tape {
+		if r.played[i] || it.Request != want {
+			continue
+		}
+		r.played[i] = true
+
+		header := make(http.Header)
+		for name, v := range it.Response.Header {
+			header.Set(name, v)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", it.Response.Status, http.StatusText(it.Response.Status)),
+			StatusCode:    it.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader([]byte(it.Response.Body))),
+			ContentLength: int64(len(it.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("vcr: no recorded interaction for %s %s", want.Method, want.URL)
+}
+
+// Unplayed returns the interactions no request has asked for yet, so a test
+// can tell when the client stopped making a call it used to.
+func (r *Replayer) Unplayed() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var left []Interaction
+	for i, it := range r.tape {
+		if !r.played[i] {
+			left = append(left, it)
+		}
+	}
+	return left
+}
@@ -0,0 +1,95 @@
+package vcr
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "abc123")
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(body)
+			return
+		}
+		_, _ = io.WriteString(w, `{"claimCode":"TIGER-MAPLE-7492","path":"`+r.URL.Path+`"}`)
+	}))
+	defer srv.Close()
+
+	rec := &Recorder{}
+	client := &http.Client{Transport: rec}
+	status, body := get(t, client, srv.URL+"/player/TIGER-MAPLE-7492/stats?x=1")
+	if status != http.StatusOK || !strings.Contains(body, "TIGER-MAPLE-7492") {
+		t.Errorf("recording changed the live response: %d %s", status, body)
+	}
+	resp, err := client.Post(srv.URL+"/game/g1/check", "application/json", strings.NewReader(`{"solution":"HI"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "c.json")
+	if err := rec.Cassette().Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(c.Interactions) != 2 {
+		t.Fatalf("recorded %d interactions, want 2", len(c.Interactions))
+	}
+	first := c.Interactions[0]
+	if first.Request.URL != "/player/"+Placeholder+"/stats?x=1" || strings.Contains(first.Response.Body, "TIGER") {
+		t.Errorf("claim code not sanitized: %+v", first)
+	}
+	if _, kept := first.Response.Header["X-Request-Id"]; kept || first.Response.Header["Content-Type"] != "application/json" {
+		t.Errorf("headers = %v, want only the kept ones", first.Response.Header)
+	}
+
+	replayer := NewReplayer(c)
+	offline := &http.Client{Transport: replayer}
+	status, body = get(t, offline, "http://replay.invalid/player/"+Placeholder+"/stats?x=1")
+	if status != http.StatusOK || !strings.Contains(body, Placeholder) {
+		t.Errorf("replay = %d %s", status, body)
+	}
+	if left := replayer.Unplayed(); len(left) != 1 {
+		t.Errorf("Unplayed() = %d interactions, want the check left", len(left))
+	}
+
+	// The body is part of the match
+	if _, err := offline.Post("http://replay.invalid/game/g1/check", "application/json", strings.NewReader(`{"solution":"NO"}`)); err == nil {
+		t.Error("replaying a request with another body should fail")
+	}
+	resp, err = offline.Post("http://replay.invalid/game/g1/check", "application/json", strings.NewReader(`{"solution":"HI"}`))
+	if err != nil || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("replaying the check = %v, %v", resp, err)
+	}
+	_ = resp.Body.Close()
+
+	// Each interaction plays once
+	if _, err := offline.Get("http://replay.invalid/player/" + Placeholder + "/stats?x=1"); err == nil {
+		t.Error("replaying an interaction twice should fail")
+	}
+	if left := replayer.Unplayed(); len(left) != 0 {
+		t.Errorf("Unplayed() = %+v, want none", left)
+	}
+}
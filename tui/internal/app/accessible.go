@@ -0,0 +1,229 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// accessibleWord is one word of the puzzle as read out in accessible mode.
+type accessibleWord struct {
+	cells []puzzle.Cell
+}
+
+// accessibleWords splits the cells into words, dropping the spaces between them.
+func (m Model) accessibleWords() []accessibleWord {
+	var words []accessibleWord
+	for _, group := range ui.GroupCellsByWord(m.game.cells) {
+		if len(group.Cells) == 1 && group.Cells[0].Char == ' ' {
+			continue
+		}
+		words = append(words, accessibleWord{cells: group.Cells})
+	}
+	return words
+}
+
+// describe renders a word as "Word 1: _ _ E, cipher X M T".
+func (w accessibleWord) describe(number int) string {
+	inputs := make([]string, 0, len(w.cells))
+	ciphers := make([]string, 0, len(w.cells))
+	for _, cell := range w.cells {
+		if cell.Kind == puzzle.CellPunctuation {
+			inputs = append(inputs, string(cell.Char))
+			ciphers = append(ciphers, string(cell.Char))
+			continue
+		}
+		inputs = append(inputs, inputContent(cell))
+		ciphers = append(ciphers, string(cell.Char))
+	}
+	return fmt.Sprintf("Word %d: %s, cipher %s", number, strings.Join(inputs, " "), strings.Join(ciphers, " "))
+}
+
+// describeCursor renders the cursor position as "Cursor at word 1 letter 2,
+// cipher M", adding ", locked" on a locked letter.
+// Letters count every character in the word, punctuation included, so the
+// position matches the word as read out.
+func (m Model) describeCursor() string {
+	for i, word := range m.accessibleWords() {
+		for j, cell := range word.cells {
+			if cell.Index == m.game.cursorPos && cell.Locked {
+				return fmt.Sprintf("Cursor at word %d letter %d, cipher %c, locked", i+1, j+1, cell.Char)
+			}
+			if cell.Index == m.game.cursorPos {
+				return fmt.Sprintf("Cursor at word %d letter %d, cipher %c", i+1, j+1, cell.Char)
+			}
+		}
+	}
+	return ""
+}
+
+// describeConflicts names the letters assigned to more than one cipher letter,
+// which the standard grid only shows with a background color.
+func (m Model) describeConflicts() string {
+	duplicates := findDuplicateInputs(m.game.cells)
+	if len(duplicates) == 0 {
+		return ""
+	}
+
+	letters := make([]string, 0, len(duplicates))
+	for input := range duplicates {
+		letters = append(letters, string(input))
+	}
+	sort.Strings(letters)
+	return "Used for more than one cipher letter: " + strings.Join(letters, ", ")
+}
+
+// viewPlayingAccessible renders the puzzle as plain lines of text for screen
+// readers: no box drawing, no color-only cues, one word per line.
+func (m Model) viewPlayingAccessible() string {
+	width := max(m.width, MinTerminalWidth)
+
+	lines := m.accessibleHeader()
+	lines = append(lines, "")
+	for i, word := range m.accessibleWords() {
+		lines = append(lines, word.describe(i+1))
+	}
+	lines = append(lines, "", "Author: "+m.game.puzzle.Author, "")
+	if m.state == StatePlaying {
+		lines = append(lines, m.accessibleSolving()...)
+	}
+	lines = append(lines, m.accessibleFooter()...)
+	lines = append(lines, m.accessibleHelp())
+
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = ui.WordWrapText(line, width)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// accessibleHeader returns the lines above the words: the puzzle, the clock,
+// progress and clues, and anything the header bar would show.
+func (m Model) accessibleHeader() []string {
+	var offline, idle, clues string
+	if m.game.offline {
+		offline = "Offline: playing a saved copy of today's puzzle."
+	}
+	if m.idle() {
+		idle = idleText
+	}
+	if len(m.game.puzzle.Hints) > 0 {
+		letters := make([]string, 0, len(m.game.puzzle.Hints))
+		for _, hint := range m.game.puzzle.Hints {
+			letters = append(letters, hint.CipherLetter+" = "+hint.PlainLetter)
+		}
+		clues = "Clues: " + strings.Join(letters, ", ")
+	}
+	return textLines(
+		m.headerTitle(),
+		fmt.Sprintf("%s. Difficulty: %s.", m.game.puzzle.Category, puzzle.DifficultyText(m.game.puzzle.Difficulty)),
+		fmt.Sprintf("Time: %s", formatElapsed(m.Elapsed())),
+		"Progress: "+ui.MeasureProgress(m.game.cells).Summary(),
+		m.countdownText(),
+		idle,
+		offline,
+		clues,
+	)
+}
+
+// accessibleSolving returns the lines that help while solving: where the
+// cursor is, conflicts and suggestions, and any open menu or prompt.
+func (m Model) accessibleSolving() []string {
+	return textLines(
+		m.describeCursor(),
+		m.describeConflicts(),
+		m.autoFillText(),
+		m.wordSuggestionText(),
+		m.accessiblePicker(),
+		m.accessibleAlphabet(),
+		m.accessibleJump(),
+		m.accessibleSearch(),
+		m.accessibleSwap(),
+	)
+}
+
+// accessibleFooter returns the notices, status and results below the words.
+func (m Model) accessibleFooter() []string {
+	var banner, tutorial string
+	if m.showsStatsBanner() {
+		banner = statsDownText + ". Press Ctrl+X to dismiss."
+	}
+	if m.inTutorial() {
+		tutorial = "Tutorial: " + m.tutorialText()
+	}
+	return textLines(
+		m.newPuzzleNotice(),
+		m.errs.play,
+		banner,
+		m.accessibleStatus(),
+		m.renderSolveComparison(),
+		m.renderCommunity(),
+		m.renderRatingPrompt(),
+		m.accessibleNote(),
+		m.renderSplits(),
+		m.renderDuel(),
+		m.renderLetterBreakdown(),
+		tutorial,
+	)
+}
+
+// textLines returns the lines that have something to say, skipping empty ones.
+func textLines(lines ...string) []string {
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return kept
+}
+
+// accessibleStatus returns the status line as plain text.
+func (m Model) accessibleStatus() string {
+	switch m.state {
+	case StateChecking:
+		if m.loadingMsg != "" {
+			return m.loadingMsg
+		}
+		return "Checking solution..."
+	case StateSolved:
+		if m.game.revealed {
+			return fmt.Sprintf("Solution revealed after %s. Better luck next time!", formatElapsed(m.Elapsed()))
+		}
+		if m.game.solvedElsewhere {
+			return fmt.Sprintf("Solved on another device in %s.", formatElapsed(m.Elapsed()))
+		}
+		status := fmt.Sprintf("Congratulations! You solved it in %s!", formatElapsed(m.Elapsed()))
+		if m.solvedAssisted() {
+			status += " (" + assistedTag + ")"
+		}
+		return status
+	default:
+		return ""
+	}
+}
+
+// accessibleHelp lists the available keys as plain text.
+func (m Model) accessibleHelp() string {
+	items := m.helpItems()
+	if m.state == StatePlaying && !m.pickerOpen() {
+		items = append([]helpItem{
+			{label: "[Left/Right] Move"},
+			{label: "[Letter] Fill"},
+			{label: "[Backspace] Erase"},
+		}, items...)
+	}
+
+	labels := make([]string, 0, len(items))
+	for _, item := range items {
+		// The compact grid has no effect on the linear layout
+		if item != helpCompact {
+			labels = append(labels, item.label)
+		}
+	}
+	return "Keys: " + strings.Join(labels, ", ")
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// accessibleModel creates a playing Model in accessible mode.
+func accessibleModel(text string) Model {
+	cells := puzzle.BuildCells(text, nil)
+	return Model{
+		state:      StatePlaying,
+		accessible: true,
+		game:       gameModel{puzzle: &api.Puzzle{ID: "game-001", EncryptedText: text, Author: "Test Author", Category: "Test", Difficulty: 50}, cells: cells, cursorPos: puzzle.FirstLetterCell(cells)},
+		width:      80,
+		height:     24,
+		sizeReady:  true,
+	}
+}
+
+func TestAccessibleWord_Describe(t *testing.T) {
+	m := accessibleModel("XMT, AB'C")
+	m.game.cells[2].Input = 'E'
+
+	words := m.accessibleWords()
+	if len(words) != 2 {
+		t.Fatalf("accessibleWords() returned %d words, want 2", len(words))
+	}
+
+	tests := []struct {
+		want string
+		word accessibleWord
+		num  int
+	}{
+		{word: words[0], num: 1, want: "Word 1: _ _ E ,, cipher X M T ,"},
+		{word: words[1], num: 2, want: "Word 2: _ _ ' _, cipher A B ' C"},
+	}
+	for _, tt := range tests {
+		if got := tt.word.describe(tt.num); got != tt.want {
+			t.Errorf("describe(%d) = %q, want %q", tt.num, got, tt.want)
+		}
+	}
+}
+
+func TestDescribeCursor(t *testing.T) {
+	m := accessibleModel("XMT AB")
+	m.game.cursorPos = 5 // the B in the second word
+
+	if got, want := m.describeCursor(), "Cursor at word 2 letter 2, cipher B"; got != want {
+		t.Errorf("describeCursor() = %q, want %q", got, want)
+	}
+}
+
+func TestDescribeConflicts(t *testing.T) {
+	m := accessibleModel("XMT")
+	if got := m.describeConflicts(); got != "" {
+		t.Errorf("describeConflicts() with no conflicts = %q, want empty", got)
+	}
+
+	m.game.cells[0].Input = 'E'
+	m.game.cells[1].Input = 'E'
+	if got, want := m.describeConflicts(), "Used for more than one cipher letter: E"; got != want {
+		t.Errorf("describeConflicts() = %q, want %q", got, want)
+	}
+}
+
+func TestViewAccessible_PlainText(t *testing.T) {
+	m := accessibleModel("XMT AB")
+	view := m.View().Content
+
+	for _, want := range []string{"CRYPTO-QUIP", "Word 1: _ _ _, cipher X M T", "Word 2: _ _, cipher A B", "Author: Test Author", "Cursor at word 1 letter 1, cipher X", "Keys:"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("accessible view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "\x1b[") {
+		t.Error("accessible view should not contain styling escape sequences")
+	}
+	if strings.Contains(view, "Compact") {
+		t.Error("accessible view should not offer the compact grid toggle")
+	}
+}
+
+func TestHandleConfigLoaded_AccessibleFromConfig(t *testing.T) {
+	m := NewWithClient(nil)
+	model, _ := m.handleConfigLoaded(configLoadedMsg{config: &config.Config{Accessible: true}})
+	if !model.(Model).accessible {
+		t.Error("Accessible in config should enable accessible mode")
+	}
+
+	m.opts.Accessible = true
+	model, _ = m.handleConfigLoaded(configLoadedMsg{config: &config.Config{}})
+	if !model.(Model).accessible {
+		t.Error("--accessible should win over a config without it")
+	}
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+const (
+	// alphabetTitle heads the alphabet panel.
+	alphabetTitle = "Key"
+	// alphabetBesideWidth is the narrowest terminal that puts the alphabet
+	// panel beside the grid rather than below it.
+	alphabetBesideWidth = 100
+	// alphabetColumns is how many columns the panel has beside the grid.
+	alphabetColumns = 2
+	// alphabetEntryWidth fits "A→E!": cipher letter, arrow, input, conflict mark.
+	alphabetEntryWidth = 4
+	// alphabetGap separates the panel from the grid, and its entries from each other.
+	alphabetGap = 2
+)
+
+// alphabetRows is how many rows the panel takes beside the grid, title included.
+var alphabetRows = 1 + (len(pickerLetters)+alphabetColumns-1)/alphabetColumns
+
+// alphabetPanelWidth is the panel's width beside the grid.
+const alphabetPanelWidth = alphabetColumns*alphabetEntryWidth + (alphabetColumns-1)*alphabetGap
+
+// keyEntry is one cipher letter of the alphabet panel and what it stands for.
+type keyEntry struct {
+	cipher   rune
+	input    rune // 0 when not filled in
+	present  bool // the cipher letter is in the puzzle
+	hint     bool // given by a clue
+	conflict bool // its input is also another cipher letter's
+	locked   bool // the player locked it
+}
+
+// cipherKey derives the alphabet panel from the cells: every cipher letter
+// A-Z with the letter the player has for it.
+func cipherKey(cells []puzzle.Cell) []keyEntry {
+	duplicates := findDuplicateInputs(cells)
+	entries := make([]keyEntry, 0, len(pickerLetters))
+	for _, cipher := range pickerLetters {
+		entry := keyEntry{cipher: cipher}
+		for _, cell := range cells {
+			if cell.Kind == puzzle.CellPunctuation || puzzle.NormalizeLetter(cell.Char) != cipher {
+				continue
+			}
+			entry.present = true
+			entry.input = cell.Input
+			entry.hint = cell.Kind == puzzle.CellHint
+			entry.locked = cell.Locked
+			entry.conflict = cell.Kind == puzzle.CellLetter && slices.Contains(duplicates[cell.Input], cell.Char)
+			break
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// render draws the entry as "A→E", "A→?" while empty, with "!" after a
+// conflict and in bold when locked; cipher letters the puzzle doesn't use
+// are muted.
+func (e keyEntry) render() string {
+	input := "?"
+	if e.input != 0 {
+		input = string(e.input)
+	}
+	text := string(e.cipher) + "→" + input
+	switch {
+	case !e.present:
+		return lipgloss.NewStyle().Foreground(ui.ColorMuted).Render(string(e.cipher)) + "   "
+	case e.conflict:
+		return ui.WarningStyle.Render(text + conflictMarker)
+	case e.hint:
+		return lipgloss.NewStyle().Foreground(ui.ColorSecondary).Render(text) + " "
+	case e.locked:
+		return lipgloss.NewStyle().Bold(true).Render(text) + " "
+	default:
+		return text + " "
+	}
+}
+
+// alphabetShown reports whether the alphabet panel is on for the playing
+// screen. Accessible mode reads the key out instead.
+func (m Model) alphabetShown() bool {
+	return m.alphabetPanel && !m.accessible && len(m.game.cells) > 0
+}
+
+// fitsAlphabetBeside reports whether the terminal is wide enough to put the
+// alphabet panel beside the grid; syncGridView checks it is tall enough too.
+func (m Model) fitsAlphabetBeside() bool {
+	return m.alphabetShown() && m.width >= alphabetBesideWidth
+}
+
+// renderAlphabetBeside renders the panel as columns to stand beside the grid.
+func (m Model) renderAlphabetBeside() string {
+	entries := cipherKey(m.game.cells)
+	rows := alphabetRows - 1
+	lines := []string{lipgloss.NewStyle().Bold(true).Render(alphabetTitle)}
+	for r := range rows {
+		var line strings.Builder
+		for c := range alphabetColumns {
+			i := c*rows + r
+			if i >= len(entries) {
+				break
+			}
+			if c > 0 {
+				line.WriteString(strings.Repeat(" ", alphabetGap))
+			}
+			line.WriteString(entries[i].render())
+		}
+		lines = append(lines, line.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderAlphabetBelow renders the panel as rows under the grid, wrapped to
+// the terminal. Returns "" when the panel is off or sits beside the grid.
+func (m Model) renderAlphabetBelow() string {
+	if !m.alphabetShown() || m.alphabetBeside {
+		return ""
+	}
+	width := max(m.width, MinTerminalWidth)
+	lines := []string{lipgloss.NewStyle().Bold(true).Render(alphabetTitle)}
+	var line string
+	for _, entry := range cipherKey(m.game.cells) {
+		rendered := entry.render()
+		if line != "" && lipgloss.Width(line)+alphabetGap+alphabetEntryWidth > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += strings.Repeat(" ", alphabetGap)
+		}
+		line += rendered
+	}
+	lines = append(lines, line)
+	return strings.Join(lines, "\n")
+}
+
+// besideAlphabet puts the alphabet panel to the right of the grid block when
+// it sits beside the grid.
+func (m Model) besideAlphabet(grid string) string {
+	if !m.alphabetShown() || !m.alphabetBeside {
+		return grid
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, grid, strings.Repeat(" ", alphabetGap), m.renderAlphabetBeside())
+}
+
+// accessibleAlphabet reads out the key of the cipher letters in the puzzle,
+// e.g. "Key: A is T, B is blank, C is E (conflict)."
+func (m Model) accessibleAlphabet() string {
+	if !m.alphabetPanel {
+		return ""
+	}
+	var parts []string
+	for _, entry := range cipherKey(m.game.cells) {
+		if !entry.present {
+			continue
+		}
+		part := string(entry.cipher) + " is blank"
+		if entry.input != 0 {
+			part = string(entry.cipher) + " is " + string(entry.input)
+		}
+		if entry.conflict {
+			part += " (conflict)"
+		}
+		if entry.locked {
+			part += " (locked)"
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "Key: " + strings.Join(parts, ", ") + "."
+}
+
+// toggleAlphabetPanel shows or hides the alphabet panel, and remembers the
+// choice in the config file.
+func (m Model) toggleAlphabetPanel() (tea.Model, tea.Cmd) {
+	m.alphabetPanel = !m.alphabetPanel
+	m = m.syncGridView(true)
+
+	var cfg config.Config
+	if m.cfg != nil {
+		cfg = *m.cfg
+	}
+	cfg.AlphabetPanel = m.alphabetPanel
+	m.cfg = &cfg
+
+	if m.opts.Ephemeral {
+		return m, nil
+	}
+	return m, savePreferencesCmd(m.cfg)
+}
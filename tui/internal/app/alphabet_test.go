@@ -0,0 +1,165 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/adrg/xdg"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+func TestCipherKey(t *testing.T) {
+	cells := puzzle.BuildCells("AB CD", nil)
+	cells[0].Input = 'E'
+	cells[1].Input = 'E'
+	cells[3].Kind = puzzle.CellHint
+	cells[3].Input = 'T'
+
+	key := cipherKey(cells)
+	if len(key) != len(pickerLetters) {
+		t.Fatalf("cipherKey has %d entries, want one per letter A-Z", len(key))
+	}
+	byLetter := make(map[rune]keyEntry)
+	for _, entry := range key {
+		byLetter[entry.cipher] = entry
+	}
+
+	if a := byLetter['A']; !a.present || a.input != 'E' || !a.conflict {
+		t.Errorf("A = %+v, want E in conflict with B", a)
+	}
+	if c := byLetter['C']; !c.present || !c.hint || c.input != 'T' {
+		t.Errorf("C = %+v, want the hint T", c)
+	}
+	if d := byLetter['D']; !d.present || d.input != 0 {
+		t.Errorf("D = %+v, want present and empty", d)
+	}
+	if z := byLetter['Z']; z.present {
+		t.Errorf("Z = %+v, want not present", z)
+	}
+}
+
+func TestKeyEntryRender_FixedWidth(t *testing.T) {
+	entries := []keyEntry{
+		{cipher: 'A', present: true, input: 'E'},
+		{cipher: 'B', present: true},
+		{cipher: 'C', present: true, input: 'E', conflict: true},
+		{cipher: 'D', present: true, input: 'T', hint: true},
+		{cipher: 'Z'},
+	}
+	want := []string{"A→E ", "B→? ", "C→E!", "D→T ", "Z   "}
+	for i, entry := range entries {
+		if got := ansi.Strip(entry.render()); got != want[i] {
+			t.Errorf("render(%c) = %q, want %q", entry.cipher, got, want[i])
+		}
+	}
+}
+
+func TestAlphabetPanel_BelowOnNarrowTerminal(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.alphabetPanel = true
+	m = m.syncGridView(false)
+	if m.alphabetBeside {
+		t.Fatal("an 80-column terminal should put the panel below the grid")
+	}
+
+	below := ansi.Strip(m.renderAlphabetBelow())
+	if !strings.Contains(below, alphabetTitle) || !strings.Contains(below, "A→?") || !strings.Contains(below, "Z") {
+		t.Errorf("panel below the grid should list the cipher alphabet:\n%s", below)
+	}
+	for line := range strings.SplitSeq(below, "\n") {
+		if w := ansi.StringWidth(line); w > m.width {
+			t.Errorf("line %q is %d wide, past the terminal's %d", line, w, m.width)
+		}
+	}
+	if screen := ansi.Strip(m.playingScreen("")); !strings.Contains(screen, "B→?") {
+		t.Errorf("playing screen should show the panel:\n%s", screen)
+	}
+}
+
+func TestAlphabetPanel_BesideOnWideTerminal(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.alphabetPanel = true
+	m.width = 120
+	m = m.syncGridView(false)
+	if !m.alphabetBeside {
+		t.Fatal("a 120x40 terminal should put the panel beside the grid")
+	}
+	if m.renderAlphabetBelow() != "" {
+		t.Error("nothing should render below the grid while the panel is beside it")
+	}
+	if got, want := m.gridLineWidth(), 120-alphabetPanelWidth-alphabetGap; got != want {
+		t.Errorf("gridLineWidth = %d, want %d to leave room for the panel", got, want)
+	}
+
+	beside := ansi.Strip(m.besideAlphabet("grid"))
+	first := strings.TrimRight(strings.Split(beside, "\n")[0], " ")
+	if !strings.HasPrefix(first, "grid") || !strings.HasSuffix(first, alphabetTitle) {
+		t.Errorf("first line = %q, want the grid then the panel's title", first)
+	}
+	if lines := strings.Count(beside, "\n") + 1; lines != alphabetRows {
+		t.Errorf("panel beside the grid is %d rows, want %d", lines, alphabetRows)
+	}
+
+	// Too short for every row beside the grid
+	m.height = 16
+	m = m.syncGridView(false)
+	if m.alphabetBeside {
+		t.Error("a short terminal should put the panel below the grid")
+	}
+}
+
+func TestAlphabetPanel_Accessible(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.accessible = true
+	m.alphabetPanel = true
+	m.game.cells[0].Input = 'T'
+
+	if m.alphabetShown() {
+		t.Error("accessible mode should not draw the panel")
+	}
+	if got, want := m.accessibleAlphabet(), "Key: A is T, B is blank."; got != want {
+		t.Errorf("accessibleAlphabet() = %q, want %q", got, want)
+	}
+}
+
+func TestToggleAlphabetPanel(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	m := revealModel(&config.Config{ClaimCode: "TIGER-MAPLE-7492"}, 0)
+	m, cmd := press(t, m, tea.KeyPressMsg{Code: 'k', Mod: tea.ModCtrl})
+	if !m.alphabetPanel {
+		t.Fatal("Ctrl+K should show the alphabet panel")
+	}
+	if cmd == nil {
+		t.Fatal("toggling should return a command saving the preference")
+	}
+	cmd()
+
+	saved, err := config.Load()
+	if err != nil || saved == nil {
+		t.Fatalf("config.Load() = %v, %v", saved, err)
+	}
+	if !saved.AlphabetPanel || saved.ClaimCode != "TIGER-MAPLE-7492" {
+		t.Errorf("saved config = %+v, want the panel on and the rest kept", saved)
+	}
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: 'k', Mod: tea.ModCtrl})
+	if m.alphabetPanel {
+		t.Error("second Ctrl+K should hide the panel")
+	}
+}
+
+func TestToggleAlphabetPanel_EphemeralDoesNotSave(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.opts.Ephemeral = true
+	m, cmd := press(t, m, tea.KeyPressMsg{Code: 'k', Mod: tea.ModCtrl})
+	if !m.alphabetPanel || cmd != nil {
+		t.Errorf("panel %v, cmd %v; want it shown and nothing saved", m.alphabetPanel, cmd)
+	}
+}
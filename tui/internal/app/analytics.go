@@ -0,0 +1,87 @@
+package app
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// letterTimes records, per cipher letter, when it was first and last given a
+// plain letter. Hint letters are never assigned, so they never appear.
+type letterTimes map[rune]storage.LetterTiming
+
+// recordLetterTime stamps the current elapsed time on a cipher letter the
+// player has just assigned.
+func (m Model) recordLetterTime(cipher rune) Model {
+	// Copy before writing: save commands may still hold the previous map
+	times := make(letterTimes, len(m.game.letters)+1)
+	maps.Copy(times, m.game.letters)
+
+	now := m.Elapsed()
+	t, ok := times[cipher]
+	if !ok {
+		t.First = now
+	}
+	t.Last = now
+	times[cipher] = t
+
+	m.game.letters = times
+	return m
+}
+
+// forSession converts the timings to the string-keyed form sessions store.
+func (t letterTimes) forSession() storage.LetterTimes {
+	if len(t) == 0 {
+		return nil
+	}
+	stored := make(storage.LetterTimes, len(t))
+	for cipher, timing := range t {
+		stored[string(cipher)] = timing
+	}
+	return stored
+}
+
+// letterTimesFromSession restores timings saved by forSession, skipping keys
+// that aren't a single letter.
+func letterTimesFromSession(stored storage.LetterTimes) letterTimes {
+	if len(stored) == 0 {
+		return nil
+	}
+	times := make(letterTimes, len(stored))
+	for key, timing := range stored {
+		if r := []rune(key); len(r) == 1 {
+			times[r[0]] = timing
+		}
+	}
+	return times
+}
+
+// renderLetterBreakdown renders the solved screen's per-letter breakdown, e.g.
+// "You spent 01:02 stuck before placing Q". Revealed games and sessions saved
+// without timings have none.
+func (m Model) renderLetterBreakdown() string {
+	if m.state != StateSolved || m.game.revealed || len(m.game.letters) == 0 {
+		return ""
+	}
+
+	times := m.game.letters.forSession()
+	var lines []string
+	if letter, pause := times.LongestPause(); pause >= storage.MinPause {
+		lines = append(lines, fmt.Sprintf("You spent %s stuck before placing %s", formatElapsed(pause), letter))
+	}
+	if letter, span := times.LongestRevision(); span >= storage.MinRevision {
+		lines = append(lines, fmt.Sprintf("%s took longest to settle: %s between first and last guess", letter, formatElapsed(span)))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	breakdown := strings.Join(lines, "\n")
+	if m.accessible {
+		return breakdown
+	}
+	return ui.TimerStyle.Render(breakdown)
+}
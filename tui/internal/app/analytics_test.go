@@ -0,0 +1,90 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+func TestRecordLetterTime(t *testing.T) {
+	m := speedRunModel(StateChecking, 0, 10*time.Second)
+	m = m.recordLetterTime('A')
+	first := m.game.letters
+
+	m.game.elapsedAtPause = 40 * time.Second
+	m = m.recordLetterTime('A')
+	m = m.recordLetterTime('B')
+
+	if got := m.game.letters['A']; got.First != 10*time.Second || got.Last != 40*time.Second {
+		t.Errorf("A = %+v, want first 10s and last 40s", got)
+	}
+	if got := m.game.letters['B']; got.First != 40*time.Second || got.Last != 40*time.Second {
+		t.Errorf("B = %+v, want first and last 40s", got)
+	}
+	if len(first) != 1 || first['A'].Last != 10*time.Second {
+		t.Error("recording should not change a map a save command may still hold")
+	}
+}
+
+func TestRenderLetterBreakdown(t *testing.T) {
+	m := speedRunModel(StateSolved, 0, 2*time.Minute)
+	m.game.letters = letterTimes{
+		'A': {First: 5 * time.Second, Last: 5 * time.Second},
+		'Q': {First: 67 * time.Second, Last: 68 * time.Second},
+	}
+
+	got := ansi.Strip(m.renderLetterBreakdown())
+	if got != "You spent 01:02 stuck before placing Q" {
+		t.Errorf("renderLetterBreakdown() = %q", got)
+	}
+	if view := ansi.Strip(m.viewPlaying()); !strings.Contains(view, "stuck before placing Q") {
+		t.Errorf("solved view should show the breakdown:\n%s", view)
+	}
+
+	m.game.letters['A'] = storage.LetterTiming{First: 5 * time.Second, Last: 100 * time.Second}
+	if got := ansi.Strip(m.renderLetterBreakdown()); !strings.Contains(got, "A took longest to settle: 01:35") {
+		t.Errorf("renderLetterBreakdown() = %q, want A's revisions called out", got)
+	}
+
+	m.game.revealed = true
+	if got := m.renderLetterBreakdown(); got != "" {
+		t.Errorf("a revealed game should have no breakdown, got %q", got)
+	}
+
+	m.game.revealed = false
+	m.state = StatePlaying
+	if got := m.renderLetterBreakdown(); got != "" {
+		t.Errorf("the breakdown should wait for the solve, got %q", got)
+	}
+}
+
+func TestLetterTimes_SurviveSessionRoundTrip(t *testing.T) {
+	storagetest.UseMemory(t)
+
+	m := speedRunModel(StateChecking, 0, 30*time.Second)
+	model, _ := m.handleLetterInput('N')
+	m = model.(Model)
+	if _, ok := m.game.letters['A']; !ok {
+		t.Fatal("typing a letter should record its time")
+	}
+
+	saveSolvedSessionCmd(storage.Daily, m.game.puzzle, m.game.cells, time.Minute, time.Now(), m.run, m.game.letters, m.game.assists)()
+	session, err := storage.LoadSession(m.game.puzzle.ID)
+	if err != nil || session == nil {
+		t.Fatalf("LoadSession() = %v, %v", session, err)
+	}
+	if got := session.LetterTimes["A"]; got.First != 30*time.Second {
+		t.Errorf("saved timing = %+v, want first 30s", got)
+	}
+
+	restored := speedRunModel(StateLoading, 0, 0)
+	model, _ = restored.handleSessionLoaded(sessionLoadedMsg{session: session})
+	if got := model.(Model).game.letters['A']; got.First != 30*time.Second {
+		t.Errorf("restored timing = %+v, want first 30s", got)
+	}
+}
@@ -0,0 +1,254 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/pack"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// archiveStatus is how far the player got with a pack puzzle.
+type archiveStatus int
+
+const (
+	archiveNew archiveStatus = iota
+	archiveInProgress
+	archiveSolved
+	archiveRevealed
+)
+
+// String labels the status in words, so the list never relies on color.
+func (s archiveStatus) String() string {
+	switch s {
+	case archiveInProgress:
+		return "In progress"
+	case archiveSolved:
+		return "Solved"
+	case archiveRevealed:
+		return "Revealed"
+	default:
+		return "New"
+	}
+}
+
+// archiveModel is the pack archive screen: the pack's puzzles with the
+// player's progress on each, and the selected row.
+type archiveModel struct {
+	entries []archiveEntry // nil until the pack has been loaded
+	cursor  int
+}
+
+// archiveEntry is one puzzle on the pack archive screen.
+type archiveEntry struct {
+	puzzle *puzzlegen.Puzzle
+	note   string // the player's note on the solve, if any
+	status archiveStatus
+}
+
+// loadArchiveCmd creates a command that generates a pack's puzzles and looks
+// up the player's progress on each in the custom sessions namespace
+func loadArchiveCmd(p *pack.Pack) tea.Cmd {
+	return func() tea.Msg {
+		entries := make([]archiveEntry, 0, len(p.Puzzles))
+		for i, e := range p.Puzzles {
+			generated, err := e.Generate()
+			if err != nil {
+				return errMsg{err: fmt.Errorf("pack puzzle %d: %w", i+1, err)}
+			}
+
+			entry := archiveEntry{puzzle: generated}
+			// Progress is best-effort; unreadable sessions show as new
+			if session, err := storage.Custom.LoadSession(generated.ID); err == nil && session != nil {
+				switch {
+				case session.Revealed:
+					entry.status = archiveRevealed
+				case session.Solved:
+					entry.status = archiveSolved
+				default:
+					entry.status = archiveInProgress
+				}
+				entry.note = session.Note
+			}
+			entries = append(entries, entry)
+		}
+		return archiveLoadedMsg{entries: entries}
+	}
+}
+
+// load shows entries, the pack's puzzles as just read. The first time, the
+// cursor starts on the first puzzle the player hasn't finished.
+func (a archiveModel) load(entries []archiveEntry) archiveModel {
+	first := a.entries == nil
+	a.entries = entries
+	if first {
+		a.cursor = 0
+		for i, e := range a.entries {
+			if e.status == archiveNew || e.status == archiveInProgress {
+				a.cursor = i
+				break
+			}
+		}
+	}
+	a.cursor = min(a.cursor, len(a.entries)-1)
+	return a
+}
+
+// update moves through the pack's puzzles. It also reports whether the
+// player picked the selected one to play.
+func (a archiveModel) update(msg tea.KeyPressMsg) (archiveModel, bool) {
+	switch msg.String() {
+	case "up", "k":
+		a.cursor = max(a.cursor-1, 0)
+	case "down", "j":
+		a.cursor = min(a.cursor+1, len(a.entries)-1)
+	case "enter":
+		return a, true
+	}
+	return a, false
+}
+
+// handleArchiveLoaded shows the archive screen.
+func (m Model) handleArchiveLoaded(msg archiveLoadedMsg) (tea.Model, tea.Cmd) {
+	m.archive = m.archive.load(msg.entries)
+	m.state = StateArchive
+	m.loadingMsg = ""
+	return m, nil
+}
+
+// handleArchiveKeyMsg passes keys to the archive screen and starts the
+// puzzle it picks.
+func (m Model) handleArchiveKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	var play bool
+	if m.archive, play = m.archive.update(msg); play {
+		return m.playArchiveEntry(m.archive.cursor)
+	}
+	return m, nil
+}
+
+// playArchiveEntry starts the pack puzzle at index i, resuming any saved session.
+func (m Model) playArchiveEntry(i int) (tea.Model, tea.Cmd) {
+	if i < 0 || i >= len(m.archive.entries) {
+		return m, nil
+	}
+	m.archive.cursor = i
+	m = m.resetGame()
+	m.opts.Local = m.archive.entries[i].puzzle
+	m.state = StateLoading
+	return m.fetch()
+}
+
+// backToArchive leaves the finished puzzle and reloads the pack's progress.
+func (m Model) backToArchive() (tea.Model, tea.Cmd) {
+	m = m.resetGame()
+	m.opts.Local = nil
+	m.state = StateLoading
+	return m.fetch()
+}
+
+// entryAt returns the index of the archive row under the mouse, or -1.
+func (a archiveModel) entryAt(msg tea.MouseMsg) int {
+	for i := range a.entries {
+		if zone.Get(fmt.Sprintf("archive-%d", i)).InBounds(msg) {
+			return i
+		}
+	}
+	return -1
+}
+
+// archiveChromeHeight is the number of lines the archive screen uses around
+// the list: header (3), blank, description, blank, summary, blank, and the
+// help bar (2).
+const archiveChromeHeight = 10
+
+// viewArchive renders the archive screen for the pack being played.
+func (m Model) viewArchive() string {
+	return m.archive.view(m.chrome(), m.opts.Pack)
+}
+
+// view renders p's puzzles with the player's progress on each, scrolled to
+// keep the cursor on screen.
+func (a archiveModel) view(c chrome, p *pack.Pack) string {
+	name := ui.SanitizeString(p.Name)
+
+	description := ui.SanitizeString(p.Description)
+	if p.Author != "" {
+		description = strings.TrimSpace(description + " · by " + ui.SanitizeString(p.Author))
+	}
+	if description == "" {
+		description = name
+	}
+	description = ui.DifficultyStyle.Render(ui.WordWrapText(description, max(c.width, MinTerminalWidth)))
+
+	finished := 0
+	for _, e := range a.entries {
+		if e.status == archiveSolved || e.status == archiveRevealed {
+			finished++
+		}
+	}
+	summary := ui.TimerStyle.Render(fmt.Sprintf("%d of %d puzzles finished", finished, len(a.entries)))
+
+	// Show a window of rows around the cursor when the pack is taller than the screen
+	rows := max(c.height-archiveChromeHeight-statusBarHeight, 1)
+	start := min(max(a.cursor-rows/2, 0), max(len(a.entries)-rows, 0))
+	end := min(start+rows, len(a.entries))
+
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		lines = append(lines, zone.Mark(fmt.Sprintf("archive-%d", i), a.renderRow(i, c.width)))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		c.header,
+		"",
+		description,
+		"",
+		summary,
+		"",
+		strings.Join(lines, "\n"),
+		ui.HelpStyle.Render(c.help),
+	)
+}
+
+// renderRow renders one pack puzzle, e.g. "›  3. Solved       — Oscar Wilde",
+// followed by the player's note on it, cut to width.
+// The cursor is marked with "›" as well as color.
+func (a archiveModel) renderRow(i, width int) string {
+	e := a.entries[i]
+
+	marker := "  "
+	if i == a.cursor {
+		marker = "› "
+	}
+
+	author := ui.SanitizeString(e.puzzle.Author)
+	if author == "" {
+		author = "Unknown"
+	}
+	row := fmt.Sprintf("%s%2d. %-12s — %s", marker, i+1, e.status, author)
+	if e.note != "" {
+		row += " · " + ui.SanitizeString(e.note)
+	}
+	if width > 0 {
+		row = ansi.Truncate(row, width, "…")
+	}
+
+	switch {
+	case i == a.cursor:
+		return lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Render(row)
+	case e.status == archiveSolved:
+		return ui.SuccessStyle.Render(row)
+	case e.status == archiveRevealed:
+		return ui.WarningStyle.Render(row)
+	default:
+		return row
+	}
+}
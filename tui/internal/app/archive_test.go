@@ -0,0 +1,140 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/pack"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// archiveScreenModel creates a Model showing the archive screen for a three-puzzle
+// pack, with progress read from a fresh state directory.
+func archiveScreenModel(t *testing.T) Model {
+	t.Helper()
+	storagetest.UseMemory(t)
+
+	p := &pack.Pack{
+		Format:      pack.FormatVersion,
+		Name:        "Stoic Sayings",
+		Description: "Old advice",
+		Puzzles: []pack.Entry{
+			{Quote: "We suffer more in imagination than in reality", Author: "Seneca"},
+			{Quote: "The obstacle is the way", Author: "Marcus Aurelius"},
+			{Quote: "No man is free who is not master of himself", Author: "Epictetus"},
+		},
+	}
+
+	// The first puzzle is already solved, with a note
+	solved, err := p.Puzzles[0].Generate()
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	if err := storage.Custom.SaveSession(&storage.GameSession{GameID: solved.ID, Solved: true, Note: "hard but fair"}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{state: StateLoading, opts: Options{Pack: p}, width: 80, height: 40}
+	model, _ := m.Update(m.fetchCmd()())
+	return model.(Model)
+}
+
+func TestArchive_LoadsProgress(t *testing.T) {
+	m := archiveScreenModel(t)
+
+	if m.state != StateArchive {
+		t.Fatalf("state = %v, want StateArchive", m.state)
+	}
+	statuses := []archiveStatus{m.archive.entries[0].status, m.archive.entries[1].status, m.archive.entries[2].status}
+	if !slices.Equal(statuses, []archiveStatus{archiveSolved, archiveNew, archiveNew}) {
+		t.Errorf("statuses = %v, want the first solved", statuses)
+	}
+	if m.archive.cursor != 1 {
+		t.Errorf("archiveCursor = %d, want the first unfinished puzzle", m.archive.cursor)
+	}
+
+	view := ansi.Strip(m.viewArchive())
+	for _, want := range []string{"STOIC SAYINGS", "Old advice", "1 of 3 puzzles finished", "Solved", "Seneca · hard but fair", "›  2. New", "Marcus Aurelius"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("archive view missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestArchive_Navigation(t *testing.T) {
+	m := archiveScreenModel(t)
+
+	for _, key := range []tea.KeyPressMsg{{Code: tea.KeyDown}, {Code: tea.KeyDown}, {Code: tea.KeyDown}} {
+		model, _ := m.handleKeyMsg(key)
+		m = model.(Model)
+	}
+	if m.archive.cursor != 2 {
+		t.Errorf("archiveCursor = %d, want it to stop at the last puzzle", m.archive.cursor)
+	}
+
+	model, _ := m.handleKeyMsg(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	if model.(Model).archive.cursor != 1 {
+		t.Errorf("archiveCursor = %d, want k to move up", model.(Model).archive.cursor)
+	}
+}
+
+func TestArchive_PlayAndReturn(t *testing.T) {
+	m := archiveScreenModel(t)
+
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = model.(Model)
+	if m.state != StateLoading || m.opts.Local != m.archive.entries[1].puzzle {
+		t.Fatalf("Enter should load the selected puzzle, got state %v", m.state)
+	}
+
+	model, _ = m.Update(cmd())
+	m = model.(Model)
+	if m.game.puzzle.ID != m.archive.entries[1].puzzle.ID || m.game.puzzle.Category != "Stoic Sayings" {
+		t.Errorf("playing %+v, want the selected pack puzzle", m.game.puzzle)
+	}
+	if m.sessions() != storage.Custom || m.recordsStats() {
+		t.Error("pack puzzles must be saved as custom sessions and never recorded")
+	}
+
+	// Solve it and head back to the archive
+	puzzle.RevealSolution(m.game.cells, m.opts.Local.Solution)
+	model, saveCmd := m.handleSolutionChecked(solutionCheckedMsg{correct: true})
+	m = model.(Model)
+	saveCmd()
+	if !slices.Contains(m.helpItems(), helpArchive) {
+		t.Error("the solved screen should offer going back to the archive")
+	}
+
+	model, cmd = m.handleKeyMsg(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	m = model.(Model)
+	if m.opts.Local != nil || m.game.puzzle != nil {
+		t.Error("going back to the archive should drop the finished puzzle")
+	}
+	model, _ = m.Update(cmd())
+	m = model.(Model)
+
+	if m.state != StateArchive || m.archive.entries[1].status != archiveSolved {
+		t.Errorf("state = %v, status = %v; want the archive with the puzzle solved", m.state, m.archive.entries[1].status)
+	}
+	if m.archive.cursor != 1 {
+		t.Errorf("archiveCursor = %d, want it to stay on the puzzle just played", m.archive.cursor)
+	}
+}
+
+func TestResetGame(t *testing.T) {
+	m := revealModel(nil, 2)
+	m.game.revealed = true
+	m, _ = m.notify(toastError, "Not quite right.")
+	m.game.elapsedAtPause = 42
+
+	m = m.resetGame()
+	if m.game.puzzle != nil || m.game.cells != nil || m.game.revealed || m.game.failedChecks != 0 || m.toasts != nil || m.game.elapsedAtPause != 0 {
+		t.Errorf("resetGame() left game state behind: %+v", m)
+	}
+}
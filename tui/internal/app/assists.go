@@ -0,0 +1,24 @@
+package app
+
+import (
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// assistedTag is the tag after the solve time of an assisted solve.
+const assistedTag = "assisted"
+
+// solvedAssisted reports whether the player had help on the puzzle just
+// solved; see storage.Assists.Level.
+func (m Model) solvedAssisted() bool {
+	return m.state == StateSolved && !m.game.revealed && !m.game.solvedElsewhere && m.game.assists.Level() != storage.AssistNone
+}
+
+// renderAssistedTag renders the assisted tag for the solved screen's status
+// line, or nothing for a clean solve.
+func (m Model) renderAssistedTag() string {
+	if !m.solvedAssisted() {
+		return ""
+	}
+	return ui.TimerStyle.Render(" · " + assistedTag)
+}
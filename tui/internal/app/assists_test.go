@@ -0,0 +1,42 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+func TestAssistedTag(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.state = StateSolved
+	if status := ansi.Strip(m.renderStatus()); strings.Contains(status, assistedTag) {
+		t.Errorf("a clean solve should not be tagged: %q", status)
+	}
+
+	m.game.assists = storage.Assists{HintsUsed: 1}
+	if status := ansi.Strip(m.renderStatus()); !strings.HasSuffix(status, " · assisted") {
+		t.Errorf("renderStatus() = %q, want the assisted tag", status)
+	}
+	if status := m.accessibleStatus(); !strings.HasSuffix(status, "(assisted)") {
+		t.Errorf("accessibleStatus() = %q, want the assisted tag", status)
+	}
+
+	m.game.solvedElsewhere = true
+	if status := ansi.Strip(m.renderStatus()); strings.Contains(status, assistedTag) {
+		t.Errorf("a solve from another device should not be tagged: %q", status)
+	}
+}
+
+func TestNewSession_AssistLevel(t *testing.T) {
+	m := revealModel(nil, 0)
+	session := newSession(m.game.puzzle, m.game.cells, 0, m.run, m.game.letters, storage.Assists{AutoCheckUsed: true})
+	if session.AssistLevel != storage.AssistHeavy {
+		t.Errorf("AssistLevel = %q, want heavy for auto-check", session.AssistLevel)
+	}
+	if got := apiAssists(session.Assists); got.AssistLevel != "heavy" || !got.AutoCheckUsed {
+		t.Errorf("apiAssists() = %+v, want the level uploaded", got)
+	}
+}
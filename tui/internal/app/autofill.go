@@ -0,0 +1,76 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// autoFillSuggestions returns the letters Ctrl+F would fill: those the board
+// leaves only one choice for (see puzzle.Suggest), when the player opted in
+// with Config.AutoFill.
+func (m Model) autoFillSuggestions() []puzzle.Suggestion {
+	if m.cfg == nil || !m.cfg.AutoFill || m.state != StatePlaying || m.inTutorial() || m.game.revealed {
+		return nil
+	}
+	return puzzle.Suggest(m.game.cells)
+}
+
+// describeSuggestions lists suggestions as "Z for cipher A, Q for cipher X".
+func describeSuggestions(suggestions []puzzle.Suggestion) string {
+	parts := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		parts[i] = fmt.Sprintf("%c for cipher %c", s.Plain, s.Cipher)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// autoFill fills every suggested letter and moves the cursor to the first
+// cell still empty. Each letter filled counts as a hint in the solve's
+// assists, as if the player had asked for it.
+func (m Model) autoFill() (tea.Model, tea.Cmd) {
+	suggestions := m.autoFillSuggestions()
+	if len(suggestions) == 0 {
+		return m, nil
+	}
+
+	m = m.pushUndo()
+	for _, s := range suggestions {
+		i := slices.IndexFunc(m.game.cells, func(cell puzzle.Cell) bool {
+			return cell.Kind == puzzle.CellLetter && puzzle.NormalizeLetter(cell.Char) == s.Cipher
+		})
+		puzzle.SetInput(m.game.cells, i, s.Plain)
+	}
+	m.game.assists.HintsUsed += len(suggestions)
+	if next := puzzle.NextUnfilledLetterCell(m.game.cells, -1); next >= 0 {
+		m.game.cursorPos = next
+	}
+	m = m.recordSplits()
+
+	save := saveSessionCmd(m.sessions(), m.clock().Now(), m.game.puzzle, m.game.cells, m.Elapsed(), m.run, m.game.letters, m.game.assists)
+	m, toast := m.notify(toastInfo, "Filled "+describeSuggestions(suggestions))
+	return m, tea.Batch(save, toast)
+}
+
+// autoFillText offers the suggested letters, or is empty when there are none.
+func (m Model) autoFillText() string {
+	suggestions := m.autoFillSuggestions()
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return "Only one letter fits: " + describeSuggestions(suggestions) + ". Press Ctrl+F to fill."
+}
+
+// renderAutoFill renders autoFillText under the grid.
+func (m Model) renderAutoFill() string {
+	text := m.autoFillText()
+	if text == "" {
+		return ""
+	}
+	return ui.TimerStyle.Render(ui.WordWrapText(text, max(m.width-4, 20)))
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// autoFillModel is a playing model on a pangram with every letter filled but
+// the last cipher letter, A, which only Z can fill.
+func autoFillModel(cfg *config.Config) Model {
+	m := revealModel(cfg, 0)
+	m.game.puzzle.EncryptedText = "BCDEFGHIJKLMNOPQRSTUVWXYZ A"
+	m.game.cells = puzzle.BuildCells(m.game.puzzle.EncryptedText, nil)
+	for i, cell := range m.game.cells {
+		if cell.Kind == puzzle.CellLetter && cell.Char != 'A' {
+			m.game.cells[i].Input = cell.Char - 1
+		}
+	}
+	m.game.cursorPos = 0
+	return m
+}
+
+func TestAutoFill_FillsLastLetter(t *testing.T) {
+	m := autoFillModel(&config.Config{AutoFill: true})
+	if text := m.autoFillText(); !strings.Contains(text, "Z for cipher A") {
+		t.Errorf("autoFillText() = %q, want Z offered for cipher A", text)
+	}
+	if !slices.Contains(m.helpItems(), helpFill) {
+		t.Errorf("help = %v, want Ctrl+F offered", m.helpItems())
+	}
+
+	m, cmd := press(t, m, tea.KeyPressMsg{Code: 'f', Mod: tea.ModCtrl})
+	last := m.game.cells[len(m.game.cells)-1]
+	if last.Input != 'Z' || cmd == nil {
+		t.Fatalf("cipher A input = %q, cmd = %v; want Z filled and saved", last.Input, cmd)
+	}
+	if m.game.assists.HintsUsed != 1 {
+		t.Errorf("HintsUsed = %d, want the fill counted as a hint", m.game.assists.HintsUsed)
+	}
+	if got := toastText(m); got != "Filled Z for cipher A" {
+		t.Errorf("toast = %q, want what was filled", got)
+	}
+	if m.autoFillText() != "" || slices.Contains(m.helpItems(), helpFill) {
+		t.Error("nothing is left to fill, so nothing should be offered")
+	}
+}
+
+func TestAutoFill_OptIn(t *testing.T) {
+	m := autoFillModel(nil)
+	if m.autoFillText() != "" {
+		t.Errorf("autoFillText() = %q, want nothing offered without opting in", m.autoFillText())
+	}
+
+	m, cmd := press(t, m, tea.KeyPressMsg{Code: 'f', Mod: tea.ModCtrl})
+	if m.game.cells[len(m.game.cells)-1].Input != 0 || cmd != nil || m.game.assists.HintsUsed != 0 {
+		t.Error("Ctrl+F should do nothing without Config.AutoFill")
+	}
+}
@@ -0,0 +1,149 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/perfbudget"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// benchModel returns a model playing text on a terminal of the given width,
+// sized through a WindowSizeMsg like a real one. cache is nil to render
+// without memoizing.
+func benchModel(tb testing.TB, text string, width int, cache *gridCache) Model {
+	tb.Helper()
+	m := Model{
+		state: StatePlaying,
+		game: gameModel{puzzle: &api.Puzzle{
+			ID:            "bench",
+			EncryptedText: text,
+			Author:        "Bench Author",
+			Category:      "Wisdom",
+			Difficulty:    50,
+			Hints:         []api.Hint{{CipherLetter: "X", PlainLetter: "T"}},
+		}, startTime: time.Now()},
+		gridCache: cache,
+	}
+	m.game.cells = puzzle.BuildCells(text, map[rune]rune{'X': 'T'})
+	m.game.cursorPos = puzzle.FirstLetterCell(m.game.cells)
+
+	model, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: 40})
+	return model.(Model)
+}
+
+// moveCursor steps the cursor to the next letter cell, wrapping at the end,
+// so each frame has something to redraw.
+func (m Model) moveCursor() Model {
+	if m.game.cursorPos = puzzle.NextLetterCell(m.game.cells, m.game.cursorPos); m.game.cursorPos < 0 {
+		m.game.cursorPos = puzzle.FirstLetterCell(m.game.cells)
+	}
+	return m
+}
+
+// benchSizes runs bench for every benchmark puzzle size and terminal width.
+func benchSizes(b *testing.B, bench func(b *testing.B, text string, width int)) {
+	for _, p := range perfbudget.Puzzles {
+		for _, width := range perfbudget.Widths {
+			b.Run(fmt.Sprintf("%s/%d", p.Name, width), func(b *testing.B) {
+				bench(b, p.Text, width)
+			})
+		}
+	}
+}
+
+func BenchmarkRenderGrid(b *testing.B) {
+	for _, bb := range []struct {
+		name  string
+		cache func() *gridCache
+	}{
+		{name: "uncached", cache: func() *gridCache { return nil }},
+		{name: "cached", cache: newGridCache},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			benchSizes(b, func(b *testing.B, text string, width int) {
+				m := benchModel(b, text, width, bb.cache())
+				m.renderGrid()
+
+				for b.Loop() {
+					// A tick-like frame with nothing changed, then a cursor move
+					m.renderGrid()
+					m = m.moveCursor()
+					m.renderGrid()
+				}
+				if m.gridCache != nil {
+					b.ReportMetric(float64(m.gridCache.cellRenders)/float64(b.N), "cell-renders/op")
+					b.ReportMetric(float64(m.gridCache.lineRenders)/float64(b.N), "line-renders/op")
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkView(b *testing.B) {
+	b.Run("cursor", func(b *testing.B) {
+		benchSizes(b, func(b *testing.B, text string, width int) {
+			m := benchModel(b, text, width, newGridCache())
+			m.frame = &frameCache{}
+
+			for b.Loop() {
+				m = m.moveCursor()
+				m.View()
+			}
+		})
+	})
+
+	// Timer ticks: nothing but the clock changes between frames
+	b.Run("tick", func(b *testing.B) {
+		benchSizes(b, func(b *testing.B, text string, width int) {
+			m := benchModel(b, text, width, newGridCache())
+			m.frame = &frameCache{}
+
+			for b.Loop() {
+				m.View()
+			}
+			b.ReportMetric(float64(m.frame.scans)/float64(b.N), "scans/op")
+		})
+	})
+}
+
+// Budgets for the huge puzzle on a standard terminal; see perfbudget for
+// turning them on.
+func TestRenderBudgets(t *testing.T) {
+	huge := perfbudget.Puzzles[len(perfbudget.Puzzles)-1].Text
+
+	t.Run("renderGrid", func(t *testing.T) {
+		m := benchModel(t, huge, 80, newGridCache())
+		perfbudget.Check(t, 2*time.Millisecond, func(b *testing.B) {
+			for b.Loop() {
+				m = m.moveCursor()
+				m.renderGrid()
+			}
+		})
+	})
+
+	t.Run("View", func(t *testing.T) {
+		m := benchModel(t, huge, 80, newGridCache())
+		m.frame = &frameCache{}
+		perfbudget.Check(t, 10*time.Millisecond, func(b *testing.B) {
+			for b.Loop() {
+				m = m.moveCursor()
+				m.View()
+			}
+		})
+	})
+
+	t.Run("tick", func(t *testing.T) {
+		m := benchModel(t, huge, 80, newGridCache())
+		m.frame = &frameCache{}
+		perfbudget.Check(t, 2*time.Millisecond, func(b *testing.B) {
+			for b.Loop() {
+				m.View()
+			}
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+)
+
+// fetchCapabilitiesCmd asks the API which optional features it serves. The
+// client keeps the answer, so asking again is free. Failures are silent:
+// every feature stays assumed, and the next health check that finds the API
+// online asks again.
+func fetchCapabilitiesCmd(client *api.Client) tea.Cmd {
+	return func() tea.Msg {
+		caps, err := client.FetchCapabilities()
+		if err != nil {
+			return nil
+		}
+		return capabilitiesMsg{caps: caps}
+	}
+}
+
+// supports reports whether the API serves an optional feature. Until its
+// capabilities arrive every feature is assumed, and calls fail as they
+// always have if it's missing.
+func (m Model) supports(feature string) bool {
+	return m.capabilities.Has(feature)
+}
+
+// handleCapabilities keeps the API's capabilities, which hide the actions it
+// can't serve. A duel on a server without duels goes on as solo play.
+func (m Model) handleCapabilities(msg capabilitiesMsg) (tea.Model, tea.Cmd) {
+	m.capabilities = msg.caps
+	if m.duel.room == "" || m.supports(api.FeatureDuel) {
+		return m, nil
+	}
+	return m.leaveDuel()
+}
+
+// leaveDuel drops the duel for solo play on a server that doesn't host
+// them. A player still waiting for an opponent starts the puzzle, picking up
+// any saved session like any other game.
+func (m Model) leaveDuel() (Model, tea.Cmd) {
+	m = m.closeDuelStream()
+	m.duel = duelState{}
+	var load tea.Cmd
+	if m.state == StateDuelWaiting && m.game.puzzle != nil {
+		m.state = StatePlaying
+		load = tagFetch(m.puzzleFetch, loadSessionCmd(m.sessions(), m.game.puzzle.ID))
+	}
+	m, cmd := m.notify(toastWarning, "This server doesn't host duels, so you're playing solo.")
+	return m, tea.Batch(load, cmd)
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+)
+
+func TestCapabilities_HideWhatTheServerLacks(t *testing.T) {
+	m := revealModel(nil, 3)
+	m.game.puzzle = &api.Puzzle{ID: "game-001", Date: "2026-01-20", Author: "Mark Twain"}
+	if !m.canReveal() {
+		t.Fatal("reveal should be offered while the server's capabilities are unknown")
+	}
+
+	model, _ := m.handleCapabilities(capabilitiesMsg{caps: &api.Capabilities{}})
+	m = model.(Model)
+	if m.canReveal() {
+		t.Error("reveal offered by a server without hints")
+	}
+	if m.offersCommunity() {
+		t.Error("community stats offered by a server without the leaderboard")
+	}
+	m.state = StateSolved
+	if m.offersMore() {
+		t.Error("more by this author offered by a server without the archive")
+	}
+
+	model, _ = m.handleCapabilities(capabilitiesMsg{caps: &api.Capabilities{Features: []string{api.FeatureLeaderboard, api.FeatureArchive}}})
+	if m = model.(Model); !m.offersCommunity() || !m.offersMore() {
+		t.Error("features the server lists should be offered")
+	}
+}
+
+func TestCapabilities_DuelPlaysSolo(t *testing.T) {
+	m := duelModel(t, StateDuelWaiting)
+
+	model, cmd := m.handleCapabilities(capabilitiesMsg{caps: &api.Capabilities{Features: []string{api.FeatureHints}}})
+	m = model.(Model)
+	if m.state != StatePlaying || m.duel.room != "" || cmd == nil {
+		t.Errorf("state = %v, room = %q; want solo play with the session loading", m.state, m.duel.room)
+	}
+	if got := toastText(m); got != "This server doesn't host duels, so you're playing solo." {
+		t.Errorf("toast = %q, want the duel's end explained", got)
+	}
+
+	// A poll already in flight is dropped
+	if _, cmd := m.handleDuelPolled(duelPolledMsg{err: api.ErrUnsupported}); cmd != nil {
+		t.Error("a poll after leaving the duel should schedule nothing")
+	}
+}
+
+func TestDuel_UnsupportedPollPlaysSolo(t *testing.T) {
+	m := duelModel(t, StateDuelWaiting)
+
+	model, _ := m.handleDuelPolled(duelPolledMsg{err: api.ErrUnsupported})
+	if m = model.(Model); m.state != StatePlaying || m.duel.room != "" {
+		t.Errorf("state = %v, room = %q; want solo play", m.state, m.duel.room)
+	}
+}
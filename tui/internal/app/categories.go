@@ -0,0 +1,105 @@
+package app
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// categoryNameWidth caps the name column of the categories table.
+const categoryNameWidth = 20
+
+// categoryStats is one row on the Categories tab: how the player has done on
+// daily puzzles of one category.
+type categoryStats struct {
+	name    string
+	played  int           // solved or revealed
+	solved  int           // solved without revealing
+	average time.Duration // mean solve time; 0 without solves
+}
+
+// summarizeCategories groups finished sessions by puzzle category, most
+// played first. Unfinished sessions, and older ones that don't know their
+// category, are left out.
+func summarizeCategories(sessions []storage.GameSession) []categoryStats {
+	byName := make(map[string]*categoryStats)
+	totals := make(map[string]time.Duration)
+	for _, s := range sessions {
+		if s.Category == "" || (!s.Solved && !s.Revealed) {
+			continue
+		}
+		c, ok := byName[s.Category]
+		if !ok {
+			c = &categoryStats{name: s.Category}
+			byName[s.Category] = c
+		}
+		c.played++
+		if s.Solved {
+			c.solved++
+			totals[s.Category] += s.CompletionTime
+		}
+	}
+
+	categories := make([]categoryStats, 0, len(byName))
+	for name, c := range byName {
+		if c.solved > 0 {
+			c.average = totals[name] / time.Duration(c.solved)
+		}
+		categories = append(categories, *c)
+	}
+	slices.SortFunc(categories, func(a, b categoryStats) int {
+		return cmp.Or(cmp.Compare(b.played, a.played), cmp.Compare(a.name, b.name))
+	})
+	return categories
+}
+
+// loadCategoryStatsCmd creates a command that summarizes the player's daily
+// sessions by category for the Categories tab. Best-effort: a storage error
+// leaves the tab off the screen.
+func loadCategoryStatsCmd() tea.Cmd {
+	return func() tea.Msg {
+		sessions, err := storage.Daily.ListSessions()
+		if err != nil {
+			return nil
+		}
+		return categoryStatsMsg{categories: summarizeCategories(sessions)}
+	}
+}
+
+// hasCategories reports whether there is a category breakdown to show.
+func (s statsModel) hasCategories() bool {
+	return len(s.categories) > 0
+}
+
+// renderCategoriesTab renders the Categories tab: games played, solved and
+// the average solve time in each category.
+func (s statsModel) renderCategoriesTab(accessible bool) string {
+	row := func(name, played, solved, avg string) string {
+		name = ansi.Truncate(name, categoryNameWidth, "…")
+		return fmt.Sprintf("  %-*s %7s %7s %9s", categoryNameWidth, name, played, solved, avg)
+	}
+
+	header := row("Category", "Played", "Solved", "Avg Time")
+	lines := []string{header}
+	for _, c := range s.categories {
+		avg := "—"
+		if c.solved > 0 {
+			avg = formatMs(float64(c.average.Milliseconds()))
+		}
+		lines = append(lines, row(ui.SanitizeString(c.name), fmt.Sprintf("%d", c.played), fmt.Sprintf("%d", c.solved), avg))
+	}
+
+	if !accessible {
+		lines[0] = lipgloss.NewStyle().Foreground(ui.ColorMuted).Render(header)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+func TestSummarizeCategories(t *testing.T) {
+	sessions := []storage.GameSession{
+		{GameID: "a", Category: "Humor", Solved: true, CompletionTime: 2 * time.Minute},
+		{GameID: "b", Category: "Humor", Solved: true, CompletionTime: 4 * time.Minute},
+		{GameID: "c", Category: "Humor", Revealed: true},
+		{GameID: "d", Category: "Wisdom", Revealed: true},
+		{GameID: "e", Category: "Wisdom"}, // unfinished
+		{GameID: "f", Solved: true},       // older session without a category
+	}
+
+	got := summarizeCategories(sessions)
+	want := []categoryStats{
+		{name: "Humor", played: 3, solved: 2, average: 3 * time.Minute},
+		{name: "Wisdom", played: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("summarizeCategories() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("category %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCategoriesTab(t *testing.T) {
+	m := friendsModel()
+	model, _ := m.Update(categoryStatsMsg{categories: []categoryStats{
+		{name: "Humor", played: 3, solved: 2, average: 3 * time.Minute},
+		{name: "Wisdom", played: 1},
+	}})
+	m = model.(Model)
+
+	// You → Friends → Categories → You
+	wantTabs := []statsTab{statsTabFriends, statsTabCategories, statsTabYou}
+	for _, want := range wantTabs {
+		model, _ = m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyTab})
+		m = model.(Model)
+		if m.stats.tab != want {
+			t.Fatalf("statsTab = %d, want %d", m.stats.tab, want)
+		}
+		if want == statsTabFriends && !strings.Contains(m.viewStats(), "[Tab] Categories") {
+			t.Error("the Friends tab should offer the Categories tab next")
+		}
+	}
+
+	m.stats.tab = statsTabCategories
+	view := ansi.Strip(m.viewStats())
+	for _, want := range []string{"Category", "Humor", "3:00", "Wisdom", "—", "[Tab] Your stats"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Categories tab missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestFetchRandomPuzzle_SkipsOtherCategories(t *testing.T) {
+	storagetest.UseMemory(t)
+
+	served := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("category") != "puns" {
+			t.Errorf("query = %q, want the category passed on", r.URL.RawQuery)
+		}
+		// A server that ignores the filter: the first puzzle is off-category
+		served++
+		if served == 1 {
+			_, _ = w.Write([]byte(`{"id": "wisdom-1", "date": "2025-06-02", "encryptedText": "XYZ", "category": "Wisdom"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "puns-1", "date": "2025-06-01", "encryptedText": "XYZ", "category": "Puns"}`))
+	}))
+	defer server.Close()
+	client, err := api.NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := fetchRandomPuzzleCmd(client, storage.Daily, "puns")()
+	fetched, ok := msg.(puzzleFetchedMsg)
+	if !ok || fetched.puzzle.ID != "puns-1" {
+		t.Errorf("fetch = %#v, want the Puns puzzle", msg)
+	}
+}
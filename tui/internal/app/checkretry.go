@@ -0,0 +1,37 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// defaultCheckRetries is how many times an answer check that timed out is
+// sent again, unless the config says otherwise.
+const defaultCheckRetries = 2
+
+// stillCheckingText replaces "Checking solution..." while a timed-out check
+// is retried.
+const stillCheckingText = "Still checking…"
+
+// checkRetries returns how many times a timed-out check is retried.
+func (m Model) checkRetries() int {
+	retries := defaultCheckRetries
+	if m.cfg != nil && m.cfg.CheckRetries != 0 {
+		retries = m.cfg.CheckRetries
+	}
+	return max(retries, 0)
+}
+
+// handleCheckTimedOut sends the same solution again while retries remain,
+// keeping the board as it was submitted; after that the check fails like any
+// other. Timeouts for a check no longer waited on are ignored.
+func (m Model) handleCheckTimedOut(msg checkTimedOutMsg) (tea.Model, tea.Cmd) {
+	if m.state != StateChecking || m.game.puzzle == nil || msg.gameID != m.game.puzzle.ID {
+		return m, nil
+	}
+	if m.game.checkAttempts >= m.checkRetries() {
+		return m.handlePlayError(playErrMsg{err: msg.err, action: "check your answer"})
+	}
+	m.game.checkAttempts++
+	m.loadingMsg = stillCheckingText
+	return m, checkSolutionCmd(m.game.trace.client(m.client), msg.gameID, msg.solution)
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+func TestCheckTimedOut_RetriesThenGivesUp(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.client = newTestClient(t)
+	m.state = StateChecking
+	timedOut := checkTimedOutMsg{err: context.DeadlineExceeded, gameID: "game-001", solution: "XY, YX"}
+
+	for i := range defaultCheckRetries {
+		model, cmd := m.Update(timedOut)
+		m = model.(Model)
+		if m.state != StateChecking || cmd == nil {
+			t.Fatalf("timeout %d: state = %v, cmd = %v; want the check sent again", i+1, m.state, cmd)
+		}
+		if screen := ansi.Strip(m.renderStatus()); screen != stillCheckingText {
+			t.Errorf("status = %q, want %q", screen, stillCheckingText)
+		}
+	}
+
+	model, cmd := m.Update(timedOut)
+	m = model.(Model)
+	if m.state != StatePlaying || cmd != nil {
+		t.Fatalf("state = %v, cmd = %v; want play back once retries run out", m.state, cmd)
+	}
+	if !strings.HasPrefix(m.errs.play, "Couldn't check your answer. Request timed out.") {
+		t.Errorf("errs.play = %q, want the timeout explained", m.errs.play)
+	}
+}
+
+func TestCheckTimedOut_Config(t *testing.T) {
+	m := revealModel(&config.Config{CheckRetries: -1}, 0)
+	m.state = StateChecking
+
+	model, _ := m.Update(checkTimedOutMsg{err: context.DeadlineExceeded, gameID: "game-001"})
+	if m = model.(Model); m.state != StatePlaying || m.errs.play == "" {
+		t.Errorf("state = %v, errs.play = %q; want no retry when disabled", m.state, m.errs.play)
+	}
+
+	m = revealModel(&config.Config{CheckRetries: 5}, 0)
+	if got := m.checkRetries(); got != 5 {
+		t.Errorf("checkRetries() = %d, want 5 from the config", got)
+	}
+}
+
+func TestCheckTimedOut_IgnoresStaleChecks(t *testing.T) {
+	m := revealModel(nil, 0)
+
+	model, cmd := m.Update(checkTimedOutMsg{err: context.DeadlineExceeded, gameID: "game-001"})
+	if m = model.(Model); m.state != StatePlaying || cmd != nil || m.errs.play != "" {
+		t.Errorf("state = %v, cmd = %v, errs.play = %q; want a timeout no longer waited on ignored", m.state, cmd, m.errs.play)
+	}
+
+	m.state = StateChecking
+	model, cmd = m.Update(checkTimedOutMsg{err: context.DeadlineExceeded, gameID: "game-000"})
+	if m = model.(Model); m.state != StateChecking || cmd != nil {
+		t.Errorf("state = %v, cmd = %v; want another puzzle's timeout ignored", m.state, cmd)
+	}
+}
+
+func TestIsTimeout(t *testing.T) {
+	tests := []struct {
+		err  error
+		name string
+		want bool
+	}{
+		{name: "deadline", err: fmt.Errorf("failed to check solution: %w", context.DeadlineExceeded), want: true},
+		{name: "client timeout", err: &url.Error{Op: "Post", URL: "http://x", Err: &net.DNSError{IsTimeout: true}}, want: true},
+		{name: "refused", err: syscall.ECONNREFUSED, want: false},
+		{name: "nil", err: nil, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTimeout(tt.err); got != tt.want {
+				t.Errorf("isTimeout(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// clearPlaintext takes the plaintext letter in the cell under the cursor off
+// every cipher letter it was given to, for an early guess that spread across
+// the board. Locked letters and hints keep it.
+func (m Model) clearPlaintext() (tea.Model, tea.Cmd) {
+	if m.cursorCipher() == 0 {
+		return m, nil
+	}
+	letter := m.game.cells[m.game.cursorPos].Input
+	if letter == 0 {
+		return m.notify(toastWarning, "No letter here to clear")
+	}
+	if m.lockedAt(m.game.cursorPos) {
+		return m.rejectLocked(m.game.cursorPos)
+	}
+
+	m = m.pushUndo()
+	cleared := puzzle.ClearPlaintext(m.game.cells, letter)
+	text := fmt.Sprintf("Cleared %c from %d cipher letters", letter, cleared)
+	if cleared == 1 {
+		text = fmt.Sprintf("Cleared %c", letter)
+	}
+	m, cmd := m.notify(toastInfo, text)
+	return m, tea.Batch(cmd, saveSessionCmd(m.sessions(), m.clock().Now(), m.game.puzzle, m.game.cells, m.Elapsed(), m.run, m.game.letters, m.game.assists))
+}
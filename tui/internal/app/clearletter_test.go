@@ -0,0 +1,53 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+var ctrlD = tea.KeyPressMsg{Code: 'd', Mod: tea.ModCtrl}
+
+func TestClearPlaintext_ClearsEveryCipherLetter(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.game.puzzle = &api.Puzzle{ID: "game-001", EncryptedText: "ABC CD"}
+	m.game.cells = puzzle.BuildCells(m.game.puzzle.EncryptedText, nil)
+	puzzle.SetInput(m.game.cells, 0, 'E')
+	puzzle.SetInput(m.game.cells, 1, 'E')
+	puzzle.SetInput(m.game.cells, 2, 'T')
+	m.game.cursorPos = 1
+
+	m, cmd := press(t, m, ctrlD)
+	if got := puzzle.AssembleSolution(m.game.cells); got != "__T T_" {
+		t.Errorf("after Ctrl+D on E: %q, want every E cleared and T kept", got)
+	}
+	if cmd == nil {
+		t.Error("clearing should save the session")
+	}
+	if got := lastToast(m); got != "Cleared E from 2 cipher letters" {
+		t.Errorf("toast = %q", got)
+	}
+	if m.game.cursorPos != 1 {
+		t.Errorf("cursorPos = %d, want the cursor left in place", m.game.cursorPos)
+	}
+}
+
+func TestClearPlaintext_EmptyOrLockedCell(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.game.cursorPos = 0
+
+	m, _ = press(t, m, ctrlD)
+	if got := lastToast(m); got != "No letter here to clear" {
+		t.Errorf("toast = %q, want a warning on an empty cell", got)
+	}
+
+	puzzle.SetInput(m.game.cells, 0, 'T')
+	puzzle.SetLocked(m.game.cells, 0, true)
+	m, _ = press(t, m, ctrlD)
+	if m.game.cells[0].Input != 'T' || lastToast(m) != "A is locked as T; Ctrl+L unlocks it" {
+		t.Errorf("input %c, toast %q; want the locked letter kept", m.game.cells[0].Input, lastToast(m))
+	}
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/clock"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// clockModel returns rolloverModel's game on a fake clock reading 23:59:59
+// UTC on the puzzle's day, started just now.
+func clockModel(t *testing.T) (Model, *clock.Fake) {
+	t.Helper()
+	clk := clock.NewFake(time.Date(2026, 1, 20, 23, 59, 59, 0, time.UTC))
+	m := rolloverModel(t)
+	m.opts.Clock = clk
+	m.game.startTime = clk.Now()
+	return m, clk
+}
+
+// nextTick runs a tick command and moves clk along until it fires.
+func nextTick(t *testing.T, clk *clock.Fake, cmd tea.Cmd) tea.Msg {
+	t.Helper()
+	msgs := make(chan tea.Msg, 1)
+	go func() { msgs <- cmd() }()
+	deadline := time.Now().Add(time.Second)
+	for clk.Waiting() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the tick never started waiting on the clock")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	clk.Advance(time.Second)
+	return <-msgs
+}
+
+func TestElapsed_FollowsClock(t *testing.T) {
+	m, clk := clockModel(t)
+
+	clk.Advance(5*time.Hour + 30*time.Second)
+	if got := m.Elapsed(); got != 5*time.Hour+30*time.Second {
+		t.Errorf("Elapsed() = %v after a five-hour solve, want 5h0m30s", got)
+	}
+}
+
+func TestTickCmd_RollsOverAtMidnight(t *testing.T) {
+	m, clk := clockModel(t)
+
+	msg := nextTick(t, clk, tickCmd(m.clock(), m.tickInterval()))
+	if got := time.Time(msg.(tickMsg)); !got.Equal(time.Date(2026, 1, 21, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("tick at %v, want midnight", got)
+	}
+	model, cmd := m.handleTick(msg.(tickMsg))
+	m = model.(Model)
+	if !m.game.newPuzzle || cmd == nil {
+		t.Errorf("newPuzzle = %v at midnight, want true and the timer still ticking", m.game.newPuzzle)
+	}
+	if got := m.Elapsed(); got != time.Second {
+		t.Errorf("Elapsed() = %v, want the one second the clock moved", got)
+	}
+}
+
+func TestSolve_StampsSessionWithClock(t *testing.T) {
+	storagetest.UseMemory(t)
+	m, clk := clockModel(t)
+	clk.Advance(3 * time.Minute)
+
+	model, cmd := m.handleSolutionChecked(solutionCheckedMsg{correct: true})
+	m = model.(Model)
+	if m.game.elapsedAtPause != 3*time.Minute {
+		t.Errorf("completion time = %v, want 3m", m.game.elapsedAtPause)
+	}
+	// The save comes first; the rest tops up the offline cache
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		batch[0]()
+	}
+
+	session, err := storage.LoadSession("game-0120")
+	if err != nil || session == nil {
+		t.Fatalf("LoadSession() = %v, %v", session, err)
+	}
+	if session.SolvedAt == nil || !session.SolvedAt.Equal(clk.Now()) || !session.SavedAt.Equal(clk.Now()) {
+		t.Errorf("SolvedAt = %v, SavedAt = %v; want both at the fake clock's %v", session.SolvedAt, session.SavedAt, clk.Now())
+	}
+}
+
+func TestResume_CountsOnFromSavedTime(t *testing.T) {
+	m, clk := clockModel(t)
+	m.state = StateLoading
+
+	model, _ := m.handleSessionLoaded(sessionLoadedMsg{session: &storage.GameSession{
+		GameID:      "game-0120",
+		Inputs:      map[string]string{},
+		ElapsedTime: 2 * time.Minute,
+	}})
+	m = model.(Model)
+	m.state = StatePlaying
+
+	// Time spent away before resuming doesn't count; time after does
+	clk.Advance(45 * time.Second)
+	if got := m.Elapsed(); got != 2*time.Minute+45*time.Second {
+		t.Errorf("Elapsed() = %v after resuming, want 2m45s", got)
+	}
+}
@@ -3,41 +3,137 @@ package app
 import (
 	"fmt"
 	"io"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
 
 	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/clock"
 	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/goal"
 	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
 	"github.com/bojanrajkovic/unquote/tui/internal/share"
 	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
 )
 
 const maxRandomRetries = 50
 
-// fetchPuzzleCmd creates a command to fetch today's puzzle
-func fetchPuzzleCmd(client *api.Client) tea.Cmd {
+// fetchPuzzleCmd creates a command to fetch today's puzzle: the one dated
+// today in loc, so the day doesn't depend on the server's clock. When the API
+// can't be reached it falls back like fetchPuzzleByDateCmd.
+func fetchPuzzleCmd(client *api.Client, sessions storage.Namespace, clk clock.Clock, loc *time.Location) tea.Cmd {
 	return func() tea.Msg {
-		puzzle, err := client.FetchTodaysPuzzle()
-		if err != nil {
-			return errMsg{err: err}
+		return fetchDailyPuzzle(client, sessions, clk, cache.Today(clk.Now().In(loc)))
+	}
+}
+
+// fetchPuzzleByDateCmd creates a command to fetch the daily puzzle for a date.
+// If the API can't be reached, the puzzle is played offline from the cache,
+// whose entries expire by clk, or, without an answer to check against, from
+// a session saved with it.
+func fetchPuzzleByDateCmd(client *api.Client, sessions storage.Namespace, clk clock.Clock, date string) tea.Cmd {
+	return func() tea.Msg {
+		return fetchDailyPuzzle(client, sessions, clk, date)
+	}
+}
+
+// fetchPuzzleByIDCmd creates a command to fetch a puzzle by its game ID. If
+// the API can't be reached, a session saved with the puzzle is played
+// offline, without an answer to check against.
+func fetchPuzzleByIDCmd(client *api.Client, sessions storage.Namespace, gameID string) tea.Cmd {
+	return func() tea.Msg {
+		puzzle, err := client.FetchPuzzleByID(gameID)
+		if err == nil {
+			return puzzleFetchedMsg{puzzle: puzzle}
+		}
+		if session, loadErr := sessions.LoadSession(gameID); loadErr == nil && session != nil {
+			if stored := puzzleFromSession(session); stored != nil {
+				return puzzleFetchedMsg{puzzle: stored, offline: true}
+			}
 		}
+		return errMsg{err: err}
+	}
+}
+
+// fetchDailyPuzzle fetches the daily puzzle for a date with the offline
+// fallbacks described on fetchPuzzleByDateCmd.
+func fetchDailyPuzzle(client *api.Client, sessions storage.Namespace, clk clock.Clock, date string) tea.Msg {
+	puzzle, err := client.FetchPuzzleByDate(date)
+	if err == nil {
 		return puzzleFetchedMsg{puzzle: puzzle}
 	}
+	if entry, cacheErr := cache.Load(date, clk.Now()); cacheErr == nil && entry != nil {
+		return puzzleFetchedMsg{puzzle: entry.Puzzle, answer: entry.Solution, offline: true}
+	}
+	if stored := storedPuzzle(sessions, date); stored != nil {
+		return puzzleFetchedMsg{puzzle: stored, offline: true}
+	}
+	return errMsg{err: err}
+}
+
+// tagFetch marks what cmd returns with the puzzle fetch generation it
+// belongs to; see Model.startFetch. Other messages pass through unchanged.
+func tagFetch(fetch int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		switch msg := cmd().(type) {
+		case puzzleFetchedMsg:
+			msg.fetch = fetch
+			return msg
+		case sessionLoadedMsg:
+			msg.fetch = fetch
+			return msg
+		case remoteSessionMsg:
+			msg.fetch = fetch
+			return msg
+		case errMsg:
+			msg.fetch = fetch
+			return msg
+		default:
+			return msg
+		}
+	}
+}
+
+// autoPrefetchDays is how many days ahead are cached in the background after a solve
+const autoPrefetchDays = 7
+
+// prefetchCmd caches upcoming puzzles for offline play, starting at today's
+// date by clk in loc, and drops expired ones. Best-effort: runs in the
+// background after a solve and never reports errors.
+func prefetchCmd(client *api.Client, clk clock.Clock, loc *time.Location) tea.Cmd {
+	return func() tea.Msg {
+		now := clk.Now().In(loc)
+		_, _ = cache.Prune(now)
+		_, _ = cache.Prefetch(client, now, autoPrefetchDays)
+		return nil
+	}
 }
 
 // fetchRandomPuzzleCmd creates a command to fetch a random puzzle,
-// retrying until it finds one that hasn't been played before.
-func fetchRandomPuzzleCmd(client *api.Client) tea.Cmd {
+// retrying until it finds one that hasn't been played before. A non-empty
+// category (matched case-insensitively) also skips puzzles from other
+// categories, in case the server doesn't filter.
+func fetchRandomPuzzleCmd(client *api.Client, sessions storage.Namespace, category string) tea.Cmd {
 	return func() tea.Msg {
 		for range maxRandomRetries {
-			puzzle, err := client.FetchRandomPuzzle()
+			puzzle, err := client.FetchRandomPuzzle(category)
 			if err != nil {
 				return errMsg{err: err}
 			}
+			if category != "" && !strings.EqualFold(puzzle.Category, category) {
+				continue
+			}
 
-			played, err := storage.SessionExists(puzzle.ID)
+			played, err := sessions.SessionExists(puzzle.ID)
 			if err != nil {
 				// Storage errors are best-effort; treat as unplayed
 				return puzzleFetchedMsg{puzzle: puzzle}
@@ -48,32 +144,96 @@ func fetchRandomPuzzleCmd(client *api.Client) tea.Cmd {
 			}
 		}
 
+		if category != "" {
+			return errMsg{err: fmt.Errorf("could not find an unplayed %q puzzle after %d attempts", category, maxRandomRetries)}
+		}
 		return errMsg{err: fmt.Errorf("could not find an unplayed puzzle after %d attempts", maxRandomRetries)}
 	}
 }
 
-// checkSolutionCmd creates a command to check the user's solution
+// checkSolutionCmd creates a command to check the user's solution. A
+// timeout carries the solution back so it can be sent again as is.
 func checkSolutionCmd(client *api.Client, gameID, solution string) tea.Cmd {
 	return func() tea.Msg {
 		result, err := client.CheckSolution(gameID, solution)
+		if isTimeout(err) {
+			return checkTimedOutMsg{err: err, gameID: gameID, solution: solution}
+		}
 		if err != nil {
-			return errMsg{err: err}
+			return playErrMsg{err: err, action: "check your answer"}
 		}
 		return solutionCheckedMsg{correct: result.Correct}
 	}
 }
 
-// tickCmd creates a command that fires a tickMsg after one second
-func tickCmd() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
+// fetchSolutionCmd creates a command to fetch the solution when the player gives up
+func fetchSolutionCmd(client *api.Client, gameID string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := client.FetchSolution(gameID)
+		if err != nil {
+			return playErrMsg{err: err, action: "reveal the solution"}
+		}
+		return solutionRevealedMsg{solution: result.Solution}
+	}
+}
+
+// localPuzzleCmd creates a command that loads a custom puzzle without the API
+func localPuzzleCmd(p *puzzlegen.Puzzle, category string) tea.Cmd {
+	return func() tea.Msg {
+		hints := make([]api.Hint, 0, len(p.Hints))
+		for _, h := range p.Hints {
+			hints = append(hints, api.Hint{CipherLetter: string(h.Cipher), PlainLetter: string(h.Plain)})
+		}
+		return puzzleFetchedMsg{puzzle: &api.Puzzle{
+			ID:            p.ID,
+			EncryptedText: p.EncryptedText,
+			Author:        p.Author,
+			Category:      category,
+			Difficulty:    50, // unrated; custom quotes have no difficulty score
+			Hints:         hints,
+		}, answer: p.Solution}
+	}
+}
+
+// checkAnswerCmd creates a command to check a solution against an answer
+// known without the API (a custom puzzle or one cached for offline play)
+func checkAnswerCmd(answer, solution string) tea.Cmd {
+	return func() tea.Msg {
+		return solutionCheckedMsg{correct: puzzle.SolutionMatches(answer, solution)}
+	}
+}
+
+// revealAnswerCmd creates a command that reveals an answer known without the API
+func revealAnswerCmd(answer string) tea.Cmd {
+	return func() tea.Msg {
+		return solutionRevealedMsg{solution: answer}
+	}
+}
+
+// tickCmd creates a command that fires a tickMsg after interval
+func tickCmd(clk clock.Clock, interval time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		return tickMsg(<-clk.After(interval))
+	}
+}
+
+// waitForEventCmd creates a command that delivers a stream's next event as a
+// streamEventMsg. Handlers re-issue it to keep listening; once the stream is
+// closed it returns nil, ending the subscription.
+func waitForEventCmd(stream *api.Stream) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-stream.Events()
+		if !ok {
+			return nil
+		}
+		return streamEventMsg{stream: stream, event: event}
+	}
 }
 
 // loadSessionCmd creates a command to load a saved session for a game
-func loadSessionCmd(gameID string) tea.Cmd {
+func loadSessionCmd(sessions storage.Namespace, gameID string) tea.Cmd {
 	return func() tea.Msg {
-		session, err := storage.LoadSession(gameID)
+		session, err := sessions.LoadSession(gameID)
 		if err != nil {
 			// Silently treat errors as "no session" - persistence is best-effort
 			// and shouldn't block gameplay. LoadSession already returns nil for
@@ -94,7 +254,8 @@ func checkRemoteSessionCmd(client *api.Client, claimCode, gameID string) tea.Cmd
 	}
 }
 
-// loadConfigCmd creates a command to load the player config from disk.
+// loadConfigCmd creates a command to load the player config from disk,
+// along with the custom theme it names.
 // Returns configLoadedMsg{config: nil} if no config file exists.
 func loadConfigCmd() tea.Cmd {
 	return func() tea.Msg {
@@ -102,10 +263,27 @@ func loadConfigCmd() tea.Cmd {
 		if err != nil {
 			return errMsg{err: err}
 		}
-		return configLoadedMsg{config: cfg}
+		msg := configLoadedMsg{config: cfg}
+		if cfg != nil && cfg.Theme != "" {
+			msg.theme, msg.themeErr = loadTheme(cfg.Theme)
+		}
+		return msg
 	}
 }
 
+// loadTheme reads and checks the theme file for name.
+func loadTheme(name string) (*ui.CustomTheme, error) {
+	file, err := config.LoadTheme(name)
+	if err != nil {
+		return nil, err
+	}
+	theme, err := ui.ParseCustomTheme(file.Background, file.Colors)
+	if err != nil {
+		return nil, fmt.Errorf("theme %s: %w", name, err)
+	}
+	return theme, nil
+}
+
 // registerPlayerCmd creates a command to register a new player via the API.
 // Returns playerRegisteredMsg on success, errMsg on failure.
 func registerPlayerCmd(client *api.Client) tea.Cmd {
@@ -129,118 +307,293 @@ func saveConfigCmd(cfg *config.Config) tea.Cmd {
 	}
 }
 
-// saveSessionCmd creates a command to save the current session state
-func saveSessionCmd(gameID string, cells []puzzle.Cell, elapsed time.Duration) tea.Cmd {
+// savePreferencesCmd persists a preference change to the config file.
+// Best-effort: a failed write keeps the preference for this run only.
+func savePreferencesCmd(cfg *config.Config) tea.Cmd {
 	return func() tea.Msg {
-		// Build inputs map from cells - only store unique cipher->input mappings
-		inputs := make(map[string]string)
-		for _, cell := range cells {
-			if cell.Kind == puzzle.CellLetter && cell.Input != 0 {
-				inputs[string(cell.Char)] = string(cell.Input)
-			}
+		_ = config.Save(cfg)
+		return nil
+	}
+}
+
+// bellCmd rings the terminal bell.
+func bellCmd() tea.Cmd {
+	return tea.Raw(ui.Bell)
+}
+
+// solveNotifyCmd sends a desktop notification (with a bell fallback) for a solve.
+func solveNotifyCmd(elapsed time.Duration) tea.Cmd {
+	return tea.Raw(ui.NotifySequence(fmt.Sprintf("Unquote solved in %s", formatElapsed(elapsed))))
+}
+
+// sessionForPuzzle starts a session for p, carrying the puzzle itself so the
+// session can be shown or resumed without fetching it again.
+func sessionForPuzzle(p *api.Puzzle) *storage.GameSession {
+	var hints map[string]string
+	if len(p.Hints) > 0 {
+		hints = make(map[string]string, len(p.Hints))
+		for _, h := range p.Hints {
+			hints[h.CipherLetter] = h.PlainLetter
 		}
+	}
+	return &storage.GameSession{
+		GameID:        p.ID,
+		Date:          p.Date,
+		EncryptedText: p.EncryptedText,
+		Author:        p.Author,
+		Category:      p.Category,
+		Difficulty:    p.Difficulty,
+		Hints:         hints,
+	}
+}
+
+// puzzleFromSession rebuilds the puzzle a session was saved with, or returns
+// nil for sessions saved before they carried it.
+func puzzleFromSession(s *storage.GameSession) *api.Puzzle {
+	if s.EncryptedText == "" {
+		return nil
+	}
+	p := &api.Puzzle{
+		ID:            s.GameID,
+		Date:          s.Date,
+		EncryptedText: s.EncryptedText,
+		Author:        s.Author,
+		Category:      s.Category,
+		Difficulty:    s.Difficulty,
+	}
+	for _, cipher := range slices.Sorted(maps.Keys(s.Hints)) {
+		p.Hints = append(p.Hints, api.Hint{CipherLetter: cipher, PlainLetter: s.Hints[cipher]})
+	}
+	return p
+}
 
-		session := &storage.GameSession{
-			GameID:      gameID,
-			Inputs:      inputs,
-			ElapsedTime: elapsed,
-			Solved:      false,
+// storedPuzzle finds the puzzle for a date among the sessions saved in the
+// namespace, for resuming or reviewing it when neither the API nor the
+// offline cache has it. Returns nil when no session carries it.
+func storedPuzzle(sessions storage.Namespace, date string) *api.Puzzle {
+	saved, err := sessions.ListSessions()
+	if err != nil {
+		return nil
+	}
+	for i := range saved {
+		if saved[i].Date != date {
+			continue
 		}
+		if p := puzzleFromSession(&saved[i]); p != nil {
+			return p
+		}
+	}
+	return nil
+}
 
+// saveSessionCmd creates a command to save the current session state. The
+// session is built right away: the cells and letter times are shared with the
+// model, which keeps changing them while the command runs. Without a puzzle,
+// or with cells from another one, there is nothing to save.
+func saveSessionCmd(sessions storage.Namespace, savedAt time.Time, p *api.Puzzle, cells []puzzle.Cell, elapsed time.Duration, run speedRun, letters letterTimes, assists storage.Assists) tea.Cmd {
+	session := newSession(p, cells, elapsed, run, letters, assists)
+	if session == nil {
+		return nil
+	}
+	return func() tea.Msg {
 		// Silently ignore errors - persistence is best-effort and shouldn't
 		// interrupt gameplay. File system errors are rare and non-critical.
-		_ = storage.SaveSession(session)
+		_ = sessions.SaveSessionAt(session, savedAt)
 		return nil
 	}
 }
 
+// newSession builds the session saved for a puzzle in progress. It returns
+// nil without a puzzle, or when cells weren't built from p's text: if
+// fetches raced, the inputs would be saved under the wrong game.
+func newSession(p *api.Puzzle, cells []puzzle.Cell, elapsed time.Duration, run speedRun, letters letterTimes, assists storage.Assists) *storage.GameSession {
+	if p == nil || !puzzle.BuiltFrom(cells, p.EncryptedText) {
+		return nil
+	}
+
+	// Build inputs map from cells - only store unique cipher->input mappings
+	inputs := make(map[string]string)
+	var locked []string
+	for _, cell := range cells {
+		if cell.Kind == puzzle.CellLetter && cell.Input != 0 {
+			if _, seen := inputs[string(cell.Char)]; !seen && cell.Locked {
+				locked = append(locked, string(cell.Char))
+			}
+			inputs[string(cell.Char)] = string(cell.Input)
+		}
+	}
+	slices.Sort(locked)
+
+	session := sessionForPuzzle(p)
+	session.Inputs = inputs
+	session.Locked = locked
+	session.ElapsedTime = elapsed
+	session.Target = run.target
+	session.Splits = run.splits
+	session.LetterTimes = letters.forSession()
+	session.Assists = assists
+	session.AssistLevel = assists.Level()
+	return session
+}
+
 // recordSessionCmd creates a command to record a solved session to the server
-func recordSessionCmd(client *api.Client, claimCode, gameID string, completionTime time.Duration, solvedAt time.Time) tea.Cmd {
+func recordSessionCmd(client *api.Client, claimCode, gameID string, completionTime time.Duration, solvedAt time.Time, assists storage.Assists) tea.Cmd {
 	return func() tea.Msg {
-		err := client.RecordSession(claimCode, gameID, completionTime.Milliseconds(), solvedAt)
+		resp, err := client.RecordSession(claimCode, gameID, completionTime.Milliseconds(), solvedAt, apiAssists(assists))
 		if err != nil {
-			// Silently ignore — stats recording is best-effort (AC3.4)
-			return nil
+			// Stats recording is best-effort (AC3.4); the solve stays queued
+			return sessionRecordFailedMsg{gameID: gameID, err: err}
 		}
-		return sessionRecordedMsg{gameID: gameID}
+		return sessionRecordedMsg{gameID: gameID, percentile: resp.Percentile}
+	}
+}
+
+// apiAssists converts a session's assists to the form uploads carry.
+func apiAssists(a storage.Assists) api.Assists {
+	return api.Assists{
+		AssistLevel:     api.AssistLevel(a.Level()),
+		HintsUsed:       a.HintsUsed,
+		AutoCheckUsed:   a.AutoCheckUsed,
+		RevealUsed:      a.RevealUsed,
+		SuggestionsUsed: a.SuggestionsUsed,
 	}
 }
 
 // markSessionUploadedCmd creates a command to mark a session as uploaded in local storage
 func markSessionUploadedCmd(gameID string) tea.Cmd {
 	return func() tea.Msg {
-		session, err := storage.LoadSession(gameID)
-		if err != nil || session == nil {
-			return nil
-		}
-		session.Uploaded = true
-		_ = storage.SaveSession(session)
+		_ = storage.MarkUploaded(gameID)
 		return nil
 	}
 }
 
-// reconcileSessionsCmd creates a command to upload all solved-but-not-uploaded sessions
-func reconcileSessionsCmd(client *api.Client, claimCode string) tea.Cmd {
+// reconcileSessionsCmd creates a command to reconcile this device's sessions
+// with the server; see reconcile.
+func reconcileSessionsCmd(client *api.Client, clk clock.Clock, claimCode string, attempts bool) tea.Cmd {
 	return func() tea.Msg {
-		sessions, err := storage.ListSolvedSessions()
-		if err != nil || len(sessions) == 0 {
-			return reconciliationDoneMsg{}
+		result, err := reconcile(client, clk, claimCode, attempts)
+		return reconciliationDoneMsg{pending: result.Pending, err: err}
+	}
+}
+
+// uploadSolves uploads the solved sessions the server hasn't been sent yet,
+// marking each one that goes through, and returns how many went up, how many
+// are still pending and the first error. Individual failures don't stop the
+// rest (AC5.5). With a non-zero until, no upload starts after it by clk; the
+// ones left count as pending.
+func uploadSolves(client *api.Client, clk clock.Clock, claimCode string, until time.Time) (uploaded, pending int, err error) {
+	sessions, listErr := storage.ListSolvedSessions()
+	if listErr != nil || len(sessions) == 0 {
+		return 0, 0, nil
+	}
+	for i, s := range sessions {
+		if !until.IsZero() && clk.Now().After(until) {
+			return uploaded, pending + len(sessions) - i, err
 		}
-		for _, s := range sessions {
-			// Use the dedicated solved timestamp if present (set since this fix was
-			// introduced); fall back to SavedAt for sessions recorded before the fix.
-			solvedAt := s.SavedAt
-			if s.SolvedAt != nil {
-				solvedAt = *s.SolvedAt
+		// Sessions saved before SolvedAt existed fall back to SavedAt or the
+		// puzzle date, so the server never stamps an old solve with today
+		solvedAt, _ := s.SolveTime()
+		if _, recordErr := client.RecordSession(claimCode, s.GameID, s.CompletionTime.Milliseconds(), solvedAt, apiAssists(s.Assists)); recordErr != nil {
+			pending++
+			if err == nil {
+				err = recordErr
 			}
-			err := client.RecordSession(claimCode, s.GameID, s.CompletionTime.Milliseconds(), solvedAt)
-			if err != nil {
-				// Silently ignore individual failures (AC5.5)
-				continue
-			}
-			// Mark as uploaded — s is a range copy, but that's fine since we only
-			// need to persist the change via SaveSession, not update the original slice
-			s.Uploaded = true
-			_ = storage.SaveSession(&s)
+			continue
+		}
+		_ = storage.MarkUploaded(s.GameID)
+		uploaded++
+	}
+	return uploaded, pending, err
+}
+
+// reportAttempts reports daily puzzles the player started but hasn't solved,
+// including ones they gave up on, so the server's win rate isn't always 100%.
+// Sessions with nothing filled in don't count. Best-effort: failures are
+// retried on the next reconciliation.
+func reportAttempts(client *api.Client, claimCode string) {
+	sessions, err := storage.Daily.ListSessions()
+	if err != nil {
+		return
+	}
+	for _, s := range sessions {
+		if s.Solved || s.AttemptSent || (len(s.Inputs) == 0 && !s.Revealed) {
+			continue
+		}
+		if err := client.RecordAttempt(claimCode, s.GameID, s.SavedAt, s.Revealed); err != nil {
+			continue
 		}
-		return reconciliationDoneMsg{}
+		s.AttemptSent = true
+		_ = storage.SaveSession(&s)
 	}
 }
 
-// fetchStatsCmd creates a command to fetch player stats from the API
-func fetchStatsCmd(client *api.Client, claimCode string) tea.Cmd {
+// fetchStatsCmd creates a command to fetch player stats from the API for
+// the stats screen, tagged with the screen's fetch generation
+func fetchStatsCmd(client *api.Client, claimCode string, fetch int) tea.Cmd {
 	return func() tea.Msg {
 		stats, err := client.FetchStats(claimCode)
 		if err != nil {
-			return errMsg{err: err}
+			return statsFailedMsg{err: err, fetch: fetch, screen: true}
 		}
-		return statsFetchedMsg{stats: stats}
+		return statsFetchedMsg{stats: stats, fetch: fetch}
 	}
 }
 
-// saveSolvedSessionCmd creates a command to save the solved session state
-func saveSolvedSessionCmd(gameID string, cells []puzzle.Cell, completionTime time.Duration, solvedAt time.Time) tea.Cmd {
+// loadGoalCmd computes this week's progress toward the weekly goal for the
+// stats screen, as of now, whose location the weeks follow. Best-effort: a
+// storage error leaves the goal off the screen.
+func loadGoalCmd(weeklyGoal int, now time.Time) tea.Cmd {
 	return func() tea.Msg {
-		// Build inputs map from cells
-		inputs := make(map[string]string)
-		for _, cell := range cells {
-			if cell.Kind == puzzle.CellLetter && cell.Input != 0 {
-				inputs[string(cell.Char)] = string(cell.Input)
-			}
+		progress, err := goal.Load(weeklyGoal, now)
+		if err != nil {
+			return nil
 		}
+		return goalLoadedMsg{progress: progress}
+	}
+}
 
-		session := &storage.GameSession{
-			GameID:         gameID,
-			Inputs:         inputs,
-			ElapsedTime:    completionTime,
-			Solved:         true,
-			CompletionTime: completionTime,
-			SolvedAt:       &solvedAt,
+// fetchSolveStatsCmd fetches player stats in the background after a solve so
+// the solved screen can compare the solve against the player's average.
+// Failures are silent — the comparison panel is optional.
+func fetchSolveStatsCmd(client *api.Client, claimCode string) tea.Cmd {
+	return func() tea.Msg {
+		stats, err := client.FetchStats(claimCode)
+		if err != nil {
+			return statsFailedMsg{err: err}
 		}
+		return solveStatsFetchedMsg{stats: stats}
+	}
+}
 
+// saveSolvedSessionCmd creates a command to save the solved session state,
+// built right away and checked against the puzzle as saveSessionCmd's is
+func saveSolvedSessionCmd(sessions storage.Namespace, p *api.Puzzle, cells []puzzle.Cell, completionTime time.Duration, solvedAt time.Time, run speedRun, letters letterTimes, assists storage.Assists) tea.Cmd {
+	session := newSession(p, cells, completionTime, run, letters, assists)
+	if session == nil {
+		return nil
+	}
+	session.Solved = true
+	session.CompletionTime = completionTime
+	session.SolvedAt = &solvedAt
+	return func() tea.Msg {
 		// Silently ignore errors - persistence is best-effort and shouldn't
 		// interrupt the celebration of solving. File system errors are rare.
-		_ = storage.SaveSession(session)
+		_ = sessions.SaveSessionAt(session, solvedAt)
+		return nil
+	}
+}
+
+// saveRevealedSessionCmd creates a command to save a session the player gave up
+// on. It is saved unsolved so it is never uploaded or counted in stats.
+func saveRevealedSessionCmd(sessions storage.Namespace, savedAt time.Time, p *api.Puzzle, cells []puzzle.Cell, elapsed time.Duration) tea.Cmd {
+	session := newSession(p, cells, elapsed, speedRun{}, nil, storage.Assists{})
+	if session == nil {
+		return nil
+	}
+	session.Revealed = true
+	return func() tea.Msg {
+		// Silently ignore errors - persistence is best-effort
+		_ = sessions.SaveSessionAt(session, savedAt)
 		return nil
 	}
 }
@@ -260,11 +613,12 @@ func shareSessionCmd(data share.SessionShareData) tea.Cmd {
 
 		// Progressive enhancement: generate and share image
 		img := share.GenerateSessionCard(data)
-		if share.CopyImageToClipboard(img) {
+		imageOK := share.CopyImageToClipboard(img)
+		if imageOK {
 			feedback = "Copied image to clipboard!"
 		}
 		share.DisplayInlineImage(img)
 
-		return shareSessionResultMsg{feedback: feedback}
+		return shareSessionResultMsg{feedback: feedback, ok: textOK || imageOK}
 	}
 }
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/statsdiff"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// offersCommunity reports whether the solved puzzle has community stats:
+// any daily puzzle from the API, in any mode. Custom and pack puzzles aren't
+// on the server, nor on servers without the leaderboard.
+func (m Model) offersCommunity() bool {
+	return m.game.puzzle != nil && m.game.puzzle.Date != "" && m.opts.Local == nil && !m.game.offline && m.supports(api.FeatureLeaderboard)
+}
+
+// fetchGlobalStatsCmd creates a command that fetches how every player did on
+// the current puzzle's day, or nil when there is nothing to fetch. Failures
+// are silent; the panel is optional.
+func (m Model) fetchGlobalStatsCmd() tea.Cmd {
+	if !m.offersCommunity() {
+		return nil
+	}
+	client, date := m.client, m.game.puzzle.Date
+	return func() tea.Msg {
+		stats, err := client.FetchGlobalStats(date)
+		if err != nil {
+			return nil
+		}
+		return globalStatsMsg{date: date, stats: stats}
+	}
+}
+
+// handleGlobalStats keeps the community stats for the solved screen. Answers
+// for a puzzle no longer open are dropped.
+func (m Model) handleGlobalStats(msg globalStatsMsg) (tea.Model, tea.Cmd) {
+	if m.game.puzzle == nil || msg.date != m.game.puzzle.Date {
+		return m, nil
+	}
+	m.game.globalStats = msg.stats
+	return m, nil
+}
+
+// renderCommunity renders how every player did on the solved puzzle and, when
+// the server can tell, how hard it played against its stated difficulty.
+func (m Model) renderCommunity() string {
+	s := m.game.globalStats
+	if m.state != StateSolved || s == nil {
+		return ""
+	}
+
+	summary := "Everyone: no solves yet"
+	if s.SolveCount > 0 {
+		solves := fmt.Sprintf("%d solves", s.SolveCount)
+		if s.SolveCount == 1 {
+			solves = "1 solve"
+		}
+		summary = "Everyone: " + solves
+		if s.AverageTime != nil {
+			summary += " · average " + statsdiff.FormatDuration(time.Duration(*s.AverageTime)*time.Millisecond)
+		}
+		if s.MedianTime != nil {
+			summary += " · median " + statsdiff.FormatDuration(time.Duration(*s.MedianTime)*time.Millisecond)
+		}
+	}
+	lines := []string{ui.TimerStyle.Render(summary)}
+
+	if s.PlayedDifficulty != nil {
+		played, rated := *s.PlayedDifficulty, m.game.puzzle.Difficulty
+		playedText, ratedText := puzzle.DifficultyText(played), puzzle.DifficultyText(rated)
+		verdict := "about as rated"
+		switch {
+		case playedText != ratedText && played > rated:
+			verdict = "harder than rated"
+		case playedText != ratedText && played < rated:
+			verdict = "easier than rated"
+		}
+		lines = append(lines, ui.TimerStyle.Render(fmt.Sprintf("Played %s (%d), rated %s (%d): %s",
+			playedText, played, ratedText, rated, verdict)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
+)
+
+// communityModel returns a solved puzzle rated 30 whose day's stats come from
+// an API answering with body.
+func communityModel(t *testing.T, body string) Model {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stats/2026-01-20" {
+			t.Errorf("request = %s, want the puzzle day's stats", r.URL)
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	client, err := api.NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := rolloverModel(t)
+	m.client = client
+	m.state = StateSolved
+	m.game.puzzle.Difficulty = 30
+	puzzle.RevealSolution(m.game.cells, "IT, TI")
+	return m
+}
+
+// fetchCommunity runs the global stats fetch and delivers its answer.
+func fetchCommunity(t *testing.T, m Model) Model {
+	t.Helper()
+	cmd := m.fetchGlobalStatsCmd()
+	if cmd == nil {
+		t.Fatal("fetchGlobalStatsCmd() = nil, want a fetch for a daily puzzle")
+	}
+	model, _ := m.Update(cmd())
+	return model.(Model)
+}
+
+func TestCommunity_ShowsEveryonesTimes(t *testing.T) {
+	m := fetchCommunity(t, communityModel(t, `{"date": "2026-01-20", "solveCount": 1234, "averageTime": 192000, "medianTime": 170000, "playedDifficulty": 68}`))
+
+	view := ansi.Strip(m.viewPlaying())
+	for _, want := range []string{
+		"Everyone: 1234 solves · average 3:12 · median 2:50",
+		"Played Hard (68), rated Medium (30): harder than rated",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("solved screen missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestCommunity_Verdicts(t *testing.T) {
+	m := communityModel(t, "")
+	tests := []struct {
+		name   string
+		want   string
+		played int
+	}{
+		{name: "easier", played: 10, want: "Played Easy (10), rated Medium (30): easier than rated"},
+		{name: "same label", played: 45, want: "Played Medium (45), rated Medium (30): about as rated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m.game.globalStats = &api.GlobalStats{Date: "2026-01-20", PlayedDifficulty: &tt.played}
+			got := ansi.Strip(m.renderCommunity())
+			if !strings.Contains(got, "Everyone: no solves yet") || !strings.Contains(got, tt.want) {
+				t.Errorf("renderCommunity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommunity_DropsStaleAndSkipsLocal(t *testing.T) {
+	m := communityModel(t, "")
+
+	model, _ := m.Update(globalStatsMsg{date: "2026-01-19", stats: &api.GlobalStats{SolveCount: 3}})
+	if model.(Model).game.globalStats != nil {
+		t.Error("stats for another day should be dropped")
+	}
+
+	m.opts.Local = &puzzlegen.Puzzle{}
+	if m.fetchGlobalStatsCmd() != nil {
+		t.Error("a custom puzzle has no community stats")
+	}
+
+	m.opts.Local = nil
+	m.game.globalStats = &api.GlobalStats{SolveCount: 3}
+	if m = m.resetGame(); m.game.globalStats != nil {
+		t.Error("resetGame should clear the community stats")
+	}
+}
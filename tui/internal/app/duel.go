@@ -0,0 +1,252 @@
+package app
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// duelPollInterval is how often a duel reports progress and checks on the opponent.
+const duelPollInterval = 2 * time.Second
+
+// duelBarWidth is the number of cells in each duel progress bar.
+const duelBarWidth = 20
+
+// duelState is a head-to-head race on today's puzzle: both players join the
+// same room and see each other's share of letters filled. Progress is
+// reported by polling; the room's event stream, when the server offers one,
+// delivers the opponent's progress between polls.
+type duelState struct {
+	solvedAt  time.Time       // when this player solved; zero until then
+	opponent  *api.DuelPlayer // nil until someone else joins the room
+	stream    *api.Stream     // room updates pushed by the server; nil until the puzzle loads
+	room      string          // shared room code; empty when not dueling
+	player    string          // this run's anonymous ID in the room, never the claim code
+	connected bool            // the last poll reached the server
+}
+
+// newDuelState joins the given room under a fresh random player ID, so two
+// runs on one machine can still race each other. An empty room plays solo.
+func newDuelState(room string) duelState {
+	if room == "" {
+		return duelState{}
+	}
+	return duelState{room: room, player: rand.Text(), connected: true}
+}
+
+// pollDuelCmd reports progress to the duel room after the given delay and
+// returns the room's state. Always produces a duelPolledMsg, so the caller
+// can schedule the next poll.
+func pollDuelCmd(client *api.Client, room string, progress api.DuelProgressRequest, after time.Duration) tea.Cmd {
+	return tea.Tick(after, func(time.Time) tea.Msg {
+		result, err := client.UpdateDuel(room, progress)
+		return duelPolledMsg{room: result, err: err}
+	})
+}
+
+// duelProgress reports this player's state in the room: the share of letter
+// cells filled, and the solve time once solved.
+func (m Model) duelProgress() api.DuelProgressRequest {
+	progress := api.DuelProgressRequest{Player: m.duel.player}
+	if m.game.puzzle != nil {
+		progress.GameID = m.game.puzzle.ID
+	}
+
+	letters, filled := 0, 0
+	for _, c := range m.game.cells {
+		if c.Kind == puzzle.CellPunctuation {
+			continue
+		}
+		letters++
+		if c.Input != 0 {
+			filled++
+		}
+	}
+	if letters > 0 {
+		progress.Progress = float64(filled) / float64(letters)
+	}
+
+	if !m.duel.solvedAt.IsZero() {
+		solvedAt := m.duel.solvedAt.UTC().Format(time.RFC3339)
+		progress.SolvedAt = &solvedAt
+	}
+	return progress
+}
+
+// opponentSolvedAt returns when the opponent solved, if they have.
+func (m Model) opponentSolvedAt() (time.Time, bool) {
+	if m.duel.opponent == nil || m.duel.opponent.SolvedAt == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, *m.duel.opponent.SolvedAt)
+	return t, err == nil
+}
+
+// applyDuelRoom records the opponent's progress from a room update. The race
+// starts once an opponent has joined.
+func (m Model) applyDuelRoom(room *api.DuelRoom) (Model, tea.Cmd) {
+	for _, p := range room.Players {
+		if p.Player != m.duel.player {
+			p.Player = ui.SanitizeString(p.Player)
+			m.duel.opponent = &p
+			break
+		}
+	}
+
+	if m.state != StateDuelWaiting || m.duel.opponent == nil {
+		return m, nil
+	}
+	m.state = StatePlaying
+	m.game.startTime = m.clock().Now()
+	return m.startTick()
+}
+
+// closeDuelStream stops the room's event stream, if one is open.
+func (m Model) closeDuelStream() Model {
+	if m.duel.stream != nil {
+		m.duel.stream.Close()
+		m.duel.stream = nil
+	}
+	return m
+}
+
+// handleDuelPolled records the opponent's progress and schedules the next
+// poll. Polling, and the room's event stream, stop once both sides are done
+// or this player gave up. A full room ends the run with an error; a server
+// without duels turns it into solo play.
+func (m Model) handleDuelPolled(msg duelPolledMsg) (tea.Model, tea.Cmd) {
+	if m.duel.room == "" {
+		return m, nil
+	}
+	if errors.Is(msg.err, api.ErrUnsupported) {
+		return m.leaveDuel()
+	}
+	if errors.Is(msg.err, api.ErrDuelRoomFull) {
+		m = m.closeDuelStream()
+		m.state = StateError
+		m.errs.load = fmt.Sprintf("Duel room %s already has two players. Pick another code.", m.duel.room)
+		return m, nil
+	}
+
+	m.duel.connected = msg.err == nil
+	var cmd tea.Cmd
+	if msg.room != nil {
+		m, cmd = m.applyDuelRoom(msg.room)
+	}
+
+	_, opponentDone := m.opponentSolvedAt()
+	if m.state == StateSolved && (opponentDone || m.game.revealed) {
+		return m.closeDuelStream(), cmd
+	}
+	return m, tea.Batch(cmd, pollDuelCmd(m.client, m.duel.room, m.duelProgress(), duelPollInterval))
+}
+
+// handleStreamEvent applies a "room" event pushed on the duel's event stream
+// and keeps listening. Events from a stream that has since been closed, and
+// payloads that don't parse, are ignored.
+func (m Model) handleStreamEvent(msg streamEventMsg) (tea.Model, tea.Cmd) {
+	if msg.stream == nil || msg.stream != m.duel.stream {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	var room api.DuelRoom
+	if msg.event.Type == "room" && msg.event.Decode(&room) == nil {
+		m, cmd = m.applyDuelRoom(&room)
+	}
+	return m, tea.Batch(cmd, waitForEventCmd(msg.stream))
+}
+
+// renderDuelBar renders one side's progress, e.g. "You       █████░░░░░  50%".
+func (m Model) renderDuelBar(label string, progress float64) string {
+	progress = min(max(progress, 0), 1)
+	filled := int(progress * duelBarWidth)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", duelBarWidth-filled)
+	if !m.accessible {
+		bar = lipgloss.NewStyle().Foreground(ui.ColorPrimary).Render(bar)
+	}
+	return fmt.Sprintf("%-9s %s %3.0f%%", label, bar, progress*100)
+}
+
+// duelResult describes how the race stands, or "" while both are still solving.
+func (m Model) duelResult() string {
+	theirs, opponentDone := m.opponentSolvedAt()
+	ours := m.duel.solvedAt
+	switch {
+	case m.game.revealed:
+		return "You gave up on this duel."
+	case !ours.IsZero() && opponentDone && !theirs.Before(ours):
+		return fmt.Sprintf("You won the duel by %s!", formatElapsed(theirs.Sub(ours)))
+	case !ours.IsZero() && opponentDone:
+		return fmt.Sprintf("Your opponent won the duel by %s.", formatElapsed(ours.Sub(theirs)))
+	case !ours.IsZero():
+		return "You finished first! Waiting for your opponent..."
+	case opponentDone:
+		return "Your opponent has finished. Keep going!"
+	default:
+		return ""
+	}
+}
+
+// renderDuel renders the duel panel: a progress bar for each side and how the
+// race stands. The accessible layout describes progress in words instead.
+func (m Model) renderDuel() string {
+	if m.duel.room == "" {
+		return ""
+	}
+
+	ours := m.duelProgress().Progress
+	var lines []string
+	if m.accessible {
+		line := fmt.Sprintf("Duel: you have filled %.0f%% of the letters", ours*100)
+		if m.duel.opponent != nil {
+			line += fmt.Sprintf(", your opponent %.0f%%", m.duel.opponent.Progress*100)
+		}
+		lines = append(lines, line+".")
+	} else {
+		lines = append(lines, m.renderDuelBar("You", ours))
+		if m.duel.opponent != nil {
+			lines = append(lines, m.renderDuelBar("Opponent", m.duel.opponent.Progress))
+		}
+	}
+
+	if result := m.duelResult(); result != "" {
+		lines = append(lines, result)
+	}
+	if !m.duel.connected {
+		lines = append(lines, "Can't reach the duel server, retrying...")
+	}
+
+	if m.accessible {
+		return strings.Join(lines, "\n")
+	}
+	return ui.TimerStyle.Render(strings.Join(lines, "\n"))
+}
+
+// viewDuelWaiting renders the lobby shown until an opponent joins the room.
+func (m Model) viewDuelWaiting() string {
+	lines := []string{
+		m.renderHeader(),
+		"",
+		fmt.Sprintf("Duel room: %s", m.duel.room),
+		"",
+		"Waiting for an opponent to join. Share this command:",
+		fmt.Sprintf("  unquote duel %s", m.duel.room),
+		"",
+		"The clock starts when they arrive.",
+	}
+	if !m.duel.connected {
+		lines = append(lines, "", "Can't reach the duel server, retrying...")
+	}
+	lines = append(lines, ui.HelpStyle.Render(renderHelpItems(m.helpItems())))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
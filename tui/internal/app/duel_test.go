@@ -0,0 +1,170 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// duelModel returns a model racing in room "K7PX2M" on the puzzle "AB CD".
+func duelModel(t *testing.T, state State) Model {
+	t.Helper()
+	m := speedRunModel(state, 0, 0)
+	m.client = newTestClient(t)
+	m.duel = newDuelState("K7PX2M")
+	return m
+}
+
+func solvedAt(t time.Time) *string {
+	s := t.UTC().Format(time.RFC3339)
+	return &s
+}
+
+func TestDuel_WaitsForOpponent(t *testing.T) {
+	m := duelModel(t, StateLoading)
+
+	model, cmd := m.handlePuzzleFetched(puzzleFetchedMsg{puzzle: &api.Puzzle{ID: "game-1", EncryptedText: "AB CD"}})
+	m = model.(Model)
+	if m.state != StateDuelWaiting || cmd == nil {
+		t.Fatalf("state = %v, want waiting with a poll scheduled", m.state)
+	}
+	if view := ansi.Strip(m.viewDuelWaiting()); !strings.Contains(view, "unquote duel K7PX2M") {
+		t.Errorf("waiting view should show how to join:\n%s", view)
+	}
+
+	// Nobody else in the room yet
+	alone := &api.DuelRoom{Players: []api.DuelPlayer{{Player: m.duel.player}}}
+	model, _ = m.handleDuelPolled(duelPolledMsg{room: alone})
+	if m = model.(Model); m.state != StateDuelWaiting {
+		t.Errorf("state = %v, want still waiting", m.state)
+	}
+
+	joined := &api.DuelRoom{Players: []api.DuelPlayer{{Player: m.duel.player}, {Player: "them", Progress: 0.25}}}
+	model, _ = m.handleDuelPolled(duelPolledMsg{room: joined})
+	m = model.(Model)
+	if m.state != StatePlaying || !m.ticking {
+		t.Errorf("state = %v, ticking = %v; want the race started", m.state, m.ticking)
+	}
+	if m.duel.opponent == nil || m.duel.opponent.Progress != 0.25 {
+		t.Errorf("opponent = %+v", m.duel.opponent)
+	}
+}
+
+func TestDuel_RoomFull(t *testing.T) {
+	m := duelModel(t, StateDuelWaiting)
+
+	model, cmd := m.handleDuelPolled(duelPolledMsg{err: api.ErrDuelRoomFull})
+	m = model.(Model)
+	if m.state != StateError || cmd != nil {
+		t.Errorf("state = %v, want an error and no further polling", m.state)
+	}
+	if !strings.Contains(m.errs.load, "K7PX2M") {
+		t.Errorf("errs.load = %q, want the room named", m.errs.load)
+	}
+}
+
+func TestDuelProgress(t *testing.T) {
+	m := duelModel(t, StatePlaying)
+	m.game.cells[0].Input = 'X'
+
+	progress := m.duelProgress()
+	if progress.Progress != 0.25 || progress.Player != m.duel.player || progress.GameID != "game-001" {
+		t.Errorf("duelProgress() = %+v, want a quarter of the letters filled", progress)
+	}
+	if progress.SolvedAt != nil {
+		t.Error("an unsolved run should not report a solve time")
+	}
+}
+
+func TestDuelResult(t *testing.T) {
+	start := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+
+	m := duelModel(t, StateSolved)
+	m.duel.solvedAt = start
+	if got := m.duelResult(); !strings.Contains(got, "finished first") {
+		t.Errorf("duelResult() = %q before the opponent finished", got)
+	}
+
+	m.duel.opponent = &api.DuelPlayer{Player: "them", Progress: 1, SolvedAt: solvedAt(start.Add(42 * time.Second))}
+	if got := m.duelResult(); got != "You won the duel by 00:42!" {
+		t.Errorf("duelResult() = %q", got)
+	}
+
+	m.duel.opponent.SolvedAt = solvedAt(start.Add(-5 * time.Second))
+	if got := m.duelResult(); got != "Your opponent won the duel by 00:05." {
+		t.Errorf("duelResult() = %q", got)
+	}
+
+	m.duel.solvedAt = time.Time{}
+	m.state = StatePlaying
+	if got := m.duelResult(); !strings.Contains(got, "Keep going") {
+		t.Errorf("duelResult() = %q while still solving", got)
+	}
+}
+
+func TestDuel_StopsPollingWhenBothDone(t *testing.T) {
+	m := duelModel(t, StateSolved)
+	m.duel.solvedAt = time.Now()
+
+	room := &api.DuelRoom{Players: []api.DuelPlayer{{Player: "them", Progress: 0.5}}}
+	if _, cmd := m.handleDuelPolled(duelPolledMsg{room: room}); cmd == nil {
+		t.Error("polling should continue while the opponent is still solving")
+	}
+
+	room.Players[0].SolvedAt = solvedAt(time.Now())
+	if _, cmd := m.handleDuelPolled(duelPolledMsg{room: room}); cmd != nil {
+		t.Error("polling should stop once both players are done")
+	}
+}
+
+func TestDuel_KeptOutOfStatsAndHistory(t *testing.T) {
+	m := duelModel(t, StateSolved)
+	m.claimCode = "TIGER-MAPLE-7492"
+
+	if m.recordsStats() || m.sessions() != storage.Duel || m.playsToday() || m.offersNext() {
+		t.Error("a duel should never be recorded, restored, rolled over or followed by another puzzle")
+	}
+}
+
+func TestRenderDuel_Accessible(t *testing.T) {
+	m := duelModel(t, StatePlaying)
+	m.accessible = true
+	m.game.cells[0].Input = 'X'
+	m.duel.opponent = &api.DuelPlayer{Player: "them", Progress: 0.5}
+
+	got := m.renderDuel()
+	if got != "Duel: you have filled 25% of the letters, your opponent 50%." {
+		t.Errorf("renderDuel() = %q", got)
+	}
+	if strings.Contains(got, "\x1b") {
+		t.Error("accessible duel panel should not contain escape sequences")
+	}
+}
+
+func TestDuel_StreamEventStartsRace(t *testing.T) {
+	m := duelModel(t, StateDuelWaiting)
+	m.duel.stream = m.client.SubscribeDuel(m.duel.room)
+	t.Cleanup(m.duel.stream.Close)
+
+	event := api.Event{Type: "room", Data: `{"players":[{"player":"them","progress":0.75}]}`}
+	model, cmd := m.handleStreamEvent(streamEventMsg{stream: m.duel.stream, event: event})
+	m = model.(Model)
+	if m.state != StatePlaying || cmd == nil {
+		t.Errorf("state = %v, want the race started and the stream still read", m.state)
+	}
+	if m.duel.opponent == nil || m.duel.opponent.Progress != 0.75 {
+		t.Errorf("opponent = %+v", m.duel.opponent)
+	}
+
+	// Events from a stream the model no longer holds are dropped
+	stale := m.client.SubscribeDuel(m.duel.room)
+	stale.Close()
+	if _, cmd := m.handleStreamEvent(streamEventMsg{stream: stale, event: event}); cmd != nil {
+		t.Error("a stale stream should not be read again")
+	}
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// errorState keeps the latest failure of each concern apart. Each shows in
+// its own place, so a failure in the background never replaces the screen
+// the player is on; only load failures use StateError.
+type errorState struct {
+	load           string // nothing could be loaded to play (puzzle, config, registration, duel room); StateError shows it
+	play           string // checking or revealing the answer failed; shown over the puzzle until the next try
+	sync           string // the last failed upload of a solve; shown in the status bar while solves wait
+	stats          bool   // stats calls fail while puzzles load fine; see statsdown.go
+	statsDismissed bool   // the player closed the stats banner; it stays closed this run
+}
+
+// handleError shows a load failure on the error screen, or counts down to a
+// retry when the server asked to slow down. A failure arriving while a
+// puzzle is on screen is shown over it instead, so the game isn't lost.
+// The failure of a puzzle fetch a newer one replaced is dropped.
+func (m Model) handleError(msg errMsg) (tea.Model, tea.Cmd) {
+	if m.staleFetch(msg.fetch) {
+		return m, nil
+	}
+	if m.showsPuzzle() {
+		return m.handlePlayError(playErrMsg{err: msg.err, action: "finish a background task"})
+	}
+	if wait, ok := rateLimitWait(msg.err); ok {
+		return m.handleRateLimited(wait)
+	}
+	m.state = StateError
+	m.errs.load = formatErrorMessage(msg.err)
+	m.retryAt = time.Time{}
+	return m, nil
+}
+
+// handlePlayError puts the player back on the puzzle after checking or
+// revealing the answer failed, with the reason under the grid.
+func (m Model) handlePlayError(msg playErrMsg) (tea.Model, tea.Cmd) {
+	if m.state == StateChecking {
+		m.state = StatePlaying
+	}
+	m.loadingMsg = ""
+	m.errs.play = playErrorMessage(msg.action, msg.err)
+	return m, nil
+}
+
+// noteSyncError keeps why the last upload failed for the status bar; a nil
+// err means the uploads went through.
+func (m Model) noteSyncError(err error) Model {
+	m.errs.sync = ""
+	if err != nil {
+		m.errs.sync, _ = describeError(err)
+	}
+	return m
+}
+
+// showsPuzzle reports whether a puzzle is on screen, in play or finished.
+func (m Model) showsPuzzle() bool {
+	return m.game.puzzle != nil && (m.state == StatePlaying || m.state == StateChecking || m.state == StateSolved)
+}
+
+// renderPlayError renders the last failed check or reveal, or nothing.
+func (m Model) renderPlayError() string {
+	if m.errs.play == "" || !m.showsPuzzle() {
+		return ""
+	}
+	return ui.ErrorStyle.Render(ui.WordWrapText(m.errs.play, max(m.width-4, 20)))
+}
+
+// describeError converts an error to a message for the player, and reports
+// whether trying again might help.
+func describeError(err error) (string, bool) {
+	var apiErr *api.APIError
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return "Cannot connect to server. Check that the API is running.", false
+	case isTimeout(err):
+		return "Request timed out.", true
+	case errors.Is(err, api.ErrRateLimited):
+		return "The server is busy.", true
+	case errors.Is(err, api.ErrServerUnavailable):
+		return "The server is unavailable right now.", true
+	case errors.Is(err, api.ErrNotFound):
+		// Retrying won't make it appear
+		return err.Error(), false
+	case errors.Is(err, api.ErrUnsupported):
+		// Retrying won't help; the server lacks the endpoint
+		return "This server is too old for that (" + err.Error() + "). Try another puzzle.", false
+	case errors.Is(err, api.ErrInvalidPuzzle):
+		return "The server sent a puzzle that can't be played (" + err.Error() + "). Try another puzzle.", false
+	case errors.As(err, &apiErr):
+		return err.Error(), true
+	default:
+		return err.Error(), false
+	}
+}
+
+// isTimeout reports whether err is a request that ran out of time.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// formatErrorMessage converts error to user-friendly message for the error
+// screen, where r retries
+func formatErrorMessage(err error) string {
+	text, retryable := describeError(err)
+	if retryable {
+		text += " Press 'r' to retry."
+	}
+	return text
+}
+
+// playErrorMessage describes a failed check or reveal. Letters are input
+// during play, so there is no retry key; the player tries the action again.
+func playErrorMessage(action string, err error) string {
+	text, retryable := describeError(err)
+	msg := fmt.Sprintf("Couldn't %s. %s", action, text)
+	if retryable {
+		msg += " Your progress is saved; try again in a moment."
+	}
+	return msg
+}
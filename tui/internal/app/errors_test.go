@@ -0,0 +1,76 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+)
+
+func TestPlayError_KeepsTheGame(t *testing.T) {
+	m := revealModel(nil, 0)
+	for i := range m.game.cells {
+		m.game.cells[i].Input = 'X'
+	}
+	m.state = StateChecking
+
+	model, _ := m.Update(playErrMsg{err: &api.APIError{Status: 503}, action: "check your answer"})
+	m = model.(Model)
+	if m.state != StatePlaying || m.game.cells[0].Input != 'X' {
+		t.Fatalf("state = %v, first input %q; want the puzzle back as it was", m.state, m.game.cells[0].Input)
+	}
+	want := "Couldn't check your answer. The server is unavailable right now. Your progress is saved; try again in a moment."
+	if m.errs.play != want || m.errs.load != "" {
+		t.Errorf("errs = %+v, want only the play error %q", m.errs, want)
+	}
+	if screen := ansi.Strip(m.layoutPlaying("", "")); !strings.Contains(screen, "Couldn't check your answer.") {
+		t.Errorf("the failure should show with the puzzle:\n%s", screen)
+	}
+
+	model, _ = m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m = model.(Model); m.errs.play != "" {
+		t.Errorf("errs.play = %q after submitting again, want it cleared", m.errs.play)
+	}
+}
+
+func TestHandleError_DoesNotReplaceThePuzzle(t *testing.T) {
+	m := revealModel(nil, 0)
+
+	model, _ := m.Update(errMsg{err: errors.New("disk full")})
+	m = model.(Model)
+	if m.state != StatePlaying || m.errs.load != "" {
+		t.Errorf("state = %v, errs.load = %q; want play going on", m.state, m.errs.load)
+	}
+	if !strings.Contains(m.errs.play, "disk full") {
+		t.Errorf("errs.play = %q, want the failure shown over the puzzle", m.errs.play)
+	}
+}
+
+func TestHandleError_LoadFailureShowsErrorScreen(t *testing.T) {
+	m := Model{state: StateLoading}
+
+	model, _ := m.Update(errMsg{err: &api.APIError{Status: 500, Body: "boom"}})
+	m = model.(Model)
+	if m.state != StateError || m.errs.load != "server returned 500: boom Press 'r' to retry." {
+		t.Errorf("state = %v, errs.load = %q; want the error screen", m.state, m.errs.load)
+	}
+}
+
+func TestReconciliation_KeepsWhySyncFailed(t *testing.T) {
+	m := Model{connection: connOnline, claimCode: "TIGER-MAPLE-7492", state: StatePlaying}
+
+	model, _ := m.Update(reconciliationDoneMsg{pending: 2, err: &api.APIError{Status: 503}})
+	m = model.(Model)
+	if m.errs.sync != "The server is unavailable right now." || m.state != StatePlaying {
+		t.Errorf("errs.sync = %q, state = %v; want the reason kept and play untouched", m.errs.sync, m.state)
+	}
+
+	model, _ = m.Update(pendingSyncMsg{count: 0})
+	if m = model.(Model); m.errs.sync != "" {
+		t.Errorf("errs.sync = %q once nothing waits, want it cleared", m.errs.sync)
+	}
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// offersFavorite reports whether the solved screen lets the player bookmark
+// the quote: one they solved, with every letter filled in to save.
+func (m Model) offersFavorite() bool {
+	return m.state == StateSolved && m.game.puzzle != nil && !m.game.revealed &&
+		len(m.game.cells) > 0 && puzzle.IsComplete(m.game.cells)
+}
+
+// loadFavoriteCmd creates a command that checks whether a puzzle's quote is
+// already a favorite. Errors read as not a favorite; favorites are
+// best-effort like the rest of storage.
+func loadFavoriteCmd(gameID string) tea.Cmd {
+	return func() tea.Msg {
+		favorite, _ := storage.IsFavorite(gameID)
+		return favoriteMsg{gameID: gameID, favorite: favorite}
+	}
+}
+
+// toggleFavoriteCmd creates a command that bookmarks the solved quote, or
+// removes the bookmark it already has.
+func (m Model) toggleFavoriteCmd() tea.Cmd {
+	f := storage.Favorite{
+		AddedAt:  m.clock().Now(),
+		GameID:   m.game.puzzle.ID,
+		Date:     m.game.puzzle.Date,
+		Text:     puzzle.AssembleSolution(m.game.cells),
+		Author:   m.game.puzzle.Author,
+		Category: m.game.puzzle.Category,
+	}
+	return func() tea.Msg {
+		favorite, err := storage.ToggleFavorite(f)
+		return favoriteMsg{err: err, gameID: f.GameID, favorite: favorite, toggled: true}
+	}
+}
+
+// handleFavorite records whether the open puzzle is a favorite. A toggle
+// says what changed in a toast.
+func (m Model) handleFavorite(msg favoriteMsg) (tea.Model, tea.Cmd) {
+	if m.game.puzzle == nil || msg.gameID != m.game.puzzle.ID {
+		return m, nil
+	}
+	if !msg.toggled {
+		m.game.favorite = msg.favorite
+		return m, nil
+	}
+
+	switch {
+	case msg.err != nil:
+		return m.notify(toastError, "Couldn't save favorite: "+msg.err.Error())
+	case msg.favorite:
+		m.game.favorite = true
+		return m.notify(toastSuccess, "★ Added to favorites. See them with 'unquote favorites'")
+	default:
+		m.game.favorite = false
+		return m.notify(toastSuccess, "Removed from favorites")
+	}
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// pressFavorite presses * on the solved screen and delivers the toggle.
+func pressFavorite(t *testing.T, m Model) Model {
+	t.Helper()
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: '*', Text: "*"})
+	if cmd == nil {
+		t.Fatal("* on the solved screen should toggle the favorite")
+	}
+	model, _ = model.(Model).Update(cmd())
+	return model.(Model)
+}
+
+func TestFavorite_Toggle(t *testing.T) {
+	storagetest.UseMemory(t)
+	m := rolloverModel(t)
+	m.state = StateSolved
+	m.game.puzzle.Author = "Mark Twain"
+	m.game.puzzle.Category = "Wit"
+	puzzle.RevealSolution(m.game.cells, "IT, TI")
+	if !slices.Contains(m.helpItems(), helpFavorite) {
+		t.Fatal("the solved screen should offer to favorite the quote")
+	}
+
+	m = pressFavorite(t, m)
+	if !m.game.favorite || !strings.Contains(toastText(m), "Added to favorites") {
+		t.Errorf("favorite = %v, toast %q; want added", m.game.favorite, toastText(m))
+	}
+	if view := ansi.Strip(m.renderStatus()); !strings.Contains(view, "★") {
+		t.Errorf("a favorite should be marked on the solved screen: %q", view)
+	}
+	favorites, err := storage.LoadFavorites()
+	if err != nil || len(favorites) != 1 {
+		t.Fatalf("LoadFavorites() = %v, %v; want the quote", favorites, err)
+	}
+	if f := favorites[0]; f.GameID != "game-0120" || f.Date != "2026-01-20" || f.Text != "IT, TI" ||
+		f.Author != "Mark Twain" || f.Category != "Wit" {
+		t.Errorf("favorite = %+v, want the solved quote", f)
+	}
+
+	m = m.expireToasts(time.Now().Add(time.Hour))
+	if !slices.Contains(m.helpItems(), helpUnfavorite) {
+		t.Error("a favorite should offer to remove the bookmark")
+	}
+	m = pressFavorite(t, m)
+	if m.game.favorite || toastText(m) != "Removed from favorites" {
+		t.Errorf("favorite = %v, toast %q; want removed", m.game.favorite, toastText(m))
+	}
+	if favorites, _ := storage.LoadFavorites(); len(favorites) != 0 {
+		t.Errorf("favorites = %+v after removing, want none", favorites)
+	}
+}
+
+func TestFavorite_LoadedWithPuzzle(t *testing.T) {
+	storagetest.UseMemory(t)
+	if _, err := storage.ToggleFavorite(storage.Favorite{GameID: "game-0120", Text: "IT, TI"}); err != nil {
+		t.Fatal(err)
+	}
+	m := rolloverModel(t)
+
+	model, _ := m.Update(loadFavoriteCmd("game-0120")())
+	if !model.(Model).game.favorite {
+		t.Error("a puzzle already bookmarked should load as a favorite")
+	}
+	model, _ = m.Update(favoriteMsg{gameID: "game-0119", favorite: true})
+	if model.(Model).game.favorite {
+		t.Error("a check for another puzzle should be ignored")
+	}
+}
+
+func TestFavorite_NotOfferedUnsolved(t *testing.T) {
+	storagetest.UseMemory(t)
+	m := rolloverModel(t)
+	m.state = StateSolved
+	m.game.revealed = true
+	puzzle.RevealSolution(m.game.cells, "IT, TI")
+
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: '*', Text: "*"})
+	if cmd != nil || model.(Model).game.favorite || slices.Contains(m.helpItems(), helpFavorite) {
+		t.Error("a revealed puzzle wasn't solved and can't be a favorite")
+	}
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// timerSlot stands in for the timer while the rest of the playing screen is
+// laid out and scanned for zones. It is a private-use character that no
+// puzzle, style or zone marker produces, and it sits alone on its line.
+const timerSlot = "\ue000"
+
+// frameCache keeps the last playing screen as laid out with timerSlot, so a
+// tick that changes nothing but the time reuses the zone-scanned screen and
+// only redraws the timer's line. Model is copied by value, so it sits behind a
+// pointer every copy shares; a nil cache draws every frame in full.
+type frameCache struct {
+	raw   string   // screen with timerSlot, before zone scanning
+	lines []string // raw after zone scanning, one entry per line
+	timer int      // index of timerSlot's line in lines; -1 if it has none
+	scans int      // frames scanned rather than reused
+}
+
+// render returns screen, a layout holding timerSlot, zone-scanned and with
+// timer drawn in the slot's place. The screen is only scanned again when it
+// differs from the last frame's; the timer line is padded to the width the
+// slot's line had, so a longer or shorter time doesn't shift anything.
+func (c *frameCache) render(screen, timer string) string {
+	if c == nil {
+		return zone.Scan(strings.Replace(screen, timerSlot, timer, 1))
+	}
+	if screen != c.raw || c.lines == nil {
+		c.raw = screen
+		c.lines = strings.Split(zone.Scan(screen), "\n")
+		c.timer = slotLine(c.lines)
+		c.scans++
+	}
+	if c.timer < 0 {
+		return strings.Join(c.lines, "\n")
+	}
+
+	lines := make([]string, len(c.lines))
+	copy(lines, c.lines)
+	slot := lines[c.timer]
+	line := strings.Replace(strings.TrimRight(slot, " "), timerSlot, timer, 1)
+	if pad := ansi.StringWidth(slot) - ansi.StringWidth(line); pad > 0 {
+		line += strings.Repeat(" ", pad)
+	}
+	lines[c.timer] = line
+	return strings.Join(lines, "\n")
+}
+
+// slotLine returns the index of the line holding timerSlot, or -1.
+func slotLine(lines []string) int {
+	for i, line := range lines {
+		if strings.Contains(line, timerSlot) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// frameModel returns a model checking hugeQuote with elapsed on the clock,
+// drawing frames through frame (nil draws every frame in full).
+func frameModel(t *testing.T, elapsed time.Duration, frame *frameCache) Model {
+	t.Helper()
+	m := benchModel(t, hugeQuote, 80, newGridCache())
+	m.state = StateChecking
+	m.game.elapsedAtPause = elapsed
+	m.frame = frame
+	return m
+}
+
+func TestFrameCache_MatchesFullView(t *testing.T) {
+	frame := &frameCache{}
+	for _, elapsed := range []time.Duration{
+		5 * time.Second,
+		6 * time.Second,
+		59*time.Minute + 59*time.Second,
+		3*time.Hour + 2*time.Minute, // longer than the times before it
+		time.Second,
+	} {
+		got := frameModel(t, elapsed, frame).View().Content
+		want := frameModel(t, elapsed, nil).View().Content
+		if got != want {
+			t.Fatalf("at %s, frame differs from full view:\n%s\nwant:\n%s", elapsed, got, want)
+		}
+	}
+}
+
+func TestFrameCache_TickReusesScannedScreen(t *testing.T) {
+	frame := &frameCache{}
+	m := frameModel(t, time.Second, frame)
+	m.View()
+	if frame.scans != 1 {
+		t.Fatalf("first frame scanned %d times, want 1", frame.scans)
+	}
+
+	// Only the clock moved: the screen is reused and only the timer redrawn
+	m.game.elapsedAtPause = 2 * time.Second
+	view := ansi.Strip(m.View().Content)
+	if frame.scans != 1 {
+		t.Errorf("tick rescanned the screen: %d scans, want 1", frame.scans)
+	}
+	if !strings.Contains(view, "Time: 00:02") {
+		t.Errorf("tick frame doesn't show the new time:\n%s", view)
+	}
+	if strings.Contains(view, timerSlot) {
+		t.Errorf("tick frame still holds the timer slot:\n%s", view)
+	}
+
+	// Anything else changing scans the screen again
+	m = m.moveCursor()
+	m.View()
+	if frame.scans != 2 {
+		t.Errorf("cursor move scanned %d times in total, want 2", frame.scans)
+	}
+}
+
+func TestFrameCache_NilRendersInFull(t *testing.T) {
+	var frame *frameCache
+	got := ansi.Strip(frame.render("a\n"+timerSlot+"\nb", "Time: 00:01"))
+	if got != "a\nTime: 00:01\nb" {
+		t.Errorf("render = %q, want the timer in the slot", got)
+	}
+}
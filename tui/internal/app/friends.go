@@ -0,0 +1,123 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// statsTab is the stats screen's tab: the player's own stats, how they
+// compare with their friends, or how they do in each puzzle category.
+type statsTab int
+
+const (
+	statsTabYou statsTab = iota
+	statsTabFriends
+	statsTabCategories
+)
+
+// nextTab returns the tab Tab switches to, skipping tabs with nothing to
+// show; friends reports whether the Friends tab is offered. It is the
+// current tab when there is no other.
+func (s statsModel) nextTab(friends bool) statsTab {
+	offered := []bool{
+		statsTabYou:        true,
+		statsTabFriends:    friends,
+		statsTabCategories: s.hasCategories(),
+	}
+	for i := 1; i < len(offered); i++ {
+		tab := (s.tab + statsTab(i)) % statsTab(len(offered))
+		if offered[tab] {
+			return tab
+		}
+	}
+	return s.tab
+}
+
+// friendNameWidth caps the name column of the friends table.
+const friendNameWidth = 28
+
+// friendStats is one friend's row on the Friends tab.
+type friendStats struct {
+	stats *api.PlayerStatsResponse // nil when their stats couldn't be loaded
+	code  string
+}
+
+// hasFriends reports whether the player has added any friends to compare with.
+func (m Model) hasFriends() bool {
+	return m.cfg != nil && len(m.cfg.Friends) > 0
+}
+
+// fetchFriendStatsCmd creates a command that loads every friend's stats, a few
+// at a time. A friend whose stats fail to load is kept with nil stats, so one
+// bad code doesn't hide the rest.
+func fetchFriendStatsCmd(client *api.Client, codes []string) tea.Cmd {
+	codes = append([]string(nil), codes...)
+	return func() tea.Msg {
+		stats, _ := client.FetchStatsForCodes(codes)
+		friends := make([]friendStats, len(codes))
+		for i, code := range codes {
+			friends[i] = friendStats{code: code, stats: stats[i]}
+		}
+		return friendStatsMsg{friends: friends}
+	}
+}
+
+// compareAverage describes the player's average time against a friend's,
+// e.g. "you're 0:25 faster". Empty when either has no solves yet.
+func compareAverage(yours, theirs *float64) string {
+	if yours == nil || theirs == nil {
+		return ""
+	}
+	diff := *theirs - *yours
+	switch {
+	case diff >= 1000:
+		return fmt.Sprintf("you're %s faster", formatMs(diff))
+	case diff <= -1000:
+		return fmt.Sprintf("you're %s slower", formatMs(-diff))
+	default:
+		return "neck and neck"
+	}
+}
+
+// renderFriendsTab renders the Friends tab: the player's streak and average
+// time next to each friend's, with how the averages compare.
+func (s statsModel) renderFriendsTab(accessible bool) string {
+	if s.friends == nil {
+		return ui.HelpStyle.Render("Loading your friends' stats...")
+	}
+
+	optMs := func(ms *float64) string {
+		if ms == nil {
+			return "—"
+		}
+		return formatMs(*ms)
+	}
+	row := func(name, streak, avg, note string) string {
+		name = ansi.Truncate(name, friendNameWidth, "…")
+		return strings.TrimRight(fmt.Sprintf("  %-*s %7s %9s   %s", friendNameWidth, name, streak, avg, note), " ")
+	}
+
+	header := row("Player", "Streak", "Avg Time", "")
+	lines := []string{header}
+	lines = append(lines, row("You", fmt.Sprintf("%d", s.player.CurrentStreak), optMs(s.player.AverageTime), ""))
+	for _, f := range s.friends {
+		if f.stats == nil {
+			lines = append(lines, row(f.code, "—", "—", "couldn't load stats"))
+			continue
+		}
+		lines = append(lines, row(f.code, fmt.Sprintf("%d", f.stats.CurrentStreak), optMs(f.stats.AverageTime),
+			compareAverage(s.player.AverageTime, f.stats.AverageTime)))
+	}
+
+	if !accessible {
+		lines[0] = lipgloss.NewStyle().Foreground(ui.ColorMuted).Render(header)
+	}
+	return strings.Join(lines, "\n")
+}
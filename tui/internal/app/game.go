@@ -0,0 +1,257 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"charm.land/bubbles/v2/list"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// gameModel is the puzzle being played: everything about it that goes when
+// the player moves on to another, from the grid and timer to the solved
+// screen's panels. update and view are its entry points from the router.
+// Both take the app the game is part of, since playing drives the status
+// bar, config and API calls that every screen shares.
+type gameModel struct {
+	puzzle          *api.Puzzle
+	percentile      *float64          // today's solve vs. other players, from the record-session response
+	quoteContext    *api.QuoteContext // background on the solved quote; nil until looked up
+	globalStats     *api.GlobalStats  // how every player did on the solved puzzle's day; nil until fetched
+	noteInput       *textinput.Model  // note editor on the solved screen; nil when closed
+	picker          *letterPicker     // Tab letter picker while playing; nil when closed
+	jump            *list.Model       // Ctrl+J word list while playing; nil when closed
+	trace           *puzzleTrace      // the open puzzle's trace, from its fetch to the end of play; nil without one
+	startTime       time.Time
+	letters         letterTimes     // when each cipher letter was first and last assigned
+	assists         storage.Assists // help the player had on this puzzle, uploaded with the solve
+	answer          string          // solution known locally (custom or cached puzzle); checked without the API
+	note            string          // the player's note on the solved puzzle, from its session
+	infoNote        string          // shown on the quote info panel in place of context: loading, or why there is none
+	cells           []puzzle.Cell
+	undo            puzzle.UndoStack // the grid's inputs before each edit, for Ctrl+U
+	elapsedAtPause  time.Duration
+	cursorPos       int
+	infoScroll      int  // first visible line of the quote info panel
+	rating          int  // how hard the solve felt, 1-5; 0 until the player rates it
+	arrowMoves      int  // arrow-key moves on this puzzle, counted toward the click tip
+	failedChecks    int  // wrong submissions this run; unlocks the reveal option
+	checkAttempts   int  // retries of the answer check in flight, after timeouts
+	hoverChar       rune // cipher letter under the mouse; previews related-letter highlight
+	lastSearch      rune // cipher letter last found with /; Enter at the prompt finds it again
+	solvedElsewhere bool
+	freshSolve      bool // solved in this run (not restored or solved elsewhere)
+	offline         bool // playing today's puzzle from the offline cache
+	newPuzzle       bool // the daily puzzle rolled over while this one was open
+	revealed        bool // player gave up and the solution was filled in; the game is over but not solved
+	favorite        bool // the solved quote is bookmarked in favorites
+	idle            bool // the timer paused after no input for idleAfter; any key resumes it
+	searching       bool // / was typed; the next letter is a cipher letter to find
+	swapping        bool // Ctrl+T was typed; the next letter is the cipher letter to swap with
+}
+
+// update handles msg for the game, on m: the keys and clicks of the
+// puzzle's screens, the messages of playing it and of the solved screen,
+// and what the note editor and the jump menu need while open. It reports
+// whether msg was for the game.
+func (g gameModel) update(m Model, msg tea.Msg) (tea.Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		return handled(g.updateKey(m, msg))
+	case tea.MouseReleaseMsg:
+		return handled(m.handleGridClick(msg, msg.Mouse().Button))
+	case tea.MouseMotionMsg:
+		if m.state != StatePlaying || m.IsTooSmall() {
+			m.game.hoverChar = 0
+		} else {
+			m.game = g.hover(msg)
+		}
+		return m, nil, true
+	}
+
+	for _, route := range []func(gameModel, Model, tea.Msg) (tea.Model, tea.Cmd, bool){
+		gameModel.updatePlaying,
+		gameModel.updateSolved,
+	} {
+		if model, cmd, ok := route(g, m, msg); ok {
+			return model, cmd, true
+		}
+	}
+
+	switch {
+	case m.state == StateSolved && g.noteInput != nil:
+		return handled(m.updateNoteInput(msg))
+	case m.jumpOpen():
+		return handled(m.updateJump(msg))
+	}
+	return m, nil, false
+}
+
+// updateKey handles msg while the puzzle is on screen, being solved or
+// over. The tutorial takes its own keys, and Ctrl+G toggles the compact
+// grid on either screen.
+func (g gameModel) updateKey(m Model, msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case m.inTutorial():
+		return m.handleTutorialKeyMsg(msg)
+	case msg.String() == "ctrl+g":
+		return m.toggleCompactGrid()
+	case m.state == StatePlaying:
+		return m.handlePlayingKeyMsg(msg)
+	default:
+		return m.handleSolvedKeyMsg(msg)
+	}
+}
+
+// updatePlaying handles the messages of playing a puzzle: loading it and
+// its saved session, the clock, checking and revealing the answer, and a
+// duel's progress. It reports whether msg was one of them.
+func (g gameModel) updatePlaying(m Model, msg tea.Msg) (tea.Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case puzzleFetchedMsg:
+		return handled(m.handlePuzzleFetched(msg))
+	case sessionLoadedMsg:
+		return handled(m.handleSessionLoaded(msg))
+	case remoteSessionMsg:
+		return handled(m.handleRemoteSession(msg))
+	case tickMsg:
+		return handled(m.handleTick(msg))
+	case solutionCheckedMsg:
+		return handled(m.handleSolutionChecked(msg))
+	case solutionRevealedMsg:
+		return handled(m.handleSolutionRevealed(msg))
+	case checkTimedOutMsg:
+		return handled(m.handleCheckTimedOut(msg))
+	case playErrMsg:
+		return handled(m.handlePlayError(msg))
+	case duelPolledMsg:
+		return handled(m.handleDuelPolled(msg))
+	case streamEventMsg:
+		return handled(m.handleStreamEvent(msg))
+	}
+	return m, nil, false
+}
+
+// updateSolved handles the messages of the finished puzzle's screens:
+// recording the solve, its rating, note, favorite and share, the quote
+// info panel and the next-puzzle menu. It reports whether msg was one of
+// them.
+func (g gameModel) updateSolved(m Model, msg tea.Msg) (tea.Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case sessionRecordedMsg:
+		return handled(m.handleSessionRecorded(msg))
+	case sessionRecordFailedMsg:
+		return handled(m.handleSessionRecordFailed(msg))
+	case difficultyRatedMsg:
+		return handled(m.handleDifficultyRated(msg))
+	case noteSavedMsg:
+		return handled(m.handleNoteSaved(msg))
+	case favoriteMsg:
+		return handled(m.handleFavorite(msg))
+	case shareSessionResultMsg:
+		level := toastSuccess
+		if !msg.ok {
+			level = toastWarning
+		}
+		return handled(m.notifyAbout(topicShare, level, msg.feedback))
+	case globalStatsMsg:
+		return handled(m.handleGlobalStats(msg))
+	case quoteContextMsg:
+		return handled(m.handleQuoteContext(msg))
+	case nextChoicesMsg:
+		return handled(m.handleNextChoices(msg))
+	case authorPuzzlesMsg:
+		return handled(m.handleAuthorPuzzles(msg))
+	}
+	return m, nil, false
+}
+
+// view renders the puzzle on m while it is being solved, checked or is
+// over. timer is set when content holds timerSlot, for the frame cache to
+// fill in.
+func (g gameModel) view(m Model) (content, timer string) {
+	switch {
+	case m.accessible:
+		return m.viewPlayingAccessible(), ""
+	case m.idle():
+		return m.viewIdle(), ""
+	case m.frame != nil:
+		// Lay out everything but the timer, which changes every tick
+		return m.playingScreen(timerSlot), m.renderTimer()
+	default:
+		return m.viewPlaying(), ""
+	}
+}
+
+// hover tracks the letter under the mouse, in the grid or in the clues
+// line, so the grid can preview its related-letter highlight.
+func (g gameModel) hover(msg tea.MouseMsg) gameModel {
+	g.hoverChar = g.hintCharAt(msg)
+	if index := g.letterCellAt(msg); index >= 0 {
+		g.hoverChar = g.cells[index].Char
+	}
+	return g
+}
+
+// hintCharAt returns the cipher letter of the clue or hint cell under the
+// mouse, or 0.
+func (g gameModel) hintCharAt(msg tea.MouseMsg) rune {
+	if g.puzzle != nil {
+		for i, hint := range g.puzzle.Hints {
+			if cipher, _, ok := hint.Letters(); ok && zone.Get(fmt.Sprintf("hint-%d", i)).InBounds(msg) {
+				return cipher
+			}
+		}
+	}
+
+	for _, cell := range g.cells {
+		if cell.Kind == puzzle.CellHint && zone.Get(fmt.Sprintf("cell-%d", cell.Index)).InBounds(msg) {
+			return cell.Char
+		}
+	}
+	return 0
+}
+
+// letterCellAt returns the index of the letter cell under the mouse, or -1.
+func (g gameModel) letterCellAt(msg tea.MouseMsg) int {
+	// Check each cell's zone for the mouse position
+	for _, cell := range g.cells {
+		if cell.Kind != puzzle.CellLetter {
+			continue
+		}
+
+		zoneID := fmt.Sprintf("cell-%d", cell.Index)
+		if zone.Get(zoneID).InBounds(msg) {
+			return cell.Index
+		}
+	}
+	return -1
+}
+
+// restoreInputs applies a saved session's inputs, and its locks, to the
+// grid. SetInput propagates each to every cell with the same cipher letter.
+func (g gameModel) restoreInputs(session *storage.GameSession) {
+	for i := range g.cells {
+		if g.cells[i].Kind != puzzle.CellLetter {
+			continue
+		}
+		cipherChar := string(g.cells[i].Char)
+		input := session.Inputs[cipherChar]
+		if input == "" {
+			continue
+		}
+		r, _ := utf8.DecodeRuneInString(input)
+		puzzle.SetInput(g.cells, i, r)
+		if slices.Contains(session.Locked, cipherChar) {
+			puzzle.SetLocked(g.cells, i, true)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"unicode"
 
@@ -13,113 +14,296 @@ import (
 )
 
 const (
-	cellWidth    = 3
-	maxLineWidth = 60
+	cellWidth = 3
+	// compactCellWidth fits "A→X" plus a column separating it from the next cell.
+	compactCellWidth = 4
+	// conflictMarker flags a conflicting input when shape cues are on.
+	conflictMarker = "!"
+	// defaultLineWidth is the wrap width used before the terminal size is known.
+	defaultLineWidth = 60
 )
 
+// gridLineWidth returns the width the grid wraps at. It follows the terminal
+// width, so every WindowSizeMsg re-wraps the grid to use the space available,
+// less the alphabet panel's when it sits beside the grid.
+func (m Model) gridLineWidth() int {
+	if m.width <= 0 {
+		return defaultLineWidth
+	}
+	if m.alphabetShown() && m.alphabetBeside {
+		return m.width - alphabetPanelWidth - alphabetGap
+	}
+	return m.width
+}
+
+// gridCellWidth returns the rendered width of one cell in the current grid mode.
+func (m Model) gridCellWidth() int {
+	if m.compactGrid {
+		return compactCellWidth
+	}
+	return cellWidth
+}
+
+// gridLineSpacing returns how many terminal rows one wrapped grid line takes,
+// and how many of those are the blank gap separating it from the next line.
+// The standard grid stacks input over cipher with a gap; the compact grid
+// puts both on a single row with no gap.
+func (m Model) gridLineSpacing() (rows, gap int) {
+	if m.compactGrid {
+		return 1, 0
+	}
+	return 3, 1
+}
+
+// gridLines groups the cells by word and wraps them into lines that fit gridLineWidth.
+func (m Model) gridLines() [][]puzzle.Cell {
+	groups := ui.GroupCellsByWord(m.game.cells)
+	wrapped := ui.WrapWordGroups(groups, m.gridLineWidth(), m.gridCellWidth())
+
+	lines := make([][]puzzle.Cell, 0, len(wrapped))
+	for _, line := range wrapped {
+		lines = append(lines, ui.FlattenLine(line))
+	}
+	return lines
+}
+
+// cursorLine returns the index of the wrapped grid line containing the cursor,
+// or 0 if the cursor is not on a rendered cell.
+func (m Model) cursorLine() int {
+	for i, line := range m.gridLines() {
+		for _, cell := range line {
+			if cell.Index == m.game.cursorPos {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
 // renderGrid renders the puzzle grid with input cells above cipher letters
 func (m Model) renderGrid() string {
-	if len(m.cells) == 0 {
+	if len(m.game.cells) == 0 {
 		return ""
 	}
 
 	// Derive highlight character from cursor position
 	// Only highlight if cursor is on a letter cell
 	var highlightChar rune
-	if m.cursorPos >= 0 && m.cursorPos < len(m.cells) && m.cells[m.cursorPos].Kind == puzzle.CellLetter {
-		highlightChar = m.cells[m.cursorPos].Char
+	if m.game.cursorPos >= 0 && m.game.cursorPos < len(m.game.cells) && m.game.cells[m.game.cursorPos].Kind == puzzle.CellLetter {
+		highlightChar = m.game.cells[m.game.cursorPos].Char
+	}
+
+	// Hovering a letter previews its related-letter highlight instead
+	if m.game.hoverChar != 0 {
+		highlightChar = m.game.hoverChar
 	}
 
 	// Find duplicate input assignments for warning highlights
-	duplicateInputs := findDuplicateInputs(m.cells)
+	duplicateInputs := findDuplicateInputs(m.game.cells)
 
-	// Group cells by word and wrap into lines
-	groups := ui.GroupCellsByWord(m.cells)
-	lines := ui.WrapWordGroups(groups, maxLineWidth, cellWidth)
+	// Words filled in without conflicts get a quieter mark of their own
+	words := puzzle.WordStates(m.game.cells)
 
-	var renderedLines []string
-	for _, line := range lines {
-		cells := ui.FlattenLine(line)
-		renderedLines = append(renderedLines, m.renderLine(cells, highlightChar, duplicateInputs))
+	lines := m.gridLines()
+	renderedLines := make([]string, 0, len(lines))
+	for n, cells := range lines {
+		keys := make([]lineCell, len(cells))
+		for i, cell := range cells {
+			keys[i] = lineCell{index: cell.Index, key: m.cellKey(cell, highlightChar, duplicateInputs, words)}
+		}
+		renderedLines = append(renderedLines, m.gridCache.line(n, keys))
 	}
+	m.gridCache.trim(len(lines))
 
-	return strings.Join(renderedLines, "\n\n")
+	separator := "\n\n"
+	if m.compactGrid {
+		separator = "\n"
+	}
+	grid := strings.Join(renderedLines, separator)
+	if m.gridCache != nil {
+		// Hand back the last frame's string when nothing changed, so the
+		// viewport cache can compare grids without reading them
+		if grid == m.gridCache.grid {
+			return m.gridCache.grid
+		}
+		m.gridCache.grid, m.gridCache.gridWidth = grid, -1
+	}
+	return grid
 }
 
-// renderLine renders a single line with input row above cipher row
-func (m Model) renderLine(cells []puzzle.Cell, highlightChar rune, duplicateInputs map[rune]bool) string {
-	var columns []string
-
-	for _, cell := range cells {
-		inputContent := m.renderInputCell(cell, highlightChar, duplicateInputs)
-		cipherContent := m.renderCipherCell(cell)
-
-		// Join input and cipher vertically to form a column
-		column := lipgloss.JoinVertical(lipgloss.Left, inputContent, cipherContent)
-
-		// Wrap letter and hint cell columns with zone marker for click detection
-		if cell.Kind == puzzle.CellLetter || cell.Kind == puzzle.CellHint {
-			column = zone.Mark(fmt.Sprintf("cell-%d", cell.Index), column)
+// renderGridLine renders one wrapped grid line from its cells' keys, marking
+// letter and hint cells as click zones. In the standard grid each cell is a
+// column with the input above the cipher letter.
+func renderGridLine(cells []lineCell, cache *gridCache) string {
+	rendered := make([]string, 0, len(cells))
+	for _, c := range cells {
+		content := cache.cell(c.key)
+		if c.key.kind == puzzle.CellLetter || c.key.kind == puzzle.CellHint {
+			content = zone.Mark(fmt.Sprintf("cell-%d", c.index), content)
 		}
-
-		columns = append(columns, column)
+		rendered = append(rendered, content)
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+	if len(cells) > 0 && cells[0].key.compact {
+		return strings.Join(rendered, "")
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
 }
 
-// renderInputCell renders the user input cell (top row)
-func (m Model) renderInputCell(cell puzzle.Cell, highlightChar rune, duplicateInputs map[rune]bool) string {
+// cellKey collects what the cell's rendering depends on: its letters, the
+// look of its input and, with shape cues, the related and conflict marks.
+// words holds puzzle.WordStates of the grid's cells.
+func (m Model) cellKey(cell puzzle.Cell, highlightChar rune, duplicateInputs map[rune][]rune, words []puzzle.WordState) cellKey {
+	key := cellKey{char: cell.Char, kind: cell.Kind, compact: m.compactGrid}
 	if cell.Kind == puzzle.CellPunctuation {
-		// Non-letter: show the character as-is (punctuation, space)
-		return ui.CellStyle.Render(string(cell.Char))
+		return key
 	}
+	key.input = cell.Input
+	key.look = m.cellLook(cell, highlightChar, duplicateInputs, words)
+	key.locked = cell.Locked
+	if m.shapeCues {
+		key.underline = m.isRelated(cell, highlightChar)
+		key.conflict = isConflict(cell, duplicateInputs)
+	}
+	return key
+}
 
-	// Letter or hint cell: show user input or underscore
-	var content string
-	if cell.Input != 0 {
-		content = string(unicode.ToUpper(cell.Input))
-	} else {
-		content = "_"
+// render draws the cell: input above cipher letter, or side by side in the
+// compact grid.
+func (k cellKey) render() string {
+	if k.compact {
+		return k.renderCompact()
 	}
+	return lipgloss.JoinVertical(lipgloss.Left, k.renderInput(), k.renderCipher())
+}
 
-	// Highlight if this is the cursor position (takes precedence)
-	if cell.Index == m.cursorPos {
-		return ui.ActiveCellStyle.Render(content)
+// renderCompact renders a compact grid cell as input then cipher letter,
+// e.g. "A→X", or "_→X" when the cell is empty
+func (k cellKey) renderCompact() string {
+	if k.kind == puzzle.CellPunctuation {
+		return ui.CompactCellStyle.Render(string(k.char))
 	}
 
-	// Highlight duplicate input assignments (warning)
-	if cell.Input != 0 && duplicateInputs[cell.Input] {
-		return ui.DuplicateInputStyle.Render(content)
+	// Input letter keeps the standard cell highlighting, without the cell padding
+	inputStyle := k.look.style().UnsetWidth()
+	if k.underline {
+		inputStyle = inputStyle.Underline(true)
+	}
+	if k.locked {
+		inputStyle = inputStyle.Bold(true)
 	}
+	input := inputStyle.Render(inputContent(puzzle.Cell{Input: k.input}))
 
-	// Highlight related cells (same cipher letter as cursor)
-	if highlightChar != 0 && cell.Char == highlightChar {
-		return ui.RelatedCellStyle.Render(content)
+	// With shape cues a conflict replaces the arrow ("E!X"), keeping the cell width
+	separator := "→"
+	if k.conflict {
+		separator = conflictMarker
 	}
+	cipher := ui.CompactCipherStyle.Render(separator + string(k.char))
 
-	// Hint cells get distinct styling
-	if cell.Kind == puzzle.CellHint {
-		return ui.HintCellStyle.Render(content)
+	return ui.CompactCellStyle.Render(input + cipher)
+}
+
+// renderInput renders the user input cell (top row)
+func (k cellKey) renderInput() string {
+	if k.kind == puzzle.CellPunctuation {
+		// Non-letter: show the character as-is (punctuation, space)
+		return ui.CellStyle.Render(string(k.char))
+	}
+
+	style := k.look.style()
+	content := inputContent(puzzle.Cell{Input: k.input})
+
+	// Shape cues repeat the color-only states so they read without color
+	if k.conflict {
+		content += conflictMarker
+	}
+	if k.underline {
+		style = style.Underline(true)
+	}
+	// Locked letters are bold whatever their look, like the cursor
+	if k.locked {
+		style = style.Bold(true)
 	}
 
-	return ui.CellStyle.Render(content)
+	return style.Render(content)
 }
 
-// renderCipherCell renders the cipher letter cell (bottom row)
-func (m Model) renderCipherCell(cell puzzle.Cell) string {
-	if cell.Kind == puzzle.CellPunctuation {
+// renderCipher renders the cipher letter cell (bottom row)
+func (k cellKey) renderCipher() string {
+	if k.kind == puzzle.CellPunctuation {
 		// Non-letter: empty space below punctuation
 		return ui.CipherStyle.Render(" ")
 	}
 
-	return ui.CipherStyle.Render(string(cell.Char))
+	return ui.CipherStyle.Render(string(k.char))
+}
+
+// renderCompactCell renders a compact grid cell as input then cipher letter,
+// e.g. "A→X", or "_→X" when the cell is empty
+func (m Model) renderCompactCell(cell puzzle.Cell, highlightChar rune, duplicateInputs map[rune][]rune) string {
+	return m.cellKey(cell, highlightChar, duplicateInputs, puzzle.WordStates(m.game.cells)).renderCompact()
+}
+
+// renderInputCell renders the user input cell (top row)
+func (m Model) renderInputCell(cell puzzle.Cell, highlightChar rune, duplicateInputs map[rune][]rune) string {
+	return m.cellKey(cell, highlightChar, duplicateInputs, puzzle.WordStates(m.game.cells)).renderInput()
+}
+
+// isConflict reports whether the cell's input is also assigned to another cipher letter.
+func isConflict(cell puzzle.Cell, duplicateInputs map[rune][]rune) bool {
+	return cell.Input != 0 && len(duplicateInputs[cell.Input]) > 0
+}
+
+// isRelated reports whether the cell shares the highlighted cipher letter,
+// not counting the cursor cell itself.
+func (m Model) isRelated(cell puzzle.Cell, highlightChar rune) bool {
+	return cell.Index != m.game.cursorPos && highlightChar != 0 && cell.Char == highlightChar
 }
 
-// findDuplicateInputs scans cells and returns the set of plaintext input
-// letters that are assigned to two or more distinct cipher letters. This
-// identifies conflicting assignments the player should be warned about.
-func findDuplicateInputs(cells []puzzle.Cell) map[rune]bool {
+// inputContent returns the text shown for a letter or hint cell's input:
+// the player's letter, or an underscore when empty.
+func inputContent(cell puzzle.Cell) string {
+	if cell.Input != 0 {
+		return string(unicode.ToUpper(cell.Input))
+	}
+	return "_"
+}
+
+// cellLook picks the look of a letter or hint cell's input, shared by the
+// standard and compact grids.
+func (m Model) cellLook(cell puzzle.Cell, highlightChar rune, duplicateInputs map[rune][]rune, words []puzzle.WordState) cellLook {
+	switch {
+	case cell.Index == m.game.cursorPos:
+		// The cursor position takes precedence
+		return lookActive
+	case m.game.revealed && cell.Kind == puzzle.CellLetter:
+		// Letters filled in by a reveal are marked as not the player's own
+		return lookRevealed
+	case m.lowBandwidth && cell.Kind == puzzle.CellHint:
+		return lookHint
+	case m.lowBandwidth:
+		// No background tints, which repaint cells across the grid as the
+		// cursor moves; shape cues mark conflicts and related cells instead
+		return lookPlain
+	case isConflict(cell, duplicateInputs):
+		return lookConflict
+	case m.isRelated(cell, highlightChar):
+		return lookRelated
+	case cell.Kind == puzzle.CellHint:
+		return lookHint
+	case cell.Index < len(words) && words[cell.Index] == puzzle.WordConsistent:
+		return lookConsistent
+	default:
+		return lookPlain
+	}
+}
+
+// findDuplicateInputs scans cells and returns the plaintext input letters
+// that are assigned to two or more distinct cipher letters, each mapped to
+// those cipher letters in alphabetical order. This identifies conflicting
+// assignments the player should be warned about.
+func findDuplicateInputs(cells []puzzle.Cell) map[rune][]rune {
 	// Map each plaintext input to the set of cipher letters it's assigned to
 	inputToCiphers := make(map[rune]map[rune]bool)
 
@@ -134,12 +318,103 @@ func findDuplicateInputs(cells []puzzle.Cell) map[rune]bool {
 	}
 
 	// Any input mapped to 2+ distinct cipher letters is a duplicate
-	duplicates := make(map[rune]bool)
+	duplicates := make(map[rune][]rune)
 	for input, ciphers := range inputToCiphers {
-		if len(ciphers) >= 2 {
-			duplicates[input] = true
+		if len(ciphers) < 2 {
+			continue
 		}
+		letters := make([]rune, 0, len(ciphers))
+		for cipher := range ciphers {
+			letters = append(letters, cipher)
+		}
+		slices.Sort(letters)
+		duplicates[input] = letters
 	}
 
 	return duplicates
 }
+
+// conflictWarning explains the first conflicting assignment in plain words,
+// e.g. "Warning: 'E' is assigned to both X and Q", noting how many other
+// letters conflict too. Returns "" when there are no conflicts.
+func conflictWarning(duplicateInputs map[rune][]rune) string {
+	if len(duplicateInputs) == 0 {
+		return ""
+	}
+
+	inputs := make([]rune, 0, len(duplicateInputs))
+	for input := range duplicateInputs {
+		inputs = append(inputs, input)
+	}
+	slices.Sort(inputs)
+
+	ciphers := duplicateInputs[inputs[0]]
+	names := make([]string, 0, len(ciphers))
+	for _, cipher := range ciphers {
+		names = append(names, string(cipher))
+	}
+
+	var assigned string
+	if len(names) == 2 {
+		assigned = "both " + names[0] + " and " + names[1]
+	} else {
+		assigned = strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	}
+
+	warning := fmt.Sprintf("Warning: '%c' is assigned to %s", inputs[0], assigned)
+	if more := len(inputs) - 1; more > 0 {
+		warning += fmt.Sprintf(" (+%d more)", more)
+	}
+	return warning
+}
+
+// syncGridView refreshes the grid viewport's content and size from the current
+// cells and terminal size, keeping the scroll position on whole grid lines so
+// an input row is never shown without its cipher row. When follow is true the
+// viewport scrolls just enough to keep the cursor's line visible.
+func (m Model) syncGridView(follow bool) Model {
+	if len(m.game.cells) == 0 || m.game.puzzle == nil {
+		return m
+	}
+
+	// The alphabet panel goes beside the grid when the terminal has room for
+	// all its rows there, and below it otherwise
+	m.alphabetBeside = m.fitsAlphabetBeside()
+	if m.alphabetBeside && m.height > 0 {
+		chrome := lipgloss.Height(m.layoutPlaying("", timerSlot)) - 1 + statusBarHeight
+		m.alphabetBeside = m.height-chrome >= alphabetRows
+	}
+
+	content := m.renderGrid()
+	total := lipgloss.Height(content)
+
+	avail := total
+	if m.height > 0 {
+		// Everything on the playing screen except the grid's own row, plus the status bar
+		chrome := lipgloss.Height(m.layoutPlaying("", timerSlot)) - 1 + statusBarHeight
+		avail = m.height - chrome
+		if total > avail {
+			// Reserve rows for the "more above" / "more below" indicators
+			avail -= 2
+		}
+	}
+	rows, gap := m.gridLineSpacing()
+	visible := max((avail+gap)/rows, 1)
+
+	m.gridView.SetContent(content)
+	m.gridView.SetWidth(max(m.gridCache.width(content), 1))
+	m.gridView.SetHeight(min(total, visible*rows-gap))
+
+	top := m.gridView.YOffset() / rows
+	if follow {
+		line := m.cursorLine()
+		if line < top {
+			top = line
+		} else if line >= top+visible {
+			top = line - visible + 1
+		}
+	}
+	m.gridView.SetYOffset(top * rows)
+
+	return m
+}
@@ -1,10 +1,16 @@
 package app
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone/v2"
+
 	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
 )
 
 func TestRenderInputCell(t *testing.T) {
@@ -160,7 +166,7 @@ func TestRenderInputCell(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := Model{
-				cursorPos: tt.cursorPos,
+				game: gameModel{cursorPos: tt.cursorPos},
 			}
 
 			result := m.renderInputCell(tt.cell, tt.highlightChar, nil)
@@ -290,7 +296,7 @@ func TestRenderInputCellTableDrivenComprehensive(t *testing.T) {
 			}
 
 			m := Model{
-				cursorPos: tc.cursorPos,
+				game: gameModel{cursorPos: tc.cursorPos},
 			}
 
 			// Only test if we have cells
@@ -354,7 +360,7 @@ func TestRenderInputCellStylePrecedence(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := Model{cursorPos: tt.cursorPos}
+			m := Model{game: gameModel{cursorPos: tt.cursorPos}}
 			result := m.renderInputCell(tt.cell, tt.highlightChar, nil)
 
 			// The result should contain the expected content
@@ -379,14 +385,14 @@ func TestRenderInputCellStylePrecedence(t *testing.T) {
 //nolint:govet
 func TestFindDuplicateInputs(t *testing.T) {
 	tests := []struct {
-		expected map[rune]bool
+		expected map[rune][]rune
 		name     string
 		cells    []puzzle.Cell
 	}{
 		{
 			name:     "no cells",
 			cells:    []puzzle.Cell{},
-			expected: map[rune]bool{},
+			expected: map[rune][]rune{},
 		},
 		{
 			name: "no inputs",
@@ -394,7 +400,7 @@ func TestFindDuplicateInputs(t *testing.T) {
 				{Index: 0, Char: 'A', Input: 0, Kind: puzzle.CellLetter},
 				{Index: 1, Char: 'B', Input: 0, Kind: puzzle.CellLetter},
 			},
-			expected: map[rune]bool{},
+			expected: map[rune][]rune{},
 		},
 		{
 			name: "all unique inputs",
@@ -403,7 +409,7 @@ func TestFindDuplicateInputs(t *testing.T) {
 				{Index: 1, Char: 'B', Input: 'Y', Kind: puzzle.CellLetter},
 				{Index: 2, Char: 'C', Input: 'Z', Kind: puzzle.CellLetter},
 			},
-			expected: map[rune]bool{},
+			expected: map[rune][]rune{},
 		},
 		{
 			name: "same input for same cipher letter is not a duplicate",
@@ -411,7 +417,7 @@ func TestFindDuplicateInputs(t *testing.T) {
 				{Index: 0, Char: 'A', Input: 'X', Kind: puzzle.CellLetter},
 				{Index: 1, Char: 'A', Input: 'X', Kind: puzzle.CellLetter},
 			},
-			expected: map[rune]bool{},
+			expected: map[rune][]rune{},
 		},
 		{
 			name: "same input for different cipher letters is a duplicate",
@@ -419,7 +425,7 @@ func TestFindDuplicateInputs(t *testing.T) {
 				{Index: 0, Char: 'A', Input: 'X', Kind: puzzle.CellLetter},
 				{Index: 1, Char: 'B', Input: 'X', Kind: puzzle.CellLetter},
 			},
-			expected: map[rune]bool{'X': true},
+			expected: map[rune][]rune{'X': {'A', 'B'}},
 		},
 		{
 			name: "multiple duplicate inputs",
@@ -430,7 +436,7 @@ func TestFindDuplicateInputs(t *testing.T) {
 				{Index: 3, Char: 'D', Input: 'Y', Kind: puzzle.CellLetter},
 				{Index: 4, Char: 'E', Input: 'Z', Kind: puzzle.CellLetter},
 			},
-			expected: map[rune]bool{'X': true, 'Y': true},
+			expected: map[rune][]rune{'X': {'A', 'B'}, 'Y': {'C', 'D'}},
 		},
 		{
 			name: "non-letter cells ignored",
@@ -439,7 +445,7 @@ func TestFindDuplicateInputs(t *testing.T) {
 				{Index: 1, Char: ' ', Input: 0, Kind: puzzle.CellPunctuation},
 				{Index: 2, Char: 'B', Input: 'X', Kind: puzzle.CellLetter},
 			},
-			expected: map[rune]bool{'X': true},
+			expected: map[rune][]rune{'X': {'A', 'B'}},
 		},
 		{
 			name: "three cipher letters with same input",
@@ -448,7 +454,7 @@ func TestFindDuplicateInputs(t *testing.T) {
 				{Index: 1, Char: 'B', Input: 'X', Kind: puzzle.CellLetter},
 				{Index: 2, Char: 'C', Input: 'X', Kind: puzzle.CellLetter},
 			},
-			expected: map[rune]bool{'X': true},
+			expected: map[rune][]rune{'X': {'A', 'B', 'C'}},
 		},
 		{
 			name: "partial inputs - only filled cells considered",
@@ -457,7 +463,7 @@ func TestFindDuplicateInputs(t *testing.T) {
 				{Index: 1, Char: 'B', Input: 0, Kind: puzzle.CellLetter},
 				{Index: 2, Char: 'C', Input: 'X', Kind: puzzle.CellLetter},
 			},
-			expected: map[rune]bool{'X': true},
+			expected: map[rune][]rune{'X': {'A', 'C'}},
 		},
 	}
 
@@ -470,9 +476,9 @@ func TestFindDuplicateInputs(t *testing.T) {
 					len(result), len(tt.expected), result, tt.expected)
 			}
 
-			for key := range tt.expected {
-				if !result[key] {
-					t.Errorf("findDuplicateInputs() missing expected key %q in result %v", string(key), result)
+			for key, ciphers := range tt.expected {
+				if !slices.Equal(result[key], ciphers) {
+					t.Errorf("findDuplicateInputs()[%q] = %q, want %q", string(key), string(result[key]), string(ciphers))
 				}
 			}
 		})
@@ -482,7 +488,7 @@ func TestFindDuplicateInputs(t *testing.T) {
 //nolint:govet
 func TestRenderInputCellDuplicateStyle(t *testing.T) {
 	tests := []struct {
-		duplicateInputs map[rune]bool
+		duplicateInputs map[rune][]rune
 		name            string
 		expectedContent string
 		description     string
@@ -495,7 +501,7 @@ func TestRenderInputCellDuplicateStyle(t *testing.T) {
 			cell:            puzzle.Cell{Index: 2, Char: 'B', Input: 'X', Kind: puzzle.CellLetter},
 			cursorPos:       0,
 			highlightChar:   'A',
-			duplicateInputs: map[rune]bool{'X': true},
+			duplicateInputs: map[rune][]rune{'X': {'A', 'B'}},
 			expectedContent: "X",
 			description:     "Cell with duplicate input should render with DuplicateInputStyle",
 		},
@@ -504,7 +510,7 @@ func TestRenderInputCellDuplicateStyle(t *testing.T) {
 			cell:            puzzle.Cell{Index: 0, Char: 'A', Input: 'X', Kind: puzzle.CellLetter},
 			cursorPos:       0,
 			highlightChar:   'A',
-			duplicateInputs: map[rune]bool{'X': true},
+			duplicateInputs: map[rune][]rune{'X': {'A', 'B'}},
 			expectedContent: "X",
 			description:     "Cursor position takes precedence over duplicate warning",
 		},
@@ -513,7 +519,7 @@ func TestRenderInputCellDuplicateStyle(t *testing.T) {
 			cell:            puzzle.Cell{Index: 2, Char: 'A', Input: 'X', Kind: puzzle.CellLetter},
 			cursorPos:       0,
 			highlightChar:   'A',
-			duplicateInputs: map[rune]bool{'X': true},
+			duplicateInputs: map[rune][]rune{'X': {'A', 'B'}},
 			expectedContent: "X",
 			description:     "Duplicate warning takes precedence over related cell highlighting",
 		},
@@ -522,7 +528,7 @@ func TestRenderInputCellDuplicateStyle(t *testing.T) {
 			cell:            puzzle.Cell{Index: 3, Char: 'C', Input: 'Y', Kind: puzzle.CellLetter},
 			cursorPos:       0,
 			highlightChar:   'A',
-			duplicateInputs: map[rune]bool{'X': true},
+			duplicateInputs: map[rune][]rune{'X': {'A', 'B'}},
 			expectedContent: "Y",
 			description:     "Cell without duplicate input should not be affected",
 		},
@@ -540,7 +546,7 @@ func TestRenderInputCellDuplicateStyle(t *testing.T) {
 			cell:            puzzle.Cell{Index: 2, Char: 'B', Input: 0, Kind: puzzle.CellLetter},
 			cursorPos:       0,
 			highlightChar:   0,
-			duplicateInputs: map[rune]bool{'X': true},
+			duplicateInputs: map[rune][]rune{'X': {'A', 'B'}},
 			expectedContent: "_",
 			description:     "Cell with no input should not be flagged even if its input rune (0) were in the map",
 		},
@@ -549,7 +555,7 @@ func TestRenderInputCellDuplicateStyle(t *testing.T) {
 			cell:            puzzle.Cell{Index: 2, Char: ',', Input: 0, Kind: puzzle.CellPunctuation},
 			cursorPos:       0,
 			highlightChar:   0,
-			duplicateInputs: map[rune]bool{',': true},
+			duplicateInputs: map[rune][]rune{',': {'A', 'B'}},
 			expectedContent: ",",
 			description:     "Non-letter cells should never get duplicate styling",
 		},
@@ -557,7 +563,7 @@ func TestRenderInputCellDuplicateStyle(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := Model{cursorPos: tt.cursorPos}
+			m := Model{game: gameModel{cursorPos: tt.cursorPos}}
 			result := m.renderInputCell(tt.cell, tt.highlightChar, tt.duplicateInputs)
 
 			if !strings.Contains(result, tt.expectedContent) {
@@ -571,7 +577,7 @@ func TestRenderInputCellDuplicateStyle(t *testing.T) {
 func TestRenderInputCellHintStyle(t *testing.T) {
 	//nolint:govet
 	tests := []struct {
-		duplicateInputs map[rune]bool
+		duplicateInputs map[rune][]rune
 		name            string
 		expectedContent string
 		cell            puzzle.Cell
@@ -606,7 +612,7 @@ func TestRenderInputCellHintStyle(t *testing.T) {
 			cell:            puzzle.Cell{Index: 1, Char: 'B', Input: 'X', Kind: puzzle.CellHint},
 			cursorPos:       5,
 			highlightChar:   0,
-			duplicateInputs: map[rune]bool{'X': true},
+			duplicateInputs: map[rune][]rune{'X': {'A', 'B'}},
 			name:            "hint cell content renders correctly with duplicate map present",
 			expectedContent: "X",
 		},
@@ -614,7 +620,7 @@ func TestRenderInputCellHintStyle(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m := Model{cursorPos: tt.cursorPos}
+			m := Model{game: gameModel{cursorPos: tt.cursorPos}}
 			result := m.renderInputCell(tt.cell, tt.highlightChar, tt.duplicateInputs)
 
 			if !strings.Contains(result, tt.expectedContent) {
@@ -638,3 +644,188 @@ func TestFindDuplicateInputsIgnoresHintCells(t *testing.T) {
 		t.Errorf("expected no duplicates when one input is from a hint cell, got %v", result)
 	}
 }
+
+func TestRenderCompactCell(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		cell puzzle.Cell
+	}{
+		{
+			name: "empty letter cell",
+			cell: puzzle.Cell{Index: 1, Char: 'X', Kind: puzzle.CellLetter},
+			want: "_→X ",
+		},
+		{
+			name: "filled letter cell",
+			cell: puzzle.Cell{Index: 1, Char: 'X', Input: 'a', Kind: puzzle.CellLetter},
+			want: "A→X ",
+		},
+		{
+			name: "hint cell",
+			cell: puzzle.Cell{Index: 1, Char: 'Q', Input: 'E', Kind: puzzle.CellHint},
+			want: "E→Q ",
+		},
+		{
+			name: "punctuation cell",
+			cell: puzzle.Cell{Index: 1, Char: ',', Kind: puzzle.CellPunctuation},
+			want: ",   ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{game: gameModel{cursorPos: -1}}
+			got := ansi.Strip(m.renderCompactCell(tt.cell, 0, nil))
+			if got != tt.want {
+				t.Errorf("renderCompactCell() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderGrid_CompactUsesOneRowPerLine(t *testing.T) {
+	m := Model{game: gameModel{cells: puzzle.BuildCells("XLMW MW E ZIVC PSRK UYSXI XLEX OIITW KSMRK", nil)}, width: 30}
+
+	standard := m.renderGrid()
+	m.compactGrid = true
+	compact := zone.Scan(m.renderGrid())
+
+	lines := len(m.gridLines())
+	if got := lipgloss.Height(compact); got != lines {
+		t.Errorf("compact grid height = %d, want one row per line (%d)", got, lines)
+	}
+	if lipgloss.Height(compact) >= lipgloss.Height(standard) {
+		t.Error("compact grid should be shorter than the standard grid")
+	}
+	for _, line := range strings.Split(compact, "\n") {
+		if w := lipgloss.Width(line); w > m.width {
+			t.Errorf("compact line width %d exceeds %d: %q", w, m.width, ansi.Strip(line))
+		}
+	}
+}
+
+func TestRenderInputCell_ShapeCues(t *testing.T) {
+	conflict := puzzle.Cell{Index: 1, Char: 'X', Input: 'E', Kind: puzzle.CellLetter}
+	related := puzzle.Cell{Index: 2, Char: 'Q', Input: 'A', Kind: puzzle.CellLetter}
+	duplicates := map[rune][]rune{'E': {'Q', 'X'}}
+
+	tests := []struct {
+		cell          puzzle.Cell
+		name          string
+		want          string
+		highlightChar rune
+		shapeCues     bool
+	}{
+		{
+			name:      "conflict marked with !",
+			cell:      conflict,
+			shapeCues: true,
+			want:      ui.DuplicateInputStyle.Render("E!"),
+		},
+		{
+			name:      "conflict color only without shape cues",
+			cell:      conflict,
+			shapeCues: false,
+			want:      ui.DuplicateInputStyle.Render("E"),
+		},
+		{
+			name:          "related cell underlined",
+			cell:          related,
+			highlightChar: 'Q',
+			shapeCues:     true,
+			want:          ui.RelatedCellStyle.Underline(true).Render("A"),
+		},
+		{
+			name:          "related cell color only without shape cues",
+			cell:          related,
+			highlightChar: 'Q',
+			shapeCues:     false,
+			want:          ui.RelatedCellStyle.Render("A"),
+		},
+		{
+			name:      "cursor conflict still marked",
+			cell:      puzzle.Cell{Index: 0, Char: 'X', Input: 'E', Kind: puzzle.CellLetter},
+			shapeCues: true,
+			want:      ui.ActiveCellStyle.Render("E!"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{game: gameModel{cursorPos: 0}, shapeCues: tt.shapeCues}
+			if got := m.renderInputCell(tt.cell, tt.highlightChar, duplicates); got != tt.want {
+				t.Errorf("renderInputCell() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderInputCell_ConsistentWord(t *testing.T) {
+	cells := puzzle.BuildCells("AB CB", nil)
+	puzzle.SetInput(cells, 0, 'T')
+	puzzle.SetInput(cells, 1, 'O')
+	m := Model{game: gameModel{cells: cells, cursorPos: -1}}
+
+	if got, want := m.renderInputCell(cells[0], 0, nil), ui.ConsistentWordStyle.Render("T"); got != want {
+		t.Errorf("renderInputCell() in a filled word = %q, want %q", got, want)
+	}
+	if got, want := m.renderInputCell(cells[4], 0, nil), ui.CellStyle.Render("O"); got != want {
+		t.Errorf("renderInputCell() in a word with a gap = %q, want %q", got, want)
+	}
+
+	// Giving C the same letter as A conflicts; the first word loses its mark
+	puzzle.SetInput(cells, 3, 'T')
+	m.game.cells = cells
+	duplicates := findDuplicateInputs(cells)
+	if got, want := m.renderInputCell(cells[1], 0, duplicates), ui.CellStyle.Render("O"); got != want {
+		t.Errorf("renderInputCell() in a word with a conflict = %q, want %q", got, want)
+	}
+}
+
+func TestRenderCompactCell_ShapeCuesConflict(t *testing.T) {
+	m := Model{game: gameModel{cursorPos: -1}, shapeCues: true}
+	cell := puzzle.Cell{Index: 1, Char: 'X', Input: 'E', Kind: puzzle.CellLetter}
+
+	got := ansi.Strip(m.renderCompactCell(cell, 0, map[rune][]rune{'E': {'Q', 'X'}}))
+	if got != "E!X " {
+		t.Errorf("renderCompactCell() = %q, want %q", got, "E!X ")
+	}
+}
+
+func TestConflictWarning(t *testing.T) {
+	tests := []struct {
+		duplicates map[rune][]rune
+		name       string
+		want       string
+	}{
+		{
+			name:       "no conflicts",
+			duplicates: map[rune][]rune{},
+			want:       "",
+		},
+		{
+			name:       "two cipher letters",
+			duplicates: map[rune][]rune{'E': {'Q', 'X'}},
+			want:       "Warning: 'E' is assigned to both Q and X",
+		},
+		{
+			name:       "three cipher letters",
+			duplicates: map[rune][]rune{'E': {'M', 'Q', 'X'}},
+			want:       "Warning: 'E' is assigned to M, Q and X",
+		},
+		{
+			name:       "several conflicting letters",
+			duplicates: map[rune][]rune{'T': {'A', 'B'}, 'E': {'Q', 'X'}, 'S': {'C', 'D'}},
+			want:       "Warning: 'E' is assigned to both Q and X (+2 more)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := conflictWarning(tt.duplicates); got != tt.want {
+				t.Errorf("conflictWarning() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package app
+
+import (
+	"slices"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// maxCachedCells caps the rendered-cell cache; when it fills up it starts over.
+const maxCachedCells = 4096
+
+// cellLook is the style a letter or hint cell's input is drawn with.
+type cellLook uint8
+
+const (
+	lookPlain      cellLook = iota
+	lookActive              // under the cursor
+	lookRevealed            // filled in by a reveal
+	lookConflict            // input also assigned to another cipher letter
+	lookRelated             // shares the highlighted cipher letter
+	lookHint                // prefilled by a hint
+	lookConsistent          // in a word filled in without conflicts
+)
+
+// style returns the lipgloss style for the look.
+func (l cellLook) style() lipgloss.Style {
+	switch l {
+	case lookActive:
+		return ui.ActiveCellStyle
+	case lookRevealed:
+		return ui.RevealedCellStyle
+	case lookConflict:
+		return ui.DuplicateInputStyle
+	case lookRelated:
+		return ui.RelatedCellStyle
+	case lookHint:
+		return ui.HintCellStyle
+	case lookConsistent:
+		return ui.ConsistentWordStyle
+	default:
+		return ui.CellStyle
+	}
+}
+
+// cellKey is everything a rendered cell depends on except its position, so
+// cells that look the same share one rendering.
+type cellKey struct {
+	char      rune
+	input     rune
+	kind      puzzle.CellKind
+	look      cellLook
+	underline bool // shape cue: shares the highlighted cipher letter
+	conflict  bool // shape cue: conflicting input
+	locked    bool // the player locked the letter
+	compact   bool
+}
+
+// lineCell is a cell on a wrapped grid line: its key and its index, which
+// names its click zone.
+type lineCell struct {
+	index int
+	key   cellKey
+}
+
+// cachedLine is a grid line as rendered on the last frame.
+type cachedLine struct {
+	cells    []lineCell
+	rendered string
+}
+
+// gridCache memoizes grid rendering across frames: rendered cells by key, and
+// each wrapped line by the cells on it, so a frame only redraws the lines
+// whose cells changed. Model is copied by value, so the cache sits behind a
+// pointer every copy shares. A nil cache renders everything every time.
+type gridCache struct {
+	cells       map[cellKey]string
+	lines       []cachedLine
+	grid        string // the last frame's whole grid, as renderGrid returned it
+	gridWidth   int    // grid's width in cells; -1 until measured
+	view        gridViewKey
+	viewBlock   string // the grid viewport as rendered for view
+	cellRenders int    // cells rendered rather than found in the cache
+	lineRenders int    // lines rendered rather than reused from the last frame
+}
+
+// gridViewKey is what the visible part of the grid depends on: the whole
+// grid and where the viewport sits on it.
+type gridViewKey struct {
+	grid                   string
+	yOffset, width, height int
+}
+
+func newGridCache() *gridCache {
+	return &gridCache{cells: make(map[cellKey]string), gridWidth: -1}
+}
+
+// cell returns the rendered cell for key, rendering it on a miss.
+func (c *gridCache) cell(key cellKey) string {
+	if c == nil {
+		return key.render()
+	}
+	if rendered, ok := c.cells[key]; ok {
+		return rendered
+	}
+	if len(c.cells) >= maxCachedCells {
+		clear(c.cells)
+	}
+	rendered := key.render()
+	c.cells[key] = rendered
+	c.cellRenders++
+	return rendered
+}
+
+// line returns grid line n, reusing the last frame's rendering when the
+// line holds the same cells with the same keys.
+func (c *gridCache) line(n int, cells []lineCell) string {
+	if c == nil {
+		return renderGridLine(cells, nil)
+	}
+	if n < len(c.lines) && slices.Equal(c.lines[n].cells, cells) {
+		return c.lines[n].rendered
+	}
+
+	rendered := renderGridLine(cells, c)
+	c.lineRenders++
+	if n >= len(c.lines) {
+		c.lines = append(c.lines, make([]cachedLine, n-len(c.lines)+1)...)
+	}
+	c.lines[n] = cachedLine{cells: cells, rendered: rendered}
+	return rendered
+}
+
+// trim forgets cached lines past the first n, after the grid got shorter.
+func (c *gridCache) trim(n int) {
+	if c != nil && len(c.lines) > n {
+		c.lines = c.lines[:n]
+	}
+}
+
+// width returns grid's width in cells, measuring it only once per grid.
+func (c *gridCache) width(grid string) int {
+	if c == nil || grid != c.grid {
+		return lipgloss.Width(grid)
+	}
+	if c.gridWidth < 0 {
+		c.gridWidth = lipgloss.Width(grid)
+	}
+	return c.gridWidth
+}
+
+// viewport returns the grid viewport for key, rendering it with render when
+// the grid or the viewport's position changed since the last frame.
+func (c *gridCache) viewport(key gridViewKey, render func() string) string {
+	if c == nil {
+		return render()
+	}
+	if key != c.view {
+		c.view, c.viewBlock = key, render()
+	}
+	return c.viewBlock
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// hugeQuote is a 200+ character cipher text that wraps into many grid lines.
+const hugeQuote = "XBZ QFMWP OEVUS AVT YJKGC VMZE XBZ HNLD RVQ. " +
+	"XBZ QFMWP OEVUS AVT YJKGC VMZE XBZ HNLD RVQ, NSR XBZ HNLD RVQ CNOF SVX " +
+	"QNEZ NX NHH, AZWNJCZ XBZ QFMWP OEVUS AVT YJKGC VMZE XBZ HNLD RVQ NFNDS. " +
+	"XBZ QFMWP OEVUS AVT YJKGC VMZE XBZ HNLD RVQ."
+
+// gridModel returns a model playing hugeQuote in an 80-column terminal,
+// rendering through cache (nil renders uncached).
+func gridModel(cache *gridCache) Model {
+	m := Model{
+		state:     StatePlaying,
+		game:      gameModel{puzzle: &api.Puzzle{ID: "grid", EncryptedText: hugeQuote}, cells: puzzle.BuildCells(hugeQuote, map[rune]rune{'X': 'T'})},
+		width:     80,
+		height:    40,
+		gridCache: cache,
+	}
+	m.game.cursorPos = puzzle.FirstLetterCell(m.game.cells)
+	return m
+}
+
+func TestGridCache_MatchesUncachedRendering(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		compact bool
+		cues    bool
+	}{
+		{name: "standard"},
+		{name: "compact", compact: true},
+		{name: "shape cues", cues: true},
+		{name: "compact shape cues", compact: true, cues: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cached := gridModel(newGridCache())
+			cached.compactGrid, cached.shapeCues = tt.compact, tt.cues
+
+			// Render a few frames, changing the cells between them
+			steps := []func(m Model) Model{
+				func(m Model) Model { return m },
+				func(m Model) Model { puzzle.SetInput(m.game.cells, m.game.cursorPos, 'E'); return m },
+				func(m Model) Model {
+					m.game.cursorPos = puzzle.NextLetterCell(m.game.cells, m.game.cursorPos)
+					return m
+				},
+				func(m Model) Model { puzzle.SetInput(m.game.cells, m.game.cursorPos, 'E'); return m }, // a conflict
+				func(m Model) Model { m.game.hoverChar = 'Q'; return m },
+				func(m Model) Model { m.width = 50; return m },
+				func(m Model) Model { m.game.revealed = true; return m },
+			}
+			for i, step := range steps {
+				cached = step(cached)
+				uncached := cached
+				uncached.gridCache = nil
+				if got, want := cached.renderGrid(), uncached.renderGrid(); got != want {
+					t.Fatalf("frame %d: cached grid differs from uncached:\n%s\nwant:\n%s", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGridCache_RerendersOnlyChangedLines(t *testing.T) {
+	m := gridModel(newGridCache())
+	lines := len(m.gridLines())
+	if lines < 4 {
+		t.Fatalf("long quote wraps into %d lines, want enough to tell changed lines apart", lines)
+	}
+
+	m.renderGrid()
+	if m.gridCache.lineRenders != lines {
+		t.Fatalf("first frame rendered %d lines, want all %d", m.gridCache.lineRenders, lines)
+	}
+
+	// A frame with nothing changed, such as a timer tick, reuses every line
+	cells, linesBefore := m.gridCache.cellRenders, m.gridCache.lineRenders
+	m.renderGrid()
+	if m.gridCache.cellRenders != cells || m.gridCache.lineRenders != linesBefore {
+		t.Errorf("unchanged frame rendered %d cells and %d lines, want none",
+			m.gridCache.cellRenders-cells, m.gridCache.lineRenders-linesBefore)
+	}
+
+	// Moving the cursor onto a different cipher letter redraws the lines
+	// holding the old and new cursor letters, not the whole grid
+	target := m.game.cursorPos
+	for target >= 0 && m.game.cells[target].Char == m.game.cells[m.game.cursorPos].Char {
+		target = puzzle.NextLetterCell(m.game.cells, target)
+	}
+	if target < 0 {
+		t.Fatal("no second cipher letter to move to")
+	}
+	linesBefore = m.gridCache.lineRenders
+	m.game.cursorPos = target
+	m.renderGrid()
+	if redrawn := m.gridCache.lineRenders - linesBefore; redrawn == 0 || redrawn >= lines {
+		t.Errorf("moving the cursor redrew %d of %d lines, want only the changed ones", redrawn, lines)
+	}
+}
+
+func TestGridCache_RepeatedCellsRenderOnce(t *testing.T) {
+	cache := newGridCache()
+	m := gridModel(cache)
+	m.renderGrid()
+
+	// The quote repeats a handful of words; far fewer distinct cells than cells
+	if cache.cellRenders >= len(m.game.cells)/2 {
+		t.Errorf("rendered %d cells for %d in the grid, want repeats shared", cache.cellRenders, len(m.game.cells))
+	}
+}
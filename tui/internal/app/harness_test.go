@@ -0,0 +1,221 @@
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/adrg/xdg"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/clock"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// The harness drives the real tea.Program end to end, in the spirit of
+// teatest: keys go in as raw terminal bytes, commands run on the program's own
+// goroutines against a stub API, and tests wait for what the player would see.
+
+// harnessWait is how long a harness waits for a screen before failing.
+const harnessWait = 5 * time.Second
+
+// harnessCode is the claim code the stub API hands out and answers to.
+const harnessCode = "TIGER-MAPLE-7492"
+
+// stubAPI is an httptest server speaking enough of the API for a daily game:
+// one puzzle, "XM, MX" (answer "AB, BA"), for the harness clock's date.
+type stubAPI struct {
+	date      string
+	failLoads atomic.Int32 // puzzle loads still to fail with a 500
+	loads     atomic.Int32
+	recorded  atomic.Int32
+}
+
+// newStubAPI starts a stub API for the date on clk and points the app at it.
+func newStubAPI(t *testing.T, clk clock.Clock) *stubAPI {
+	t.Helper()
+	s := &stubAPI{date: cache.Today(clk.Now())}
+	srv := httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(srv.Close)
+	t.Setenv("UNQUOTE_API_URL", srv.URL)
+	return s
+}
+
+func (s *stubAPI) serve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method + " " + r.URL.Path {
+	case "GET /health/live":
+		w.WriteHeader(http.StatusOK)
+	case "GET /game/" + s.date:
+		s.loads.Add(1)
+		if s.failLoads.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": "database unavailable"})
+			return
+		}
+		json.NewEncoder(w).Encode(api.Puzzle{ID: "game-1", Date: s.date, EncryptedText: "XM, MX", Author: "Anon", Difficulty: 10})
+	case "POST /game/game-1/check":
+		var req api.CheckRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		json.NewEncoder(w).Encode(api.CheckResponse{Correct: puzzle.SolutionMatches("AB, BA", req.Solution)})
+	case "POST /player":
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(api.RegisterPlayerResponse{ClaimCode: harnessCode})
+	case "POST /player/" + harnessCode + "/session":
+		s.recorded.Add(1)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(api.RecordSessionResponse{Status: "created"})
+	case "GET /player/" + harnessCode + "/stats":
+		json.NewEncoder(w).Encode(api.PlayerStatsResponse{
+			ClaimCode: harnessCode, GamesPlayed: 12, GamesSolved: 11, WinRate: 11.0 / 12,
+			CurrentStreak: 4, BestStreak: 6, RecentSolves: []api.RecentSolve{},
+		})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// screen holds the latest frame the program rendered, without styling.
+type screen struct {
+	mu    sync.Mutex
+	frame string
+}
+
+func (s *screen) set(frame string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.frame = frame
+}
+
+func (s *screen) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.frame
+}
+
+// recorder wraps the app's model, as cmd's crash guard does, and keeps each
+// frame it renders.
+type recorder struct {
+	model  tea.Model
+	screen *screen
+}
+
+func (r recorder) Init() tea.Cmd {
+	return r.model.Init()
+}
+
+func (r recorder) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := r.model.Update(msg)
+	r.model = model
+	return r, cmd
+}
+
+func (r recorder) View() tea.View {
+	v := r.model.View()
+	r.screen.set(ansi.Strip(v.Content))
+	return v
+}
+
+// harness is a running program and the keyboard feeding it.
+type harness struct {
+	t      *testing.T
+	keys   *io.PipeWriter
+	screen *screen
+	done   chan struct{}
+	final  tea.Model
+	err    error
+}
+
+// harnessClock is the fake clock a harness runs on: midday, so the daily
+// puzzle's date doesn't depend on when the test runs.
+func harnessClock() *clock.Fake {
+	return clock.NewFake(time.Date(2026, 1, 20, 12, 0, 0, 0, time.Local))
+}
+
+// harnessHomes gives the run empty config and cache directories and
+// in-memory sessions.
+func harnessHomes(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+	storagetest.UseMemory(t)
+}
+
+// runProgram builds the app with New, as cmd does, and runs it in an 80x30
+// terminal until the test quits it or ends.
+func runProgram(t *testing.T, opts Options) *harness {
+	t.Helper()
+	opts.Insecure = true
+	m, err := New(opts)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	in, keys := io.Pipe()
+	h := &harness{t: t, keys: keys, screen: &screen{}, done: make(chan struct{})}
+	p := tea.NewProgram(recorder{model: m, screen: h.screen},
+		tea.WithInput(in),
+		tea.WithOutput(io.Discard),
+		tea.WithWindowSize(80, 30),
+		tea.WithEnvironment([]string{"TERM=xterm-256color"}),
+		tea.WithoutSignalHandler(),
+	)
+	go func() {
+		defer close(h.done)
+		h.final, h.err = p.Run()
+	}()
+	t.Cleanup(func() {
+		p.Kill()
+		keys.Close()
+		<-h.done
+	})
+	return h
+}
+
+// Type sends keys as a terminal would: raw bytes, escape sequences included.
+func (h *harness) Type(keys string) {
+	h.t.Helper()
+	if _, err := io.WriteString(h.keys, keys); err != nil {
+		h.t.Fatalf("typing %q: %v", keys, err)
+	}
+}
+
+// WaitFor waits until the screen shows text, failing with the last frame if
+// it never does.
+func (h *harness) WaitFor(text string) {
+	h.t.Helper()
+	deadline := time.Now().Add(harnessWait)
+	for !strings.Contains(h.screen.String(), text) {
+		if time.Now().After(deadline) {
+			h.t.Fatalf("screen never showed %q; last frame:\n%s", text, h.screen)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// Quit presses Esc and returns the app's model once the program has stopped.
+func (h *harness) Quit() Model {
+	h.t.Helper()
+	h.Type("\x1b")
+	select {
+	case <-h.done:
+	case <-time.After(harnessWait):
+		h.t.Fatalf("program didn't quit; last frame:\n%s", h.screen)
+	}
+	if h.err != nil {
+		h.t.Fatalf("program error: %v", h.err)
+	}
+	return h.final.(recorder).model.(Model)
+}
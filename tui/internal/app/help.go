@@ -0,0 +1,194 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+)
+
+// helpItem is one action in the help bar. Each item is a bubblezone zone, so
+// clicking it behaves like pressing its key.
+type helpItem struct {
+	label string          // text shown in the help bar, e.g. "[Enter] Submit"
+	key   tea.KeyPressMsg // key press a click on the item stands in for
+}
+
+var (
+	helpSubmit     = helpItem{label: "[Enter] Submit", key: tea.KeyPressMsg{Code: tea.KeyEnter}}
+	helpClear      = helpItem{label: "[Ctrl+C] Clear", key: tea.KeyPressMsg{Code: 'c', Mod: tea.ModCtrl}}
+	helpUndo       = helpItem{label: "[Ctrl+U] Undo", key: tea.KeyPressMsg{Code: 'u', Mod: tea.ModCtrl}}
+	helpCompact    = helpItem{label: "[Ctrl+G] Compact", key: tea.KeyPressMsg{Code: 'g', Mod: tea.ModCtrl}}
+	helpReveal     = helpItem{label: "[Ctrl+V] Reveal", key: tea.KeyPressMsg{Code: 'v', Mod: tea.ModCtrl}}
+	helpQuit       = helpItem{label: "[Esc] Quit", key: tea.KeyPressMsg{Code: tea.KeyEsc}}
+	helpBack       = helpItem{label: "[Esc] Back", key: tea.KeyPressMsg{Code: tea.KeyEsc}}
+	helpRetry      = helpItem{label: "[r] Retry", key: tea.KeyPressMsg{Code: 'r', Text: "r"}}
+	helpStats      = helpItem{label: "[s] Stats", key: tea.KeyPressMsg{Code: 's', Text: "s"}}
+	helpShare      = helpItem{label: "[c] Share", key: tea.KeyPressMsg{Code: 'c', Text: "c"}}
+	helpPlay       = helpItem{label: "[Enter] Play", key: tea.KeyPressMsg{Code: tea.KeyEnter}}
+	helpArchive    = helpItem{label: "[a] Archive", key: tea.KeyPressMsg{Code: 'a', Text: "a"}}
+	helpNewPuzzle  = helpItem{label: "[Ctrl+N] New puzzle", key: tea.KeyPressMsg{Code: 'n', Mod: tea.ModCtrl}}
+	helpNextPuzzle = helpItem{label: "[n] Next puzzle", key: tea.KeyPressMsg{Code: 'n', Text: "n"}}
+	helpMore       = helpItem{label: "[m] More by author", key: tea.KeyPressMsg{Code: 'm', Text: "m"}}
+	helpInfo       = helpItem{label: "[i] About", key: tea.KeyPressMsg{Code: 'i', Text: "i"}}
+	helpFavorite   = helpItem{label: "[*] Favorite", key: tea.KeyPressMsg{Code: '*', Text: "*"}}
+	helpUnfavorite = helpItem{label: "[*] Unfavorite", key: tea.KeyPressMsg{Code: '*', Text: "*"}}
+	helpNote       = helpItem{label: "[N] Note", key: tea.KeyPressMsg{Code: 'N', Text: "N"}}
+	helpLetters    = helpItem{label: "[Tab] Letters", key: tea.KeyPressMsg{Code: tea.KeyTab}}
+	helpFill       = helpItem{label: "[Ctrl+F] Fill", key: tea.KeyPressMsg{Code: 'f', Mod: tea.ModCtrl}}
+	helpPick       = helpItem{label: "[Enter] Pick", key: tea.KeyPressMsg{Code: tea.KeyEnter}}
+	helpWords      = helpItem{label: "[Ctrl+J] Words", key: tea.KeyPressMsg{Code: 'j', Mod: tea.ModCtrl}}
+	helpJump       = helpItem{label: "[Enter] Jump", key: tea.KeyPressMsg{Code: tea.KeyEnter}}
+	helpFilter     = helpItem{label: "[/] Filter", key: tea.KeyPressMsg{Code: '/', Text: "/"}}
+	helpSaveNote   = helpItem{label: "[Enter] Save note", key: tea.KeyPressMsg{Code: tea.KeyEnter}}
+	helpCancel     = helpItem{label: "[Esc] Cancel", key: tea.KeyPressMsg{Code: tea.KeyEsc}}
+	helpFriends    = helpItem{label: "[Tab] Friends", key: tea.KeyPressMsg{Code: tea.KeyTab}}
+	helpYourStats  = helpItem{label: "[Tab] Your stats", key: tea.KeyPressMsg{Code: tea.KeyTab}}
+	helpCategories = helpItem{label: "[Tab] Categories", key: tea.KeyPressMsg{Code: tea.KeyTab}}
+	helpContinue   = helpItem{label: "[Enter] Continue", key: tea.KeyPressMsg{Code: tea.KeyEnter}}
+	helpSkip       = helpItem{label: "[Tab] Skip tutorial", key: tea.KeyPressMsg{Code: tea.KeyTab}}
+	helpDismiss    = helpItem{label: "[Ctrl+X] Dismiss", key: tea.KeyPressMsg{Code: 'x', Mod: tea.ModCtrl}}
+	helpRestore    = helpItem{label: "[y] Restore", key: tea.KeyPressMsg{Code: 'y', Text: "y"}}
+	helpDiscard    = helpItem{label: "[n] Discard", key: tea.KeyPressMsg{Code: 'n', Text: "n"}}
+)
+
+// helpEntry is a help bar action and when the screen offers it.
+type helpEntry struct {
+	item  helpItem
+	shown func(Model) bool
+}
+
+// always offers a help bar action whenever its screen is shown.
+func always(Model) bool { return true }
+
+// playingHelp lists the actions offered while solving, in help bar order.
+var playingHelp = []helpEntry{
+	{helpSubmit, always},
+	{helpClear, always},
+	{helpUndo, func(m Model) bool { return !m.game.undo.Empty() }},
+	{helpCompact, always},
+	{helpLetters, always},
+	// Long quotes are where jumping between words pays off
+	{helpWords, Model.gridScrolls},
+	{helpFill, func(m Model) bool { return len(m.autoFillSuggestions()) > 0 }},
+	{helpReveal, Model.canReveal},
+	{helpNewPuzzle, func(m Model) bool { return m.game.newPuzzle }},
+	{helpDismiss, Model.showsStatsBanner},
+	{helpQuit, always},
+}
+
+// solvedHelp lists the actions offered once the puzzle is over, in help bar
+// order.
+var solvedHelp = []helpEntry{
+	{helpNextPuzzle, Model.offersNext},
+	{helpMore, Model.offersMore},
+	{helpInfo, Model.offersInfo},
+	{helpUnfavorite, func(m Model) bool { return m.offersFavorite() && m.game.favorite }},
+	{helpFavorite, func(m Model) bool { return m.offersFavorite() && !m.game.favorite }},
+	{helpNote, Model.offersNote},
+	{helpArchive, func(m Model) bool { return m.opts.Pack != nil }},
+	{helpStats, func(m Model) bool { return m.claimCode != "" }},
+	// Nothing to share after giving up
+	{helpShare, func(m Model) bool { return !m.game.revealed }},
+	{helpDismiss, Model.showsStatsBanner},
+	{helpQuit, always},
+}
+
+// screenHelp lists the actions of the screens that always offer the same ones.
+var screenHelp = map[State][]helpItem{
+	StateLoading:     {helpQuit},
+	StateError:       {helpRetry, helpQuit},
+	StateRecovery:    {helpRestore, helpDiscard, helpQuit},
+	StateArchive:     {helpPlay, helpQuit},
+	StateDuelWaiting: {helpQuit},
+	StateNextPuzzle:  {helpPlay, helpBack},
+	StateQuoteInfo:   {helpBack},
+}
+
+// helpItems returns the clickable help bar actions for the current screen.
+func (m Model) helpItems() []helpItem {
+	if m.inTutorial() && (m.state == StatePlaying || m.state == StateSolved) {
+		return m.tutorialHelpItems()
+	}
+	switch m.state {
+	case StatePlaying:
+		return m.playingHelpItems()
+	case StateSolved:
+		if m.game.noteInput != nil {
+			return []helpItem{helpSaveNote, helpCancel}
+		}
+		return m.offered(solvedHelp)
+	case StateStats:
+		return m.statsHelpItems()
+	default:
+		return slices.Clone(screenHelp[m.state])
+	}
+}
+
+// playingHelpItems returns the actions of the open prompt or menu, or else
+// the puzzle's own.
+func (m Model) playingHelpItems() []helpItem {
+	switch {
+	case m.pickerOpen():
+		return []helpItem{helpPick, helpCancel}
+	case m.jumpOpen():
+		return []helpItem{helpJump, helpFilter, helpCancel}
+	case m.searchOpen() || m.swapOpen():
+		return []helpItem{helpCancel}
+	default:
+		return m.offered(playingHelp)
+	}
+}
+
+// statsHelpItems names the tab Tab moves to next, if there is another.
+func (m Model) statsHelpItems() []helpItem {
+	if m.stats.player == nil {
+		return []helpItem{helpQuit}
+	}
+	switch next := m.stats.nextTab(m.hasFriends()); {
+	case next == m.stats.tab:
+		return []helpItem{helpBack}
+	case next == statsTabFriends:
+		return []helpItem{helpFriends, helpBack}
+	case next == statsTabCategories:
+		return []helpItem{helpCategories, helpBack}
+	default:
+		return []helpItem{helpYourStats, helpBack}
+	}
+}
+
+// offered returns the actions in entries the current screen offers.
+func (m Model) offered(entries []helpEntry) []helpItem {
+	var items []helpItem
+	for _, entry := range entries {
+		if entry.shown(m) {
+			items = append(items, entry.item)
+		}
+	}
+	return items
+}
+
+// renderHelpItems renders help bar actions separated by two spaces, each
+// marked as a zone so it can be clicked.
+func renderHelpItems(items []helpItem) string {
+	labels := make([]string, 0, len(items))
+	for _, item := range items {
+		labels = append(labels, zone.Mark(helpZoneID(item), item.label))
+	}
+	return strings.Join(labels, "  ")
+}
+
+// helpItemAt returns the help bar action under the mouse, if any.
+func (m Model) helpItemAt(msg tea.MouseMsg) (helpItem, bool) {
+	for _, item := range m.helpItems() {
+		if zone.Get(helpZoneID(item)).InBounds(msg) {
+			return item, true
+		}
+	}
+	return helpItem{}, false
+}
+
+func helpZoneID(item helpItem) string {
+	return "help-" + item.key.String()
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// defaultIdleAfter is how long the player can go without a key press or
+// click before the timer pauses, unless the config says otherwise.
+const defaultIdleAfter = 2 * time.Minute
+
+// idleText is the overlay over the dimmed board while the timer is paused.
+const idleText = "Idle — timer paused. Press any key to resume."
+
+// idleAfter returns how long without input pauses the timer; 0 never does.
+func (m Model) idleAfter() time.Duration {
+	if m.cfg != nil && m.cfg.IdleSeconds != 0 {
+		return max(time.Duration(m.cfg.IdleSeconds)*time.Second, 0)
+	}
+	return defaultIdleAfter
+}
+
+// checkIdle pauses the timer once the player has gone idleAfter without
+// input, as of now; the tick loop calls it. Mouse motion counts as input but
+// only a key or click wakes the timer again. The clock stops at the moment
+// they went idle, not at the tick that noticed, and the game is saved there.
+// Duels race a shared clock and never pause, and a suspended process waits
+// for its resume first.
+func (m Model) checkIdle(now time.Time) (Model, tea.Cmd) {
+	after := m.idleAfter()
+	if m.game.idle || after == 0 || m.state != StatePlaying || m.game.puzzle == nil || m.duel.room != "" || !m.suspendedAt.IsZero() {
+		return m, nil
+	}
+	since := m.lastInput
+	if m.game.startTime.After(since) {
+		since = m.game.startTime
+	}
+	at := since.Add(after)
+	if now.Before(at) {
+		return m, nil
+	}
+	m.game.elapsedAtPause += at.Sub(m.game.startTime)
+	m.game.idle = true
+	return m, saveSessionCmd(m.sessions(), at, m.game.puzzle, m.game.cells, m.game.elapsedAtPause, m.run, m.game.letters, m.game.assists)
+}
+
+// idle reports whether the timer is paused for idleness, which only the
+// playing screen shows.
+func (m Model) idle() bool {
+	return m.game.idle && m.state == StatePlaying
+}
+
+// wake starts the timer again where it paused.
+func (m Model) wake() Model {
+	m.game.idle = false
+	m.game.startTime = m.clock().Now()
+	return m
+}
+
+// viewIdle renders the playing screen dimmed, with idleText over its middle.
+func (m Model) viewIdle() string {
+	screen := lipgloss.NewStyle().Foreground(ui.ColorMuted).Render(ansi.Strip(m.viewPlaying()))
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.ColorPrimary).
+		Foreground(ui.ColorPrimary).
+		Bold(true).
+		Padding(1, 3).
+		Render(idleText)
+	x := max((min(lipgloss.Width(screen), m.width)-lipgloss.Width(box))/2, 0)
+	y := max((lipgloss.Height(screen)-lipgloss.Height(box))/2, 0)
+	return lipgloss.NewCompositor(lipgloss.NewLayer(screen), lipgloss.NewLayer(box).X(x).Y(y).Z(1)).Render()
+}
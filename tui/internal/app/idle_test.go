@@ -0,0 +1,102 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// tickAt runs the tick handler at the clock's time.
+func tickAt(t *testing.T, m Model, now time.Time) (Model, tea.Cmd) {
+	t.Helper()
+	model, cmd := m.handleTick(tickMsg(now))
+	return model.(Model), cmd
+}
+
+// Two minutes without input pause the timer where the player went idle,
+// save the game there and dim the board; the next key resumes it without
+// being typed.
+func TestIdle_PausesTimerUntilAKey(t *testing.T) {
+	storagetest.UseMemory(t)
+	m, clk := clockModel(t)
+	m.sizeReady = true
+
+	clk.Advance(3 * time.Minute)
+	m, cmd := tickAt(t, m, clk.Now())
+	if !m.game.idle {
+		t.Fatal("idle = false after three minutes without input, want the timer paused")
+	}
+	if got := m.Elapsed(); got != defaultIdleAfter {
+		t.Errorf("Elapsed() = %v, want it stopped at the %v the player went idle", got, defaultIdleAfter)
+	}
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		batch[0]()
+	}
+	if s, _ := storage.LoadSession("game-0120"); s == nil || s.ElapsedTime != defaultIdleAfter {
+		t.Errorf("saved session = %+v, want it saved at %v", s, defaultIdleAfter)
+	}
+	if view := ansi.Strip(m.View().Content); !strings.Contains(view, idleText) {
+		t.Errorf("view missing the idle overlay:\n%s", view)
+	}
+
+	clk.Advance(time.Hour)
+	model, _ := m.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	m = model.(Model)
+	if m.game.idle {
+		t.Fatal("idle = true after a key press, want the timer running")
+	}
+	if m.game.cells[m.game.cursorPos].Input != 0 {
+		t.Error("the key that woke the timer was typed into the grid")
+	}
+	clk.Advance(5 * time.Second)
+	if got := m.Elapsed(); got != defaultIdleAfter+5*time.Second {
+		t.Errorf("Elapsed() after resuming = %v, want %v without the hour away", got, defaultIdleAfter+5*time.Second)
+	}
+}
+
+// Input restarts the idle wait.
+func TestIdle_InputKeepsTimerRunning(t *testing.T) {
+	m, clk := clockModel(t)
+
+	clk.Advance(90 * time.Second)
+	model, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	m = model.(Model)
+	clk.Advance(90 * time.Second)
+	if m, _ = tickAt(t, m, clk.Now()); m.game.idle {
+		t.Error("idle = true 90s after the last key, want the timer running")
+	}
+}
+
+func TestIdle_NeverPauses(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(m *Model)
+	}{
+		{name: "turned off", change: func(m *Model) { m.cfg = &config.Config{IdleSeconds: -1} }},
+		{name: "duel", change: func(m *Model) { m.duel.room = "ROOM42" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, clk := clockModel(t)
+			tt.change(&m)
+			clk.Advance(time.Hour)
+			if m, _ = tickAt(t, m, clk.Now()); m.game.idle {
+				t.Error("idle = true, want the timer to keep running")
+			}
+		})
+	}
+}
+
+func TestIdleAfter_FromConfig(t *testing.T) {
+	m := Model{cfg: &config.Config{IdleSeconds: 30}}
+	if got := m.idleAfter(); got != 30*time.Second {
+		t.Errorf("idleAfter() = %v, want 30s", got)
+	}
+}
@@ -0,0 +1,180 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// infoChromeHeight is the rows the quote info screen spends around its
+// panel: the blank and title rows, the two scroll indicators, the help bar
+// with its top padding, and the status bar.
+const infoChromeHeight = 2 + 2 + 2 + statusBarHeight
+
+// offersInfo reports whether the solved screen offers background on the
+// quote. Looking it up needs at least an author.
+func (m Model) offersInfo() bool {
+	return m.state == StateSolved && m.game.puzzle != nil && strings.TrimSpace(m.game.puzzle.Author) != ""
+}
+
+// fetchQuoteContextCmd creates a command that looks up background on the
+// current quote. Custom and pack puzzles aren't on the server, so only their
+// author is looked up.
+func (m Model) fetchQuoteContextCmd() tea.Cmd {
+	client, current := m.client, m.game.puzzle
+	gameID := current.ID
+	if m.opts.Local != nil {
+		gameID = ""
+	}
+	return func() tea.Msg {
+		quoteCtx, err := client.FetchQuoteContext(gameID, current.Author)
+		return quoteContextMsg{gameID: current.ID, context: quoteCtx, err: err}
+	}
+}
+
+// openQuoteInfo shows the quote info panel, looking the context up the first
+// time it is opened for a puzzle.
+func (m Model) openQuoteInfo() (tea.Model, tea.Cmd) {
+	m.state = StateQuoteInfo
+	m.game.infoScroll = 0
+	if m.game.quoteContext != nil {
+		return m, nil
+	}
+	m.game.infoNote = "Looking up this quote..."
+	return m, m.fetchQuoteContextCmd()
+}
+
+// handleQuoteContext fills the info panel with what the lookup found, or
+// says why there is nothing. Answers for a puzzle no longer open are dropped.
+func (m Model) handleQuoteContext(msg quoteContextMsg) (tea.Model, tea.Cmd) {
+	if m.game.puzzle == nil || msg.gameID != m.game.puzzle.ID {
+		return m, nil
+	}
+	switch {
+	case msg.err == nil:
+		m.game.quoteContext = msg.context
+		m.game.infoNote = ""
+	case errors.Is(msg.err, api.ErrNoQuoteContext):
+		m.game.infoNote = "No background found for this quote."
+	default:
+		reason, _ := describeError(msg.err)
+		m.game.infoNote = "Couldn't look up this quote: " + reason
+	}
+	return m, nil
+}
+
+// handleInfoKeyMsg scrolls the info panel. Esc and b go back to the solved
+// screen.
+func (m Model) handleInfoKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	page := m.infoHeight()
+	switch msg.String() {
+	case "esc", "b":
+		m.state = StateSolved
+		return m, nil
+	case "up", "k":
+		m.game.infoScroll--
+	case "down", "j":
+		m.game.infoScroll++
+	case "pgup":
+		m.game.infoScroll -= page
+	case "pgdown", "space":
+		m.game.infoScroll += page
+	case "home", "g":
+		m.game.infoScroll = 0
+	case "end", "G":
+		m.game.infoScroll = len(m.infoLines())
+	}
+	m.game.infoScroll = max(min(m.game.infoScroll, len(m.infoLines())-page), 0)
+	return m, nil
+}
+
+// infoHeight is how many panel lines fit on the info screen.
+func (m Model) infoHeight() int {
+	return max(m.height-lipgloss.Height(m.renderHeader())-infoChromeHeight, 3)
+}
+
+// infoLines renders the info panel's content, wrapped to the terminal. The
+// quote and everything the lookup returned is sanitized, since it comes
+// from the server or Wikipedia.
+func (m Model) infoLines() []string {
+	width := max(m.width-4, 20)
+	wrap := func(s string) []string {
+		return strings.Split(ui.WordWrapText(ui.SanitizeString(s), width), "\n")
+	}
+
+	var lines []string
+	if len(m.game.cells) > 0 && puzzle.IsComplete(m.game.cells) {
+		lines = append(lines, wrap("“"+puzzle.AssembleSolution(m.game.cells)+"”")...)
+		lines = append(lines, "")
+	}
+	author := ""
+	if m.game.puzzle != nil {
+		author = m.game.puzzle.Author
+	}
+
+	c := m.game.quoteContext
+	if c == nil {
+		lines = append(lines, wrap("— "+author)...)
+		return append(append(lines, ""), wrap(m.game.infoNote)...)
+	}
+	if c.Description != "" {
+		author += ", " + c.Description
+	}
+	lines = append(lines, wrap("— "+author)...)
+	switch {
+	case c.Source != "" && c.Year != 0:
+		lines = append(lines, wrap(fmt.Sprintf("From %s (%d)", c.Source, c.Year))...)
+	case c.Source != "":
+		lines = append(lines, wrap("From "+c.Source)...)
+	case c.Year != 0:
+		lines = append(lines, wrap(fmt.Sprintf("Said in %d", c.Year))...)
+	}
+	if c.Bio != "" {
+		lines = append(lines, "")
+		lines = append(lines, wrap(c.Bio)...)
+	}
+	if c.URL != "" {
+		lines = append(lines, "")
+		lines = append(lines, wrap("Read more: "+c.URL)...)
+	}
+	return lines
+}
+
+// viewQuoteInfo renders the quote info panel, scrolled to infoScroll, with
+// an indicator above and below when it doesn't fit.
+func (m Model) viewQuoteInfo() string {
+	title := lipgloss.NewStyle().Bold(true).Render("About this quote")
+
+	lines := m.infoLines()
+	height := m.infoHeight()
+	start := max(min(m.game.infoScroll, len(lines)-height), 0)
+	end := min(start+height, len(lines))
+
+	var above, below string
+	if start > 0 {
+		above = ui.HelpStyle.Render("▲ more above")
+	}
+	if end < len(lines) {
+		below = ui.HelpStyle.Render("▼ more below")
+	}
+
+	help := ui.HelpStyle.Render(renderHelpItems(m.helpItems()))
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		m.renderHeader(),
+		"",
+		title,
+		above,
+		strings.Join(lines[start:end], "\n"),
+		below,
+		help,
+	)
+}
@@ -0,0 +1,147 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// infoModel returns a solved puzzle whose quote context comes from an API
+// answering with body.
+func infoModel(t *testing.T, body string) (Model, *int) {
+	t.Helper()
+	lookups := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/game/game-0120/context" {
+			t.Errorf("request = %s, want the puzzle's context", r.URL)
+		}
+		lookups++
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	client, err := api.NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := rolloverModel(t)
+	m.client = client
+	m.state = StateSolved
+	m.game.puzzle.Author = "Mark Twain"
+	m.height = 24
+	puzzle.RevealSolution(m.game.cells, "IT, TI")
+	return m, &lookups
+}
+
+// pressInfo presses i on the solved screen and delivers the lookup.
+func pressInfo(t *testing.T, m Model) Model {
+	t.Helper()
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: 'i', Text: "i"})
+	m = model.(Model)
+	if m.state != StateQuoteInfo {
+		t.Fatalf("state = %v after i, want the quote info panel", m.state)
+	}
+	if cmd != nil {
+		model, _ = m.Update(cmd())
+		m = model.(Model)
+	}
+	return m
+}
+
+func TestQuoteInfo_ShowsContext(t *testing.T) {
+	m, lookups := infoModel(t, `{"source": "New York Journal", "year": 1897, "description": "American writer",
+		"bio": "Samuel Clemens\u0007 wrote \u001bbooks.", "url": "https://en.wikipedia.org/wiki/Mark_Twain"}`)
+	if !slices.Contains(m.helpItems(), helpInfo) {
+		t.Error("the solved screen should offer the quote's background")
+	}
+
+	m = pressInfo(t, m)
+	view := m.viewQuoteInfo()
+	for _, want := range []string{
+		"About this quote", "“IT, TI”", "— Mark Twain, American writer", "From New York Journal (1897)",
+		"Samuel Clemens wrote books.", "Read more: https://en.wikipedia.org/wiki/Mark_Twain",
+	} {
+		if !strings.Contains(ansi.Strip(view), want) {
+			t.Errorf("info panel is missing %q:\n%s", want, view)
+		}
+	}
+	if strings.ContainsRune(view, '\a') {
+		t.Error("control characters from the lookup should be stripped")
+	}
+
+	// Back and in again reuses the context
+	model, _ := m.handleKeyMsg(tea.KeyPressMsg{Code: 'b', Text: "b"})
+	if model.(Model).state != StateSolved {
+		t.Fatalf("b should go back to the solved screen, got %v", model.(Model).state)
+	}
+	pressInfo(t, model.(Model))
+	if *lookups != 1 {
+		t.Errorf("looked the quote up %d times, want once", *lookups)
+	}
+}
+
+func TestQuoteInfo_Scrolls(t *testing.T) {
+	m, _ := infoModel(t, `{"bio": "`+strings.Repeat("A sentence about the author. ", 80)+`"}`)
+	m = pressInfo(t, m)
+	if view := ansi.Strip(m.viewQuoteInfo()); strings.Contains(view, "more above") || !strings.Contains(view, "▼ more below") {
+		t.Fatalf("a long bio should start at the top with more below:\n%s", view)
+	}
+
+	model, _ := m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyDown})
+	if m = model.(Model); m.game.infoScroll != 1 {
+		t.Errorf("infoScroll = %d after down, want 1", m.game.infoScroll)
+	}
+	model, _ = m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyEnd})
+	m = model.(Model)
+	if want := len(m.infoLines()) - m.infoHeight(); m.game.infoScroll != want {
+		t.Errorf("infoScroll = %d after end, want the last page at %d", m.game.infoScroll, want)
+	}
+	if view := ansi.Strip(m.viewQuoteInfo()); !strings.Contains(view, "▲ more above") || strings.Contains(view, "more below") {
+		t.Errorf("the last page should have more above only:\n%s", view)
+	}
+
+	model, _ = m.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	if got := model.(Model).game.infoScroll; got != m.game.infoScroll-1 {
+		t.Errorf("wheel up scrolled to %d, want %d", got, m.game.infoScroll-1)
+	}
+}
+
+func TestQuoteInfo_LookupFailed(t *testing.T) {
+	m := rolloverModel(t)
+	m.state = StateSolved
+	m.game.puzzle.Author = "Mark Twain"
+
+	// The unreachable test API fails the lookup
+	m = pressInfo(t, m)
+	if view := m.viewQuoteInfo(); !strings.Contains(view, "Couldn't look up this quote") {
+		t.Errorf("a failed lookup should say so:\n%s", view)
+	}
+
+	model, _ := m.Update(quoteContextMsg{gameID: "game-0120", err: api.ErrNoQuoteContext})
+	if view := model.(Model).viewQuoteInfo(); !strings.Contains(view, "No background found for this quote.") {
+		t.Errorf("a lookup that found nothing should say so:\n%s", view)
+	}
+
+	// A lookup for a puzzle that is no longer open is dropped
+	model, _ = m.Update(quoteContextMsg{gameID: "game-0119", context: &api.QuoteContext{Bio: "Someone else."}})
+	if model.(Model).game.quoteContext != nil {
+		t.Error("context for another puzzle should be ignored")
+	}
+}
+
+func TestQuoteInfo_NotOfferedWithoutAuthor(t *testing.T) {
+	m := rolloverModel(t)
+	m.state = StateSolved
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: 'i', Text: "i"})
+	if model.(Model).state != StateSolved || cmd != nil || slices.Contains(m.helpItems(), helpInfo) {
+		t.Error("a quote without an author has nothing to look up")
+	}
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+func TestHandlePlayingKeyMsg_AccentedInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string // cipher text; the cursor starts on its first cell
+		accents string
+		key     tea.KeyPressMsg
+		want    rune
+	}{
+		{name: "lower case", text: "AB", key: tea.KeyPressMsg{Code: 'q', Text: "q"}, want: 'Q'},
+		{name: "code only", text: "AB", key: tea.KeyPressMsg{Code: 'q'}, want: 'Q'},
+		{name: "auto folds on a plain puzzle", text: "AB", key: tea.KeyPressMsg{Code: 'é', Text: "é"}, want: 'E'},
+		{name: "auto keeps on an accented puzzle", text: "AÉ", key: tea.KeyPressMsg{Code: 'é', Text: "é"}, want: 'É'},
+		{name: "keep", text: "AB", accents: "keep", key: tea.KeyPressMsg{Code: 'é', Text: "é"}, want: 'É'},
+		{name: "fold", text: "AÉ", accents: "fold", key: tea.KeyPressMsg{Code: 'é', Text: "é"}, want: 'E'},
+		{name: "AltGr composed", text: "AB", accents: "keep", key: tea.KeyPressMsg{Code: 'e', Mod: tea.ModCtrl | tea.ModAlt, Text: "€"}, want: 0},
+		{name: "AltGr letter", text: "AB", accents: "keep", key: tea.KeyPressMsg{Code: 'z', Mod: tea.ModCtrl | tea.ModAlt, Text: "ż"}, want: 'Ż'},
+		{name: "option composed", text: "AB", accents: "keep", key: tea.KeyPressMsg{Code: 'ø', Text: "ø"}, want: 'Ø'},
+		{name: "dead key decomposed", text: "AB", accents: "keep", key: tea.KeyPressMsg{Code: 'e', Text: "e\u0301"}, want: 'E'},
+		{name: "alt shortcut", text: "AB", key: tea.KeyPressMsg{Code: 'e', Mod: tea.ModAlt}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setCacheHome(t)
+			m := Model{
+				state: StatePlaying,
+				cfg:   &config.Config{Accents: tt.accents},
+				game:  gameModel{cells: puzzle.BuildCells(tt.text, nil)},
+			}
+			result, _ := m.handlePlayingKeyMsg(tt.key)
+			if got := result.(Model).game.cells[0].Input; got != tt.want {
+				t.Errorf("input = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
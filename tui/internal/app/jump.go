@@ -0,0 +1,189 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// jumpRows is how many words the jump menu lists at once; longer quotes page.
+const jumpRows = 8
+
+// jumpWord is one word in the jump menu: its number, how it is filled in so
+// far and the cell the cursor lands on.
+type jumpWord struct {
+	number  int
+	pattern string // inputs with blanks, e.g. "_E_ER"
+	cipher  string
+	target  int // first empty letter cell, else the first letter cell; -1 when the word has none
+}
+
+// FilterValue lets the menu's filter match words by pattern or cipher letters.
+func (w jumpWord) FilterValue() string {
+	return w.pattern + " " + w.cipher
+}
+
+// title labels the word as "word 4: _E_ER".
+func (w jumpWord) title() string {
+	return fmt.Sprintf("word %d: %s", w.number, w.pattern)
+}
+
+// jumpWords lists the puzzle's words for the jump menu, numbered like
+// accessible mode reads them out.
+func (m Model) jumpWords() []list.Item {
+	words := m.accessibleWords()
+	items := make([]list.Item, 0, len(words))
+	for i, word := range words {
+		var pattern, cipher strings.Builder
+		first, empty := -1, -1
+		for _, cell := range word.cells {
+			if cell.Kind == puzzle.CellPunctuation {
+				pattern.WriteRune(cell.Char)
+				continue
+			}
+			pattern.WriteString(inputContent(cell))
+			cipher.WriteRune(cell.Char)
+			if cell.Kind != puzzle.CellLetter {
+				continue
+			}
+			if first < 0 {
+				first = cell.Index
+			}
+			if empty < 0 && cell.Input == 0 {
+				empty = cell.Index
+			}
+		}
+		target := first
+		if empty >= 0 {
+			target = empty
+		}
+		items = append(items, jumpWord{number: i + 1, pattern: pattern.String(), cipher: cipher.String(), target: target})
+	}
+	return items
+}
+
+// jumpDelegate draws the jump menu's words one per line, the selected one
+// marked and in the primary color.
+type jumpDelegate struct{}
+
+func (jumpDelegate) Height() int                         { return 1 }
+func (jumpDelegate) Spacing() int                        { return 0 }
+func (jumpDelegate) Update(tea.Msg, *list.Model) tea.Cmd { return nil }
+
+func (jumpDelegate) Render(w io.Writer, l list.Model, index int, item list.Item) {
+	word, ok := item.(jumpWord)
+	if !ok {
+		return
+	}
+	if index == l.Index() {
+		fmt.Fprint(w, lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Render("> "+word.title()))
+		return
+	}
+	fmt.Fprint(w, "  "+word.title())
+}
+
+// jumpOpen reports whether the jump menu is showing.
+func (m Model) jumpOpen() bool {
+	return m.game.jump != nil && m.state == StatePlaying
+}
+
+// gridScrolls reports whether the grid is too long to show at once.
+func (m Model) gridScrolls() bool {
+	return m.gridView.TotalLineCount() > m.gridView.Height()
+}
+
+// openJump opens the jump menu on the word under the cursor.
+func (m Model) openJump() (tea.Model, tea.Cmd) {
+	items := m.jumpWords()
+	if len(items) == 0 {
+		return m, nil
+	}
+
+	height := len(items) + 1
+	if len(items) > jumpRows {
+		// Room for the page dots and the line above them
+		height = jumpRows + 3
+	}
+	menu := list.New(items, jumpDelegate{}, max(m.width, MinTerminalWidth), height)
+	menu.Title = "Jump to a word"
+	menu.Styles.Title = lipgloss.NewStyle().Bold(true)
+	menu.Styles.TitleBar = lipgloss.NewStyle()
+	menu.Styles.PaginationStyle = lipgloss.NewStyle()
+	menu.SetShowStatusBar(false)
+	menu.SetShowHelp(false)
+	menu.DisableQuitKeybindings()
+
+	for i, word := range m.accessibleWords() {
+		for _, cell := range word.cells {
+			if cell.Index == m.game.cursorPos {
+				menu.Select(i)
+			}
+		}
+	}
+	m.game.jump = &menu
+	return m, nil
+}
+
+// handleJumpKeyMsg drives the open jump menu, which takes every key: Enter
+// moves the cursor to the selected word, Esc or Ctrl+J closes the menu, and
+// the rest go to the list, where / filters it and the arrows move. While a
+// filter is typed, Enter and Esc finish or drop it instead, and Esc clears
+// one already applied.
+func (m Model) handleJumpKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	state := m.game.jump.FilterState()
+	if state != list.Filtering {
+		switch msg.String() {
+		case "esc":
+			if state == list.Unfiltered {
+				m.game.jump = nil
+				return m, nil
+			}
+		case "ctrl+j":
+			m.game.jump = nil
+			return m, nil
+		case "enter":
+			word, ok := m.game.jump.SelectedItem().(jumpWord)
+			m.game.jump = nil
+			if ok && word.target >= 0 {
+				m.game.cursorPos = word.target
+			}
+			return m, nil
+		}
+	}
+	return m.updateJump(msg)
+}
+
+// updateJump passes msg to the jump menu, for its keys and the filter
+// results it computes in the background.
+func (m Model) updateJump(msg tea.Msg) (tea.Model, tea.Cmd) {
+	menu, cmd := m.game.jump.Update(msg)
+	m.game.jump = &menu
+	return m, cmd
+}
+
+// renderJump renders the open jump menu.
+func (m Model) renderJump() string {
+	if !m.jumpOpen() {
+		return ""
+	}
+	return m.game.jump.View()
+}
+
+// accessibleJump is renderJump as plain text.
+func (m Model) accessibleJump() string {
+	if !m.jumpOpen() {
+		return ""
+	}
+	word, ok := m.game.jump.SelectedItem().(jumpWord)
+	if !ok {
+		return "Jumping to a word. No word matches the filter."
+	}
+	return fmt.Sprintf("Jumping to a word. Selected: %s. Up and down move, slash filters, Enter jumps.", word.title())
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// jumpModel is a playing model on a five-word quote with the second word
+// partly filled in.
+func jumpModel() Model {
+	m := revealModel(nil, 0)
+	m.game.puzzle = &api.Puzzle{ID: "game-001", EncryptedText: "AB CDEDF GH IJ KL"}
+	m.game.cells = puzzle.BuildCells(m.game.puzzle.EncryptedText, nil)
+	puzzle.SetInput(m.game.cells, 4, 'E')
+	puzzle.SetInput(m.game.cells, 7, 'R')
+	m.game.cursorPos = 0
+	return m
+}
+
+// runFilter runs the commands typing into the jump menu's filter returned
+// and hands the filter's matches back to the model.
+func runFilter(t *testing.T, m Model, cmd tea.Cmd) Model {
+	t.Helper()
+	if cmd == nil {
+		return m
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			m = runFilter(t, m, c)
+		}
+	case list.FilterMatchesMsg:
+		model, _ := m.Update(msg)
+		m = model.(Model)
+	}
+	return m
+}
+
+func TestJump_MovesCursorToWord(t *testing.T) {
+	m := jumpModel()
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: 'j', Mod: tea.ModCtrl})
+	if !m.jumpOpen() {
+		t.Fatal("Ctrl+J should open the jump menu")
+	}
+	menu := ansi.Strip(m.renderJump())
+	for _, want := range []string{"Jump to a word", "> word 1: __", "word 2: _E_ER"} {
+		if !strings.Contains(menu, want) {
+			t.Errorf("menu missing %q:\n%s", want, menu)
+		}
+	}
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyDown})
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.jumpOpen() {
+		t.Error("Enter should close the jump menu")
+	}
+	if m.game.cursorPos != 3 {
+		t.Errorf("cursorPos = %d, want 3, the first empty letter of word 2", m.game.cursorPos)
+	}
+}
+
+func TestJump_Filters(t *testing.T) {
+	m := jumpModel()
+	m, _ = press(t, m, tea.KeyPressMsg{Code: 'j', Mod: tea.ModCtrl})
+	// A blinking cursor would have runFilter wait out each blink
+	styles := m.game.jump.FilterInput.Styles()
+	styles.Cursor.Blink = false
+	m.game.jump.FilterInput.SetStyles(styles)
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: '/', Text: "/"})
+	for _, r := range "IJ" {
+		var cmd tea.Cmd
+		m, cmd = press(t, m, tea.KeyPressMsg{Code: r, Text: string(r)})
+		m = runFilter(t, m, cmd)
+	}
+	if menu := ansi.Strip(m.renderJump()); strings.Contains(menu, "word 1:") || !strings.Contains(menu, "word 4: __") {
+		t.Errorf("filtering on IJ should leave word 4 alone:\n%s", menu)
+	}
+
+	// Enter accepts the filter, a second one jumps
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !m.jumpOpen() {
+		t.Fatal("Enter while filtering should only accept the filter")
+	}
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.game.cursorPos != 12 {
+		t.Errorf("cursorPos = %d, want 12, the start of word 4", m.game.cursorPos)
+	}
+}
+
+func TestJump_EscCloses(t *testing.T) {
+	m := jumpModel()
+	m, _ = press(t, m, tea.KeyPressMsg{Code: 'j', Mod: tea.ModCtrl})
+	m, cmd := press(t, m, tea.KeyPressMsg{Code: tea.KeyEsc})
+	if m.jumpOpen() || cmd != nil {
+		t.Errorf("Esc should close the menu without quitting; open %v, cmd %v", m.jumpOpen(), cmd)
+	}
+	if m.game.cursorPos != 0 {
+		t.Errorf("cursorPos = %d, want it left at 0", m.game.cursorPos)
+	}
+}
+
+func TestJump_Accessible(t *testing.T) {
+	m := jumpModel()
+	m.accessible = true
+	m, _ = press(t, m, tea.KeyPressMsg{Code: 'j', Mod: tea.ModCtrl})
+	if screen := m.viewPlayingAccessible(); !strings.Contains(screen, "Jumping to a word. Selected: word 1: __.") {
+		t.Errorf("accessible view should name the selected word:\n%s", screen)
+	}
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// localModel creates a playing Model for a custom puzzle, loaded the same way
+// as at startup.
+func localModel(t *testing.T, quote string, hints int) Model {
+	t.Helper()
+
+	local, err := puzzlegen.Generate(quote, "Tester", hints)
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	m := Model{state: StateLoading, opts: Options{Local: local}, claimCode: "TEST-CODE-1234", width: 80, height: 40}
+	msg, ok := m.fetchCmd()().(puzzleFetchedMsg)
+	if !ok {
+		t.Fatal("fetchCmd() for a custom puzzle should load it without the API")
+	}
+	model, _ := m.handlePuzzleFetched(msg)
+	return model.(Model)
+}
+
+func TestLocalPuzzle_Loads(t *testing.T) {
+	m := localModel(t, "Hello world", 2)
+
+	if m.game.puzzle.ID != m.opts.Local.ID || m.game.puzzle.EncryptedText != m.opts.Local.EncryptedText {
+		t.Errorf("loaded puzzle %+v does not match the generated one", m.game.puzzle)
+	}
+	if len(m.game.puzzle.Hints) != 2 {
+		t.Errorf("got %d hints, want 2", len(m.game.puzzle.Hints))
+	}
+	if m.sessions() != storage.Custom {
+		t.Errorf("sessions() = %q, want the custom namespace", m.sessions())
+	}
+	if m.recordsStats() {
+		t.Error("custom puzzles must never be recorded")
+	}
+}
+
+func TestLocalPuzzle_ChecksAgainstLocalAnswer(t *testing.T) {
+	m := localModel(t, "Hi there", 0)
+
+	// Fill the grid with the answer from the generated puzzle
+	puzzle.RevealSolution(m.game.cells, m.opts.Local.Solution)
+	_, cmd := m.handleSubmit()
+	if msg, ok := cmd().(solutionCheckedMsg); !ok || !msg.correct {
+		t.Errorf("submitting the right answer returned %#v, want a correct check", cmd())
+	}
+
+	// A wrong answer is rejected
+	for i := range m.game.cells {
+		if m.game.cells[i].Kind == puzzle.CellLetter {
+			m.game.cells[i].Input = 'Q'
+		}
+	}
+	_, cmd = m.handleSubmit()
+	if msg, ok := cmd().(solutionCheckedMsg); !ok || msg.correct {
+		t.Errorf("submitting a wrong answer returned %#v, want an incorrect check", cmd())
+	}
+}
+
+func TestLocalPuzzle_RevealUsesLocalAnswer(t *testing.T) {
+	m := localModel(t, "Hi there", 0)
+	m.game.failedChecks = defaultRevealAfter
+	m.game.startTime = time.Now()
+
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: 'v', Mod: tea.ModCtrl})
+	if model.(Model).state != StateChecking || cmd == nil {
+		t.Fatal("Ctrl+V should start revealing the custom puzzle")
+	}
+	msg, ok := cmd().(solutionRevealedMsg)
+	if !ok || msg.solution != "HI THERE" {
+		t.Errorf("reveal returned %#v, want the local answer", cmd())
+	}
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// toggleLock locks the letter under the cursor, or unlocks it. A locked
+// letter is kept like a hint: typing over it, clearing it and Ctrl+C leave
+// it alone until it is unlocked.
+func (m Model) toggleLock() (tea.Model, tea.Cmd) {
+	pos := m.game.cursorPos
+	if pos < 0 || pos >= len(m.game.cells) || m.game.cells[pos].Kind != puzzle.CellLetter {
+		return m, nil
+	}
+	cell := m.game.cells[pos]
+	if !puzzle.SetLocked(m.game.cells, pos, !cell.Locked) {
+		return m.notifyAbout(topicLock, toastWarning, "Type a letter before locking it")
+	}
+
+	text := fmt.Sprintf("Locked %c as %c; Ctrl+L unlocks it", cell.Char, cell.Input)
+	if cell.Locked {
+		text = fmt.Sprintf("Unlocked %c", cell.Char)
+	}
+	m, cmd := m.notifyAbout(topicLock, toastInfo, text)
+	return m, tea.Batch(cmd, saveSessionCmd(m.sessions(), m.clock().Now(), m.game.puzzle, m.game.cells, m.Elapsed(), m.run, m.game.letters, m.game.assists))
+}
+
+// lockedAt reports whether the letter cell at index is locked.
+func (m Model) lockedAt(index int) bool {
+	return index >= 0 && index < len(m.game.cells) && m.game.cells[index].Locked
+}
+
+// rejectLocked explains why the locked letter at index didn't change.
+func (m Model) rejectLocked(index int) (Model, tea.Cmd) {
+	cell := m.game.cells[index]
+	return m.notifyAbout(topicLock, toastWarning, fmt.Sprintf("%c is locked as %c; Ctrl+L unlocks it", cell.Char, cell.Input))
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+var ctrlL = tea.KeyPressMsg{Code: 'l', Mod: tea.ModCtrl}
+
+// lastToast returns the text of the newest toast, or "" when there is none.
+func lastToast(m Model) string {
+	if len(m.toasts) == 0 {
+		return ""
+	}
+	return m.toasts[len(m.toasts)-1].text
+}
+
+func TestLock_RejectsOverwritesUntilUnlocked(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.game.cursorPos = 0
+
+	m, _ = press(t, m, ctrlL)
+	if m.game.cells[0].Locked || !strings.Contains(lastToast(m), "Type a letter") {
+		t.Fatalf("Ctrl+L on an empty letter: locked %v, toast %q; want a warning instead", m.game.cells[0].Locked, lastToast(m))
+	}
+
+	m = typeLetter(t, m, 't')
+	m.game.cursorPos = 0
+	m, cmd := press(t, m, ctrlL)
+	if !m.game.cells[0].Locked || !m.game.cells[5].Locked {
+		t.Fatal("Ctrl+L should lock every A")
+	}
+	if cmd == nil {
+		t.Error("locking should save the session")
+	}
+
+	// Typing over it, backspace and right-click are refused with a reason
+	m = typeLetter(t, m, 'x')
+	if m.game.cells[0].Input != 'T' || m.game.cursorPos != 0 {
+		t.Errorf("after typing x: input %c, cursor %d; want T kept and the cursor left on it", m.game.cells[0].Input, m.game.cursorPos)
+	}
+	if !strings.Contains(lastToast(m), "A is locked as T") {
+		t.Errorf("toast = %q, want one saying A is locked", lastToast(m))
+	}
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyBackspace})
+	if m.game.cells[0].Input != 'T' {
+		t.Error("backspace should not clear a locked letter")
+	}
+
+	// Ctrl+C clears everything else
+	m.game.cursorPos = 1
+	m = typeLetter(t, m, 'o')
+	m, _ = press(t, m, tea.KeyPressMsg{Code: 'c', Mod: tea.ModCtrl})
+	if m.game.cells[0].Input != 'T' || m.game.cells[1].Input != 0 {
+		t.Errorf("after Ctrl+C: %q, want only the locked Ts left", puzzle.AssembleSolution(m.game.cells))
+	}
+
+	m.game.cursorPos = 0
+	m, _ = press(t, m, ctrlL)
+	if m.game.cells[0].Locked || lastToast(m) != "Unlocked A" {
+		t.Fatalf("second Ctrl+L: locked %v, toast %q; want unlocked", m.game.cells[0].Locked, lastToast(m))
+	}
+	m = typeLetter(t, m, 'x')
+	if m.game.cells[0].Input != 'X' {
+		t.Error("an unlocked letter should take input again")
+	}
+}
+
+func TestLock_RenderedBold(t *testing.T) {
+	m := revealModel(nil, 0)
+	puzzle.SetInput(m.game.cells, 0, 'T')
+	puzzle.SetLocked(m.game.cells, 0, true)
+	m.game.cursorPos = 1
+
+	key := m.cellKey(m.game.cells[0], 0, nil, nil)
+	if !key.locked {
+		t.Fatal("cellKey should carry the lock, so the cache tells locked cells apart")
+	}
+	if got, want := key.renderInput(), key.look.style().Bold(true).Render("T"); got != want {
+		t.Errorf("renderInput() = %q, want the letter in bold %q", got, want)
+	}
+	m.accessible = true
+	m.game.cursorPos = 0
+	if got := m.describeCursor(); !strings.HasSuffix(got, ", locked") {
+		t.Errorf("describeCursor() = %q, want it to say the letter is locked", got)
+	}
+}
+
+func TestLock_PersistedInSession(t *testing.T) {
+	m := revealModel(nil, 0)
+	puzzle.SetInput(m.game.cells, 0, 'T')
+	puzzle.SetInput(m.game.cells, 1, 'O')
+	puzzle.SetLocked(m.game.cells, 0, true)
+
+	session := newSession(m.game.puzzle, m.game.cells, 0, m.run, m.game.letters, storage.Assists{})
+	if !slices.Equal(session.Locked, []string{"A"}) {
+		t.Fatalf("session.Locked = %v, want [A]", session.Locked)
+	}
+
+	restored := revealModel(nil, 0)
+	restored.state = StateLoading
+	model, _ := restored.handleSessionLoaded(sessionLoadedMsg{session: session})
+	cells := model.(Model).game.cells
+	if !cells[0].Locked || !cells[5].Locked || cells[1].Locked {
+		t.Errorf("restored locks = %v, %v, %v; want every A locked and B not", cells[0].Locked, cells[1].Locked, cells[5].Locked)
+	}
+}
@@ -0,0 +1,20 @@
+package app
+
+import "time"
+
+// lowBandwidthTick is how often the timer redraws with Config.LowBandwidth.
+// Every frame costs bytes on a slow SSH link; the timer catching up every
+// few seconds is the cheaper trade.
+const lowBandwidthTick = 5 * time.Second
+
+// tickInterval returns how often the tick loop runs: once a second, or every
+// lowBandwidthTick with Config.LowBandwidth. Along with the slower timer, low
+// bandwidth turns on the compact grid, which draws half the rows, and drops
+// the background tints on conflicting and related cells, whose repaints
+// follow the cursor across the grid; shape cues mark those cells instead.
+func (m Model) tickInterval() time.Duration {
+	if m.lowBandwidth {
+		return lowBandwidthTick
+	}
+	return time.Second
+}
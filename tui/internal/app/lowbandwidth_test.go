@@ -0,0 +1,44 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+func TestHandleConfigLoaded_LowBandwidth(t *testing.T) {
+	model, _ := NewWithClient(nil).handleConfigLoaded(configLoadedMsg{config: &config.Config{LowBandwidth: true}})
+	m := model.(Model)
+	if !m.lowBandwidth || !m.compactGrid || !m.shapeCues {
+		t.Errorf("lowBandwidth %v, compactGrid %v, shapeCues %v; want all on", m.lowBandwidth, m.compactGrid, m.shapeCues)
+	}
+	if got := m.tickInterval(); got != lowBandwidthTick {
+		t.Errorf("tickInterval() = %v, want %v", got, lowBandwidthTick)
+	}
+
+	model, _ = NewWithClient(nil).handleConfigLoaded(configLoadedMsg{config: &config.Config{}})
+	if got := model.(Model).tickInterval(); got != time.Second {
+		t.Errorf("tickInterval() = %v by default, want a second", got)
+	}
+}
+
+func TestRenderInputCell_LowBandwidthDropsTints(t *testing.T) {
+	m := Model{game: gameModel{cursorPos: -1}, lowBandwidth: true, shapeCues: true}
+	duplicates := map[rune][]rune{'E': {'Q', 'X'}}
+
+	conflict := puzzle.Cell{Index: 1, Char: 'X', Input: 'E', Kind: puzzle.CellLetter}
+	if got, want := m.renderInputCell(conflict, 0, duplicates), ui.CellStyle.Render("E!"); got != want {
+		t.Errorf("conflict = %q, want %q: marked, not tinted", got, want)
+	}
+	related := puzzle.Cell{Index: 2, Char: 'Q', Input: 'A', Kind: puzzle.CellLetter}
+	if got, want := m.renderInputCell(related, 'Q', duplicates), ui.CellStyle.Underline(true).Render("A"); got != want {
+		t.Errorf("related = %q, want %q: underlined, not tinted", got, want)
+	}
+	hint := puzzle.Cell{Index: 3, Char: 'R', Input: 'B', Kind: puzzle.CellHint}
+	if got, want := m.renderInputCell(hint, 0, duplicates), ui.HintCellStyle.Render("B"); got != want {
+		t.Errorf("hint = %q, want %q", got, want)
+	}
+}
@@ -5,12 +5,19 @@ import (
 
 	"github.com/bojanrajkovic/unquote/tui/internal/api"
 	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/goal"
 	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
 )
 
-// puzzleFetchedMsg is sent when puzzle data has been loaded from the API
+// puzzleFetchedMsg is sent when puzzle data has been loaded from the API,
+// the offline cache, or a custom puzzle
 type puzzleFetchedMsg struct {
-	puzzle *api.Puzzle
+	puzzle  *api.Puzzle
+	trace   *puzzleTrace // started by traceFetch; nil for puzzles not from the API
+	answer  string       // solution known without the API; "" means submissions are checked online
+	offline bool         // loaded from the offline cache because the API was unreachable
+	fetch   int          // puzzle fetch generation; see Model.startFetch
 }
 
 // solutionCheckedMsg is sent when the solution check returns from the API
@@ -18,22 +25,37 @@ type solutionCheckedMsg struct {
 	correct bool
 }
 
-// errMsg is sent when an API error occurs
+// solutionRevealedMsg is sent when the solution the player gave up on has
+// been fetched from the API
+type solutionRevealedMsg struct {
+	solution string
+}
+
+// errMsg is sent when loading something to play failed: the puzzle, the
+// config, registration or a pack. Failures during play have their own
+// messages; see errors.go.
 type errMsg struct {
-	err error
+	err   error
+	fetch int // puzzle fetch generation of a failed fetch; 0 for other failures
 }
 
 // tickMsg is sent every second while the timer is running
 type tickMsg time.Time
 
+// retryTickMsg is sent every second while counting down to an automatic retry
+type retryTickMsg time.Time
+
 // sessionLoadedMsg is sent when a session has been loaded from storage
 type sessionLoadedMsg struct {
 	session *storage.GameSession
+	fetch   int // puzzle fetch generation the session was looked up for
 }
 
 // configLoadedMsg is sent when the config has been loaded from disk
 type configLoadedMsg struct {
-	config *config.Config // nil if no config file exists
+	config   *config.Config  // nil if no config file exists
+	theme    *ui.CustomTheme // Config.Theme's colors; nil without a theme or when it can't be used
+	themeErr error           // why Config.Theme can't be used
 }
 
 // playerRegisteredMsg is sent when a player has been registered via the API
@@ -46,27 +68,175 @@ type configSavedMsg struct{}
 
 // sessionRecordedMsg is sent when a session has been successfully uploaded to the server
 type sessionRecordedMsg struct {
+	percentile *float64 // nil if the server didn't report one
+	gameID     string
+}
+
+// difficultyRatedMsg is sent when the player's difficulty rating was sent,
+// or queued to send later
+type difficultyRatedMsg struct {
+	err    error // set when the rating could be neither sent nor queued
 	gameID string
+	rating int
+	queued bool // the server couldn't be reached; the rating waits for reconciliation
 }
 
 // reconciliationDoneMsg is sent when session reconciliation has completed
-type reconciliationDoneMsg struct{}
+type reconciliationDoneMsg struct {
+	err     error // the first upload that failed; nil when all went up
+	pending int   // solved sessions that still failed to upload
+}
+
+// capabilitiesMsg is sent with the API's optional features
+type capabilitiesMsg struct {
+	caps *api.Capabilities
+}
+
+// healthCheckedMsg is sent when an API health check completes
+type healthCheckedMsg struct {
+	online bool
+}
+
+// pendingSyncMsg is sent with the number of solved sessions not yet uploaded
+type pendingSyncMsg struct {
+	count int
+}
+
+// updateAvailableMsg is sent when a release newer than this build is published
+type updateAvailableMsg struct {
+	version string
+}
 
 // remoteSessionMsg is sent when a remote session check completes.
 // session is nil if no remote session exists or the check failed.
 type remoteSessionMsg struct {
 	session *api.SessionLookupResponse
+	fetch   int // puzzle fetch generation the session was looked up for
 }
 
 // statsFetchedMsg is sent when player stats have been loaded from the API
 type statsFetchedMsg struct {
 	stats *api.PlayerStatsResponse
+	fetch int // stats screen fetch generation; see statsModel.open
+}
+
+// checkTimedOutMsg is sent when checking the answer timed out. It carries
+// the solution that was sent, to send again; see handleCheckTimedOut.
+type checkTimedOutMsg struct {
+	err      error
+	gameID   string
+	solution string
+}
+
+// playErrMsg is sent when checking or revealing the answer failed. Play
+// goes on; see handlePlayError.
+type playErrMsg struct {
+	err    error
+	action string // what failed, completing "Couldn't ...": "check your answer"
+}
+
+// statsFailedMsg is sent when the player's stats couldn't be fetched. The
+// game goes on; see handleStatsFailed.
+type statsFailedMsg struct {
+	err    error
+	fetch  int  // stats screen fetch generation; 0 in the background
+	screen bool // fetched for the stats screen, rather than in the background after a solve
+}
+
+// sessionRecordFailedMsg is sent when a solve couldn't be uploaded. It stays
+// saved locally for reconciliation.
+type sessionRecordFailedMsg struct {
+	err    error
+	gameID string
+}
+
+// friendStatsMsg is sent when friends' stats have been loaded for the stats
+// screen's Friends tab
+type friendStatsMsg struct {
+	friends []friendStats
+}
+
+// categoryStatsMsg is sent when the player's sessions have been summarized
+// by category for the stats screen's Categories tab
+type categoryStatsMsg struct {
+	categories []categoryStats
+}
+
+// goalLoadedMsg is sent when this week's goal progress has been computed
+type goalLoadedMsg struct {
+	progress goal.Progress
+}
+
+// solveStatsFetchedMsg is sent when stats fetched in the background after a
+// solve arrive. Unlike statsFetchedMsg it does not switch to the stats screen.
+type solveStatsFetchedMsg struct {
+	stats *api.PlayerStatsResponse
 }
 
 // shareSessionResultMsg is sent when async share operations complete
 type shareSessionResultMsg struct {
 	feedback string
+	ok       bool // copied in some form; false when sharing isn't available
 }
 
-// clearShareFeedbackMsg is sent after a share feedback timeout expires
-type clearShareFeedbackMsg struct{}
+// noteSavedMsg is sent when the player's note on a solve has been stored, or
+// couldn't be
+type noteSavedMsg struct {
+	err    error
+	gameID string
+	note   string
+}
+
+// archiveLoadedMsg is sent when a pack's puzzles and the player's progress on
+// them are ready for the archive screen
+type archiveLoadedMsg struct {
+	entries []archiveEntry
+}
+
+// duelPolledMsg is sent after reporting progress to a duel room
+type duelPolledMsg struct {
+	room *api.DuelRoom
+	err  error
+}
+
+// streamEventMsg is sent for each event on a server-sent event subscription
+type streamEventMsg struct {
+	stream *api.Stream // subscription the event arrived on
+	event  api.Event
+}
+
+// nextChoicesMsg is sent when the options for the next-puzzle menu are ready
+type nextChoicesMsg struct {
+	choices []nextChoice
+}
+
+// globalStatsMsg is sent when how every player did on a day's puzzle arrives
+type globalStatsMsg struct {
+	stats *api.GlobalStats
+	date  string // puzzle day the stats are for
+}
+
+// quoteContextMsg is sent when a lookup of background on a puzzle's quote
+// completes
+type quoteContextMsg struct {
+	err     error
+	context *api.QuoteContext
+	gameID  string // puzzle the lookup was for
+}
+
+// favoriteMsg is sent when a check or toggle of whether a puzzle's quote is
+// a favorite completes
+type favoriteMsg struct {
+	err      error
+	gameID   string // puzzle the check or toggle was for
+	favorite bool   // whether the quote is a favorite now
+	toggled  bool   // the player pressed *; false when only checking
+}
+
+// authorPuzzlesMsg is sent when a search for more puzzles by the current
+// puzzle's author completes
+type authorPuzzlesMsg struct {
+	err     error
+	author  string
+	choices []nextChoice
+}
@@ -4,11 +4,16 @@ import (
 	"fmt"
 	"time"
 
-	"charm.land/huh/v2"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
 
 	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/clock"
 	"github.com/bojanrajkovic/unquote/tui/internal/config"
-	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/pack"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
 )
 
 // Minimum terminal dimensions
@@ -29,37 +34,104 @@ const (
 	StateOnboarding
 	StateClaimCodeDisplay
 	StateStats
+	StateArchive
+	StateNextPuzzle
+	StateDuelWaiting
+	StateRecovery
+	StateQuoteInfo
 )
 
+// stateNames names each State for debug logs, in declaration order.
+var stateNames = [...]string{
+	StateLoading:          "Loading",
+	StatePlaying:          "Playing",
+	StateChecking:         "Checking",
+	StateSolved:           "Solved",
+	StateError:            "Error",
+	StateOnboarding:       "Onboarding",
+	StateClaimCodeDisplay: "ClaimCodeDisplay",
+	StateStats:            "Stats",
+	StateArchive:          "Archive",
+	StateNextPuzzle:       "NextPuzzle",
+	StateDuelWaiting:      "DuelWaiting",
+	StateRecovery:         "Recovery",
+	StateQuoteInfo:        "QuoteInfo",
+}
+
+func (s State) String() string {
+	if s < 0 || int(s) >= len(stateNames) {
+		return fmt.Sprintf("State(%d)", int(s))
+	}
+	return stateNames[s]
+}
+
 // Options configures the application behavior.
 type Options struct {
-	Insecure bool
-	Random   bool
+	Local      *puzzlegen.Puzzle // custom puzzle played and checked offline; nil plays from the API
+	Pack       *pack.Pack        // puzzle pack browsed on the archive screen; each pick is played as Local
+	Recovery   *storage.Recovery // game saved when unquote last crashed, offered back before loading; nil without one
+	Clock      clock.Clock       // time source for the timer, its ticks and session timestamps; nil uses the system clock
+	Target     time.Duration     // speed-run target time; 0 plays without a countdown
+	Date       string            // daily puzzle to play (YYYY-MM-DD); empty plays today's
+	GameID     string            // puzzle to play by game ID; takes precedence over Date
+	Duel       string            // duel room code to race a friend on today's puzzle; empty plays solo
+	Category   string            // random puzzles only from this category (case-insensitive); empty allows any
+	Insecure   bool
+	Random     bool
+	Accessible bool // screen-reader friendly linear rendering
+	Practice   bool // random archived puzzles kept out of history and stats
+	SafeMode   bool // start every puzzle fresh, without restoring saved sessions
+	Ephemeral  bool // write nothing to disk: no onboarding, preferences or prefetching; cmd keeps sessions in memory and turns the offline cache off
+	Tutorial   bool // play only the tutorial puzzle, then quit
 }
 
-// Model holds the application state
+// Model holds the application state. It routes messages and keys to the
+// screen components it is composed of, which own their screen's state, and
+// keeps what every screen shares: the API client, config, terminal size,
+// status bar and preferences.
 type Model struct {
 	client          *api.Client
 	cfg             *config.Config
-	puzzle          *api.Puzzle
-	stats           *api.PlayerStatsResponse
-	form            *huh.Form
-	optIn           *bool
-	startTime       time.Time
+	capabilities    *api.Capabilities // the API's optional features; nil until it says, when all are assumed
+	theme           *ui.CustomTheme   // colors from Config.Theme; nil for the built-in palette
+	game            gameModel         // the puzzle being played; see game.go
+	onboarding      onboardingModel   // first-run stats question; see onboarding.go
+	stats           statsModel        // stats screen; see stats.go
+	archive         archiveModel      // pack archive screen; see archive.go
+	retryAt         time.Time         // when a rate-limited request is retried automatically; zero otherwise
+	suspendedAt     time.Time         // when Ctrl+Z stopped the process; zero while running
+	lastInput       time.Time         // the player's last key press or mouse event, for idle detection
+	gridView        viewport.Model    // scrolls the puzzle grid when it is taller than the terminal
+	gridCache       *gridCache        // rendered cells and lines from earlier frames; nil renders uncached
+	frame           *frameCache       // the last playing screen without its timer; nil renders every frame in full
+	run             speedRun          // speed-run target and per-word splits
+	duel            duelState         // head-to-head race; zero when playing solo
+	errs            errorState        // the latest failure of each concern; see errors.go
+	tutorial        tutorial          // scripted walk through the tutorial puzzle; zero when not running
 	claimCode       string
-	errorMsg        string
-	statusMsg       string
 	loadingMsg      string
-	shareFeedback   string // "Copied!" or "Printed to stdout"
-	cells           []puzzle.Cell
-	elapsedAtPause  time.Duration
+	latestVersion   string       // newer release available, shown in the status bar
+	toasts          []toast      // transient notices for the status bar, oldest first; see toast.go
+	nextChoices     []nextChoice // options on the next-puzzle menu
+	nextTitle       string       // next-puzzle menu heading; empty for "Play another puzzle"
+	nextNote        string       // shown on the next-puzzle menu in place of an empty list
 	state           State
-	cursorPos       int
+	connection      connectivity // API reachability, shown in the status bar
+	nextCursor      int          // selected row on the next-puzzle menu
+	pendingSync     int          // solved sessions waiting to be uploaded
+	puzzleFetch     int          // generation of the latest puzzle fetch; see startFetch
 	width           int
 	height          int
 	opts            Options
 	sizeReady       bool
-	solvedElsewhere bool
+	compactGrid     bool // render input and cipher on one row ("A→X") to fit short terminals
+	accessible      bool // linear, screen-reader friendly rendering (flag or config)
+	shapeCues       bool // mark conflicts with "!" and related cells with underline, not just color
+	alphabetPanel   bool // show the cipher alphabet and its mapping with the grid; see alphabet.go
+	alphabetBeside  bool // the alphabet panel fits beside the grid; set by syncGridView
+	lowBandwidth    bool // redraw less for slow links; see lowbandwidth.go
+	lightBackground bool // the terminal reported a light background; dark is assumed until it answers
+	ticking         bool // a tick loop is running; see startTick
 }
 
 // New creates a new Model with initial state
@@ -69,18 +141,38 @@ func New(opts Options) (Model, error) {
 		return Model{}, fmt.Errorf("creating API client: %w", err)
 	}
 	return Model{
-		state:  StateLoading,
-		client: client,
-		opts:   opts,
+		state:      StateLoading,
+		client:     client,
+		opts:       opts,
+		accessible: opts.Accessible,
+		run:        speedRun{target: opts.Target},
+		duel:       newDuelState(opts.Duel),
+		gridCache:  newGridCache(),
+		frame:      &frameCache{},
 	}, nil
 }
 
 // NewWithClient creates a new Model with a custom API client (for testing)
 func NewWithClient(client *api.Client) Model {
 	return Model{
-		state:  StateLoading,
-		client: client,
+		state:     StateLoading,
+		client:    client,
+		gridCache: newGridCache(),
+		frame:     &frameCache{},
+	}
+}
+
+// clock returns the run's time source: Options.Clock, or the system clock.
+func (m Model) clock() clock.Clock {
+	if m.opts.Clock != nil {
+		return m.opts.Clock
 	}
+	return clock.System
+}
+
+// State returns the screen the model is on.
+func (m Model) State() State {
+	return m.state
 }
 
 // IsTooSmall returns true if the terminal is too small for the UI
@@ -89,10 +181,158 @@ func (m Model) IsTooSmall() bool {
 }
 
 // Elapsed returns the total elapsed time for the current puzzle.
-// While playing, it calculates from startTime; when paused/solved, returns accumulated time.
+// While playing, it calculates from startTime; when paused/idle/solved, returns accumulated time.
 func (m Model) Elapsed() time.Duration {
-	if m.state == StatePlaying {
-		return m.elapsedAtPause + time.Since(m.startTime)
+	if m.state == StatePlaying && !m.game.idle {
+		now := m.clock().Now()
+		if !m.suspendedAt.IsZero() {
+			now = m.suspendedAt // frozen until the process is resumed
+		}
+		return m.game.elapsedAtPause + now.Sub(m.game.startTime)
+	}
+	return m.game.elapsedAtPause
+}
+
+// PendingSession returns the game in progress as it would be saved, and the
+// namespace it is saved in, so it can be flushed if the program crashes
+// before its last save ran. Returns nil when no unfinished game is on screen.
+func (m Model) PendingSession() (storage.Namespace, *storage.GameSession) {
+	if m.game.puzzle == nil || len(m.game.cells) == 0 || m.game.revealed || (m.state != StatePlaying && m.state != StateChecking) {
+		return m.sessions(), nil
+	}
+	return m.sessions(), newSession(m.game.puzzle, m.game.cells, m.Elapsed(), m.run, m.game.letters, m.game.assists)
+}
+
+// sessionFits reports whether a loaded session was saved for the puzzle on
+// screen: the same game ID and, for sessions that carry it, the same text.
+func (m Model) sessionFits(session *storage.GameSession) bool {
+	if m.game.puzzle == nil || session.GameID != m.game.puzzle.ID {
+		return false
+	}
+	return session.EncryptedText == "" || session.EncryptedText == m.game.puzzle.EncryptedText
+}
+
+// sessions returns where this run's puzzle sessions are saved. Practice games
+// get their own namespace so they never feed history or reconciliation, and
+// duels never resume a solo game or leave one behind.
+func (m Model) sessions() storage.Namespace {
+	switch {
+	case m.opts.Local != nil:
+		return storage.Custom
+	case m.duel.room != "":
+		return storage.Duel
+	case m.opts.Practice:
+		return storage.Practice
+	default:
+		return storage.Daily
+	}
+}
+
+// recordsStats reports whether solves in this run are uploaded to the
+// player's stats. Practice games, custom puzzles and duels never are.
+func (m Model) recordsStats() bool {
+	return m.claimCode != "" && !m.opts.Practice && m.opts.Local == nil && m.duel.room == ""
+}
+
+// reportsAttempts reports whether unsolved daily puzzles are reported to the
+// player's stats as attempts: on with the stats opt-in, unless the config
+// turns it off.
+func (m Model) reportsAttempts() bool {
+	return m.cfg != nil && m.cfg.StatsEnabled && !m.cfg.SkipAttempts
+}
+
+// playsToday reports whether this run plays today's daily puzzle, rather than
+// a random, practice, custom or pack puzzle. A duel is pinned to the puzzle
+// both players started on, so it never rolls over.
+func (m Model) playsToday() bool {
+	return !m.opts.Random && m.opts.Date == "" && m.opts.GameID == "" && m.opts.Local == nil && m.opts.Pack == nil && m.duel.room == ""
+}
+
+// location returns the time zone that decides which daily puzzle is today.
+// Duels use UTC, the API's own day, so players anywhere race the same puzzle.
+func (m Model) location() *time.Location {
+	if m.duel.room != "" {
+		return time.UTC
 	}
-	return m.elapsedAtPause
+	return m.cfg.Location()
+}
+
+// fetch starts loading this run's puzzle with fetchCmd.
+func (m Model) fetch() (Model, tea.Cmd) {
+	return m.startFetch(m.fetchCmd())
+}
+
+// startFetch starts a new puzzle fetch generation and tags what cmd, which
+// loads a puzzle, returns with it. From then on the results of earlier
+// fetches, and the sessions they looked up, are stale: a slow answer to an
+// abandoned fetch can't replace the puzzle the player moved on to.
+func (m Model) startFetch(cmd tea.Cmd) (Model, tea.Cmd) {
+	m.puzzleFetch++
+	return m, tagFetch(m.puzzleFetch, cmd)
+}
+
+// staleFetch reports whether a message tagged with fetch belongs to a puzzle
+// fetch a newer one replaced. Untagged messages (0) are never stale.
+func (m Model) staleFetch(fetch int) bool {
+	return fetch != 0 && fetch != m.puzzleFetch
+}
+
+// fetchCmd returns the command that loads this run's puzzle: the custom
+// puzzle, the pack archive to pick one from, one by game ID, a random
+// archived one, one by date, or today's. Puzzles from the API start a
+// puzzle trace.
+func (m Model) fetchCmd() tea.Cmd {
+	switch {
+	case m.opts.Local != nil && m.opts.Pack != nil:
+		return localPuzzleCmd(m.opts.Local, ui.SanitizeString(m.opts.Pack.Name))
+	case m.opts.Local != nil:
+		return localPuzzleCmd(m.opts.Local, "Custom")
+	case m.opts.Pack != nil:
+		return loadArchiveCmd(m.opts.Pack)
+	}
+	return traceFetch(m.client, func(client *api.Client) tea.Cmd {
+		switch {
+		case m.opts.GameID != "":
+			return fetchPuzzleByIDCmd(client, m.sessions(), m.opts.GameID)
+		case m.opts.Random:
+			return fetchRandomPuzzleCmd(client, m.sessions(), m.opts.Category)
+		case m.opts.Date != "":
+			return fetchPuzzleByDateCmd(client, m.sessions(), m.clock(), m.opts.Date)
+		default:
+			return fetchPuzzleCmd(client, m.sessions(), m.clock(), m.location())
+		}
+	})
+}
+
+// defaultRevealAfter is how many wrong submissions it takes before the player
+// is offered to give up and reveal the solution, unless the config says otherwise.
+const defaultRevealAfter = 3
+
+// canReveal reports whether the give-up-and-reveal action is on offer.
+func (m Model) canReveal() bool {
+	if m.state != StatePlaying {
+		return false
+	}
+	threshold := defaultRevealAfter
+	if m.cfg != nil && m.cfg.RevealAfter != 0 {
+		threshold = m.cfg.RevealAfter
+	}
+	return threshold > 0 && m.game.failedChecks >= threshold && m.supports(api.FeatureHints)
+}
+
+// soundEnabled reports whether the player turned on audio feedback in the config.
+func (m Model) soundEnabled() bool {
+	return m.cfg != nil && m.cfg.Sound
+}
+
+// resetGame clears everything about the current puzzle so another one can be
+// loaded in the same run. Preferences, identity and the speed-run target stay.
+func (m Model) resetGame() Model {
+	m.game.trace.end(outcomeLeft, nil)
+	m.game = gameModel{}
+	m.stats.fetch++ // stats still loading were asked for from the puzzle left behind
+	m.toasts = nil
+	m.errs.play = ""
+	m.run.splits = nil
+	return m
 }
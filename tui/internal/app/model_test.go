@@ -1,9 +1,25 @@
 package app
 
 import (
+	"errors"
+	"os"
 	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
 )
 
+// TestMain sets up the global bubblezone manager that rendering relies on,
+// as the root command does for the real program.
+func TestMain(m *testing.M) {
+	zone.NewGlobal()
+	os.Exit(m.Run())
+}
+
 func TestNewWithOptions(t *testing.T) {
 	tests := []struct {
 		name string
@@ -41,3 +57,71 @@ func TestNewWithOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestFetch_DropsStaleResults(t *testing.T) {
+	first, err := puzzlegen.Generate("Hello world", "Tester", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := puzzlegen.Generate("Goodbye moon", "Tester", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{state: StateLoading, opts: Options{Local: first}, width: 80, height: 40}
+	m, slow := m.fetch()
+	m.opts.Local = second
+	m, fast := m.fetch()
+
+	model, _ := m.Update(fast())
+	m = model.(Model)
+	model, cmd := m.Update(slow())
+	m = model.(Model)
+	if m.game.puzzle == nil || m.game.puzzle.ID != second.ID || cmd != nil {
+		t.Errorf("puzzle = %+v after the abandoned fetch returned; want %s kept", m.game.puzzle, second.ID)
+	}
+
+	model, _ = m.Update(errMsg{err: errors.New("timed out"), fetch: m.puzzleFetch - 1})
+	if got := model.(Model); got.state != m.state || got.errs.load != "" {
+		t.Errorf("state = %v after the abandoned fetch failed; want %v and no error", got.state, m.state)
+	}
+	model, _ = m.Update(sessionLoadedMsg{session: &storage.GameSession{GameID: first.ID, Solved: true}, fetch: m.puzzleFetch - 1})
+	if got := model.(Model); got.state != m.state {
+		t.Errorf("state = %v after a session for the abandoned fetch loaded; want %v", got.state, m.state)
+	}
+}
+
+func TestTagFetch(t *testing.T) {
+	cmd := tagFetch(3, func() tea.Msg { return puzzleFetchedMsg{} })
+	if msg := cmd().(puzzleFetchedMsg); msg.fetch != 3 {
+		t.Errorf("puzzleFetchedMsg.fetch = %d, want 3", msg.fetch)
+	}
+	if msg := tagFetch(3, func() tea.Msg { return configSavedMsg{} })(); msg != (configSavedMsg{}) {
+		t.Errorf("tagFetch() changed %v", msg)
+	}
+	if tagFetch(3, nil) != nil {
+		t.Error("tagFetch(nil) should be nil")
+	}
+}
+
+func TestStatsFetch_DropsStaleResults(t *testing.T) {
+	m := Model{state: StateSolved, claimCode: "TEST-CODE-1234", width: 80, height: 40}
+	m.stats = m.stats.open(false)
+	abandoned := m.stats.fetch
+	m = m.resetGame()
+
+	model, _ := m.Update(statsFetchedMsg{stats: &api.PlayerStatsResponse{}, fetch: abandoned})
+	if got := model.(Model); got.state != StateSolved || got.stats.player != nil {
+		t.Errorf("state = %v after stats for a puzzle left behind arrived; want %v", got.state, StateSolved)
+	}
+	model, _ = m.Update(statsFailedMsg{err: errors.New("down"), fetch: abandoned, screen: true})
+	if got := model.(Model); got.state != StateSolved || got.errs.stats {
+		t.Error("a stale stats failure should be dropped")
+	}
+
+	m.stats = m.stats.open(false)
+	model, _ = m.Update(statsFetchedMsg{stats: &api.PlayerStatsResponse{}, fetch: m.stats.fetch})
+	if got := model.(Model); got.state != StateStats {
+		t.Errorf("state = %v after the latest stats arrived; want %v", got.state, StateStats)
+	}
+}
@@ -0,0 +1,166 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	zone "github.com/lrstanley/bubblezone/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+)
+
+// cellMouse renders the view and returns mouse coordinates inside the zone of
+// the cell at index.
+func cellMouse(t *testing.T, m Model, index int) tea.Mouse {
+	t.Helper()
+	return zoneMouse(t, m, fmt.Sprintf("cell-%d", index))
+}
+
+// zoneMouse renders the view and returns mouse coordinates inside the zone
+// with the given ID. Zone positions are recorded asynchronously after Scan, so
+// this polls briefly until the zone is known.
+func zoneMouse(t *testing.T, m Model, id string) tea.Mouse {
+	t.Helper()
+	m.View()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if z := zone.Get(id); !z.IsZero() {
+			return tea.Mouse{X: z.StartX, Y: z.StartY}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("zone %s was never recorded", id)
+	return tea.Mouse{}
+}
+
+func TestMouseLeftClick_MovesCursor(t *testing.T) {
+	m := playingModel(t, "ABC DEF", 80, 40)
+	mouse := cellMouse(t, m, 5)
+	mouse.Button = tea.MouseLeft
+
+	model, _ := m.Update(tea.MouseReleaseMsg(mouse))
+	if got := model.(Model).game.cursorPos; got != 5 {
+		t.Errorf("cursorPos = %d, want 5", got)
+	}
+}
+
+func TestMouseRightClick_ClearsCell(t *testing.T) {
+	m := playingModel(t, "ABA DEF", 80, 40)
+	m.game.cells[0].Input = 'X'
+	m.game.cells[2].Input = 'X'
+	m.game.cells[1].Input = 'Y'
+
+	mouse := cellMouse(t, m, 2)
+	mouse.Button = tea.MouseRight
+
+	model, cmd := m.Update(tea.MouseReleaseMsg(mouse))
+	result := model.(Model)
+
+	if result.game.cells[0].Input != 0 || result.game.cells[2].Input != 0 {
+		t.Error("right-click should clear every cell sharing the cipher letter")
+	}
+	if result.game.cells[1].Input != 'Y' {
+		t.Error("right-click should leave other letters alone")
+	}
+	if result.game.cursorPos != m.game.cursorPos {
+		t.Error("right-click should not move the cursor")
+	}
+	if cmd == nil {
+		t.Error("right-click should save the session")
+	}
+}
+
+func TestMouseMotion_PreviewsRelatedHighlight(t *testing.T) {
+	m := playingModel(t, "ABC DEF", 80, 40)
+	mouse := cellMouse(t, m, 4)
+
+	model, _ := m.Update(tea.MouseMotionMsg(mouse))
+	m = model.(Model)
+	if m.game.hoverChar != 'D' {
+		t.Fatalf("hoverChar = %q, want 'D'", m.game.hoverChar)
+	}
+
+	// The hovered letter's cells get the related highlight, not the cursor's
+	hovered := m.renderGrid()
+	m.game.hoverChar = 0
+	if hovered == m.renderGrid() {
+		t.Error("hovering should change the grid's related-letter highlight")
+	}
+	m.game.hoverChar = 'D'
+
+	// Moving off the grid clears the preview
+	model, _ = m.Update(tea.MouseMotionMsg(tea.Mouse{X: 0, Y: 0}))
+	if got := model.(Model).game.hoverChar; got != 0 {
+		t.Errorf("hoverChar = %q after leaving the grid, want 0", got)
+	}
+}
+
+func TestMouse_IgnoredOutsidePlaying(t *testing.T) {
+	m := playingModel(t, "ABC", 80, 40)
+	mouse := cellMouse(t, m, 1)
+	m.state = StateSolved
+	m.game.cells[1].Input = 'Q'
+
+	mouse.Button = tea.MouseRight
+	model, cmd := m.Update(tea.MouseReleaseMsg(mouse))
+	if model.(Model).game.cells[1].Input != 'Q' || cmd != nil {
+		t.Error("right-click on a solved puzzle should do nothing")
+	}
+
+	model, _ = m.Update(tea.MouseMotionMsg(mouse))
+	if model.(Model).game.hoverChar != 0 {
+		t.Error("hover should not highlight on a solved puzzle")
+	}
+}
+
+func TestHelpBarClick_ActsLikeKey(t *testing.T) {
+	m := playingModel(t, "ABC", 80, 40)
+	mouse := zoneMouse(t, m, helpZoneID(helpSubmit))
+	mouse.Button = tea.MouseLeft
+
+	model, _ := m.Update(tea.MouseReleaseMsg(mouse))
+	if got := toastText(model.(Model)); got != "Fill in all letters first!" {
+		t.Errorf("clicking [Enter] Submit should submit, toast = %q", got)
+	}
+}
+
+func TestHelpBarClick_StatsBack(t *testing.T) {
+	m := statsScreenModel(sampleStats())
+	mouse := zoneMouse(t, m, helpZoneID(helpBack))
+	mouse.Button = tea.MouseLeft
+
+	model, _ := m.Update(tea.MouseReleaseMsg(mouse))
+	if got := model.(Model).state; got != StateSolved {
+		t.Errorf("clicking [Esc] Back should leave stats, state = %v", got)
+	}
+}
+
+func TestHelpBarClick_RightButtonIgnored(t *testing.T) {
+	m := playingModel(t, "ABC", 80, 40)
+	mouse := zoneMouse(t, m, helpZoneID(helpSubmit))
+	mouse.Button = tea.MouseRight
+
+	model, _ := m.Update(tea.MouseReleaseMsg(mouse))
+	if got := toastText(model.(Model)); got != "" {
+		t.Errorf("right-clicking the help bar should do nothing, toast = %q", got)
+	}
+}
+
+func TestHintClick_HighlightsCipherLetter(t *testing.T) {
+	m := playingModel(t, "ABC", 80, 40)
+	m.game.puzzle.Hints = []api.Hint{{CipherLetter: "B", PlainLetter: "E"}}
+	mouse := zoneMouse(t, m, "hint-0")
+	mouse.Button = tea.MouseLeft
+
+	model, _ := m.Update(tea.MouseReleaseMsg(mouse))
+	result := model.(Model)
+	if result.game.hoverChar != 'B' {
+		t.Errorf("clicking a clue should highlight its cipher letter, hoverChar = %q", result.game.hoverChar)
+	}
+	if result.game.cursorPos != m.game.cursorPos {
+		t.Error("clicking a clue should not move the cursor")
+	}
+}
@@ -0,0 +1,253 @@
+package app
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	zone "github.com/lrstanley/bubblezone/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// maxUnfinishedChoices caps how many unfinished puzzles the next-puzzle menu lists.
+const maxUnfinishedChoices = 5
+
+// maxAuthorChoices caps how many puzzles the "more by this author" menu lists.
+const maxAuthorChoices = 10
+
+// nextChoice is one option on the next-puzzle menu: today's puzzle, a daily
+// puzzle by date or game ID, or a random archived one.
+type nextChoice struct {
+	label  string
+	date   string // daily puzzle to load; empty for today's or a random one
+	gameID string // puzzle to load by game ID; empty otherwise
+	random bool
+}
+
+// offersNext reports whether the solved screen offers another puzzle. Pack
+// puzzles go back to the archive instead, and a custom puzzle or duel is a one-off.
+func (m Model) offersNext() bool {
+	return m.state == StateSolved && m.opts.Pack == nil && m.opts.Local == nil && m.duel.room == ""
+}
+
+// offersMore reports whether the solved screen offers more puzzles by the
+// same author. Custom and pack puzzles aren't in the archive, and a duel is a
+// one-off. Servers without the archive can't search it.
+func (m Model) offersMore() bool {
+	return m.state == StateSolved && m.game.puzzle != nil && m.game.puzzle.Author != "" &&
+		m.opts.Pack == nil && m.opts.Local == nil && m.duel.room == "" && m.supports(api.FeatureArchive)
+}
+
+// searchAuthorCmd creates a command that looks for other archived puzzles by
+// the current puzzle's author, leaving out the current one. Puzzles the player
+// has finished stay on the list, marked as such.
+func (m Model) searchAuthorCmd() tea.Cmd {
+	client, sessions, current := m.client, m.sessions(), m.game.puzzle
+	return func() tea.Msg {
+		results, err := client.SearchPuzzles(current.Author)
+		if err != nil {
+			return authorPuzzlesMsg{author: current.Author, err: err}
+		}
+
+		var choices []nextChoice
+		for _, r := range results {
+			if len(choices) == maxAuthorChoices {
+				break
+			}
+			if r.ID == "" || r.ID == current.ID {
+				continue
+			}
+			label := r.Date
+			if r.Category != "" {
+				label += " · " + ui.SanitizeString(r.Category)
+			}
+			if s, err := sessions.LoadSession(r.ID); err == nil && s != nil {
+				switch {
+				case s.Solved:
+					label += " · solved"
+				case s.Revealed:
+					label += " · revealed"
+				default:
+					label += " · in progress"
+				}
+			}
+			choices = append(choices, nextChoice{label: label, gameID: r.ID})
+		}
+		return authorPuzzlesMsg{author: current.Author, choices: choices}
+	}
+}
+
+// handleAuthorPuzzles shows the author's other puzzles on the next-puzzle
+// menu. When there are none, or the search failed, the menu says so.
+func (m Model) handleAuthorPuzzles(msg authorPuzzlesMsg) (tea.Model, tea.Cmd) {
+	if m.state != StateSolved {
+		return m, nil
+	}
+	author := ui.SanitizeString(msg.author)
+	m.nextChoices = msg.choices
+	m.nextCursor = 0
+	m.nextTitle = "More by " + author
+	switch {
+	case msg.err != nil:
+		m.nextNote = "Couldn't search for more puzzles by " + author + "."
+	case len(msg.choices) == 0:
+		m.nextNote = "No other puzzles by " + author + " yet."
+	default:
+		m.nextNote = ""
+	}
+	m.state = StateNextPuzzle
+	return m, nil
+}
+
+// loadNextChoicesCmd creates a command that builds the next-puzzle menu:
+// today's puzzle when it isn't the one just played, the day before it, a
+// random one, and the player's unfinished daily puzzles. Practice runs only
+// play random puzzles. Unfinished sessions are best-effort; a storage error
+// leaves them off the menu.
+func (m Model) loadNextChoicesCmd() tea.Cmd {
+	current, practice, category := m.game.puzzle, m.opts.Practice, m.opts.Category
+	sessions, loc, clk := m.sessions(), m.location(), m.clock()
+	return func() tea.Msg {
+		var choices []nextChoice
+		if !practice {
+			today := cache.Today(clk.Now().In(loc))
+			if current == nil || current.Date != today {
+				choices = append(choices, nextChoice{label: "Today's puzzle"})
+			}
+			if current != nil {
+				if day, err := time.Parse(time.DateOnly, current.Date); err == nil {
+					previous := day.AddDate(0, 0, -1).Format(time.DateOnly)
+					choices = append(choices, nextChoice{label: "Previous day's puzzle (" + previous + ")", date: previous})
+				}
+			}
+		}
+		random := "Random puzzle"
+		if category != "" {
+			random = "Random " + ui.SanitizeString(category) + " puzzle"
+		}
+		choices = append(choices, nextChoice{label: random, random: true})
+
+		unfinished, err := sessions.ListUnfinishedSessions()
+		if err != nil {
+			return nextChoicesMsg{choices: choices}
+		}
+		listed := 0
+		for _, s := range unfinished {
+			if listed == maxUnfinishedChoices {
+				break
+			}
+			// Older sessions don't know their date, so there's no way to fetch them
+			if s.Date == "" || (current != nil && s.GameID == current.ID) {
+				continue
+			}
+			label := "Continue " + s.Date
+			if s.Author != "" {
+				label += " · " + ui.SanitizeString(s.Author)
+			}
+			choices = append(choices, nextChoice{label: label, date: s.Date})
+			listed++
+		}
+		return nextChoicesMsg{choices: choices}
+	}
+}
+
+// handleNextChoices shows the next-puzzle menu, starting on the first option.
+func (m Model) handleNextChoices(msg nextChoicesMsg) (tea.Model, tea.Cmd) {
+	if m.state != StateSolved || len(msg.choices) == 0 {
+		return m, nil
+	}
+	m.nextChoices = msg.choices
+	m.nextCursor = 0
+	m.nextTitle, m.nextNote = "", ""
+	m.state = StateNextPuzzle
+	return m, nil
+}
+
+// handleNextKeyMsg moves through the next-puzzle menu and loads the selected
+// puzzle. Esc and b go back to the solved screen.
+func (m Model) handleNextKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "b":
+		m.state = StateSolved
+		m.nextChoices = nil
+		m.nextTitle, m.nextNote = "", ""
+	case "up", "k":
+		m.nextCursor = max(m.nextCursor-1, 0)
+	case "down", "j":
+		m.nextCursor = max(min(m.nextCursor+1, len(m.nextChoices)-1), 0)
+	case "enter":
+		return m.playNextChoice(m.nextCursor)
+	}
+	return m, nil
+}
+
+// playNextChoice leaves the finished puzzle for the chosen one. The finished
+// puzzle's session was saved when it was solved or revealed.
+func (m Model) playNextChoice(i int) (tea.Model, tea.Cmd) {
+	if i < 0 || i >= len(m.nextChoices) {
+		return m, nil
+	}
+	choice := m.nextChoices[i]
+
+	m = m.resetGame()
+	m.nextChoices = nil
+	m.nextTitle, m.nextNote = "", ""
+	m.opts.Random = choice.random || m.opts.Practice
+	m.opts.Date = choice.date
+	m.opts.GameID = choice.gameID
+	m.state = StateLoading
+	return m.fetch()
+}
+
+// nextChoiceAt returns the index of the next-puzzle menu row under the mouse, or -1.
+func (m Model) nextChoiceAt(msg tea.MouseMsg) int {
+	for i := range m.nextChoices {
+		if zone.Get(fmt.Sprintf("next-%d", i)).InBounds(msg) {
+			return i
+		}
+	}
+	return -1
+}
+
+// viewNextPuzzle renders the next-puzzle menu. The cursor is marked with "›"
+// as well as color.
+func (m Model) viewNextPuzzle() string {
+	title := lipgloss.NewStyle().Bold(true).Render(cmp.Or(m.nextTitle, "Play another puzzle"))
+
+	lines := make([]string, 0, len(m.nextChoices))
+	for i, choice := range m.nextChoices {
+		row := "  " + choice.label
+		if i == m.nextCursor {
+			row = "› " + choice.label
+		}
+		if m.width > 0 {
+			row = ansi.Truncate(row, m.width, "…")
+		}
+		if i == m.nextCursor && !m.accessible {
+			row = lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Render(row)
+		}
+		lines = append(lines, zone.Mark(fmt.Sprintf("next-%d", i), row))
+	}
+	if len(lines) == 0 && m.nextNote != "" {
+		lines = append(lines, ui.HelpStyle.Render(m.nextNote))
+	}
+
+	help := ui.HelpStyle.Render(renderHelpItems(m.helpItems()))
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		m.renderHeader(),
+		"",
+		title,
+		"",
+		strings.Join(lines, "\n"),
+		help,
+	)
+}
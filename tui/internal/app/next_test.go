@@ -0,0 +1,211 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/pack"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// openNextMenu presses n on the solved screen and delivers the menu's choices.
+func openNextMenu(t *testing.T, m Model) Model {
+	t.Helper()
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	if cmd == nil {
+		t.Fatal("n should load the next-puzzle menu")
+	}
+	model, _ = model.Update(cmd())
+	return model.(Model)
+}
+
+func labels(choices []nextChoice) []string {
+	out := make([]string, 0, len(choices))
+	for _, c := range choices {
+		out = append(out, c.label)
+	}
+	return out
+}
+
+func TestNextMenu_Choices(t *testing.T) {
+	setCacheHome(t)
+	for _, s := range []*storage.GameSession{
+		{GameID: "game-0115", Date: "2026-01-15"},
+		{GameID: "game-0120", Date: "2026-01-20"}, // the puzzle just played
+		{GameID: "undated"},
+		{GameID: "game-0110", Date: "2026-01-10", Solved: true},
+	} {
+		if err := storage.SaveSession(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := rolloverModel(t)
+	m.state = StateSolved
+	m = openNextMenu(t, m)
+
+	want := []string{"Today's puzzle", "Previous day's puzzle (2026-01-19)", "Random puzzle", "Continue 2026-01-15"}
+	if got := labels(m.nextChoices); m.state != StateNextPuzzle || !slices.Equal(got, want) {
+		t.Errorf("state %v, choices %q; want the menu with %q", m.state, got, want)
+	}
+}
+
+func TestNextMenu_PracticeOnlyPlaysRandom(t *testing.T) {
+	setCacheHome(t)
+	m := rolloverModel(t)
+	m.state = StateSolved
+	m.opts = Options{Practice: true, Random: true}
+
+	m = openNextMenu(t, m)
+	if got := labels(m.nextChoices); !slices.Equal(got, []string{"Random puzzle"}) {
+		t.Errorf("practice choices = %q", got)
+	}
+}
+
+func TestNextMenu_NotOfferedForPacksOrCustomPuzzles(t *testing.T) {
+	for _, opts := range []Options{
+		{Pack: &pack.Pack{Name: "Stoic Sayings"}},
+		{Local: &puzzlegen.Puzzle{ID: "local-1"}},
+	} {
+		m := rolloverModel(t)
+		m.state = StateSolved
+		m.opts = opts
+
+		if _, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: 'n', Text: "n"}); cmd != nil {
+			t.Errorf("n should do nothing with options %+v", opts)
+		}
+		for _, item := range m.helpItems() {
+			if item == helpNextPuzzle {
+				t.Errorf("help bar should not offer a next puzzle with options %+v", opts)
+			}
+		}
+	}
+}
+
+func TestNextMenu_Navigation(t *testing.T) {
+	setCacheHome(t)
+	m := rolloverModel(t)
+	m.state = StateSolved
+	m.game.elapsedAtPause = time.Minute
+	m = openNextMenu(t, m)
+
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyEsc})
+	if back := model.(Model); back.state != StateSolved || cmd != nil {
+		t.Errorf("Esc should go back to the solved screen, got state %v", back.state)
+	}
+
+	model, _ = m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyDown})
+	model, cmd = model.(Model).handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = model.(Model)
+	if m.state != StateLoading || m.opts.Date != "2026-01-19" || m.game.puzzle != nil || m.game.cells != nil || m.game.elapsedAtPause != 0 {
+		t.Fatalf("Enter should reset the game and load the previous day, got state %v, date %q", m.state, m.opts.Date)
+	}
+	if cmd == nil || m.playsToday() {
+		t.Error("a dated puzzle should be fetched and not watched for rollover")
+	}
+
+	m.state = StateSolved
+	m.game.puzzle = &api.Puzzle{ID: "game-0119", Date: "2026-01-19"}
+	m = openNextMenu(t, m)
+	m.nextCursor = slices.IndexFunc(m.nextChoices, func(c nextChoice) bool { return c.random })
+	model, _ = m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m := model.(Model); !m.opts.Random || m.opts.Date != "" {
+		t.Errorf("choosing random should switch to random puzzles, got %+v", m.opts)
+	}
+}
+
+func TestSaveSessionCmd_StoresDate(t *testing.T) {
+	setCacheHome(t)
+	m := rolloverModel(t)
+
+	saveSessionCmd(storage.Daily, time.Now(), m.game.puzzle, m.game.cells, time.Minute, m.run, m.game.letters, m.game.assists)()
+	session, err := storage.LoadSession(m.game.puzzle.ID)
+	if err != nil || session == nil || session.Date != "2026-01-20" {
+		t.Errorf("LoadSession() = %+v, %v; want the puzzle's date saved", session, err)
+	}
+}
+
+func TestMoreByAuthor(t *testing.T) {
+	setCacheHome(t)
+	if err := storage.SaveSession(&storage.GameSession{GameID: "wilde-2", Solved: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/game/search" || r.URL.Query().Get("author") != "Oscar Wilde" {
+			t.Errorf("request = %s, want a search for Oscar Wilde", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"puzzles": [
+			{"id": "game-0120", "date": "2026-01-20", "author": "Oscar Wilde", "category": "Humor"},
+			{"id": "wilde-1", "date": "2025-06-01", "author": "Oscar Wilde", "category": "Humor"},
+			{"id": "wilde-2", "date": "2024-02-11", "author": "Oscar Wilde", "category": "Wisdom"}
+		]}`))
+	}))
+	defer server.Close()
+	client, err := api.NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := rolloverModel(t)
+	m.client = client
+	m.state = StateSolved
+	m.game.puzzle.Author = "Oscar Wilde"
+	if !slices.Contains(m.helpItems(), helpMore) {
+		t.Error("the solved screen should offer more by the author")
+	}
+
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: 'm', Text: "m"})
+	if cmd == nil {
+		t.Fatal("m should search for the author's puzzles")
+	}
+	model, _ = model.Update(cmd())
+	m = model.(Model)
+
+	want := []string{"2025-06-01 · Humor", "2024-02-11 · Wisdom · solved"}
+	if got := labels(m.nextChoices); m.state != StateNextPuzzle || !slices.Equal(got, want) {
+		t.Fatalf("state %v, choices %q; want the menu with %q", m.state, got, want)
+	}
+	if view := m.viewNextPuzzle(); !strings.Contains(view, "More by Oscar Wilde") {
+		t.Errorf("menu should be titled for the author:\n%s", view)
+	}
+
+	model, cmd = m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = model.(Model)
+	if m.state != StateLoading || m.opts.GameID != "wilde-1" || cmd == nil || m.playsToday() {
+		t.Errorf("Enter should load the chosen puzzle by ID, got state %v, opts %+v", m.state, m.opts)
+	}
+}
+
+func TestMoreByAuthor_NoResults(t *testing.T) {
+	m := rolloverModel(t)
+	m.state = StateSolved
+	m.game.puzzle.Author = "Oscar Wilde"
+
+	// The unreachable test API fails the search
+	model, _ := m.Update(m.searchAuthorCmd()())
+	m = model.(Model)
+	if m.state != StateNextPuzzle || !strings.Contains(m.viewNextPuzzle(), "Couldn't search for more puzzles by Oscar Wilde") {
+		t.Errorf("a failed search should say so:\n%s", m.viewNextPuzzle())
+	}
+
+	model, _ = m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyDown})
+	model, cmd := model.(Model).handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if model.(Model).state != StateNextPuzzle || cmd != nil {
+		t.Error("an empty menu should have nothing to play")
+	}
+
+	m.state = StateSolved
+	m.game.puzzle.Author = ""
+	if _, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: 'm', Text: "m"}); cmd != nil {
+		t.Error("m should do nothing for a puzzle without an author")
+	}
+}
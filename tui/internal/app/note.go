@@ -0,0 +1,121 @@
+package app
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// noteCharLimit is the longest note the player can attach to a solve.
+const noteCharLimit = 140
+
+// notePrompt introduces the note, in the editor and once saved.
+const notePrompt = "Note: "
+
+// offersNote reports whether the solved screen lets the player note the
+// solve: any puzzle solved on this device, not one given up on or solved
+// elsewhere, which has no session here to keep it.
+func (m Model) offersNote() bool {
+	return m.state == StateSolved && m.game.puzzle != nil && !m.game.revealed && !m.game.solvedElsewhere
+}
+
+// startNote opens the note editor on the solved screen, holding the current
+// note.
+func (m Model) startNote() (Model, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = notePrompt
+	input.Placeholder = "e.g. solved on the train, great pun"
+	input.CharLimit = noteCharLimit
+	if m.width > 0 {
+		input.SetWidth(max(m.width-lipgloss.Width(notePrompt)-1, 1))
+	}
+	input.SetValue(m.game.note)
+	cmd := input.Focus()
+	m.game.noteInput = &input
+	return m, cmd
+}
+
+// handleNoteKeyMsg edits the note: Enter saves it, Esc closes the editor
+// without saving, and anything else is typed.
+func (m Model) handleNoteKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.game.noteInput = nil
+		return m, nil
+	case "enter":
+		note := strings.TrimSpace(m.game.noteInput.Value())
+		m.game.noteInput = nil
+		if note == m.game.note {
+			return m, nil
+		}
+		return m, m.saveNoteCmd(note)
+	}
+	return m.updateNoteInput(msg)
+}
+
+// updateNoteInput passes msg to the note editor, for typing and its cursor
+// blink.
+func (m Model) updateNoteInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	input, cmd := m.game.noteInput.Update(msg)
+	m.game.noteInput = &input
+	return m, cmd
+}
+
+// saveNoteCmd creates a command that stores note on the solved puzzle's session.
+func (m Model) saveNoteCmd(note string) tea.Cmd {
+	sessions, gameID := m.sessions(), m.game.puzzle.ID
+	return func() tea.Msg {
+		return noteSavedMsg{err: sessions.SetNote(gameID, note), gameID: gameID, note: note}
+	}
+}
+
+// handleNoteSaved shows the saved note and says what happened in a toast.
+func (m Model) handleNoteSaved(msg noteSavedMsg) (tea.Model, tea.Cmd) {
+	if m.game.puzzle == nil || msg.gameID != m.game.puzzle.ID {
+		return m, nil
+	}
+
+	switch {
+	case msg.err != nil:
+		return m.notify(toastError, "Couldn't save note: "+msg.err.Error())
+	case msg.note == "":
+		m.game.note = ""
+		return m.notify(toastSuccess, "Note removed")
+	default:
+		m.game.note = msg.note
+		return m.notify(toastSuccess, "Note saved")
+	}
+}
+
+// renderNote renders the note editor while it is open, otherwise the solve's
+// note, if it has one.
+func (m Model) renderNote() string {
+	switch {
+	case m.state != StateSolved:
+		return ""
+	case m.game.noteInput != nil:
+		return m.game.noteInput.View()
+	case m.game.note != "":
+		return ui.TimerStyle.Render(notePrompt + ui.SanitizeString(m.game.note))
+	default:
+		return ""
+	}
+}
+
+// accessibleNote is renderNote as plain text.
+func (m Model) accessibleNote() string {
+	switch {
+	case m.state != StateSolved:
+		return ""
+	case m.game.noteInput != nil:
+		return notePrompt + m.game.noteInput.Value() + " (Enter saves, Esc cancels)"
+	case m.game.note != "":
+		return notePrompt + ui.SanitizeString(m.game.note)
+	default:
+		return ""
+	}
+}
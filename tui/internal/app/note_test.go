@@ -0,0 +1,111 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// solvedNoteModel returns a solved Model whose session is saved.
+func solvedNoteModel(t *testing.T) Model {
+	t.Helper()
+	storagetest.UseMemory(t)
+	m := rolloverModel(t)
+	m.state = StateSolved
+	if err := storage.SaveSession(&storage.GameSession{GameID: m.game.puzzle.ID, Solved: true}); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// typeNote types text into the open note editor, one key at a time.
+func typeNote(m Model, text string) Model {
+	for _, r := range text {
+		model, _ := m.handleKeyMsg(tea.KeyPressMsg{Code: r, Text: string(r)})
+		m = model.(Model)
+	}
+	return m
+}
+
+func TestNote_SaveFromSolvedScreen(t *testing.T) {
+	m := solvedNoteModel(t)
+	if !slices.Contains(m.helpItems(), helpNote) {
+		t.Fatal("the solved screen should offer a note")
+	}
+
+	model, _ := m.handleKeyMsg(tea.KeyPressMsg{Code: 'n', ShiftedCode: 'N', Mod: tea.ModShift, Text: "N"})
+	m = model.(Model)
+	if m.game.noteInput == nil {
+		t.Fatal("N should open the note editor")
+	}
+	if items := m.helpItems(); !slices.Equal(items, []helpItem{helpSaveNote, helpCancel}) {
+		t.Errorf("help = %v, want save and cancel while editing", items)
+	}
+
+	// Keys that act on the solved screen are typed instead
+	m = typeNote(m, "great pun, s")
+	if m.state != StateSolved || !strings.Contains(ansi.Strip(m.renderNote()), "great pun, s") {
+		t.Fatalf("editor shows %q, want the typed note", ansi.Strip(m.renderNote()))
+	}
+
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = model.(Model)
+	if m.game.noteInput != nil || cmd == nil {
+		t.Fatal("Enter should close the editor and save the note")
+	}
+	model, _ = m.Update(cmd())
+	m = model.(Model)
+	if m.game.note != "great pun, s" || toastText(m) != "Note saved" {
+		t.Errorf("note = %q, toast %q; want the note saved", m.game.note, toastText(m))
+	}
+	if view := ansi.Strip(m.renderNote()); view != "Note: great pun, s" {
+		t.Errorf("solved screen shows %q, want the note", view)
+	}
+	session, err := storage.LoadSession(m.game.puzzle.ID)
+	if err != nil || session == nil || session.Note != "great pun, s" || !session.Solved {
+		t.Errorf("session = %+v, %v; want the note stored on the solve", session, err)
+	}
+}
+
+func TestNote_EscCancels(t *testing.T) {
+	m := solvedNoteModel(t)
+	m.game.note = "on the train"
+
+	m, _ = m.startNote()
+	if got := m.game.noteInput.Value(); got != "on the train" {
+		t.Errorf("editor holds %q, want the current note", got)
+	}
+	m = typeNote(m, "!")
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyEsc})
+	m = model.(Model)
+	if m.game.noteInput != nil || cmd != nil || m.game.note != "on the train" {
+		t.Errorf("Esc should close the editor without saving or quitting; note = %q", m.game.note)
+	}
+}
+
+func TestNote_RestoredWithSession(t *testing.T) {
+	storagetest.UseMemory(t)
+	m := rolloverModel(t)
+
+	model, _ := m.Update(sessionLoadedMsg{session: &storage.GameSession{GameID: m.game.puzzle.ID, Solved: true, Note: "lucky guess"}})
+	m = model.(Model)
+	if view := ansi.Strip(m.renderNote()); view != "Note: lucky guess" {
+		t.Errorf("restored solve shows %q, want its note", view)
+	}
+}
+
+func TestNote_NotOfferedAfterReveal(t *testing.T) {
+	m := solvedNoteModel(t)
+	m.game.revealed = true
+
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: 'N', Text: "N"})
+	if model.(Model).game.noteInput != nil || cmd != nil {
+		t.Error("a revealed puzzle has no solve to note")
+	}
+}
@@ -0,0 +1,195 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+	"github.com/bojanrajkovic/unquote/tui/internal/clock"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// setCacheHome isolates the offline puzzle cache in a temp dir, and sessions
+// in memory.
+func setCacheHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+	storagetest.UseMemory(t)
+}
+
+func TestFetchPuzzle_FallsBackToCacheOffline(t *testing.T) {
+	setCacheHome(t)
+
+	today := cache.Today(time.Now())
+	entry := &cache.Entry{
+		Puzzle:   &api.Puzzle{ID: "game-today", Date: today, EncryptedText: "XM, MX", Category: "Classic"},
+		Solution: "HI, IH",
+	}
+	if err := cache.Save(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{state: StateLoading, client: newTestClient(t), width: 80, height: 40, sizeReady: true}
+	msg, ok := m.fetchCmd()().(puzzleFetchedMsg)
+	if !ok {
+		t.Fatal("an unreachable API with a cached puzzle should still load today's puzzle")
+	}
+	if !msg.offline || msg.answer != "HI, IH" {
+		t.Errorf("puzzleFetchedMsg = %+v, want the cached puzzle marked offline", msg)
+	}
+
+	model, _ := m.handlePuzzleFetched(msg)
+	m = model.(Model)
+	if !strings.Contains(ansi.Strip(m.viewPlaying()), "Offline") {
+		t.Error("the playing screen should say the puzzle is offline")
+	}
+
+	// Submissions are checked against the cached answer
+	puzzle.RevealSolution(m.game.cells, "HI, IH")
+	_, cmd := m.handleSubmit()
+	if checked, ok := cmd().(solutionCheckedMsg); !ok || !checked.correct {
+		t.Errorf("offline submission returned %#v, want a correct check", cmd())
+	}
+}
+
+func TestFetchPuzzle_OfflineWithoutCacheErrors(t *testing.T) {
+	setCacheHome(t)
+
+	m := Model{state: StateLoading, client: newTestClient(t)}
+	if _, ok := m.fetchCmd()().(errMsg); !ok {
+		t.Error("an unreachable API with nothing cached should report the error")
+	}
+}
+
+func TestFetchPuzzle_OfflineResumesStoredSession(t *testing.T) {
+	setCacheHome(t)
+
+	p := &api.Puzzle{
+		ID: "game-stored", Date: "2026-01-15", EncryptedText: "XM, MX", Author: "Anon", Category: "Classic", Difficulty: 3,
+		Hints: []api.Hint{{CipherLetter: "X", PlainLetter: "H"}},
+	}
+	saveSessionCmd(storage.Daily, time.Now(), p, puzzle.BuildCells(p.EncryptedText, nil), time.Minute, speedRun{}, nil, storage.Assists{})()
+
+	m := Model{state: StateLoading, client: newTestClient(t), opts: Options{Date: "2026-01-15"}, width: 80, height: 40, sizeReady: true}
+	msg, ok := m.fetchCmd()().(puzzleFetchedMsg)
+	if !ok {
+		t.Fatal("an unreachable API should fall back to the puzzle saved with its session")
+	}
+	if !msg.offline || msg.answer != "" || !reflect.DeepEqual(msg.puzzle, p) {
+		t.Errorf("puzzleFetchedMsg = %+v, want the stored puzzle offline without an answer", msg)
+	}
+
+	// Without an answer, a full grid can't be checked until the API is back
+	model, _ := m.handlePuzzleFetched(msg)
+	m = model.(Model)
+	puzzle.RevealSolution(m.game.cells, "HI, IH")
+	model, cmd := m.handleSubmit()
+	m = model.(Model)
+	if m.state != StatePlaying || !strings.Contains(toastText(m), "Can't check this puzzle offline") || cmd == nil {
+		t.Errorf("state = %v, toast = %q; want to keep playing with progress saved", m.state, toastText(m))
+	}
+}
+
+// Cached puzzles expire by the model's clock, not the wall clock.
+func TestFetchPuzzle_CacheExpiresByClock(t *testing.T) {
+	setCacheHome(t)
+
+	entry := &cache.Entry{
+		Puzzle:   &api.Puzzle{ID: "game-cached", Date: "2026-01-15", EncryptedText: "XM, MX"},
+		Solution: "HI, IH",
+	}
+	if err := cache.Save(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	clk := clock.NewFake(time.Date(2026, 1, 16, 12, 0, 0, 0, time.UTC))
+	m := Model{state: StateLoading, client: newTestClient(t), opts: Options{Date: "2026-01-15", Clock: clk}}
+	if msg, ok := m.fetchCmd()().(puzzleFetchedMsg); !ok || msg.answer != "HI, IH" {
+		t.Errorf("fetch the day after = %#v, want the cached puzzle", msg)
+	}
+
+	clk.Set(time.Date(2026, 1, 16, 12, 0, 0, 0, time.UTC).Add(cache.Retention + 24*time.Hour))
+	if msg, ok := m.fetchCmd()().(errMsg); !ok {
+		t.Errorf("fetch once the entry expired = %#v, want the API's error", msg)
+	}
+}
+
+// Prefetching after a solve caches the days ahead of the model's clock and
+// prunes what expired by it.
+func TestPrefetchCmd_FollowsClock(t *testing.T) {
+	setCacheHome(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if id, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/game/"), "/solution"); ok {
+			_ = json.NewEncoder(w).Encode(api.SolutionResponse{Solution: "ANSWER " + id})
+			return
+		}
+		date := strings.TrimPrefix(r.URL.Path, "/game/")
+		_ = json.NewEncoder(w).Encode(api.Puzzle{ID: "id-" + date, Date: date, EncryptedText: "XYZ"})
+	}))
+	defer server.Close()
+	client, err := api.NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, date := range []string{"2026-01-05", "2026-01-15"} {
+		if err := cache.Save(&cache.Entry{Puzzle: &api.Puzzle{ID: "old-" + date, Date: date}, Solution: "OLD"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	clk := clock.NewFake(time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC))
+	prefetchCmd(client, clk, time.UTC)()
+
+	dir := filepath.Join(xdg.CacheHome, "unquote", "puzzles")
+	for date, want := range map[string]bool{
+		"2026-01-05": false, // expired a week after its day
+		"2026-01-15": true,
+		"2026-01-20": true,
+		"2026-01-26": true, // the last of autoPrefetchDays
+		"2026-01-27": false,
+	} {
+		if _, err := os.Stat(filepath.Join(dir, date+".json")); (err == nil) != want {
+			t.Errorf("%s cached = %v, want %v", date, err == nil, want)
+		}
+	}
+}
+
+func TestPlaysToday(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want bool
+	}{
+		{name: "daily", want: true},
+		{name: "random", opts: Options{Random: true}, want: false},
+		{name: "practice", opts: Options{Random: true, Practice: true}, want: false},
+		{name: "custom", opts: Options{Local: &puzzlegen.Puzzle{ID: "local-1"}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Model{opts: tt.opts}).playsToday(); got != tt.want {
+				t.Errorf("playsToday() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// onboardingModel is the first-run screen asking whether to track stats.
+// The router acts on the answer once the form is completed.
+type onboardingModel struct {
+	form  *huh.Form // nil when not onboarding
+	optIn *bool     // the form's answer; shared by every copy of the model
+}
+
+// newOnboarding builds the stats question, with huh's accessible mode and
+// base theme when accessible.
+func newOnboarding(accessible bool) onboardingModel {
+	// Allocate a persistent bool pointer for the huh.Confirm binding.
+	// This must survive model value copies — all copies share the same pointer,
+	// so when huh writes the user's selection into it, optIn reflects it correctly.
+	o := onboardingModel{optIn: new(bool)}
+
+	o.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewNote().
+				Title("Track Your Stats?").
+				Description("Unquote can track your solve times and streaks.\n\n"+
+					"What we store:\n"+
+					"  - Which puzzles you solved\n"+
+					"  - How long each took\n\n"+
+					"What we don't store:\n"+
+					"  - No personal information\n"+
+					"  - No email, no password\n\n"+
+					"You'll get a random claim code (like TIGER-MAPLE-7492)\n"+
+					"that identifies your stats. Save it to access your\n"+
+					"stats from another device."),
+			huh.NewConfirm().
+				Title("Track my stats?").
+				Affirmative("Yes, track my stats").
+				Negative("No thanks").
+				Value(o.optIn),
+		),
+	).WithShowHelp(false).WithShowErrors(false).WithAccessible(accessible)
+	if accessible {
+		// The base theme marks the selected option with text, not just color
+		o.form = o.form.WithTheme(huh.ThemeFunc(huh.ThemeBase))
+	}
+	return o
+}
+
+// init starts the form.
+func (o onboardingModel) init() tea.Cmd {
+	return o.form.Init()
+}
+
+// update passes msg to the form: key presses, and the focus, cursor blink
+// and other internal messages returned by its commands.
+func (o onboardingModel) update(msg tea.Msg) (onboardingModel, tea.Cmd) {
+	if o.form == nil {
+		return o, nil
+	}
+	formModel, cmd := o.form.Update(msg)
+	if f, ok := formModel.(*huh.Form); ok {
+		o.form = f
+	}
+	return o, cmd
+}
+
+// completed reports whether the question has been answered.
+func (o onboardingModel) completed() bool {
+	return o.form != nil && o.form.State == huh.StateCompleted
+}
+
+// optedIn reports whether the answer was to track stats.
+func (o onboardingModel) optedIn() bool {
+	return o.optIn != nil && *o.optIn
+}
+
+// view renders the form centered in a terminal of width x height, above the
+// status bar.
+func (o onboardingModel) view(width, height int) string {
+	if o.form == nil {
+		return ""
+	}
+	return lipgloss.Place(width, height-statusBarHeight, lipgloss.Center, lipgloss.Center, o.form.View())
+}
@@ -19,7 +19,7 @@ func TestHandleConfigLoaded_NoConfig_ShowsOnboarding(t *testing.T) {
 	if result.state != StateOnboarding {
 		t.Errorf("state: want StateOnboarding (%d), got %d", StateOnboarding, result.state)
 	}
-	if result.form == nil {
+	if result.onboarding.form == nil {
 		t.Error("form: want non-nil huh.Form, got nil")
 	}
 	if cmd == nil {
@@ -49,6 +49,31 @@ func TestHandleConfigLoaded_ExistingConfig_SkipsOnboarding(t *testing.T) {
 	}
 }
 
+// An ephemeral run with no config plays without stats instead of registering
+// a claim code that would be lost on exit.
+func TestHandleConfigLoaded_NoConfigEphemeral_SkipsOnboarding(t *testing.T) {
+	m := Model{
+		state: StateLoading,
+		opts:  Options{Ephemeral: true},
+	}
+
+	resultModel, cmd := m.handleConfigLoaded(configLoadedMsg{config: nil})
+	result := resultModel.(Model)
+
+	if result.state != StateLoading {
+		t.Errorf("state: want StateLoading (%d), got %d", StateLoading, result.state)
+	}
+	if result.onboarding.form != nil {
+		t.Error("form: want no onboarding form")
+	}
+	if result.cfg == nil || result.cfg.StatsEnabled || result.claimCode != "" {
+		t.Errorf("cfg = %+v, claim code %q; want an empty config without stats", result.cfg, result.claimCode)
+	}
+	if cmd == nil {
+		t.Error("cmd: want puzzle fetch cmd, got nil")
+	}
+}
+
 // TestHandlePlayerRegistered_TransitionsToClaimCodeDisplay verifies AC2.2 (partial):
 // Receiving playerRegisteredMsg stores claim code and transitions to StateClaimCodeDisplay.
 func TestHandlePlayerRegistered_TransitionsToClaimCodeDisplay(t *testing.T) {
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"github.com/charmbracelet/colorprofile"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// applyPalette switches the styles to the palette for Config.Background, or
+// for the custom theme's or terminal's background when that is auto, with
+// the custom theme's colors, and drops the grid cells cached in the old
+// colors. Bubble Tea converts the true colors to what the terminal shows.
+func (m Model) applyPalette() Model {
+	var background ui.Background
+	if m.cfg != nil {
+		background, _ = ui.ParseBackground(m.cfg.Background)
+	}
+	background = ui.PickBackground(background, m.theme)
+	ui.UsePalette(background.IsDark(!m.lightBackground), colorprofile.TrueColor, m.theme)
+	if m.gridCache != nil {
+		m.gridCache = newGridCache()
+	}
+	return m
+}
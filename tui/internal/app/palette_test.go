@@ -0,0 +1,64 @@
+package app
+
+import (
+	"errors"
+	"image/color"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+func TestApplyPalette(t *testing.T) {
+	t.Cleanup(func() { ui.UsePalette(true, colorprofile.ANSI256, nil) })
+	white := tea.BackgroundColorMsg{Color: color.White}
+
+	model, _ := NewWithClient(nil).Update(white)
+	m := model.(Model)
+	if !m.lightBackground || ui.ColorText != lipgloss.Color("#000000") {
+		t.Errorf("lightBackground %v, text %v; want the light palette for a white terminal", m.lightBackground, ui.ColorText)
+	}
+
+	model, _ = m.handleConfigLoaded(configLoadedMsg{config: &config.Config{Background: "dark"}})
+	if ui.ColorText != lipgloss.Color("#FFFFFF") {
+		t.Errorf("text = %v, want Config.Background to win over the terminal", ui.ColorText)
+	}
+	model, _ = model.(Model).Update(white)
+	if ui.ColorText != lipgloss.Color("#FFFFFF") {
+		t.Errorf("text = %v, want the configured background kept", ui.ColorText)
+	}
+}
+
+func TestHandleConfigLoaded_Theme(t *testing.T) {
+	t.Cleanup(func() { ui.UsePalette(true, colorprofile.ANSI256, nil) })
+	theme, err := ui.ParseCustomTheme("light", map[string]string{"primary": "#1F3A93"})
+	if err != nil {
+		t.Fatalf("ParseCustomTheme: %v", err)
+	}
+
+	model, _ := NewWithClient(nil).handleConfigLoaded(configLoadedMsg{config: &config.Config{Theme: "paper"}, theme: theme})
+	if ui.ColorPrimary != lipgloss.Color("#1F3A93") || ui.ColorText != lipgloss.Color("#000000") {
+		t.Errorf("primary %v, text %v; want the theme's primary on its light background", ui.ColorPrimary, ui.ColorText)
+	}
+	model, _ = model.(Model).Update(tea.BackgroundColorMsg{Color: color.Black})
+	if ui.ColorText != lipgloss.Color("#000000") {
+		t.Errorf("text = %v, want the theme's background kept over the terminal's", ui.ColorText)
+	}
+
+	model, _ = NewWithClient(nil).handleConfigLoaded(configLoadedMsg{
+		config:   &config.Config{Theme: "broken"},
+		themeErr: errors.New("theme broken: unknown color role \"accent\""),
+	})
+	m := model.(Model)
+	if len(m.toasts) != 1 || !strings.Contains(m.toasts[0].text, "built-in colors") {
+		t.Errorf("toasts = %+v, want a warning that the built-in colors are used", m.toasts)
+	}
+	if ui.ColorPrimary == lipgloss.Color("#1F3A93") {
+		t.Error("a theme that can't be used should leave the built-in colors")
+	}
+}
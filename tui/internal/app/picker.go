@@ -0,0 +1,220 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// pickerLetters are the plaintext letters the letter picker lists.
+const pickerLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// letterPicker is the Tab panel listing every plaintext letter with the
+// cipher letters it is assigned to, for picking a free one for the cell under
+// the cursor. Letters assigned to two or more cipher letters are conflicts
+// and stand out, so the panel also shows where the board contradicts itself.
+type letterPicker struct {
+	selected rune // highlighted letter, Enter assigns it; 0 when every letter is taken
+}
+
+// letterAssignments maps each plaintext letter on the board to the cipher
+// letters it stands for, in alphabetical order. Clue letters count, since
+// they are taken as surely as typed ones.
+func letterAssignments(cells []puzzle.Cell) map[rune][]rune {
+	assigned := make(map[rune][]rune)
+	for _, cell := range cells {
+		if cell.Input == 0 || (cell.Kind != puzzle.CellLetter && cell.Kind != puzzle.CellHint) {
+			continue
+		}
+		if !slices.Contains(assigned[cell.Input], cell.Char) {
+			assigned[cell.Input] = append(assigned[cell.Input], cell.Char)
+		}
+	}
+	for _, ciphers := range assigned {
+		slices.Sort(ciphers)
+	}
+	return assigned
+}
+
+// pickerOpen reports whether the letter picker is showing.
+func (m Model) pickerOpen() bool {
+	return m.game.picker != nil && m.state == StatePlaying
+}
+
+// cursorCipher returns the cipher letter of the cell under the cursor, or 0
+// when the cursor is not on a letter.
+func (m Model) cursorCipher() rune {
+	if m.game.cursorPos < 0 || m.game.cursorPos >= len(m.game.cells) || m.game.cells[m.game.cursorPos].Kind != puzzle.CellLetter {
+		return 0
+	}
+	return m.game.cells[m.game.cursorPos].Char
+}
+
+// pickable reports whether letter is free for the cell under the cursor: no
+// other cipher letter has it.
+func (m Model) pickable(assigned map[rune][]rune, letter rune) bool {
+	cipher := m.cursorCipher()
+	for _, c := range assigned[letter] {
+		if c != cipher {
+			return false
+		}
+	}
+	return true
+}
+
+// stepPicker returns the next letter free for the cell under the cursor
+// after from, going forward (step 1) or back (step -1) and wrapping around,
+// or 0 when none is free. A from of 0 starts before A.
+func (m Model) stepPicker(assigned map[rune][]rune, from rune, step int) rune {
+	n := len(pickerLetters)
+	i := strings.IndexRune(pickerLetters, from)
+	if i < 0 {
+		i = n - 1
+		if step < 0 {
+			i = 0
+		}
+	}
+	for range n {
+		i = (i + step + n) % n
+		if letter := rune(pickerLetters[i]); m.pickable(assigned, letter) {
+			return letter
+		}
+	}
+	return 0
+}
+
+// openPicker opens the letter picker on the letter already in the cell under
+// the cursor, or else the first free one.
+func (m Model) openPicker() (tea.Model, tea.Cmd) {
+	if m.cursorCipher() == 0 {
+		return m, nil
+	}
+	assigned := letterAssignments(m.game.cells)
+	selected := m.game.cells[m.game.cursorPos].Input
+	if !strings.ContainsRune(pickerLetters, selected) || !m.pickable(assigned, selected) {
+		selected = m.stepPicker(assigned, 0, 1)
+	}
+	m.game.picker = &letterPicker{selected: selected}
+	return m, nil
+}
+
+// handlePickerKeyMsg drives the open letter picker, which takes every key:
+// left and right move between free letters, typing a free letter selects it,
+// Enter assigns the selection to the cell under the cursor, and Esc or Tab
+// closes the picker.
+func (m Model) handlePickerKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	assigned := letterAssignments(m.game.cells)
+	switch msg.String() {
+	case "esc", "tab":
+		m.game.picker = nil
+		return m, nil
+	case "enter":
+		letter := m.game.picker.selected
+		m.game.picker = nil
+		if letter == 0 || !m.pickable(assigned, letter) {
+			return m, nil
+		}
+		return m.handleLetterInput(letter)
+	case "left":
+		m.game.picker = &letterPicker{selected: m.stepPicker(assigned, m.game.picker.selected, -1)}
+	case "right":
+		m.game.picker = &letterPicker{selected: m.stepPicker(assigned, m.game.picker.selected, 1)}
+	default:
+		letter, ok := puzzle.InputLetter(msg.Text, true)
+		if ok && strings.ContainsRune(pickerLetters, letter) && m.pickable(assigned, letter) {
+			m.game.picker = &letterPicker{selected: letter}
+		}
+	}
+	return m, nil
+}
+
+// pickerEntry labels one letter in the picker: the letter alone when free,
+// else with the cipher letters it is assigned to ("E=X", "T=Q,R").
+func pickerEntry(letter rune, ciphers []rune) string {
+	if len(ciphers) == 0 {
+		return string(letter)
+	}
+	names := make([]string, len(ciphers))
+	for i, c := range ciphers {
+		names[i] = string(c)
+	}
+	return string(letter) + "=" + strings.Join(names, ",")
+}
+
+// renderPicker renders the open letter picker, wrapped to the terminal:
+// free letters plain, taken ones muted, conflicts in the warning color and
+// the selection bracketed.
+func (m Model) renderPicker() string {
+	if !m.pickerOpen() {
+		return ""
+	}
+	assigned := letterAssignments(m.game.cells)
+	taken := lipgloss.NewStyle().Foreground(ui.ColorMuted)
+	selected := lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true)
+
+	width := max(m.width, MinTerminalWidth)
+	lines := []string{lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Letter for cipher %c:", m.cursorCipher()))}
+	var line string
+	for _, letter := range pickerLetters {
+		ciphers := assigned[letter]
+		entry := pickerEntry(letter, ciphers)
+		switch {
+		case letter == m.game.picker.selected:
+			entry = selected.Render("[" + entry + "]")
+		case len(ciphers) > 1:
+			entry = ui.WarningStyle.Render(" " + entry + " ")
+		case !m.pickable(assigned, letter):
+			entry = taken.Render(" " + entry + " ")
+		default:
+			entry = " " + entry + " "
+		}
+		if line != "" && lipgloss.Width(line)+lipgloss.Width(entry) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		line += entry
+	}
+	lines = append(lines, line)
+	if m.game.picker.selected == 0 {
+		lines = append(lines, ui.WarningStyle.Render("Every letter is taken; clear one to free it."))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// accessiblePicker is renderPicker as plain text.
+func (m Model) accessiblePicker() string {
+	if !m.pickerOpen() {
+		return ""
+	}
+	assigned := letterAssignments(m.game.cells)
+	var free, taken []string
+	for _, letter := range pickerLetters {
+		ciphers := assigned[letter]
+		switch {
+		case len(ciphers) > 1:
+			taken = append(taken, pickerEntry(letter, ciphers)+" (conflict)")
+		case !m.pickable(assigned, letter):
+			taken = append(taken, pickerEntry(letter, ciphers))
+		default:
+			free = append(free, string(letter))
+		}
+	}
+
+	text := fmt.Sprintf("Picking a letter for cipher %c.", m.cursorCipher())
+	if m.game.picker.selected != 0 {
+		text += fmt.Sprintf(" Selected: %c.", m.game.picker.selected)
+	}
+	if len(free) > 0 {
+		text += " Free: " + strings.Join(free, " ") + "."
+	}
+	if len(taken) > 0 {
+		text += " Taken: " + strings.Join(taken, ", ") + "."
+	}
+	return text + " Left and right move between free letters."
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+func TestPicker_PicksFreeLetter(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.game.cells[puzzle.NextLetterCell(m.game.cells, m.game.cursorPos)].Input = 'A' // cipher B is A
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyTab})
+	if !m.pickerOpen() || m.game.picker.selected != 'B' {
+		t.Fatalf("picker = %+v, want it open on B, the first free letter", m.game.picker)
+	}
+	panel := ansi.Strip(m.renderPicker())
+	if !strings.Contains(panel, "Letter for cipher A:") || !strings.Contains(panel, "A=B") {
+		t.Errorf("panel should name the cipher letter and show A taken by B:\n%s", panel)
+	}
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyLeft})
+	if m.game.picker.selected != 'Z' {
+		t.Errorf("selected = %c after left, want Z: A is taken, so it wraps past it", m.game.picker.selected)
+	}
+	m = typeLetter(t, m, 'a')
+	if m.game.picker.selected != 'Z' {
+		t.Errorf("selected = %c after typing a taken letter, want it unchanged", m.game.picker.selected)
+	}
+	m = typeLetter(t, m, 'q')
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.pickerOpen() || m.state != StatePlaying {
+		t.Fatalf("state = %v, picker open %v; want the picker closed and play going on", m.state, m.pickerOpen())
+	}
+	if m.game.cells[0].Input != 'Q' {
+		t.Errorf("cipher A input = %q, want Q from the picker", m.game.cells[0].Input)
+	}
+}
+
+func TestPicker_ShowsConflicts(t *testing.T) {
+	m := revealModel(nil, 0)
+	for i := range m.game.cells {
+		if m.game.cells[i].Kind == puzzle.CellLetter {
+			m.game.cells[i].Input = 'E'
+		}
+	}
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyTab})
+	if m.game.picker.selected != 'A' {
+		t.Errorf("selected = %c, want A: the cell's own E conflicts", m.game.picker.selected)
+	}
+	if panel := ansi.Strip(m.renderPicker()); !strings.Contains(panel, "E=A,B") {
+		t.Errorf("panel should show E assigned to both cipher letters:\n%s", panel)
+	}
+	if text := m.accessiblePicker(); !strings.Contains(text, "Taken: E=A,B (conflict).") {
+		t.Errorf("accessiblePicker() = %q, want the conflict named", text)
+	}
+}
+
+func TestPicker_CountsClues(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.game.puzzle = &api.Puzzle{ID: "game-001"}
+	m.game.cells = puzzle.BuildCells("AB, BA", map[rune]rune{'B': 'T'})
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyTab})
+	m = typeLetter(t, m, 't')
+	if m.game.picker.selected == 'T' {
+		t.Error("T is a clue for cipher B and should not be pickable")
+	}
+}
+
+func TestPicker_EscClosesWithoutQuitting(t *testing.T) {
+	m := revealModel(nil, 0)
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyTab})
+	if items := m.helpItems(); len(items) != 2 || items[0] != helpPick {
+		t.Errorf("help = %v, want the picker's own keys", items)
+	}
+	m, cmd := press(t, m, tea.KeyPressMsg{Code: tea.KeyEsc})
+	if m.pickerOpen() || cmd != nil {
+		t.Errorf("picker open %v, cmd %v; want Esc to close the picker only", m.pickerOpen(), cmd)
+	}
+}
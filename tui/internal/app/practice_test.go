@@ -0,0 +1,66 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// practiceModel creates a playing Model for a registered player, in practice
+// mode or not.
+func practiceModel(practice bool) Model {
+	cells := puzzle.BuildCells("AB", nil)
+	return Model{
+		state:     StatePlaying,
+		opts:      Options{Practice: practice, Random: practice},
+		claimCode: "TEST-CODE-1234",
+		game:      gameModel{puzzle: &api.Puzzle{ID: "game-001", EncryptedText: "AB"}, cells: cells, cursorPos: puzzle.FirstLetterCell(cells), startTime: time.Now()},
+		width:     80,
+		height:    40,
+	}
+}
+
+func TestHeaderTitle_LabelsPractice(t *testing.T) {
+	if got := practiceModel(true).renderHeader(); !strings.Contains(got, "PRACTICE") {
+		t.Errorf("practice header = %q, want it to say PRACTICE", got)
+	}
+	if got := practiceModel(false).renderHeader(); strings.Contains(got, "PRACTICE") {
+		t.Errorf("daily header = %q, should not say PRACTICE", got)
+	}
+}
+
+func TestRecordsStats(t *testing.T) {
+	if !practiceModel(false).recordsStats() {
+		t.Error("a registered player's daily solves should be recorded")
+	}
+	if practiceModel(true).recordsStats() {
+		t.Error("practice solves must never be recorded")
+	}
+}
+
+func TestPracticeSolve_SavedOutsideDailyHistory(t *testing.T) {
+	storagetest.UseMemory(t)
+
+	_, cmd := practiceModel(true).handleSolutionChecked(solutionCheckedMsg{correct: true})
+	if cmd == nil {
+		t.Fatal("a practice solve should still be saved")
+	}
+	// Only the save runs; with nothing to record the command is not a batch
+	if msg := cmd(); msg != nil {
+		t.Errorf("practice solve command returned %T, want only the local save", msg)
+	}
+
+	practice, err := storage.Practice.LoadSession("game-001")
+	if err != nil || practice == nil || !practice.Solved {
+		t.Errorf("Practice.LoadSession() = %v, %v; want the solved practice session", practice, err)
+	}
+	daily, err := storage.LoadSession("game-001")
+	if err != nil || daily != nil {
+		t.Errorf("LoadSession() = %v, %v; practice solves must not reach the daily history", daily, err)
+	}
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/clock"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// The sample screens are fixed models of a game in progress, a stats panel
+// and a claim-code card. 'unquote theme preview' renders them to compare
+// themes, and the golden-frame tests render the same models.
+
+// sampleNow is when every sample screen is rendered.
+var sampleNow = time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+
+// sampleClaimCode is the claim code the sample screens show.
+const sampleClaimCode = "TIGER-MAPLE-7492"
+
+// PreviewScreen is one rendered sample screen and what it shows.
+type PreviewScreen struct {
+	Title   string
+	Content string
+}
+
+// PreviewScreens renders the sample board, stats panel and claim-code card
+// at width x height in the current ui palette.
+func PreviewScreens(width, height int) []PreviewScreen {
+	screens := []struct {
+		title string
+		model Model
+	}{
+		{"Board", samplePlaying()},
+		{"Stats", sampleStatsPanel()},
+		{"Claim code", sampleClaimCodeCard()},
+	}
+	rendered := make([]PreviewScreen, 0, len(screens))
+	for _, s := range screens {
+		rendered = append(rendered, PreviewScreen{Title: s.title, Content: s.model.renderAt(width, height)})
+	}
+	return rendered
+}
+
+// renderAt renders m's screen in a terminal of width x height.
+func (m Model) renderAt(width, height int) string {
+	model, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return model.(Model).View().Content
+}
+
+// sampleModel returns a model on a fake clock at sampleNow, in UTC, with
+// the status bar online.
+func sampleModel(state State) Model {
+	return Model{
+		state:      state,
+		opts:       Options{Clock: clock.NewFake(sampleNow)},
+		cfg:        &config.Config{Timezone: "UTC"},
+		connection: connOnline,
+	}
+}
+
+// samplePlaying is a puzzle part way through: one hint, the first two words
+// typed and the cursor on the next letter.
+func samplePlaying() Model {
+	m := sampleModel(StatePlaying)
+	m.game.puzzle = &api.Puzzle{
+		ID:            "game-0120",
+		Date:          "2026-01-20",
+		EncryptedText: "XLI UYMGO FVSAR JSB NYQTW SZIV XLI PEDC HSK",
+		Author:        "Typing Practice",
+		Category:      "Wisdom",
+		Difficulty:    42,
+		Hints:         []api.Hint{{CipherLetter: "X", PlainLetter: "T"}},
+	}
+	m.game.cells = puzzle.BuildCells(m.game.puzzle.EncryptedText, map[rune]rune{'X': 'T'})
+	for i, r := range "THE QUICK" {
+		if m.game.cells[i].Kind == puzzle.CellLetter {
+			puzzle.SetInput(m.game.cells, i, r)
+		}
+	}
+	m.game.cursorPos = puzzle.NextLetterCell(m.game.cells, 8)
+	m.game.startTime = sampleNow.Add(-94 * time.Second)
+	return m
+}
+
+// sampleStatsPanel is a registered player's stats with a week of solve times.
+func sampleStatsPanel() Model {
+	best, avg, clean := 128000.0, 195000.0, 30
+	m := sampleModel(StateStats)
+	m.claimCode = sampleClaimCode
+	m.stats.player = &api.PlayerStatsResponse{
+		ClaimCode:     m.claimCode,
+		GamesPlayed:   42,
+		GamesSolved:   40,
+		WinRate:       0.952,
+		CurrentStreak: 5,
+		BestStreak:    12,
+		BestTime:      &best,
+		AverageTime:   &avg,
+		CleanSolves:   &clean,
+		RecentSolves: []api.RecentSolve{
+			{Date: "2026-01-16", CompletionTime: 240000},
+			{Date: "2026-01-17", CompletionTime: 150000},
+			{Date: "2026-01-18", CompletionTime: 210000},
+			{Date: "2026-01-19", CompletionTime: 195000},
+			{Date: "2026-01-20", CompletionTime: 128000},
+		},
+	}
+	return m
+}
+
+// sampleClaimCodeCard is the card shown after registering.
+func sampleClaimCodeCard() Model {
+	m := sampleModel(StateClaimCodeDisplay)
+	m.claimCode = sampleClaimCode
+	return m
+}
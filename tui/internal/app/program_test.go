@@ -0,0 +1,120 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// harnessPlayer saves a config registered to the stub API's claim code.
+func harnessPlayer(t *testing.T) {
+	t.Helper()
+	if err := config.Save(&config.Config{ClaimCode: harnessCode, StatsEnabled: true}); err != nil {
+		t.Fatalf("saving config: %v", err)
+	}
+}
+
+// waitUntil polls cond until it holds, failing with what after harnessWait.
+func waitUntil(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(harnessWait)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestProgram_OnboardingRegistersAndPlays(t *testing.T) {
+	harnessHomes(t)
+	clk := harnessClock()
+	newStubAPI(t, clk)
+
+	h := runProgram(t, Options{Clock: clk})
+	h.WaitFor("Track my stats?")
+	h.Type("y")
+	h.WaitFor(harnessCode)
+	h.WaitFor("Press any key to continue")
+	h.Type(" ")
+	h.WaitFor("[Tab] Skip tutorial")
+	h.Type("\t")
+	h.WaitFor("[Enter] Submit")
+
+	m := h.Quit()
+	if m.claimCode != harnessCode || m.state != StatePlaying {
+		t.Errorf("claim code %q in state %d, want %q while playing", m.claimCode, m.state, harnessCode)
+	}
+	cfg, err := config.Load()
+	if err != nil || cfg == nil || cfg.ClaimCode != harnessCode || !cfg.StatsEnabled {
+		t.Errorf("config.Load() = %+v, %v; want the registered claim code with stats on", cfg, err)
+	}
+}
+
+func TestProgram_SolveRecordsSession(t *testing.T) {
+	harnessHomes(t)
+	harnessPlayer(t)
+	clk := harnessClock()
+	srv := newStubAPI(t, clk)
+
+	h := runProgram(t, Options{Clock: clk})
+	h.WaitFor("[Enter] Submit")
+	h.Type("ab")
+	waitUntil(t, "the timer to tick", func() bool { return clk.Waiting() > 0 })
+	clk.Advance(94 * time.Second)
+	h.WaitFor("Time: 01:34")
+	h.Type("\r")
+	h.WaitFor("Congratulations! You solved it in 01:34!")
+	waitUntil(t, "the solve to be recorded and marked uploaded", func() bool {
+		session, err := storage.LoadSession("game-1")
+		return err == nil && session != nil && session.Solved && session.Uploaded
+	})
+
+	if m := h.Quit(); m.state != StateSolved {
+		t.Errorf("state = %d, want StateSolved", m.state)
+	}
+	if got := srv.recorded.Load(); got != 1 {
+		t.Errorf("sessions recorded = %d, want 1", got)
+	}
+}
+
+func TestProgram_ErrorRetryLoadsPuzzle(t *testing.T) {
+	harnessHomes(t)
+	harnessPlayer(t)
+	clk := harnessClock()
+	srv := newStubAPI(t, clk)
+	srv.failLoads.Store(1)
+
+	h := runProgram(t, Options{Clock: clk})
+	h.WaitFor("Error:")
+	h.Type("r")
+	h.WaitFor("[Enter] Submit")
+
+	h.Quit()
+	if got := srv.loads.Load(); got != 2 {
+		t.Errorf("puzzle loads = %d, want the failed one and the retry", got)
+	}
+}
+
+func TestProgram_StatsAfterSolve(t *testing.T) {
+	harnessHomes(t)
+	harnessPlayer(t)
+	clk := harnessClock()
+	newStubAPI(t, clk)
+
+	h := runProgram(t, Options{Clock: clk})
+	h.WaitFor("[Enter] Submit")
+	h.Type("ab\r")
+	h.WaitFor("[s] Stats")
+	h.Type("s")
+	h.WaitFor("Games Played    12")
+	h.WaitFor("Win Rate        91.7%")
+	h.Type("b")
+	h.WaitFor("Congratulations!")
+
+	if m := h.Quit(); m.stats.player == nil || m.stats.player.CurrentStreak != 4 {
+		t.Errorf("stats = %+v, want the stub API's", m.stats.player)
+	}
+}
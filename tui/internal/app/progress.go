@@ -0,0 +1,11 @@
+package app
+
+import (
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// renderProgress renders the line under the timer: a bar of the letters
+// filled in and how many words are complete.
+func (m Model) renderProgress() string {
+	return ui.RenderProgress(ui.MeasureProgress(m.game.cells), m.width)
+}
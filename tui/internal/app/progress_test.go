@@ -0,0 +1,25 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+func TestProgress_FollowsTyping(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.game.cells = puzzle.BuildCells("QWE RT", nil)
+	m.game.cursorPos = 4
+
+	m = typeLetter(t, m, 'o')
+	m = typeLetter(t, m, 'f')
+	if got, want := ansi.Strip(m.renderProgress()), "2/5 letters · 1/2 words (40%)"; !strings.HasSuffix(got, want) {
+		t.Errorf("renderProgress() = %q, want it to end in %q", got, want)
+	}
+	if screen := ansi.Strip(m.viewPlayingAccessible()); !strings.Contains(screen, "Progress: 2/5 letters · 1/2 words (40%)") {
+		t.Errorf("accessible view should read out the progress:\n%s", screen)
+	}
+}
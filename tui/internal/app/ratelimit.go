@@ -0,0 +1,70 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/clock"
+)
+
+// Waits before retrying a rate-limited request: the default when the server
+// doesn't send Retry-After, and the longest the screen will count down.
+const (
+	defaultRateLimitWait = 5 * time.Second
+	maxRateLimitWait     = 2 * time.Minute
+)
+
+// rateLimitWait returns how long to wait before retrying after err, and
+// whether err was a rate limit at all.
+func rateLimitWait(err error) (time.Duration, bool) {
+	var limited *api.RateLimitedError
+	if !errors.As(err, &limited) {
+		return 0, false
+	}
+	if limited.RetryAfter <= 0 {
+		return defaultRateLimitWait, true
+	}
+	return min(limited.RetryAfter, maxRateLimitWait), true
+}
+
+// retryTickCmd ticks once a second while counting down to an automatic retry.
+func retryTickCmd(clk clock.Clock) tea.Cmd {
+	return func() tea.Msg {
+		return retryTickMsg(<-clk.After(time.Second))
+	}
+}
+
+// busyMessage describes the countdown to the next retry, e.g.
+// "Server busy — retrying in 12s".
+func busyMessage(remaining time.Duration) string {
+	seconds := time.Duration(math.Ceil(remaining.Seconds())) * time.Second
+	return fmt.Sprintf("Server busy — retrying in %s", max(seconds, time.Second))
+}
+
+// handleRateLimited shows the error screen counting down to an automatic
+// retry of the request the server turned away.
+func (m Model) handleRateLimited(wait time.Duration) (tea.Model, tea.Cmd) {
+	m.state = StateError
+	m.retryAt = m.clock().Now().Add(wait)
+	m.errs.load = busyMessage(wait)
+	return m, retryTickCmd(m.clock())
+}
+
+// handleRetryTick updates the countdown and retries once it runs out. Ticks
+// left over after the player retried or moved on are ignored.
+func (m Model) handleRetryTick(msg retryTickMsg) (tea.Model, tea.Cmd) {
+	if m.state != StateError || m.retryAt.IsZero() {
+		return m, nil
+	}
+	remaining := m.retryAt.Sub(time.Time(msg))
+	if remaining > 0 {
+		m.errs.load = busyMessage(remaining)
+		return m, retryTickCmd(m.clock())
+	}
+	return m.retry()
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+)
+
+func TestRateLimitWait(t *testing.T) {
+	tests := []struct {
+		err    error
+		want   time.Duration
+		wantOK bool
+	}{
+		{err: fmt.Errorf("fetch: %w", &api.RateLimitedError{RetryAfter: 12 * time.Second}), want: 12 * time.Second, wantOK: true},
+		{err: &api.RateLimitedError{}, want: defaultRateLimitWait, wantOK: true},
+		{err: &api.RateLimitedError{RetryAfter: time.Hour}, want: maxRateLimitWait, wantOK: true},
+		{err: &api.APIError{Status: 500}},
+		{err: errors.New("boom")},
+	}
+	for _, tt := range tests {
+		got, ok := rateLimitWait(tt.err)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("rateLimitWait(%v) = %v, %v; want %v, %v", tt.err, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRateLimited_CountsDownAndRetries(t *testing.T) {
+	m := rolloverModel(t)
+	m.state = StateLoading
+
+	model, cmd := m.Update(errMsg{err: &api.RateLimitedError{RetryAfter: 12 * time.Second}})
+	m = model.(Model)
+	if m.state != StateError || cmd == nil {
+		t.Fatalf("state = %v, want the error screen with a countdown", m.state)
+	}
+	if view := ansi.Strip(m.viewError()); !strings.Contains(view, "Server busy — retrying in 12s") || strings.Contains(view, "Error:") {
+		t.Errorf("error screen should count down instead of showing the error:\n%s", view)
+	}
+
+	model, cmd = m.Update(retryTickMsg(m.retryAt.Add(-5 * time.Second)))
+	m = model.(Model)
+	if m.state != StateError || cmd == nil || m.errs.load != "Server busy — retrying in 5s" {
+		t.Errorf("tick should update the countdown, got %q", m.errs.load)
+	}
+
+	model, cmd = m.Update(retryTickMsg(m.retryAt))
+	m = model.(Model)
+	if m.state != StateLoading || cmd == nil || !m.retryAt.IsZero() {
+		t.Errorf("the countdown should end in a retry, got state %v", m.state)
+	}
+
+	// A tick left over from the countdown does nothing once it's over
+	if _, cmd := m.Update(retryTickMsg(time.Now())); cmd != nil {
+		t.Error("a stale retry tick should be ignored")
+	}
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// offersRating reports whether the solved screen asks how hard the puzzle
+// felt: once, right after the player solved it here, for puzzles that count
+// toward their stats.
+func (m Model) offersRating() bool {
+	return m.state == StateSolved && m.game.freshSolve && !m.game.revealed && m.game.puzzle != nil &&
+		m.recordsStats() && m.game.rating == 0
+}
+
+// rateDifficultyCmd creates a command that sends the player's rating, or
+// queues it for the next reconciliation when the server can't take it.
+func (m Model) rateDifficultyCmd(rating int) tea.Cmd {
+	client := m.client
+	r := storage.Rating{RatedAt: m.clock().Now(), GameID: m.game.puzzle.ID, Rating: rating}
+	return func() tea.Msg {
+		if err := client.RateDifficulty(r.GameID, r.Rating); err == nil {
+			return difficultyRatedMsg{gameID: r.GameID, rating: r.Rating}
+		}
+		if err := storage.QueueRating(r); err != nil {
+			return difficultyRatedMsg{err: err, gameID: r.GameID}
+		}
+		return difficultyRatedMsg{gameID: r.GameID, rating: r.Rating, queued: true}
+	}
+}
+
+// handleDifficultyRated says where the rating went in a toast. A rating that
+// couldn't be kept at all is asked for again.
+func (m Model) handleDifficultyRated(msg difficultyRatedMsg) (tea.Model, tea.Cmd) {
+	if m.game.puzzle == nil || msg.gameID != m.game.puzzle.ID {
+		return m, nil
+	}
+
+	switch {
+	case msg.err != nil:
+		m.game.rating = 0
+		return m.notify(toastError, "Couldn't save rating: "+msg.err.Error())
+	case msg.queued:
+		return m.notify(toastInfo, "Rating saved; it will be sent when you're back online")
+	default:
+		return m.notify(toastSuccess, "Thanks for rating!")
+	}
+}
+
+// renderRatingPrompt asks how hard the solve felt, until the player answers
+// or moves on.
+func (m Model) renderRatingPrompt() string {
+	if !m.offersRating() {
+		return ""
+	}
+	return ui.TimerStyle.Render(fmt.Sprintf("How hard did this feel? Press %d (easy) to %d (very hard)",
+		api.MinDifficultyRating, api.MaxDifficultyRating))
+}
+
+// sendQueuedRatings sends the ratings queued while the server was out of
+// reach. Ratings for games the server doesn't know are dropped; other
+// failures wait for the next reconciliation.
+func sendQueuedRatings(client *api.Client) {
+	ratings, err := storage.PendingRatings()
+	if err != nil {
+		return
+	}
+	for _, r := range ratings {
+		if err := client.RateDifficulty(r.GameID, r.Rating); err != nil && !errors.Is(err, api.ErrNotFound) {
+			continue
+		}
+		_ = storage.RemoveRating(r.GameID)
+	}
+}
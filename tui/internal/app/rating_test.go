@@ -0,0 +1,143 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// ratingClient returns a client for an API that answers rating posts with
+// status, and the ratings it was sent by game ID.
+func ratingClient(t *testing.T, status int) (*api.Client, map[string]int) {
+	t.Helper()
+	got := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gameID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/game/"), "/rating")
+		if r.Method != http.MethodPost || !ok {
+			t.Errorf("request = %s %s, want a rating", r.Method, r.URL)
+		}
+		if status != http.StatusCreated {
+			w.WriteHeader(status)
+			return
+		}
+		var req api.RateDifficultyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding rating: %v", err)
+		}
+		got[gameID] = req.Rating
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	client, err := api.NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, got
+}
+
+// freshlySolved returns a puzzle the registered player just solved, rated
+// through client.
+func freshlySolved(t *testing.T, client *api.Client) Model {
+	t.Helper()
+	m := rolloverModel(t)
+	m.client = client
+	m.claimCode = "TEST-CODE-1234"
+	m.state = StateSolved
+	m.game.freshSolve = true
+	return m
+}
+
+// pressRating presses key on the solved screen and delivers the rating.
+func pressRating(t *testing.T, m Model, key rune) Model {
+	t.Helper()
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: key, Text: string(key)})
+	if cmd == nil {
+		t.Fatalf("%c on the solved screen should rate the puzzle", key)
+	}
+	model, _ = model.(Model).Update(cmd())
+	return model.(Model)
+}
+
+func TestRating_Sent(t *testing.T) {
+	storagetest.UseMemory(t)
+	client, got := ratingClient(t, http.StatusCreated)
+	m := freshlySolved(t, client)
+	if view := ansi.Strip(m.viewPlaying()); !strings.Contains(view, "How hard did this feel? Press 1 (easy) to 5 (very hard)") {
+		t.Fatalf("a fresh solve should ask for a rating:\n%s", view)
+	}
+
+	m = pressRating(t, m, '4')
+	if got["game-0120"] != 4 || m.game.rating != 4 || toastText(m) != "Thanks for rating!" {
+		t.Errorf("sent %v, rating %d, toast %q; want 4 sent", got, m.game.rating, toastText(m))
+	}
+	if prompt := m.renderRatingPrompt(); prompt != "" {
+		t.Errorf("the prompt should go once rated, got %q", prompt)
+	}
+	if _, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: '2', Text: "2"}); cmd != nil {
+		t.Error("a puzzle should only be rated once")
+	}
+}
+
+func TestRating_QueuedOffline(t *testing.T) {
+	storagetest.UseMemory(t)
+	down, _ := ratingClient(t, http.StatusServiceUnavailable)
+	m := pressRating(t, freshlySolved(t, down), '2')
+	if !strings.Contains(toastText(m), "sent when you're back online") {
+		t.Errorf("toast = %q, want the rating queued", toastText(m))
+	}
+	pending, err := storage.PendingRatings()
+	if err != nil || len(pending) != 1 || pending[0].GameID != "game-0120" || pending[0].Rating != 2 {
+		t.Fatalf("PendingRatings() = %+v, %v; want the rating queued", pending, err)
+	}
+
+	up, got := ratingClient(t, http.StatusCreated)
+	sendQueuedRatings(up)
+	if got["game-0120"] != 2 {
+		t.Errorf("sent %v, want the queued rating", got)
+	}
+	if pending, _ := storage.PendingRatings(); len(pending) != 0 {
+		t.Errorf("PendingRatings() = %+v after sending, want none", pending)
+	}
+}
+
+func TestRating_DropsUnknownGames(t *testing.T) {
+	storagetest.UseMemory(t)
+	if err := storage.QueueRating(storage.Rating{GameID: "game-gone", Rating: 3}); err != nil {
+		t.Fatal(err)
+	}
+	client, _ := ratingClient(t, http.StatusNotFound)
+	sendQueuedRatings(client)
+	if pending, _ := storage.PendingRatings(); len(pending) != 0 {
+		t.Errorf("PendingRatings() = %+v, want a rating for an unknown game dropped", pending)
+	}
+}
+
+func TestRating_NotOffered(t *testing.T) {
+	tests := []struct {
+		change func(*Model)
+		name   string
+	}{
+		{name: "unregistered", change: func(m *Model) { m.claimCode = "" }},
+		{name: "revealed", change: func(m *Model) { m.game.revealed = true }},
+		{name: "restored", change: func(m *Model) { m.game.freshSolve = false }},
+		{name: "practice", change: func(m *Model) { m.opts.Practice = true }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := freshlySolved(t, newTestClient(t))
+			tt.change(&m)
+			if _, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: '3', Text: "3"}); cmd != nil || m.renderRatingPrompt() != "" {
+				t.Error("the puzzle shouldn't be offered for rating")
+			}
+		})
+	}
+}
@@ -1,12 +1,20 @@
 package app
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/clock"
 	"github.com/bojanrajkovic/unquote/tui/internal/config"
 	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
 )
 
 // newTestClient returns an API client pointed at a local stub URL.
@@ -29,8 +37,7 @@ func TestAC3_1_SolveWithClaimCode_BatchesRecordCmd(t *testing.T) {
 		state:     StateChecking,
 		claimCode: "TIGER-MAPLE-7492",
 		client:    client,
-		puzzle:    &api.Puzzle{ID: "game-001"},
-		cells:     []puzzle.Cell{{Kind: puzzle.CellLetter, Char: 'A', Input: 'B'}},
+		game:      gameModel{puzzle: &api.Puzzle{ID: "game-001"}, cells: []puzzle.Cell{{Kind: puzzle.CellLetter, Char: 'A', Input: 'B'}}},
 	}
 
 	resultModel, cmd := m.handleSolutionChecked(solutionCheckedMsg{correct: true})
@@ -101,8 +108,7 @@ func TestAC3_4_SolveWithoutClaimCode_NoRecordCmd(t *testing.T) {
 		state:     StateChecking,
 		claimCode: "",
 		client:    client,
-		puzzle:    &api.Puzzle{ID: "game-002"},
-		cells:     []puzzle.Cell{{Kind: puzzle.CellLetter, Char: 'A', Input: 'B'}},
+		game:      gameModel{puzzle: &api.Puzzle{ID: "game-002"}, cells: []puzzle.Cell{{Kind: puzzle.CellLetter, Char: 'A', Input: 'B'}}},
 	}
 
 	resultModel, cmd := m.handleSolutionChecked(solutionCheckedMsg{correct: true})
@@ -183,3 +189,98 @@ func TestAC5_5_ReconciliationDoneMsg_IsNoOp(t *testing.T) {
 		t.Error("cmd: want nil (no action on reconciliationDoneMsg), got non-nil")
 	}
 }
+
+// Reconciling a legacy session without solved_at sends the time it was last
+// saved, so the server doesn't stamp the old solve with today's date.
+func TestReconcileSessions_LegacySessionSendsSavedAt(t *testing.T) {
+	sessions := storagetest.UseMemory(t)
+
+	legacy := &storage.GameSession{
+		SavedAt:        time.Date(2026, 1, 15, 21, 30, 0, 0, time.UTC),
+		Inputs:         map[string]string{},
+		GameID:         "legacy-game",
+		CompletionTime: time.Minute,
+		Solved:         true,
+	}
+	if err := sessions.SaveSession(storage.Daily, legacy); err != nil {
+		t.Fatal(err)
+	}
+
+	var got api.RecordSessionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	client, err := api.NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := reconcileSessionsCmd(client, clock.System, "TIGER-MAPLE-7492", false)()
+	if done, ok := msg.(reconciliationDoneMsg); !ok || done.pending != 0 {
+		t.Fatalf("reconcile = %#v, want nothing left pending", msg)
+	}
+	if got.GameID != "legacy-game" || got.SolvedAt != "2026-01-15T21:30:00Z" {
+		t.Errorf("recorded %+v, want the legacy session with its saved time", got)
+	}
+}
+
+// With attempts on, reconciliation reports unsolved daily puzzles the player
+// touched, once each, and leaves untouched and solved ones alone.
+func TestReconcileSessions_ReportsAttempts(t *testing.T) {
+	storagetest.UseMemory(t)
+
+	for _, s := range []*storage.GameSession{
+		{GameID: "started", Inputs: map[string]string{"X": "A"}},
+		{GameID: "revealed", Revealed: true},
+		{GameID: "untouched"},
+		{GameID: "solved", Inputs: map[string]string{"X": "A"}, Solved: true, Uploaded: true},
+	} {
+		if err := storage.SaveSession(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []api.RecordAttemptRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req api.RecordAttemptRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		got = append(got, req)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	client, err := api.NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reconcileSessionsCmd(client, clock.System, "TIGER-MAPLE-7492", true)()
+	slices.SortFunc(got, func(a, b api.RecordAttemptRequest) int { return strings.Compare(a.GameID, b.GameID) })
+	if len(got) != 2 || got[0].GameID != "revealed" || !got[0].Revealed || got[1].GameID != "started" || got[1].Revealed {
+		t.Fatalf("attempts sent = %+v, want the revealed and started puzzles", got)
+	}
+
+	got = nil
+	reconcileSessionsCmd(client, clock.System, "TIGER-MAPLE-7492", true)()
+	if len(got) != 0 {
+		t.Errorf("second reconciliation sent %+v, want nothing new", got)
+	}
+}
+
+func TestReportsAttempts(t *testing.T) {
+	tests := []struct {
+		cfg  *config.Config
+		want bool
+	}{
+		{nil, false},
+		{&config.Config{StatsEnabled: false}, false},
+		{&config.Config{StatsEnabled: true}, true},
+		{&config.Config{StatsEnabled: true, SkipAttempts: true}, false},
+	}
+	for _, tt := range tests {
+		if got := (Model{cfg: tt.cfg}).reportsAttempts(); got != tt.want {
+			t.Errorf("reportsAttempts() with %+v = %v, want %v", tt.cfg, got, tt.want)
+		}
+	}
+}
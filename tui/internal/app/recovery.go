@@ -0,0 +1,113 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// offersRecovery reports whether the game saved when unquote last crashed is
+// offered back before this run's puzzle loads. Runs with a puzzle of their
+// own (a custom puzzle, a pack or a duel) leave it for a later start.
+func (m Model) offersRecovery() bool {
+	r := m.opts.Recovery
+	return r != nil && r.Restorable() && !m.opts.SafeMode &&
+		m.opts.Local == nil && m.opts.Pack == nil && m.opts.Duel == ""
+}
+
+// restoreRecoveryCmd creates a command that puts the recovered game back
+// among its namespace's sessions, where loading the puzzle picks it up, and
+// clears the recovery file. Best-effort like every session save.
+func restoreRecoveryCmd(r *storage.Recovery) tea.Cmd {
+	return func() tea.Msg {
+		session := r.Session
+		_ = r.Namespace.SaveSession(&session)
+		_ = storage.ClearRecovery()
+		return nil
+	}
+}
+
+// clearRecoveryCmd creates a command that drops the recovery file after the
+// player turned it down.
+func clearRecoveryCmd() tea.Cmd {
+	return func() tea.Msg {
+		_ = storage.ClearRecovery()
+		return nil
+	}
+}
+
+// handleRecoveryKeyMsg restores the recovered game on y or Enter, and drops
+// it for this run's own puzzle on n.
+func (m Model) handleRecoveryKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		return m.restoreRecovery()
+	case "n":
+		m.opts.Recovery = nil
+		m.state = StateLoading
+		m, fetch := m.fetch()
+		return m, tea.Batch(clearRecoveryCmd(), fetch)
+	}
+	return m, nil
+}
+
+// restoreRecovery plays the recovered game instead of this run's puzzle. A
+// daily puzzle is loaded by date, so the offline cache can stand in for the
+// API; anything else by game ID.
+func (m Model) restoreRecovery() (tea.Model, tea.Cmd) {
+	r := m.opts.Recovery
+	m.opts.Recovery = nil
+	m.opts.Random = false
+	m.opts.Practice = r.Namespace == storage.Practice
+	m.opts.Date, m.opts.GameID = "", r.Session.GameID
+	if r.Namespace == storage.Daily && r.Session.Date != "" {
+		m.opts.Date, m.opts.GameID = r.Session.Date, ""
+	}
+	m.state = StateLoading
+	// The session must be back in place before the puzzle loads and looks for it
+	m, fetch := m.fetch()
+	return m, tea.Sequence(restoreRecoveryCmd(r), fetch)
+}
+
+// recoveredGame describes the recovered game for the prompt, e.g. "the
+// 2026-10-15 puzzle (3 letters filled in, 01:42 on the clock)".
+func recoveredGame(r *storage.Recovery) string {
+	name := "a puzzle"
+	switch {
+	case r.Namespace == storage.Practice:
+		name = "a practice puzzle"
+	case r.Session.Date != "":
+		name = "the " + r.Session.Date + " puzzle"
+	}
+	if r.Session.Author != "" {
+		name += " by " + ui.SanitizeString(r.Session.Author)
+	}
+	letters := "letters"
+	if len(r.Session.Inputs) == 1 {
+		letters = "letter"
+	}
+	return fmt.Sprintf("%s (%d %s filled in, %s on the clock)", name, len(r.Session.Inputs), letters, formatElapsed(r.Session.ElapsedTime))
+}
+
+// viewRecovery renders the prompt offering back the game saved when unquote
+// last crashed.
+func (m Model) viewRecovery() string {
+	maxWidth := max(m.width-4, 20)
+	text := ui.WordWrapText(fmt.Sprintf("unquote closed unexpectedly while you were playing %s. Pick up where you left off?", recoveredGame(m.opts.Recovery)), maxWidth)
+	note := ui.WordWrapText("If it keeps crashing, start with --safe-mode to skip restoring saved games.", maxWidth)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		m.renderHeader(),
+		"",
+		ui.WarningStyle.Render(text),
+		"",
+		ui.HelpStyle.Render(note),
+		"",
+		ui.HelpStyle.Render(renderHelpItems(m.helpItems())),
+	)
+}
@@ -0,0 +1,178 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// recoveryModel returns a model starting up with r offered back.
+func recoveryModel(t *testing.T, r *storage.Recovery) Model {
+	t.Helper()
+	m := NewWithClient(newTestClient(t))
+	m.opts.Recovery = r
+	m.width, m.height, m.sizeReady = 80, 24, true
+	return m
+}
+
+func dailyRecovery() *storage.Recovery {
+	return &storage.Recovery{
+		Namespace: storage.Daily,
+		Session: storage.GameSession{
+			GameID:      "game-001",
+			Date:        "2026-10-15",
+			Author:      "Ada Lovelace",
+			Inputs:      map[string]string{"X": "T", "Q": "H"},
+			ElapsedTime: 102 * time.Second,
+		},
+	}
+}
+
+func TestPendingSession(t *testing.T) {
+	m := Model{
+		state: StatePlaying,
+		game:  gameModel{puzzle: &api.Puzzle{ID: "game-001", Date: "2026-10-15", EncryptedText: "XQ"}, cells: puzzle.BuildCells("XQ", nil), startTime: time.Now()},
+	}
+	puzzle.SetInput(m.game.cells, 0, 'T')
+
+	namespace, session := m.PendingSession()
+	if namespace != storage.Daily || session == nil {
+		t.Fatalf("PendingSession() = %q, %v; want the daily game in progress", namespace, session)
+	}
+	if session.GameID != "game-001" || session.Date != "2026-10-15" || session.Inputs["X"] != "T" {
+		t.Errorf("PendingSession() session = %+v, want game-001 with X→T", session)
+	}
+
+	for _, state := range []State{StateLoading, StateSolved, StateError} {
+		m.state = state
+		if _, session := m.PendingSession(); session != nil {
+			t.Errorf("PendingSession() in state %d = %+v, want nil with no game in progress", state, session)
+		}
+	}
+	m.state, m.game.revealed = StatePlaying, true
+	if _, session := m.PendingSession(); session != nil {
+		t.Errorf("PendingSession() after revealing = %+v, want nil", session)
+	}
+}
+
+func TestConfigLoaded_OffersRecovery(t *testing.T) {
+	model, _ := recoveryModel(t, dailyRecovery()).handleConfigLoaded(configLoadedMsg{config: &config.Config{}})
+	m := model.(Model)
+	if m.state != StateRecovery {
+		t.Fatalf("state = %d, want StateRecovery", m.state)
+	}
+
+	view := ansi.Strip(m.View().Content)
+	for _, want := range []string{"the 2026-10-15 puzzle by Ada Lovelace", "2 letters filled in", "01:42", "[y] Restore", "[n] Discard"} {
+		if !strings.Contains(strings.Join(strings.Fields(view), " "), want) {
+			t.Errorf("recovery prompt missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestConfigLoaded_SkipsUnofferedRecovery(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(m *Model)
+	}{
+		{name: "safe mode", modify: func(m *Model) { m.opts.SafeMode = true }},
+		{name: "custom puzzle run", modify: func(m *Model) { m.opts.Local = &puzzlegen.Puzzle{} }},
+		{name: "duel", modify: func(m *Model) { m.opts.Duel = "ROOM" }},
+		{name: "custom puzzle recovered", modify: func(m *Model) { m.opts.Recovery.Namespace = storage.Custom }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := recoveryModel(t, dailyRecovery())
+			tt.modify(&m)
+			model, _ := m.handleConfigLoaded(configLoadedMsg{config: &config.Config{}})
+			if got := model.(Model).state; got != StateLoading {
+				t.Errorf("state = %d, want StateLoading without the prompt", got)
+			}
+		})
+	}
+}
+
+func TestRecoveryKeys(t *testing.T) {
+	t.Run("restore daily", func(t *testing.T) {
+		m := recoveryModel(t, dailyRecovery())
+		m.state = StateRecovery
+		m.opts.Random = true
+		model, cmd := m.Update(tea.KeyPressMsg{Code: 'y', Text: "y"})
+		m = model.(Model)
+		if m.state != StateLoading || cmd == nil {
+			t.Fatalf("state = %d, cmd nil = %v; want loading the recovered game", m.state, cmd == nil)
+		}
+		if m.opts.Date != "2026-10-15" || m.opts.GameID != "" || m.opts.Random || m.opts.Recovery != nil {
+			t.Errorf("opts = %+v, want the recovered puzzle's date instead of a random one", m.opts)
+		}
+	})
+
+	t.Run("restore practice", func(t *testing.T) {
+		r := dailyRecovery()
+		r.Namespace = storage.Practice
+		m := recoveryModel(t, r)
+		m.state = StateRecovery
+		model, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+		m = model.(Model)
+		if m.opts.GameID != "game-001" || m.opts.Date != "" || !m.opts.Practice {
+			t.Errorf("opts = %+v, want the practice puzzle by game ID", m.opts)
+		}
+	})
+
+	t.Run("discard", func(t *testing.T) {
+		m := recoveryModel(t, dailyRecovery())
+		m.state = StateRecovery
+		model, cmd := m.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+		m = model.(Model)
+		if m.state != StateLoading || cmd == nil || m.opts.Recovery != nil || m.opts.Date != "" {
+			t.Errorf("state = %d, opts = %+v; want this run's own puzzle loading", m.state, m.opts)
+		}
+	})
+}
+
+func TestRestoreRecoveryCmd(t *testing.T) {
+	setCacheHome(t)
+
+	r := dailyRecovery()
+	if err := storage.SaveRecovery(r); err != nil {
+		t.Fatal(err)
+	}
+	restoreRecoveryCmd(r)()
+
+	session, err := storage.LoadSession("game-001")
+	if err != nil || session == nil || session.Inputs["Q"] != "H" || session.ElapsedTime != 102*time.Second {
+		t.Errorf("LoadSession() = %+v, %v; want the recovered game saved", session, err)
+	}
+	if left, err := storage.LoadRecovery(); err != nil || left != nil {
+		t.Errorf("LoadRecovery() = %v, %v; want the recovery cleared", left, err)
+	}
+}
+
+func TestSafeMode_SkipsSavedSession(t *testing.T) {
+	setCacheHome(t)
+	if err := storage.SaveSession(&storage.GameSession{GameID: "game-001", Inputs: map[string]string{"X": "T"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewWithClient(newTestClient(t))
+	m.opts.SafeMode = true
+	model, _ := m.handlePuzzleFetched(puzzleFetchedMsg{puzzle: &api.Puzzle{ID: "game-001", EncryptedText: "XQ"}})
+	m = model.(Model)
+	if m.state != StatePlaying {
+		t.Fatalf("state = %d, want StatePlaying", m.state)
+	}
+	for _, cell := range m.game.cells {
+		if cell.Input != 0 {
+			t.Errorf("cell %q restored input %q in safe mode, want a fresh start", cell.Char, cell.Input)
+		}
+	}
+}
@@ -24,9 +24,9 @@ func TestRemoteSession_AC31_ShowsSolvedElsewhere(t *testing.T) {
 
 	m := NewWithClient(client)
 	m.state = StatePlaying
-	m.puzzle = &api.Puzzle{ID: "test-game-id"}
+	m.game.puzzle = &api.Puzzle{ID: "test-game-id"}
 	m.claimCode = "ABCD-1234"
-	m.cells = []puzzle.Cell{{Kind: puzzle.CellLetter, Char: 'A'}}
+	m.game.cells = []puzzle.Cell{{Kind: puzzle.CellLetter, Char: 'A'}}
 
 	// Call handleRemoteSession with a session response
 	remoteSession := &api.SessionLookupResponse{
@@ -40,15 +40,15 @@ func TestRemoteSession_AC31_ShowsSolvedElsewhere(t *testing.T) {
 	if m.state != StateSolved {
 		t.Errorf("AC3.1: expected state StateSolved, got %v", m.state)
 	}
-	if !m.solvedElsewhere {
+	if !m.game.solvedElsewhere {
 		t.Errorf("AC3.1: expected solvedElsewhere to be true")
 	}
 	expectedDuration := 53260 * time.Millisecond
-	if m.elapsedAtPause != expectedDuration {
-		t.Errorf("AC3.1: expected elapsedAtPause %v, got %v", expectedDuration, m.elapsedAtPause)
+	if m.game.elapsedAtPause != expectedDuration {
+		t.Errorf("AC3.1: expected elapsedAtPause %v, got %v", expectedDuration, m.game.elapsedAtPause)
 	}
-	if m.statusMsg != "" {
-		t.Errorf("AC3.1: expected empty statusMsg, got %q", m.statusMsg)
+	if len(m.toasts) != 0 {
+		t.Errorf("AC3.1: expected no toast, got %q", lastToast(m))
 	}
 	if cmd != nil {
 		t.Error("AC3.1: expected no command returned")
@@ -80,7 +80,7 @@ func TestRemoteSession_AC32_StatsKeyWorks(t *testing.T) {
 
 	m := NewWithClient(client)
 	m.state = StateSolved
-	m.solvedElsewhere = true
+	m.game.solvedElsewhere = true
 	m.claimCode = "ABCD-1234"
 
 	// Verify renderHelp contains "[s] Stats"
@@ -100,7 +100,7 @@ func TestRemoteSession_AC33_LocalSolveWinsOverRemote(t *testing.T) {
 
 	m := NewWithClient(client)
 	m.state = StateSolved
-	m.solvedElsewhere = false
+	m.game.solvedElsewhere = false
 
 	// Call handleRemoteSession while already locally solved
 	remoteSession := &api.SessionLookupResponse{
@@ -114,7 +114,7 @@ func TestRemoteSession_AC33_LocalSolveWinsOverRemote(t *testing.T) {
 	if m.state != StateSolved {
 		t.Errorf("AC3.3: expected state StateSolved, got %v", m.state)
 	}
-	if m.solvedElsewhere {
+	if m.game.solvedElsewhere {
 		t.Errorf("AC3.3: expected solvedElsewhere to be false (remote result ignored)")
 	}
 	if cmd != nil {
@@ -181,8 +181,8 @@ func TestRemoteSession_AC36_NoClaimCodeSkipsRemoteCheck(t *testing.T) {
 	m := NewWithClient(client)
 	m.state = StatePlaying
 	m.claimCode = "" // No claim code set
-	m.puzzle = &api.Puzzle{ID: "test-game-id"}
-	m.cells = []puzzle.Cell{{Kind: puzzle.CellLetter, Char: 'A'}}
+	m.game.puzzle = &api.Puzzle{ID: "test-game-id"}
+	m.game.cells = []puzzle.Cell{{Kind: puzzle.CellLetter, Char: 'A'}}
 
 	// Call handleSessionLoaded with nil session (fresh load)
 	model, cmd := m.handleSessionLoaded(sessionLoadedMsg{session: nil})
@@ -219,8 +219,8 @@ func TestRemoteSession_AC36_WithClaimCodeFiresRemoteCheck(t *testing.T) {
 	m := NewWithClient(client)
 	m.state = StatePlaying
 	m.claimCode = "ABCD-1234"
-	m.puzzle = &api.Puzzle{ID: "test-game-id"}
-	m.cells = []puzzle.Cell{{Kind: puzzle.CellLetter, Char: 'A'}}
+	m.game.puzzle = &api.Puzzle{ID: "test-game-id"}
+	m.game.cells = []puzzle.Cell{{Kind: puzzle.CellLetter, Char: 'A'}}
 	m.client = client
 
 	// Call handleSessionLoaded with nil session
@@ -263,11 +263,11 @@ func TestRemoteSession_RaceConditionLocalSolveBeforeRemoteCheck(t *testing.T) {
 	m := NewWithClient(client)
 	// Start in playing state (just loaded session, remote check is in flight)
 	m.state = StatePlaying
-	m.solvedElsewhere = false
+	m.game.solvedElsewhere = false
 
 	// Meanwhile, handleSolutionChecked transitions to StateSolved
 	m.state = StateSolved
-	m.elapsedAtPause = 60 * time.Second
+	m.game.elapsedAtPause = 60 * time.Second
 
 	// Then the remote check completes
 	remoteSession := &api.SessionLookupResponse{
@@ -281,11 +281,11 @@ func TestRemoteSession_RaceConditionLocalSolveBeforeRemoteCheck(t *testing.T) {
 	if m.state != StateSolved {
 		t.Errorf("AC3.3 (race): expected state StateSolved, got %v", m.state)
 	}
-	if m.solvedElsewhere {
+	if m.game.solvedElsewhere {
 		t.Errorf("AC3.3 (race): expected solvedElsewhere to be false (local state preserved)")
 	}
-	if m.elapsedAtPause != 60*time.Second {
-		t.Errorf("AC3.3 (race): expected elapsed time to be preserved as 60s, got %v", m.elapsedAtPause)
+	if m.game.elapsedAtPause != 60*time.Second {
+		t.Errorf("AC3.3 (race): expected elapsed time to be preserved as 60s, got %v", m.game.elapsedAtPause)
 	}
 	if cmd != nil {
 		t.Error("AC3.3 (race): expected no command returned")
@@ -363,8 +363,8 @@ func TestRemoteSession_ElapsedConversion(t *testing.T) {
 
 	// 53260 milliseconds -> time.Duration(53260) * time.Millisecond
 	expectedDuration := time.Duration(53260) * time.Millisecond
-	if m.elapsedAtPause != expectedDuration {
-		t.Errorf("expected elapsedAtPause %v, got %v", expectedDuration, m.elapsedAtPause)
+	if m.game.elapsedAtPause != expectedDuration {
+		t.Errorf("expected elapsedAtPause %v, got %v", expectedDuration, m.game.elapsedAtPause)
 	}
 }
 
@@ -378,9 +378,9 @@ func TestRemoteSession_HeaderNotModified(t *testing.T) {
 
 	m := NewWithClient(client)
 	m.state = StatePlaying
-	m.puzzle = &api.Puzzle{ID: "test-game-id", Date: "2026-02-23"}
+	m.game.puzzle = &api.Puzzle{ID: "test-game-id", Date: "2026-02-23"}
 	m.claimCode = "ABCD-1234"
-	m.errorMsg = ""
+	m.errs.load = ""
 	m.width = 80
 	m.height = 24
 
@@ -392,10 +392,10 @@ func TestRemoteSession_HeaderNotModified(t *testing.T) {
 	m = model.(Model)
 
 	// Verify other fields are unchanged
-	if m.puzzle.ID != "test-game-id" {
+	if m.game.puzzle.ID != "test-game-id" {
 		t.Errorf("expected puzzle ID to remain 'test-game-id'")
 	}
-	if m.puzzle.Date != "2026-02-23" {
+	if m.game.puzzle.Date != "2026-02-23" {
 		t.Errorf("expected puzzle Date to remain '2026-02-23'")
 	}
 	if m.claimCode != "ABCD-1234" {
@@ -419,7 +419,7 @@ func TestRemoteSession_MessageIntegration(t *testing.T) {
 
 	m := NewWithClient(client)
 	m.state = StatePlaying
-	m.puzzle = &api.Puzzle{ID: "test-game-id"}
+	m.game.puzzle = &api.Puzzle{ID: "test-game-id"}
 
 	// Create a remoteSessionMsg with a session
 	msg := remoteSessionMsg{
@@ -437,7 +437,7 @@ func TestRemoteSession_MessageIntegration(t *testing.T) {
 	if updatedM.state != StateSolved {
 		t.Errorf("expected state to be StateSolved, got %v", updatedM.state)
 	}
-	if !updatedM.solvedElsewhere {
+	if !updatedM.game.solvedElsewhere {
 		t.Errorf("expected solvedElsewhere to be true")
 	}
 	if cmd != nil {
@@ -455,7 +455,7 @@ func TestRemoteSession_WithNilSessionMessage(t *testing.T) {
 
 	m := NewWithClient(client)
 	m.state = StatePlaying
-	m.puzzle = &api.Puzzle{ID: "test-game-id"}
+	m.game.puzzle = &api.Puzzle{ID: "test-game-id"}
 
 	// Create a remoteSessionMsg with nil session
 	msg := remoteSessionMsg{session: nil}
@@ -468,7 +468,7 @@ func TestRemoteSession_WithNilSessionMessage(t *testing.T) {
 	if updatedM.state != StatePlaying {
 		t.Errorf("expected state to be StatePlaying, got %v", updatedM.state)
 	}
-	if updatedM.solvedElsewhere {
+	if updatedM.game.solvedElsewhere {
 		t.Errorf("expected solvedElsewhere to be false")
 	}
 	if cmd != nil {
@@ -504,7 +504,7 @@ func TestRemoteSession_MultipleRemoteChecks(t *testing.T) {
 	if m.state != StateSolved {
 		t.Errorf("after second check: expected state StateSolved, got %v", m.state)
 	}
-	if !m.solvedElsewhere {
+	if !m.game.solvedElsewhere {
 		t.Errorf("after second check: expected solvedElsewhere to be true")
 	}
 }
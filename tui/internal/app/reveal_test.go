@@ -0,0 +1,167 @@
+package app
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// revealModel creates a playing Model with the given number of failed submissions.
+func revealModel(cfg *config.Config, failedChecks int) Model {
+	cells := puzzle.BuildCells("AB, BA", nil)
+	return Model{
+		state:   StatePlaying,
+		cfg:     cfg,
+		game:    gameModel{puzzle: &api.Puzzle{ID: "game-001", EncryptedText: "AB, BA"}, cells: cells, cursorPos: puzzle.FirstLetterCell(cells), failedChecks: failedChecks, startTime: time.Now()},
+		width:   80,
+		height:  40,
+		ticking: true,
+	}
+}
+
+func TestCanReveal(t *testing.T) {
+	tests := []struct {
+		cfg          *config.Config
+		name         string
+		failedChecks int
+		state        State
+		want         bool
+	}{
+		{name: "below default threshold", failedChecks: 2, state: StatePlaying, want: false},
+		{name: "at default threshold", failedChecks: 3, state: StatePlaying, want: true},
+		{name: "configured threshold", cfg: &config.Config{RevealAfter: 1}, failedChecks: 1, state: StatePlaying, want: true},
+		{name: "zero uses default", cfg: &config.Config{RevealAfter: 0}, failedChecks: 2, state: StatePlaying, want: false},
+		{name: "negative disables", cfg: &config.Config{RevealAfter: -1}, failedChecks: 10, state: StatePlaying, want: false},
+		{name: "not while checking", failedChecks: 3, state: StateChecking, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := revealModel(tt.cfg, tt.failedChecks)
+			m.state = tt.state
+			if got := m.canReveal(); got != tt.want {
+				t.Errorf("canReveal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrongSubmission_OffersRevealAtThreshold(t *testing.T) {
+	m := revealModel(&config.Config{RevealAfter: 2}, 0)
+
+	model, _ := m.handleSolutionChecked(solutionCheckedMsg{correct: false})
+	m = model.(Model)
+	if slices.Contains(m.helpItems(), helpReveal) {
+		t.Error("reveal should not be offered after one failure")
+	}
+
+	model, _ = m.handleSolutionChecked(solutionCheckedMsg{correct: false})
+	m = model.(Model)
+	if m.game.failedChecks != 2 {
+		t.Errorf("failedChecks = %d, want 2", m.game.failedChecks)
+	}
+	if !slices.Contains(m.helpItems(), helpReveal) {
+		t.Error("reveal should be offered after reaching the threshold")
+	}
+	if got := toastText(m); got != "Not quite right. Keep trying, or press Ctrl+V to reveal the answer." {
+		t.Errorf("toast = %q, want the latest verdict, mentioning the reveal key", got)
+	}
+}
+
+func TestRevealKey(t *testing.T) {
+	key := tea.KeyPressMsg{Code: 'v', Mod: tea.ModCtrl}
+
+	model, cmd := revealModel(nil, 0).handleKeyMsg(key)
+	if model.(Model).state != StatePlaying || cmd != nil {
+		t.Error("Ctrl+V before the threshold should do nothing")
+	}
+
+	model, cmd = revealModel(nil, defaultRevealAfter).handleKeyMsg(key)
+	m := model.(Model)
+	if m.state != StateChecking {
+		t.Errorf("state = %v, want StateChecking while the solution is fetched", m.state)
+	}
+	if cmd == nil {
+		t.Error("Ctrl+V after the threshold should fetch the solution")
+	}
+}
+
+func TestHandleSolutionRevealed(t *testing.T) {
+	storagetest.UseMemory(t)
+
+	m := revealModel(nil, defaultRevealAfter)
+	m.state = StateChecking
+	m.loadingMsg = "Revealing solution..."
+
+	model, cmd := m.handleSolutionRevealed(solutionRevealedMsg{solution: "no, on"})
+	m = model.(Model)
+
+	if m.state != StateSolved || !m.game.revealed {
+		t.Errorf("state = %v, revealed = %v; want StateSolved and revealed", m.state, m.game.revealed)
+	}
+	if got := puzzle.AssembleSolution(m.game.cells); got != "NO, ON" {
+		t.Errorf("grid after reveal = %q, want %q", got, "NO, ON")
+	}
+	if slices.Contains(m.helpItems(), helpShare) {
+		t.Error("a revealed puzzle should not offer sharing")
+	}
+
+	if cmd == nil {
+		t.Fatal("reveal should save the session")
+	}
+	cmd()
+
+	session, err := storage.LoadSession("game-001")
+	if err != nil || session == nil {
+		t.Fatalf("LoadSession() = %v, %v; want the revealed session", session, err)
+	}
+	if session.Solved || !session.Revealed {
+		t.Errorf("saved session Solved = %v, Revealed = %v; want unsolved and revealed", session.Solved, session.Revealed)
+	}
+}
+
+func TestHandleSolutionRevealed_MismatchedSolution(t *testing.T) {
+	m := revealModel(nil, defaultRevealAfter)
+	m.state = StateChecking
+
+	model, cmd := m.handleSolutionRevealed(solutionRevealedMsg{solution: "NO"})
+	m = model.(Model)
+
+	if m.state != StatePlaying || m.game.revealed {
+		t.Errorf("state = %v, revealed = %v; want to keep playing", m.state, m.game.revealed)
+	}
+	if toastText(m) == "" {
+		t.Error("a solution that doesn't fit the grid should explain itself")
+	}
+	if cmd != nil {
+		t.Error("nothing should be saved when the reveal fails")
+	}
+}
+
+func TestHandleSessionLoaded_Revealed(t *testing.T) {
+	m := revealModel(nil, 0)
+	session := &storage.GameSession{
+		GameID:      "game-001",
+		Inputs:      map[string]string{"A": "N", "B": "O"},
+		ElapsedTime: 90 * time.Second,
+		Revealed:    true,
+	}
+
+	model, _ := m.handleSessionLoaded(sessionLoadedMsg{session: session})
+	m = model.(Model)
+
+	if m.state != StateSolved || !m.game.revealed {
+		t.Errorf("state = %v, revealed = %v; want the revealed game to stay over", m.state, m.game.revealed)
+	}
+	if m.Elapsed() != 90*time.Second {
+		t.Errorf("Elapsed() = %v, want 1m30s", m.Elapsed())
+	}
+}
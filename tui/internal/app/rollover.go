@@ -0,0 +1,84 @@
+package app
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/cache"
+)
+
+// rolledOver reports whether today's daily puzzle has changed since the one
+// on screen was loaded: whether the date in the player's time zone has moved
+// past the puzzle's own date.
+func (m Model) rolledOver(now time.Time) bool {
+	if !m.playsToday() || m.game.puzzle == nil || m.game.puzzle.Date == "" {
+		return false
+	}
+	// Dates are YYYY-MM-DD, so they order as strings; a puzzle dated ahead of
+	// the local clock is never treated as stale.
+	return cache.Today(now.In(m.location())) > m.game.puzzle.Date
+}
+
+// startTick starts the tick loop, once a second or slower with low bandwidth, unless one is already running,
+// so loading a new puzzle mid-run never doubles it up.
+func (m Model) startTick() (Model, tea.Cmd) {
+	if m.ticking {
+		return m, nil
+	}
+	m.ticking = true
+	return m, tickCmd(m.clock(), m.tickInterval())
+}
+
+// handleTick re-renders the timer while playing, dismisses toasts whose time
+// is up and watches for the daily puzzle rolling over. The solved screen of
+// today's puzzle, and the next-puzzle menu and quote info opened from it,
+// keep ticking until a new puzzle shows up; any screen keeps ticking while a
+// toast is queued.
+func (m Model) handleTick(msg tickMsg) (tea.Model, tea.Cmd) {
+	if !m.game.newPuzzle && m.rolledOver(time.Time(msg)) {
+		m.game.newPuzzle = true
+	}
+	m = m.expireToasts(time.Time(msg))
+	m, save := m.checkIdle(time.Time(msg))
+
+	switch {
+	case m.state == StatePlaying, m.state == StateChecking:
+	case (m.state == StateSolved || m.state == StateNextPuzzle || m.state == StateQuoteInfo) && m.playsToday() && !m.game.newPuzzle:
+	case len(m.toasts) > 0:
+	default:
+		m.ticking = false
+		return m, save
+	}
+	return m, tea.Batch(save, tickCmd(m.clock(), m.tickInterval()))
+}
+
+// newPuzzleNotice returns the prompt shown once a new daily puzzle is out,
+// naming the key that loads it on the current screen.
+func (m Model) newPuzzleNotice() string {
+	if !m.game.newPuzzle {
+		return ""
+	}
+	switch m.state {
+	case StatePlaying:
+		return "A new puzzle is available — press Ctrl+N to load it"
+	case StateSolved:
+		return "A new puzzle is available — press n to play it"
+	default:
+		return ""
+	}
+}
+
+// loadNewPuzzle leaves the current puzzle for the new daily one. An unfinished
+// puzzle's session is saved first, with its time so far.
+func (m Model) loadNewPuzzle() (tea.Model, tea.Cmd) {
+	var save tea.Cmd
+	if m.state == StatePlaying {
+		save = saveSessionCmd(m.sessions(), m.clock().Now(), m.game.puzzle, m.game.cells, m.Elapsed(), m.run, m.game.letters, m.game.assists)
+	}
+
+	m = m.resetGame()
+	m.state = StateLoading
+	m, fetch := m.fetch()
+	return m, tea.Batch(save, fetch)
+}
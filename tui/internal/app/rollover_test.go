@@ -0,0 +1,162 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// rolloverModel creates a Model playing the daily puzzle for 2026-01-20,
+// with the puzzle day following UTC.
+func rolloverModel(t *testing.T) Model {
+	t.Helper()
+	cells := puzzle.BuildCells("AB, BA", nil)
+	return Model{
+		state:   StatePlaying,
+		client:  newTestClient(t),
+		cfg:     &config.Config{Timezone: "UTC"},
+		game:    gameModel{puzzle: &api.Puzzle{ID: "game-0120", EncryptedText: "AB, BA", Date: "2026-01-20"}, cells: cells, cursorPos: puzzle.FirstLetterCell(cells), startTime: time.Now()},
+		ticking: true,
+		width:   80,
+		height:  40,
+	}
+}
+
+// at returns the given UTC time on 2026-01-20 (day 0) or the days after it.
+func at(day, hour int) tickMsg {
+	return tickMsg(time.Date(2026, 1, 20+day, hour, 0, 0, 0, time.UTC))
+}
+
+func TestTick_DetectsRollover(t *testing.T) {
+	m := rolloverModel(t)
+
+	model, cmd := m.handleTick(at(0, 23))
+	m = model.(Model)
+	if m.game.newPuzzle || cmd == nil {
+		t.Fatalf("newPuzzle = %v before midnight UTC, want false and the timer still ticking", m.game.newPuzzle)
+	}
+
+	model, cmd = m.handleTick(at(1, 0))
+	m = model.(Model)
+	if !m.game.newPuzzle || cmd == nil {
+		t.Fatalf("newPuzzle = %v after midnight UTC, want true and the timer still ticking", m.game.newPuzzle)
+	}
+
+	view := ansi.Strip(m.viewPlaying())
+	if !strings.Contains(view, "A new puzzle is available — press Ctrl+N to load it") {
+		t.Errorf("playing view should announce the new puzzle:\n%s", view)
+	}
+	if !slices.Contains(m.helpItems(), helpNewPuzzle) {
+		t.Error("help bar should offer the new puzzle")
+	}
+}
+
+func TestTick_RolloverFollowsTimezone(t *testing.T) {
+	m := rolloverModel(t)
+	m.cfg = &config.Config{Timezone: "America/New_York"}
+
+	// 02:00 UTC on Jan 21 is still Jan 20 in New York
+	model, _ := m.handleTick(at(1, 2))
+	if model.(Model).game.newPuzzle {
+		t.Fatal("the puzzle should not roll over before midnight in the player's zone")
+	}
+	model, _ = m.handleTick(at(1, 5))
+	if !model.(Model).game.newPuzzle {
+		t.Fatal("the puzzle should roll over at midnight in the player's zone")
+	}
+
+	m.duel = duelState{room: "FOX-4821"}
+	if loc := m.location(); loc != time.UTC {
+		t.Errorf("duel location() = %v, want UTC so both players share a puzzle", loc)
+	}
+}
+
+func TestTick_IgnoresRolloverOffToday(t *testing.T) {
+	m := rolloverModel(t)
+	m.opts.Random = true
+
+	model, _ := m.handleTick(at(1, 0))
+	if model.(Model).game.newPuzzle {
+		t.Error("a random puzzle has no newer daily version to offer")
+	}
+}
+
+func TestTick_SolvedScreenWatchesForRollover(t *testing.T) {
+	setCacheHome(t)
+	m := rolloverModel(t)
+	m.state = StateSolved
+
+	model, cmd := m.handleTick(at(0, 12))
+	m = model.(Model)
+	if cmd == nil || !m.ticking {
+		t.Fatal("the solved screen of today's puzzle should keep ticking")
+	}
+
+	model, cmd = m.handleTick(at(1, 0))
+	m = model.(Model)
+	if !m.game.newPuzzle || cmd != nil || m.ticking {
+		t.Errorf("newPuzzle = %v, ticking = %v; want the loop to stop once the new puzzle is found", m.game.newPuzzle, m.ticking)
+	}
+	if view := ansi.Strip(m.viewPlaying()); !strings.Contains(view, "press n to play it") {
+		t.Errorf("solved view should announce the new puzzle:\n%s", view)
+	}
+
+	model, cmd = m.handleKeyMsg(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	model, _ = model.Update(cmd())
+	m = model.(Model)
+	if m.state != StateNextPuzzle || m.nextChoices[0].label != "Today's puzzle" {
+		t.Fatalf("n should offer the new puzzle first, got state %v and %+v", m.state, m.nextChoices)
+	}
+
+	model, _ = m.handleKeyMsg(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m := model.(Model); m.state != StateLoading || m.game.puzzle != nil || m.game.newPuzzle || !m.playsToday() {
+		t.Errorf("Enter should load the new puzzle, got state %v", m.state)
+	}
+}
+
+func TestLoadNewPuzzle_KeepsProgress(t *testing.T) {
+	setCacheHome(t)
+	m := rolloverModel(t)
+
+	// n is a letter while playing
+	model, _ := m.handleKeyMsg(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	m = model.(Model)
+	if m.game.cells[0].Input != 'N' {
+		t.Fatal("n should fill the cell while playing")
+	}
+
+	model, cmd := m.handleKeyMsg(tea.KeyPressMsg{Code: 'n', Mod: tea.ModCtrl})
+	if model.(Model).state != StatePlaying || cmd != nil {
+		t.Error("Ctrl+N should do nothing before a new puzzle is out")
+	}
+
+	m.game.newPuzzle = true
+	model, cmd = m.handleKeyMsg(tea.KeyPressMsg{Code: 'n', Mod: tea.ModCtrl})
+	m = model.(Model)
+	if m.state != StateLoading || m.game.puzzle != nil || m.game.newPuzzle {
+		t.Fatalf("Ctrl+N should load the new puzzle, got state %v", m.state)
+	}
+
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("Ctrl+N returned %T, want the save and the fetch batched", cmd())
+	}
+	for _, c := range batch {
+		c()
+	}
+
+	session, err := storage.Daily.LoadSession("game-0120")
+	if err != nil || session == nil || session.Inputs["A"] != "N" {
+		t.Errorf("LoadSession() = %+v, %v; want the old puzzle's progress saved", session, err)
+	}
+}
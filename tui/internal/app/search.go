@@ -0,0 +1,92 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// searchPrompt asks for the cipher letter to find after /.
+const searchPrompt = "Find cipher letter: "
+
+// searchOpen reports whether / is waiting for a cipher letter to find.
+func (m Model) searchOpen() bool {
+	return m.game.searching && m.state == StatePlaying
+}
+
+// startSearch waits for the cipher letter to find.
+func (m Model) startSearch() (tea.Model, tea.Cmd) {
+	m.game.searching = true
+	return m, nil
+}
+
+// handleSearchKeyMsg takes the key typed after /: a letter moves the cursor
+// to the next cell with that cipher letter, wrapping around to the start;
+// Enter finds the last letter searched for again; Esc gives up. Any other
+// key leaves the prompt waiting.
+func (m Model) handleSearchKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	var cipher rune
+	switch msg.String() {
+	case "esc":
+		m.game.searching = false
+		return m, nil
+	case "enter":
+		cipher = m.game.lastSearch
+	default:
+		text := msg.Text
+		if text == "" {
+			text = msg.String()
+		}
+		letter, ok := puzzle.InputLetter(text, false)
+		if !ok {
+			return m, nil
+		}
+		cipher = letter
+	}
+	m.game.searching = false
+	if cipher == 0 {
+		return m, nil
+	}
+	m.game.lastSearch = cipher
+
+	next := nextCipherCell(m.game.cells, m.game.cursorPos, cipher)
+	if next < 0 {
+		return m.notify(toastWarning, fmt.Sprintf("No cipher letter %c to fill in", cipher))
+	}
+	m.game.cursorPos = next
+	return m, nil
+}
+
+// nextCipherCell finds the next letter cell after from whose cipher letter
+// is cipher, wrapping past the end; from itself comes last, so a letter that
+// appears once is found where the cursor already is. Returns -1 when no
+// letter cell has it.
+func nextCipherCell(cells []puzzle.Cell, from int, cipher rune) int {
+	n := len(cells)
+	for step := 1; step <= n; step++ {
+		i := ((from+step)%n + n) % n
+		if cells[i].Kind == puzzle.CellLetter && puzzle.NormalizeLetter(cells[i].Char) == cipher {
+			return i
+		}
+	}
+	return -1
+}
+
+// renderSearch renders the prompt waiting for the letter to find.
+func (m Model) renderSearch() string {
+	if !m.searchOpen() {
+		return ""
+	}
+	return ui.LoadingStyle.Render(searchPrompt + "_")
+}
+
+// accessibleSearch is renderSearch as plain text.
+func (m Model) accessibleSearch() string {
+	if !m.searchOpen() {
+		return ""
+	}
+	return "Type a cipher letter to find it. Enter finds the last one again, Esc cancels."
+}
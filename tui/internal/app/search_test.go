@@ -0,0 +1,83 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+func TestSearch_FindsNextCipherLetterAndWraps(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.game.puzzle = &api.Puzzle{ID: "game-001", EncryptedText: "XA BX CX"}
+	m.game.cells = puzzle.BuildCells(m.game.puzzle.EncryptedText, nil)
+	m.game.cursorPos = 0
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: '/', Text: "/"})
+	if !m.searchOpen() {
+		t.Fatal("/ should wait for a cipher letter")
+	}
+	if prompt := ansi.Strip(m.layoutPlaying("", "")); !strings.Contains(prompt, searchPrompt) {
+		t.Errorf("playing screen should show the search prompt:\n%s", prompt)
+	}
+
+	m = typeLetter(t, m, 'x')
+	if m.searchOpen() || m.game.cursorPos != 4 {
+		t.Fatalf("after /x: open %v, cursorPos = %d; want the prompt closed and the cursor on the X at 4", m.searchOpen(), m.game.cursorPos)
+	}
+	if m.game.cells[0].Input != 0 {
+		t.Error("the letter typed after / should not be entered in the grid")
+	}
+
+	// Enter at the prompt finds X again
+	m, _ = press(t, m, tea.KeyPressMsg{Code: '/', Text: "/"})
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.game.cursorPos != 7 {
+		t.Errorf("cursorPos = %d after /Enter, want 7", m.game.cursorPos)
+	}
+	m, _ = press(t, m, tea.KeyPressMsg{Code: '/', Text: "/"})
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.game.cursorPos != 0 {
+		t.Errorf("cursorPos = %d, want the search to wrap around to 0", m.game.cursorPos)
+	}
+}
+
+func TestSearch_MissingLetter(t *testing.T) {
+	m := revealModel(nil, 0)
+	start := m.game.cursorPos
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: '/', Text: "/"})
+	m = typeLetter(t, m, 'q')
+	if m.game.cursorPos != start {
+		t.Errorf("cursorPos = %d, want it left at %d when no cell has Q", m.game.cursorPos, start)
+	}
+	if len(m.toasts) == 0 || !strings.Contains(m.toasts[len(m.toasts)-1].text, "No cipher letter Q") {
+		t.Errorf("toasts = %+v, want one saying Q isn't in the puzzle", m.toasts)
+	}
+}
+
+func TestSearch_EscCancels(t *testing.T) {
+	m := revealModel(nil, 0)
+	m, _ = press(t, m, tea.KeyPressMsg{Code: '/', Text: "/"})
+	m, cmd := press(t, m, tea.KeyPressMsg{Code: tea.KeyEsc})
+	if m.searchOpen() || cmd != nil {
+		t.Errorf("Esc should close the prompt without quitting; open %v, cmd %v", m.searchOpen(), cmd)
+	}
+}
+
+func TestNextCipherCell(t *testing.T) {
+	cells := puzzle.BuildCells("AB, BA", map[rune]rune{'B': 'E'})
+	if got := nextCipherCell(cells, 0, 'A'); got != 5 {
+		t.Errorf("nextCipherCell(A from 0) = %d, want 5", got)
+	}
+	if got := nextCipherCell(cells, 5, 'A'); got != 0 {
+		t.Errorf("nextCipherCell(A from 5) = %d, want 0 after wrapping", got)
+	}
+	if got := nextCipherCell(cells, 0, 'B'); got != -1 {
+		t.Errorf("nextCipherCell(B) = %d, want -1: hint cells aren't stops", got)
+	}
+}
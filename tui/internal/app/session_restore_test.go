@@ -6,20 +6,20 @@ import (
 
 	"github.com/bojanrajkovic/unquote/tui/internal/api"
 	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
 	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
 )
 
 func TestHandleSessionLoaded_RestoresInputs(t *testing.T) {
 	// Create a model with cells
 	encryptedText := "XMT KTQS"
 	model := Model{
-		puzzle: &api.Puzzle{
+		game: gameModel{puzzle: &api.Puzzle{
 			ID:            "test-game",
 			EncryptedText: encryptedText,
-		},
-		cells:     puzzle.BuildCells(encryptedText, nil),
-		state:     StatePlaying,
-		startTime: time.Now(),
+		}, cells: puzzle.BuildCells(encryptedText, nil), startTime: time.Now()},
+		state: StatePlaying,
 	}
 
 	// Create a session with saved inputs
@@ -52,7 +52,7 @@ func TestHandleSessionLoaded_RestoresInputs(t *testing.T) {
 		'S': 'T',
 	}
 
-	for i, cell := range m.cells {
+	for i, cell := range m.game.cells {
 		if cell.Kind != puzzle.CellLetter {
 			continue
 		}
@@ -67,8 +67,8 @@ func TestHandleSessionLoaded_RestoresInputs(t *testing.T) {
 	}
 
 	// Verify elapsed time was restored
-	if m.elapsedAtPause != 30*time.Second {
-		t.Errorf("ElapsedAtPause: expected %v, got %v", 30*time.Second, m.elapsedAtPause)
+	if m.game.elapsedAtPause != 30*time.Second {
+		t.Errorf("ElapsedAtPause: expected %v, got %v", 30*time.Second, m.game.elapsedAtPause)
 	}
 }
 
@@ -92,8 +92,8 @@ func TestFullFlow_PuzzleFetchThenSessionLoad(t *testing.T) {
 	model = resultModel.(Model)
 
 	// Verify cells were created
-	if len(model.cells) != 8 {
-		t.Fatalf("Expected 8 cells, got %d", len(model.cells))
+	if len(model.game.cells) != 8 {
+		t.Fatalf("Expected 8 cells, got %d", len(model.game.cells))
 	}
 
 	// Verify a command was returned (loadSessionCmd)
@@ -130,7 +130,7 @@ func TestFullFlow_PuzzleFetchThenSessionLoad(t *testing.T) {
 		'S': 'T',
 	}
 
-	for i, cell := range model.cells {
+	for i, cell := range model.game.cells {
 		if cell.Kind != puzzle.CellLetter {
 			continue
 		}
@@ -145,7 +145,7 @@ func TestFullFlow_PuzzleFetchThenSessionLoad(t *testing.T) {
 	}
 
 	t.Logf("All cells after session restore:")
-	for i, cell := range model.cells {
+	for i, cell := range model.game.cells {
 		t.Logf("  Cell %d: Char=%c, Kind=%v, Input=%c", i, cell.Char, cell.Kind, cell.Input)
 	}
 }
@@ -154,13 +154,11 @@ func TestHandleSessionLoaded_SolvedSessionRestoresInputs(t *testing.T) {
 	// This tests the bug fix: solved sessions must also restore inputs
 	encryptedText := "XMT KTQS"
 	model := Model{
-		puzzle: &api.Puzzle{
+		game: gameModel{puzzle: &api.Puzzle{
 			ID:            "test-game",
 			EncryptedText: encryptedText,
-		},
-		cells:     puzzle.BuildCells(encryptedText, nil),
-		state:     StatePlaying,
-		startTime: time.Now(),
+		}, cells: puzzle.BuildCells(encryptedText, nil), startTime: time.Now()},
+		state: StatePlaying,
 	}
 
 	// Create a SOLVED session with inputs
@@ -198,7 +196,7 @@ func TestHandleSessionLoaded_SolvedSessionRestoresInputs(t *testing.T) {
 		'S': 'T',
 	}
 
-	for i, cell := range m.cells {
+	for i, cell := range m.game.cells {
 		if cell.Kind != puzzle.CellLetter {
 			continue
 		}
@@ -219,17 +217,15 @@ func TestUpdate_SessionLoadedRestoresInputs(t *testing.T) {
 
 	// Create initial model after puzzle fetch
 	model := Model{
-		puzzle: &api.Puzzle{
+		game: gameModel{puzzle: &api.Puzzle{
 			ID:            "test-game",
 			EncryptedText: encryptedText,
-		},
-		cells:     puzzle.BuildCells(encryptedText, nil),
-		state:     StatePlaying,
-		startTime: time.Now(),
+		}, cells: puzzle.BuildCells(encryptedText, nil), startTime: time.Now()},
+		state: StatePlaying,
 	}
 
 	// Verify cells start with no input
-	for i, cell := range model.cells {
+	for i, cell := range model.game.cells {
 		if cell.Kind == puzzle.CellLetter && cell.Input != 0 {
 			t.Errorf("Cell %d should start with no input, got %c", i, cell.Input)
 		}
@@ -265,20 +261,20 @@ func TestUpdate_SessionLoadedRestoresInputs(t *testing.T) {
 	}
 
 	t.Log("Cells in ORIGINAL model after Update:")
-	for i, cell := range model.cells {
+	for i, cell := range model.game.cells {
 		if cell.Kind == puzzle.CellLetter {
 			t.Logf("  Cell %d: Char=%c, Input=%c", i, cell.Char, cell.Input)
 		}
 	}
 
 	t.Log("Cells in RETURNED model after Update:")
-	for i, cell := range updatedModel.cells {
+	for i, cell := range updatedModel.game.cells {
 		if cell.Kind == puzzle.CellLetter {
 			t.Logf("  Cell %d: Char=%c, Input=%c", i, cell.Char, cell.Input)
 		}
 	}
 
-	for i, cell := range updatedModel.cells {
+	for i, cell := range updatedModel.game.cells {
 		if cell.Kind != puzzle.CellLetter {
 			continue
 		}
@@ -298,18 +294,16 @@ func TestHandleSessionLoaded_PreservesHintCells(t *testing.T) {
 	encryptedText := "AB CD"
 	hints := map[rune]rune{'A': 'X'}
 	model := Model{
-		puzzle: &api.Puzzle{
+		game: gameModel{puzzle: &api.Puzzle{
 			ID:            "test-game",
 			EncryptedText: encryptedText,
-		},
-		cells:     puzzle.BuildCells(encryptedText, hints),
-		state:     StatePlaying,
-		startTime: time.Now(),
+		}, cells: puzzle.BuildCells(encryptedText, hints), startTime: time.Now()},
+		state: StatePlaying,
 	}
 
 	// Verify hint cell is set up correctly
-	if model.cells[0].Kind != puzzle.CellHint || model.cells[0].Input != 'X' {
-		t.Fatalf("cell 0: expected CellHint with Input 'X', got Kind=%v Input=%c", model.cells[0].Kind, model.cells[0].Input)
+	if model.game.cells[0].Kind != puzzle.CellHint || model.game.cells[0].Input != 'X' {
+		t.Fatalf("cell 0: expected CellHint with Input 'X', got Kind=%v Input=%c", model.game.cells[0].Kind, model.game.cells[0].Input)
 	}
 
 	// Session has saved inputs for regular letters only
@@ -329,22 +323,22 @@ func TestHandleSessionLoaded_PreservesHintCells(t *testing.T) {
 	m := resultModel.(Model)
 
 	// Verify hint cell A is unchanged (still 'X')
-	if m.cells[0].Input != 'X' {
-		t.Errorf("hint cell A: expected Input 'X' preserved, got %c", m.cells[0].Input)
+	if m.game.cells[0].Input != 'X' {
+		t.Errorf("hint cell A: expected Input 'X' preserved, got %c", m.game.cells[0].Input)
 	}
-	if m.cells[0].Kind != puzzle.CellHint {
-		t.Errorf("hint cell A: expected CellHint, got %v", m.cells[0].Kind)
+	if m.game.cells[0].Kind != puzzle.CellHint {
+		t.Errorf("hint cell A: expected CellHint, got %v", m.game.cells[0].Kind)
 	}
 
 	// Verify regular cells got session inputs
-	if m.cells[1].Input != 'Y' {
-		t.Errorf("regular cell B: expected Input 'Y', got %c", m.cells[1].Input)
+	if m.game.cells[1].Input != 'Y' {
+		t.Errorf("regular cell B: expected Input 'Y', got %c", m.game.cells[1].Input)
 	}
-	if m.cells[3].Input != 'Z' {
-		t.Errorf("regular cell C: expected Input 'Z', got %c", m.cells[3].Input)
+	if m.game.cells[3].Input != 'Z' {
+		t.Errorf("regular cell C: expected Input 'Z', got %c", m.game.cells[3].Input)
 	}
-	if m.cells[4].Input != 'W' {
-		t.Errorf("regular cell D: expected Input 'W', got %c", m.cells[4].Input)
+	if m.game.cells[4].Input != 'W' {
+		t.Errorf("regular cell D: expected Input 'W', got %c", m.game.cells[4].Input)
 	}
 }
 
@@ -367,21 +361,21 @@ func TestHandlePuzzleFetched_CreatesHintCells(t *testing.T) {
 	m := resultModel.(Model)
 
 	// Verify hint cell A was created
-	if m.cells[0].Kind != puzzle.CellHint {
-		t.Errorf("cell 0 (A): expected CellHint, got %v", m.cells[0].Kind)
+	if m.game.cells[0].Kind != puzzle.CellHint {
+		t.Errorf("cell 0 (A): expected CellHint, got %v", m.game.cells[0].Kind)
 	}
-	if m.cells[0].Input != 'X' {
-		t.Errorf("cell 0 (A): expected Input 'X', got %c", m.cells[0].Input)
+	if m.game.cells[0].Input != 'X' {
+		t.Errorf("cell 0 (A): expected Input 'X', got %c", m.game.cells[0].Input)
 	}
 
 	// Verify regular letter B was created
-	if m.cells[1].Kind != puzzle.CellLetter {
-		t.Errorf("cell 1 (B): expected CellLetter, got %v", m.cells[1].Kind)
+	if m.game.cells[1].Kind != puzzle.CellLetter {
+		t.Errorf("cell 1 (B): expected CellLetter, got %v", m.game.cells[1].Kind)
 	}
 
 	// Verify cursor starts on first regular letter (not hint)
-	if m.cursorPos != 1 {
-		t.Errorf("cursorPos: expected 1 (first CellLetter), got %d", m.cursorPos)
+	if m.game.cursorPos != 1 {
+		t.Errorf("cursorPos: expected 1 (first CellLetter), got %d", m.game.cursorPos)
 	}
 
 	// Verify a command was returned (loadSessionCmd)
@@ -389,3 +383,107 @@ func TestHandlePuzzleFetched_CreatesHintCells(t *testing.T) {
 		t.Fatal("Expected loadSessionCmd to be returned")
 	}
 }
+
+func TestHandlePuzzleFetched_MultiByteHints(t *testing.T) {
+	model := Model{state: StateLoading}
+	resultModel, _ := model.handlePuzzleFetched(puzzleFetchedMsg{
+		puzzle: &api.Puzzle{
+			ID:            "test-game",
+			EncryptedText: "ŽĆ ŽA",
+			Hints:         []api.Hint{{CipherLetter: "Ž", PlainLetter: "é"}},
+		},
+	})
+	m := resultModel.(Model)
+
+	for _, i := range []int{0, 3} {
+		if m.game.cells[i].Kind != puzzle.CellHint || m.game.cells[i].Input != 'É' {
+			t.Errorf("cell %d = Kind %v Input %c, want a hint for É", i, m.game.cells[i].Kind, m.game.cells[i].Input)
+		}
+	}
+	// Cells are indexed by rune, so the cursor lands on Ć, not a byte offset
+	if m.game.cursorPos != 1 || m.game.cells[m.game.cursorPos].Char != 'Ć' {
+		t.Errorf("cursorPos = %d, want 1 (Ć)", m.game.cursorPos)
+	}
+}
+
+func TestHandleSessionLoaded_RestoresMultiByteInputs(t *testing.T) {
+	setCacheHome(t)
+	m := Model{state: StatePlaying, game: gameModel{puzzle: &api.Puzzle{ID: "test-game", EncryptedText: "ŽĆ"}, cells: puzzle.BuildCells("ŽĆ", nil)}}
+	result, _ := m.handleSessionLoaded(sessionLoadedMsg{
+		session: &storage.GameSession{GameID: "test-game", Inputs: map[string]string{"Ž": "Ø", "Ć": "ß"}},
+	})
+	m = result.(Model)
+	if m.game.cells[0].Input != 'Ø' || m.game.cells[1].Input != 'ß' {
+		t.Errorf("inputs = %c %c, want Ø ß", m.game.cells[0].Input, m.game.cells[1].Input)
+	}
+}
+
+func TestNewSession_RefusesCellsFromAnotherPuzzle(t *testing.T) {
+	p := &api.Puzzle{ID: "game-001", EncryptedText: "XMT KTQS"}
+	other := puzzle.BuildCells("XMT KTQZ", nil)
+
+	if session := newSession(p, other, time.Minute, speedRun{}, nil, storage.Assists{}); session != nil {
+		t.Errorf("newSession() = %+v, want nil for another puzzle's cells", session)
+	}
+	if session := newSession(p, other[:3], time.Minute, speedRun{}, nil, storage.Assists{}); session != nil {
+		t.Error("newSession() should refuse a grid shorter than the puzzle")
+	}
+	if saveSessionCmd(storage.Daily, time.Now(), p, other, time.Minute, speedRun{}, nil, storage.Assists{}) != nil ||
+		saveSolvedSessionCmd(storage.Daily, p, other, time.Minute, time.Now(), speedRun{}, nil, storage.Assists{}) != nil ||
+		saveRevealedSessionCmd(storage.Daily, time.Now(), p, other, time.Minute) != nil {
+		t.Error("saving another puzzle's cells should write nothing")
+	}
+}
+
+// TestSessionBinding_InterleavedMessages plays one puzzle, then switches to
+// another while the first one's save and session load are still in flight.
+func TestSessionBinding_InterleavedMessages(t *testing.T) {
+	storagetest.UseMemory(t)
+	first, err := puzzlegen.Generate("Hello world", "Tester", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := puzzlegen.Generate("Goodbye moon", "Tester", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := Model{state: StateLoading, opts: Options{Local: first}, width: 80, height: 40}
+	m, fetch := m.fetch()
+	model, _ := m.Update(fetch())
+	m = typeLetter(t, model.(Model), 'Q')
+	save := saveSessionCmd(m.sessions(), time.Now(), m.game.puzzle, m.game.cells, time.Minute, m.run, m.game.letters, m.game.assists)
+
+	m = m.resetGame()
+	m.opts.Local = second
+	m, fetch = m.fetch()
+	model, _ = m.Update(fetch())
+	m = model.(Model)
+	save()
+
+	saved, err := storage.Custom.LoadSession(first.ID)
+	if err != nil || saved == nil || len(saved.Inputs) != 1 {
+		t.Fatalf("first puzzle's session = %+v, %v; want its one input saved", saved, err)
+	}
+	if other, _ := storage.Custom.LoadSession(second.ID); other != nil {
+		t.Errorf("second puzzle's session = %+v, want nothing saved for it", other)
+	}
+
+	// A save built mid-switch, with the new puzzle but the old grid
+	if cmd := saveSessionCmd(m.sessions(), time.Now(), m.game.puzzle, puzzle.BuildCells(first.EncryptedText, nil), time.Minute, m.run, m.game.letters, m.game.assists); cmd != nil {
+		t.Error("a save pairing the second puzzle with the first one's grid should write nothing")
+	}
+
+	// The first puzzle's session arriving late
+	saved.Solved = true
+	model, _ = m.Update(sessionLoadedMsg{session: saved})
+	got := model.(Model)
+	if got.state != StatePlaying || got.game.elapsedAtPause != m.game.elapsedAtPause {
+		t.Errorf("state = %v after the first puzzle's session loaded; want the second still playing", got.state)
+	}
+	for _, cell := range got.game.cells {
+		if cell.Kind == puzzle.CellLetter && cell.Input != 0 {
+			t.Fatalf("cell %d = %c, want the second puzzle's grid left empty", cell.Index, cell.Input)
+		}
+	}
+}
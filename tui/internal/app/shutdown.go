@@ -0,0 +1,48 @@
+package app
+
+import (
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// ShutdownUploadTimeout is how long exiting waits, at most, for solves still
+// to be uploaded; the rest go out with the next start's reconciliation.
+const ShutdownUploadTimeout = 3 * time.Second
+
+// Shutdown finishes up after the program exited, however it was asked to:
+// the game on screen is saved, since its last save runs as a command that
+// may not have finished, solves not yet uploaded get up to timeout to go
+// out, and the duel's event stream and the client's idle connections are
+// closed. Returns the error saving the game, if any; uploads are
+// best-effort.
+func (m Model) Shutdown(timeout time.Duration) error {
+	var err error
+	if namespace, session := m.PendingSession(); session != nil {
+		err = namespace.SaveSession(session)
+	}
+	m = m.closeDuelStream()
+	if m.client == nil {
+		return err
+	}
+	if m.claimCode != "" {
+		m.drainUploads(timeout)
+	}
+	m.client.CloseIdleConnections()
+	return err
+}
+
+// drainUploads uploads pending solves for up to timeout. No upload starts
+// after it, and one still in flight is left behind rather than waited on.
+func (m Model) drainUploads(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = storage.ReplayUploads()
+		_, _, _ = uploadSolves(m.client, m.clock(), m.claimCode, m.clock().Now().Add(timeout))
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// Shutdown saves the game on screen and uploads solves still pending.
+func TestShutdown_FlushesGameAndUploads(t *testing.T) {
+	sessions := storagetest.UseMemory(t)
+	if err := sessions.SaveSession(storage.Daily, &storage.GameSession{
+		GameID: "solved-001", Inputs: map[string]string{}, CompletionTime: time.Minute, Solved: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var uploaded []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req api.RecordSessionRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		uploaded = append(uploaded, req.GameID)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	client, err := api.NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewWithClient(client)
+	m.claimCode = "TIGER-MAPLE-7492"
+	m.state = StatePlaying
+	m.game = gameModel{puzzle: &api.Puzzle{ID: "game-002", EncryptedText: "XQ"}, cells: puzzle.BuildCells("XQ", nil), startTime: time.Now()}
+	puzzle.SetInput(m.game.cells, 0, 'T')
+
+	if err := m.Shutdown(time.Second); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if s, err := storage.LoadSession("game-002"); err != nil || s == nil || s.Inputs["X"] != "T" {
+		t.Errorf("LoadSession(game-002) = %+v, %v; want the game on screen saved", s, err)
+	}
+	if len(uploaded) != 1 || uploaded[0] != "solved-001" {
+		t.Errorf("uploaded %v, want the pending solve", uploaded)
+	}
+	if s, _ := storage.LoadSession("solved-001"); s == nil || !s.Uploaded {
+		t.Errorf("solved-001 = %+v, want it marked uploaded", s)
+	}
+}
+
+// A server that doesn't answer holds shutdown up for the timeout, not for
+// the client's own.
+func TestShutdown_BoundsUploads(t *testing.T) {
+	sessions := storagetest.UseMemory(t)
+	if err := sessions.SaveSession(storage.Daily, &storage.GameSession{
+		GameID: "solved-001", Inputs: map[string]string{}, CompletionTime: time.Minute, Solved: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { <-release }))
+	defer server.Close()
+	defer close(release)
+	client, err := api.NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewWithClient(client)
+	m.claimCode = "TIGER-MAPLE-7492"
+	start := time.Now()
+	if err := m.Shutdown(50 * time.Millisecond); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if took := time.Since(start); took > time.Second {
+		t.Errorf("Shutdown() took %v, want it to give up after the timeout", took)
+	}
+}
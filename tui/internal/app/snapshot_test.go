@@ -0,0 +1,132 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// The golden-frame tests render whole screens at several terminal sizes and
+// compare them with testdata/golden/<test>/<size>.golden. After a deliberate
+// layout change, rewrite the files and review the diff:
+//
+//	go test ./internal/app -run Golden -update
+
+var update = flag.Bool("update", false, "rewrite golden frames in testdata/golden")
+
+// goldenSizes are the terminal sizes every golden screen is rendered at: the
+// smallest supported, the classic 80x24, and roomy.
+var goldenSizes = []struct{ width, height int }{
+	{MinTerminalWidth, 16},
+	{80, 24},
+	{120, 40},
+}
+
+// normalizeFrame makes a frame comparable across terminals and platforms:
+// styling and zone markers stripped, trailing spaces trimmed, line endings
+// unified, and ending in exactly one newline.
+func normalizeFrame(frame string) string {
+	lines := strings.Split(strings.ReplaceAll(ansi.Strip(frame), "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+// assertGolden compares frame with its golden file, or rewrites the file
+// under -update.
+func assertGolden(t *testing.T, name, frame string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", filepath.FromSlash(name)+".golden")
+	got := normalizeFrame(frame)
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil { //nolint:gosec // fixtures are checked in
+			t.Fatal(err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // path is built from the test name
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	// A checkout may have turned the file's line endings into CRLF
+	want := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if got == want {
+		return
+	}
+	gotLines, wantLines := strings.Split(got, "\n"), strings.Split(want, "\n")
+	for i := range max(len(gotLines), len(wantLines)) {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			t.Errorf("frame differs from %s at line %d:\n got: %q\nwant: %q\n\nwhole frame:\n%s\n(run with -update if the change is intended)",
+				path, i+1, g, w, got)
+			return
+		}
+	}
+}
+
+// assertGoldenSizes renders m at each golden size and checks the frames.
+func assertGoldenSizes(t *testing.T, m Model) {
+	t.Helper()
+	for _, size := range goldenSizes {
+		assertGolden(t, fmt.Sprintf("%s/%dx%d", t.Name(), size.width, size.height), m.renderAt(size.width, size.height))
+	}
+}
+
+func TestGolden_Playing(t *testing.T) {
+	assertGoldenSizes(t, samplePlaying())
+}
+
+func TestGolden_Stats(t *testing.T) {
+	assertGoldenSizes(t, sampleStatsPanel())
+}
+
+func TestGolden_ClaimCodeDisplay(t *testing.T) {
+	assertGoldenSizes(t, sampleClaimCodeCard())
+}
+
+func TestGolden_Error(t *testing.T) {
+	m := sampleModel(StateError)
+	m.errs.load = "Can't reach the Unquote server. Check your connection and press r to try again."
+	m.connection = connOffline
+	assertGoldenSizes(t, m)
+}
+
+func TestGolden_RateLimited(t *testing.T) {
+	m := sampleModel(StateError)
+	m.errs.load = "The server is busy. Trying again in 30s..."
+	m.retryAt = sampleNow.Add(30 * time.Second)
+	assertGoldenSizes(t, m)
+}
+
+func TestGolden_TooSmall(t *testing.T) {
+	m := sampleModel(StatePlaying)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 30, Height: 8})
+	sized := model.(Model)
+	assertGolden(t, t.Name(), sized.View().Content)
+}
+
+func TestNormalizeFrame(t *testing.T) {
+	styled := "\x1b[1mTitle\x1b[0m   \r\n\x1b[31mbody\x1b[0m\n\n\n"
+	if got, want := normalizeFrame(styled), "Title\nbody\n"; got != want {
+		t.Errorf("normalizeFrame() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// soundModel creates a playing Model with sound feedback on or off, its timer
+// ticking as in play.
+func soundModel(sound bool, text string) Model {
+	cells := puzzle.BuildCells(text, nil)
+	return Model{
+		state:   StatePlaying,
+		cfg:     &config.Config{Sound: sound},
+		game:    gameModel{puzzle: &api.Puzzle{ID: "game-001", EncryptedText: text}, cells: cells, cursorPos: puzzle.FirstLetterCell(cells)},
+		ticking: true,
+	}
+}
+
+// isRaw reports whether cmd prints the given raw terminal sequence.
+func isRaw(cmd tea.Cmd, seq string) bool {
+	if cmd == nil {
+		return false
+	}
+	raw, ok := cmd().(tea.RawMsg)
+	return ok && raw.Msg == seq
+}
+
+func TestWrongSubmission_RingsBellWhenEnabled(t *testing.T) {
+	_, cmd := soundModel(true, "AB").handleSolutionChecked(solutionCheckedMsg{correct: false})
+	if !isRaw(cmd, ui.Bell) {
+		t.Error("wrong submission with sound on should ring the bell")
+	}
+
+	_, cmd = soundModel(false, "AB").handleSolutionChecked(solutionCheckedMsg{correct: false})
+	if cmd != nil {
+		t.Error("wrong submission with sound off should be silent")
+	}
+}
+
+func TestNewConflict_RingsBellWhenEnabled(t *testing.T) {
+	storagetest.UseMemory(t)
+
+	tests := []struct {
+		name      string
+		sound     bool
+		wantBatch bool
+	}{
+		{name: "sound on", sound: true, wantBatch: true},
+		{name: "sound off", sound: false, wantBatch: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := soundModel(tt.sound, "AB")
+			m.game.cells[0].Input = 'E'
+
+			// Typing E into the B cell assigns E to two cipher letters
+			m.game.cursorPos = 1
+			_, cmd := m.handleLetterInput('E')
+
+			batch, isBatch := cmd().(tea.BatchMsg)
+			if isBatch != tt.wantBatch {
+				t.Fatalf("batched save + bell = %v, want %v", isBatch, tt.wantBatch)
+			}
+			if isBatch && !isRaw(batch[len(batch)-1], ui.Bell) {
+				t.Error("conflict should ring the bell")
+			}
+		})
+	}
+}
+
+func TestSolve_NotifiesWhenEnabled(t *testing.T) {
+	storagetest.UseMemory(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	m := soundModel(true, "AB")
+	m.client = newTestClient(t) // the solve also tops up the offline cache
+	m.state = StateChecking
+	m.game.startTime = time.Now()
+
+	_, cmd := m.handleSolutionChecked(solutionCheckedMsg{correct: true})
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("solve should return a batch")
+	}
+
+	var notified bool
+	for _, c := range batch {
+		if raw, ok := c().(tea.RawMsg); ok && strings.HasPrefix(raw.Msg.(string), "\x1b]9;Unquote solved in") {
+			notified = true
+		}
+	}
+	if !notified {
+		t.Error("solve with sound on should send a notification")
+	}
+}
+
+func TestHasNewConflict(t *testing.T) {
+	tests := []struct {
+		before map[rune][]rune
+		after  map[rune][]rune
+		name   string
+		want   bool
+	}{
+		{name: "no conflicts", before: map[rune][]rune{}, after: map[rune][]rune{}, want: false},
+		{name: "new conflict", before: map[rune][]rune{}, after: map[rune][]rune{'E': {'Q', 'X'}}, want: true},
+		{name: "existing conflict", before: map[rune][]rune{'E': {'Q', 'X'}}, after: map[rune][]rune{'E': {'Q', 'X'}}, want: false},
+		{name: "conflict resolved", before: map[rune][]rune{'E': {'Q', 'X'}}, after: map[rune][]rune{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasNewConflict(tt.before, tt.after); got != tt.want {
+				t.Errorf("hasNewConflict() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
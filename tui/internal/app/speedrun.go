@@ -0,0 +1,166 @@
+package app
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// speedRun is the state of a speed run: a target time to beat and the time
+// at which each word was first completely filled in.
+type speedRun struct {
+	splits []time.Duration // indexed like accessibleWords; 0 until the word is first filled
+	target time.Duration   // 0 when not speed-running
+}
+
+// timerWarnDivisor sets when the countdown turns to the warning color: once
+// less than 1/timerWarnDivisor of the target is left.
+const timerWarnDivisor = 4
+
+// remaining returns how much of the target time is left; negative once over.
+func (m Model) remaining() time.Duration {
+	return m.run.target - m.Elapsed()
+}
+
+// renderTimer renders the elapsed time and, in a speed run, the countdown to
+// the target. The countdown turns orange in the last quarter and red once the
+// target has passed.
+func (m Model) renderTimer() string {
+	elapsed := fmt.Sprintf("Time: %s", formatElapsed(m.Elapsed()))
+	if m.run.target <= 0 {
+		return ui.TimerStyle.Render(elapsed)
+	}
+
+	style := ui.TimerStyle
+	switch left := m.remaining(); {
+	case left < 0:
+		style = ui.ErrorStyle
+	case left <= m.run.target/timerWarnDivisor:
+		style = ui.WarningStyle
+	}
+	return ui.TimerStyle.Render(elapsed+" · ") + style.Render(m.countdownText())
+}
+
+// countdownText describes the time left to the target, or how far over it the
+// player is. Returns "" when not speed-running.
+func (m Model) countdownText() string {
+	if m.run.target <= 0 {
+		return ""
+	}
+	left := m.remaining()
+	if left < 0 {
+		return fmt.Sprintf("Over target by %s", formatElapsed(-left))
+	}
+	return fmt.Sprintf("Left: %s", formatElapsed(left))
+}
+
+// wordFilled reports whether every letter in the word has an input.
+// Words without letters (a lone dash) are never filled.
+func wordFilled(word accessibleWord) bool {
+	letters := 0
+	for _, cell := range word.cells {
+		if cell.Kind == puzzle.CellPunctuation {
+			continue
+		}
+		if cell.Input == 0 {
+			return false
+		}
+		letters++
+	}
+	return letters > 0
+}
+
+// recordSplits stamps the current elapsed time on every word that has just
+// been filled in for the first time. Splits are only kept in a speed run.
+func (m Model) recordSplits() Model {
+	if m.run.target <= 0 {
+		return m
+	}
+
+	words := m.accessibleWords()
+	// Copy before writing: save commands may still hold the previous slice
+	splits := make([]time.Duration, len(words))
+	copy(splits, m.run.splits)
+
+	now := m.Elapsed()
+	for i, word := range words {
+		if splits[i] == 0 && wordFilled(word) {
+			splits[i] = now
+		}
+	}
+	m.run.splits = splits
+	return m
+}
+
+// splitTimes returns how long each recorded word took, measured from the
+// previous split in the order they were completed. Words filled by the same
+// keystroke share a split, so all but the first of them take 0.
+func splitTimes(splits []time.Duration) []time.Duration {
+	order := make([]int, 0, len(splits))
+	for i, split := range splits {
+		if split > 0 {
+			order = append(order, i)
+		}
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(splits[a], splits[b])
+	})
+
+	times := make([]time.Duration, len(splits))
+	var previous time.Duration
+	for _, i := range order {
+		times[i] = splits[i] - previous
+		previous = splits[i]
+	}
+	return times
+}
+
+// renderSplits renders the solved screen's per-word split line, e.g.
+// "Splits: W1 00:12 · W2 00:31★ · W3 00:09", starring the fastest word and
+// comparing the finish against the target.
+func (m Model) renderSplits() string {
+	if m.state != StateSolved || m.game.revealed || m.run.target <= 0 || len(m.run.splits) == 0 {
+		return ""
+	}
+
+	times := splitTimes(m.run.splits)
+	best := -1
+	for i, d := range times {
+		if d > 0 && (best < 0 || d < times[best]) {
+			best = i
+		}
+	}
+
+	parts := make([]string, 0, len(times))
+	for i, d := range times {
+		if m.run.splits[i] == 0 {
+			continue
+		}
+		part := fmt.Sprintf("W%d %s", i+1, formatElapsed(d))
+		if i == best {
+			part += "★"
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+
+	var result string
+	if left := m.remaining(); left >= 0 {
+		result = ui.SuccessStyle.Render(fmt.Sprintf("Beat the %s target by %s", formatElapsed(m.run.target), formatElapsed(left)))
+	} else {
+		result = ui.ErrorStyle.Render(fmt.Sprintf("Missed the %s target by %s", formatElapsed(m.run.target), formatElapsed(-left)))
+	}
+
+	width := max(m.width, MinTerminalWidth)
+	splits := ui.TimerStyle.Render(ui.WordWrapText("Splits: "+strings.Join(parts, " · "), width))
+	return lipgloss.JoinVertical(lipgloss.Left, result, splits)
+}
@@ -0,0 +1,167 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// speedRunModel creates a Model paused at the given elapsed time with a
+// speed-run target (0 for none).
+func speedRunModel(state State, target, elapsed time.Duration) Model {
+	cells := puzzle.BuildCells("AB CD", nil)
+	return Model{
+		state:  state,
+		game:   gameModel{puzzle: &api.Puzzle{ID: "game-001", EncryptedText: "AB CD"}, cells: cells, cursorPos: puzzle.FirstLetterCell(cells), elapsedAtPause: elapsed, startTime: time.Now()},
+		run:    speedRun{target: target},
+		width:  80,
+		height: 40,
+	}
+}
+
+func TestRenderTimer(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		target  time.Duration
+		elapsed time.Duration
+	}{
+		{
+			name:    "no target",
+			elapsed: time.Minute,
+			want:    ui.TimerStyle.Render("Time: 01:00"),
+		},
+		{
+			name:    "plenty left",
+			target:  3 * time.Minute,
+			elapsed: time.Minute,
+			want:    ui.TimerStyle.Render("Time: 01:00 · ") + ui.TimerStyle.Render("Left: 02:00"),
+		},
+		{
+			name:    "last quarter warns",
+			target:  4 * time.Minute,
+			elapsed: 3*time.Minute + 10*time.Second,
+			want:    ui.TimerStyle.Render("Time: 03:10 · ") + ui.WarningStyle.Render("Left: 00:50"),
+		},
+		{
+			name:    "over target",
+			target:  time.Minute,
+			elapsed: 90 * time.Second,
+			want:    ui.TimerStyle.Render("Time: 01:30 · ") + ui.ErrorStyle.Render("Over target by 00:30"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := speedRunModel(StateChecking, tt.target, tt.elapsed)
+			if got := m.renderTimer(); got != tt.want {
+				t.Errorf("renderTimer() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordSplits(t *testing.T) {
+	m := speedRunModel(StateChecking, 3*time.Minute, 20*time.Second)
+	puzzle.SetInput(m.game.cells, 0, 'N')
+	puzzle.SetInput(m.game.cells, 1, 'O')
+	m = m.recordSplits()
+
+	if !slices.Equal(m.run.splits, []time.Duration{20 * time.Second, 0}) {
+		t.Fatalf("splits after first word = %v, want [20s 0s]", m.run.splits)
+	}
+
+	// Later words get their own split; earlier splits are kept even if the
+	// word is edited again
+	m.game.elapsedAtPause = 50 * time.Second
+	puzzle.SetInput(m.game.cells, 3, 'G')
+	puzzle.SetInput(m.game.cells, 4, 'O')
+	m = m.recordSplits()
+
+	if !slices.Equal(m.run.splits, []time.Duration{20 * time.Second, 50 * time.Second}) {
+		t.Errorf("splits after second word = %v, want [20s 50s]", m.run.splits)
+	}
+}
+
+func TestRecordSplits_OnlyInSpeedRun(t *testing.T) {
+	m := speedRunModel(StateChecking, 0, 20*time.Second)
+	puzzle.SetInput(m.game.cells, 0, 'N')
+	puzzle.SetInput(m.game.cells, 1, 'O')
+
+	if m = m.recordSplits(); m.run.splits != nil {
+		t.Errorf("splits without a target = %v, want none", m.run.splits)
+	}
+}
+
+func TestSplitTimes(t *testing.T) {
+	// Words completed out of order, and two words filled by one keystroke
+	splits := []time.Duration{40 * time.Second, 10 * time.Second, 0, 40 * time.Second}
+	want := []time.Duration{30 * time.Second, 10 * time.Second, 0, 0}
+
+	if got := splitTimes(splits); !slices.Equal(got, want) {
+		t.Errorf("splitTimes() = %v, want %v", got, want)
+	}
+}
+
+func TestRenderSplits(t *testing.T) {
+	m := speedRunModel(StateSolved, time.Minute, 50*time.Second)
+	m.run.splits = []time.Duration{15 * time.Second, 50 * time.Second}
+
+	got := ansi.Strip(m.renderSplits())
+	for _, want := range []string{"Beat the 01:00 target by 00:10", "W1 00:15★", "W2 00:35"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderSplits() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	m.game.elapsedAtPause = 75 * time.Second
+	if got := ansi.Strip(m.renderSplits()); !strings.Contains(got, "Missed the 01:00 target by 00:15") {
+		t.Errorf("renderSplits() over target = %q, want the miss reported", got)
+	}
+
+	m.state = StatePlaying
+	if got := m.renderSplits(); got != "" {
+		t.Errorf("renderSplits() while playing = %q, want nothing until solved", got)
+	}
+}
+
+func TestSpeedRun_SplitsSavedAndRestored(t *testing.T) {
+	storagetest.UseMemory(t)
+
+	m := speedRunModel(StatePlaying, 3*time.Minute, 0)
+	m.game.cursorPos = 0
+	model, _ := m.handleLetterInput('N')
+	m = model.(Model)
+	model, cmd := m.handleLetterInput('O')
+	m = model.(Model)
+
+	if m.run.splits[0] == 0 {
+		t.Fatal("filling the first word should record its split")
+	}
+	cmd()
+
+	session, err := storage.LoadSession("game-001")
+	if err != nil || session == nil {
+		t.Fatalf("LoadSession() = %v, %v; want the saved session", session, err)
+	}
+	if session.Target != 3*time.Minute || len(session.Splits) != 2 || session.Splits[0] == 0 {
+		t.Errorf("saved Target = %v, Splits = %v; want the speed run persisted", session.Target, session.Splits)
+	}
+
+	// Resuming without --target keeps the saved speed run going
+	resumed := speedRunModel(StateLoading, 0, 0)
+	model, _ = resumed.handleSessionLoaded(sessionLoadedMsg{session: session})
+	resumed = model.(Model)
+	if resumed.run.target != 3*time.Minute || !slices.Equal(resumed.run.splits, session.Splits) {
+		t.Errorf("resumed run = %+v, want target and splits restored", resumed.run)
+	}
+}
@@ -0,0 +1,254 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/guptarohit/asciigraph"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/goal"
+	"github.com/bojanrajkovic/unquote/tui/internal/statsdiff"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// statsModel is the stats screen: the player's numbers and solve-time graph,
+// and the Friends and Categories tabs. The router fills it in as its data
+// loads and hands it the keys while it is open.
+type statsModel struct {
+	player     *api.PlayerStatsResponse
+	goal       *goal.Progress  // this week's progress toward Config.WeeklyGoal; nil without a goal
+	friends    []friendStats   // friends' stats for the Friends tab; nil until loaded
+	categories []categoryStats // per-category breakdown for the Categories tab; nil until loaded
+	page       statsPage       // visible panel in the paged stats layout
+	tab        statsTab        // own stats, the Friends comparison or the categories
+	fetch      int             // generation of the latest fetch for the screen; older results are dropped
+}
+
+// open readies the stats screen to be shown again: on the player's own tab,
+// with the categories and, when the Friends tab is offered, the friends'
+// stats cleared to be loaded afresh. It starts a new fetch generation for
+// fetchStatsCmd.
+func (s statsModel) open(friends bool) statsModel {
+	s.fetch++
+	s.tab = statsTabYou
+	s.categories = nil
+	if friends {
+		s.friends = nil
+	}
+	return s
+}
+
+// stale reports whether a result tagged with fetch belongs to a fetch for
+// the screen a newer one replaced. Untagged results (0) are never stale.
+func (s statsModel) stale(fetch int) bool {
+	return fetch != 0 && fetch != s.fetch
+}
+
+// update applies msg to the stats screen: the data it shows as that loads,
+// and the keys that page and switch tabs while it is open. friends reports
+// whether the Friends tab is offered. It also reports whether the player
+// asked to leave.
+func (s statsModel) update(msg tea.Msg, friends bool) (statsModel, bool) {
+	switch msg := msg.(type) {
+	case solveStatsFetchedMsg:
+		s.player = msg.stats
+	case goalLoadedMsg:
+		s.goal = &msg.progress
+	case friendStatsMsg:
+		s.friends = msg.friends
+	case categoryStatsMsg:
+		s.categories = msg.categories
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "esc", "b":
+			return s, true
+		case "left", "h":
+			s.page = statsPageGraph
+		case "right", "l":
+			s.page = statsPageNumbers
+		case "tab":
+			s.tab = s.nextTab(friends)
+		}
+	}
+	return s, false
+}
+
+// statsLayout selects how the stats screen arranges the graph and the numbers.
+type statsLayout int
+
+const (
+	statsLayoutSideBySide statsLayout = iota // graph left, sidebar right
+	statsLayoutStacked                       // graph above a compact numbers table
+	statsLayoutPaged                         // one panel at a time, ←/→ to switch
+)
+
+// statsPage is the panel shown in the paged stats layout.
+type statsPage int
+
+const (
+	statsPageGraph statsPage = iota
+	statsPageNumbers
+)
+
+const (
+	statsSidebarWidth  = 28
+	statsGraphHeight   = 10
+	statsDayWindow     = 30
+	statsMinGraphWidth = 60
+	// statsSideBySideWidth is the narrowest terminal that fits the sidebar next
+	// to a graph of at least statsMinGraphWidth cells.
+	statsSideBySideWidth = statsSidebarWidth + statsMinGraphWidth + 6
+	// statsStackedHeight is the shortest terminal that fits the header, graph
+	// (plus axis caption), compact numbers table (up to 9 rows with clean
+	// solves and a weekly goal), help bar and status bar stacked vertically.
+	statsStackedHeight = 3 + 1 + statsGraphHeight + 2 + 1 + 9 + 2 + statusBarHeight
+)
+
+// statsLayoutFor picks the stats layout for a terminal of width x height.
+func statsLayoutFor(width, height int) statsLayout {
+	switch {
+	case width >= statsSideBySideWidth:
+		return statsLayoutSideBySide
+	case height >= statsStackedHeight:
+		return statsLayoutStacked
+	default:
+		return statsLayoutPaged
+	}
+}
+
+// view renders the stats screen with a solve-time graph and summary numbers,
+// arranged side by side, stacked, or paged depending on the terminal size.
+func (s statsModel) view(c chrome) string {
+	help := ui.HelpStyle.Render(c.help)
+	if s.player == nil {
+		return lipgloss.JoinVertical(lipgloss.Left, c.header, "", ui.ErrorStyle.Render("Failed to load stats."), "", help)
+	}
+
+	switch s.tab {
+	case statsTabFriends:
+		return lipgloss.JoinVertical(lipgloss.Left, c.header, "", s.renderFriendsTab(c.accessible), "", help)
+	case statsTabCategories:
+		return lipgloss.JoinVertical(lipgloss.Left, c.header, "", s.renderCategoriesTab(c.accessible), "", help)
+	}
+
+	var content string
+	graphWidthAlone := max(c.width-10, 20) // leaves room for the y-axis labels
+	switch statsLayoutFor(c.width, c.height) {
+	case statsLayoutSideBySide:
+		graphPanel := s.renderGraph(max(c.width-statsSidebarWidth-6, statsMinGraphWidth), c.now)
+		content = lipgloss.JoinHorizontal(lipgloss.Top, graphPanel, "  ", s.renderSidebar())
+	case statsLayoutStacked:
+		content = lipgloss.JoinVertical(lipgloss.Left, s.renderGraph(graphWidthAlone, c.now), "", s.renderCompact())
+	case statsLayoutPaged:
+		if s.page == statsPageNumbers {
+			content = s.renderCompact()
+		} else {
+			content = s.renderGraph(graphWidthAlone, c.now)
+		}
+		help = ui.HelpStyle.Render(s.renderPageTabs() + "  [←/→] Page  " + c.help)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, c.header, "", content, "", help)
+}
+
+// renderGraph renders the solve-time graph for the 30 calendar days up to now.
+func (s statsModel) renderGraph(width int, now time.Time) string {
+	// Build solve-time data points on a calendar axis (last 30 days, NaN for missing days)
+	points, hasData := statsdiff.DailySolveMinutes(s.player.RecentSolves, now, statsDayWindow)
+	if !hasData {
+		return ui.HelpStyle.Render("No solve history in the last 30 days.")
+	}
+	return asciigraph.Plot(
+		points,
+		asciigraph.Height(statsGraphHeight),
+		asciigraph.Width(width),
+		asciigraph.Precision(1),
+		asciigraph.LowerBound(0),
+		asciigraph.Caption(statsdiff.SolveTimesCaption(s.player.RecentSolves, statsDayWindow)),
+	)
+}
+
+// statsRow is a single label/value pair on the stats screen.
+type statsRow struct {
+	label string
+	value string
+}
+
+// rows returns the summary numbers shown alongside the graph.
+func (s statsModel) rows() []statsRow {
+	formatOptMs := func(ms *float64) string {
+		if ms == nil {
+			return "—"
+		}
+		return formatMs(*ms)
+	}
+
+	rows := []statsRow{
+		{"Games Played", fmt.Sprintf("%d", s.player.GamesPlayed)},
+		{"Games Solved", fmt.Sprintf("%d", s.player.GamesSolved)},
+		{"Win Rate", fmt.Sprintf("%.1f%%", s.player.WinRate*100)},
+		{"Current Streak", fmt.Sprintf("%d", s.player.CurrentStreak)},
+		{"Best Streak", fmt.Sprintf("%d", s.player.BestStreak)},
+		{"Best Time", formatOptMs(s.player.BestTime)},
+		{"Avg Time", formatOptMs(s.player.AverageTime)},
+	}
+	if s.player.CleanSolves != nil {
+		rows = append(rows, statsRow{"Clean Solves", fmt.Sprintf("%d of %d", *s.player.CleanSolves, s.player.GamesSolved)})
+	}
+	if s.goal != nil {
+		value := fmt.Sprintf("%d/%d days", s.goal.Count(), s.goal.Goal)
+		if s.goal.Met() {
+			value += " ✓"
+		}
+		rows = append(rows, statsRow{"Weekly Goal", value})
+	}
+	return rows
+}
+
+// renderSidebar renders the numbers as a tall label-over-value column.
+func (s statsModel) renderSidebar() string {
+	labelStyle := lipgloss.NewStyle().Foreground(ui.ColorMuted)
+	valueStyle := lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true)
+
+	var lines []string
+	for i, row := range s.rows() {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, labelStyle.Render(row.label), valueStyle.Render(row.value))
+	}
+
+	sidebarContent := strings.Join(lines, "\n")
+	return lipgloss.NewStyle().Width(statsSidebarWidth).Padding(0, 2).Render(sidebarContent)
+}
+
+// renderCompact renders the numbers one row per line, for layouts where
+// the graph takes the full width.
+func (s statsModel) renderCompact() string {
+	labelStyle := lipgloss.NewStyle().Foreground(ui.ColorMuted).Width(16)
+	valueStyle := lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true)
+
+	rows := s.rows()
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		lines = append(lines, "  "+labelStyle.Render(row.label)+valueStyle.Render(row.value))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderPageTabs renders the page indicator for the paged layout,
+// highlighting the current page.
+func (s statsModel) renderPageTabs() string {
+	active := lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true)
+	graph, numbers := "Graph", "Numbers"
+	if s.page == statsPageNumbers {
+		numbers = active.Render(numbers)
+	} else {
+		graph = active.Render(graph)
+	}
+	return graph + " · " + numbers
+}
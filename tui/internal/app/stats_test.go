@@ -3,10 +3,13 @@ package app
 import (
 	"strings"
 	"testing"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
 
 	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/goal"
 )
 
 // sampleStats returns a populated PlayerStatsResponse for testing.
@@ -22,19 +25,25 @@ func sampleStats() *api.PlayerStatsResponse {
 		BestStreak:    12,
 		BestTime:      &bestTime,
 		AverageTime:   &avgTime,
+		// Dates are relative to today so they fall inside the graph's 30-day window.
 		RecentSolves: []api.RecentSolve{
-			{Date: "2026-02-15", CompletionTime: 128000},
-			{Date: "2026-02-14", CompletionTime: 195000},
-			{Date: "2026-02-13", CompletionTime: 210000},
+			{Date: daysAgo(2), CompletionTime: 210000},
+			{Date: daysAgo(1), CompletionTime: 195000},
+			{Date: daysAgo(0), CompletionTime: 128000},
 		},
 	}
 }
 
-// statsModel creates a Model in StateStats with the given stats data.
-func statsModel(stats *api.PlayerStatsResponse) Model {
+// daysAgo returns the YYYY-MM-DD date n days before today.
+func daysAgo(n int) string {
+	return time.Now().AddDate(0, 0, -n).Format("2006-01-02")
+}
+
+// statsScreenModel creates a Model in StateStats with the given stats data.
+func statsScreenModel(stats *api.PlayerStatsResponse) Model {
 	return Model{
 		state:     StateStats,
-		stats:     stats,
+		stats:     statsModel{player: stats},
 		width:     120,
 		height:    40,
 		sizeReady: true,
@@ -67,7 +76,7 @@ func TestFormatMs(t *testing.T) {
 
 // TestViewStats_ContainsSidebarLabels verifies the stats view renders sidebar labels.
 func TestViewStats_ContainsSidebarLabels(t *testing.T) {
-	m := statsModel(sampleStats())
+	m := statsScreenModel(sampleStats())
 	view := m.viewStats()
 
 	labels := []string{"Games Played", "Games Solved", "Win Rate", "Current Streak", "Best Streak", "Best Time", "Avg Time"}
@@ -80,7 +89,7 @@ func TestViewStats_ContainsSidebarLabels(t *testing.T) {
 
 // TestViewStats_ContainsFormattedValues verifies the stats view renders formatted values.
 func TestViewStats_ContainsFormattedValues(t *testing.T) {
-	m := statsModel(sampleStats())
+	m := statsScreenModel(sampleStats())
 	view := m.viewStats()
 
 	if !strings.Contains(view, "95.7%") {
@@ -96,7 +105,7 @@ func TestViewStats_ContainsFormattedValues(t *testing.T) {
 
 // TestViewStats_ContainsGraphCharacters verifies the graph is rendered when solves exist.
 func TestViewStats_ContainsGraphCharacters(t *testing.T) {
-	m := statsModel(sampleStats())
+	m := statsScreenModel(sampleStats())
 	view := m.viewStats()
 
 	graphChars := []string{"┤", "Solve Times"}
@@ -117,7 +126,7 @@ func TestViewStats_NilBestTime(t *testing.T) {
 	stats := sampleStats()
 	stats.BestTime = nil
 	stats.AverageTime = nil
-	m := statsModel(stats)
+	m := statsScreenModel(stats)
 	view := m.viewStats()
 
 	if !strings.Contains(view, "—") {
@@ -129,7 +138,7 @@ func TestViewStats_NilBestTime(t *testing.T) {
 func TestViewStats_EmptyRecentSolves(t *testing.T) {
 	stats := sampleStats()
 	stats.RecentSolves = nil
-	m := statsModel(stats)
+	m := statsScreenModel(stats)
 	view := m.viewStats()
 
 	if !strings.Contains(view, "No solve history") {
@@ -137,9 +146,22 @@ func TestViewStats_EmptyRecentSolves(t *testing.T) {
 	}
 }
 
+// TestViewStats_SolvesOutsideWindow verifies solves older than 30 calendar
+// days are not plotted, even though they are the most recent entries.
+func TestViewStats_SolvesOutsideWindow(t *testing.T) {
+	stats := sampleStats()
+	stats.RecentSolves = []api.RecentSolve{{Date: daysAgo(45), CompletionTime: 128000}}
+	m := statsScreenModel(stats)
+	view := m.viewStats()
+
+	if !strings.Contains(view, "No solve history") {
+		t.Errorf("viewStats() with only stale solves should show 'No solve history', got: %q", view[:min(200, len(view))])
+	}
+}
+
 // TestViewStats_NilStats verifies error message when stats is nil.
 func TestViewStats_NilStats(t *testing.T) {
-	m := statsModel(nil)
+	m := statsScreenModel(nil)
 	view := m.viewStats()
 
 	if !strings.Contains(view, "Failed to load stats") {
@@ -149,7 +171,7 @@ func TestViewStats_NilStats(t *testing.T) {
 
 // TestViewStats_HelpBar verifies the help bar shows [Esc] Back.
 func TestViewStats_HelpBar(t *testing.T) {
-	m := statsModel(sampleStats())
+	m := statsScreenModel(sampleStats())
 	view := m.viewStats()
 
 	if !strings.Contains(view, "[Esc] Back") {
@@ -260,3 +282,195 @@ func TestRenderHelp_StatsSolved_NoClaimCode(t *testing.T) {
 		t.Errorf("renderHelp() for StateSolved without claimCode should NOT show '[s] Stats', got: %q", help)
 	}
 }
+
+// TestRenderSolveComparison_FreshSolve verifies the post-solve panel compares
+// today's time against the historical average (excluding today) and shows the
+// percentile when the server reported one.
+func TestRenderSolveComparison_FreshSolve(t *testing.T) {
+	avg := 180000.0 // (165s + 195s) / 2 — includes today's solve
+	percentile := 72.0
+	m := Model{
+		state: StateSolved,
+		game:  gameModel{freshSolve: true, elapsedAtPause: 165 * time.Second, percentile: &percentile},
+		stats: statsModel{player: &api.PlayerStatsResponse{AverageTime: &avg, GamesSolved: 2}},
+	}
+
+	got := m.renderSolveComparison()
+
+	for _, want := range []string{"Today: 2:45", "Your average: 3:15", "18% faster than usual", "Faster than 72% of players"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("renderSolveComparison() missing %q, got %q", want, got)
+		}
+	}
+}
+
+// TestRenderSolveComparison_HiddenWithoutFreshSolve verifies the panel is not
+// shown for restored or solved-elsewhere sessions, or before stats arrive.
+func TestRenderSolveComparison_HiddenWithoutFreshSolve(t *testing.T) {
+	avg := 180000.0
+	stats := &api.PlayerStatsResponse{AverageTime: &avg, GamesSolved: 5}
+
+	tests := []struct {
+		name string
+		m    Model
+	}{
+		{name: "restored session", m: Model{state: StateSolved, stats: statsModel{player: stats}}},
+		{name: "stats not yet fetched", m: Model{state: StateSolved, game: gameModel{freshSolve: true}}},
+		{name: "still playing", m: Model{state: StatePlaying, game: gameModel{freshSolve: true}, stats: statsModel{player: stats}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.renderSolveComparison(); got != "" {
+				t.Errorf("renderSolveComparison() = %q, want empty", got)
+			}
+		})
+	}
+}
+
+// TestHandleSessionRecorded_FreshSolveFetchesStats verifies that recording a
+// fresh solve stores the percentile and keeps the player on the solved screen.
+func TestHandleSessionRecorded_FreshSolveFetchesStats(t *testing.T) {
+	percentile := 40.0
+	m := Model{
+		state:     StateSolved,
+		game:      gameModel{freshSolve: true, puzzle: &api.Puzzle{ID: "game-001"}},
+		claimCode: "TIGER-MAPLE-7492",
+		client:    newTestClient(t),
+	}
+
+	resultModel, cmd := m.Update(sessionRecordedMsg{gameID: "game-001", percentile: &percentile})
+	result := resultModel.(Model)
+
+	if result.state != StateSolved {
+		t.Errorf("state: want StateSolved (%d), got %d", StateSolved, result.state)
+	}
+	if result.game.percentile == nil || *result.game.percentile != 40 {
+		t.Errorf("percentile: want 40, got %v", result.game.percentile)
+	}
+	if cmd == nil {
+		t.Error("cmd: want non-nil batch (mark uploaded + fetch stats), got nil")
+	}
+}
+
+// TestSolveStatsFetchedMsg_StaysOnSolvedScreen verifies background stats don't
+// navigate away from the solved screen.
+func TestSolveStatsFetchedMsg_StaysOnSolvedScreen(t *testing.T) {
+	m := Model{state: StateSolved}
+
+	resultModel, _ := m.Update(solveStatsFetchedMsg{stats: sampleStats()})
+	result := resultModel.(Model)
+
+	if result.state != StateSolved {
+		t.Errorf("state: want StateSolved (%d), got %d", StateSolved, result.state)
+	}
+	if result.stats.player == nil {
+		t.Error("stats: want stored, got nil")
+	}
+}
+
+// TestStatsLayout_BySize verifies the layout is chosen from the terminal size.
+func TestStatsLayout_BySize(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		want   statsLayout
+	}{
+		{name: "wide terminal", width: 120, height: 40, want: statsLayoutSideBySide},
+		{name: "80 columns, tall", width: 80, height: 40, want: statsLayoutStacked},
+		{name: "80 columns, short", width: 80, height: 24, want: statsLayoutPaged},
+		{name: "minimum size", width: MinTerminalWidth, height: MinTerminalHeight, want: statsLayoutPaged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statsLayoutFor(tt.width, tt.height); got != tt.want {
+				t.Errorf("statsLayoutFor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestViewStats_StackedLayout verifies narrow-but-tall terminals show both the
+// graph and the numbers, with the numbers below the graph.
+func TestViewStats_StackedLayout(t *testing.T) {
+	m := statsScreenModel(sampleStats())
+	m.width, m.height = 70, 40
+	view := m.viewStats()
+
+	graphAt := strings.Index(view, "Solve Times")
+	numbersAt := strings.Index(view, "Games Played")
+	if graphAt < 0 || numbersAt < 0 {
+		t.Fatalf("stacked view should contain graph and numbers, got: %q", view)
+	}
+	if numbersAt < graphAt {
+		t.Error("stacked view should render numbers below the graph")
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if w := lipgloss.Width(line); w > m.width {
+			t.Errorf("line width %d exceeds terminal width %d: %q", w, m.width, line)
+		}
+	}
+}
+
+// TestViewStats_PagedLayout verifies short narrow terminals show one page at a
+// time, switched with left/right.
+func TestViewStats_PagedLayout(t *testing.T) {
+	m := statsScreenModel(sampleStats())
+	m.width, m.height = 70, 20
+
+	view := m.viewStats()
+	if !strings.Contains(view, "Solve Times") || strings.Contains(view, "Games Played") {
+		t.Error("first page should show only the graph")
+	}
+	if !strings.Contains(view, "[←/→] Page") {
+		t.Error("paged view should show page navigation help")
+	}
+
+	resultModel, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	m = resultModel.(Model)
+	view = m.viewStats()
+	if strings.Contains(view, "Solve Times") || !strings.Contains(view, "Games Played") {
+		t.Error("after → the numbers page should be shown")
+	}
+
+	resultModel, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	m = resultModel.(Model)
+	if m.stats.page != statsPageGraph {
+		t.Errorf("after ← statsPage = %d, want graph page", m.stats.page)
+	}
+	if m.state != StateStats {
+		t.Errorf("page navigation should stay on stats screen, got state %d", m.state)
+	}
+}
+
+// TestViewStats_WeeklyGoal verifies the goal row appears once progress loads.
+func TestViewStats_WeeklyGoal(t *testing.T) {
+	m := statsScreenModel(sampleStats())
+	if strings.Contains(m.viewStats(), "Weekly Goal") {
+		t.Error("no goal row should show before a goal is loaded")
+	}
+
+	now := time.Now()
+	model, _ := m.Update(goalLoadedMsg{progress: goal.Compute(2, []time.Time{now}, now)})
+	view := model.(Model).viewStats()
+	if !strings.Contains(view, "Weekly Goal") || !strings.Contains(view, "1/2 days") {
+		t.Errorf("stats should show goal progress:\n%s", view)
+	}
+}
+
+// TestViewStats_CleanSolves verifies the clean solves row appears only when the
+// server reports it.
+func TestViewStats_CleanSolves(t *testing.T) {
+	stats := sampleStats()
+	if strings.Contains(statsScreenModel(stats).viewStats(), "Clean Solves") {
+		t.Error("no clean solves row should show when the server doesn't report them")
+	}
+
+	clean := 31
+	stats.CleanSolves = &clean
+	if view := statsScreenModel(stats).viewStats(); !strings.Contains(view, "Clean Solves") || !strings.Contains(view, "31 of 40") {
+		t.Errorf("stats should show clean solves:\n%s", view)
+	}
+}
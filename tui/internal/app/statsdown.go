@@ -0,0 +1,76 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// statsDownText is the banner shown while the stats endpoints fail and the
+// puzzle endpoints don't.
+const statsDownText = "Stats temporarily unavailable — your solves are saved locally"
+
+// noteStatsDown raises the stats banner after a stats call failed. Offline
+// play has its own notice, and a banner the player dismissed stays away for
+// the rest of the run.
+func (m Model) noteStatsDown() Model {
+	if m.game.offline || m.connection == connOffline || m.errs.statsDismissed {
+		return m
+	}
+	return m.setStatsDown(true)
+}
+
+// setStatsDown raises or lowers the stats banner, resizing the grid around it.
+func (m Model) setStatsDown(down bool) Model {
+	if m.errs.stats == down {
+		return m
+	}
+	m.errs.stats = down
+	return m.syncGridView(false)
+}
+
+// handleStatsFailed keeps the game going when stats can't be fetched: the
+// player goes back to the solved screen they asked from, with the banner up.
+// Without a puzzle to go back to, it is an ordinary error. A failed fetch a
+// newer one replaced is dropped.
+func (m Model) handleStatsFailed(msg statsFailedMsg) (tea.Model, tea.Cmd) {
+	if msg.screen {
+		if m.stats.stale(msg.fetch) {
+			return m, nil
+		}
+		if m.game.puzzle == nil {
+			return m.handleError(errMsg{err: msg.err})
+		}
+		if m.state == StateLoading {
+			m.state = StateSolved
+		}
+	}
+	return m.noteStatsDown(), nil
+}
+
+// handleSessionRecordFailed raises the banner after a solve failed to
+// upload, and keeps why for the status bar. The session stays solved and not uploaded, so reconciliation
+// sends it later.
+func (m Model) handleSessionRecordFailed(msg sessionRecordFailedMsg) (tea.Model, tea.Cmd) {
+	return m.noteSyncError(msg.err).noteStatsDown(), countPendingCmd()
+}
+
+// showsStatsBanner reports whether the stats banner is on screen: it is only
+// shown over the puzzle.
+func (m Model) showsStatsBanner() bool {
+	return m.errs.stats && (m.state == StatePlaying || m.state == StateChecking || m.state == StateSolved)
+}
+
+// dismissStatsBanner hides the banner for the rest of the run.
+func (m Model) dismissStatsBanner() Model {
+	m.errs.statsDismissed = true
+	return m.setStatsDown(false)
+}
+
+// renderStatsBanner renders the stats banner, or nothing when it is down.
+func (m Model) renderStatsBanner() string {
+	if !m.showsStatsBanner() {
+		return ""
+	}
+	return ui.WarningStyle.Render(statsDownText)
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+)
+
+var errStatsDown = &api.APIError{Status: 500, Body: "database unavailable"}
+
+func TestStatsFailed_StaysOnSolvedScreen(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.state = StateLoading // the player pressed s on the solved screen
+	m.claimCode = "TIGER-MAPLE-7492"
+
+	model, _ := m.Update(statsFailedMsg{err: errStatsDown, screen: true})
+	m = model.(Model)
+	if m.state != StateSolved {
+		t.Fatalf("state = %v, want the solved screen back", m.state)
+	}
+	if screen := ansi.Strip(m.layoutPlaying("", "")); !strings.Contains(screen, statsDownText) {
+		t.Errorf("solved screen should show the stats banner:\n%s", screen)
+	}
+	if !slices.Contains(m.helpItems(), helpDismiss) {
+		t.Error("the banner should offer to be dismissed")
+	}
+}
+
+func TestStatsFailed_DismissedBannerStaysDown(t *testing.T) {
+	m := revealModel(nil, 0)
+	model, _ := m.Update(sessionRecordFailedMsg{gameID: "game-001", err: errStatsDown})
+	m = model.(Model)
+	if m.state != StatePlaying || !m.showsStatsBanner() {
+		t.Fatalf("state = %v, banner = %v; want play going on under the banner", m.state, m.showsStatsBanner())
+	}
+
+	model, _ = m.handleKeyMsg(tea.KeyPressMsg{Code: 'x', Mod: tea.ModCtrl})
+	m = model.(Model)
+	if m.showsStatsBanner() {
+		t.Error("Ctrl+X should dismiss the banner")
+	}
+	model, _ = m.Update(statsFailedMsg{err: errStatsDown})
+	if m = model.(Model); m.showsStatsBanner() {
+		t.Error("a dismissed banner came back for the rest of the run")
+	}
+}
+
+func TestStatsFailed_BannerClearsWhenStatsRecover(t *testing.T) {
+	m := revealModel(nil, 0)
+	model, _ := m.Update(statsFailedMsg{err: errStatsDown})
+	m = model.(Model)
+	if !m.showsStatsBanner() {
+		t.Fatal("a background stats failure should raise the banner")
+	}
+
+	model, _ = m.Update(sessionRecordedMsg{gameID: "game-001"})
+	if m = model.(Model); m.showsStatsBanner() {
+		t.Error("the banner should go once a solve uploads")
+	}
+}
+
+func TestStatsFailed_NoBannerOffline(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.game.offline = true
+	model, _ := m.Update(sessionRecordFailedMsg{gameID: "game-001", err: errors.New("connection refused")})
+	if m = model.(Model); m.showsStatsBanner() {
+		t.Error("offline play has its own notice; no stats banner")
+	}
+}
+
+func TestStatsFailed_WithoutPuzzleIsAnError(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.state = StateLoading
+	m.game.puzzle = nil
+
+	model, _ := m.Update(statsFailedMsg{err: errStatsDown, screen: true})
+	if m = model.(Model); m.state != StateError {
+		t.Errorf("state = %v, want the error screen with nothing to go back to", m.state)
+	}
+}
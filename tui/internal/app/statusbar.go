@@ -0,0 +1,148 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+	"github.com/bojanrajkovic/unquote/tui/internal/versioninfo"
+)
+
+// statusBarHeight is the number of lines the footer takes at the bottom of
+// every screen.
+const statusBarHeight = 1
+
+// healthCheckInterval is how often the API's reachability is re-checked.
+const healthCheckInterval = time.Minute
+
+// connectivity is whether the API could be reached, as last checked.
+type connectivity int
+
+const (
+	connUnknown connectivity = iota
+	connOnline
+	connOffline
+)
+
+func (c connectivity) String() string {
+	switch c {
+	case connOnline:
+		return "Online"
+	case connOffline:
+		return "Offline"
+	default:
+		return "Connecting..."
+	}
+}
+
+// checkHealthCmd probes the API after the given delay. Always produces a
+// healthCheckedMsg, so the caller can schedule the next check.
+func checkHealthCmd(client *api.Client, after time.Duration) tea.Cmd {
+	return tea.Tick(after, func(time.Time) tea.Msg {
+		return healthCheckedMsg{online: client.CheckHealth() == nil}
+	})
+}
+
+// countPendingCmd counts solved daily sessions not yet uploaded to the server.
+// Best-effort: storage errors produce no message.
+func countPendingCmd() tea.Cmd {
+	return func() tea.Msg {
+		sessions, err := storage.ListSolvedSessions()
+		if err != nil {
+			return nil
+		}
+		return pendingSyncMsg{count: len(sessions)}
+	}
+}
+
+// checkForUpdateCmd looks up the newest release and reports it when it is
+// newer than this build. Development builds skip the lookup; failures are
+// silent.
+func checkForUpdateCmd(client *api.Client) tea.Cmd {
+	info := versioninfo.Get()
+	if !info.IsRelease() {
+		return nil
+	}
+	return func() tea.Msg {
+		latest, err := client.FetchLatestVersion()
+		if err != nil || !info.UpdateAvailable(latest) {
+			return nil
+		}
+		return updateAvailableMsg{version: latest}
+	}
+}
+
+// handleHealthChecked records the API's reachability and schedules the next
+// check, asking for the API's capabilities if they aren't known yet.
+func (m Model) handleHealthChecked(msg healthCheckedMsg) (tea.Model, tea.Cmd) {
+	m.connection = connOffline
+	if msg.online {
+		m.connection = connOnline
+	}
+	next := checkHealthCmd(m.client, healthCheckInterval)
+	// The capabilities lookup at startup may have found the API unreachable
+	if msg.online && m.capabilities == nil {
+		return m, tea.Batch(next, fetchCapabilitiesCmd(m.client))
+	}
+	return m, next
+}
+
+// renderStatusBar renders the footer: the toast on screen, if any, otherwise
+// API connectivity, solves waiting to be uploaded, and a notice when a newer
+// release is out.
+func (m Model) renderStatusBar() string {
+	if t, ok := m.currentToast(); ok {
+		text := ui.SanitizeString(t.text)
+		if m.accessible {
+			return text
+		}
+		if m.width > 0 {
+			text = ansi.Truncate(text, m.width, "…")
+		}
+		return t.level.style().Render(text)
+	}
+
+	parts := []string{m.connection.String()}
+	if m.claimCode != "" && m.pendingSync > 0 {
+		noun := "solves"
+		if m.pendingSync == 1 {
+			noun = "solve"
+		}
+		parts = append(parts, fmt.Sprintf("%d %s waiting to sync", m.pendingSync, noun))
+		if m.errs.sync != "" {
+			parts = append(parts, "Last sync failed: "+m.errs.sync)
+		}
+	}
+	if m.latestVersion != "" {
+		parts = append(parts, "Update available: "+ui.SanitizeString(m.latestVersion))
+	}
+
+	bar := strings.Join(parts, " · ")
+	if m.accessible {
+		return bar
+	}
+	if m.width > 0 {
+		bar = ansi.Truncate(bar, m.width, "…")
+	}
+	return ui.StatusBarStyle.Render(bar)
+}
+
+// withStatusBar pins the status bar to the bottom of the terminal, below
+// content. The accessible layout reads top to bottom, so there it simply
+// follows the content.
+func (m Model) withStatusBar(content string) string {
+	if m.accessible {
+		return content + "\n\n" + m.renderStatusBar()
+	}
+	if gap := m.height - lipgloss.Height(content) - statusBarHeight; gap > 0 {
+		content += strings.Repeat("\n", gap)
+	}
+	return content + "\n" + m.renderStatusBar()
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+func TestRenderStatusBar(t *testing.T) {
+	tests := []struct {
+		name  string
+		model Model
+		want  string
+	}{
+		{name: "before the first check", want: "Connecting..."},
+		{name: "online", model: Model{connection: connOnline}, want: "Online"},
+		{
+			name:  "pending solves",
+			model: Model{connection: connOffline, claimCode: "TIGER-MAPLE-7492", pendingSync: 2},
+			want:  "Offline · 2 solves waiting to sync",
+		},
+		{
+			name:  "failed sync",
+			model: Model{connection: connOnline, claimCode: "TIGER-MAPLE-7492", pendingSync: 1, errs: errorState{sync: "The server is busy."}},
+			want:  "Online · 1 solve waiting to sync · Last sync failed: The server is busy.",
+		},
+		{
+			name:  "pending without a claim code",
+			model: Model{connection: connOnline, pendingSync: 2},
+			want:  "Online",
+		},
+		{
+			name:  "update available",
+			model: Model{connection: connOnline, claimCode: "TIGER-MAPLE-7492", pendingSync: 1, latestVersion: "0.9.0"},
+			want:  "Online · 1 solve waiting to sync · Update available: 0.9.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ansi.Strip(tt.model.renderStatusBar()); got != tt.want {
+				t.Errorf("renderStatusBar() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestView_PinsStatusBar(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.sizeReady = true
+	m.connection = connOnline
+
+	lines := strings.Split(ansi.Strip(m.View().Content), "\n")
+	if len(lines) != m.height {
+		t.Errorf("view is %d lines, want it to fill the %d-line terminal", len(lines), m.height)
+	}
+	if last := lines[len(lines)-1]; last != "Online" {
+		t.Errorf("last line = %q, want the status bar", last)
+	}
+}
+
+func TestHealthChecked_SchedulesNextCheck(t *testing.T) {
+	m := Model{client: newTestClient(t)}
+
+	model, cmd := m.Update(healthCheckedMsg{online: false})
+	if model.(Model).connection != connOffline {
+		t.Errorf("connection = %v, want Offline", model.(Model).connection)
+	}
+	if cmd == nil {
+		t.Error("a health check should schedule the next one")
+	}
+
+	model, _ = model.Update(healthCheckedMsg{online: true})
+	if model.(Model).connection != connOnline {
+		t.Errorf("connection = %v, want Online", model.(Model).connection)
+	}
+}
+
+func TestCountPendingCmd(t *testing.T) {
+	storagetest.UseMemory(t)
+
+	for _, s := range []*storage.GameSession{
+		{GameID: "solved", Solved: true},
+		{GameID: "uploaded", Solved: true, Uploaded: true},
+		{GameID: "unsolved"},
+	} {
+		if err := storage.SaveSession(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	msg, ok := countPendingCmd()().(pendingSyncMsg)
+	if !ok || msg.count != 1 {
+		t.Errorf("countPendingCmd() = %#v, want one solve waiting to sync", msg)
+	}
+}
+
+func TestUpdateAvailableMsg(t *testing.T) {
+	model, _ := Model{}.Update(updateAvailableMsg{version: "0.9.0"})
+	if got := model.(Model).latestVersion; got != "0.9.0" {
+		t.Errorf("latestVersion = %q, want 0.9.0", got)
+	}
+}
+
+func TestReconciliationDone_ToastsWhenAllSynced(t *testing.T) {
+	m := Model{state: StateSolved, claimCode: "TIGER-MAPLE-7492", pendingSync: 2, ticking: true}
+
+	model, _ := m.Update(reconciliationDoneMsg{pending: 1})
+	if m = model.(Model); m.pendingSync != 1 || toastText(m) != "" {
+		t.Fatalf("pending = %d, toast %q; want no toast while solves still wait", m.pendingSync, toastText(m))
+	}
+	model, _ = m.Update(reconciliationDoneMsg{pending: 0})
+	if got := toastText(model.(Model)); got != "All solves synced" {
+		t.Errorf("toast = %q, want the sync reported", got)
+	}
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"runtime"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// suspendKey stops unquote for the shell's job control, as in any terminal
+// program; fg resumes it. It works on every screen.
+const suspendKey = "ctrl+z"
+
+// canSuspend reports whether the platform has job control to suspend to.
+// Bubble Tea ignores tea.Suspend on Windows, and no ResumeMsg would come to
+// start the clock again.
+func canSuspend() bool {
+	return runtime.GOOS != "windows"
+}
+
+// suspend freezes the game clock and saves the game before Bubble Tea hands
+// the terminal back and stops the process, which may never be resumed. The
+// clock stays frozen until ResumeMsg arrives, so a tick that lands first
+// doesn't count the time spent stopped.
+func (m Model) suspend() (tea.Model, tea.Cmd) {
+	m.suspendedAt = m.clock().Now()
+	save := saveSessionCmd(m.sessions(), m.suspendedAt, m.game.puzzle, m.game.cells, m.Elapsed(), m.run, m.game.letters, m.game.assists)
+	if _, session := m.PendingSession(); session == nil {
+		save = nil
+	}
+	return m, tea.Sequence(save, tea.Suspend)
+}
+
+// handleResume picks up after the process was continued. The game's start
+// moves forward by the time spent stopped, so the clock carries on where it
+// froze, and the window size is asked for again in case the terminal was
+// resized meanwhile; the screen is cleared of whatever the shell left there.
+func (m Model) handleResume() (tea.Model, tea.Cmd) {
+	if m.suspendedAt.IsZero() {
+		return m, nil
+	}
+	stopped := m.clock().Now().Sub(m.suspendedAt)
+	if !m.game.startTime.IsZero() && stopped > 0 {
+		m.game.startTime = m.game.startTime.Add(stopped)
+	}
+	if !m.lastInput.IsZero() && stopped > 0 {
+		m.lastInput = m.lastInput.Add(stopped) // time stopped isn't time idle
+	}
+	m.suspendedAt = time.Time{}
+	return m, tea.Batch(tea.RequestWindowSize, tea.ClearScreen)
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// Ctrl+Z saves the game, then suspends; the time spent stopped never reaches
+// the clock, and resuming asks for the window size again.
+func TestSuspend_FreezesClockUntilResumed(t *testing.T) {
+	if !canSuspend() {
+		t.Skip("no job control to suspend to")
+	}
+	storagetest.UseMemory(t)
+	m, clk := clockModel(t)
+	puzzle.SetInput(m.game.cells, m.game.cursorPos, 'T')
+	clk.Advance(time.Minute)
+
+	model, cmd := m.Update(tea.KeyPressMsg{Code: 'z', Mod: tea.ModCtrl})
+	m = model.(Model)
+	cmds := reflect.ValueOf(cmd())
+	if cmds.Kind() != reflect.Slice || cmds.Len() != 2 {
+		t.Fatalf("Ctrl+Z returned %#v, want the save and then the suspend in sequence", cmds.Interface())
+	}
+	cmds.Index(0).Interface().(tea.Cmd)()
+	if msg := cmds.Index(1).Interface().(tea.Cmd)(); msg != (tea.SuspendMsg{}) {
+		t.Errorf("last command returned %#v, want tea.SuspendMsg", msg)
+	}
+	if s, err := storage.LoadSession("game-0120"); err != nil || s == nil || s.ElapsedTime != time.Minute {
+		t.Errorf("LoadSession() = %+v, %v; want the game saved at 1m", s, err)
+	}
+
+	clk.Advance(time.Hour)
+	if got := m.Elapsed(); got != time.Minute {
+		t.Errorf("Elapsed() while stopped = %v, want it frozen at 1m", got)
+	}
+
+	model, cmd = m.Update(tea.ResumeMsg{})
+	m = model.(Model)
+	if cmd == nil {
+		t.Error("resuming returned no command, want the window size asked for")
+	}
+	clk.Advance(time.Second)
+	if got := m.Elapsed(); got != time.Minute+time.Second {
+		t.Errorf("Elapsed() after resuming = %v, want 1m1s, without the hour stopped", got)
+	}
+}
+
+// A resume without a suspend from this model, such as after a failed one,
+// leaves the clock alone.
+func TestResume_WithoutSuspend(t *testing.T) {
+	m, clk := clockModel(t)
+	clk.Advance(time.Minute)
+
+	model, cmd := m.Update(tea.ResumeMsg{})
+	if got := model.(Model).Elapsed(); got != time.Minute || cmd != nil {
+		t.Errorf("Elapsed() = %v, cmd = %v; want 1m and nothing to do", got, cmd)
+	}
+}
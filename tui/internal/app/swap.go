@@ -0,0 +1,82 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// swapOpen reports whether Ctrl+T is waiting for the cipher letter to swap
+// with.
+func (m Model) swapOpen() bool {
+	return m.game.swapping && m.state == StatePlaying
+}
+
+// swapPrompt asks for the cipher letter whose input trades places with the
+// one under the cursor.
+func (m Model) swapPrompt() string {
+	return fmt.Sprintf("Swap %c with cipher letter: ", m.cursorCipher())
+}
+
+// startSwap waits for the cipher letter to swap inputs with, when the cursor
+// is on a letter.
+func (m Model) startSwap() (tea.Model, tea.Cmd) {
+	if m.cursorCipher() != 0 {
+		m.game.swapping = true
+	}
+	return m, nil
+}
+
+// handleSwapKeyMsg takes the key typed after Ctrl+T: a letter swaps the
+// inputs of the cipher letter under the cursor and that cipher letter, Esc
+// gives up. Any other key leaves the prompt waiting. The same chord again
+// swaps them back.
+func (m Model) handleSwapKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" {
+		m.game.swapping = false
+		return m, nil
+	}
+	text := msg.Text
+	if text == "" {
+		text = msg.String()
+	}
+	other, ok := puzzle.InputLetter(text, false)
+	if !ok {
+		return m, nil
+	}
+	m.game.swapping = false
+
+	cipher := puzzle.NormalizeLetter(m.cursorCipher())
+	if cipher == 0 || other == cipher {
+		return m, nil
+	}
+	if nextCipherCell(m.game.cells, m.game.cursorPos, other) < 0 {
+		return m.notify(toastWarning, fmt.Sprintf("No cipher letter %c to swap with", other))
+	}
+	m = m.pushUndo()
+	if !puzzle.SwapInputs(m.game.cells, cipher, other) {
+		return m.notify(toastWarning, fmt.Sprintf("Can't swap %c and %c: locked or both empty", cipher, other))
+	}
+
+	m, cmd := m.notify(toastInfo, fmt.Sprintf("Swapped %c and %c; Ctrl+U undoes it", cipher, other))
+	return m, tea.Batch(cmd, saveSessionCmd(m.sessions(), m.clock().Now(), m.game.puzzle, m.game.cells, m.Elapsed(), m.run, m.game.letters, m.game.assists))
+}
+
+// renderSwap renders the prompt waiting for the letter to swap with.
+func (m Model) renderSwap() string {
+	if !m.swapOpen() {
+		return ""
+	}
+	return ui.LoadingStyle.Render(m.swapPrompt() + "_")
+}
+
+// accessibleSwap is renderSwap as plain text.
+func (m Model) accessibleSwap() string {
+	if !m.swapOpen() {
+		return ""
+	}
+	return fmt.Sprintf("Type a cipher letter to swap its letter with %c's. Esc cancels.", m.cursorCipher())
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+var ctrlT = tea.KeyPressMsg{Code: 't', Mod: tea.ModCtrl}
+
+func TestSwap_ChordSwapsAndUndoes(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.game.puzzle = &api.Puzzle{ID: "game-001", EncryptedText: "AB CA"}
+	m.game.cells = puzzle.BuildCells(m.game.puzzle.EncryptedText, nil)
+	puzzle.SetInput(m.game.cells, 0, 'T')
+	puzzle.SetInput(m.game.cells, 1, 'O')
+	m.game.cursorPos = 0
+
+	m, _ = press(t, m, ctrlT)
+	if !m.swapOpen() {
+		t.Fatal("Ctrl+T should wait for a cipher letter")
+	}
+	if prompt := ansi.Strip(m.layoutPlaying("", "")); !strings.Contains(prompt, "Swap A with cipher letter:") {
+		t.Errorf("playing screen should show the swap prompt:\n%s", prompt)
+	}
+
+	m, cmd := press(t, m, tea.KeyPressMsg{Code: 'b', Text: "b"})
+	if m.swapOpen() {
+		t.Error("the prompt should close after the letter")
+	}
+	if got := puzzle.AssembleSolution(m.game.cells); got != "OT _O" {
+		t.Errorf("after Ctrl+T b: %q, want A and B's letters swapped", got)
+	}
+	if cmd == nil {
+		t.Error("swapping should save the session")
+	}
+	if m.game.cursorPos != 0 {
+		t.Errorf("cursorPos = %d, want the cursor left in place", m.game.cursorPos)
+	}
+
+	m, _ = press(t, m, ctrlT)
+	m, _ = press(t, m, tea.KeyPressMsg{Code: 'b', Text: "b"})
+	if got := puzzle.AssembleSolution(m.game.cells); got != "TO _T" {
+		t.Errorf("the same chord again should undo the swap, got %q", got)
+	}
+}
+
+func TestSwap_Rejected(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.game.cursorPos = 0
+
+	m, _ = press(t, m, ctrlT)
+	m, _ = press(t, m, tea.KeyPressMsg{Code: 'q', Text: "q"})
+	if got := lastToast(m); got != "No cipher letter Q to swap with" {
+		t.Errorf("toast = %q, want a warning for a letter not in the puzzle", got)
+	}
+
+	m, _ = press(t, m, ctrlT)
+	m, _ = press(t, m, tea.KeyPressMsg{Code: 'b', Text: "b"})
+	if got := lastToast(m); !strings.HasPrefix(got, "Can't swap A and B") {
+		t.Errorf("toast = %q, want a warning when both are empty", got)
+	}
+
+	m, _ = press(t, m, ctrlT)
+	m, cmd := press(t, m, tea.KeyPressMsg{Code: tea.KeyEsc})
+	if m.swapOpen() || cmd != nil {
+		t.Errorf("Esc should close the prompt without quitting; open %v, cmd %v", m.swapOpen(), cmd)
+	}
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/clock"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// SyncResult is what a reconciliation with the server did.
+type SyncResult struct {
+	Replayed int `json:"replayed"` // uploads the journal said the server already had, marked without sending again
+	Uploaded int `json:"uploaded"` // solves sent to the server
+	Pending  int `json:"pending"`  // solves that still failed to upload
+}
+
+// Sync runs the reconciliation the interactive UI starts with, for 'unquote
+// sync': it uploads the solves the server hasn't been sent, reports unfinished
+// puzzles as attempts unless cfg skips them, and sends queued difficulty
+// ratings. The error is the first upload that failed; the rest are still
+// tried.
+func Sync(client *api.Client, cfg *config.Config) (SyncResult, error) {
+	return reconcile(client, clock.System, cfg.ClaimCode, cfg.StatsEnabled && !cfg.SkipAttempts)
+}
+
+// reconcile uploads all solved-but-not-uploaded sessions and, when attempts
+// is set, reports unsolved ones as attempts. Only solves count toward the
+// pending total. Uploads the journal says the server already accepted are
+// marked first rather than sent again, and difficulty ratings queued while
+// offline go out with them.
+func reconcile(client *api.Client, clk clock.Clock, claimCode string, attempts bool) (SyncResult, error) {
+	var result SyncResult
+	result.Replayed, _ = storage.ReplayUploads()
+	if attempts {
+		reportAttempts(client, claimCode)
+	}
+	sendQueuedRatings(client)
+
+	var err error
+	result.Uploaded, result.Pending, err = uploadSolves(client, clk, claimCode, time.Time{})
+	return result, err
+}
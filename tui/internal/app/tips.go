@@ -0,0 +1,72 @@
+package app
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// tipArrowMoves is how many arrow-key moves on one puzzle earn the click tip.
+const tipArrowMoves = 5
+
+// tip is a pointer shown once, as a toast, the first time the player does
+// something it helps with. Seen tips are kept in Config.HintsSeen.
+type tip struct {
+	id   string // stored in Config.HintsSeen; never change a shipped one
+	text string
+}
+
+var (
+	tipPropagate = tip{id: "propagate", text: "Tip: letters propagate to all matching cipher letters"}
+	tipClick     = tip{id: "click", text: "Tip: click a cell to jump there"}
+)
+
+// offersTips reports whether tips may be shown: the player hasn't turned them
+// off and isn't in the tutorial, which explains the same things.
+func (m Model) offersTips() bool {
+	return m.cfg != nil && !m.cfg.NoTips && !m.inTutorial()
+}
+
+// showTip shows t as a toast and marks it seen, unless tips are off or it was
+// shown before. The config is saved so it stays seen.
+func (m Model) showTip(t tip) (Model, tea.Cmd) {
+	if !m.offersTips() || slices.Contains(m.cfg.HintsSeen, t.id) {
+		return m, nil
+	}
+	cfg := *m.cfg
+	cfg.HintsSeen = append(slices.Clone(cfg.HintsSeen), t.id)
+	m.cfg = &cfg
+
+	m, cmd := m.notify(toastInfo, t.text)
+	if m.opts.Ephemeral {
+		return m, cmd
+	}
+	return m, tea.Batch(cmd, savePreferencesCmd(m.cfg))
+}
+
+// tipAfterLetter shows the propagation tip once a letter fills more than one
+// cell.
+func (m Model) tipAfterLetter(cipher rune) (Model, tea.Cmd) {
+	count := 0
+	for _, c := range m.game.cells {
+		if c.Kind == puzzle.CellLetter && c.Char == cipher {
+			count++
+		}
+	}
+	if count < 2 {
+		return m, nil
+	}
+	return m.showTip(tipPropagate)
+}
+
+// tipAfterArrow counts an arrow-key move and, after a few, shows the click
+// tip. Accessible mode has no grid to click.
+func (m Model) tipAfterArrow() (Model, tea.Cmd) {
+	m.game.arrowMoves++
+	if m.game.arrowMoves < tipArrowMoves || m.accessible {
+		return m, nil
+	}
+	return m.showTip(tipClick)
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// tipsModel creates a playing Model for text with the given config.
+func tipsModel(cfg *config.Config, text string) Model {
+	cells := puzzle.BuildCells(text, nil)
+	return Model{
+		state: StatePlaying,
+		cfg:   cfg,
+		opts:  Options{Ephemeral: true},
+		game:  gameModel{puzzle: &api.Puzzle{ID: "game-001", EncryptedText: text}, cells: cells, cursorPos: puzzle.FirstLetterCell(cells)},
+	}
+}
+
+// typeKey plays a key press on m.
+func typeKey(m Model, key tea.KeyPressMsg) Model {
+	model, _ := m.handlePlayingKeyMsg(key)
+	return model.(Model)
+}
+
+func TestTips_PropagateShownOnceForRepeatedLetter(t *testing.T) {
+	storagetest.UseMemory(t)
+
+	m := tipsModel(&config.Config{}, "AB A")
+	m = typeKey(m, tea.KeyPressMsg{Code: 'x', Text: "x"})
+	if toastText(m) != tipPropagate.text {
+		t.Fatalf("tip = %q, want the propagation tip after a repeated letter", toastText(m))
+	}
+	if !slices.Contains(m.cfg.HintsSeen, tipPropagate.id) {
+		t.Errorf("HintsSeen = %v, want the tip marked seen", m.cfg.HintsSeen)
+	}
+
+	m = m.expireToasts(time.Now().Add(time.Hour))
+
+	m = typeKey(m, tea.KeyPressMsg{Code: tea.KeyBackspace})
+	m = typeKey(m, tea.KeyPressMsg{Code: 'y', Text: "y"})
+	if toastText(m) != "" {
+		t.Errorf("tip = %q, want a seen tip not shown again", toastText(m))
+	}
+}
+
+func TestTips_SingleLetterShowsNoTip(t *testing.T) {
+	storagetest.UseMemory(t)
+
+	m := typeKey(tipsModel(&config.Config{}, "AB"), tea.KeyPressMsg{Code: 'x', Text: "x"})
+	if toastText(m) != "" {
+		t.Errorf("tip = %q, want none for a letter that appears once", toastText(m))
+	}
+}
+
+func TestTips_ClickAfterArrowMoves(t *testing.T) {
+	m := tipsModel(&config.Config{}, "ABC")
+	for range tipArrowMoves - 1 {
+		m = typeKey(m, tea.KeyPressMsg{Code: tea.KeyRight})
+	}
+	if toastText(m) != "" {
+		t.Fatalf("tip = %q, want none before enough moves", toastText(m))
+	}
+	m = typeKey(m, tea.KeyPressMsg{Code: tea.KeyLeft})
+	if toastText(m) != tipClick.text {
+		t.Errorf("tip = %q, want the click tip", toastText(m))
+	}
+
+	m = tipsModel(&config.Config{}, "ABC")
+	m.accessible = true
+	for range tipArrowMoves {
+		m = typeKey(m, tea.KeyPressMsg{Code: tea.KeyRight})
+	}
+	if toastText(m) != "" {
+		t.Errorf("tip = %q, want no click tip in accessible mode", toastText(m))
+	}
+}
+
+func TestTips_Disabled(t *testing.T) {
+	storagetest.UseMemory(t)
+
+	m := typeKey(tipsModel(&config.Config{NoTips: true}, "AB A"), tea.KeyPressMsg{Code: 'x', Text: "x"})
+	if toastText(m) != "" || len(m.cfg.HintsSeen) != 0 {
+		t.Errorf("tip = %q, seen = %v; want no tips when turned off", toastText(m), m.cfg.HintsSeen)
+	}
+}
+
+func TestTips_SeenTipIsSaved(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	m := tipsModel(&config.Config{ClaimCode: "TIGER-MAPLE-7492"}, "AB A")
+	m.opts.Ephemeral = false
+	m, cmd := m.showTip(tipPropagate)
+	if cmd == nil {
+		t.Fatal("showing a tip should save the config")
+	}
+	// The batch is the tick loop that dismisses the toast, then the save
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("batch = %v, want the expiry and the save", batch)
+	}
+	batch[1]()
+	cfg, err := config.Load()
+	if err != nil || cfg == nil || !slices.Contains(cfg.HintsSeen, tipPropagate.id) || cfg.ClaimCode != m.cfg.ClaimCode {
+		t.Errorf("saved config = %+v, %v; want the tip seen alongside the claim code", cfg, err)
+	}
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"slices"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// toastQueueLimit is how many notices can wait their turn; past it, the one
+// on screen gives way early.
+const toastQueueLimit = 4
+
+// toastLevel is what kind of notice a toast is, which sets its style and how
+// long it stays by default.
+type toastLevel int
+
+const (
+	toastInfo    toastLevel = iota // tips and progress: "Sharing..."
+	toastSuccess                   // something the player asked for worked
+	toastWarning                   // it can't be done right now
+	toastError                     // it went wrong, or the answer is wrong
+)
+
+// duration is how long a toast of this level stays on screen by default.
+func (l toastLevel) duration() time.Duration {
+	switch l {
+	case toastSuccess:
+		return 3 * time.Second
+	case toastInfo:
+		return 6 * time.Second
+	default:
+		return 5 * time.Second
+	}
+}
+
+// style is how a toast of this level is rendered.
+func (l toastLevel) style() lipgloss.Style {
+	switch l {
+	case toastSuccess:
+		return ui.SuccessStyle
+	case toastWarning:
+		return ui.WarningStyle
+	case toastError:
+		return ui.ErrorStyle
+	default:
+		return ui.HelpStyle
+	}
+}
+
+// Toast topics: a toast on a topic replaces the last one on it instead of
+// waiting behind it, since only the latest still holds.
+const (
+	topicAnswer = "answer" // checking the player's answer
+	topicShare  = "share"  // sharing the solve
+	topicLock   = "lock"   // locking and unlocking letters
+)
+
+// toast is a transient notice in the status bar. Toasts queue so none hides
+// another for good: each is shown for its full time, in the order raised.
+type toast struct {
+	expires time.Time // zero while it waits behind another toast
+	text    string
+	topic   string // see notifyAbout; empty for a toast that stands alone
+	level   toastLevel
+}
+
+// toastDuration is how long a toast of level stays on screen: the player's
+// Config.ToastSeconds when set, otherwise the level's default.
+func (m Model) toastDuration(level toastLevel) time.Duration {
+	if m.cfg != nil && m.cfg.ToastSeconds > 0 {
+		return time.Duration(m.cfg.ToastSeconds) * time.Second
+	}
+	return level.duration()
+}
+
+// notify queues a toast and makes sure the tick loop is running to dismiss
+// it. A toast already queued with the same text isn't repeated.
+func (m Model) notify(level toastLevel, text string) (Model, tea.Cmd) {
+	return m.notifyAbout("", level, text)
+}
+
+// notifyAbout is notify for a toast on topic, which first drops any toast
+// still queued on that topic.
+func (m Model) notifyAbout(topic string, level toastLevel, text string) (Model, tea.Cmd) {
+	toasts := slices.Clone(m.toasts)
+	if topic != "" {
+		toasts = slices.DeleteFunc(toasts, func(t toast) bool { return t.topic == topic })
+	}
+	if !slices.ContainsFunc(toasts, func(t toast) bool { return t.text == text }) {
+		toasts = append(toasts, toast{text: text, topic: topic, level: level})
+	}
+	if len(toasts) > toastQueueLimit {
+		toasts = toasts[len(toasts)-toastQueueLimit:]
+	}
+	m.toasts = toasts
+	m = m.showNextToast(m.clock().Now())
+	return m.startTick()
+}
+
+// expireToasts drops the toasts whose time is up at now and starts the clock
+// on the next. Called by the tick loop.
+func (m Model) expireToasts(now time.Time) Model {
+	for len(m.toasts) > 0 && !m.toasts[0].expires.IsZero() && !now.Before(m.toasts[0].expires) {
+		m.toasts = m.toasts[1:]
+		m = m.showNextToast(now)
+	}
+	return m
+}
+
+// showNextToast starts the clock on the toast at the front of the queue, if
+// it is waiting.
+func (m Model) showNextToast(now time.Time) Model {
+	if len(m.toasts) == 0 || !m.toasts[0].expires.IsZero() {
+		return m
+	}
+	m.toasts = slices.Clone(m.toasts)
+	m.toasts[0].expires = now.Add(m.toastDuration(m.toasts[0].level))
+	return m
+}
+
+// currentToast returns the toast on screen, if there is one.
+func (m Model) currentToast() (toast, bool) {
+	if len(m.toasts) == 0 {
+		return toast{}, false
+	}
+	return m.toasts[0], true
+}
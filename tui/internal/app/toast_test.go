@@ -0,0 +1,137 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+)
+
+// toastText returns the text of the toast on screen, or "" when there is none.
+func toastText(m Model) string {
+	t, ok := m.currentToast()
+	if !ok {
+		return ""
+	}
+	return t.text
+}
+
+func TestNotify_QueuesToastsInOrder(t *testing.T) {
+	m, clk := clockModel(t)
+
+	m, _ = m.notify(toastSuccess, "Added to favorites")
+	m, _ = m.notify(toastInfo, "Tip: click a cell to jump there")
+	if got := toastText(m); got != "Added to favorites" {
+		t.Fatalf("toast = %q, want the first one shown until its time is up", got)
+	}
+
+	clk.Advance(toastSuccess.duration())
+	m = m.expireToasts(clk.Now())
+	if got := toastText(m); got != "Tip: click a cell to jump there" {
+		t.Fatalf("toast = %q, want the tip next", got)
+	}
+
+	// The tip's time starts when it is shown, not when it was raised
+	clk.Advance(toastInfo.duration() - time.Second)
+	if m = m.expireToasts(clk.Now()); toastText(m) == "" {
+		t.Fatal("the tip was dismissed before its full time")
+	}
+	clk.Advance(time.Second)
+	if m = m.expireToasts(clk.Now()); len(m.toasts) != 0 {
+		t.Errorf("toasts = %v, want none left", m.toasts)
+	}
+}
+
+func TestNotify_SkipsRepeats(t *testing.T) {
+	m, _ := clockModel(t)
+
+	m, _ = m.notify(toastError, "Fill in all letters first!")
+	m, _ = m.notify(toastError, "Fill in all letters first!")
+	if len(m.toasts) != 1 {
+		t.Errorf("toasts = %v, want the repeat dropped", m.toasts)
+	}
+}
+
+func TestNotifyAbout_ReplacesToastOnTopic(t *testing.T) {
+	m, _ := clockModel(t)
+
+	m, _ = m.notifyAbout(topicShare, toastInfo, "Sharing...")
+	m, _ = m.notify(toastSuccess, "Note saved")
+	m, _ = m.notifyAbout(topicShare, toastSuccess, "Copied to clipboard!")
+
+	if len(m.toasts) != 2 || toastText(m) != "Note saved" || m.toasts[1].text != "Copied to clipboard!" {
+		t.Errorf("toasts = %v, want the note then only the share result", m.toasts)
+	}
+	if m.toasts[0].expires.IsZero() {
+		t.Error("the toast now at the front should be on the clock")
+	}
+}
+
+func TestNotify_CapsQueue(t *testing.T) {
+	m, _ := clockModel(t)
+
+	for _, text := range []string{"one", "two", "three", "four", "five"} {
+		m, _ = m.notify(toastInfo, text)
+	}
+	if len(m.toasts) != toastQueueLimit || toastText(m) != "two" {
+		t.Errorf("toasts = %v, want the oldest dropped", m.toasts)
+	}
+}
+
+func TestNotify_ToastSecondsOverridesDefault(t *testing.T) {
+	m, clk := clockModel(t)
+	m.cfg = &config.Config{ToastSeconds: 10}
+
+	m, _ = m.notify(toastSuccess, "Note saved")
+	if got := m.toasts[0].expires.Sub(clk.Now()); got != 10*time.Second {
+		t.Errorf("toast stays %v, want the configured 10s", got)
+	}
+}
+
+func TestNotify_StartsTickLoop(t *testing.T) {
+	m, _ := clockModel(t)
+	m.state, m.ticking = StateSolved, false
+	m.game.puzzle.Date = "2020-01-01" // a past puzzle, so the solved screen doesn't tick by itself
+
+	m, cmd := m.notify(toastSuccess, "Note saved")
+	if cmd == nil || !m.ticking {
+		t.Fatal("notify should start the tick loop to dismiss the toast")
+	}
+	if _, cmd = m.notify(toastInfo, "Tip"); cmd != nil {
+		t.Error("a second toast shouldn't start another tick loop")
+	}
+}
+
+func TestHandleTick_KeepsTickingWhileToastsQueued(t *testing.T) {
+	m, clk := clockModel(t)
+	m.state = StateSolved
+	m.game.puzzle.Date = "2020-01-01"
+	m, _ = m.notify(toastSuccess, "Note saved")
+
+	model, cmd := m.handleTick(tickMsg(clk.Now().Add(time.Second)))
+	if m = model.(Model); cmd == nil || toastText(m) != "Note saved" {
+		t.Fatalf("toast = %q, want it still shown and the loop going", toastText(m))
+	}
+
+	model, cmd = m.handleTick(tickMsg(clk.Now().Add(toastSuccess.duration())))
+	if m = model.(Model); cmd != nil || m.ticking || len(m.toasts) != 0 {
+		t.Errorf("toasts = %v, ticking %v; want the toast gone and the loop stopped", m.toasts, m.ticking)
+	}
+}
+
+func TestRenderStatusBar_ShowsToast(t *testing.T) {
+	m, _ := clockModel(t)
+
+	m, _ = m.notify(toastError, "Not quite right.")
+	if got := ansi.Strip(m.renderStatusBar()); !strings.Contains(got, "Not quite right.") {
+		t.Errorf("status bar = %q, want the toast", got)
+	}
+
+	m.accessible = true
+	if got := m.renderStatusBar(); !strings.Contains(got, "Not quite right.") {
+		t.Errorf("accessible status bar = %q, want the toast", got)
+	}
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+
+	tea "charm.land/bubbletea/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+)
+
+// tracerName is the instrumentation scope of the app's spans.
+const tracerName = "github.com/bojanrajkovic/unquote/tui/internal/app"
+
+// Phases of play, each a child span of the puzzle's trace.
+const (
+	phaseFetch      = "fetch puzzle" // asking the API for the puzzle
+	phaseFirstInput = "first input"  // puzzle on screen, nothing typed yet
+	phaseSolve      = "solve"        // first letter to the end of play
+)
+
+// Outcomes recorded on a puzzle's trace as unquote.outcome.
+const (
+	outcomeSolved        = "solved"
+	outcomeRevealed      = "revealed"
+	outcomeAlreadySolved = "already solved"
+	outcomeLeft          = "left" // another puzzle was loaded, or unquote quit
+	outcomeFetchFailed   = "fetch failed"
+)
+
+// puzzleTrace is one puzzle's trace: a root span from the fetch to the end
+// of play, with a child span for the current phase. API requests made
+// through client are children of the phase. With tracing off every span is a
+// no-op. It is shared by the copies of a Model, like the other pointers, and
+// only changed from Update.
+type puzzleTrace struct {
+	ctx      context.Context // carries the current phase's span
+	root     trace.Span
+	phase    trace.Span
+	phaseNow string
+	ended    bool
+}
+
+// startPuzzleTrace starts a puzzle's trace in its fetch phase.
+func startPuzzleTrace() *puzzleTrace {
+	ctx, root := otel.Tracer(tracerName).Start(context.Background(), "puzzle")
+	t := &puzzleTrace{ctx: ctx, root: root}
+	t.startPhase(phaseFetch)
+	return t
+}
+
+// startPhase ends the current phase and starts the named one.
+func (t *puzzleTrace) startPhase(name string) {
+	if t == nil || t.ended {
+		return
+	}
+	if t.phase != nil {
+		t.phase.End()
+	}
+	t.ctx, t.phase = otel.Tracer(tracerName).Start(trace.ContextWithSpan(context.Background(), t.root), name)
+	t.phaseNow = name
+}
+
+// shown marks the puzzle as on screen and waiting for the first input.
+func (t *puzzleTrace) shown(p *api.Puzzle) {
+	if t == nil || t.ended {
+		return
+	}
+	t.root.SetAttributes(attribute.String("unquote.game_id", p.ID), attribute.String("unquote.date", p.Date))
+	t.startPhase(phaseFirstInput)
+}
+
+// firstInput moves from waiting for the first input to solving.
+func (t *puzzleTrace) firstInput() {
+	if t != nil && t.phaseNow == phaseFirstInput {
+		t.startPhase(phaseSolve)
+	}
+}
+
+// end ends the trace with outcome, recording err when play ended in one.
+// Ending it again does nothing.
+func (t *puzzleTrace) end(outcome string, err error) {
+	if t == nil || t.ended {
+		return
+	}
+	t.ended = true
+	t.phase.End()
+	t.root.SetAttributes(attribute.String("unquote.outcome", outcome))
+	if err != nil {
+		t.root.RecordError(err)
+		t.root.SetStatus(codes.Error, err.Error())
+	}
+	t.root.End()
+}
+
+// client returns c with its requests traced under the current phase.
+func (t *puzzleTrace) client(c *api.Client) *api.Client {
+	if t == nil || c == nil {
+		return c
+	}
+	return c.WithContext(t.ctx)
+}
+
+// traceFetch runs fetch, which loads the puzzle through the client it is
+// given, in a new puzzle trace. The trace rides on the puzzleFetchedMsg;
+// when no puzzle arrives it ends there.
+func traceFetch(client *api.Client, fetch func(*api.Client) tea.Cmd) tea.Cmd {
+	t := startPuzzleTrace()
+	cmd := fetch(t.client(client))
+	return func() tea.Msg {
+		msg := cmd()
+		switch msg := msg.(type) {
+		case puzzleFetchedMsg:
+			msg.trace = t
+			return msg
+		case errMsg:
+			t.end(outcomeFetchFailed, msg.err)
+		default:
+			t.end(outcomeFetchFailed, nil)
+		}
+		return msg
+	}
+}
+
+// EndTrace ends the open puzzle's trace, if any, for when the program exits.
+func (m Model) EndTrace() {
+	m.game.trace.end(outcomeLeft, nil)
+}
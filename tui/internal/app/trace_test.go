@@ -0,0 +1,123 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// recordSpans installs a tracer provider that keeps every span for the test,
+// and no-ops after.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+	return recorder
+}
+
+// endedSpans returns the names of the ended spans, in the order they ended.
+func endedSpans(recorder *tracetest.SpanRecorder) []string {
+	var names []string
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+	}
+	return names
+}
+
+// outcome returns the unquote.outcome recorded on span.
+func outcome(span sdktrace.ReadOnlySpan) string {
+	for _, kv := range span.Attributes() {
+		if kv.Key == "unquote.outcome" {
+			return kv.Value.AsString()
+		}
+	}
+	return ""
+}
+
+func TestPuzzleTrace_FollowsPlay(t *testing.T) {
+	storagetest.UseMemory(t)
+	recorder := recordSpans(t)
+
+	fetch := traceFetch(nil, func(*api.Client) tea.Cmd {
+		return func() tea.Msg { return puzzleFetchedMsg{puzzle: &api.Puzzle{ID: "game-001", EncryptedText: "AB"}} }
+	})
+	msg := fetch().(puzzleFetchedMsg)
+	if msg.trace == nil {
+		t.Fatal("a fetched puzzle should carry its trace")
+	}
+	model, _ := Model{}.handlePuzzleFetched(msg)
+	m := model.(Model)
+
+	model, _ = m.handleLetterInput('x')
+	m = model.(Model)
+	model, _ = m.handleLetterInput('y')
+	m = model.(Model)
+	_, _ = m.handleSolutionChecked(solutionCheckedMsg{correct: true})
+
+	want := []string{phaseFetch, phaseFirstInput, phaseSolve, "puzzle"}
+	got := endedSpans(recorder)
+	if len(got) != len(want) {
+		t.Fatalf("ended spans = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ended spans = %v, want %v", got, want)
+		}
+	}
+	spans := recorder.Ended()
+	root := spans[3]
+	for _, phase := range spans[:3] {
+		if phase.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Errorf("%s should be a child of the puzzle span", phase.Name())
+		}
+	}
+	if outcome(root) != outcomeSolved {
+		t.Errorf("outcome = %q, want %q", outcome(root), outcomeSolved)
+	}
+}
+
+func TestTraceFetch_EndsWhenFetchFails(t *testing.T) {
+	recorder := recordSpans(t)
+
+	fetch := traceFetch(nil, func(*api.Client) tea.Cmd {
+		return func() tea.Msg { return errMsg{err: errors.New("server returned 503")} }
+	})
+	if _, ok := fetch().(errMsg); !ok {
+		t.Fatal("the fetch's error should come through")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 || spans[1].Name() != "puzzle" {
+		t.Fatalf("ended spans = %v, want the fetch phase and the puzzle", endedSpans(recorder))
+	}
+	if root := spans[1]; outcome(root) != outcomeFetchFailed || root.Status().Code != codes.Error {
+		t.Errorf("outcome = %q, status %v; want the failed fetch recorded", outcome(root), root.Status())
+	}
+}
+
+func TestResetGame_EndsTrace(t *testing.T) {
+	recorder := recordSpans(t)
+
+	m := Model{game: gameModel{trace: startPuzzleTrace()}}
+	m = m.resetGame()
+	m.EndTrace()
+
+	spans := recorder.Ended()
+	if m.game.trace != nil || len(spans) != 2 || outcome(spans[1]) != outcomeLeft {
+		t.Errorf("ended spans = %v, want the puzzle left once", endedSpans(recorder))
+	}
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// The tutorial's puzzle: short, well known and made of only six letters, so
+// a first-time player finishes it in a minute. One letter is given as a hint.
+const (
+	tutorialQuote  = "To be or not to be."
+	tutorialAuthor = "William Shakespeare"
+	tutorialHints  = 1
+)
+
+// tutorialCalloutWidth caps the width of a callout box, border included.
+const tutorialCalloutWidth = 64
+
+// tutorialStep is how far the player has got through the tutorial. Each step
+// after the first ends once the grid shows the player did what it asked.
+type tutorialStep int
+
+const (
+	tutorialOff      tutorialStep = iota // no tutorial running
+	tutorialCipher                       // what the cipher row is; Enter continues
+	tutorialType                         // type a guess
+	tutorialConflict                     // give the same letter to a second cipher letter
+	tutorialErase                        // clear the conflict
+	tutorialFinish                       // fill in the rest and submit
+)
+
+// tutorial is the scripted walk through a built-in puzzle, played after
+// onboarding or by 'unquote tutorial'. It plays as a custom puzzle, so
+// nothing in it is recorded.
+type tutorial struct {
+	local *puzzlegen.Puzzle // the run's own custom puzzle, put back when the tutorial ends
+	step  tutorialStep
+	guess rune // the first letter typed, suggested for the conflict
+}
+
+// inTutorial reports whether the tutorial puzzle is on screen.
+func (m Model) inTutorial() bool {
+	return m.tutorial.step != tutorialOff
+}
+
+// offersTutorial reports whether onboarding goes on to the tutorial: always,
+// except when a friend is waiting in a duel.
+func (m Model) offersTutorial() bool {
+	return m.duel.room == ""
+}
+
+// startTutorial loads the tutorial puzzle in place of the run's own, which
+// is loaded once the tutorial ends.
+func (m Model) startTutorial() (Model, tea.Cmd) {
+	p, err := puzzlegen.Generate(tutorialQuote, tutorialAuthor, tutorialHints)
+	if err != nil {
+		// The quote is fixed; this only fails if it is edited badly
+		m.state = StateLoading
+		return m.fetch()
+	}
+	m.tutorial = tutorial{local: m.opts.Local, step: tutorialCipher}
+	m.opts.Local = p
+	m.state = StateLoading
+	return m.startFetch(localPuzzleCmd(p, "Tutorial"))
+}
+
+// endTutorial leaves the tutorial. 'unquote tutorial' quits; after
+// onboarding, the run's own puzzle loads.
+func (m Model) endTutorial() (tea.Model, tea.Cmd) {
+	if m.opts.Tutorial {
+		return m, tea.Quit
+	}
+	m.opts.Local = m.tutorial.local
+	m.tutorial = tutorial{}
+	m = m.resetGame()
+	m.state = StateLoading
+	return m.fetch()
+}
+
+// handleTutorialKeyMsg handles a key on the tutorial puzzle: Tab skips the
+// rest, Enter on the first step or the solved screen moves on, and anything
+// else plays as usual before the script checks whether the step is done.
+func (m Model) handleTutorialKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "tab":
+		return m.endTutorial()
+	case msg.String() == "ctrl+g":
+		return m.toggleCompactGrid()
+	case m.state == StateSolved:
+		if msg.String() == "enter" {
+			return m.endTutorial()
+		}
+		return m, nil
+	case m.tutorial.step == tutorialCipher && msg.String() == "enter":
+		m.tutorial.step = tutorialType
+		return m, nil
+	}
+
+	model, cmd := m.handlePlayingKeyMsg(msg)
+	return model.(Model).advanceTutorial(), cmd
+}
+
+// advanceTutorial moves past every step the grid shows is done. Typing on
+// the first step skips its Enter.
+func (m Model) advanceTutorial() Model {
+	for {
+		switch m.tutorial.step {
+		case tutorialCipher, tutorialType:
+			guess := firstGuess(m.game.cells)
+			if guess == 0 {
+				return m
+			}
+			m.tutorial.guess = guess
+			m.tutorial.step = tutorialConflict
+		case tutorialConflict:
+			if len(findDuplicateInputs(m.game.cells)) == 0 {
+				return m
+			}
+			m.tutorial.step = tutorialErase
+		case tutorialErase:
+			if len(findDuplicateInputs(m.game.cells)) > 0 {
+				return m
+			}
+			m.tutorial.step = tutorialFinish
+		default:
+			return m
+		}
+	}
+}
+
+// firstGuess returns the first letter the player filled in, reading the grid
+// in order, or 0 when there is none. Hints don't count.
+func firstGuess(cells []puzzle.Cell) rune {
+	for _, cell := range cells {
+		if cell.Kind == puzzle.CellLetter && cell.Input != 0 {
+			return cell.Input
+		}
+	}
+	return 0
+}
+
+// tutorialHelpItems returns the help bar actions on the tutorial puzzle:
+// only what the tutorial has explained, and a way out.
+func (m Model) tutorialHelpItems() []helpItem {
+	switch {
+	case m.state == StateSolved:
+		return []helpItem{helpContinue, helpQuit}
+	case m.tutorial.step == tutorialCipher:
+		return []helpItem{helpContinue, helpSkip, helpQuit}
+	default:
+		return []helpItem{helpSubmit, helpClear, helpCompact, helpSkip, helpQuit}
+	}
+}
+
+// tutorialText returns what the current step asks of the player.
+func (m Model) tutorialText() string {
+	if m.state == StateSolved {
+		if m.opts.Tutorial {
+			return "You've got it! A new quote is waiting every day. Press Enter to finish."
+		}
+		return "You've got it! Press Enter to play today's puzzle. " +
+			"Run 'unquote tutorial' any time to see this again."
+	}
+	switch m.tutorial.step {
+	case tutorialCipher:
+		return "Each quote is written in code: every letter stands for another. " +
+			"The dim letters under the boxes are the cipher row, and the same cipher letter " +
+			"always stands for the same real letter. The clue above the grid gives one away. " +
+			"Press Enter to continue."
+	case tutorialType:
+		return "Type a letter to guess what the highlighted cipher letter stands for. " +
+			"Every box with that cipher letter fills in at once. Arrow keys move between boxes."
+	case tutorialConflict:
+		return fmt.Sprintf("Two cipher letters never stand for the same letter. "+
+			"Type %c again, under a different cipher letter, to see what a conflict looks like.", m.tutorial.guess)
+	case tutorialErase:
+		return "Conflicting boxes are highlighted, with a warning below the grid. " +
+			"Press Backspace on one of them to clear it."
+	case tutorialFinish:
+		return "That's all there is to it. Fill in the rest and press Enter to check your answer."
+	default:
+		return ""
+	}
+}
+
+// renderTutorial renders the current step's callout, wrapped to fit the
+// terminal.
+func (m Model) renderTutorial() string {
+	text := m.tutorialText()
+	if !m.inTutorial() || text == "" {
+		return ""
+	}
+	width := tutorialCalloutWidth
+	if m.width > 0 {
+		width = min(width, m.width)
+	}
+	// The border and padding take two columns on each side
+	return ui.CalloutStyle.Render(ui.WordWrapText(text, max(width-4, 10)))
+}
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+// tutorialModel starts the tutorial from a daily puzzle run and delivers the
+// tutorial puzzle.
+func tutorialModel(t *testing.T, opts Options) Model {
+	t.Helper()
+	storagetest.UseMemory(t)
+	m := rolloverModel(t)
+	m.opts = opts
+	m = m.resetGame()
+	m, cmd := m.startTutorial()
+	if cmd == nil {
+		t.Fatal("startTutorial() should load the tutorial puzzle")
+	}
+	model, _ := m.Update(cmd())
+	m = model.(Model)
+	if m.state != StatePlaying || !m.inTutorial() {
+		t.Fatalf("state = %d, tutorial step %d; want the tutorial playing", m.state, m.tutorial.step)
+	}
+	return m
+}
+
+// press delivers a key to m and returns the model it leaves.
+func press(t *testing.T, m Model, key tea.KeyPressMsg) (Model, tea.Cmd) {
+	t.Helper()
+	model, cmd := m.handleKeyMsg(key)
+	return model.(Model), cmd
+}
+
+// typeLetter presses a letter key.
+func typeLetter(t *testing.T, m Model, r rune) Model {
+	t.Helper()
+	m, _ = press(t, m, tea.KeyPressMsg{Code: r, Text: string(r)})
+	return m
+}
+
+func TestTutorial_WalksThroughSteps(t *testing.T) {
+	m := tutorialModel(t, Options{})
+	view := ansi.Strip(m.viewPlaying())
+	for _, want := range []string{"CRYPTO-QUIP · TUTORIAL", "cipher row", "[Tab] Skip tutorial"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("tutorial screen missing %q:\n%s", want, view)
+		}
+	}
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.tutorial.step != tutorialType || m.state != StatePlaying {
+		t.Fatalf("Enter on the first step: step %d, state %d; want the typing step", m.tutorial.step, m.state)
+	}
+
+	m = typeLetter(t, m, 'x')
+	if m.tutorial.step != tutorialConflict || !strings.Contains(m.tutorialText(), "Type X again") {
+		t.Fatalf("after a guess: step %d, %q; want the conflict step", m.tutorial.step, m.tutorialText())
+	}
+
+	// The cursor moved on to another cipher letter
+	m = typeLetter(t, m, 'x')
+	if m.tutorial.step != tutorialErase {
+		t.Fatalf("after a conflict: step %d, want the erase step", m.tutorial.step)
+	}
+
+	for i := 0; i < 3 && m.tutorial.step == tutorialErase; i++ {
+		m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyBackspace})
+	}
+	if m.tutorial.step != tutorialFinish {
+		t.Fatalf("after clearing the conflict: step %d, want the last step", m.tutorial.step)
+	}
+
+	puzzle.RevealSolution(m.game.cells, m.game.answer)
+	m, cmd := press(t, m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	model, _ := m.Update(cmd())
+	if m = model.(Model); m.state != StateSolved || !strings.Contains(m.tutorialText(), "play today's puzzle") {
+		t.Fatalf("state %d, %q; want solved with a way on", m.state, m.tutorialText())
+	}
+
+	m, cmd = press(t, m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.inTutorial() || m.opts.Local != nil || m.state != StateLoading || cmd == nil {
+		t.Errorf("after the tutorial: step %d, local %v, state %d; want today's puzzle loading", m.tutorial.step, m.opts.Local, m.state)
+	}
+}
+
+func TestTutorial_Skip(t *testing.T) {
+	m := tutorialModel(t, Options{})
+	m = typeLetter(t, m, 'x')
+
+	m, cmd := press(t, m, tea.KeyPressMsg{Code: tea.KeyTab})
+	if m.inTutorial() || m.state != StateLoading || cmd == nil {
+		t.Errorf("Tab: step %d, state %d; want the run's puzzle loading", m.tutorial.step, m.state)
+	}
+}
+
+func TestTutorial_CommandQuitsWhenDone(t *testing.T) {
+	m := tutorialModel(t, Options{Tutorial: true, Ephemeral: true})
+	m, _ = press(t, m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	puzzle.RevealSolution(m.game.cells, m.game.answer)
+	m, cmd := press(t, m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	model, _ := m.Update(cmd())
+	m = model.(Model)
+	if !strings.Contains(m.tutorialText(), "Press Enter to finish") {
+		t.Errorf("solved text = %q, want it to finish", m.tutorialText())
+	}
+
+	if _, cmd = press(t, m, tea.KeyPressMsg{Code: tea.KeyEnter}); cmd == nil {
+		t.Fatal("Enter on the solved tutorial should quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("'unquote tutorial' should quit once done")
+	}
+}
+
+func TestTutorial_AfterOnboarding(t *testing.T) {
+	storagetest.UseMemory(t)
+	m := Model{state: StateOnboarding, client: newTestClient(t)}
+
+	model, cmd := m.Update(configSavedMsg{})
+	if m = model.(Model); !m.inTutorial() || cmd == nil {
+		t.Fatalf("step %d after opting out; want the tutorial", m.tutorial.step)
+	}
+	if _, ok := cmd().(puzzleFetchedMsg); !ok {
+		t.Error("the tutorial should load its own puzzle, without the API")
+	}
+}
+
+func TestTutorial_NotBeforeDuel(t *testing.T) {
+	m := Model{state: StateOnboarding, client: newTestClient(t)}
+	m.duel.room = "ROOM"
+
+	model, _ := m.Update(configSavedMsg{})
+	if model.(Model).inTutorial() {
+		t.Error("a friend waiting in a duel shouldn't wait for the tutorial")
+	}
+}
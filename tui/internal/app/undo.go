@@ -0,0 +1,21 @@
+package app
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// pushUndo remembers the grid's inputs before an edit, so Ctrl+U can take
+// the edit back.
+func (m Model) pushUndo() Model {
+	m.game.undo.Push(m.game.cells)
+	return m
+}
+
+// undo takes back the last edit to the grid: a letter typed or erased, a
+// swap, or a clear or fill of several letters. Locked letters keep theirs.
+func (m Model) undo() (tea.Model, tea.Cmd) {
+	if !m.game.undo.Undo(m.game.cells) {
+		return m.notify(toastInfo, "Nothing to undo")
+	}
+	return m, saveSessionCmd(m.sessions(), m.clock().Now(), m.game.puzzle, m.game.cells, m.Elapsed(), m.run, m.game.letters, m.game.assists)
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+var ctrlU = tea.KeyPressMsg{Code: 'u', Mod: tea.ModCtrl}
+
+func TestUndo_RevertsSwapThenTyping(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.game.puzzle = &api.Puzzle{ID: "game-001", EncryptedText: "AB CA"}
+	m.game.cells = puzzle.BuildCells(m.game.puzzle.EncryptedText, nil)
+	m.game.cursorPos = 0
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: 't', Text: "t"})
+	m, _ = press(t, m, tea.KeyPressMsg{Code: 'o', Text: "o"})
+	m.game.cursorPos = 0
+	m, _ = press(t, m, ctrlT)
+	m, _ = press(t, m, tea.KeyPressMsg{Code: 'b', Text: "b"})
+	if got := puzzle.AssembleSolution(m.game.cells); got != "OT _O" {
+		t.Fatalf("after the swap: %q, want OT _O", got)
+	}
+
+	m, cmd := press(t, m, ctrlU)
+	if got := puzzle.AssembleSolution(m.game.cells); got != "TO _T" {
+		t.Errorf("Ctrl+U after a swap: %q, want the swap taken back", got)
+	}
+	if cmd == nil {
+		t.Error("undoing should save the session")
+	}
+
+	m, _ = press(t, m, ctrlU)
+	if got := puzzle.AssembleSolution(m.game.cells); got != "T_ _T" {
+		t.Errorf("Ctrl+U again: %q, want the O typed last taken back", got)
+	}
+	m, _ = press(t, m, ctrlU)
+	m, _ = press(t, m, ctrlU)
+	if got := lastToast(m); got != "Nothing to undo" {
+		t.Errorf("toast = %q, want Nothing to undo once every edit is taken back", got)
+	}
+}
+
+// A locked letter keeps its input when the edit before it is undone.
+func TestUndo_KeepsLockedLetters(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.game.puzzle = &api.Puzzle{ID: "game-001", EncryptedText: "AB"}
+	m.game.cells = puzzle.BuildCells(m.game.puzzle.EncryptedText, nil)
+	m.game.cursorPos = 0
+
+	m, _ = press(t, m, tea.KeyPressMsg{Code: 't', Text: "t"})
+	m.game.cursorPos = 0
+	m, _ = press(t, m, ctrlL)
+	m, _ = press(t, m, ctrlU)
+	if got := puzzle.AssembleSolution(m.game.cells); got != "T_" {
+		t.Errorf("Ctrl+U with the letter locked: %q, want T kept", got)
+	}
+}
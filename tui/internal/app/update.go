@@ -2,117 +2,172 @@ package app
 
 import (
 	"fmt"
-	"strings"
 	"time"
-	"unicode"
 
 	tea "charm.land/bubbletea/v2"
-	"charm.land/huh/v2"
-	zone "github.com/lrstanley/bubblezone/v2"
 
 	"github.com/bojanrajkovic/unquote/tui/internal/config"
 	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
 	"github.com/bojanrajkovic/unquote/tui/internal/share"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
 	"github.com/bojanrajkovic/unquote/tui/internal/ui"
 )
 
 // Init is called when the program starts
 func (m Model) Init() tea.Cmd {
-	return loadConfigCmd()
+	return tea.Batch(loadConfigCmd(), checkHealthCmd(m.client, 0), fetchCapabilitiesCmd(m.client), checkForUpdateCmd(m.client), tea.RequestBackgroundColor)
 }
 
-// Update handles incoming messages.
-//
-//nolint:gocyclo // Bubble Tea's Update is a central message dispatcher — each message type needs its own case.
+// Update routes incoming messages. Input and terminal events come first;
+// then each message goes to the part of the app that owns it: the app-wide
+// config, connection and sync state, the stats and archive screens, or the
+// game. Anything left over is for the onboarding form, which needs its own
+// messages, such as the cursor blink.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	for _, route := range []func(Model, tea.Msg) (tea.Model, tea.Cmd, bool){
+		Model.updateInput,
+		Model.updateTerminal,
+		Model.updateApp,
+		Model.updateScreens,
+		func(m Model, msg tea.Msg) (tea.Model, tea.Cmd, bool) { return m.game.update(m, msg) },
+	} {
+		if model, cmd, ok := route(m, msg); ok {
+			return model, cmd
+		}
+	}
+
+	if m.state == StateOnboarding {
+		return m.updateOnboarding(msg)
+	}
+	return m, nil
+}
+
+// updateInput handles the player's keys and mouse, noting when they were
+// last used for the idle timer. Ctrl+Z suspends, and any input wakes an
+// idle game without acting on it. It reports whether msg was input.
+func (m Model) updateInput(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
-		return m.handleKeyMsg(msg)
+		m.lastInput = m.clock().Now()
+		if msg.String() == suspendKey && canSuspend() {
+			return handled(m.suspend())
+		}
+		if m.idle() {
+			return m.wake(), nil, true
+		}
+		return handled(followCursor(m.handleKeyMsg(msg)))
 
 	case tea.MouseReleaseMsg:
-		return m.handleMouseMsg(msg)
-
-	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		m.sizeReady = true
-		return m, nil
-
-	case puzzleFetchedMsg:
-		return m.handlePuzzleFetched(msg)
+		m.lastInput = m.clock().Now()
+		if m.idle() {
+			return m.wake(), nil, true
+		}
+		return handled(m.handleMouseMsg(msg))
 
-	case solutionCheckedMsg:
-		return m.handleSolutionChecked(msg)
+	case tea.MouseMotionMsg:
+		m.lastInput = m.clock().Now()
+		return m.game.update(m, msg)
 
-	case errMsg:
-		return m.handleError(msg)
+	case tea.MouseWheelMsg:
+		m.lastInput = m.clock().Now()
+		return handled(m.handleMouseWheelMsg(msg))
+	}
+	return m, nil, false
+}
 
-	case tickMsg:
-		// Only tick while playing - this triggers re-render for timer display
-		if m.state == StatePlaying {
-			return m, tickCmd()
-		}
-		return m, nil
+// updateTerminal handles the terminal's own events: resuming after a
+// suspend, its background color and its size. It reports whether msg was
+// one of them.
+func (m Model) updateTerminal(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case tea.ResumeMsg:
+		return handled(m.handleResume())
 
-	case sessionLoadedMsg:
-		return m.handleSessionLoaded(msg)
+	case tea.BackgroundColorMsg:
+		m.lightBackground = !msg.IsDark()
+		return m.applyPalette(), nil, true
 
-	case remoteSessionMsg:
-		return m.handleRemoteSession(msg)
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.sizeReady = true
+		return m.syncGridView(true), nil, true
+	}
+	return m, nil, false
+}
 
+// updateApp handles the messages about the app as a whole: the config and
+// registration, errors and retries, and the connection, sync and update
+// state shown in the status bar. It reports whether msg was one of them.
+func (m Model) updateApp(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
 	case configLoadedMsg:
-		return m.handleConfigLoaded(msg)
-
+		return handled(m.handleConfigLoaded(msg))
 	case playerRegisteredMsg:
-		return m.handlePlayerRegistered(msg)
-
+		return handled(m.handlePlayerRegistered(msg))
 	case configSavedMsg:
-		return m.handleConfigSaved()
-
-	case sessionRecordedMsg:
-		return m.handleSessionRecorded(msg)
-
+		return handled(m.handleConfigSaved())
+	case errMsg:
+		return handled(m.handleError(msg))
+	case retryTickMsg:
+		return handled(m.handleRetryTick(msg))
+	case capabilitiesMsg:
+		return handled(m.handleCapabilities(msg))
+	case healthCheckedMsg:
+		return handled(m.handleHealthChecked(msg))
 	case reconciliationDoneMsg:
-		return m, nil
-
-	case statsFetchedMsg:
-		return m.handleStatsFetched(msg)
+		return handled(m.handleReconciliationDone(msg))
+	case pendingSyncMsg:
+		m.pendingSync = msg.count
+		if msg.count == 0 {
+			m.errs.sync = ""
+		}
+		return m, nil, true
+	case updateAvailableMsg:
+		m.latestVersion = msg.version
+		return m, nil, true
+	}
+	return m, nil, false
+}
 
-	case shareSessionResultMsg:
-		m.shareFeedback = msg.feedback
-		return m, tea.Tick(2500*time.Millisecond, func(_ time.Time) tea.Msg {
-			return clearShareFeedbackMsg{}
-		})
+// handled reports a message as taken by the route that passed it to a
+// handler, for routes to return the handler's results.
+func handled(model tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd, bool) {
+	return model, cmd, true
+}
 
-	case clearShareFeedbackMsg:
-		m.shareFeedback = ""
-		return m, nil
+// handleReconciliationDone records how many solves are still waiting to be
+// uploaded, and says so when the last of them went up.
+func (m Model) handleReconciliationDone(msg reconciliationDoneMsg) (tea.Model, tea.Cmd) {
+	synced := m.claimCode != "" && m.pendingSync > 0 && msg.pending == 0
+	m.pendingSync = msg.pending
+	m = m.noteSyncError(msg.err)
+	if synced {
+		return m.notify(toastSuccess, "All solves synced")
 	}
+	return m, nil
+}
 
-	// Forward unhandled messages to huh form during onboarding (e.g. focus,
-	// cursor blink, and other internal messages returned by form.Init()).
-	// Also check for form completion here: huh may complete the form via an
-	// internal message (not a KeyMsg), so we must handle it in both places.
-	if m.state == StateOnboarding && m.form != nil {
-		formModel, cmd := m.form.Update(msg)
-		if f, ok := formModel.(*huh.Form); ok {
-			m.form = f
-		}
-		return m.checkOnboardingComplete(cmd)
+// updateScreens handles the messages that fill in the stats and archive
+// screens. It reports whether msg was one of them.
+func (m Model) updateScreens(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case solveStatsFetchedMsg, goalLoadedMsg, friendStatsMsg, categoryStatsMsg:
+		m.stats, _ = m.stats.update(msg, m.hasFriends())
+		return m, nil, true
+	case statsFetchedMsg:
+		return handled(m.handleStatsFetched(msg))
+	case statsFailedMsg:
+		return handled(m.handleStatsFailed(msg))
+	case archiveLoadedMsg:
+		return handled(m.handleArchiveLoaded(msg))
 	}
-
-	return m, nil
+	return m, nil, false
 }
 
 func (m Model) handleKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	// Stats screen intercepts Esc/b before the global quit handler
-	if m.state == StateStats {
-		switch msg.String() {
-		case "esc", "b":
-			m.state = StateSolved
-			return m, nil
-		}
-		return m, nil
+	if model, cmd, ok := m.handleOverlayKeyMsg(msg); ok {
+		return model, cmd
 	}
 
 	// Global keybindings (always work)
@@ -125,50 +180,115 @@ func (m Model) handleKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// State-specific keybindings
+	if msg.String() == "ctrl+x" && m.showsStatsBanner() {
+		return m.dismissStatsBanner(), nil
+	}
+	return m.handleScreenKeyMsg(msg)
+}
+
+// handleOverlayKeyMsg gives msg to the screen, menu or prompt open over the
+// game, which uses Esc to back out of it instead of quitting. It reports
+// whether one was open.
+func (m Model) handleOverlayKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd, bool) {
+	// Menus and prompts wait for the terminal to be big enough to show them
+	fits := !m.IsTooSmall()
+	switch {
+	case m.state == StateStats:
+		var back bool
+		if m.stats, back = m.stats.update(msg, m.hasFriends()); back {
+			m.state = StateSolved
+		}
+		return m, nil, true
+	case m.state == StateNextPuzzle:
+		return handled(m.handleNextKeyMsg(msg))
+	case m.state == StateQuoteInfo:
+		return handled(m.handleInfoKeyMsg(msg))
+	case m.state == StateSolved && m.game.noteInput != nil:
+		// The note editor takes every key while open
+		return handled(m.handleNoteKeyMsg(msg))
+	case m.pickerOpen() && fits:
+		return handled(m.handlePickerKeyMsg(msg))
+	case m.jumpOpen() && fits:
+		return handled(m.handleJumpKeyMsg(msg))
+	case m.searchOpen() && fits:
+		// The one key after /
+		return handled(m.handleSearchKeyMsg(msg))
+	case m.swapOpen() && fits:
+		// The one key after Ctrl+T
+		return handled(m.handleSwapKeyMsg(msg))
+	}
+	return m, nil, false
+}
+
+// handleScreenKeyMsg handles msg for the current screen.
+func (m Model) handleScreenKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	switch m.state {
-	case StateLoading, StateChecking:
-		// No input during loading or checking
+	case StateLoading, StateChecking, StateDuelWaiting:
+		// No input during loading, checking, or while waiting for an opponent
 		return m, nil
 
 	case StateError:
 		return m.handleErrorKeyMsg(msg)
 
-	case StatePlaying:
-		return m.handlePlayingKeyMsg(msg)
+	case StateRecovery:
+		return m.handleRecoveryKeyMsg(msg)
 
-	case StateSolved:
-		return m.handleSolvedKeyMsg(msg)
+	case StatePlaying, StateSolved:
+		model, cmd, _ := m.game.update(m, msg)
+		return model, cmd
+
+	case StateArchive:
+		return m.handleArchiveKeyMsg(msg)
 
 	case StateOnboarding:
-		return m.handleOnboardingKeyMsg(msg)
+		return m.updateOnboarding(msg)
 
 	case StateClaimCodeDisplay:
-		// Any keypress proceeds to puzzle loading
-		m.state = StateLoading
-		m.form = nil
-		if m.opts.Random {
-			return m, fetchRandomPuzzleCmd(m.client)
+		// Any keypress proceeds to the tutorial, or to puzzle loading
+		m.onboarding = onboardingModel{}
+		if m.offersTutorial() {
+			return m.startTutorial()
 		}
-		return m, fetchPuzzleCmd(m.client)
+		m.state = StateLoading
+		return m.fetch()
 	}
 
 	return m, nil
 }
 
+// toggleCompactGrid switches between the standard two-row grid and the compact
+// single-row grid, and remembers the choice in the config file.
+func (m Model) toggleCompactGrid() (tea.Model, tea.Cmd) {
+	m.compactGrid = !m.compactGrid
+	m = m.syncGridView(true)
+
+	var cfg config.Config
+	if m.cfg != nil {
+		cfg = *m.cfg
+	}
+	cfg.CompactGrid = m.compactGrid
+	m.cfg = &cfg
+
+	if m.opts.Ephemeral {
+		return m, nil
+	}
+	return m, savePreferencesCmd(m.cfg)
+}
+
 func (m Model) handlePlayerRegistered(msg playerRegisteredMsg) (tea.Model, tea.Cmd) {
 	if msg.claimCode == "" {
 		m.state = StateError
-		m.errorMsg = "Registration failed: server returned an empty claim code"
+		m.errs.load = "Registration failed: server returned an empty claim code"
 		m.loadingMsg = ""
 		return m, nil
 	}
 	m.claimCode = msg.claimCode
 	m.state = StateClaimCodeDisplay
 	m.loadingMsg = ""
+	m.cfg = &config.Config{ClaimCode: msg.claimCode, StatsEnabled: true, CompactGrid: m.compactGrid}
 	return m, tea.Batch(
-		saveConfigCmd(&config.Config{ClaimCode: msg.claimCode, StatsEnabled: true}),
-		reconcileSessionsCmd(m.client, msg.claimCode),
+		saveConfigCmd(m.cfg),
+		reconcileSessionsCmd(m.client, m.clock(), msg.claimCode, m.reportsAttempts()),
 	)
 }
 
@@ -176,11 +296,11 @@ func (m Model) handleConfigSaved() (tea.Model, tea.Cmd) {
 	// If we're in claim code display, wait for user keypress.
 	// If we're still in onboarding (opt-out path), proceed to puzzle.
 	if m.state == StateOnboarding {
-		m.state = StateLoading
-		if m.opts.Random {
-			return m, fetchRandomPuzzleCmd(m.client)
+		if m.offersTutorial() {
+			return m.startTutorial()
 		}
-		return m, fetchPuzzleCmd(m.client)
+		m.state = StateLoading
+		return m.fetch()
 	}
 	return m, nil
 }
@@ -189,75 +309,66 @@ func (m Model) handleConfigSaved() (tea.Model, tea.Cmd) {
 // If config exists (AC2.4), skip onboarding and proceed to puzzle loading.
 // If config is nil (AC2.1), show onboarding form.
 func (m Model) handleConfigLoaded(msg configLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.config == nil && m.opts.Ephemeral {
+		// A claim code registered now would be lost on exit; play without stats
+		msg.config = &config.Config{}
+	}
 	if msg.config != nil {
 		// Config exists — skip onboarding
 		m.cfg = msg.config
 		m.claimCode = msg.config.ClaimCode
+		m.lowBandwidth = msg.config.LowBandwidth
+		m.compactGrid = msg.config.CompactGrid || m.lowBandwidth
+		m.accessible = m.opts.Accessible || msg.config.Accessible
+		// Without tints, the shape cues are what mark conflicts and related cells
+		m.shapeCues = msg.config.ShapeCues || m.lowBandwidth
+		m.alphabetPanel = msg.config.AlphabetPanel
+		m.theme = msg.theme
+		m = m.applyPalette()
 		m.state = StateLoading
 
-		var fetchCmd tea.Cmd
-		if m.opts.Random {
-			fetchCmd = fetchRandomPuzzleCmd(m.client)
-		} else {
-			fetchCmd = fetchPuzzleCmd(m.client)
+		var cmds []tea.Cmd
+		if msg.themeErr != nil {
+			var cmd tea.Cmd
+			m, cmd = m.notify(toastWarning, fmt.Sprintf("Using the built-in colors: %v", msg.themeErr))
+			cmds = append(cmds, cmd)
+		}
+		switch {
+		case m.opts.Tutorial:
+			var cmd tea.Cmd
+			m, cmd = m.startTutorial()
+			cmds = append(cmds, cmd)
+		case m.offersRecovery():
+			// The puzzle loads once the player has answered the prompt
+			m.state = StateRecovery
+		default:
+			var fetch tea.Cmd
+			m, fetch = m.fetch()
+			cmds = append(cmds, fetch)
 		}
-
-		cmds := []tea.Cmd{fetchCmd}
 		if m.claimCode != "" {
-			cmds = append(cmds, reconcileSessionsCmd(m.client, m.claimCode))
+			cmds = append(cmds, reconcileSessionsCmd(m.client, m.clock(), m.claimCode, m.reportsAttempts()))
 		}
 		return m, tea.Batch(cmds...)
 	}
 
-	// Allocate a persistent bool pointer for the huh.Confirm binding.
-	// This must survive model value copies — all copies share the same pointer,
-	// so when huh writes the user's selection into it, m.optIn reflects it correctly.
-	m.optIn = new(bool)
-
 	// No config — show onboarding form (AC2.1)
-	m.form = huh.NewForm(
-		huh.NewGroup(
-			huh.NewNote().
-				Title("Track Your Stats?").
-				Description("Unquote can track your solve times and streaks.\n\n"+
-					"What we store:\n"+
-					"  - Which puzzles you solved\n"+
-					"  - How long each took\n\n"+
-					"What we don't store:\n"+
-					"  - No personal information\n"+
-					"  - No email, no password\n\n"+
-					"You'll get a random claim code (like TIGER-MAPLE-7492)\n"+
-					"that identifies your stats. Save it to access your\n"+
-					"stats from another device."),
-			huh.NewConfirm().
-				Title("Track my stats?").
-				Affirmative("Yes, track my stats").
-				Negative("No thanks").
-				Value(m.optIn),
-		),
-	).WithShowHelp(false).WithShowErrors(false)
+	m.onboarding = newOnboarding(m.accessible)
 	m.state = StateOnboarding
-	return m, m.form.Init()
-}
-
-// handleOnboardingKeyMsg delegates key events to the huh form.
-// When the form completes, it delegates to checkOnboardingComplete.
-func (m Model) handleOnboardingKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	formModel, cmd := m.form.Update(msg)
-	if f, ok := formModel.(*huh.Form); ok {
-		m.form = f
-	}
-	return m.checkOnboardingComplete(cmd)
+	return m, m.onboarding.init()
 }
 
-// checkOnboardingComplete checks if the huh form has finished and handles opt-in/opt-out.
-// Called from both handleOnboardingKeyMsg and the catch-all non-key message handler,
-// because huh may finalize the form via an internal message rather than the key event itself.
-func (m Model) checkOnboardingComplete(fallbackCmd tea.Cmd) (tea.Model, tea.Cmd) {
-	if m.form.State != huh.StateCompleted {
-		return m, fallbackCmd
-	}
-	if m.optIn != nil && *m.optIn {
+// updateOnboarding passes msg to the onboarding form, then handles opt-in or
+// opt-out once it is answered. Key presses and the form's own messages both
+// come here, because huh may finalize the form via an internal message
+// rather than the key event itself.
+func (m Model) updateOnboarding(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.onboarding, cmd = m.onboarding.update(msg)
+	if !m.onboarding.completed() {
+		return m, cmd
+	}
+	if m.onboarding.optedIn() {
 		// AC2.2: opt-in — show loading while registering
 		cfg := &config.Config{StatsEnabled: true}
 		m.cfg = cfg
@@ -272,13 +383,16 @@ func (m Model) checkOnboardingComplete(fallbackCmd tea.Cmd) (tea.Model, tea.Cmd)
 }
 
 func (m Model) handleMouseMsg(msg tea.MouseReleaseMsg) (tea.Model, tea.Cmd) {
-	if msg.Mouse().Button != tea.MouseLeft {
+	button := msg.Mouse().Button
+	if button != tea.MouseLeft && button != tea.MouseRight {
 		return m, nil
 	}
 
-	// Only handle clicks in playing state
-	if m.state != StatePlaying {
-		return m, nil
+	// Help bar items act like pressing their key
+	if button == tea.MouseLeft {
+		if item, ok := m.helpItemAt(msg); ok {
+			return followCursor(m.handleKeyMsg(item.key))
+		}
 	}
 
 	// Ignore clicks if terminal too small
@@ -286,190 +400,464 @@ func (m Model) handleMouseMsg(msg tea.MouseReleaseMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Check each cell's zone for click
-	for _, cell := range m.cells {
-		if cell.Kind != puzzle.CellLetter {
-			continue
+	switch {
+	case m.state == StateArchive && button == tea.MouseLeft:
+		// Clicking a pack puzzle on the archive screen plays it
+		return m.playArchiveEntry(m.archive.entryAt(msg))
+	case m.state == StateNextPuzzle && button == tea.MouseLeft:
+		// Clicking an option on the next-puzzle menu loads it
+		return m.playNextChoice(m.nextChoiceAt(msg))
+	case m.state == StatePlaying:
+		model, cmd, _ := m.game.update(m, msg)
+		return model, cmd
+	}
+	return m, nil
+}
+
+// handleGridClick handles a click on the puzzle grid: a left click moves the
+// cursor to a letter, a right click clears it.
+func (m Model) handleGridClick(msg tea.MouseReleaseMsg, button tea.MouseButton) (tea.Model, tea.Cmd) {
+	index := m.game.letterCellAt(msg)
+	if index < 0 {
+		// Clicking a clue or hint cell highlights where its cipher letter appears
+		if button == tea.MouseLeft {
+			if char := m.game.hintCharAt(msg); char != 0 {
+				m.game.hoverChar = char
+			}
+		}
+		return m, nil
+	}
+
+	// Right-click clears the cell (and all cells sharing its cipher letter)
+	if button == tea.MouseRight {
+		if m.lockedAt(index) {
+			return m.rejectLocked(index)
 		}
+		m = m.pushUndo()
+		puzzle.ClearInput(m.game.cells, index)
+		return m, saveSessionCmd(m.sessions(), m.clock().Now(), m.game.puzzle, m.game.cells, m.Elapsed(), m.run, m.game.letters, m.game.assists)
+	}
 
-		zoneID := fmt.Sprintf("cell-%d", cell.Index)
-		if zone.Get(zoneID).InBounds(msg) {
-			m.cursorPos = cell.Index
-			return m, nil
+	m.game.cursorPos = index
+	return m, nil
+}
+
+// handleMouseWheelMsg scrolls the puzzle grid one line at a time, or the
+// quote info panel like the arrow keys.
+func (m Model) handleMouseWheelMsg(msg tea.MouseWheelMsg) (tea.Model, tea.Cmd) {
+	if m.state == StateQuoteInfo {
+		switch msg.Mouse().Button {
+		case tea.MouseWheelUp:
+			return m.handleInfoKeyMsg(tea.KeyPressMsg{Code: tea.KeyUp})
+		case tea.MouseWheelDown:
+			return m.handleInfoKeyMsg(tea.KeyPressMsg{Code: tea.KeyDown})
 		}
+		return m, nil
+	}
+	if m.state != StatePlaying && m.state != StateChecking && m.state != StateSolved {
+		return m, nil
 	}
 
+	m = m.syncGridView(false)
+	rows, _ := m.gridLineSpacing()
+	switch msg.Mouse().Button {
+	case tea.MouseWheelUp:
+		m.gridView.ScrollUp(rows)
+	case tea.MouseWheelDown:
+		m.gridView.ScrollDown(rows)
+	}
 	return m, nil
 }
 
+// followCursor scrolls the puzzle grid so the cursor stays visible after a
+// key press moved it.
+func followCursor(model tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	if m, ok := model.(Model); ok && m.state == StatePlaying {
+		return m.syncGridView(true), cmd
+	}
+	return model, cmd
+}
+
 func (m Model) handleErrorKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	if msg.String() == "r" {
-		m.state = StateLoading
-		m.errorMsg = ""
-		// If registration was in-flight (opted in but not yet registered), retry it.
-		if m.cfg != nil && m.cfg.StatsEnabled && m.claimCode == "" {
-			m.loadingMsg = "Registering..."
-			return m, registerPlayerCmd(m.client)
-		}
-		m.loadingMsg = ""
-		if m.opts.Random {
-			return m, fetchRandomPuzzleCmd(m.client)
-		}
-		return m, fetchPuzzleCmd(m.client)
+		return m.retry()
 	}
 	return m, nil
 }
 
-func (m Model) handleSolvedKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "s":
-		if m.claimCode != "" {
-			m.state = StateLoading
-			return m, fetchStatsCmd(m.client, m.claimCode)
-		}
-	case "c":
-		// Build session share data from current model state
-		var streak int
-		if m.claimCode != "" && m.stats != nil {
-			streak = m.stats.CurrentStreak
-		}
+// retry leaves the error screen and tries again: registration if it was
+// in flight, otherwise loading the puzzle.
+func (m Model) retry() (tea.Model, tea.Cmd) {
+	m.state = StateLoading
+	m.errs.load = ""
+	m.retryAt = time.Time{}
+	// If registration was in-flight (opted in but not yet registered), retry it.
+	if m.cfg != nil && m.cfg.StatsEnabled && m.claimCode == "" {
+		m.loadingMsg = "Registering..."
+		return m, registerPlayerCmd(m.client)
+	}
+	m.loadingMsg = ""
+	return m.fetch()
+}
 
-		var completionMs int64
-		if m.elapsedAtPause > 0 {
-			completionMs = m.elapsedAtPause.Milliseconds()
-		}
+// solvedAction is what a key does on the solved screen, when the screen
+// offers it.
+type solvedAction struct {
+	offered func(Model) bool
+	run     func(Model) (tea.Model, tea.Cmd)
+}
 
-		data := share.SessionShareData{
-			Cells:        m.cells,
-			PuzzleNumber: m.puzzle.Date,
-			CompletionMs: completionMs,
-			Streak:       streak,
-			Solved:       true,
-		}
+// solvedKeys maps each key with an action on the solved screen to it.
+var solvedKeys = map[string]solvedAction{
+	"s": {func(m Model) bool { return m.claimCode != "" }, Model.openStats},
+	"a": {func(m Model) bool { return m.opts.Pack != nil }, Model.backToArchive},
+	"n": {Model.offersNext, func(m Model) (tea.Model, tea.Cmd) { return m, m.loadNextChoicesCmd() }},
+	"m": {Model.offersMore, func(m Model) (tea.Model, tea.Cmd) { return m, m.searchAuthorCmd() }},
+	"i": {Model.offersInfo, Model.openQuoteInfo},
+	"*": {Model.offersFavorite, func(m Model) (tea.Model, tea.Cmd) { return m, m.toggleFavoriteCmd() }},
+	"N": {Model.offersNote, func(m Model) (tea.Model, tea.Cmd) { return m.startNote() }},
+	// A revealed puzzle has no solve to share
+	"c": {func(m Model) bool { return !m.game.revealed }, Model.shareSolve},
+}
 
-		m.shareFeedback = "Sharing..."
-		return m, shareSessionCmd(data)
+func (m Model) handleSolvedKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if action, ok := solvedKeys[key]; ok && action.offered(m) {
+		return action.run(m)
+	}
+	if len(key) == 1 && key >= "1" && key <= "5" && m.offersRating() {
+		rating := int(key[0] - '0')
+		// Set now so a second press can't rate twice; cleared again if it can't be kept
+		m.game.rating = rating
+		return m, m.rateDifficultyCmd(rating)
 	}
 	return m, nil
 }
 
+// openStats fetches the player's stats for the stats screen, with their
+// friends', categories and weekly goal alongside.
+func (m Model) openStats() (tea.Model, tea.Cmd) {
+	m.state = StateLoading
+	m.stats = m.stats.open(m.hasFriends())
+	cmds := []tea.Cmd{fetchStatsCmd(m.client, m.claimCode, m.stats.fetch), loadCategoryStatsCmd()}
+	if m.hasFriends() {
+		cmds = append(cmds, fetchFriendStatsCmd(m.client, m.cfg.Friends))
+	}
+	if m.cfg != nil && m.cfg.WeeklyGoal > 0 {
+		cmds = append(cmds, loadGoalCmd(m.cfg.WeeklyGoal, m.clock().Now().In(m.location())))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// shareSolve copies the solve's share text, built from the current model
+// state.
+func (m Model) shareSolve() (tea.Model, tea.Cmd) {
+	var streak int
+	if m.claimCode != "" && m.stats.player != nil {
+		streak = m.stats.player.CurrentStreak
+	}
+
+	var completionMs int64
+	if m.game.elapsedAtPause > 0 {
+		completionMs = m.game.elapsedAtPause.Milliseconds()
+	}
+
+	data := share.SessionShareData{
+		Cells:        m.game.cells,
+		PuzzleNumber: m.game.puzzle.Date,
+		CompletionMs: completionMs,
+		Streak:       streak,
+		Solved:       true,
+	}
+
+	m, cmd := m.notifyAbout(topicShare, toastInfo, "Sharing...")
+	return m, tea.Batch(cmd, shareSessionCmd(data))
+}
+
+// playingKeys maps each key with its own action while solving to that
+// action. Commands use Ctrl, since plain letters are typed into the grid;
+// every key not listed here is offered as a letter.
+var playingKeys = map[string]func(Model) (tea.Model, tea.Cmd){
+	"ctrl+c":    Model.clearAll,
+	"enter":     Model.handleSubmit,
+	"tab":       Model.openPicker,
+	"ctrl+f":    Model.autoFill,
+	"ctrl+j":    Model.openJump,
+	"ctrl+d":    Model.clearPlaintext,
+	"ctrl+l":    Model.toggleLock,
+	"ctrl+k":    Model.toggleAlphabetPanel,
+	"/":         Model.startSearch,
+	"ctrl+t":    Model.startSwap,
+	"ctrl+u":    Model.undo,
+	"ctrl+n":    Model.offerNewPuzzle,
+	"ctrl+v":    Model.startReveal,
+	"left":      Model.moveLeft,
+	"right":     Model.moveRight,
+	"backspace": Model.erase,
+}
+
 func (m Model) handlePlayingKeyMsg(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c":
-		// Clear all input
-		puzzle.ClearAllInput(m.cells)
-		m.cursorPos = puzzle.FirstLetterCell(m.cells)
-		m.statusMsg = ""
-		// Save session after clearing all
-		return m, saveSessionCmd(m.puzzle.ID, m.cells, m.Elapsed())
-
-	case "enter":
-		// Submit solution if complete
-		return m.handleSubmit()
-
-	case "left":
-		// Move cursor left to previous letter cell
-		prevPos := puzzle.PrevLetterCell(m.cells, m.cursorPos)
-		if prevPos >= 0 {
-			m.cursorPos = prevPos
-		}
-		return m, nil
+	if action, ok := playingKeys[msg.String()]; ok {
+		return action(m)
+	}
 
-	case "right":
-		// Move cursor right to next letter cell
-		nextPos := puzzle.NextLetterCell(m.cells, m.cursorPos)
-		if nextPos >= 0 {
-			m.cursorPos = nextPos
-		}
+	// Check for letter input. Text carries what option, AltGr and dead
+	// keys composed; synthesized keys may only set the code.
+	text := msg.Text
+	if text == "" {
+		text = msg.String()
+	}
+	if letter, ok := puzzle.InputLetter(text, m.foldAccents()); ok {
+		return m.handleLetterInput(letter)
+	}
+	return m, nil
+}
+
+// clearAll clears every input but locked letters and puts the cursor back
+// on the first letter.
+func (m Model) clearAll() (tea.Model, tea.Cmd) {
+	m = m.pushUndo()
+	puzzle.ClearAllInput(m.game.cells)
+	m.game.cursorPos = puzzle.FirstLetterCell(m.game.cells)
+	return m, saveSessionCmd(m.sessions(), m.clock().Now(), m.game.puzzle, m.game.cells, m.Elapsed(), m.run, m.game.letters, m.game.assists)
+}
+
+// offerNewPuzzle switches to the new daily puzzle, once there is one.
+func (m Model) offerNewPuzzle() (tea.Model, tea.Cmd) {
+	if m.game.newPuzzle {
+		return m.loadNewPuzzle()
+	}
+	return m, nil
+}
+
+// startReveal gives up and fetches the solution, once enough submissions
+// have failed.
+func (m Model) startReveal() (tea.Model, tea.Cmd) {
+	if !m.canReveal() {
 		return m, nil
+	}
+	m.state = StateChecking
+	m.loadingMsg = "Revealing solution..."
+	m.errs.play = ""
+	if m.game.answer != "" {
+		return m, revealAnswerCmd(m.game.answer)
+	}
+	return m, fetchSolutionCmd(m.client, m.game.puzzle.ID)
+}
 
-	case "backspace":
-		// Clear current cell (and all matching cipher letters) and move back
-		if m.cursorPos >= 0 && m.cursorPos < len(m.cells) {
-			puzzle.ClearInput(m.cells, m.cursorPos)
-			prevPos := puzzle.PrevLetterCell(m.cells, m.cursorPos)
-			if prevPos >= 0 {
-				m.cursorPos = prevPos
-			}
-		}
-		m.statusMsg = ""
-		// Save session after clearing
-		return m, saveSessionCmd(m.puzzle.ID, m.cells, m.Elapsed())
+// moveLeft moves the cursor to the previous letter cell.
+func (m Model) moveLeft() (tea.Model, tea.Cmd) {
+	if prevPos := puzzle.PrevLetterCell(m.game.cells, m.game.cursorPos); prevPos >= 0 {
+		m.game.cursorPos = prevPos
+	}
+	return m.tipAfterArrow()
+}
 
-	default:
-		// Check for letter input
-		runes := []rune(msg.String())
-		if len(runes) == 1 && unicode.IsLetter(runes[0]) {
-			return m.handleLetterInput(unicode.ToUpper(runes[0]))
+// moveRight moves the cursor to the next letter cell.
+func (m Model) moveRight() (tea.Model, tea.Cmd) {
+	if nextPos := puzzle.NextLetterCell(m.game.cells, m.game.cursorPos); nextPos >= 0 {
+		m.game.cursorPos = nextPos
+	}
+	return m.tipAfterArrow()
+}
+
+// erase clears the cell under the cursor, and every cell sharing its cipher
+// letter, then moves back one letter.
+func (m Model) erase() (tea.Model, tea.Cmd) {
+	if m.lockedAt(m.game.cursorPos) {
+		return m.rejectLocked(m.game.cursorPos)
+	}
+	if m.game.cursorPos >= 0 && m.game.cursorPos < len(m.game.cells) {
+		m = m.pushUndo()
+		puzzle.ClearInput(m.game.cells, m.game.cursorPos)
+		if prevPos := puzzle.PrevLetterCell(m.game.cells, m.game.cursorPos); prevPos >= 0 {
+			m.game.cursorPos = prevPos
 		}
 	}
+	return m, saveSessionCmd(m.sessions(), m.clock().Now(), m.game.puzzle, m.game.cells, m.Elapsed(), m.run, m.game.letters, m.game.assists)
+}
 
-	return m, nil
+// foldAccents reports whether typed accents are stripped (é → E), following
+// the configured accent policy. By default they are, unless the puzzle has
+// accented letters of its own.
+func (m Model) foldAccents() bool {
+	var policy puzzle.AccentPolicy
+	if m.cfg != nil {
+		policy, _ = puzzle.ParseAccentPolicy(m.cfg.Accents)
+	}
+	switch policy {
+	case puzzle.AccentsFold:
+		return true
+	case puzzle.AccentsKeep:
+		return false
+	default:
+		return !puzzle.HasAccents(m.game.cells)
+	}
 }
 
+// handleLetterInput enters a normalized letter at the cursor and moves on to
+// the next unfilled cell.
 func (m Model) handleLetterInput(letter rune) (tea.Model, tea.Cmd) {
-	if m.cursorPos < 0 || m.cursorPos >= len(m.cells) {
+	if m.game.cursorPos < 0 || m.game.cursorPos >= len(m.game.cells) {
 		return m, nil
 	}
+	if m.lockedAt(m.game.cursorPos) {
+		return m.rejectLocked(m.game.cursorPos)
+	}
+
+	conflictsBefore := findDuplicateInputs(m.game.cells)
+	if _, ok := m.cursorWordSuggestion(); ok {
+		// Typed with dictionary words in view
+		m.game.assists.SuggestionsUsed = true
+	}
 
 	// Set the input
-	if puzzle.SetInput(m.cells, m.cursorPos, letter) {
+	cipher := m.game.cells[m.game.cursorPos].Char
+	m = m.pushUndo()
+	if puzzle.SetInput(m.game.cells, m.game.cursorPos, letter) {
+		m = m.recordLetterTime(cipher)
+		m.game.trace.firstInput()
 		// Auto-advance to next unfilled letter cell
-		nextPos := puzzle.NextUnfilledLetterCell(m.cells, m.cursorPos)
+		nextPos := puzzle.NextUnfilledLetterCell(m.game.cells, m.game.cursorPos)
 		if nextPos >= 0 {
-			m.cursorPos = nextPos
+			m.game.cursorPos = nextPos
 		}
 	}
 
-	// Clear any status message when typing
-	m.statusMsg = ""
+	m = m.recordSplits()
 
 	// Save session after input
-	return m, saveSessionCmd(m.puzzle.ID, m.cells, m.Elapsed())
+	cmd := saveSessionCmd(m.sessions(), m.clock().Now(), m.game.puzzle, m.game.cells, m.Elapsed(), m.run, m.game.letters, m.game.assists)
+	if m.soundEnabled() && hasNewConflict(conflictsBefore, findDuplicateInputs(m.game.cells)) {
+		cmd = tea.Batch(cmd, bellCmd())
+	}
+	m, tipCmd := m.tipAfterLetter(cipher)
+	return m, tea.Batch(cmd, tipCmd)
+}
+
+// hasNewConflict reports whether after contains a duplicate input that before did not.
+func hasNewConflict(before, after map[rune][]rune) bool {
+	for input := range after {
+		if _, ok := before[input]; !ok {
+			return true
+		}
+	}
+	return false
 }
 
 func (m Model) handleSubmit() (tea.Model, tea.Cmd) {
 	// Check if puzzle is complete
-	if !puzzle.IsComplete(m.cells) {
-		m.statusMsg = "Fill in all letters first!"
-		return m, nil
+	if !puzzle.IsComplete(m.game.cells) {
+		return m.notifyAbout(topicAnswer, toastError, "Fill in all letters first!")
+	}
+
+	// A puzzle resumed from its session has no answer to check against offline
+	if m.game.offline && m.game.answer == "" && m.connection != connOnline {
+		m, cmd := m.notifyAbout(topicAnswer, toastWarning, "Can't check this puzzle offline. Your progress is saved; try again once you're back online.")
+		return m, tea.Batch(cmd, saveSessionCmd(m.sessions(), m.clock().Now(), m.game.puzzle, m.game.cells, m.Elapsed(), m.run, m.game.letters, m.game.assists))
 	}
 
 	// Assemble solution and submit
-	solution := puzzle.AssembleSolution(m.cells)
+	solution := puzzle.AssembleSolution(m.game.cells)
 	m.state = StateChecking
-	m.statusMsg = ""
+	m.errs.play = ""
+	m.loadingMsg = ""
+	m.game.checkAttempts = 0
 
-	return m, checkSolutionCmd(m.client, m.puzzle.ID, solution)
+	if m.game.answer != "" {
+		return m, checkAnswerCmd(m.game.answer, solution)
+	}
+	return m, checkSolutionCmd(m.game.trace.client(m.client), m.game.puzzle.ID, solution)
 }
 
 func (m Model) handleSolutionChecked(msg solutionCheckedMsg) (tea.Model, tea.Cmd) {
 	if msg.correct {
 		m.state = StateSolved
-		m.statusMsg = ""
+		m.game.freshSolve = true
 		// Capture final elapsed time and solve timestamp atomically
-		m.elapsedAtPause += time.Since(m.startTime)
-		solvedAt := time.Now()
+		solvedAt := m.clock().Now()
+		m.game.elapsedAtPause += solvedAt.Sub(m.game.startTime)
+		if m.duel.room != "" {
+			m.duel.solvedAt = solvedAt
+		}
 
-		cmds := []tea.Cmd{saveSolvedSessionCmd(m.puzzle.ID, m.cells, m.elapsedAtPause, solvedAt)}
+		cmds := []tea.Cmd{saveSolvedSessionCmd(m.sessions(), m.game.puzzle, m.game.cells, m.game.elapsedAtPause, solvedAt, m.run, m.game.letters, m.game.assists)}
+		if m.soundEnabled() {
+			cmds = append(cmds, solveNotifyCmd(m.game.elapsedAtPause))
+		}
 
-		if m.claimCode != "" {
-			cmds = append(cmds, recordSessionCmd(m.client, m.claimCode, m.puzzle.ID, m.elapsedAtPause, solvedAt))
+		if m.recordsStats() {
+			// Count the new solve as pending until the upload confirms it
+			cmds[0] = tea.Sequence(cmds[0], countPendingCmd())
+			cmds = append(cmds, recordSessionCmd(m.game.trace.client(m.client), m.claimCode, m.game.puzzle.ID, m.game.elapsedAtPause, solvedAt, m.game.assists))
+		} else {
+			// Recorded solves fetch it once the server has them
+			cmds = append(cmds, m.fetchGlobalStatsCmd())
 		}
 
+		// Top up the offline cache while the API is reachable
+		if m.playsToday() && !m.game.offline && !m.opts.Ephemeral {
+			cmds = append(cmds, prefetchCmd(m.client, m.clock(), m.location()))
+		}
+
+		m.game.trace.end(outcomeSolved, nil)
 		return m, tea.Batch(cmds...)
 	}
 	m.state = StatePlaying
-	m.statusMsg = "Not quite right. Keep trying!"
-	return m, nil
+	m.game.failedChecks++
+	text := "Not quite right. Keep trying!"
+	if m.canReveal() {
+		text = "Not quite right. Keep trying, or press Ctrl+V to reveal the answer."
+	}
+	m, cmd := m.notifyAbout(topicAnswer, toastError, text)
+	if m.soundEnabled() {
+		return m, tea.Batch(cmd, bellCmd())
+	}
+	return m, cmd
+}
+
+// handleSolutionRevealed fills the grid with the fetched solution and ends the
+// game. The session is saved unsolved and never recorded, so giving up does
+// not count toward stats.
+func (m Model) handleSolutionRevealed(msg solutionRevealedMsg) (tea.Model, tea.Cmd) {
+	m.loadingMsg = ""
+	if !puzzle.RevealSolution(m.game.cells, msg.solution) {
+		m.state = StatePlaying
+		return m.notifyAbout(topicAnswer, toastError, "Couldn't reveal the solution for this puzzle.")
+	}
+
+	m.state = StateSolved
+	m.game.revealed = true
+	now := m.clock().Now()
+	m.game.elapsedAtPause += now.Sub(m.game.startTime)
+	m.game.trace.end(outcomeRevealed, nil)
+
+	return m, tea.Batch(saveRevealedSessionCmd(m.sessions(), now, m.game.puzzle, m.game.cells, m.game.elapsedAtPause), m.fetchGlobalStatsCmd())
 }
 
 func (m Model) handleSessionRecorded(msg sessionRecordedMsg) (tea.Model, tea.Cmd) {
+	// Stats are back
+	m = m.setStatsDown(false)
 	// Mark session as uploaded in background — fire and forget
-	return m, markSessionUploadedCmd(msg.gameID)
+	cmds := []tea.Cmd{tea.Sequence(markSessionUploadedCmd(msg.gameID), countPendingCmd())}
+
+	// For a solve made in this run, fetch stats (which now include it) so the
+	// solved screen can compare against the player's average.
+	if m.game.freshSolve && m.game.puzzle != nil && msg.gameID == m.game.puzzle.ID && m.claimCode != "" {
+		m.game.percentile = msg.percentile
+		cmds = append(cmds, fetchSolveStatsCmd(m.client, m.claimCode), m.fetchGlobalStatsCmd())
+	}
+
+	return m, tea.Batch(cmds...)
 }
 
 func (m Model) handlePuzzleFetched(msg puzzleFetchedMsg) (tea.Model, tea.Cmd) {
+	// The player moved on before this fetch returned; its trace ends unused
+	if m.staleFetch(msg.fetch) {
+		msg.trace.end(outcomeLeft, nil)
+		return m, nil
+	}
+
 	// Sanitize API response fields to prevent terminal escape sequence injection
 	msg.puzzle.Author = ui.SanitizeString(msg.puzzle.Author)
 	msg.puzzle.EncryptedText = ui.SanitizeString(msg.puzzle.EncryptedText)
@@ -483,65 +871,110 @@ func (m Model) handlePuzzleFetched(msg puzzleFetchedMsg) (tea.Model, tea.Cmd) {
 	if len(msg.puzzle.Hints) > 0 {
 		hints = make(map[rune]rune, len(msg.puzzle.Hints))
 		for _, h := range msg.puzzle.Hints {
-			if len(h.CipherLetter) > 0 && len(h.PlainLetter) > 0 {
-				hints[rune(h.CipherLetter[0])] = rune(h.PlainLetter[0])
+			if cipher, plain, ok := h.Letters(); ok {
+				hints[cipher] = plain
 			}
 		}
 	}
 
-	m.puzzle = msg.puzzle
-	m.cells = puzzle.BuildCells(msg.puzzle.EncryptedText, hints)
-	m.cursorPos = puzzle.FirstLetterCell(m.cells)
+	m.game.puzzle = msg.puzzle
+	m.game.answer = msg.answer
+	m.game.offline = msg.offline
+	if msg.offline {
+		m.connection = connOffline
+	}
+	m.game.cells = puzzle.BuildCells(msg.puzzle.EncryptedText, hints)
+	m.game.cursorPos = puzzle.FirstLetterCell(m.game.cells)
 	m.state = StatePlaying
-	m.startTime = time.Now()
-	m.elapsedAtPause = 0
+	m.game.startTime = m.clock().Now()
+	m.game.elapsedAtPause = 0
+	m.game.trace = msg.trace
+	m.game.trace.shown(msg.puzzle)
+	// Whether the quote is already a favorite, for the solved screen
+	favorite := loadFavoriteCmd(msg.puzzle.ID)
+	// A duel starts fresh once an opponent joins, rather than from a saved session
+	if m.duel.room != "" {
+		m.state = StateDuelWaiting
+		m.duel.stream = m.client.SubscribeDuel(m.duel.room)
+		return m, tea.Batch(pollDuelCmd(m.client, m.duel.room, m.duelProgress(), 0), waitForEventCmd(m.duel.stream), favorite)
+	}
+	// Safe mode starts fresh rather than restore a session that may be corrupt,
+	// and the tutorial always starts from the beginning
+	if m.opts.SafeMode || m.inTutorial() {
+		model, cmd := m.handleSessionLoaded(sessionLoadedMsg{})
+		return model, tea.Batch(cmd, favorite)
+	}
 	// Load any saved session for this puzzle
-	return m, loadSessionCmd(msg.puzzle.ID)
+	return m, tea.Batch(tagFetch(m.puzzleFetch, loadSessionCmd(m.sessions(), msg.puzzle.ID)), favorite)
 }
 
 func (m Model) handleSessionLoaded(msg sessionLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.staleFetch(msg.fetch) {
+		return m, nil
+	}
+	// A session saved for another puzzle would put its inputs in this grid
+	if msg.session != nil && !m.sessionFits(msg.session) {
+		msg.session = nil
+	}
 	if msg.session == nil {
 		// No saved session - check for remote completion before starting
-		if m.claimCode != "" && m.puzzle != nil {
-			return m, tea.Batch(tickCmd(), checkRemoteSessionCmd(m.client, m.claimCode, m.puzzle.ID))
+		m, tick := m.startTick()
+		if m.recordsStats() && m.game.puzzle != nil {
+			return m, tea.Batch(tick, tagFetch(m.puzzleFetch, checkRemoteSessionCmd(m.client, m.claimCode, m.game.puzzle.ID)))
 		}
-		return m, tickCmd()
+		return m, tick
 	}
 
-	// Restore inputs - iterate cells and apply saved inputs
-	// This must happen for both solved and in-progress sessions
-	for i := range m.cells {
-		if m.cells[i].Kind != puzzle.CellLetter {
-			continue
-		}
-		cipherChar := string(m.cells[i].Char)
-		if input, ok := msg.session.Inputs[cipherChar]; ok && input != "" {
-			// SetInput propagates to all cells with same cipher letter
-			puzzle.SetInput(m.cells, i, rune(input[0]))
-		}
+	// Restore inputs for both solved and in-progress sessions
+	m.game.restoreInputs(msg.session)
+
+	// Resuming a speed run keeps its target and the splits made so far
+	if msg.session.Target > 0 && m.run.target == 0 {
+		m.run.target = msg.session.Target
 	}
+	if m.run.target > 0 {
+		m.run.splits = msg.session.Splits
+	}
+	m.game.letters = letterTimesFromSession(msg.session.LetterTimes)
+	m.game.assists = msg.session.Assists
+	m.game.note = msg.session.Note
 
-	// Check if already solved locally (AC3.3: local state always wins)
-	if msg.session.Solved {
-		m.state = StateSolved
-		m.elapsedAtPause = msg.session.CompletionTime
-		m.statusMsg = ""
-		return m, nil
+	if msg.session.Revealed || msg.session.Solved {
+		return m.resumeFinished(msg.session)
 	}
 
 	// In-progress session — restore timer and check for remote completion
-	m.elapsedAtPause = msg.session.ElapsedTime
-	m.startTime = time.Now()
+	m.game.elapsedAtPause = msg.session.ElapsedTime
+	m.game.startTime = m.clock().Now()
 
-	if m.claimCode != "" && m.puzzle != nil {
-		return m, tea.Batch(tickCmd(), checkRemoteSessionCmd(m.client, m.claimCode, m.puzzle.ID))
+	m, tick := m.startTick()
+	if m.recordsStats() && m.game.puzzle != nil {
+		return m, tea.Batch(tick, tagFetch(m.puzzleFetch, checkRemoteSessionCmd(m.client, m.claimCode, m.game.puzzle.ID)))
 	}
-	return m, tickCmd()
+	return m, tick
+}
+
+// resumeFinished shows a saved puzzle that is already over: given up on,
+// which stays over but not solved, or solved (AC3.3: local state always
+// wins). Either way it keeps watching for the next daily puzzle.
+func (m Model) resumeFinished(session *storage.GameSession) (tea.Model, tea.Cmd) {
+	m.state = StateSolved
+	if session.Revealed {
+		m.game.revealed = true
+		m.game.elapsedAtPause = session.ElapsedTime
+		m.game.trace.end(outcomeRevealed, nil)
+	} else {
+		m.game.elapsedAtPause = session.CompletionTime
+		m.game.trace.end(outcomeAlreadySolved, nil)
+	}
+	m, tick := m.startTick()
+	return m, tea.Batch(tick, m.fetchGlobalStatsCmd())
 }
 
 func (m Model) handleRemoteSession(msg remoteSessionMsg) (tea.Model, tea.Cmd) {
-	// AC3.4/AC3.5: nil session means 404 or error — continue playing
-	if msg.session == nil {
+	// AC3.4/AC3.5: nil session means 404 or error — continue playing, as
+	// does a check for a puzzle the player has since left
+	if msg.session == nil || m.staleFetch(msg.fetch) {
 		return m, nil
 	}
 
@@ -553,44 +986,19 @@ func (m Model) handleRemoteSession(msg remoteSessionMsg) (tea.Model, tea.Cmd) {
 
 	// AC3.1: remote completion detected — show solved-elsewhere state
 	m.state = StateSolved
-	m.solvedElsewhere = true
-	m.elapsedAtPause = time.Duration(msg.session.CompletionTime) * time.Millisecond
-	m.statusMsg = ""
+	m.game.solvedElsewhere = true
+	m.game.elapsedAtPause = time.Duration(msg.session.CompletionTime) * time.Millisecond
+	m.game.trace.end(outcomeAlreadySolved, nil)
 
 	return m, nil
 }
 
 func (m Model) handleStatsFetched(msg statsFetchedMsg) (tea.Model, tea.Cmd) {
-	m.stats = msg.stats
+	if m.stats.stale(msg.fetch) {
+		return m, nil
+	}
+	m.stats.player = msg.stats
+	m = m.setStatsDown(false)
 	m.state = StateStats
 	return m, nil
 }
-
-func (m Model) handleError(msg errMsg) (tea.Model, tea.Cmd) {
-	m.state = StateError
-	m.errorMsg = formatErrorMessage(msg.err)
-	return m, nil
-}
-
-// formatErrorMessage converts error to user-friendly message
-func formatErrorMessage(err error) string {
-	errStr := err.Error()
-
-	// Check for connection refused
-	if strings.Contains(errStr, "connection refused") {
-		return "Cannot connect to server. Check that the API is running."
-	}
-
-	// Check for timeout
-	if strings.Contains(errStr, "timeout") || strings.Contains(errStr, "deadline exceeded") {
-		return "Request timed out. Press 'r' to retry."
-	}
-
-	// Check for HTTP status errors (from our client)
-	if strings.Contains(errStr, "server returned") {
-		return errStr + " Press 'r' to retry."
-	}
-
-	// Default: show original error
-	return errStr
-}
@@ -1,8 +1,15 @@
 package app
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
 	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
 )
 
 func TestFormatErrorMessage(t *testing.T) {
@@ -13,23 +20,53 @@ func TestFormatErrorMessage(t *testing.T) {
 	}{
 		{
 			name:     "connection refused",
-			err:      errors.New("connection refused"),
+			err:      fmt.Errorf("failed to fetch puzzle: %w", &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}),
 			expected: "Cannot connect to server. Check that the API is running.",
 		},
 		{
 			name:     "timeout error",
-			err:      errors.New("i/o timeout"),
+			err:      &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded},
 			expected: "Request timed out. Press 'r' to retry.",
 		},
 		{
 			name:     "deadline exceeded",
-			err:      errors.New("context deadline exceeded"),
+			err:      fmt.Errorf("failed to fetch puzzle: %w", context.DeadlineExceeded),
 			expected: "Request timed out. Press 'r' to retry.",
 		},
 		{
 			name:     "server error response",
-			err:      errors.New("server returned 500 Internal Server Error"),
-			expected: "server returned 500 Internal Server Error Press 'r' to retry.",
+			err:      &api.APIError{Status: 500, Body: "Internal Server Error"},
+			expected: "server returned 500: Internal Server Error Press 'r' to retry.",
+		},
+		{
+			name:     "server unavailable",
+			err:      &api.APIError{Status: 503},
+			expected: "The server is unavailable right now. Press 'r' to retry.",
+		},
+		{
+			name:     "rate limited",
+			err:      &api.APIError{Status: 429, Body: "slow down"},
+			expected: "The server is busy. Press 'r' to retry.",
+		},
+		{
+			name:     "not found",
+			err:      api.ErrGameNotFound,
+			expected: "game not found: invalid game ID",
+		},
+		{
+			name:     "unsupported",
+			err:      fmt.Errorf("%s: %w", api.FeatureArchive, api.ErrUnsupported),
+			expected: "This server is too old for that (archive: not supported by this server). Try another puzzle.",
+		},
+		{
+			name:     "invalid puzzle",
+			err:      api.Validate(&api.Puzzle{ID: "g", Date: "2026-01-20", EncryptedText: "ABC", Hints: []api.Hint{{CipherLetter: "Q", PlainLetter: "E"}}}),
+			expected: `The server sent a puzzle that can't be played (invalid puzzle "g": hint 1: Q does not appear in the puzzle). Try another puzzle.`,
+		},
+		{
+			name:     "error text alone is not classified",
+			err:      errors.New("connection refused"),
+			expected: "connection refused",
 		},
 		{
 			name:     "generic error",
@@ -2,16 +2,16 @@ package app
 
 import (
 	"fmt"
-	"math"
 	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
-	"github.com/guptarohit/asciigraph"
+	"github.com/charmbracelet/x/ansi"
 	zone "github.com/lrstanley/bubblezone/v2"
 
 	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/statsdiff"
 	"github.com/bojanrajkovic/unquote/tui/internal/ui"
 )
 
@@ -24,34 +24,85 @@ func formatElapsed(d time.Duration) string {
 
 // View renders the UI
 func (m Model) View() tea.View {
-	var content string
+	content, timer := m.viewContent()
+	// Scan to process zone markers and calculate boundaries
+	var v tea.View
+	if timer != "" {
+		v = tea.NewView(m.frame.render(content, timer))
+	} else {
+		v = tea.NewView(zone.Scan(content))
+	}
+	v.AltScreen = true
+	// All-motion mode reports hover, not just drags, for the related-letter preview
+	v.MouseMode = tea.MouseModeAllMotion
+	return v
+}
+
+// viewContent renders the current screen under the status bar. timer is set
+// when content holds timerSlot, for the frame cache to fill in.
+func (m Model) viewContent() (content, timer string) {
 	switch {
 	case !m.sizeReady:
-		content = "Initializing..."
+		return "Initializing...", ""
 	case m.IsTooSmall():
-		content = m.viewTooSmall()
+		return m.viewTooSmall(), ""
+	}
+
+	switch m.state {
+	case StateLoading:
+		content = m.viewLoading()
+	case StateError:
+		content = m.viewError()
+	case StateRecovery:
+		content = m.viewRecovery()
+	case StatePlaying, StateChecking, StateSolved:
+		content, timer = m.game.view(m)
+	case StateOnboarding:
+		content = m.viewOnboarding()
+	case StateClaimCodeDisplay:
+		content = m.viewClaimCodeDisplay()
+	case StateStats:
+		content = m.viewStats()
+	case StateArchive:
+		content = m.viewArchive()
+	case StateNextPuzzle:
+		content = m.viewNextPuzzle()
+	case StateQuoteInfo:
+		content = m.viewQuoteInfo()
+	case StateDuelWaiting:
+		content = m.viewDuelWaiting()
 	default:
-		switch m.state {
-		case StateLoading:
-			content = m.viewLoading()
-		case StateError:
-			content = m.viewError()
-		case StatePlaying, StateChecking, StateSolved:
-			content = m.viewPlaying()
-		case StateOnboarding:
-			content = m.viewOnboarding()
-		case StateClaimCodeDisplay:
-			content = m.viewClaimCodeDisplay()
-		case StateStats:
-			content = m.viewStats()
-		default:
-			content = "Unknown state"
-		}
+		content = "Unknown state"
 	}
-	v := tea.NewView(content)
-	v.AltScreen = true
-	v.MouseMode = tea.MouseModeCellMotion
-	return v
+	return m.withStatusBar(content), timer
+}
+
+// chrome is what the router hands a screen component to draw itself with:
+// the terminal's size and the parts every screen shares.
+type chrome struct {
+	now        time.Time // in the player's zone
+	header     string    // the app header, rendered
+	help       string    // the screen's help bar items, unstyled
+	width      int
+	height     int
+	accessible bool
+}
+
+// chrome returns what the screen components draw themselves with now.
+func (m Model) chrome() chrome {
+	return chrome{
+		now:        m.clock().Now().In(m.location()),
+		header:     m.renderHeader(),
+		help:       renderHelpItems(m.helpItems()),
+		width:      m.width,
+		height:     m.height,
+		accessible: m.accessible,
+	}
+}
+
+// viewStats renders the stats screen.
+func (m Model) viewStats() string {
+	return m.stats.view(m.chrome())
 }
 
 func (m Model) viewTooSmall() string {
@@ -63,7 +114,7 @@ func (m Model) viewTooSmall() string {
 		MinTerminalWidth, MinTerminalHeight,
 	)
 
-	help := ui.HelpStyle.Render("\n[Esc] Quit")
+	help := ui.HelpStyle.Render("\n" + renderHelpItems([]helpItem{helpQuit}))
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -79,7 +130,7 @@ func (m Model) viewLoading() string {
 		msg = "Loading puzzle..."
 	}
 	content := ui.LoadingStyle.Render(msg)
-	help := ui.HelpStyle.Render("[Esc] Quit")
+	help := ui.HelpStyle.Render(renderHelpItems(m.helpItems()))
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -96,10 +147,15 @@ func (m Model) viewError() string {
 
 	// Wrap error message to fit terminal width (leave margin for padding)
 	maxWidth := max(m.width-4, 20)
-	wrappedMsg := ui.WordWrapText(fmt.Sprintf("Error: %s", m.errorMsg), maxWidth)
-	content := ui.ErrorStyle.Render(wrappedMsg)
+	var content string
+	if !m.retryAt.IsZero() {
+		// A rate limit isn't the player's problem; it clears on its own
+		content = ui.WarningStyle.Render(ui.WordWrapText(m.errs.load, maxWidth))
+	} else {
+		content = ui.ErrorStyle.Render(ui.WordWrapText(fmt.Sprintf("Error: %s", m.errs.load), maxWidth))
+	}
 
-	help := ui.HelpStyle.Render("[r] Retry  [Esc] Quit")
+	help := ui.HelpStyle.Render(renderHelpItems(m.helpItems()))
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -112,71 +168,175 @@ func (m Model) viewError() string {
 }
 
 func (m Model) viewPlaying() string {
-	header := m.renderHeader()
-
-	// Category and Difficulty
-	diffText := puzzle.DifficultyText(m.puzzle.Difficulty)
-	difficulty := ui.DifficultyStyle.Render(fmt.Sprintf("%s · Difficulty: %s", m.puzzle.Category, diffText))
-
-	// Timer
-	timer := ui.TimerStyle.Render(fmt.Sprintf("Time: %s", formatElapsed(m.Elapsed())))
-
-	// Hints
-	hints := m.renderHints()
-
-	// Puzzle grid
-	grid := m.renderGrid()
-
-	// Author
-	author := ui.AuthorStyle.Render(fmt.Sprintf("— %s", m.puzzle.Author))
+	return m.playingScreen(m.renderTimer())
+}
 
-	// Status message (incorrect answer, incomplete, etc.)
-	status := m.renderStatus()
+// playingScreen renders the playing screen with timer in the timer's row.
+func (m Model) playingScreen(timer string) string {
+	m = m.syncGridView(false)
+	return m.layoutPlaying(m.besideAlphabet(m.renderGridViewport()), timer)
+}
 
-	// Help bar based on state
-	help := m.renderHelp()
+// layoutPlaying stacks the playing screen around an already-rendered grid
+// block and timer.
+func (m Model) layoutPlaying(grid, timer string) string {
+	author := ui.AuthorStyle.Render(fmt.Sprintf("— %s", m.game.puzzle.Author))
 
-	view := lipgloss.JoinVertical(
+	return lipgloss.JoinVertical(
 		lipgloss.Left,
-		header,
-		difficulty,
+		m.renderHeader(),
+		m.renderDetails(),
 		timer,
+		// Letters and words filled in so far
+		m.renderProgress(),
 		"",
-		hints,
+		m.renderHints(),
 		"",
 		grid,
 		"",
 		author,
 		"",
-		status,
-		help,
+		m.renderStatusArea(),
+		m.renderHelp(),
 	)
+}
 
-	// Scan to process zone markers and calculate boundaries
-	return zone.Scan(view)
+// renderDetails renders the puzzle's category and difficulty, and whether
+// it is being played offline.
+func (m Model) renderDetails() string {
+	details := fmt.Sprintf("%s · Difficulty: %s", m.game.puzzle.Category, puzzle.DifficultyText(m.game.puzzle.Difficulty))
+	if m.game.offline {
+		details += " · Offline"
+	}
+	return ui.DifficultyStyle.Render(details)
+}
+
+// renderStatusArea renders the status message (incorrect answer, incomplete,
+// etc.) with the alerts that go above it and the panels that go below it.
+// Panels with nothing to show take no room.
+func (m Model) renderStatusArea() string {
+	var notice string
+	if text := m.newPuzzleNotice(); text != "" {
+		// The daily puzzle rolled over while this one was open
+		notice = ui.WarningStyle.Render(text)
+	}
+	above := textLines(
+		// The last check or reveal that failed
+		m.renderPlayError(),
+		// Stats calls failing while the puzzle plays on
+		m.renderStatsBanner(),
+		notice,
+	)
+	below := textLines(
+		// Post-solve comparison against the player's own history
+		m.renderSolveComparison(),
+		// How everyone else did on this puzzle
+		m.renderCommunity(),
+		// Optional difficulty rating
+		m.renderRatingPrompt(),
+		// The player's note on the solve, or the editor for it
+		m.renderNote(),
+		// Speed-run result and per-word splits
+		m.renderSplits(),
+		// Head-to-head progress
+		m.renderDuel(),
+		// Where the player got stuck
+		m.renderLetterBreakdown(),
+		// Letters the board leaves no choice about
+		m.renderAutoFill(),
+		// Dictionary words that fit the word under the cursor
+		m.renderWordSuggestions(),
+		// The cipher alphabet and what each letter stands for, when it
+		// doesn't fit beside the grid
+		m.renderAlphabetBelow(),
+		// Letters to pick from for the cell under the cursor
+		m.renderPicker(),
+		// Words to jump between
+		m.renderJump(),
+		// Waiting for the cipher letter to find
+		m.renderSearch(),
+		// Waiting for the cipher letter to swap with
+		m.renderSwap(),
+		// What the tutorial's current step asks
+		m.renderTutorial(),
+	)
+
+	above = append(above, m.renderStatus())
+	return lipgloss.JoinVertical(lipgloss.Left, append(above, below...)...)
+}
+
+// renderGridViewport renders the visible part of the grid, with a scroll
+// indicator above and below when the grid does not fit the terminal. It
+// reuses the last frame's when neither the grid nor the scroll position
+// changed.
+func (m Model) renderGridViewport() string {
+	if m.gridCache == nil {
+		return m.drawGridViewport()
+	}
+	key := gridViewKey{
+		grid:    m.gridCache.grid,
+		yOffset: m.gridView.YOffset(),
+		width:   m.gridView.Width(),
+		height:  m.gridView.Height(),
+	}
+	return m.gridCache.viewport(key, m.drawGridViewport)
+}
+
+// drawGridViewport renders the visible part of the grid and its scroll indicators.
+func (m Model) drawGridViewport() string {
+	view := m.gridView.View()
+	if m.gridView.TotalLineCount() <= m.gridView.Height() {
+		return view
+	}
+
+	var above, below string
+	if !m.gridView.AtTop() {
+		above = ui.HelpStyle.Render("▲ more above")
+	}
+	if !m.gridView.AtBottom() {
+		below = ui.HelpStyle.Render("▼ more below")
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, above, view, below)
 }
 
 func (m Model) renderHeader() string {
+	if m.accessible {
+		return m.headerTitle()
+	}
 	headerStyle := ui.HeaderStyle
 	if m.width > 0 {
 		headerStyle = headerStyle.Width(m.width)
 	}
-	return headerStyle.Render("CRYPTO-QUIP")
+	return headerStyle.Render(m.headerTitle())
+}
+
+// headerTitle labels the tutorial, practice games and pack puzzles so they
+// are never mistaken for the daily puzzle.
+func (m Model) headerTitle() string {
+	switch {
+	case m.inTutorial():
+		return "CRYPTO-QUIP · TUTORIAL"
+	case m.opts.Practice:
+		return "CRYPTO-QUIP · PRACTICE"
+	case m.opts.Pack != nil:
+		return "CRYPTO-QUIP · " + strings.ToUpper(ui.SanitizeString(m.opts.Pack.Name))
+	default:
+		return "CRYPTO-QUIP"
+	}
 }
 
 func (m Model) renderHints() string {
-	if m.puzzle == nil || len(m.puzzle.Hints) == 0 {
+	if m.game.puzzle == nil || len(m.game.puzzle.Hints) == 0 {
 		return ""
 	}
 
 	var builder strings.Builder
-	for i, hint := range m.puzzle.Hints {
+	for i, hint := range m.game.puzzle.Hints {
 		if i > 0 {
 			builder.WriteString(", ")
 		}
-		builder.WriteString(hint.CipherLetter)
-		builder.WriteString(" = ")
-		builder.WriteString(hint.PlainLetter)
+		// Each clue is clickable to highlight where its cipher letter appears
+		builder.WriteString(zone.Mark(fmt.Sprintf("hint-%d", i), hint.CipherLetter+" = "+hint.PlainLetter))
 	}
 
 	return ui.HintStyle.Render(fmt.Sprintf("Clues: %s", builder.String()))
@@ -185,43 +345,66 @@ func (m Model) renderHints() string {
 func (m Model) renderStatus() string {
 	switch m.state {
 	case StateChecking:
+		if m.loadingMsg != "" {
+			return ui.LoadingStyle.Render(m.loadingMsg)
+		}
 		return ui.LoadingStyle.Render("Checking solution...")
 	case StateSolved:
-		if m.solvedElsewhere {
+		if m.game.revealed {
+			return ui.WarningStyle.Render(fmt.Sprintf("Solution revealed after %s. Better luck next time!", formatElapsed(m.Elapsed())))
+		}
+		if m.game.solvedElsewhere {
 			return ui.SuccessStyle.Render(fmt.Sprintf("Solved on another device in %s", formatElapsed(m.Elapsed())))
 		}
-		return ui.SuccessStyle.Render(fmt.Sprintf("Congratulations! You solved it in %s!", formatElapsed(m.Elapsed())))
+		status := fmt.Sprintf("Congratulations! You solved it in %s!", formatElapsed(m.Elapsed()))
+		if m.game.favorite {
+			status += " ★"
+		}
+		return ui.SuccessStyle.Render(status) + m.renderAssistedTag()
 	default:
-		if m.statusMsg != "" {
-			return ui.ErrorStyle.Render(m.statusMsg)
+		if warning := conflictWarning(findDuplicateInputs(m.game.cells)); warning != "" {
+			if m.width > 0 {
+				warning = ansi.Truncate(warning, m.width, "…")
+			}
+			return ui.WarningStyle.Render(warning)
 		}
 		return ""
 	}
 }
 
+// renderSolveComparison renders today's solve time against the player's
+// average and, when the server reported one, a percentile versus other
+// players. Only shown for solves made in this run once stats have arrived.
+func (m Model) renderSolveComparison() string {
+	if m.state != StateSolved || !m.game.freshSolve || m.stats.player == nil {
+		return ""
+	}
+
+	c := statsdiff.Compare(m.game.elapsedAtPause, m.stats.player.AverageTime, m.stats.player.GamesSolved, true, m.game.percentile)
+	lines := []string{ui.TimerStyle.Render(c.Summary())}
+	if p := c.PercentileText(); p != "" {
+		lines = append(lines, ui.TimerStyle.Render(p))
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (m Model) renderHelp() string {
 	switch m.state {
 	case StateChecking:
 		return ""
 	case StateSolved:
-		if m.shareFeedback != "" {
-			return ui.HelpStyle.Render(m.shareFeedback)
+		if m.claimCode != "" || m.inTutorial() {
+			return ui.HelpStyle.Render(renderHelpItems(m.helpItems()))
 		}
-		if m.claimCode != "" {
-			return ui.HelpStyle.Render("[s] Stats  [c] Share  [Esc] Quit")
-		}
-		return ui.HelpStyle.Render("[c] Share  [Esc] Quit  · Tip: run 'unquote register' to track your stats")
+		return ui.HelpStyle.Render(renderHelpItems(m.helpItems()) + "  · Tip: run 'unquote register' to track your stats")
 	default:
-		return ui.HelpStyle.Render("[Enter] Submit  [Ctrl+C] Clear  [Esc] Quit")
+		return ui.HelpStyle.Render(renderHelpItems(m.helpItems()))
 	}
 }
 
-// viewOnboarding renders the huh onboarding form centered in the terminal.
+// viewOnboarding renders the onboarding form centered in the terminal.
 func (m Model) viewOnboarding() string {
-	if m.form == nil {
-		return ""
-	}
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.form.View())
+	return m.onboarding.view(m.width, m.height)
 }
 
 // formatMs formats milliseconds as M:SS (e.g. 128000 → "2:08").
@@ -231,103 +414,6 @@ func formatMs(ms float64) string {
 	return fmt.Sprintf("%d:%02d", minutes, seconds)
 }
 
-// viewStats renders the stats screen with a solve-time graph and summary sidebar.
-func (m Model) viewStats() string {
-	header := m.renderHeader()
-
-	if m.stats == nil {
-		help := ui.HelpStyle.Render("[Esc] Quit")
-		return lipgloss.JoinVertical(lipgloss.Left, header, "", ui.ErrorStyle.Render("Failed to load stats."), "", help)
-	}
-
-	const sidebarWidth = 28
-	const dayWindow = 30
-
-	// Build solve-time data points (last 30 days, NaN for missing days)
-	solveMap := make(map[string]float64, len(m.stats.RecentSolves))
-	for _, s := range m.stats.RecentSolves {
-		solveMap[s.Date] = s.CompletionTime
-	}
-
-	points := make([]float64, dayWindow)
-	hasData := false
-	for i := range dayWindow {
-		points[i] = math.NaN()
-	}
-
-	// Fill points from recentSolves (API returns them ordered; use date map).
-	// Cap to dayWindow entries and right-align in the points array.
-	n := min(len(m.stats.RecentSolves), dayWindow)
-	offset := dayWindow - n
-	for i := range n {
-		points[offset+i] = m.stats.RecentSolves[len(m.stats.RecentSolves)-n+i].CompletionTime / 60000.0
-		hasData = true
-	}
-
-	// Build graph panel
-	graphWidth := max(m.width-sidebarWidth-6, 20)
-
-	var graphPanel string
-	if !hasData {
-		graphPanel = ui.HelpStyle.Render("No solve history in the last 30 days.")
-	} else {
-		plot := asciigraph.Plot(
-			points,
-			asciigraph.Height(10),
-			asciigraph.Width(graphWidth),
-			asciigraph.Precision(1),
-			asciigraph.LowerBound(0),
-			asciigraph.Caption("Solve Times (last 30 days, minutes)"),
-		)
-		graphPanel = plot
-	}
-
-	// Build sidebar panel
-	labelStyle := lipgloss.NewStyle().Foreground(ui.ColorMuted)
-	valueStyle := lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true)
-
-	formatOptMs := func(ms *float64) string {
-		if ms == nil {
-			return "—"
-		}
-		return formatMs(*ms)
-	}
-
-	winRatePct := fmt.Sprintf("%.1f%%", m.stats.WinRate*100)
-
-	lines := []string{
-		labelStyle.Render("Games Played"),
-		valueStyle.Render(fmt.Sprintf("%d", m.stats.GamesPlayed)),
-		"",
-		labelStyle.Render("Games Solved"),
-		valueStyle.Render(fmt.Sprintf("%d", m.stats.GamesSolved)),
-		"",
-		labelStyle.Render("Win Rate"),
-		valueStyle.Render(winRatePct),
-		"",
-		labelStyle.Render("Current Streak"),
-		valueStyle.Render(fmt.Sprintf("%d", m.stats.CurrentStreak)),
-		"",
-		labelStyle.Render("Best Streak"),
-		valueStyle.Render(fmt.Sprintf("%d", m.stats.BestStreak)),
-		"",
-		labelStyle.Render("Best Time"),
-		valueStyle.Render(formatOptMs(m.stats.BestTime)),
-		"",
-		labelStyle.Render("Avg Time"),
-		valueStyle.Render(formatOptMs(m.stats.AverageTime)),
-	}
-
-	sidebarContent := strings.Join(lines, "\n")
-	sidebarPanel := lipgloss.NewStyle().Width(sidebarWidth).Padding(0, 2).Render(sidebarContent)
-
-	content := lipgloss.JoinHorizontal(lipgloss.Top, graphPanel, "  ", sidebarPanel)
-
-	help := ui.HelpStyle.Render("[Esc] Back")
-
-	return lipgloss.JoinVertical(lipgloss.Left, header, "", content, "", help)
-}
-
 // viewClaimCodeDisplay renders the claim code as a raffle-ticket style card.
 func (m Model) viewClaimCodeDisplay() string {
 	// innerWidth is the content area width. All items are constrained to this
@@ -408,5 +494,5 @@ func (m Model) viewClaimCodeDisplay() string {
 	)
 
 	box := boxStyle.Render(content)
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+	return lipgloss.Place(m.width, m.height-statusBarHeight, lipgloss.Center, lipgloss.Center, box)
 }
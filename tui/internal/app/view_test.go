@@ -1,8 +1,18 @@
 package app
 
 import (
+	"strings"
 	"testing"
 	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/adrg/xdg"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
 )
 
 func TestFormatElapsed(t *testing.T) {
@@ -62,3 +72,269 @@ func TestFormatElapsed(t *testing.T) {
 		})
 	}
 }
+
+// playingModel creates a Model in StatePlaying for the given cipher text and
+// terminal size, as it would be after the puzzle loads and the size is known.
+func playingModel(t *testing.T, text string, width, height int) Model {
+	t.Helper()
+
+	m := Model{
+		state: StatePlaying,
+		game: gameModel{puzzle: &api.Puzzle{
+			ID:            "test-puzzle",
+			EncryptedText: text,
+			Author:        "Test Author",
+			Category:      "Test",
+			Difficulty:    50,
+		}, startTime: time.Now()},
+	}
+	m.game.cells = puzzle.BuildCells(text, nil)
+	m.game.cursorPos = puzzle.FirstLetterCell(m.game.cells)
+
+	model, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	return model.(Model)
+}
+
+// longQuote is long enough to wrap onto many grid lines at 60 columns.
+const longQuote = "XLMW MW E ZIVC PSRK UYSXI XLEX OIITW KSMRK ERH KSMRK " +
+	"ERH KSMRK YRXMP MX HSIW RSX JMX SR XLI WGVIIR ER CQSVI " +
+	"FIGEYWI XLI XIVQMREP MW QYGL XSS WLSVX JSV MX XS JMX"
+
+func TestGridLineWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		width int
+		want  int
+	}{
+		{name: "unknown width", width: 0, want: defaultLineWidth},
+		{name: "narrow terminal", width: 45, want: 45},
+		{name: "wide terminal", width: 200, want: 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{width: tt.width}
+			if got := m.gridLineWidth(); got != tt.want {
+				t.Errorf("gridLineWidth() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWindowSizeMsg_RewrapsGrid(t *testing.T) {
+	m := playingModel(t, longQuote, 60, 40)
+	linesAt60 := len(m.gridLines())
+
+	tests := []struct {
+		name    string
+		compare func(lines int) bool
+		width   int
+	}{
+		{name: "wider terminal uses fewer lines", width: 160, compare: func(n int) bool { return n < linesAt60 }},
+		{name: "narrower terminal uses more lines", width: 40, compare: func(n int) bool { return n > linesAt60 }},
+		{name: "same width keeps wrapping", width: 60, compare: func(n int) bool { return n == linesAt60 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, _ := m.Update(tea.WindowSizeMsg{Width: tt.width, Height: 40})
+			resized := model.(Model)
+
+			lines := resized.gridLines()
+			if !tt.compare(len(lines)) {
+				t.Errorf("width %d wrapped into %d lines (%d at width 60)", tt.width, len(lines), linesAt60)
+			}
+			for i, line := range lines {
+				if w := len(line) * cellWidth; w > tt.width {
+					t.Errorf("line %d is %d columns wide, exceeds terminal width %d", i, w, tt.width)
+				}
+			}
+		})
+	}
+}
+
+func TestWindowSizeMsg_KeepsCursorVisible(t *testing.T) {
+	m := playingModel(t, longQuote, 160, 24)
+	m.game.cursorPos = puzzle.LastLetterCell(m.game.cells)
+
+	// Shrinking the terminal re-wraps the grid onto more lines than fit
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 40, Height: 24})
+	m = model.(Model)
+
+	rows, gap := m.gridLineSpacing()
+	top := m.gridView.YOffset() / rows
+	visible := (m.gridView.Height() + gap) / rows
+	if line := m.cursorLine(); line < top || line >= top+visible {
+		t.Errorf("cursor line %d outside visible lines %d-%d after resize", line, top, top+visible-1)
+	}
+}
+
+func TestViewPlaying_ShortQuoteHasNoScrollIndicators(t *testing.T) {
+	m := playingModel(t, "ABC DEF", 80, 40)
+	view := m.viewPlaying()
+
+	if strings.Contains(view, "more above") || strings.Contains(view, "more below") {
+		t.Error("grid that fits should not show scroll indicators")
+	}
+	if !strings.Contains(view, "Test Author") {
+		t.Error("view should contain the author line")
+	}
+}
+
+func TestViewPlaying_LongQuoteScrollsWithinTerminal(t *testing.T) {
+	m := playingModel(t, longQuote, 60, 24)
+	view := m.viewPlaying()
+
+	if h := lipgloss.Height(view); h > m.height {
+		t.Errorf("view height %d exceeds terminal height %d", h, m.height)
+	}
+	if !strings.Contains(view, "▼ more below") {
+		t.Error("long quote should show the more-below indicator")
+	}
+	if strings.Contains(view, "▲ more above") {
+		t.Error("grid starts at the top, more-above indicator should be hidden")
+	}
+}
+
+func TestViewPlaying_GridFollowsCursor(t *testing.T) {
+	m := playingModel(t, longQuote, 60, 24)
+
+	// Walk the cursor to the last letter of the quote
+	last := puzzle.LastLetterCell(m.game.cells)
+	for m.game.cursorPos != last {
+		model, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+		m = model.(Model)
+	}
+
+	if m.gridView.YOffset() == 0 {
+		t.Fatal("grid should have scrolled to follow the cursor")
+	}
+	if rows, _ := m.gridLineSpacing(); m.gridView.YOffset()%rows != 0 {
+		t.Errorf("YOffset %d should land on a whole grid line", m.gridView.YOffset())
+	}
+
+	view := m.viewPlaying()
+	if !strings.Contains(view, "▲ more above") {
+		t.Error("scrolled grid should show the more-above indicator")
+	}
+	if strings.Contains(view, "▼ more below") {
+		t.Error("grid scrolled to the last line should hide the more-below indicator")
+	}
+}
+
+func TestHandleMouseWheel_ScrollsGrid(t *testing.T) {
+	m := playingModel(t, longQuote, 60, 24)
+	rows, _ := m.gridLineSpacing()
+
+	model, _ := m.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	m = model.(Model)
+	if got := m.gridView.YOffset(); got != rows {
+		t.Errorf("after wheel down YOffset = %d, want %d", got, rows)
+	}
+
+	// Timer ticks must not snap the grid back to the cursor
+	model, _ = m.Update(tickMsg{})
+	m = model.(Model)
+	if got := m.gridView.YOffset(); got != rows {
+		t.Errorf("tick should keep scroll position, YOffset = %d", got)
+	}
+
+	model, _ = m.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	m = model.(Model)
+	if got := m.gridView.YOffset(); got != 0 {
+		t.Errorf("after wheel up YOffset = %d, want 0", got)
+	}
+}
+
+func TestToggleCompactGrid(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	m := playingModel(t, longQuote, 60, 24)
+	m.cfg = &config.Config{ClaimCode: "TIGER-MAPLE-7492", StatsEnabled: true}
+	standardHeight := m.gridView.TotalLineCount()
+
+	model, cmd := m.Update(tea.KeyPressMsg{Code: 'g', Mod: tea.ModCtrl})
+	m = model.(Model)
+	if !m.compactGrid {
+		t.Fatal("Ctrl+G should enable the compact grid")
+	}
+	if cmd == nil {
+		t.Fatal("toggling should return a command saving the preference")
+	}
+	cmd()
+
+	saved, err := config.Load()
+	if err != nil || saved == nil {
+		t.Fatalf("config.Load() = %v, %v", saved, err)
+	}
+	if !saved.CompactGrid {
+		t.Error("compact grid preference should be saved")
+	}
+	if saved.ClaimCode != "TIGER-MAPLE-7492" || !saved.StatsEnabled {
+		t.Errorf("saving the preference must keep the rest of the config, got %+v", saved)
+	}
+
+	if got := m.gridView.TotalLineCount(); got >= standardHeight {
+		t.Errorf("compact grid should take fewer rows: %d vs %d", got, standardHeight)
+	}
+	if !strings.Contains(m.viewPlaying(), "→") {
+		t.Error("compact grid should render cells as input→cipher")
+	}
+
+	model, _ = m.Update(tea.KeyPressMsg{Code: 'g', Mod: tea.ModCtrl})
+	if model.(Model).compactGrid {
+		t.Error("second Ctrl+G should switch back to the standard grid")
+	}
+}
+
+func TestToggleCompactGrid_EphemeralDoesNotSave(t *testing.T) {
+	m := playingModel(t, longQuote, 60, 24)
+	m.opts.Ephemeral = true
+
+	model, cmd := m.Update(tea.KeyPressMsg{Code: 'g', Mod: tea.ModCtrl})
+	if !model.(Model).compactGrid {
+		t.Fatal("Ctrl+G should still enable the compact grid")
+	}
+	if cmd != nil {
+		t.Error("an ephemeral run should keep the preference in memory only")
+	}
+}
+
+func TestPlainGStillTypesLetter(t *testing.T) {
+	m := playingModel(t, "ABC", 60, 24)
+
+	model, _ := m.Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	m = model.(Model)
+	if m.compactGrid {
+		t.Error("plain g is puzzle input and must not toggle the grid")
+	}
+	if m.game.cells[0].Input != 'G' {
+		t.Errorf("expected G typed into first cell, got %q", m.game.cells[0].Input)
+	}
+}
+
+func TestRenderStatus_ConflictWarning(t *testing.T) {
+	m := playingModel(t, "XQ XQ", 80, 40)
+	puzzle.SetInput(m.game.cells, 0, 'E')
+	puzzle.SetInput(m.game.cells, 1, 'E')
+
+	want := "Warning: 'E' is assigned to both Q and X"
+	if got := ansi.Strip(m.renderStatus()); got != want {
+		t.Errorf("renderStatus() = %q, want %q", got, want)
+	}
+
+	// Narrow terminals truncate the warning rather than wrapping it
+	m.width = 20
+	got := ansi.Strip(m.renderStatus())
+	if ansi.StringWidth(got) > 20 || !strings.HasSuffix(got, "…") {
+		t.Errorf("narrow renderStatus() = %q, want at most 20 columns ending in …", got)
+	}
+
+	// A toast goes to the status bar and doesn't hide the warning
+	m, _ = m.notify(toastError, "Fill in all letters first!")
+	if got := ansi.Strip(m.renderStatus()); !strings.HasPrefix(got, "Warning:") {
+		t.Errorf("renderStatus() = %q, want the warning kept", got)
+	}
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+	"github.com/bojanrajkovic/unquote/tui/internal/wordlist"
+)
+
+// maxWordSuggestions caps the words listed for the word under the cursor.
+const maxWordSuggestions = 8
+
+// wordIndex is the embedded English word list, indexed on first use.
+var wordIndex = sync.OnceValue(func() *puzzle.WordIndex {
+	return puzzle.NewWordIndex(wordlist.English())
+})
+
+// cursorWordSuggestion returns the dictionary words that fit the word under
+// the cursor, when the player opted in with Config.WordSuggestions.
+func (m Model) cursorWordSuggestion() (puzzle.WordSuggestion, bool) {
+	if m.cfg == nil || !m.cfg.WordSuggestions || m.state != StatePlaying || m.inTutorial() || m.game.revealed {
+		return puzzle.WordSuggestion{}, false
+	}
+	for _, s := range puzzle.SuggestWords(m.game.cells, wordIndex()) {
+		if m.game.cursorPos >= s.Start && m.game.cursorPos < s.End {
+			return s, true
+		}
+	}
+	return puzzle.WordSuggestion{}, false
+}
+
+// wordSuggestionText lists the most common words that fit the word under the
+// cursor, or is empty when there are none or the panel is off.
+func (m Model) wordSuggestionText() string {
+	s, ok := m.cursorWordSuggestion()
+	if !ok {
+		return ""
+	}
+	words := s.Words[:min(len(s.Words), maxWordSuggestions)]
+	text := fmt.Sprintf("Words that fit %s: %s", s.Cipher, strings.Join(words, ", "))
+	if more := len(s.Words) - len(words); more > 0 {
+		text += fmt.Sprintf(" (+%d more)", more)
+	}
+	return text
+}
+
+// renderWordSuggestions renders wordSuggestionText under the grid.
+func (m Model) renderWordSuggestions() string {
+	text := m.wordSuggestionText()
+	if text == "" {
+		return ""
+	}
+	return ui.TimerStyle.Render(ui.WordWrapText(text, max(m.width-4, 20)))
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/config"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+func TestWordSuggestions(t *testing.T) {
+	m := revealModel(&config.Config{WordSuggestions: true}, 0)
+	m.game.cells = puzzle.BuildCells("QWEQ RTY", nil)
+	m.game.cursorPos = 0
+
+	if text := m.wordSuggestionText(); !strings.HasPrefix(text, "Words that fit QWEQ: THAT, ") {
+		t.Errorf("wordSuggestionText() = %q, want THAT first for QWEQ", text)
+	}
+	if text := m.wordSuggestionText(); !strings.Contains(text, " more)") {
+		t.Errorf("wordSuggestionText() = %q, want the list capped", text)
+	}
+	if screen := ansi.Strip(m.layoutPlaying("", "")); !strings.Contains(screen, "Words that fit QWEQ") {
+		t.Errorf("the panel should show under the grid:\n%s", screen)
+	}
+
+	m.game.cursorPos = 5
+	m = typeLetter(t, m, 't')
+	m = typeLetter(t, m, 'h')
+	if text := m.wordSuggestionText(); !strings.HasPrefix(text, "Words that fit RTY: THE") {
+		t.Errorf("wordSuggestionText() = %q, want THE for TH_", text)
+	}
+	if !m.game.assists.SuggestionsUsed {
+		t.Error("typing with words suggested should count as an assist")
+	}
+}
+
+func TestWordSuggestions_OptIn(t *testing.T) {
+	m := revealModel(nil, 0)
+	m.game.cells = puzzle.BuildCells("QWEQ RTY", nil)
+	if text := m.wordSuggestionText(); text != "" {
+		t.Errorf("wordSuggestionText() = %q, want nothing without Config.WordSuggestions", text)
+	}
+	if m = typeLetter(t, m, 't'); m.game.assists.SuggestionsUsed {
+		t.Error("typing without suggestions shown should not count as an assist")
+	}
+}
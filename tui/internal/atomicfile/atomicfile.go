@@ -0,0 +1,162 @@
+// Package atomicfile writes files so that a crash or power loss leaves either
+// the old contents or the new ones, never a mix.
+package atomicfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// File is an open file being written.
+type File interface {
+	io.Writer
+	Sync() error
+	Close() error
+}
+
+// Dir is a directory files are written in: the parts of *os.Root the writes
+// need, plus a sync of the directory itself so renames survive power loss.
+// Tests substitute one that fails on purpose.
+type Dir interface {
+	OpenFile(name string, flag int, perm os.FileMode) (File, error)
+	Rename(oldname, newname string) error
+	Remove(name string) error
+	Sync() error
+}
+
+// rootDir is a Dir backed by an *os.Root.
+type rootDir struct {
+	root *os.Root
+}
+
+// Root returns a Dir for writes confined to root.
+func Root(root *os.Root) Dir {
+	return rootDir{root: root}
+}
+
+func (d rootDir) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	return d.root.OpenFile(name, flag, perm)
+}
+
+func (d rootDir) Rename(oldname, newname string) error {
+	return d.root.Rename(oldname, newname)
+}
+
+func (d rootDir) Remove(name string) error {
+	return d.root.Remove(name)
+}
+
+// Sync flushes the directory's entries to disk. Windows can't open a
+// directory for syncing and makes renames durable on its own.
+func (d rootDir) Sync() error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	f, err := d.root.Open(".")
+	if err != nil {
+		return err
+	}
+	return errors.Join(f.Sync(), f.Close())
+}
+
+// tempSuffix ends the name of every temp file WriteFile writes.
+const tempSuffix = ".tmp"
+
+// TempOf returns the file a temp file left by WriteFile was written for:
+// "state.json" for "state.json.123456.tmp". ok is false for any other name.
+// An intact temp file left behind means a write was interrupted after the
+// data was on disk but before the rename.
+func TempOf(tmpName string) (name string, ok bool) {
+	base, ok := strings.CutSuffix(tmpName, tempSuffix)
+	if !ok {
+		return "", false
+	}
+	i := strings.LastIndexByte(base, '.')
+	if i <= 0 || i == len(base)-1 || strings.Trim(base[i+1:], "0123456789") != "" {
+		return "", false
+	}
+	return base[:i], true
+}
+
+// WriteFile replaces name in dir with data: it writes and syncs a temp file,
+// renames it over name, then syncs the directory. Each write gets a temp file
+// of its own, so concurrent writes of name can't write into each other's.
+// The temp file is removed when any step before the rename fails, leaving
+// name as it was.
+func WriteFile(dir Dir, name string, data []byte, perm os.FileMode) error {
+	f, tmpName, err := createTemp(dir, name, perm)
+	if err != nil {
+		return err
+	}
+	if err := writeSynced(f, tmpName, data); err != nil {
+		_ = dir.Remove(tmpName)
+		return err
+	}
+	if err := dir.Rename(tmpName, name); err != nil {
+		_ = dir.Remove(tmpName)
+		return fmt.Errorf("renaming %s: %w", tmpName, err)
+	}
+	if err := dir.Sync(); err != nil {
+		return fmt.Errorf("syncing directory: %w", err)
+	}
+	return nil
+}
+
+// createTemp creates a new temp file in dir for a write of name, the way
+// os.CreateTemp does with the pattern name+".*.tmp", and returns it open
+// with its name.
+func createTemp(dir Dir, name string, perm os.FileMode) (File, string, error) {
+	for range 10000 {
+		tmpName := name + "." + strconv.FormatUint(uint64(rand.Uint32()), 10) + tempSuffix //nolint:gosec // temp names only need to differ, not be unguessable
+		f, err := dir.OpenFile(tmpName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("opening %s: %w", tmpName, err)
+		}
+		return f, tmpName, nil
+	}
+	return nil, "", fmt.Errorf("creating a temp file for %s: %w", name, fs.ErrExist)
+}
+
+// Append adds data to the end of name in dir, creating it if needed, and
+// syncs the file and the directory before returning. A crash part way
+// through can leave a partial last write, which readers must tolerate.
+func Append(dir Dir, name string, data []byte, perm os.FileMode) error {
+	f, err := dir.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_APPEND, perm)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", name, err)
+	}
+	if err := writeSynced(f, name, data); err != nil {
+		return err
+	}
+	if err := dir.Sync(); err != nil {
+		return fmt.Errorf("syncing directory: %w", err)
+	}
+	return nil
+}
+
+// writeSynced writes data to f, the open file name, syncs it to disk and
+// closes it.
+func writeSynced(f File, name string, data []byte) error {
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("syncing %s: %w", name, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package atomicfile
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var errInjected = errors.New("injected failure")
+
+// failingDir wraps a Dir and fails the named step: "open", "write", "sync",
+// "close", "rename" or "syncdir".
+type failingDir struct {
+	Dir
+	step string
+}
+
+func (d failingDir) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	if d.step == "open" {
+		return nil, errInjected
+	}
+	f, err := d.Dir.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return failingFile{File: f, step: d.step}, nil
+}
+
+func (d failingDir) Rename(oldname, newname string) error {
+	if d.step == "rename" {
+		return errInjected
+	}
+	return d.Dir.Rename(oldname, newname)
+}
+
+func (d failingDir) Sync() error {
+	if d.step == "syncdir" {
+		return errInjected
+	}
+	return d.Dir.Sync()
+}
+
+type failingFile struct {
+	File
+	step string
+}
+
+func (f failingFile) Write(p []byte) (int, error) {
+	if f.step == "write" {
+		// A torn write: half the data lands before the failure
+		n, _ := f.File.Write(p[:len(p)/2])
+		return n, errInjected
+	}
+	return f.File.Write(p)
+}
+
+func (f failingFile) Sync() error {
+	if f.step == "sync" {
+		return errInjected
+	}
+	return f.File.Sync()
+}
+
+func (f failingFile) Close() error {
+	if f.step == "close" {
+		_ = f.File.Close()
+		return errInjected
+	}
+	return f.File.Close()
+}
+
+// openDir returns a Dir on a temp directory and the directory's path.
+func openDir(t *testing.T) (Dir, string) {
+	t.Helper()
+	path := t.TempDir()
+	root, err := os.OpenRoot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = root.Close() })
+	return Root(root), path
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// tempFiles returns the names of the temp files WriteFile left in path.
+func tempFiles(t *testing.T, path string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var temps []string
+	for _, entry := range entries {
+		if _, ok := TempOf(entry.Name()); ok {
+			temps = append(temps, entry.Name())
+		}
+	}
+	return temps
+}
+
+func TestWriteFile(t *testing.T) {
+	dir, path := openDir(t)
+
+	if err := WriteFile(dir, "state.json", []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(dir, "state.json", []byte("new"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readFile(t, filepath.Join(path, "state.json")); got != "new" {
+		t.Errorf("contents = %q, want %q", got, "new")
+	}
+	if temps := tempFiles(t, path); len(temps) != 0 {
+		t.Errorf("temp files left behind: %v", temps)
+	}
+}
+
+func TestWriteFile_FailureKeepsOldContents(t *testing.T) {
+	for _, step := range []string{"open", "write", "sync", "close", "rename"} {
+		t.Run(step, func(t *testing.T) {
+			dir, path := openDir(t)
+			if err := WriteFile(dir, "state.json", []byte("old"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			err := WriteFile(failingDir{Dir: dir, step: step}, "state.json", []byte("new contents"), 0o600)
+			if !errors.Is(err, errInjected) {
+				t.Fatalf("err = %v, want the injected failure", err)
+			}
+
+			if got := readFile(t, filepath.Join(path, "state.json")); got != "old" {
+				t.Errorf("contents = %q, want the old contents kept", got)
+			}
+			if temps := tempFiles(t, path); len(temps) != 0 {
+				t.Errorf("temp files left behind: %v", temps)
+			}
+		})
+	}
+}
+
+// Concurrent writes of one file each use their own temp file, so every one
+// succeeds and the file ends up holding one write whole.
+func TestWriteFile_Concurrent(t *testing.T) {
+	dir, path := openDir(t)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Go(func() {
+			errs[i] = WriteFile(dir, "state.json", bytes.Repeat([]byte{byte('a' + i)}, 4096), 0o600)
+		})
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("write %d: %v", i, err)
+		}
+	}
+	got := readFile(t, filepath.Join(path, "state.json"))
+	if len(got) != 4096 || strings.Count(got, got[:1]) != len(got) {
+		t.Errorf("contents mix writes: %q...", got[:min(len(got), 16)])
+	}
+	if temps := tempFiles(t, path); len(temps) != 0 {
+		t.Errorf("temp files left behind: %v", temps)
+	}
+}
+
+func TestTempOf(t *testing.T) {
+	tests := []struct {
+		tmpName string
+		want    string
+		wantOK  bool
+	}{
+		{tmpName: "state.json.123456.tmp", want: "state.json", wantOK: true},
+		{tmpName: "game-001.json.7.tmp", want: "game-001.json", wantOK: true},
+		{tmpName: "state.json.tmp", wantOK: false},
+		{tmpName: "state.json.abc.tmp", wantOK: false},
+		{tmpName: "state.json..tmp", wantOK: false},
+		{tmpName: ".123.tmp", wantOK: false},
+		{tmpName: "state.json", wantOK: false},
+	}
+	for _, tt := range tests {
+		got, ok := TempOf(tt.tmpName)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("TempOf(%q) = %q, %v; want %q, %v", tt.tmpName, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestWriteFile_DirectorySyncFailure(t *testing.T) {
+	dir, path := openDir(t)
+
+	err := WriteFile(failingDir{Dir: dir, step: "syncdir"}, "state.json", []byte("new"), 0o600)
+	if !errors.Is(err, errInjected) {
+		t.Fatalf("err = %v, want the injected failure", err)
+	}
+	// The rename happened; only its durability is in doubt
+	if got := readFile(t, filepath.Join(path, "state.json")); got != "new" {
+		t.Errorf("contents = %q, want %q", got, "new")
+	}
+}
+
+func TestAppend(t *testing.T) {
+	dir, path := openDir(t)
+
+	for _, line := range []string{"one\n", "two\n"} {
+		if err := Append(dir, "log", []byte(line), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := readFile(t, filepath.Join(path, "log")); got != "one\ntwo\n" {
+		t.Errorf("contents = %q, want both lines", got)
+	}
+}
+
+func TestAppend_Failure(t *testing.T) {
+	for _, step := range []string{"open", "write", "sync", "close", "syncdir"} {
+		t.Run(step, func(t *testing.T) {
+			dir, _ := openDir(t)
+			err := Append(failingDir{Dir: dir, step: step}, "log", []byte("entry\n"), 0o600)
+			if !errors.Is(err, errInjected) {
+				t.Errorf("err = %v, want the injected failure", err)
+			}
+		})
+	}
+}
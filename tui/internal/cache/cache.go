@@ -0,0 +1,266 @@
+// Package cache keeps daily puzzles and their answers on disk so they can be
+// played and checked without a network connection.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/atomicfile"
+)
+
+// appName is the subdirectory name within the XDG cache directory
+const appName = "unquote"
+
+// dateLayout is the API's puzzle date format, also used for cache file names
+const dateLayout = "2006-01-02"
+
+// Retention is how long a puzzle stays cached after its date has passed.
+const Retention = 7 * 24 * time.Hour
+
+// disabled turns the cache off for runs that must write nothing to disk.
+var disabled atomic.Bool
+
+// Disable turns the cache off for an ephemeral run: Load finds nothing, and
+// Save, Prune and Prefetch neither touch the cache directory nor create it.
+// It returns a function that turns the cache back on.
+func Disable() (restore func()) {
+	disabled.Store(true)
+	return func() { disabled.Store(false) }
+}
+
+// Entry is a cached puzzle together with its answer.
+type Entry struct {
+	FetchedAt time.Time   `json:"fetched_at"`
+	Puzzle    *api.Puzzle `json:"puzzle"`
+	Solution  string      `json:"solution"`
+}
+
+// Today returns today's puzzle date: the calendar date of now in now's own
+// location. Callers pass the time in the player's zone (config.Location), so
+// the puzzle rolls over at their midnight rather than the server's.
+func Today(now time.Time) string {
+	return now.Format(dateLayout)
+}
+
+// Dates returns the puzzle dates for days consecutive days starting at from's
+// date in from's location.
+func Dates(from time.Time, days int) []string {
+	dates := make([]string, 0, max(days, 0))
+	for i := range days {
+		dates = append(dates, from.AddDate(0, 0, i).Format(dateLayout))
+	}
+	return dates
+}
+
+// Expired reports whether a puzzle dated date is past the retention window at now.
+// Unparseable dates are always expired.
+func Expired(date string, now time.Time) bool {
+	day, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return true
+	}
+	// A puzzle is playable through the end of its day
+	return now.Sub(day.Add(24*time.Hour)) > Retention
+}
+
+// cacheDir returns the absolute path to the puzzle cache directory (~/.cache/unquote/puzzles/).
+// It uses xdg.CacheFile to ensure the directory is created.
+func cacheDir() (string, error) {
+	// Create a probe file to ensure directory exists, then return the directory
+	path, err := xdg.CacheFile(filepath.Join(appName, "puzzles", ".keep"))
+	if err != nil {
+		return "", fmt.Errorf("creating cache directory: %w", err)
+	}
+	return filepath.Dir(path), nil
+}
+
+// cacheRoot opens an os.Root handle on the puzzle cache directory.
+// The caller must defer root.Close().
+func cacheRoot() (*os.Root, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("getting cache directory: %w", err)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("opening root: %w", err)
+	}
+	return root, nil
+}
+
+// validDate rejects anything that isn't a YYYY-MM-DD date before it is used
+// as a file name.
+func validDate(date string) error {
+	if _, err := time.Parse(dateLayout, date); err != nil {
+		return fmt.Errorf("invalid puzzle date %q", date)
+	}
+	return nil
+}
+
+// Save writes an entry to the cache under its puzzle's date, atomically via
+// atomicfile.
+func Save(entry *Entry) error {
+	if disabled.Load() {
+		return nil
+	}
+	if entry.Puzzle == nil {
+		return errors.New("entry has no puzzle")
+	}
+	if err := validDate(entry.Puzzle.Date); err != nil {
+		return err
+	}
+
+	root, err := cacheRoot()
+	if err != nil {
+		return fmt.Errorf("opening cache root: %w", err)
+	}
+	defer root.Close()
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling cache entry: %w", err)
+	}
+
+	if err := atomicfile.WriteFile(atomicfile.Root(root), entry.Puzzle.Date+".json", data, 0o600); err != nil {
+		return fmt.Errorf("writing cache file: %w", err)
+	}
+	return nil
+}
+
+// Load returns the cached puzzle for date.
+// Returns nil, nil if nothing is cached for that date or the entry has expired.
+func Load(date string, now time.Time) (*Entry, error) {
+	if err := validDate(date); err != nil {
+		return nil, err
+	}
+	if Expired(date, now) || disabled.Load() {
+		return nil, nil
+	}
+
+	root, err := cacheRoot()
+	if err != nil {
+		return nil, fmt.Errorf("opening cache root: %w", err)
+	}
+	defer root.Close()
+
+	data, err := root.ReadFile(date + ".json")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // not cached
+		}
+		return nil, fmt.Errorf("reading cache file: %w", err)
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("unmarshaling cache entry: %w", err)
+	}
+	if entry.Puzzle == nil || entry.Solution == "" {
+		return nil, nil // incomplete entries can't be played offline
+	}
+
+	return &entry, nil
+}
+
+// Prune deletes entries past the retention window (or not named for a date)
+// and returns how many were removed.
+// os.Root does not expose ReadDir; use os.Open for enumeration, os.OpenRoot for confined removal.
+func Prune(now time.Time) (int, error) {
+	if disabled.Load() {
+		return 0, nil
+	}
+	dir, err := cacheDir()
+	if err != nil {
+		return 0, fmt.Errorf("getting cache directory: %w", err)
+	}
+
+	f, err := os.Open(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("opening cache directory: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := f.ReadDir(-1)
+	if err != nil {
+		return 0, fmt.Errorf("reading cache directory: %w", err)
+	}
+
+	root, err := cacheRoot()
+	if err != nil {
+		return 0, fmt.Errorf("opening cache root: %w", err)
+	}
+	defer root.Close()
+
+	removed := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		// Skip non-JSON files and the .keep probe file
+		if entry.IsDir() || name == ".keep" || filepath.Ext(name) != ".json" {
+			continue
+		}
+
+		if Expired(strings.TrimSuffix(name, ".json"), now) {
+			if err := root.Remove(name); err != nil {
+				return removed, fmt.Errorf("removing cache file %q: %w", name, err)
+			}
+			removed++
+		}
+	}
+
+	return removed, nil
+}
+
+// Prefetch downloads the puzzles and answers for days consecutive days
+// starting today, skipping dates already cached, and returns how many were
+// added. Dates that fail to download are skipped and reported in the error;
+// everything that did arrive is still cached.
+func Prefetch(client *api.Client, now time.Time, days int) (int, error) {
+	if disabled.Load() {
+		return 0, nil
+	}
+	var missing []string
+	for _, date := range Dates(now, days) {
+		if entry, err := Load(date, now); err == nil && entry != nil {
+			continue
+		}
+		missing = append(missing, date)
+	}
+	if len(missing) == 0 {
+		return 0, nil
+	}
+
+	puzzles, fetchErr := client.FetchPuzzlesByDate(missing)
+	errs := []error{fetchErr}
+
+	added := 0
+	for _, puzzle := range puzzles {
+		if puzzle == nil {
+			continue
+		}
+		solution, err := client.FetchSolution(puzzle.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", puzzle.Date, err))
+			continue
+		}
+		if err := Save(&Entry{Puzzle: puzzle, Solution: solution.Solution, FetchedAt: now}); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", puzzle.Date, err))
+			continue
+		}
+		added++
+	}
+
+	return added, errors.Join(errs...)
+}
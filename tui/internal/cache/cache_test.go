@@ -0,0 +1,234 @@
+package cache
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+)
+
+// setCacheHome sets XDG_CACHE_HOME to a temp dir and reloads xdg paths.
+func setCacheHome(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+	return dir
+}
+
+// noon returns midday UTC on the given date.
+func noon(date string) time.Time {
+	day, _ := time.Parse(dateLayout, date)
+	return day.Add(12 * time.Hour)
+}
+
+func TestDates(t *testing.T) {
+	got := Dates(noon("2026-02-27"), 3)
+	if want := []string{"2026-02-27", "2026-02-28", "2026-03-01"}; !slices.Equal(got, want) {
+		t.Errorf("Dates() = %v, want %v", got, want)
+	}
+	if got := Dates(noon("2026-02-27"), 0); len(got) != 0 {
+		t.Errorf("Dates(0) = %v, want none", got)
+	}
+}
+
+func TestToday_UsesLocation(t *testing.T) {
+	// 23:30 on Jan 14 in New York is already Jan 15 in UTC
+	ny := time.FixedZone("EST", -5*60*60)
+	now := time.Date(2026, 1, 14, 23, 30, 0, 0, ny)
+	if got := Today(now); got != "2026-01-14" {
+		t.Errorf("Today() = %q, want the New York date", got)
+	}
+	if got := Today(now.UTC()); got != "2026-01-15" {
+		t.Errorf("Today() in UTC = %q, want the UTC date", got)
+	}
+	if got := Dates(now, 2); !slices.Equal(got, []string{"2026-01-14", "2026-01-15"}) {
+		t.Errorf("Dates() = %v, want to start on the New York date", got)
+	}
+}
+
+func TestExpired(t *testing.T) {
+	now := noon("2026-01-20")
+	tests := []struct {
+		date string
+		want bool
+	}{
+		{"2026-01-27", false}, // upcoming
+		{"2026-01-20", false}, // today
+		{"2026-01-13", false}, // ended 6.5 days ago
+		{"2026-01-12", true},  // ended 7.5 days ago
+		{"not-a-date", true},
+	}
+
+	for _, tt := range tests {
+		if got := Expired(tt.date, now); got != tt.want {
+			t.Errorf("Expired(%q) = %v, want %v", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	setCacheHome(t)
+
+	entry := &Entry{Puzzle: &api.Puzzle{ID: "g1", Date: "2026-01-20", EncryptedText: "XY"}, Solution: "HI"}
+	if err := Save(entry); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := Load("2026-01-20", noon("2026-01-20"))
+	if err != nil || loaded == nil {
+		t.Fatalf("Load() = %v, %v; want the saved entry", loaded, err)
+	}
+	if loaded.Puzzle.ID != "g1" || loaded.Solution != "HI" {
+		t.Errorf("loaded entry = %+v", loaded)
+	}
+
+	missing, err := Load("2026-01-21", noon("2026-01-20"))
+	if err != nil || missing != nil {
+		t.Errorf("Load(uncached) = %v, %v; want nil, nil", missing, err)
+	}
+
+	expired, err := Load("2026-01-20", noon("2026-02-20"))
+	if err != nil || expired != nil {
+		t.Errorf("Load(expired) = %v, %v; want nil, nil", expired, err)
+	}
+}
+
+func TestSave_RejectsBadDates(t *testing.T) {
+	setCacheHome(t)
+
+	for _, date := range []string{"", "../escape", "2026-13-01"} {
+		if err := Save(&Entry{Puzzle: &api.Puzzle{Date: date}, Solution: "HI"}); err == nil {
+			t.Errorf("Save() with date %q should fail", date)
+		}
+	}
+	if _, err := Load("../escape", time.Now()); err == nil {
+		t.Error("Load() with a path should fail")
+	}
+}
+
+func TestPrune(t *testing.T) {
+	dir := setCacheHome(t)
+
+	for _, date := range []string{"2026-01-01", "2026-01-19", "2026-01-25"} {
+		if err := Save(&Entry{Puzzle: &api.Puzzle{Date: date}, Solution: "HI"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed, err := Prune(noon("2026-01-20"))
+	if err != nil {
+		t.Fatalf("Prune() error: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Prune() removed %d entries, want 1", removed)
+	}
+	if _, err := os.Stat(filepath.Join(dir, appName, "puzzles", "2026-01-01.json")); !os.IsNotExist(err) {
+		t.Error("the expired entry should be deleted")
+	}
+	if _, err := os.Stat(filepath.Join(dir, appName, "puzzles", "2026-01-25.json")); err != nil {
+		t.Error("upcoming entries should be kept")
+	}
+}
+
+func TestPrefetch(t *testing.T) {
+	setCacheHome(t)
+
+	var puzzleRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/solution"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/game/"), "/solution")
+			_ = json.NewEncoder(w).Encode(api.SolutionResponse{Solution: "ANSWER " + id})
+		case r.URL.Path == "/game/2026-01-22":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			puzzleRequests.Add(1)
+			date := strings.TrimPrefix(r.URL.Path, "/game/")
+			_ = json.NewEncoder(w).Encode(api.Puzzle{ID: "id-" + date, Date: date, EncryptedText: "XYZ"})
+		}
+	}))
+	defer server.Close()
+
+	client, err := api.NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Already cached: not fetched again
+	if err := Save(&Entry{Puzzle: &api.Puzzle{ID: "cached", Date: "2026-01-20"}, Solution: "OLD"}); err != nil {
+		t.Fatal(err)
+	}
+
+	now := noon("2026-01-20")
+	added, err := Prefetch(client, now, 3)
+	if err == nil || !strings.Contains(err.Error(), "2026-01-22") {
+		t.Errorf("Prefetch() error = %v, want the failed date reported", err)
+	}
+	if added != 1 || puzzleRequests.Load() != 1 {
+		t.Errorf("Prefetch() added %d after %d requests, want 1 and 1", added, puzzleRequests.Load())
+	}
+
+	entry, _ := Load("2026-01-21", now)
+	if entry == nil || entry.Solution != "ANSWER id-2026-01-21" || !entry.FetchedAt.Equal(now) {
+		t.Errorf("cached entry = %+v, want the puzzle with its answer", entry)
+	}
+	if entry, _ := Load("2026-01-20", now); entry == nil || entry.Solution != "OLD" {
+		t.Error("an already cached day should be left alone")
+	}
+}
+
+// A disabled cache, as in an ephemeral run, creates nothing under the
+// cache directory and asks the API for nothing.
+func TestDisable_WritesNothing(t *testing.T) {
+	dir := setCacheHome(t)
+	t.Cleanup(Disable())
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	client, err := api.NewClientWithURL(server.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := noon("2026-01-20")
+	if entry, err := Load("2026-01-20", now); entry != nil || err != nil {
+		t.Errorf("Load() = %v, %v; want nothing cached", entry, err)
+	}
+	if err := Save(&Entry{Puzzle: &api.Puzzle{ID: "game", Date: "2026-01-20"}, Solution: "ANSWER"}); err != nil {
+		t.Errorf("Save() = %v, want it skipped", err)
+	}
+	if _, err := Prune(now); err != nil {
+		t.Errorf("Prune() = %v", err)
+	}
+	if added, err := Prefetch(client, now, 3); added != 0 || err != nil {
+		t.Errorf("Prefetch() = %d, %v; want it skipped", added, err)
+	}
+
+	if requests.Load() != 0 {
+		t.Errorf("made %d API requests, want none", requests.Load())
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("XDG_CACHE_HOME holds %v, want nothing created", entries)
+	}
+}
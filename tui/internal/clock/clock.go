@@ -0,0 +1,95 @@
+// Package clock tells the time for the puzzle timer, so tests and replays
+// can drive it instead of the wall clock.
+package clock
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and waits for it to pass.
+type Clock interface {
+	Now() time.Time
+	// After sends the time on the returned channel once d has passed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// system is the Clock on the wall.
+type system struct{}
+
+func (system) Now() time.Time                         { return time.Now() }
+func (system) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// System is the real clock.
+var System Clock = system{}
+
+// Fake is a Clock that only moves when told to. Waits started with After
+// fire, in deadline order, as Advance or Set moves the time past them. It is
+// safe for concurrent use, since commands wait on it from their own goroutines.
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []waiter
+}
+
+// waiter is a pending After: its deadline and the channel to send it on.
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewFake returns a Fake clock reading now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// After returns a channel that receives the deadline once the clock reaches
+// it. A wait of zero or less fires straight away.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	at := f.now.Add(d)
+	if d <= 0 {
+		ch <- at
+		return ch
+	}
+	f.waiters = append(f.waiters, waiter{at: at, ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward by d.
+func (f *Fake) Advance(d time.Duration) {
+	f.Set(f.Now().Add(d))
+}
+
+// Set moves the clock to t and fires every wait due by then. Setting it
+// backwards fires nothing.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+
+	slices.SortStableFunc(f.waiters, func(a, b waiter) int { return a.at.Compare(b.at) })
+	due := 0
+	for due < len(f.waiters) && !f.waiters[due].at.After(t) {
+		f.waiters[due].ch <- f.waiters[due].at
+		due++
+	}
+	f.waiters = slices.Delete(f.waiters, 0, due)
+}
+
+// Waiting returns how many After waits haven't fired yet, so a test can tell
+// when a command has started waiting before moving the clock.
+func (f *Fake) Waiting() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
@@ -0,0 +1,71 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var start = time.Date(2026, 10, 15, 23, 59, 0, 0, time.UTC)
+
+// fired reports what ch has received, without blocking.
+func fired(ch <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-ch:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFake_AdvanceFiresDueWaits(t *testing.T) {
+	c := NewFake(start)
+	second := c.After(time.Second)
+	minute := c.After(time.Minute)
+
+	c.Advance(500 * time.Millisecond)
+	if _, ok := fired(second); ok {
+		t.Fatal("a one-second wait fired after half a second")
+	}
+	if c.Waiting() != 2 {
+		t.Errorf("Waiting() = %d, want 2", c.Waiting())
+	}
+
+	c.Advance(time.Second)
+	if at, ok := fired(second); !ok || !at.Equal(start.Add(time.Second)) {
+		t.Errorf("one-second wait = %v, %v; want its deadline", at, ok)
+	}
+	if _, ok := fired(minute); ok {
+		t.Error("a one-minute wait fired early")
+	}
+	if c.Waiting() != 1 {
+		t.Errorf("Waiting() = %d, want 1", c.Waiting())
+	}
+
+	c.Set(start.Add(time.Hour))
+	if _, ok := fired(minute); !ok {
+		t.Error("Set past the deadline should fire the wait")
+	}
+	if got := c.Now(); !got.Equal(start.Add(time.Hour)) {
+		t.Errorf("Now() = %v, want an hour on", got)
+	}
+}
+
+func TestFake_ZeroWaitFiresImmediately(t *testing.T) {
+	c := NewFake(start)
+	if at, ok := fired(c.After(0)); !ok || !at.Equal(start) {
+		t.Errorf("After(0) = %v, %v; want the current time at once", at, ok)
+	}
+	if c.Waiting() != 0 {
+		t.Errorf("Waiting() = %d, want 0", c.Waiting())
+	}
+}
+
+func TestFake_SetBackwardsFiresNothing(t *testing.T) {
+	c := NewFake(start)
+	wait := c.After(time.Second)
+
+	c.Set(start.Add(-time.Hour))
+	if _, ok := fired(wait); ok {
+		t.Error("moving the clock back should not fire a wait")
+	}
+}
@@ -5,14 +5,73 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"time"
 
 	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/atomicfile"
 )
 
 // Config holds persistent player preferences and identity.
 type Config struct {
-	ClaimCode    string `json:"claim_code"`
-	StatsEnabled bool   `json:"stats_enabled"`
+	ClaimCode       string   `json:"claim_code"`
+	Timezone        string   `json:"timezone,omitempty"`      // IANA zone the puzzle day follows; empty = system local time
+	Friends         []string `json:"friends,omitempty"`       // friends' claim codes, compared on the stats screen
+	HintsSeen       []string `json:"hints_seen,omitempty"`    // one-time play tips already shown, by ID
+	RevealAfter     int      `json:"reveal_after,omitempty"`  // failed submissions before offering a reveal; 0 = default, <0 = never
+	WeeklyGoal      int      `json:"weekly_goal,omitempty"`   // days a week the player aims to solve; 0 = no goal
+	ToastSeconds    int      `json:"toast_seconds,omitempty"` // how long notices stay in the status bar; 0 = each kind's default
+	CheckRetries    int      `json:"check_retries,omitempty"` // times a timed-out answer check is sent again; 0 = default, <0 = never
+	IdleSeconds     int      `json:"idle_seconds,omitempty"`  // seconds without input before the timer pauses; 0 = default, <0 = never
+	StatsEnabled    bool     `json:"stats_enabled"`
+	CompactGrid     bool     `json:"compact_grid,omitempty"`
+	Sound           bool     `json:"sound,omitempty"`
+	Accessible      bool     `json:"accessible,omitempty"`
+	ShapeCues       bool     `json:"shape_cues,omitempty"`
+	SkipAttempts    bool     `json:"skip_attempts,omitempty"`    // don't report unsolved puzzles to stats; only solves count
+	NoTips          bool     `json:"no_tips,omitempty"`          // never show play tips
+	AutoFill        bool     `json:"auto_fill,omitempty"`        // offer Ctrl+F to fill letters the board leaves only one choice for
+	WordSuggestions bool     `json:"word_suggestions,omitempty"` // list dictionary words that fit the word under the cursor
+	LowBandwidth    bool     `json:"low_bandwidth,omitempty"`    // redraw less for slow links: slower ticks, no cell tints, compact grid
+	AlphabetPanel   bool     `json:"alphabet_panel,omitempty"`   // show the cipher alphabet and what each letter stands for with the grid
+	Accents         string   `json:"accents,omitempty"`          // typed accented letters: "fold" (é → E), "keep", or empty for auto
+	Background      string   `json:"background,omitempty"`       // palette: "light", "dark", or empty to follow the terminal
+	Theme           string   `json:"theme,omitempty"`            // custom theme file in themes/, by name; empty = the built-in palette
+	Reminder        string   `json:"reminder,omitempty"`         // "HH:MM" in the player's zone from which 'remind --check' nags about an unsolved daily puzzle; empty = off
+}
+
+// Location returns the time zone that decides which day's puzzle is today:
+// Timezone when set, otherwise the system's local zone. A nil Config, or a
+// zone this system doesn't know, also uses local time.
+func (c *Config) Location() *time.Location {
+	if c == nil || c.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// AddFriend adds a friend's claim code, reporting false if it was already listed.
+func (c *Config) AddFriend(code string) bool {
+	if slices.Contains(c.Friends, code) {
+		return false
+	}
+	c.Friends = append(c.Friends, code)
+	return true
+}
+
+// RemoveFriend removes a friend's claim code, reporting false if it wasn't listed.
+func (c *Config) RemoveFriend(code string) bool {
+	i := slices.Index(c.Friends, code)
+	if i < 0 {
+		return false
+	}
+	c.Friends = slices.Delete(c.Friends, i, i+1)
+	return true
 }
 
 // configDir returns the absolute path to the config directory (~/.config/unquote/).
@@ -79,16 +138,12 @@ func Save(cfg *Config) error {
 		return fmt.Errorf("marshaling config: %w", err)
 	}
 
-	// Write to temp file then rename for atomicity
-	if err := root.WriteFile("config.json.tmp", data, 0o600); err != nil {
+	// Write to a synced temp file then rename, so power loss leaves the old
+	// config or the new one
+	if err := atomicfile.WriteFile(atomicfile.Root(root), "config.json", data, 0o600); err != nil {
 		return fmt.Errorf("writing config file: %w", err)
 	}
 
-	if err := root.Rename("config.json.tmp", "config.json"); err != nil {
-		_ = root.Remove("config.json.tmp") // cleanup on failure
-		return fmt.Errorf("renaming config file: %w", err)
-	}
-
 	return nil
 }
 
@@ -2,6 +2,8 @@ package config
 
 import (
 	"testing"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/adrg/xdg"
 )
@@ -121,3 +123,79 @@ func TestLoad_MissingFile(t *testing.T) {
 		t.Error("Load should return nil for missing file")
 	}
 }
+
+// TestLoadAndSave_CompactGrid verifies the compact grid preference round-trips
+// and defaults to false for configs written before it existed.
+func TestLoadAndSave_CompactGrid(t *testing.T) {
+	tmpDir := t.TempDir()
+	setConfigHome(t, tmpDir)
+
+	if err := Save(&Config{ClaimCode: "ABC-123", CompactGrid: true}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load()
+	if err != nil || loaded == nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !loaded.CompactGrid {
+		t.Error("CompactGrid: expected true after round-trip")
+	}
+
+	if err := Save(&Config{ClaimCode: "ABC-123"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err = Load()
+	if err != nil || loaded == nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.CompactGrid {
+		t.Error("CompactGrid: expected false by default")
+	}
+}
+
+func TestFriends_AddRemoveRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	setConfigHome(t, tmpDir)
+
+	cfg := &Config{ClaimCode: "ABC-123"}
+	if !cfg.AddFriend("FOX-RIVER-0001") || !cfg.AddFriend("OWL-LAKE-0002") {
+		t.Fatal("AddFriend should add new codes")
+	}
+	if cfg.AddFriend("FOX-RIVER-0001") {
+		t.Error("AddFriend should report a duplicate")
+	}
+	if err := Save(cfg); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil || loaded == nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.Friends) != 2 || loaded.Friends[1] != "OWL-LAKE-0002" {
+		t.Errorf("Friends = %v after round-trip", loaded.Friends)
+	}
+
+	if !loaded.RemoveFriend("FOX-RIVER-0001") || loaded.RemoveFriend("FOX-RIVER-0001") {
+		t.Error("RemoveFriend should remove a listed code exactly once")
+	}
+	if len(loaded.Friends) != 1 || loaded.Friends[0] != "OWL-LAKE-0002" {
+		t.Errorf("Friends = %v after removal", loaded.Friends)
+	}
+}
+
+func TestLocation(t *testing.T) {
+	var missing *Config
+	if got := missing.Location(); got != time.Local {
+		t.Errorf("nil config Location() = %v, want local time", got)
+	}
+	if got := (&Config{}).Location(); got != time.Local {
+		t.Errorf("unset Location() = %v, want local time", got)
+	}
+	if got := (&Config{Timezone: "Pacific/Auckland"}).Location(); got.String() != "Pacific/Auckland" {
+		t.Errorf("Location() = %v, want Pacific/Auckland", got)
+	}
+	if got := (&Config{Timezone: "Mars/Olympus"}).Location(); got != time.Local {
+		t.Errorf("unknown zone Location() = %v, want local time", got)
+	}
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/atomicfile"
+)
+
+// ThemesDir is the directory under the config directory holding custom
+// theme files, one <name>.json per theme.
+const ThemesDir = "themes"
+
+// themeNamePattern matches theme names: lower case letters, digits, dashes
+// and underscores, so a name is always a plain file name.
+var themeNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// ErrThemeNotFound is returned by LoadTheme when no file has the name.
+var ErrThemeNotFound = errors.New("theme not found")
+
+// ThemeFile is a custom theme as stored in the themes directory. Colors maps
+// color roles (see ui.Roles) to "#RRGGBB" or an ANSI number; ui.ParseCustomTheme
+// checks them.
+type ThemeFile struct {
+	Schema      string            `json:"$schema,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Background  string            `json:"background,omitempty"` // "light" or "dark" when made for one; empty = either
+	Colors      map[string]string `json:"colors"`
+}
+
+// ValidThemeName reports whether name can name a theme file.
+func ValidThemeName(name string) bool {
+	return themeNamePattern.MatchString(name)
+}
+
+// ThemePath returns where the theme file for name is, for showing the player.
+func ThemePath(name string) (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ThemesDir, name+".json"), nil
+}
+
+// LoadTheme reads the theme file for name. Fields it doesn't know are an
+// error, so a misspelled key isn't silently ignored.
+func LoadTheme(name string) (*ThemeFile, error) {
+	if !ValidThemeName(name) {
+		return nil, fmt.Errorf("invalid theme name %q: use lower case letters, digits, - and _", name)
+	}
+	root, err := configRoot()
+	if err != nil {
+		return nil, fmt.Errorf("opening config root: %w", err)
+	}
+	defer root.Close()
+
+	data, err := root.ReadFile(path.Join(ThemesDir, name+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrThemeNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading theme file: %w", err)
+	}
+
+	var theme ThemeFile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&theme); err != nil {
+		return nil, fmt.Errorf("theme %s: %w", name, err)
+	}
+	return &theme, nil
+}
+
+// ThemeNames lists the themes in the themes directory, sorted. Files whose
+// names aren't theme names, such as the schema, are left out.
+func ThemeNames() ([]string, error) {
+	root, err := configRoot()
+	if err != nil {
+		return nil, fmt.Errorf("opening config root: %w", err)
+	}
+	defer root.Close()
+
+	entries, err := fs.ReadDir(root.FS(), ThemesDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading themes directory: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if ok && !entry.IsDir() && ValidThemeName(name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// WriteThemesFile writes file to the themes directory unless it already
+// exists, reporting whether it wrote it, so edited themes are never
+// overwritten.
+func WriteThemesFile(file string, data []byte) (bool, error) {
+	root, err := configRoot()
+	if err != nil {
+		return false, fmt.Errorf("opening config root: %w", err)
+	}
+	defer root.Close()
+
+	if err := root.Mkdir(ThemesDir, 0o700); err != nil && !errors.Is(err, fs.ErrExist) {
+		return false, fmt.Errorf("creating themes directory: %w", err)
+	}
+	themes, err := root.OpenRoot(ThemesDir)
+	if err != nil {
+		return false, fmt.Errorf("opening themes directory: %w", err)
+	}
+	defer themes.Close()
+
+	if _, err := themes.Stat(file); err == nil {
+		return false, nil
+	}
+	if err := atomicfile.WriteFile(atomicfile.Root(themes), file, data, 0o600); err != nil {
+		return false, fmt.Errorf("writing %s: %w", file, err)
+	}
+	return true, nil
+}
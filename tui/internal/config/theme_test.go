@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestWriteThemesFile_LoadTheme(t *testing.T) {
+	setConfigHome(t, t.TempDir())
+
+	if names, err := ThemeNames(); err != nil || names != nil {
+		t.Errorf("ThemeNames() = %v, %v; want none before the directory exists", names, err)
+	}
+	if _, err := LoadTheme("paper"); !errors.Is(err, ErrThemeNotFound) {
+		t.Errorf("LoadTheme(paper) error = %v, want ErrThemeNotFound", err)
+	}
+
+	paper := []byte(`{"description": "Paper", "background": "light", "colors": {"primary": "#1F3A93"}}`)
+	if written, err := WriteThemesFile("paper.json", paper); !written || err != nil {
+		t.Fatalf("WriteThemesFile = %v, %v; want written", written, err)
+	}
+	if written, err := WriteThemesFile("paper.json", []byte(`{}`)); written || err != nil {
+		t.Errorf("WriteThemesFile again = %v, %v; want the existing file kept", written, err)
+	}
+	if _, err := WriteThemesFile("theme.schema.json", []byte(`{}`)); err != nil {
+		t.Fatalf("WriteThemesFile(schema): %v", err)
+	}
+
+	theme, err := LoadTheme("paper")
+	if err != nil {
+		t.Fatalf("LoadTheme(paper): %v", err)
+	}
+	if theme.Background != "light" || theme.Colors["primary"] != "#1F3A93" {
+		t.Errorf("LoadTheme(paper) = %+v, want the file first written", theme)
+	}
+	if names, err := ThemeNames(); err != nil || !slices.Equal(names, []string{"paper"}) {
+		t.Errorf("ThemeNames() = %v, %v; want [paper] without the schema", names, err)
+	}
+}
+
+func TestLoadTheme_Rejects(t *testing.T) {
+	setConfigHome(t, t.TempDir())
+
+	if _, err := WriteThemesFile("typo.json", []byte(`{"colours": {}}`)); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	if _, err := LoadTheme("typo"); err == nil {
+		t.Error("LoadTheme should reject unknown fields")
+	}
+	for _, name := range []string{"../config", "Paper", ""} {
+		if _, err := LoadTheme(name); err == nil || errors.Is(err, ErrThemeNotFound) {
+			t.Errorf("LoadTheme(%q) error = %v, want an invalid name", name, err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Package goal tracks progress toward the player's weekly solve goal, counted
+// on local calendar days.
+package goal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// DaysPerWeek is the largest goal a player can set.
+const DaysPerWeek = 7
+
+// Progress is the player's progress toward their goal in one week. Weeks run
+// Monday to Sunday in the location of the time they were computed for.
+type Progress struct {
+	WeekStart time.Time         // local midnight on the week's Monday
+	Today     int               // index of the current day in Solved, Monday first
+	Solved    [DaysPerWeek]bool // whether a puzzle was solved on each day, Monday first
+	Goal      int               // days to solve per week; 0 means no goal
+}
+
+// WeekStart returns local midnight on the Monday of t's week, in t's location.
+// It steps by calendar days, not 24-hour periods, so weeks containing a
+// daylight saving change still start at midnight.
+func WeekStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+	return time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location())
+}
+
+// Compute works out this week's progress from solve times. Each solve counts
+// for the local calendar day it happened on, in now's location; solves
+// outside the week are ignored.
+func Compute(goal int, solves []time.Time, now time.Time) Progress {
+	start := WeekStart(now)
+	p := Progress{WeekStart: start, Goal: goal, Today: dayIndex(start, now)}
+	for _, solvedAt := range solves {
+		if i := dayIndex(start, solvedAt.In(now.Location())); i >= 0 && i < DaysPerWeek {
+			p.Solved[i] = true
+		}
+	}
+	return p
+}
+
+// dayIndex returns how many calendar days t is after start, which must be a
+// local midnight in t's location. Comparing dates rather than dividing the
+// elapsed time keeps 23- and 25-hour days whole.
+func dayIndex(start, t time.Time) int {
+	y, m, d := t.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	sy, sm, sd := start.Date()
+	first := time.Date(sy, sm, sd, 0, 0, 0, 0, time.UTC)
+	return int(day.Sub(first).Hours() / 24)
+}
+
+// Load computes this week's progress from the daily sessions solved on this
+// device. Practice, custom and duel games don't count.
+func Load(goal int, now time.Time) (Progress, error) {
+	sessions, err := storage.Daily.ListSessions()
+	if err != nil {
+		return Progress{}, fmt.Errorf("listing sessions: %w", err)
+	}
+	solves := make([]time.Time, 0, len(sessions))
+	for _, s := range sessions {
+		if solvedAt, ok := s.SolveTime(); ok && s.Solved {
+			solves = append(solves, solvedAt)
+		}
+	}
+	return Compute(goal, solves, now), nil
+}
+
+// Count returns how many days this week have a solve.
+func (p Progress) Count() int {
+	n := 0
+	for _, solved := range p.Solved {
+		if solved {
+			n++
+		}
+	}
+	return n
+}
+
+// Met reports whether this week's goal has been reached.
+func (p Progress) Met() bool {
+	return p.Goal > 0 && p.Count() >= p.Goal
+}
+
+// DaysLeft returns how many days this week can still count: the rest of the
+// week, plus today when it has no solve yet.
+func (p Progress) DaysLeft() int {
+	left := DaysPerWeek - 1 - p.Today
+	if !p.Solved[p.Today] {
+		left++
+	}
+	return left
+}
+
+// Summary describes the week's progress in words, e.g.
+// "3 of 5 days this week · 2 more to go, 4 days left".
+func (p Progress) Summary() string {
+	count := p.Count()
+	switch {
+	case p.Met():
+		return fmt.Sprintf("Goal met: %d of %d days this week", count, p.Goal)
+	case p.Goal-count > p.DaysLeft():
+		return fmt.Sprintf("%d of %d days this week · out of reach, try again next week", count, p.Goal)
+	default:
+		return fmt.Sprintf("%d of %d days this week · %d more to go, %s left", count, p.Goal, p.Goal-count, plural(p.DaysLeft(), "day"))
+	}
+}
+
+// Calendar renders the week as one mark per day, Monday first: "●" for a
+// solve, "○" for a missed or open day up to today and "·" for days to come.
+func (p Progress) Calendar() string {
+	marks := make([]string, DaysPerWeek)
+	for i, solved := range p.Solved {
+		switch {
+		case solved:
+			marks[i] = "●"
+		case i <= p.Today:
+			marks[i] = "○"
+		default:
+			marks[i] = "·"
+		}
+	}
+	return "M T W T F S S\n" + strings.Join(marks, " ")
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -0,0 +1,144 @@
+package goal
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata" // DST tests need America/New_York wherever they run
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+	"github.com/bojanrajkovic/unquote/tui/internal/storage/storagetest"
+)
+
+func newYork(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("loading time zone: %v", err)
+	}
+	return loc
+}
+
+func TestWeekStart(t *testing.T) {
+	ny := newYork(t)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{name: "midweek", now: time.Date(2026, 1, 21, 15, 0, 0, 0, ny), want: time.Date(2026, 1, 19, 0, 0, 0, 0, ny)},
+		{name: "monday midnight", now: time.Date(2026, 1, 19, 0, 0, 0, 0, ny), want: time.Date(2026, 1, 19, 0, 0, 0, 0, ny)},
+		{name: "sunday night", now: time.Date(2026, 1, 25, 23, 59, 0, 0, ny), want: time.Date(2026, 1, 19, 0, 0, 0, 0, ny)},
+		// Clocks spring forward on Sunday 2026-03-08 and fall back on Sunday 2026-11-01
+		{name: "after spring forward", now: time.Date(2026, 3, 8, 12, 0, 0, 0, ny), want: time.Date(2026, 3, 2, 0, 0, 0, 0, ny)},
+		{name: "week after spring forward", now: time.Date(2026, 3, 9, 0, 30, 0, 0, ny), want: time.Date(2026, 3, 9, 0, 0, 0, 0, ny)},
+		{name: "after fall back", now: time.Date(2026, 11, 1, 23, 0, 0, 0, ny), want: time.Date(2026, 10, 26, 0, 0, 0, 0, ny)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WeekStart(tt.now)
+			if !got.Equal(tt.want) || got.Hour() != 0 {
+				t.Errorf("WeekStart(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompute_AcrossDST(t *testing.T) {
+	ny := newYork(t)
+
+	// The week of 2026-10-26 is 169 hours long: clocks fall back on Sunday
+	now := time.Date(2026, 11, 1, 22, 0, 0, 0, ny)
+	solves := []time.Time{
+		time.Date(2026, 10, 25, 23, 30, 0, 0, ny),     // Sunday of the previous week
+		time.Date(2026, 10, 26, 0, 10, 0, 0, ny),      // Monday, just after midnight
+		time.Date(2026, 10, 28, 3, 0, 0, 0, time.UTC), // Tuesday 23:00 in New York
+		time.Date(2026, 11, 1, 1, 30, 0, 0, ny),       // Sunday, in the repeated hour
+		time.Date(2026, 11, 1, 23, 30, 0, 0, ny),      // Sunday, after the change
+		time.Date(2026, 11, 2, 0, 5, 0, 0, ny),        // next week
+	}
+
+	p := Compute(4, solves, now)
+	want := [DaysPerWeek]bool{true, true, false, false, false, false, true}
+	if p.Solved != want {
+		t.Errorf("Solved = %v, want %v", p.Solved, want)
+	}
+	if p.Today != 6 || p.Count() != 3 || p.Met() {
+		t.Errorf("Today = %d, Count() = %d, Met() = %v", p.Today, p.Count(), p.Met())
+	}
+
+	// Spring forward: Sunday 2026-03-08 is only 23 hours long
+	now = time.Date(2026, 3, 8, 23, 59, 0, 0, ny)
+	p = Compute(1, []time.Time{time.Date(2026, 3, 8, 23, 0, 0, 0, ny)}, now)
+	if !p.Solved[6] || !p.Met() {
+		t.Errorf("a late solve on a 23-hour Sunday should count for Sunday, got %v", p.Solved)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	monday := time.Date(2026, 1, 19, 9, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return monday.AddDate(0, 0, n) }
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		want   string
+		solves []time.Time
+		goal   int
+	}{
+		{name: "on track", goal: 5, now: day(2), solves: []time.Time{day(0), day(1)}, want: "2 of 5 days this week · 3 more to go, 5 days left"},
+		{name: "met", goal: 2, now: day(3), solves: []time.Time{day(0), day(2)}, want: "Goal met: 2 of 2 days this week"},
+		{name: "out of reach", goal: 7, now: day(4), solves: []time.Time{day(4)}, want: "1 of 7 days this week · out of reach, try again next week"},
+		{name: "last day", goal: 3, now: day(6), solves: []time.Time{day(0), day(3)}, want: "2 of 3 days this week · 1 more to go, 1 day left"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compute(tt.goal, tt.solves, tt.now).Summary(); got != tt.want {
+				t.Errorf("Summary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	wednesday := time.Date(2026, 1, 21, 9, 0, 0, 0, time.UTC)
+	p := Compute(3, []time.Time{wednesday.AddDate(0, 0, -2)}, wednesday)
+
+	lines := strings.Split(p.Calendar(), "\n")
+	if len(lines) != 2 || lines[1] != "● ○ ○ · · · ·" {
+		t.Errorf("Calendar() = %q", p.Calendar())
+	}
+}
+
+func TestLoad_CountsDailySolvesOnly(t *testing.T) {
+	storagetest.UseMemory(t)
+
+	now := time.Date(2026, 1, 21, 9, 0, 0, 0, time.UTC)
+	monday := now.AddDate(0, 0, -2)
+	tuesday := now.AddDate(0, 0, -1)
+
+	sessions := []struct {
+		session *storage.GameSession
+		ns      storage.Namespace
+	}{
+		{ns: storage.Daily, session: &storage.GameSession{GameID: "daily-1", Solved: true, SolvedAt: &monday}},
+		{ns: storage.Daily, session: &storage.GameSession{GameID: "daily-2", Solved: true, Uploaded: true, SolvedAt: &tuesday}},
+		{ns: storage.Daily, session: &storage.GameSession{GameID: "daily-3", SolvedAt: &now}},
+		{ns: storage.Practice, session: &storage.GameSession{GameID: "practice-1", Solved: true, SolvedAt: &now}},
+	}
+	for _, s := range sessions {
+		if err := s.ns.SaveSession(s.session); err != nil {
+			t.Fatalf("setup: %v", err)
+		}
+	}
+
+	p, err := Load(3, now)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := [DaysPerWeek]bool{true, true, false, false, false, false, false}
+	if p.Solved != want {
+		t.Errorf("Solved = %v, want Monday and Tuesday only", p.Solved)
+	}
+}
@@ -0,0 +1,46 @@
+package goal
+
+import (
+	"fmt"
+	"time"
+)
+
+// Reminder is the time of day, in the player's zone, from which an unsolved
+// daily puzzle is worth a reminder.
+type Reminder struct {
+	Hour   int
+	Minute int
+}
+
+// ParseReminder parses a 24-hour "HH:MM" time of day, like "19:30" or "7:05".
+func ParseReminder(s string) (Reminder, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return Reminder{}, fmt.Errorf("reminder time %q: expected HH:MM, like 19:30", s)
+	}
+	return Reminder{Hour: t.Hour(), Minute: t.Minute()}, nil
+}
+
+// String formats the reminder as "HH:MM".
+func (r Reminder) String() string {
+	return fmt.Sprintf("%02d:%02d", r.Hour, r.Minute)
+}
+
+// On returns when the reminder goes off on t's calendar day, in t's
+// location. A time a daylight saving change skips goes off as long after
+// the gap as it would have been into it, never before.
+func (r Reminder) On(t time.Time) time.Time {
+	y, m, d := t.Date()
+	at := time.Date(y, m, d, r.Hour, r.Minute, 0, 0, t.Location())
+	if at.Hour() != r.Hour {
+		// time.Date resolves a skipped time with the offset from before the
+		// change, landing before the gap
+		at = at.Add(time.Hour)
+	}
+	return at
+}
+
+// Due reports whether the reminder has gone off by now on now's calendar day.
+func (r Reminder) Due(now time.Time) bool {
+	return !now.Before(r.On(now))
+}
@@ -0,0 +1,68 @@
+package goal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseReminder(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Reminder
+		wantErr bool
+	}{
+		{in: "19:30", want: Reminder{Hour: 19, Minute: 30}},
+		{in: "07:05", want: Reminder{Hour: 7, Minute: 5}},
+		{in: "7:05", want: Reminder{Hour: 7, Minute: 5}},
+		{in: "00:00", want: Reminder{}},
+		{in: "7pm", wantErr: true},
+		{in: "24:00", wantErr: true},
+		{in: "19:60", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseReminder(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseReminder(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseReminder(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+			if round, _ := ParseReminder(got.String()); !tt.wantErr && round != got {
+				t.Errorf("String() = %q doesn't parse back to %v", got.String(), got)
+			}
+		})
+	}
+}
+
+func TestReminder_Due(t *testing.T) {
+	ny := newYork(t)
+	evening := Reminder{Hour: 19, Minute: 30}
+	early := Reminder{Hour: 2, Minute: 30}
+
+	tests := []struct {
+		name     string
+		reminder Reminder
+		now      time.Time
+		want     bool
+	}{
+		{name: "before", reminder: evening, now: time.Date(2026, 1, 21, 19, 29, 0, 0, ny), want: false},
+		{name: "on the minute", reminder: evening, now: time.Date(2026, 1, 21, 19, 30, 0, 0, ny), want: true},
+		{name: "late that night", reminder: evening, now: time.Date(2026, 1, 21, 23, 59, 0, 0, ny), want: true},
+		{name: "next morning", reminder: evening, now: time.Date(2026, 1, 22, 8, 0, 0, 0, ny), want: false},
+		// Clocks spring forward from 02:00 to 03:00 on 2026-03-08: 02:30 never
+		// happens, so the reminder goes off just after the gap
+		{name: "skipped by spring forward", reminder: early, now: time.Date(2026, 3, 8, 3, 0, 0, 0, ny), want: false},
+		{name: "after spring forward gap", reminder: early, now: time.Date(2026, 3, 8, 3, 30, 0, 0, ny), want: true},
+		// Clocks fall back from 02:00 to 01:00 on 2026-11-01; the evening is still 19:30 local
+		{name: "fall back evening", reminder: evening, now: time.Date(2026, 11, 1, 19, 30, 0, 0, ny), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.reminder.Due(tt.now); got != tt.want {
+				t.Errorf("Due(%v) = %v, want %v (goes off %v)", tt.now, got, tt.want, tt.reminder.On(tt.now))
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package mockapi
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
+)
+
+// quote is one fixture the mock server builds puzzles from.
+type quote struct {
+	text     string
+	author   string
+	category string
+}
+
+// quotes are public-domain lines, a few per category, so filtering random
+// puzzles and searching by author have something to find.
+var quotes = []quote{
+	{"The only thing we have to fear is fear itself.", "Franklin D. Roosevelt", "Politics"},
+	{"Ask not what your country can do for you; ask what you can do for your country.", "John F. Kennedy", "Politics"},
+	{"Government of the people, by the people, for the people, shall not perish from the earth.", "Abraham Lincoln", "Politics"},
+	{"A house divided against itself cannot stand.", "Abraham Lincoln", "Politics"},
+	{"To be, or not to be, that is the question.", "William Shakespeare", "Literature"},
+	{"All the world's a stage, and all the men and women merely players.", "William Shakespeare", "Literature"},
+	{"It was the best of times, it was the worst of times.", "Charles Dickens", "Literature"},
+	{"Call me Ishmael.", "Herman Melville", "Literature"},
+	{"Not all those who wander are lost.", "J. R. R. Tolkien", "Literature"},
+	{"I think, therefore I am.", "Rene Descartes", "Philosophy"},
+	{"The unexamined life is not worth living.", "Socrates", "Philosophy"},
+	{"He who has a why to live can bear almost any how.", "Friedrich Nietzsche", "Philosophy"},
+	{"Happiness depends upon ourselves.", "Aristotle", "Philosophy"},
+	{"Imagination is more important than knowledge.", "Albert Einstein", "Science"},
+	{"Nothing in life is to be feared, it is only to be understood.", "Marie Curie", "Science"},
+	{"If I have seen further it is by standing on the shoulders of giants.", "Isaac Newton", "Science"},
+	{"Eureka!", "Archimedes", "Science"},
+	{"Well done is better than well said.", "Benjamin Franklin", "Wisdom"},
+	{"An investment in knowledge pays the best interest.", "Benjamin Franklin", "Wisdom"},
+	{"The journey of a thousand miles begins with one step.", "Lao Tzu", "Wisdom"},
+	{"Whatever you are, be a good one.", "Abraham Lincoln", "Wisdom"},
+	{"Life is what happens when you are busy making other plans.", "Allen Saunders", "Humor"},
+	{"I can resist everything except temptation.", "Oscar Wilde", "Humor"},
+	{"Be yourself; everyone else is already taken.", "Oscar Wilde", "Humor"},
+	{"The reports of my death are greatly exaggerated.", "Mark Twain", "Humor"},
+	{"Golf is a good walk spoiled.", "Mark Twain", "Sports"},
+	{"Float like a butterfly, sting like a bee.", "Muhammad Ali", "Sports"},
+	{"It ain't over till it's over.", "Yogi Berra", "Sports"},
+}
+
+// sources are where some of the quotes come from. The rest have no context,
+// so the TUI falls back to Wikipedia for them as it would against an API
+// without any.
+var sources = map[string]api.QuoteContext{
+	"To be, or not to be, that is the question.": {
+		Source: "Hamlet", Year: 1603, Description: "English playwright and poet",
+		Bio: "William Shakespeare was an English playwright, poet and actor, widely regarded as the greatest writer in the English language.",
+	},
+	"It was the best of times, it was the worst of times.": {
+		Source: "A Tale of Two Cities", Year: 1859, Description: "English novelist",
+		Bio: "Charles Dickens was an English novelist and social critic who created some of the best-known fictional characters of the Victorian era.",
+	},
+	"Call me Ishmael.": {
+		Source: "Moby-Dick", Year: 1851, Description: "American novelist",
+		Bio: "Herman Melville was an American novelist, short story writer and poet of the American Renaissance period.",
+	},
+	"The reports of my death are greatly exaggerated.": {
+		Source: "New York Journal", Year: 1897, Description: "American writer and humorist",
+		Bio: "Samuel Langhorne Clemens, known by the pen name Mark Twain, was an American writer, humorist and lecturer.",
+	},
+	"Government of the people, by the people, for the people, shall not perish from the earth.": {
+		Source: "Gettysburg Address", Year: 1863, Description: "16th president of the United States",
+		Bio: "Abraham Lincoln was the 16th president of the United States, serving from 1861 until his assassination in 1865.",
+	},
+}
+
+// gameIDPrefix marks the mock server's game IDs, which are the date they
+// belong to, like the real API's.
+const gameIDPrefix = "mock-"
+
+// gameID returns the ID of the puzzle for date.
+func gameID(date string) string {
+	return gameIDPrefix + date
+}
+
+// gameDate returns the date a game ID belongs to.
+func gameDate(id string) (string, bool) {
+	date, ok := strings.CutPrefix(id, gameIDPrefix)
+	if !ok {
+		return "", false
+	}
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return "", false
+	}
+	return date, true
+}
+
+// fixture is a puzzle as served, with the answer the server checks against.
+type fixture struct {
+	context  *api.QuoteContext // nil when the quote has no known source
+	puzzle   api.Puzzle
+	solution string
+}
+
+// quoteFor picks the quote for date. The seed shuffles which quote each day
+// gets, so two seeds give two different calendars.
+func (s *Server) quoteFor(date string) quote {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%d\x00%s", s.opts.Seed, date))
+	return quotes[binary.BigEndian.Uint64(sum[:8])%uint64(len(quotes))]
+}
+
+// puzzleFor builds the puzzle for date. The same seed and date always give
+// the same puzzle.
+func (s *Server) puzzleFor(date string) (fixture, error) {
+	q := s.quoteFor(date)
+	generated, err := puzzlegen.Generate(q.text, q.author, s.opts.Hints)
+	if err != nil {
+		return fixture{}, fmt.Errorf("fixture for %s: %w", date, err)
+	}
+
+	hints := make([]api.Hint, 0, len(generated.Hints))
+	for _, h := range generated.Hints {
+		hints = append(hints, api.Hint{CipherLetter: string(h.Cipher), PlainLetter: string(h.Plain)})
+	}
+	f := fixture{
+		puzzle: api.Puzzle{
+			ID:            gameID(date),
+			Date:          date,
+			EncryptedText: generated.EncryptedText,
+			Author:        generated.Author,
+			Category:      q.category,
+			Hints:         hints,
+			Difficulty:    difficulty(generated.Solution),
+		},
+		solution: generated.Solution,
+	}
+	if c, ok := sources[q.text]; ok {
+		f.context = &c
+	}
+	return f, nil
+}
+
+// difficulty scores a solution 0-100: longer quotes with more distinct
+// letters score higher, roughly as the real generator ranks them.
+func difficulty(solution string) int {
+	distinct := make(map[rune]bool)
+	for _, r := range solution {
+		if r >= 'A' && r <= 'Z' {
+			distinct[r] = true
+		}
+	}
+	return min(len(distinct)*3+len(solution)/8, 100)
+}
@@ -0,0 +1,435 @@
+// Package mockapi is a stand-in for the Unquote API, serving fixture puzzles
+// and keeping players in memory, so the TUI can be developed and played
+// without the real server. It can also be made slow or flaky on purpose.
+package mockapi
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// archiveDays is how far back random picks and author searches look.
+const archiveDays = 365
+
+// maxSearchResults caps an author search, newest first.
+const maxSearchResults = 20
+
+// Options configure the mock server. The zero value serves puzzles without
+// hints, as fast as it can and without failures.
+type Options struct {
+	Seed          uint64               // picks each date's quote, claim codes and injected failures
+	Hints         int                  // hints given away per puzzle
+	Latency       time.Duration        // delay before every response
+	FailRate      float64              // share of requests (0-1) answered with a 500
+	RateLimitRate float64              // share of requests (0-1) answered with a 429
+	RetryAfter    time.Duration        // Retry-After sent with a 429; 0 leaves it out
+	Legacy        bool                 // act like a deployment from before /capabilities, without the optional endpoints
+	Now           func() time.Time     // today's date, in UTC; nil uses time.Now
+	Sleep         func(time.Duration)  // waits out Latency; nil uses time.Sleep
+	Logf          func(string, ...any) // logs each request; nil logs nothing
+}
+
+// Server is the mock API. It is safe for concurrent use.
+type Server struct {
+	opts Options
+	mux  *http.ServeMux
+
+	mu      sync.Mutex
+	rng     *rand.Rand
+	players map[string]*player
+	duels   map[string][]api.DuelPlayer
+}
+
+// New returns a mock server with no players.
+func New(opts Options) *Server {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.Sleep == nil {
+		opts.Sleep = time.Sleep
+	}
+	s := &Server{
+		opts:    opts,
+		mux:     http.NewServeMux(),
+		rng:     rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15)),
+		players: make(map[string]*player),
+		duels:   make(map[string][]api.DuelPlayer),
+	}
+
+	s.mux.HandleFunc("GET /health/live", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	s.mux.HandleFunc("GET /game/today", s.handleToday)
+	s.mux.HandleFunc("GET /game/random", s.handleRandom)
+	s.mux.HandleFunc("GET /game/{id}", s.handleGame)
+	s.mux.HandleFunc("POST /game/{id}/check", s.handleCheck)
+	s.mux.HandleFunc("GET /game/{id}/context", s.handleContext)
+	s.mux.HandleFunc("POST /game/{id}/rating", s.handleRating)
+	s.mux.HandleFunc("POST /player", s.handleRegister)
+	s.mux.HandleFunc("POST /player/{code}/session", s.handleRecordSession)
+	s.mux.HandleFunc("GET /player/{code}/session/{gameID}", s.handleLookupSession)
+	s.mux.HandleFunc("POST /player/{code}/attempt", s.handleRecordAttempt)
+	s.mux.HandleFunc("GET /player/{code}/stats", s.handleStats)
+	if opts.Legacy {
+		return s
+	}
+	s.mux.HandleFunc("GET /capabilities", s.handleCapabilities)
+	s.mux.HandleFunc("GET /game/search", s.handleSearch)
+	s.mux.HandleFunc("GET /game/{id}/solution", s.handleSolution)
+	s.mux.HandleFunc("GET /stats/{date}", s.handleGlobalStats)
+	s.mux.HandleFunc("PUT /duel/{room}", s.handleDuel)
+	return s
+}
+
+// ServeHTTP delays and fails requests as configured, then routes them.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.opts.Logf != nil {
+		s.opts.Logf("%s %s", r.Method, r.URL.RequestURI())
+	}
+	if s.opts.Latency > 0 {
+		s.opts.Sleep(s.opts.Latency)
+	}
+
+	s.mu.Lock()
+	roll := s.rng.Float64()
+	s.mu.Unlock()
+	switch {
+	case roll < s.opts.RateLimitRate:
+		if s.opts.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(s.opts.RetryAfter.Round(time.Second)/time.Second)))
+		}
+		writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+		return
+	case roll < s.opts.RateLimitRate+s.opts.FailRate:
+		writeError(w, http.StatusInternalServerError, "injected failure")
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// today returns the server's current date.
+func (s *Server) today() time.Time {
+	now := s.opts.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// handleCapabilities lists every optional feature; the mock serves them all.
+func (s *Server) handleCapabilities(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, api.Capabilities{
+		Version:  "mock",
+		Features: []string{api.FeatureHints, api.FeatureLeaderboard, api.FeatureDuel, api.FeatureArchive},
+	})
+}
+
+// writeJSON sends v with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError sends an error body shaped like the real API's.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{"statusCode": status, "error": http.StatusText(status), "message": message})
+}
+
+// decode reads a JSON request body into v, answering 400 when it can't.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64*1024)).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+	return true
+}
+
+// servePuzzle answers with the puzzle for date.
+func (s *Server) servePuzzle(w http.ResponseWriter, date string) {
+	f, err := s.puzzleFor(date)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, f.puzzle)
+}
+
+func (s *Server) handleToday(w http.ResponseWriter, _ *http.Request) {
+	s.servePuzzle(w, s.today().Format(time.DateOnly))
+}
+
+// handleGame serves a puzzle by date (YYYY-MM-DD) or by game ID.
+func (s *Server) handleGame(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, err := time.Parse(time.DateOnly, id); err == nil {
+		s.servePuzzle(w, id)
+		return
+	}
+	date, ok := gameDate(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "invalid or non-existent game ID")
+		return
+	}
+	s.servePuzzle(w, date)
+}
+
+// handleRandom serves a puzzle from the past year, from the requested
+// category when there is one.
+func (s *Server) handleRandom(w http.ResponseWriter, r *http.Request) {
+	category := r.URL.Query().Get("category")
+	today := s.today()
+	for range 4 * archiveDays {
+		s.mu.Lock()
+		daysAgo := 1 + s.rng.IntN(archiveDays)
+		s.mu.Unlock()
+
+		date := today.AddDate(0, 0, -daysAgo).Format(time.DateOnly)
+		if category == "" || strings.EqualFold(s.quoteFor(date).category, category) {
+			s.servePuzzle(w, date)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "no puzzles in category "+category)
+}
+
+// handleSearch lists the past year's puzzles by an author, newest first.
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	author := strings.TrimSpace(r.URL.Query().Get("author"))
+	if author == "" {
+		writeError(w, http.StatusBadRequest, "author is required")
+		return
+	}
+
+	result := api.SearchPuzzlesResponse{Puzzles: []api.PuzzleSummary{}}
+	today := s.today()
+	for daysAgo := 0; daysAgo <= archiveDays && len(result.Puzzles) < maxSearchResults; daysAgo++ {
+		date := today.AddDate(0, 0, -daysAgo).Format(time.DateOnly)
+		if !strings.EqualFold(s.quoteFor(date).author, author) {
+			continue
+		}
+		f, err := s.puzzleFor(date)
+		if err != nil {
+			continue
+		}
+		result.Puzzles = append(result.Puzzles, api.PuzzleSummary{
+			ID: f.puzzle.ID, Date: date, Author: f.puzzle.Author, Category: f.puzzle.Category, Difficulty: f.puzzle.Difficulty,
+		})
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// fixtureFor looks up the puzzle behind a game ID in the path, answering 404
+// when there is none.
+func (s *Server) fixtureFor(w http.ResponseWriter, r *http.Request) (fixture, bool) {
+	date, ok := gameDate(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "invalid or non-existent game ID")
+		return fixture{}, false
+	}
+	f, err := s.puzzleFor(date)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return fixture{}, false
+	}
+	return f, true
+}
+
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	f, ok := s.fixtureFor(w, r)
+	if !ok {
+		return
+	}
+	var req api.CheckRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	writeJSON(w, http.StatusOK, api.CheckResponse{Correct: puzzle.SolutionMatches(f.solution, req.Solution)})
+}
+
+func (s *Server) handleSolution(w http.ResponseWriter, r *http.Request) {
+	if f, ok := s.fixtureFor(w, r); ok {
+		writeJSON(w, http.StatusOK, api.SolutionResponse{Solution: f.solution})
+	}
+}
+
+// handleContext serves a quote's source, or 404 for quotes without one.
+func (s *Server) handleContext(w http.ResponseWriter, r *http.Request) {
+	f, ok := s.fixtureFor(w, r)
+	if !ok {
+		return
+	}
+	if f.context == nil {
+		writeError(w, http.StatusNotFound, "no context for this quote")
+		return
+	}
+	writeJSON(w, http.StatusOK, f.context)
+}
+
+// handleRating accepts a difficulty rating from 1 to 5 and forgets it; the
+// mock has nothing that reads ratings back.
+func (s *Server) handleRating(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.fixtureFor(w, r); !ok {
+		return
+	}
+	var req api.RateDifficultyRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Rating < api.MinDifficultyRating || req.Rating > api.MaxDifficultyRating {
+		writeError(w, http.StatusBadRequest, "rating must be between 1 and 5")
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]string{"status": "created"})
+}
+
+func (s *Server) handleRegister(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	code := s.register()
+	s.mu.Unlock()
+	writeJSON(w, http.StatusCreated, api.RegisterPlayerResponse{ClaimCode: code})
+}
+
+// playerFor looks up the claim code in the path, answering 404 for an
+// unknown one. Callers hold s.mu.
+func (s *Server) playerFor(w http.ResponseWriter, r *http.Request) (*player, bool) {
+	p, ok := s.players[r.PathValue("code")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "player not found")
+	}
+	return p, ok
+}
+
+// handleRecordSession records a solve: 201 the first time, 200 after.
+func (s *Server) handleRecordSession(w http.ResponseWriter, r *http.Request) {
+	var req api.RecordSessionRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	date, ok := gameDate(req.GameID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "invalid or non-existent game ID")
+		return
+	}
+	solvedAt := s.opts.Now()
+	if req.SolvedAt != "" {
+		if at, err := time.Parse(time.RFC3339, req.SolvedAt); err == nil {
+			solvedAt = at
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.playerFor(w, r)
+	if !ok {
+		return
+	}
+	if _, recorded := p.solves[req.GameID]; recorded {
+		writeJSON(w, http.StatusOK, api.RecordSessionResponse{Status: "recorded"})
+		return
+	}
+	level := req.AssistLevel
+	if level == api.AssistNone && req.Any() {
+		// Clients older than assist levels send only the assists
+		level = api.AssistLight
+	}
+	p.solves[req.GameID] = solve{
+		date:           date,
+		completionTime: float64(req.CompletionTime),
+		solvedAt:       solvedAt,
+		assistLevel:    level,
+	}
+	beat := percentile(float64(req.CompletionTime))
+	writeJSON(w, http.StatusCreated, api.RecordSessionResponse{Status: "created", Percentile: &beat})
+}
+
+func (s *Server) handleLookupSession(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.playerFor(w, r)
+	if !ok {
+		return
+	}
+	solved, ok := p.solves[r.PathValue("gameID")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "no session found")
+		return
+	}
+	writeJSON(w, http.StatusOK, api.SessionLookupResponse{
+		SolvedAt:       solved.solvedAt.UTC().Format(time.RFC3339),
+		CompletionTime: solved.completionTime,
+	})
+}
+
+// handleRecordAttempt records a puzzle started but not solved: 201 the first
+// time, 200 after.
+func (s *Server) handleRecordAttempt(w http.ResponseWriter, r *http.Request) {
+	var req api.RecordAttemptRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if _, ok := gameDate(req.GameID); !ok {
+		writeError(w, http.StatusNotFound, "invalid or non-existent game ID")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.playerFor(w, r)
+	if !ok {
+		return
+	}
+	if p.attempts[req.GameID] {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "recorded"})
+		return
+	}
+	p.attempts[req.GameID] = true
+	writeJSON(w, http.StatusCreated, map[string]string{"status": "created"})
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.playerFor(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, p.stats(r.PathValue("code"), s.today()))
+}
+
+// handleGlobalStats sums up every player's solve of a day's puzzle.
+func (s *Server) handleGlobalStats(w http.ResponseWriter, r *http.Request) {
+	date := r.PathValue("date")
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		writeError(w, http.StatusNotFound, "invalid date")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.globalStats(date))
+}
+
+// handleDuel records progress in a duel room; a third player gets a 409.
+func (s *Server) handleDuel(w http.ResponseWriter, r *http.Request) {
+	var req api.DuelProgressRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Player == "" {
+		writeError(w, http.StatusBadRequest, "player is required")
+		return
+	}
+
+	s.mu.Lock()
+	room, ok := s.updateDuel(r.PathValue("room"), req)
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusConflict, "room is full")
+		return
+	}
+	writeJSON(w, http.StatusOK, room)
+}
@@ -0,0 +1,292 @@
+package mockapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+)
+
+var today = time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+
+// newClient serves s and returns the app's own API client pointed at it, so
+// the tests check the mock against what the TUI actually sends and parses.
+func newClient(t *testing.T, opts Options) *api.Client {
+	t.Helper()
+	if opts.Now == nil {
+		opts.Now = func() time.Time { return today }
+	}
+	srv := httptest.NewServer(New(opts))
+	t.Cleanup(srv.Close)
+	client, err := api.NewClientWithURL(srv.URL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestDailyPuzzle_SameSeedSamePuzzle(t *testing.T) {
+	first, err := newClient(t, Options{Seed: 7, Hints: 2}).FetchPuzzleByDate("2026-03-14")
+	if err != nil {
+		t.Fatalf("FetchPuzzleByDate() error: %v", err)
+	}
+	again, _ := newClient(t, Options{Seed: 7, Hints: 2}).FetchPuzzleByDate("2026-03-14")
+	if again == nil || again.EncryptedText != first.EncryptedText || again.ID != first.ID {
+		t.Errorf("same seed and date gave %+v, then %+v", first, again)
+	}
+	if first.ID != "mock-2026-03-14" || first.Date != "2026-03-14" || len(first.Hints) != 2 {
+		t.Errorf("puzzle = %+v, want the date's game ID and two hints", first)
+	}
+
+	// Some date in the first week differs under another seed
+	differs := false
+	for day := 1; day <= 7 && !differs; day++ {
+		date := time.Date(2026, 3, day, 0, 0, 0, 0, time.UTC).Format(time.DateOnly)
+		a, _ := newClient(t, Options{Seed: 7}).FetchPuzzleByDate(date)
+		b, _ := newClient(t, Options{Seed: 8}).FetchPuzzleByDate(date)
+		differs = a.EncryptedText != b.EncryptedText
+	}
+	if !differs {
+		t.Error("seeds 7 and 8 served the same week of puzzles")
+	}
+}
+
+func TestCheckAndSolution(t *testing.T) {
+	client := newClient(t, Options{})
+	p, err := client.FetchTodaysPuzzle()
+	if err != nil {
+		t.Fatalf("FetchTodaysPuzzle() error: %v", err)
+	}
+	if p.Date != "2026-03-14" {
+		t.Errorf("today's puzzle is for %s, want the server's date", p.Date)
+	}
+
+	solution, err := client.FetchSolution(p.ID)
+	if err != nil {
+		t.Fatalf("FetchSolution() error: %v", err)
+	}
+	if len(solution.Solution) != len(p.EncryptedText) {
+		t.Errorf("solution %q doesn't line up with %q", solution.Solution, p.EncryptedText)
+	}
+	if got, err := client.CheckSolution(p.ID, strings.ToLower(solution.Solution)); err != nil || !got.Correct {
+		t.Errorf("CheckSolution(answer) = %+v, %v; want correct", got, err)
+	}
+	if got, _ := client.CheckSolution(p.ID, "WRONG"); got == nil || got.Correct {
+		t.Errorf("CheckSolution(wrong) = %+v, want incorrect", got)
+	}
+	if _, err := client.FetchPuzzleByID("nope"); err == nil {
+		t.Error("FetchPuzzleByID(unknown) should fail")
+	}
+}
+
+func TestPlayer_RecordsAndReportsStats(t *testing.T) {
+	client := newClient(t, Options{Seed: 1})
+	reg, err := client.RegisterPlayer()
+	if err != nil {
+		t.Fatalf("RegisterPlayer() error: %v", err)
+	}
+	code := reg.ClaimCode
+
+	solvedAt := today.Add(-time.Hour)
+	for _, s := range []struct {
+		id      string
+		ms      int64
+		assists api.Assists
+	}{
+		{"mock-2026-03-12", 90_000, api.Assists{}},
+		{"mock-2026-03-13", 60_000, api.Assists{HintsUsed: 1}},
+		{"mock-2026-03-14", 120_000, api.Assists{}},
+		{"mock-2026-03-01", 30_000, api.Assists{}},
+	} {
+		resp, err := client.RecordSession(code, s.id, s.ms, solvedAt, s.assists)
+		if err != nil || resp.Status != "created" || resp.Percentile == nil {
+			t.Fatalf("RecordSession(%s) = %+v, %v; want created with a percentile", s.id, resp, err)
+		}
+	}
+	if resp, _ := client.RecordSession(code, "mock-2026-03-14", 1, solvedAt, api.Assists{}); resp == nil || resp.Status != "recorded" {
+		t.Errorf("recording again = %+v, want recorded", resp)
+	}
+	if err := client.RecordAttempt(code, "mock-2026-03-10", solvedAt, false); err != nil {
+		t.Fatalf("RecordAttempt() error: %v", err)
+	}
+
+	stats, err := client.FetchStats(code)
+	if err != nil {
+		t.Fatalf("FetchStats() error: %v", err)
+	}
+	if stats.GamesPlayed != 5 || stats.GamesSolved != 4 || stats.CurrentStreak != 3 || stats.BestStreak != 3 {
+		t.Errorf("stats = %+v, want 5 played, 4 solved, a streak of 3", stats)
+	}
+	if *stats.BestTime != 30_000 || *stats.AverageTime != 75_000 || *stats.CleanSolves != 3 || len(stats.RecentSolves) != 4 {
+		t.Errorf("times = best %v, avg %v, clean %v, recent %+v", *stats.BestTime, *stats.AverageTime, *stats.CleanSolves, stats.RecentSolves)
+	}
+	for _, s := range stats.RecentSolves {
+		if want := map[bool]api.AssistLevel{true: api.AssistLight}[s.Date == "2026-03-13"]; s.AssistLevel != want {
+			t.Errorf("recent solve %s assist level = %q, want %q from the hint", s.Date, s.AssistLevel, want)
+		}
+	}
+
+	if got := client.GetSession(code, "mock-2026-03-13"); got == nil || got.CompletionTime != 60_000 {
+		t.Errorf("GetSession() = %+v, want the recorded solve", got)
+	}
+	if _, err := client.FetchStats("NOBODY-HERE-0000"); err == nil {
+		t.Error("FetchStats(unknown) should fail")
+	}
+	if _, err := client.RecordSession("NOBODY-HERE-0000", "mock-2026-03-14", 1, solvedAt, api.Assists{}); !errors.Is(err, api.ErrPlayerNotFound) {
+		t.Errorf("RecordSession(unknown) error = %v, want ErrPlayerNotFound", err)
+	}
+}
+
+func TestRandomAndSearch(t *testing.T) {
+	client := newClient(t, Options{Seed: 3})
+
+	p, err := client.FetchRandomPuzzle("Humor")
+	if err != nil || p.Category != "Humor" {
+		t.Fatalf("FetchRandomPuzzle(Humor) = %+v, %v", p, err)
+	}
+	if p.Date >= "2026-03-14" {
+		t.Errorf("random puzzle from %s, want one from the archive", p.Date)
+	}
+
+	found, err := client.SearchPuzzles("mark twain")
+	if err != nil || len(found) == 0 {
+		t.Fatalf("SearchPuzzles() = %+v, %v; want some puzzles", found, err)
+	}
+	for i, s := range found {
+		if s.Author != "Mark Twain" || (i > 0 && s.Date >= found[i-1].Date) {
+			t.Errorf("result %d = %+v, want Mark Twain, newest first", i, s)
+		}
+	}
+}
+
+func TestDuel_ThirdPlayerIsTurnedAway(t *testing.T) {
+	client := newClient(t, Options{})
+	for _, player := range []string{"a", "b", "a"} {
+		if _, err := client.UpdateDuel("FOX-1", api.DuelProgressRequest{GameID: "mock-2026-03-14", Player: player, Progress: 0.5}); err != nil {
+			t.Fatalf("UpdateDuel(%s) error: %v", player, err)
+		}
+	}
+	room, _ := client.UpdateDuel("FOX-1", api.DuelProgressRequest{GameID: "mock-2026-03-14", Player: "b", Progress: 1})
+	if room == nil || len(room.Players) != 2 || room.Players[1].Progress != 1 {
+		t.Errorf("room = %+v, want both players with b done", room)
+	}
+	if _, err := client.UpdateDuel("FOX-1", api.DuelProgressRequest{Player: "c"}); !errors.Is(err, api.ErrDuelRoomFull) {
+		t.Errorf("third player error = %v, want ErrDuelRoomFull", err)
+	}
+}
+
+func TestFailureInjection(t *testing.T) {
+	failing := newClient(t, Options{FailRate: 1})
+	var apiErr *api.APIError
+	if _, err := failing.FetchPuzzleByDate("2026-03-14"); !errors.As(err, &apiErr) || apiErr.Status != http.StatusInternalServerError {
+		t.Errorf("FailRate 1: error = %v, want a 500", err)
+	}
+
+	limited := newClient(t, Options{RateLimitRate: 1, RetryAfter: 30 * time.Second})
+	var rateErr *api.RateLimitedError
+	if _, err := limited.FetchPuzzleByDate("2026-03-14"); !errors.As(err, &rateErr) || rateErr.RetryAfter != 30*time.Second {
+		t.Errorf("RateLimitRate 1: error = %v, want a 429 asking for 30s", err)
+	}
+
+	var slept time.Duration
+	slow := newClient(t, Options{Latency: 250 * time.Millisecond, Sleep: func(d time.Duration) { slept += d }})
+	if err := slow.CheckHealth(); err != nil {
+		t.Fatal(err)
+	}
+	if slept != 250*time.Millisecond {
+		t.Errorf("slept %v before answering, want the configured latency", slept)
+	}
+}
+
+func TestQuoteContext(t *testing.T) {
+	srv := New(Options{})
+	client := newClient(t, Options{})
+
+	var withSource, without string
+	for day := 1; day <= 60 && (withSource == "" || without == ""); day++ {
+		date := time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC).Format(time.DateOnly)
+		f, err := srv.puzzleFor(date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.context != nil {
+			withSource = f.puzzle.ID
+		} else {
+			without = f.puzzle.ID
+		}
+	}
+
+	got, err := client.FetchQuoteContext(withSource, "")
+	if err != nil || got.Source == "" || got.Year == 0 || got.Bio == "" {
+		t.Errorf("FetchQuoteContext(%s) = %+v, %v; want its source, year and bio", withSource, got, err)
+	}
+	// Without an author to fall back on, a quote with no source has nothing
+	if _, err := client.FetchQuoteContext(without, ""); !errors.Is(err, api.ErrNoQuoteContext) {
+		t.Errorf("FetchQuoteContext(%s) error = %v, want ErrNoQuoteContext", without, err)
+	}
+}
+
+func TestRateDifficulty(t *testing.T) {
+	client := newClient(t, Options{Seed: 1})
+	if err := client.RateDifficulty("mock-2026-03-14", 5); err != nil {
+		t.Errorf("RateDifficulty() error: %v", err)
+	}
+	if err := client.RateDifficulty("nope", 3); !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("RateDifficulty() for an unknown game error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGlobalStats(t *testing.T) {
+	client := newClient(t, Options{Seed: 1})
+	for _, ms := range []int64{60_000, 120_000, 300_000} {
+		reg, err := client.RegisterPlayer()
+		if err != nil {
+			t.Fatalf("RegisterPlayer() error: %v", err)
+		}
+		if _, err := client.RecordSession(reg.ClaimCode, "mock-2026-03-14", ms, today, api.Assists{}); err != nil {
+			t.Fatalf("RecordSession() error: %v", err)
+		}
+	}
+
+	stats, err := client.FetchGlobalStats("2026-03-14")
+	if err != nil {
+		t.Fatalf("FetchGlobalStats() error: %v", err)
+	}
+	if stats.SolveCount != 3 || *stats.AverageTime != 160_000 || *stats.MedianTime != 120_000 || *stats.PlayedDifficulty != 20 {
+		t.Errorf("stats = %+v, want 3 solves averaging 2:40 with a median of 2:00, played at 20", stats)
+	}
+
+	empty, err := client.FetchGlobalStats("2026-03-13")
+	if err != nil || empty.SolveCount != 0 || empty.MedianTime != nil || empty.PlayedDifficulty != nil {
+		t.Errorf("a day no one solved = %+v, %v; want no times", empty, err)
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	client := newClient(t, Options{})
+	caps, err := client.FetchCapabilities()
+	if err != nil {
+		t.Fatalf("FetchCapabilities() error: %v", err)
+	}
+	for _, feature := range []string{api.FeatureHints, api.FeatureLeaderboard, api.FeatureDuel, api.FeatureArchive} {
+		if !caps.Has(feature) {
+			t.Errorf("capabilities %+v lack %q", caps, feature)
+		}
+	}
+
+	legacy := newClient(t, Options{Legacy: true})
+	caps, err = legacy.FetchCapabilities()
+	if err != nil || len(caps.Features) != 0 {
+		t.Fatalf("legacy FetchCapabilities() = %+v, %v; want no optional features", caps, err)
+	}
+	if _, err := legacy.FetchTodaysPuzzle(); err != nil {
+		t.Errorf("legacy FetchTodaysPuzzle() error: %v", err)
+	}
+	if _, err := legacy.UpdateDuel("FOX-1", api.DuelProgressRequest{Player: "a"}); !errors.Is(err, api.ErrUnsupported) {
+		t.Errorf("legacy UpdateDuel() error = %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,178 @@
+package mockapi
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+)
+
+// Words claim codes are made from, as ADJECTIVE-NOUN-NNNN.
+var (
+	adjectives = []string{"TIGER", "AMBER", "BRAVE", "CEDAR", "DUSKY", "EAGER", "FROST", "GOLDEN", "HAZEL", "IVORY"}
+	nouns      = []string{"MAPLE", "RIVER", "STONE", "FINCH", "CLOUD", "EMBER", "GROVE", "HARBOR", "LANTERN", "MEADOW"}
+)
+
+// recentDays is how far back stats list recent solves.
+const recentDays = 30
+
+// solve is a session a player recorded.
+type solve struct {
+	date           string
+	completionTime float64 // milliseconds
+	solvedAt       time.Time
+	assistLevel    api.AssistLevel // empty for clean solves
+}
+
+// player is everything the mock server remembers about a claim code.
+type player struct {
+	solves   map[string]solve // by game ID
+	attempts map[string]bool  // game IDs started but not solved
+}
+
+// register creates a player under a new claim code. Callers hold s.mu.
+func (s *Server) register() string {
+	for {
+		code := fmt.Sprintf("%s-%s-%04d",
+			adjectives[s.rng.IntN(len(adjectives))], nouns[s.rng.IntN(len(nouns))], s.rng.IntN(10000))
+		if _, taken := s.players[code]; !taken {
+			s.players[code] = &player{solves: make(map[string]solve), attempts: make(map[string]bool)}
+			return code
+		}
+	}
+}
+
+// stats sums up a player's solves the way the real API's stats endpoint
+// does. Streaks count consecutive solved dates and are current when the
+// newest one is today or yesterday.
+func (p *player) stats(code string, today time.Time) api.PlayerStatsResponse {
+	played := len(p.solves)
+	for id := range p.attempts {
+		if _, solved := p.solves[id]; !solved {
+			played++
+		}
+	}
+
+	stats := api.PlayerStatsResponse{
+		ClaimCode:    code,
+		GamesPlayed:  played,
+		GamesSolved:  len(p.solves),
+		RecentSolves: []api.RecentSolve{},
+	}
+	if played > 0 {
+		stats.WinRate = float64(len(p.solves)) / float64(played)
+	}
+	if len(p.solves) == 0 {
+		return stats
+	}
+
+	var total float64
+	best := -1.0
+	clean := 0
+	var dates []string
+	cutoff := today.AddDate(0, 0, -recentDays).Format(time.DateOnly)
+	for _, s := range p.solves {
+		total += s.completionTime
+		if best < 0 || s.completionTime < best {
+			best = s.completionTime
+		}
+		if s.assistLevel == api.AssistNone {
+			clean++
+		}
+		dates = append(dates, s.date)
+		if s.date >= cutoff {
+			stats.RecentSolves = append(stats.RecentSolves, api.RecentSolve{Date: s.date, AssistLevel: s.assistLevel, CompletionTime: s.completionTime})
+		}
+	}
+	average := total / float64(len(p.solves))
+	stats.BestTime = &best
+	stats.AverageTime = &average
+	stats.CleanSolves = &clean
+	slices.SortFunc(stats.RecentSolves, func(a, b api.RecentSolve) int { return cmp.Compare(a.Date, b.Date) })
+
+	slices.Sort(dates)
+	run := 0
+	var last time.Time
+	for _, d := range dates {
+		day, _ := time.Parse(time.DateOnly, d)
+		if run > 0 && day.Equal(last.AddDate(0, 0, 1)) {
+			run++
+		} else {
+			run = 1
+		}
+		last = day
+		stats.BestStreak = max(stats.BestStreak, run)
+	}
+	yesterday := today.AddDate(0, 0, -1).Format(time.DateOnly)
+	if newest := last.Format(time.DateOnly); newest == today.Format(time.DateOnly) || newest == yesterday {
+		stats.CurrentStreak = run
+	}
+	return stats
+}
+
+// globalStats sums up every player's solve of date's puzzle. Callers hold
+// s.mu. The played difficulty is the median time on percentile's ten-minute
+// scale, so slower puzzles play harder.
+func (s *Server) globalStats(date string) api.GlobalStats {
+	var times []float64
+	for _, p := range s.players {
+		for _, solved := range p.solves {
+			if solved.date == date {
+				times = append(times, solved.completionTime)
+			}
+		}
+	}
+	stats := api.GlobalStats{Date: date, SolveCount: len(times)}
+	if len(times) == 0 {
+		return stats
+	}
+
+	slices.Sort(times)
+	var total float64
+	for _, t := range times {
+		total += t
+	}
+	average := total / float64(len(times))
+	median := times[len(times)/2]
+	if len(times)%2 == 0 {
+		median = (times[len(times)/2-1] + median) / 2
+	}
+	played := int(math.Round(100 - percentile(median)))
+	stats.AverageTime = &average
+	stats.MedianTime = &median
+	stats.PlayedDifficulty = &played
+	return stats
+}
+
+// percentile makes up how many players a solve beat: everyone under ten
+// minutes beats someone, and faster beats more.
+func percentile(completionTime float64) float64 {
+	const slowest = float64(10 * time.Minute / time.Millisecond)
+	return max(0, min(100, 100*(1-completionTime/slowest)))
+}
+
+// duelCapacity is how many players fit in a duel room.
+const duelCapacity = 2
+
+// updateDuel records a player's progress in room and returns the room, or
+// false when the room is already full of other players. Callers hold s.mu.
+func (s *Server) updateDuel(room string, progress api.DuelProgressRequest) (api.DuelRoom, bool) {
+	players := s.duels[room]
+	i := slices.IndexFunc(players, func(p api.DuelPlayer) bool { return p.Player == progress.Player })
+	if i < 0 {
+		if len(players) >= duelCapacity {
+			return api.DuelRoom{}, false
+		}
+		players = append(players, api.DuelPlayer{Player: progress.Player})
+		i = len(players) - 1
+	}
+	players[i].Progress = progress.Progress
+	if progress.SolvedAt != nil {
+		players[i].SolvedAt = progress.SolvedAt
+	}
+	s.duels[room] = players
+	return api.DuelRoom{Players: slices.Clone(players)}, true
+}
@@ -0,0 +1,269 @@
+// Package pack reads, writes and installs puzzle packs: themed collections of
+// quotes shared as unquote-pack.json files and played offline.
+//
+// A pack file looks like:
+//
+//	{
+//	  "format": 1,
+//	  "name": "Sci-Fi Classics",
+//	  "description": "Lines from the golden age",
+//	  "author": "Book Club",
+//	  "puzzles": [
+//	    {"quote": "The sky above the port...", "author": "William Gibson", "hints": 2}
+//	  ]
+//	}
+package pack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/atomicfile"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzlegen"
+)
+
+// FormatVersion is the pack file format this build reads and writes.
+const FormatVersion = 1
+
+// FileName is the conventional name for a shared pack file.
+const FileName = "unquote-pack.json"
+
+// appName is the subdirectory name within the XDG data directory
+const appName = "unquote"
+
+// Pack is a named collection of quotes to play as puzzles.
+type Pack struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Author      string  `json:"author,omitempty"` // who put the pack together
+	Puzzles     []Entry `json:"puzzles"`
+	Format      int     `json:"format"`
+}
+
+// Entry is one quote in a pack.
+type Entry struct {
+	Quote  string `json:"quote"`
+	Author string `json:"author,omitempty"`
+	Hints  int    `json:"hints,omitempty"` // cipher letters to give away
+}
+
+// Generate turns the entry into a playable puzzle. The same entry always
+// yields the same puzzle, so progress is kept across runs.
+func (e Entry) Generate() (*puzzlegen.Puzzle, error) {
+	return puzzlegen.Generate(e.Quote, e.Author, e.Hints)
+}
+
+// Parse decodes and validates a pack file.
+func Parse(data []byte) (*Pack, error) {
+	var p Pack
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("unmarshaling pack: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks that the pack is in a supported format, is named, and that
+// every quote can be turned into a puzzle.
+func (p *Pack) Validate() error {
+	if p.Format != FormatVersion {
+		return fmt.Errorf("unsupported pack format %d (this version reads format %d)", p.Format, FormatVersion)
+	}
+	if p.Slug() == "" {
+		return errors.New("pack has no name")
+	}
+	if len(p.Puzzles) == 0 {
+		return errors.New("pack has no puzzles")
+	}
+	for i, entry := range p.Puzzles {
+		if _, err := entry.Generate(); err != nil {
+			return fmt.Errorf("puzzle %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Marshal encodes the pack as an indented pack file.
+func (p *Pack) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling pack: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// Slug returns the pack's installed file name without extension: its name in
+// lower case with runs of anything but letters and digits replaced by "-".
+func (p *Pack) Slug() string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(p.Name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
+
+// packsDir returns the absolute path to the installed packs directory (~/.local/share/unquote/packs/).
+// It uses xdg.DataFile to ensure the directory is created.
+func packsDir() (string, error) {
+	// Create a probe file to ensure directory exists, then return the directory
+	path, err := xdg.DataFile(filepath.Join(appName, "packs", ".keep"))
+	if err != nil {
+		return "", fmt.Errorf("creating packs directory: %w", err)
+	}
+	return filepath.Dir(path), nil
+}
+
+// packsRoot opens an os.Root handle on the packs directory.
+// The caller must defer root.Close().
+func packsRoot() (*os.Root, error) {
+	dir, err := packsDir()
+	if err != nil {
+		return nil, fmt.Errorf("getting packs directory: %w", err)
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("opening root: %w", err)
+	}
+	return root, nil
+}
+
+// Install validates the pack and saves it to the packs directory, replacing
+// any installed pack with the same slug. Writes are atomic (temp file + rename).
+func Install(p *Pack) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	data, err := p.Marshal()
+	if err != nil {
+		return err
+	}
+
+	root, err := packsRoot()
+	if err != nil {
+		return fmt.Errorf("opening packs root: %w", err)
+	}
+	defer root.Close()
+
+	if err := atomicfile.WriteFile(atomicfile.Root(root), p.Slug()+".json", data, 0o600); err != nil {
+		return fmt.Errorf("writing pack file: %w", err)
+	}
+	return nil
+}
+
+// Load returns the installed pack with the given name or slug.
+// Returns nil, nil if no such pack is installed.
+func Load(name string) (*Pack, error) {
+	slug := (&Pack{Name: name}).Slug()
+	if slug == "" {
+		return nil, errors.New("pack name is empty")
+	}
+
+	root, err := packsRoot()
+	if err != nil {
+		return nil, fmt.Errorf("opening packs root: %w", err)
+	}
+	defer root.Close()
+
+	data, err := root.ReadFile(slug + ".json")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // not installed
+		}
+		return nil, fmt.Errorf("reading pack file: %w", err)
+	}
+
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("pack %q: %w", slug, err)
+	}
+	return p, nil
+}
+
+// List returns every installed pack, sorted by slug. Files that fail to parse
+// are skipped so one bad pack doesn't hide the rest.
+// os.Root does not expose ReadDir; use os.Open for enumeration, os.OpenRoot for confined reads.
+func List() ([]*Pack, error) {
+	dir, err := packsDir()
+	if err != nil {
+		return nil, fmt.Errorf("getting packs directory: %w", err)
+	}
+
+	f, err := os.Open(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening packs directory: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := f.ReadDir(-1)
+	if err != nil {
+		return nil, fmt.Errorf("reading packs directory: %w", err)
+	}
+
+	root, err := packsRoot()
+	if err != nil {
+		return nil, fmt.Errorf("opening packs root: %w", err)
+	}
+	defer root.Close()
+
+	var packs []*Pack
+	for _, entry := range entries {
+		name := entry.Name()
+		// Skip non-JSON files and the .keep probe file
+		if entry.IsDir() || name == ".keep" || filepath.Ext(name) != ".json" {
+			continue
+		}
+
+		data, err := root.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("reading pack file %q: %w", name, err)
+		}
+
+		p, err := Parse(data)
+		if err != nil {
+			continue
+		}
+		packs = append(packs, p)
+	}
+
+	slices.SortFunc(packs, func(a, b *Pack) int {
+		return strings.Compare(a.Slug(), b.Slug())
+	})
+	return packs, nil
+}
+
+// FromQuotes builds a pack from quote file contents, in the format accepted
+// by puzzlegen.ParseQuote, giving each puzzle the same number of hints.
+func FromQuotes(name string, quotes []string, hints int) (*Pack, error) {
+	p := &Pack{Name: name, Format: FormatVersion}
+	for _, text := range quotes {
+		quote, author := puzzlegen.ParseQuote(text)
+		p.Puzzles = append(p.Puzzles, Entry{Quote: quote, Author: author, Hints: hints})
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,194 @@
+package pack
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adrg/xdg"
+)
+
+// setDataHome sets XDG_DATA_HOME to a temp dir and reloads xdg paths.
+func setDataHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+}
+
+func samplePack(name string) *Pack {
+	return &Pack{
+		Format: FormatVersion,
+		Name:   name,
+		Puzzles: []Entry{
+			{Quote: "To be or not to be", Author: "Shakespeare", Hints: 1},
+			{Quote: "I think, therefore I am", Author: "Descartes"},
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			data: `{"format": 1, "name": "Classics", "puzzles": [{"quote": "Know thyself", "author": "Socrates"}]}`,
+		},
+		{
+			name:    "not json",
+			data:    `quote: Know thyself`,
+			wantErr: "unmarshaling pack",
+		},
+		{
+			name:    "future format",
+			data:    `{"format": 2, "name": "Classics", "puzzles": [{"quote": "Know thyself"}]}`,
+			wantErr: "unsupported pack format 2",
+		},
+		{
+			name:    "missing format",
+			data:    `{"name": "Classics", "puzzles": [{"quote": "Know thyself"}]}`,
+			wantErr: "unsupported pack format 0",
+		},
+		{
+			name:    "no name",
+			data:    `{"format": 1, "name": " !? ", "puzzles": [{"quote": "Know thyself"}]}`,
+			wantErr: "no name",
+		},
+		{
+			name:    "no puzzles",
+			data:    `{"format": 1, "name": "Classics", "puzzles": []}`,
+			wantErr: "no puzzles",
+		},
+		{
+			name:    "bad quote",
+			data:    `{"format": 1, "name": "Classics", "puzzles": [{"quote": "Know thyself"}, {"quote": "Déjà vu"}]}`,
+			wantErr: "puzzle 2:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Parse([]byte(tt.data))
+			if tt.wantErr == "" {
+				if err != nil || p == nil {
+					t.Fatalf("Parse() = %v, %v; want a pack", p, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Sci-Fi Classics", "sci-fi-classics"},
+		{"  Hello,  World!  ", "hello-world"},
+		{"../../etc/passwd", "etc-passwd"},
+		{"Café Quotes", "caf-quotes"},
+		{"???", ""},
+	}
+
+	for _, tt := range tests {
+		if got := (&Pack{Name: tt.name}).Slug(); got != tt.want {
+			t.Errorf("Slug(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEntryGenerate_IsStable(t *testing.T) {
+	entry := samplePack("Classics").Puzzles[0]
+
+	first, err := entry.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	second, _ := entry.Generate()
+	if first.ID != second.ID || first.EncryptedText != second.EncryptedText {
+		t.Error("generating the same entry twice should yield the same puzzle")
+	}
+	if len(first.Hints) != 1 {
+		t.Errorf("got %d hints, want 1", len(first.Hints))
+	}
+}
+
+func TestInstallLoadList(t *testing.T) {
+	setDataHome(t)
+
+	if err := Install(samplePack("Zen Sayings")); err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	if err := Install(samplePack("Classics")); err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+
+	loaded, err := Load("zen sayings")
+	if err != nil || loaded == nil {
+		t.Fatalf("Load() = %v, %v; want the installed pack", loaded, err)
+	}
+	if loaded.Name != "Zen Sayings" || len(loaded.Puzzles) != 2 {
+		t.Errorf("loaded pack = %+v, want the saved one", loaded)
+	}
+
+	missing, err := Load("nope")
+	if err != nil || missing != nil {
+		t.Errorf("Load(missing) = %v, %v; want nil, nil", missing, err)
+	}
+
+	packs, err := List()
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(packs) != 2 || packs[0].Name != "Classics" || packs[1].Name != "Zen Sayings" {
+		t.Errorf("List() = %+v, want both packs sorted by slug", packs)
+	}
+}
+
+func TestInstall_ReplacesSameSlug(t *testing.T) {
+	setDataHome(t)
+
+	if err := Install(samplePack("Classics")); err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+	updated := samplePack("classics!")
+	updated.Puzzles = updated.Puzzles[:1]
+	if err := Install(updated); err != nil {
+		t.Fatalf("Install() error: %v", err)
+	}
+
+	packs, _ := List()
+	if len(packs) != 1 || len(packs[0].Puzzles) != 1 {
+		t.Errorf("List() = %+v, want the pack replaced", packs)
+	}
+}
+
+func TestInstall_RejectsInvalid(t *testing.T) {
+	setDataHome(t)
+
+	bad := samplePack("Classics")
+	bad.Format = 0
+	if err := Install(bad); err == nil {
+		t.Error("Install() should reject an invalid pack")
+	}
+}
+
+func TestFromQuotes(t *testing.T) {
+	p, err := FromQuotes("Mine", []string{"Carpe diem\n— Horace", "Veni, vidi, vici"}, 1)
+	if err != nil {
+		t.Fatalf("FromQuotes() error: %v", err)
+	}
+	if p.Format != FormatVersion || p.Puzzles[0].Author != "Horace" || p.Puzzles[1].Hints != 1 {
+		t.Errorf("FromQuotes() = %+v", p)
+	}
+
+	if _, err := FromQuotes("Mine", nil, 0); err == nil {
+		t.Error("FromQuotes() with no quotes should fail")
+	}
+}
@@ -0,0 +1,38 @@
+package perfbudget
+
+import "strings"
+
+// Puzzle is a cipher text the render benchmarks run against.
+type Puzzle struct {
+	Name string
+	Text string
+}
+
+// cipherWords are the words benchmark puzzles are made of: mixed lengths and
+// punctuation, so wrapping and cell styles get realistic work.
+var cipherWords = strings.Fields("XBZ QFMWP OEVUS, AVT YJKGC VMZE XBZ HNLD RVQ. " +
+	"NSR WNEZXMJFFK'C TGIZKSNHJ — \"RVUZ\" QN XQZSXC-VSZ! EMY?")
+
+// Puzzles are the small, medium and huge puzzles benchmarks are run across.
+// Huge is about four times the longest daily quote.
+var Puzzles = []Puzzle{
+	{Name: "small", Text: quote(40)},
+	{Name: "medium", Text: quote(160)},
+	{Name: "huge", Text: quote(640)},
+}
+
+// Widths are the terminal widths benchmarks are run across: a narrow split
+// pane, a standard terminal and a wide one.
+var Widths = []int{40, 80, 200}
+
+// quote builds a cipher text of at least n characters from cipherWords.
+func quote(n int) string {
+	var b strings.Builder
+	for i := 0; b.Len() < n; i++ {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(cipherWords[i%len(cipherWords)])
+	}
+	return b.String()
+}
@@ -0,0 +1,61 @@
+// Package perfbudget checks benchmarks against time budgets from ordinary
+// tests, so rendering regressions fail a test run instead of waiting for
+// someone to read benchmark output.
+//
+// Budgets only mean something on a known machine, so checks are skipped
+// unless the UNQUOTE_PERF_BUDGET environment variable is set. Its value
+// scales every budget: 1 checks them as written, 2.5 allows a slower CI
+// runner two and a half times as long.
+package perfbudget
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// EnvVar turns budget checks on and scales the budgets.
+const EnvVar = "UNQUOTE_PERF_BUDGET"
+
+// Scale returns the budget multiplier from EnvVar, and false when budget
+// checks are off. A value that isn't a positive number is an error.
+func Scale() (float64, bool, error) {
+	value := os.Getenv(EnvVar)
+	if value == "" {
+		return 0, false, nil
+	}
+	scale, err := strconv.ParseFloat(value, 64)
+	if err != nil || scale <= 0 {
+		return 0, false, fmt.Errorf("%s=%q: want a positive multiplier such as 1 or 2.5", EnvVar, value)
+	}
+	return scale, true, nil
+}
+
+// Exceeds reports an error when result took longer per op than budget
+// scaled by scale.
+func Exceeds(result testing.BenchmarkResult, budget time.Duration, scale float64) error {
+	limit := time.Duration(float64(budget) * scale)
+	if took := time.Duration(result.NsPerOp()); took > limit {
+		return fmt.Errorf("%s/op, over the %s budget", took, limit)
+	}
+	return nil
+}
+
+// Check runs bench and fails t when it takes longer per op than budget,
+// scaled by EnvVar. It skips when EnvVar isn't set.
+func Check(t *testing.T, budget time.Duration, bench func(b *testing.B)) {
+	t.Helper()
+	scale, on, err := Scale()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !on {
+		t.Skipf("set %s to check performance budgets", EnvVar)
+	}
+
+	if err := Exceeds(testing.Benchmark(bench), budget, scale); err != nil {
+		t.Error(err)
+	}
+}
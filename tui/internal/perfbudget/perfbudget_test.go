@@ -0,0 +1,64 @@
+package perfbudget
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		on      bool
+		wantErr bool
+	}{
+		{value: "", on: false},
+		{value: "1", want: 1, on: true},
+		{value: "2.5", want: 2.5, on: true},
+		{value: "0", wantErr: true},
+		{value: "-1", wantErr: true},
+		{value: "fast", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Setenv(EnvVar, tt.value)
+		scale, on, err := Scale()
+		if (err != nil) != tt.wantErr || on != tt.on || scale != tt.want {
+			t.Errorf("%s=%q: Scale() = %v, %v, %v", EnvVar, tt.value, scale, on, err)
+		}
+	}
+}
+
+func TestExceeds(t *testing.T) {
+	result := testing.BenchmarkResult{N: 10, T: 10 * time.Millisecond} // 1ms/op
+
+	if err := Exceeds(result, 2*time.Millisecond, 1); err != nil {
+		t.Errorf("1ms/op against a 2ms budget: %v", err)
+	}
+	if err := Exceeds(result, 500*time.Microsecond, 1); err == nil {
+		t.Error("1ms/op against a 500µs budget should fail")
+	}
+	if err := Exceeds(result, 500*time.Microsecond, 3); err != nil {
+		t.Errorf("1ms/op against a 500µs budget scaled by 3: %v", err)
+	}
+}
+
+func TestCheck_SkipsWhenOff(t *testing.T) {
+	t.Setenv(EnvVar, "")
+	ran := false
+	t.Run("check", func(t *testing.T) {
+		Check(t, time.Nanosecond, func(b *testing.B) { ran = true })
+	})
+	if ran {
+		t.Error("Check ran the benchmark with budgets off")
+	}
+}
+
+func TestPuzzles(t *testing.T) {
+	previous := 0
+	for _, p := range Puzzles {
+		if len(p.Text) <= previous {
+			t.Errorf("%s puzzle is %d characters, want it longer than the previous %d", p.Name, len(p.Text), previous)
+		}
+		previous = len(p.Text)
+	}
+}
@@ -1,6 +1,9 @@
 package puzzle
 
-import "unicode"
+import (
+	"unicode"
+	"unicode/utf8"
+)
 
 // CellKind distinguishes between different types of cells in the puzzle grid.
 type CellKind int
@@ -13,27 +16,43 @@ const (
 
 // Cell represents a single character position in the puzzle
 type Cell struct {
-	Index int      // Position in the original text
+	Index int      // Position in the grid: the character's rune offset in the text
 	Char  rune     // The cipher character (encrypted)
 	Input rune     // User's input (0 if empty)
 	Kind  CellKind // Type of cell: punctuation, letter, or hint
+
+	// Locked is set on a letter the player pinned: SetInput and
+	// ClearAllInput leave it alone until SetLocked unlocks it.
+	Locked bool
 }
 
-// BuildCells creates a slice of cells from encrypted text.
-// The hints map contains cipher-to-plain letter mappings. Cells whose cipher
-// character appears in hints are created as CellHint with Input pre-set.
-// Pass nil for no hints.
+// NormalizeLetter returns the form a letter takes in the grid: its upper
+// case, so é and É are the same input. Letters without an upper case, such
+// as ß, come back unchanged.
+func NormalizeLetter(r rune) rune {
+	return unicode.ToUpper(r)
+}
+
+// BuildCells creates a slice of cells from encrypted text, one per rune, so
+// a cell's Index is its position in the slice.
+// The hints map contains cipher-to-plain letter mappings, matched ignoring
+// case. Cells whose cipher character appears in hints are created as
+// CellHint with Input pre-set. Pass nil for no hints.
 func BuildCells(encryptedText string, hints map[rune]rune) []Cell {
-	cells := make([]Cell, 0, len(encryptedText))
+	normalized := make(map[rune]rune, len(hints))
+	for cipher, plain := range hints {
+		normalized[NormalizeLetter(cipher)] = NormalizeLetter(plain)
+	}
 
-	for i, char := range encryptedText {
+	cells := make([]Cell, 0, utf8.RuneCountInString(encryptedText))
+	for _, char := range encryptedText {
 		cell := Cell{
-			Index: i,
+			Index: len(cells),
 			Char:  char,
 		}
 
 		if unicode.IsLetter(char) {
-			if plain, ok := hints[char]; ok {
+			if plain, ok := normalized[NormalizeLetter(char)]; ok {
 				cell.Kind = CellHint
 				cell.Input = plain
 			} else {
@@ -48,6 +67,20 @@ func BuildCells(encryptedText string, hints map[rune]rune) []Cell {
 	return cells
 }
 
+// BuiltFrom reports whether cells are the grid BuildCells makes for
+// encryptedText, whatever their inputs: one cell per rune, with the same
+// cipher characters. Inputs saved from cells only belong to that text.
+func BuiltFrom(cells []Cell, encryptedText string) bool {
+	i := 0
+	for _, char := range encryptedText {
+		if i == len(cells) || cells[i].Char != char {
+			return false
+		}
+		i++
+	}
+	return i == len(cells)
+}
+
 // NextLetterCell finds the next editable cell index after the given position
 // Returns -1 if no next letter cell exists
 func NextLetterCell(cells []Cell, currentPos int) int {
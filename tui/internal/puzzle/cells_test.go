@@ -27,6 +27,12 @@ func TestBuildCells(t *testing.T) {
 			expectedLen:  13,
 			expectedKind: []CellKind{CellLetter, CellLetter, CellLetter, CellLetter, CellLetter, CellPunctuation, CellPunctuation, CellLetter, CellLetter, CellLetter, CellLetter, CellLetter, CellPunctuation},
 		},
+		{
+			name:         "multi-byte letters",
+			input:        "ŽIVOT, ĆE!",
+			expectedLen:  10,
+			expectedKind: []CellKind{CellLetter, CellLetter, CellLetter, CellLetter, CellLetter, CellPunctuation, CellPunctuation, CellLetter, CellLetter, CellPunctuation},
+		},
 		{
 			name:         "empty string",
 			input:        "",
@@ -226,6 +232,39 @@ func TestBuildCellsHintDuplicates(t *testing.T) {
 	}
 }
 
+func TestBuildCellsUnicodeHints(t *testing.T) {
+	// Cyrillic cipher text; hints are matched ignoring case and stored upper case
+	hints := map[rune]rune{'ж': 'я', 'Д': 'ё'}
+	cells := BuildCells("ЖДЖ Б", hints)
+
+	want := []struct {
+		kind  CellKind
+		input rune
+	}{{CellHint, 'Я'}, {CellHint, 'Ё'}, {CellHint, 'Я'}, {CellPunctuation, 0}, {CellLetter, 0}}
+	for i, w := range want {
+		if cells[i].Kind != w.kind || cells[i].Input != w.input {
+			t.Errorf("cell %d = Kind %v Input %c, want Kind %v Input %c", i, cells[i].Kind, cells[i].Input, w.kind, w.input)
+		}
+	}
+}
+
+func TestNormalizeLetter(t *testing.T) {
+	tests := []struct {
+		in, want rune
+	}{
+		{'a', 'A'},
+		{'é', 'É'},
+		{'ж', 'Ж'},
+		{'Ω', 'Ω'},
+		{'ß', 'ß'},
+	}
+	for _, tt := range tests {
+		if got := NormalizeLetter(tt.in); got != tt.want {
+			t.Errorf("NormalizeLetter(%c) = %c, want %c", tt.in, got, tt.want)
+		}
+	}
+}
+
 func TestBuildCellsNilHints(t *testing.T) {
 	cells := BuildCells("A, B", nil)
 
@@ -362,3 +401,30 @@ func TestNextUnfilledLetterCellSkipsHints(t *testing.T) {
 		})
 	}
 }
+
+func TestBuiltFrom(t *testing.T) {
+	cells := BuildCells("ŽA, B!", map[rune]rune{'B': 'E'})
+	cells[1].Input = 'X'
+
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{"same text", "ŽA, B!", true},
+		{"different letter", "ŽA, C!", false},
+		{"shorter text", "ŽA, B", false},
+		{"longer text", "ŽA, B!!", false},
+		{"empty text", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuiltFrom(cells, tt.text); got != tt.want {
+				t.Errorf("BuiltFrom(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+	if !BuiltFrom(nil, "") {
+		t.Error("BuiltFrom(nil, \"\") = false, want true")
+	}
+}
@@ -0,0 +1,104 @@
+package puzzle
+
+import "unicode"
+
+// AccentPolicy decides what happens to accented letters the player types.
+type AccentPolicy string
+
+const (
+	AccentsAuto AccentPolicy = ""     // fold, unless the puzzle has accented letters of its own
+	AccentsFold AccentPolicy = "fold" // é → E
+	AccentsKeep AccentPolicy = "keep" // é → É
+)
+
+// ParseAccentPolicy reads a policy name, reporting false for unknown names.
+// "auto" is accepted as well as the empty string.
+func ParseAccentPolicy(s string) (AccentPolicy, bool) {
+	switch AccentPolicy(s) {
+	case AccentsAuto, "auto":
+		return AccentsAuto, true
+	case AccentsFold, AccentsKeep:
+		return AccentPolicy(s), true
+	default:
+		return AccentsAuto, false
+	}
+}
+
+// accentBases lists the accented upper-case Latin letters FoldAccent strips,
+// by the base letter they fold to.
+var accentBases = map[rune]string{
+	'A': "ÀÁÂÃÄÅĀĂĄǍ",
+	'C': "ÇĆĈĊČ",
+	'D': "ĎĐ",
+	'E': "ÈÉÊËĒĔĖĘĚ",
+	'G': "ĜĞĠĢ",
+	'H': "ĤĦ",
+	'I': "ÌÍÎÏĨĪĬĮİǏ",
+	'J': "Ĵ",
+	'K': "Ķ",
+	'L': "ĹĻĽĿŁ",
+	'N': "ÑŃŅŇ",
+	'O': "ÒÓÔÕÖØŌŎŐǑ",
+	'R': "ŔŖŘ",
+	'S': "ŚŜŞŠȘ",
+	'T': "ŢŤŦȚ",
+	'U': "ÙÚÛÜŨŪŬŮŰŲǓ",
+	'W': "Ŵ",
+	'Y': "ÝŶŸ",
+	'Z': "ŹŻŽ",
+}
+
+// accentFolds maps each accented letter in accentBases to its base letter.
+var accentFolds = func() map[rune]rune {
+	folds := make(map[rune]rune)
+	for base, accented := range accentBases {
+		for _, r := range accented {
+			folds[r] = base
+		}
+	}
+	return folds
+}()
+
+// FoldAccent returns the letter without its accent, in upper case: é and É
+// both give E. Letters with no plain Latin base, such as ß, Æ or Ж, come
+// back normalized but otherwise unchanged.
+func FoldAccent(r rune) rune {
+	r = NormalizeLetter(r)
+	if base, ok := accentFolds[r]; ok {
+		return base
+	}
+	return r
+}
+
+// HasAccents reports whether any of the cells' cipher letters is accented,
+// which AccentsAuto takes as a sign the puzzle's language uses accents.
+func HasAccents(cells []Cell) bool {
+	for _, c := range cells {
+		if c.Kind != CellPunctuation && FoldAccent(c.Char) != NormalizeLetter(c.Char) {
+			return true
+		}
+	}
+	return false
+}
+
+// InputLetter turns the text of a key press into the letter to enter, or
+// reports false when the text isn't a letter. Lower case is accepted, and
+// letters composed with option, AltGr or a dead key are read whether they
+// arrive precomposed (é) or as a letter followed by combining marks (e +
+// U+0301); the latter always fold to their base letter. With fold set,
+// accents are stripped (see FoldAccent).
+func InputLetter(text string, fold bool) (rune, bool) {
+	runes := []rune(text)
+	if len(runes) == 0 || !unicode.IsLetter(runes[0]) {
+		return 0, false
+	}
+	for _, r := range runes[1:] {
+		if !unicode.Is(unicode.Mn, r) {
+			return 0, false
+		}
+	}
+	if fold || len(runes) > 1 {
+		return FoldAccent(runes[0]), true
+	}
+	return NormalizeLetter(runes[0]), true
+}
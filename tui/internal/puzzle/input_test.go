@@ -0,0 +1,152 @@
+package puzzle
+
+import (
+	"testing"
+	"testing/quick"
+	"unicode"
+)
+
+func TestInputLetter(t *testing.T) {
+	tests := []struct {
+		text   string
+		fold   bool
+		want   rune
+		wantOK bool
+	}{
+		{text: "a", want: 'A', wantOK: true},
+		{text: "Q", fold: true, want: 'Q', wantOK: true},
+		{text: "é", want: 'É', wantOK: true},
+		{text: "é", fold: true, want: 'E', wantOK: true},
+		{text: "Ø", fold: true, want: 'O', wantOK: true},
+		{text: "ß", fold: true, want: 'ß', wantOK: true},
+		{text: "ж", fold: true, want: 'Ж', wantOK: true},
+		{text: "e\u0301", want: 'E', wantOK: true},       // dead key sent decomposed
+		{text: "n\u0303\u0301", want: 'N', wantOK: true}, // stacked marks
+		{text: "", wantOK: false},
+		{text: "1", wantOK: false},
+		{text: "´", wantOK: false},      // a dead key on its own
+		{text: "\u0301", wantOK: false}, // a combining mark without a letter
+		{text: "ab", wantOK: false},
+		{text: "alt+e", wantOK: false},
+	}
+	for _, tt := range tests {
+		got, ok := InputLetter(tt.text, tt.fold)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("InputLetter(%q, %v) = %c, %v; want %c, %v", tt.text, tt.fold, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseAccentPolicy(t *testing.T) {
+	for in, want := range map[string]AccentPolicy{"": AccentsAuto, "auto": AccentsAuto, "fold": AccentsFold, "keep": AccentsKeep} {
+		if got, ok := ParseAccentPolicy(in); !ok || got != want {
+			t.Errorf("ParseAccentPolicy(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+	if _, ok := ParseAccentPolicy("FOLD"); ok {
+		t.Error("ParseAccentPolicy(FOLD) should be rejected")
+	}
+}
+
+func TestHasAccents(t *testing.T) {
+	if HasAccents(BuildCells("HELLO, WORLD ß Ж", nil)) {
+		t.Error("HasAccents = true for letters without accents")
+	}
+	if !HasAccents(BuildCells("ÇA VA", nil)) {
+		t.Error("HasAccents = false for a puzzle with Ç")
+	}
+}
+
+// quickLetter maps any rune to a letter, so properties are checked over the
+// whole of Unicode's letters rather than mostly rejected input.
+func quickLetter(r rune) (rune, bool) {
+	r = max(r, 0) % (unicode.MaxRune + 1)
+	return r, unicode.IsLetter(r)
+}
+
+func TestInputLetter_Properties(t *testing.T) {
+	config := &quick.Config{MaxCount: 20000}
+
+	// Any letter is accepted, as the normalized letter with keep and as its
+	// folded form with fold
+	accepts := func(r rune) bool {
+		r, ok := quickLetter(r)
+		if !ok {
+			_, accepted := InputLetter(string(r), false)
+			return !accepted
+		}
+		kept, keptOK := InputLetter(string(r), false)
+		folded, foldedOK := InputLetter(string(r), true)
+		return keptOK && foldedOK && kept == NormalizeLetter(r) && folded == FoldAccent(r)
+	}
+	if err := quick.Check(accepts, config); err != nil {
+		t.Error("letters are accepted and normalized:", err)
+	}
+
+	// Case never matters
+	caseless := func(r rune) bool {
+		r, ok := quickLetter(r)
+		if !ok {
+			return true
+		}
+		for _, fold := range []bool{false, true} {
+			lower, _ := InputLetter(string(unicode.ToLower(r)), fold)
+			upper, _ := InputLetter(string(unicode.ToUpper(r)), fold)
+			if lower != upper {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(caseless, config); err != nil {
+		t.Error("lower and upper case enter the same letter:", err)
+	}
+
+	// Entering a letter is idempotent: typing the letter a cell shows enters
+	// that letter again
+	idempotent := func(r rune) bool {
+		r, ok := quickLetter(r)
+		if !ok {
+			return true
+		}
+		for _, fold := range []bool{false, true} {
+			once, _ := InputLetter(string(r), fold)
+			twice, ok := InputLetter(string(once), fold)
+			if !ok || twice != once {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(idempotent, config); err != nil {
+		t.Error("input is idempotent:", err)
+	}
+
+	// Folding leaves no accent a second fold could strip, and only ever
+	// changes a letter to a plain ASCII one
+	folds := func(r rune) bool {
+		r, ok := quickLetter(r)
+		if !ok {
+			return true
+		}
+		folded := FoldAccent(r)
+		return FoldAccent(folded) == folded && (folded == NormalizeLetter(r) || folded <= unicode.MaxASCII)
+	}
+	if err := quick.Check(folds, config); err != nil {
+		t.Error("folding strips accents to ASCII:", err)
+	}
+}
+
+func TestFoldAccent_Table(t *testing.T) {
+	// Every accented letter in the table, in either case, folds to its base
+	for base, accented := range accentBases {
+		for _, r := range accented {
+			if got := FoldAccent(r); got != base {
+				t.Errorf("FoldAccent(%c) = %c, want %c", r, got, base)
+			}
+			if lower := unicode.ToLower(r); lower != r && FoldAccent(lower) != base {
+				t.Errorf("FoldAccent(%c) = %c, want %c", lower, FoldAccent(lower), base)
+			}
+		}
+	}
+}
@@ -23,6 +23,16 @@ func AssembleSolution(cells []Cell) string {
 	return builder.String()
 }
 
+// SolutionMatches reports whether attempt matches answer the way the API
+// checks solutions: ignoring case, under Unicode case folding, and
+// collapsing runs of whitespace.
+func SolutionMatches(answer, attempt string) bool {
+	normalize := func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	}
+	return strings.EqualFold(normalize(answer), normalize(attempt))
+}
+
 // IsComplete checks if all letter cells have been filled in
 func IsComplete(cells []Cell) bool {
 	for _, cell := range cells {
@@ -34,29 +44,32 @@ func IsComplete(cells []Cell) bool {
 }
 
 // ClearAllInput resets all user input in regular letter cells.
-// Hint cells are left untouched.
+// Hint and locked cells are left untouched.
 func ClearAllInput(cells []Cell) {
 	for i := range cells {
-		if cells[i].Kind == CellLetter {
+		if cells[i].Kind == CellLetter && !cells[i].Locked {
 			cells[i].Input = 0
 		}
 	}
 }
 
 // SetInput sets the user input for a specific cell index and propagates
-// to all cells with the same cipher character.
-// Returns false if the index is out of bounds or the cell is not a letter.
+// to all cells with the same cipher character, ignoring case. The input is
+// stored normalized (see NormalizeLetter).
+// Returns false if the index is out of bounds, the cell is not a letter, or
+// it is locked.
 func SetInput(cells []Cell, index int, input rune) bool {
 	if index < 0 || index >= len(cells) {
 		return false
 	}
-	if cells[index].Kind != CellLetter {
+	if cells[index].Kind != CellLetter || cells[index].Locked {
 		return false
 	}
 
-	cipherChar := cells[index].Char
+	input = NormalizeLetter(input)
+	cipherChar := NormalizeLetter(cells[index].Char)
 	for i := range cells {
-		if cells[i].Kind == CellLetter && cells[i].Char == cipherChar {
+		if cells[i].Kind == CellLetter && NormalizeLetter(cells[i].Char) == cipherChar {
 			cells[i].Input = input
 		}
 	}
@@ -65,7 +78,99 @@ func SetInput(cells []Cell, index int, input rune) bool {
 
 // ClearInput clears the user input for a specific cell index and propagates
 // to all cells with the same cipher character.
-// Returns false if the index is out of bounds or the cell is not a letter.
+// Returns false if the index is out of bounds, the cell is not a letter, or
+// it is locked.
 func ClearInput(cells []Cell, index int) bool {
 	return SetInput(cells, index, 0)
 }
+
+// ClearPlaintext clears the plaintext letter from every regular letter cell
+// it was entered in, whatever their cipher letters, matching it ignoring
+// case. Hint and locked cells are left untouched. Returns how many cipher
+// letters lost it.
+func ClearPlaintext(cells []Cell, letter rune) int {
+	letter = NormalizeLetter(letter)
+	cleared := make(map[rune]bool)
+	for i := range cells {
+		if cells[i].Kind == CellLetter && !cells[i].Locked && cells[i].Input != 0 && cells[i].Input == letter {
+			cells[i].Input = 0
+			cleared[NormalizeLetter(cells[i].Char)] = true
+		}
+	}
+	return len(cleared)
+}
+
+// SwapInputs swaps the plaintext letters entered for two cipher letters,
+// matched ignoring case, in every cell of each. An empty one swaps as empty,
+// so a letter can move to a cipher letter without one. Swapping again undoes
+// it. Returns false without changing any cell if either cipher letter has no
+// regular letter cell or is locked, if they are the same letter, or if
+// neither has an input.
+func SwapInputs(cells []Cell, a, b rune) bool {
+	a, b = NormalizeLetter(a), NormalizeLetter(b)
+	if a == b {
+		return false
+	}
+	ia, ib := -1, -1
+	for i := range cells {
+		if cells[i].Kind != CellLetter {
+			continue
+		}
+		switch NormalizeLetter(cells[i].Char) {
+		case a:
+			ia = i
+		case b:
+			ib = i
+		}
+	}
+	if ia < 0 || ib < 0 || cells[ia].Locked || cells[ib].Locked {
+		return false
+	}
+	inputA, inputB := cells[ia].Input, cells[ib].Input
+	if inputA == 0 && inputB == 0 {
+		return false
+	}
+
+	SetInput(cells, ia, inputB)
+	SetInput(cells, ib, inputA)
+	return true
+}
+
+// SetLocked locks or unlocks the letter at a specific cell index, and every
+// cell with the same cipher character. Only a filled-in letter can be locked;
+// unlocking always works. Returns false if the index is out of bounds, the
+// cell is not a letter, or locking an empty one.
+func SetLocked(cells []Cell, index int, locked bool) bool {
+	if index < 0 || index >= len(cells) {
+		return false
+	}
+	if cells[index].Kind != CellLetter || (locked && cells[index].Input == 0) {
+		return false
+	}
+
+	cipherChar := NormalizeLetter(cells[index].Char)
+	for i := range cells {
+		if cells[i].Kind == CellLetter && NormalizeLetter(cells[i].Char) == cipherChar {
+			cells[i].Locked = locked
+		}
+	}
+	return true
+}
+
+// RevealSolution fills every letter cell with its plaintext letter from the
+// solution, which lines up character for character with the cells.
+// Hint cells are left untouched. Returns false without changing any cell if
+// the solution does not match the cells' length.
+func RevealSolution(cells []Cell, solution string) bool {
+	plain := []rune(solution)
+	if len(plain) != len(cells) {
+		return false
+	}
+
+	for i := range cells {
+		if cells[i].Kind == CellLetter {
+			cells[i].Input = NormalizeLetter(plain[i])
+		}
+	}
+	return true
+}
@@ -148,6 +148,30 @@ func TestSetInput(t *testing.T) {
 	}
 }
 
+func TestSetInputUnicode(t *testing.T) {
+	// Accented input is stored upper case and fills every cell with the same
+	// cipher letter, whatever its case
+	cells := BuildCells("Éé, ÖA", nil)
+	if !SetInput(cells, 1, 'ç') {
+		t.Fatal("SetInput returned false for a letter cell")
+	}
+	for _, i := range []int{0, 1} {
+		if cells[i].Input != 'Ç' {
+			t.Errorf("cell %d Input = %c, want Ç", i, cells[i].Input)
+		}
+	}
+	if cells[4].Input != 0 {
+		t.Errorf("cell 4 Input = %c, want it untouched", cells[4].Input)
+	}
+
+	if !SetInput(cells, 4, 'ü') || AssembleSolution(cells) != "ÇÇ, Ü_" {
+		t.Errorf("AssembleSolution = %q, want %q", AssembleSolution(cells), "ÇÇ, Ü_")
+	}
+	if !RevealSolution(cells, "çç, üß") || cells[5].Input != 'ß' {
+		t.Errorf("RevealSolution left cell 5 = %c, want ß", cells[5].Input)
+	}
+}
+
 func TestClearInputRejectsHintCell(t *testing.T) {
 	hints := map[rune]rune{'A': 'X'}
 	cells := BuildCells("AB", hints)
@@ -226,3 +250,164 @@ func TestIsCompleteWithHints(t *testing.T) {
 		t.Error("expected complete when all cells filled (hint + regular)")
 	}
 }
+
+func TestRevealSolution(t *testing.T) {
+	cells := BuildCells("AB, CA", map[rune]rune{'C': 'T'})
+	cells[0].Input = 'Q'
+
+	if !RevealSolution(cells, "ho, th") {
+		t.Fatal("RevealSolution returned false for a matching solution")
+	}
+	if got := AssembleSolution(cells); got != "HO, TH" {
+		t.Errorf("AssembleSolution after reveal = %q, expected %q", got, "HO, TH")
+	}
+	if cells[4].Input != 'T' {
+		t.Errorf("hint cell input = %q, expected it untouched", cells[4].Input)
+	}
+}
+
+func TestRevealSolutionRejectsMismatchedLength(t *testing.T) {
+	cells := BuildCells("ABC", nil)
+
+	if RevealSolution(cells, "HI") {
+		t.Error("RevealSolution returned true for a solution of the wrong length")
+	}
+	for _, cell := range cells {
+		if cell.Input != 0 {
+			t.Errorf("cell %d input = %q, expected it unchanged", cell.Index, cell.Input)
+		}
+	}
+}
+
+func TestSolutionMatches(t *testing.T) {
+	tests := []struct {
+		answer  string
+		attempt string
+		want    bool
+	}{
+		{"Hello, world!", "HELLO, WORLD!", true},
+		{"Hello,  world!", "hello, world! ", true},
+		{"Hello, world!", "HELLO WORLD", false},
+		{"Hello, world!", "HELLO, WORLD?", false},
+		{"Ça va, garçon?", "ÇA VA, GARÇON?", true},
+		{"Жизнь прекрасна", "ЖИЗНЬ  ПРЕКРАСНА", true},
+		{"Ça va", "CA VA", false},
+	}
+
+	for _, tt := range tests {
+		if got := SolutionMatches(tt.answer, tt.attempt); got != tt.want {
+			t.Errorf("SolutionMatches(%q, %q) = %v, want %v", tt.answer, tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestSetLocked(t *testing.T) {
+	cells := BuildCells("ABA", nil)
+
+	if SetLocked(cells, 0, true) {
+		t.Error("SetLocked should not lock an empty letter")
+	}
+
+	SetInput(cells, 0, 'T')
+	if !SetLocked(cells, 0, true) {
+		t.Fatal("SetLocked returned false for a filled-in letter")
+	}
+	if !cells[0].Locked || !cells[2].Locked || cells[1].Locked {
+		t.Errorf("locked = %v, %v, %v; want every A locked and B not", cells[0].Locked, cells[1].Locked, cells[2].Locked)
+	}
+
+	// Overwrites and clears are rejected until unlocked
+	if SetInput(cells, 2, 'X') || ClearInput(cells, 0) {
+		t.Error("SetInput and ClearInput should return false for a locked letter")
+	}
+	SetInput(cells, 1, 'O')
+	ClearAllInput(cells)
+	if cells[0].Input != 'T' || cells[2].Input != 'T' || cells[1].Input != 0 {
+		t.Errorf("after ClearAllInput: %q, want the locked Ts kept", AssembleSolution(cells))
+	}
+
+	if !SetLocked(cells, 2, false) || cells[0].Locked {
+		t.Fatal("SetLocked should unlock every A")
+	}
+	if !ClearInput(cells, 0) || cells[2].Input != 0 {
+		t.Error("an unlocked letter should clear again")
+	}
+}
+
+func TestSetLockedRejectsHintCell(t *testing.T) {
+	cells := BuildCells("AB", map[rune]rune{'A': 'X'})
+	if SetLocked(cells, 0, true) || cells[0].Locked {
+		t.Error("SetLocked should return false for a hint cell, which is fixed already")
+	}
+}
+
+func TestClearPlaintext(t *testing.T) {
+	cells := BuildCells("ABCaD", map[rune]rune{'D': 'E'})
+	SetInput(cells, 0, 'E') // A and a
+	SetInput(cells, 1, 'E')
+	SetInput(cells, 2, 'E')
+	SetLocked(cells, 2, true)
+
+	if got := ClearPlaintext(cells, 'e'); got != 2 {
+		t.Errorf("ClearPlaintext = %d, want 2 cipher letters cleared", got)
+	}
+	if got := AssembleSolution(cells); got != "__E_E" {
+		t.Errorf("AssembleSolution = %q, want the locked C and the hint D kept", got)
+	}
+	if got := ClearPlaintext(cells, 'Q'); got != 0 {
+		t.Errorf("ClearPlaintext(Q) = %d, want 0 when no cell has it", got)
+	}
+	if got := ClearPlaintext(cells, 0); got != 0 {
+		t.Errorf("ClearPlaintext(0) = %d, want empty cells left alone", got)
+	}
+}
+
+func TestSwapInputs(t *testing.T) {
+	cells := BuildCells("ABaC", nil)
+	SetInput(cells, 0, 'T')
+	SetInput(cells, 1, 'E')
+
+	if !SwapInputs(cells, 'b', 'A') {
+		t.Fatal("SwapInputs returned false for two filled-in letters")
+	}
+	if got := AssembleSolution(cells); got != "ETE_" {
+		t.Errorf("after swap: %q, want ETE_", got)
+	}
+	if !SwapInputs(cells, 'A', 'B') || AssembleSolution(cells) != "TET_" {
+		t.Errorf("swapping again should undo it, got %q", AssembleSolution(cells))
+	}
+
+	// An empty letter swaps as empty
+	if !SwapInputs(cells, 'A', 'C') || AssembleSolution(cells) != "_E_T" {
+		t.Errorf("swap with an empty letter: %q, want _E_T", AssembleSolution(cells))
+	}
+}
+
+func TestSwapInputsRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b rune
+	}{
+		{"same letter", 'A', 'a'},
+		{"letter not in puzzle", 'A', 'Z'},
+		{"hint letter", 'A', 'H'},
+		{"locked letter", 'A', 'B'},
+		{"both empty", 'C', 'D'},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cells := BuildCells("ABCDH", map[rune]rune{'H': 'O'})
+			SetInput(cells, 0, 'T')
+			SetInput(cells, 1, 'E')
+			SetLocked(cells, 1, true)
+			before := AssembleSolution(cells)
+
+			if SwapInputs(cells, tt.a, tt.b) {
+				t.Errorf("SwapInputs(%c, %c) = true, want false", tt.a, tt.b)
+			}
+			if got := AssembleSolution(cells); got != before {
+				t.Errorf("cells changed to %q, want %q", got, before)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package puzzle
+
+// alphabet is the plaintext alphabet ciphers are drawn from.
+const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Suggestion is a letter the board leaves no choice about: every cell of
+// cipher letter Cipher can only be Plain.
+type Suggestion struct {
+	Cipher rune
+	Plain  rune
+}
+
+// Suggest returns the unfilled cipher letters that only one plaintext letter
+// can fill, in the order they first appear. A cipher letter's candidates are
+// the plaintext letters no other cell holds, except the cipher letter itself,
+// since puzzle ciphers never map a letter to itself. So the last unfilled
+// cipher letter is suggested once one plaintext letter is left over.
+//
+// Suggestions follow from the letters already on the board, right or wrong;
+// they are only as good as the player's other guesses. Cells outside A-Z are
+// not suggested.
+func Suggest(cells []Cell) []Suggestion {
+	used := make(map[rune]bool)
+	var unfilled []rune
+	seen := make(map[rune]bool)
+	for _, cell := range cells {
+		switch {
+		case cell.Kind == CellPunctuation:
+		case cell.Input != 0:
+			used[cell.Input] = true
+		case cell.Kind == CellLetter && !seen[NormalizeLetter(cell.Char)]:
+			seen[NormalizeLetter(cell.Char)] = true
+			unfilled = append(unfilled, NormalizeLetter(cell.Char))
+		}
+	}
+
+	var free []rune
+	for _, letter := range alphabet {
+		if !used[letter] {
+			free = append(free, letter)
+		}
+	}
+
+	var suggestions []Suggestion
+	for _, cipher := range unfilled {
+		if cipher < 'A' || cipher > 'Z' {
+			continue
+		}
+		var candidates []rune
+		for _, letter := range free {
+			if letter != cipher {
+				candidates = append(candidates, letter)
+			}
+		}
+		if len(candidates) == 1 {
+			suggestions = append(suggestions, Suggestion{Cipher: cipher, Plain: candidates[0]})
+		}
+	}
+	return suggestions
+}
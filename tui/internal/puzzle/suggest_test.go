@@ -0,0 +1,79 @@
+package puzzle
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// suggestBoard returns cells holding every plaintext letter except free,
+// followed by an unfilled cell for each of the cipher letters unfilled.
+func suggestBoard(free, unfilled string) []Cell {
+	var cells []Cell
+	for _, letter := range alphabet {
+		if !strings.ContainsRune(free, letter) {
+			cells = append(cells, Cell{Index: len(cells), Char: '#', Input: letter, Kind: CellLetter})
+		}
+	}
+	cells = append(cells, Cell{Index: len(cells), Char: ' ', Kind: CellPunctuation})
+	for _, cipher := range unfilled {
+		cells = append(cells, Cell{Index: len(cells), Char: cipher, Kind: CellLetter})
+	}
+	return cells
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells []Cell
+		want  []Suggestion
+	}{
+		{
+			name:  "last letter left",
+			cells: suggestBoard("Z", "A"),
+			want:  []Suggestion{{Cipher: 'A', Plain: 'Z'}},
+		},
+		{
+			name:  "cipher letter never maps to itself",
+			cells: suggestBoard("QX", "X"),
+			want:  []Suggestion{{Cipher: 'X', Plain: 'Q'}},
+		},
+		{
+			name:  "each other's only candidate",
+			cells: suggestBoard("XY", "YXXY"),
+			want:  []Suggestion{{Cipher: 'Y', Plain: 'X'}, {Cipher: 'X', Plain: 'Y'}},
+		},
+		{
+			name:  "two letters for two cipher letters",
+			cells: suggestBoard("PQ", "AB"),
+			want:  nil,
+		},
+		{
+			name:  "empty board",
+			cells: BuildCells("AB, BA", nil),
+			want:  nil,
+		},
+		{
+			name:  "clues count as used",
+			cells: append(suggestBoard("QZ", "A"), BuildCells("B", map[rune]rune{'B': 'Q'})...),
+			want:  []Suggestion{{Cipher: 'A', Plain: 'Z'}},
+		},
+		{
+			name:  "only one candidate but nothing left to fill",
+			cells: suggestBoard("Z", ""),
+			want:  nil,
+		},
+		{
+			name:  "cipher letters outside A-Z",
+			cells: suggestBoard("Z", "Ž"),
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Suggest(tt.cells); !slices.Equal(got, tt.want) {
+				t.Errorf("Suggest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package puzzle
+
+import "slices"
+
+// maxUndo is how many edits an UndoStack remembers; older ones are dropped.
+const maxUndo = 100
+
+// UndoStack remembers the grid's inputs from before each edit, so edits can
+// be taken back one at a time, newest first. The zero value is empty and
+// ready to use.
+type UndoStack struct {
+	entries [][]rune // every cell's Input before an edit, oldest first
+}
+
+// Push remembers the cells' inputs as they are, before an edit changes them.
+// Nothing is pushed when they match the newest entry.
+func (u *UndoStack) Push(cells []Cell) {
+	inputs := make([]rune, len(cells))
+	for i, c := range cells {
+		inputs[i] = c.Input
+	}
+	if n := len(u.entries); n > 0 && slices.Equal(u.entries[n-1], inputs) {
+		return
+	}
+	if len(u.entries) == maxUndo {
+		u.entries = u.entries[1:]
+	}
+	u.entries = append(u.entries, inputs)
+}
+
+// Empty reports whether there is no edit to take back.
+func (u *UndoStack) Empty() bool {
+	return len(u.entries) == 0
+}
+
+// Undo puts back the inputs from before the newest edit that changed any,
+// dropping entries for edits that didn't, such as one rejected by a lock.
+// Locked and hint cells keep their input. Returns false, changing nothing,
+// when there is no edit to take back.
+func (u *UndoStack) Undo(cells []Cell) bool {
+	for len(u.entries) > 0 {
+		inputs := u.entries[len(u.entries)-1]
+		u.entries = u.entries[:len(u.entries)-1]
+		if len(inputs) != len(cells) {
+			continue
+		}
+
+		changed := false
+		for i := range cells {
+			if cells[i].Kind == CellLetter && !cells[i].Locked && cells[i].Input != inputs[i] {
+				cells[i].Input = inputs[i]
+				changed = true
+			}
+		}
+		if changed {
+			return true
+		}
+	}
+	return false
+}
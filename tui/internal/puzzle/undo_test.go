@@ -0,0 +1,70 @@
+package puzzle
+
+import "testing"
+
+func TestUndoStack(t *testing.T) {
+	cells := BuildCells("ABaC", nil)
+	var undo UndoStack
+
+	undo.Push(cells)
+	SetInput(cells, 0, 'T')
+	undo.Push(cells)
+	SetInput(cells, 1, 'E')
+	undo.Push(cells)
+	SwapInputs(cells, 'A', 'B')
+	if got := AssembleSolution(cells); got != "ETE_" {
+		t.Fatalf("after edits: %q, want ETE_", got)
+	}
+
+	for _, want := range []string{"TET_", "T_T_", "____"} {
+		if !undo.Undo(cells) {
+			t.Fatalf("Undo returned false, want %q back", want)
+		}
+		if got := AssembleSolution(cells); got != want {
+			t.Errorf("after undo: %q, want %q", got, want)
+		}
+	}
+	if undo.Undo(cells) {
+		t.Error("Undo with nothing left returned true")
+	}
+}
+
+// Entries for edits that changed nothing are skipped, and locked letters
+// keep their input.
+func TestUndoStack_SkipsNoOpsAndLocks(t *testing.T) {
+	cells := BuildCells("AB", nil)
+	var undo UndoStack
+
+	undo.Push(cells)
+	SetInput(cells, 0, 'T')
+	SetInput(cells, 1, 'E')
+	SetLocked(cells, 0, true)
+	undo.Push(cells)
+	undo.Push(cells) // a rejected edit: nothing changed
+
+	if !undo.Undo(cells) {
+		t.Fatal("Undo returned false, want the first edit taken back")
+	}
+	if got := AssembleSolution(cells); got != "T_" {
+		t.Errorf("after undo: %q, want the locked T kept and E cleared", got)
+	}
+	if undo.Undo(cells) {
+		t.Error("Undo with nothing left returned true")
+	}
+}
+
+func TestUndoStack_DropsOldest(t *testing.T) {
+	cells := BuildCells("A", nil)
+	var undo UndoStack
+	for i := range maxUndo + 10 {
+		undo.Push(cells)
+		SetInput(cells, 0, rune('A'+i%26))
+	}
+	undone := 0
+	for undo.Undo(cells) {
+		undone++
+	}
+	if undone != maxUndo {
+		t.Errorf("undid %d edits, want the newest %d", undone, maxUndo)
+	}
+}
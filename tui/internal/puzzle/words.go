@@ -0,0 +1,152 @@
+package puzzle
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WordIndex groups dictionary words by their pattern of repeated letters,
+// for SuggestWords. It is read-only once built, so one index can be shared.
+type WordIndex struct {
+	byPattern map[string][]string
+}
+
+// NewWordIndex indexes words, which are upper case and ordered most common
+// first; suggestions keep that order. Words with anything but A-Z are
+// skipped.
+func NewWordIndex(words []string) *WordIndex {
+	index := &WordIndex{byPattern: make(map[string][]string)}
+	for _, word := range words {
+		if word == "" || strings.IndexFunc(word, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0 {
+			continue
+		}
+		pattern := letterPattern([]rune(word))
+		index.byPattern[pattern] = append(index.byPattern[pattern], word)
+	}
+	return index
+}
+
+// letterPattern returns the pattern of repeated letters in word: each letter
+// replaced by the order it first appears in, so THAT and HIGH are both
+// "ABCA".
+func letterPattern(word []rune) string {
+	order := make(map[rune]rune)
+	var b strings.Builder
+	for _, r := range word {
+		if _, ok := order[r]; !ok {
+			order[r] = 'A' + rune(len(order))
+		}
+		b.WriteRune(order[r])
+	}
+	return b.String()
+}
+
+// WordSuggestion is a word of the puzzle not yet filled in, with the
+// dictionary words that fit it.
+type WordSuggestion struct {
+	Start  int      // index of the word's first cell
+	End    int      // index one past its last cell
+	Cipher string   // the word's cipher letters
+	Words  []string // words that fit, most common first
+}
+
+// SuggestWords returns, for each word of the puzzle with empty cells, the
+// words from index that fit it: the same length and pattern of repeated
+// letters as its cipher letters, agreeing with the letters already filled
+// in, and putting in empty cells only letters no other cipher letter holds,
+// never the cipher letter itself. Puzzle words with punctuation inside (such
+// as an apostrophe) or letters outside A-Z, and those nothing fits, are left
+// out.
+func SuggestWords(cells []Cell, index *WordIndex) []WordSuggestion {
+	if index == nil {
+		return nil
+	}
+
+	taken := make(map[rune]bool)
+	for _, cell := range cells {
+		if cell.Kind != CellPunctuation && cell.Input != 0 {
+			taken[cell.Input] = true
+		}
+	}
+
+	var suggestions []WordSuggestion
+	for _, span := range wordSpans(cells) {
+		word := cells[span[0]:span[1]]
+		cipher, ok := cipherWord(word)
+		if !ok {
+			continue
+		}
+		var fits []string
+		for _, candidate := range index.byPattern[letterPattern([]rune(cipher))] {
+			if wordFits(word, candidate, taken) {
+				fits = append(fits, candidate)
+			}
+		}
+		if len(fits) > 0 {
+			suggestions = append(suggestions, WordSuggestion{Start: span[0], End: span[1], Cipher: cipher, Words: fits})
+		}
+	}
+	return suggestions
+}
+
+// wordSpans returns the [start, end) cell ranges of the puzzle's words:
+// runs of cells between spaces, without the punctuation at either end.
+func wordSpans(cells []Cell) [][2]int {
+	var spans [][2]int
+	start := -1
+	for i := 0; i <= len(cells); i++ {
+		if i < len(cells) && !unicode.IsSpace(cells[i].Char) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			end := i
+			for start < end && cells[start].Kind == CellPunctuation {
+				start++
+			}
+			for end > start && cells[end-1].Kind == CellPunctuation {
+				end--
+			}
+			if start < end {
+				spans = append(spans, [2]int{start, end})
+			}
+			start = -1
+		}
+	}
+	return spans
+}
+
+// cipherWord returns the cipher letters of word, reporting false when it has
+// punctuation, letters outside A-Z, or no empty cell left to suggest for.
+func cipherWord(word []Cell) (string, bool) {
+	var b strings.Builder
+	empty := false
+	for _, cell := range word {
+		c := NormalizeLetter(cell.Char)
+		if cell.Kind == CellPunctuation || c < 'A' || c > 'Z' {
+			return "", false
+		}
+		empty = empty || cell.Input == 0
+		b.WriteRune(c)
+	}
+	return b.String(), empty
+}
+
+// wordFits reports whether candidate, which has the word's pattern, agrees
+// with its filled cells and puts only free letters in its empty ones.
+func wordFits(word []Cell, candidate string, taken map[rune]bool) bool {
+	for i, letter := range candidate {
+		cell := word[i]
+		switch {
+		case cell.Input != 0:
+			if cell.Input != letter {
+				return false
+			}
+		case taken[letter], letter == NormalizeLetter(cell.Char):
+			return false
+		}
+	}
+	return true
+}
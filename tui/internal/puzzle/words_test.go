@@ -0,0 +1,89 @@
+package puzzle
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestLetterPattern(t *testing.T) {
+	for word, want := range map[string]string{"THAT": "ABCA", "HIGH": "ABCA", "TREE": "ABCC", "A": "A", "": ""} {
+		if got := letterPattern([]rune(word)); got != want {
+			t.Errorf("letterPattern(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestSuggestWords(t *testing.T) {
+	index := NewWordIndex([]string{"THAT", "HIGH", "THE", "AND", "SEE", "TREE", "it's", "ÉTÉ"})
+
+	tests := []struct {
+		name   string
+		text   string
+		inputs map[rune]rune // cipher letter → input
+		hints  map[rune]rune
+		want   map[string][]string // cipher word → suggested words
+	}{
+		{
+			name: "pattern of repeats",
+			text: "QWEQ RTY UII",
+			want: map[string][]string{"QWEQ": {"THAT", "HIGH"}, "RTY": {"THE", "AND"}, "UII": {"SEE"}},
+		},
+		{
+			name:   "agrees with letters filled in",
+			text:   "QWEQ RTY",
+			inputs: map[rune]rune{'Q': 'T'},
+			want:   map[string][]string{"QWEQ": {"THAT"}, "RTY": {"AND"}},
+		},
+		{
+			name:   "letters held elsewhere are out",
+			text:   "QWEQ RTY",
+			inputs: map[rune]rune{'R': 'T'},
+			want:   map[string][]string{"QWEQ": {"HIGH"}, "RTY": {"THE"}},
+		},
+		{
+			name:  "clues count",
+			text:  "QWEQ",
+			hints: map[rune]rune{'W': 'I'},
+			want:  map[string][]string{"QWEQ": {"HIGH"}},
+		},
+		{
+			name: "never a letter for itself",
+			text: "TWET",
+			want: map[string][]string{"TWET": {"HIGH"}},
+		},
+		{
+			name:   "filled words and punctuated words skipped",
+			text:   "QWE, RT'Y",
+			inputs: map[rune]rune{'Q': 'T', 'W': 'H', 'E': 'E'},
+			want:   map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cells := BuildCells(tt.text, tt.hints)
+			for i, cell := range cells {
+				if input, ok := tt.inputs[cell.Char]; ok && cell.Kind == CellLetter {
+					cells[i].Input = input
+				}
+			}
+
+			got := make(map[string][]string)
+			for _, s := range SuggestWords(cells, index) {
+				if tt.text[s.Start:s.End] != s.Cipher {
+					t.Errorf("cells %d-%d are %q, not %q", s.Start, s.End, tt.text[s.Start:s.End], s.Cipher)
+				}
+				got[s.Cipher] = s.Words
+			}
+			if !maps.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("SuggestWords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestWords_NilIndex(t *testing.T) {
+	if got := SuggestWords(BuildCells("QWEQ", nil), nil); got != nil {
+		t.Errorf("SuggestWords() = %v, want nil without an index", got)
+	}
+}
@@ -0,0 +1,57 @@
+package puzzle
+
+// WordState is how far along a word of the puzzle is.
+type WordState int
+
+const (
+	WordIncomplete WordState = iota // a letter is still empty; also cells outside any word
+	WordConflicted                  // every letter filled, but one's input is also another cipher letter's
+	WordConsistent                  // every letter filled, none in a conflict
+)
+
+// WordStates returns, indexed like cells, the state of the word each cell
+// belongs to. Words are split at spaces like ui.GroupCellsByWord does, less
+// the punctuation at either end, which stays WordIncomplete with the spaces.
+// A conflict is a player's input assigned to two or more cipher letters;
+// hint cells count as filled but never conflict.
+func WordStates(cells []Cell) []WordState {
+	conflicts := conflictingInputs(cells)
+	states := make([]WordState, len(cells))
+	for _, span := range wordSpans(cells) {
+		state := WordConsistent
+		for _, cell := range cells[span[0]:span[1]] {
+			if cell.Kind == CellPunctuation {
+				continue
+			}
+			if cell.Input == 0 {
+				state = WordIncomplete
+				break
+			}
+			if cell.Kind == CellLetter && conflicts[cell.Input] {
+				state = WordConflicted
+			}
+		}
+		for i := span[0]; i < span[1]; i++ {
+			states[i] = state
+		}
+	}
+	return states
+}
+
+// conflictingInputs returns the inputs the player assigned to more than one
+// cipher letter.
+func conflictingInputs(cells []Cell) map[rune]bool {
+	ciphers := make(map[rune]rune) // input → the first cipher letter holding it
+	conflicts := make(map[rune]bool)
+	for _, cell := range cells {
+		if cell.Kind != CellLetter || cell.Input == 0 {
+			continue
+		}
+		if cipher, ok := ciphers[cell.Input]; !ok {
+			ciphers[cell.Input] = cell.Char
+		} else if cipher != cell.Char {
+			conflicts[cell.Input] = true
+		}
+	}
+	return conflicts
+}
@@ -0,0 +1,86 @@
+package puzzle
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWordStates(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		inputs map[rune]rune // cipher letter → input
+		hints  map[rune]rune
+		want   []WordState // one per word, in order
+	}{
+		{
+			name: "nothing filled",
+			text: "AB CD",
+			want: []WordState{WordIncomplete, WordIncomplete},
+		},
+		{
+			name:   "one word filled",
+			text:   "AB CD",
+			inputs: map[rune]rune{'A': 'T', 'B': 'O'},
+			want:   []WordState{WordConsistent, WordIncomplete},
+		},
+		{
+			name:   "filled word with a conflict",
+			text:   "AB CD",
+			inputs: map[rune]rune{'A': 'T', 'B': 'O', 'C': 'T'},
+			want:   []WordState{WordConflicted, WordIncomplete},
+		},
+		{
+			name:   "hints count as filled",
+			text:   "AB CD",
+			inputs: map[rune]rune{'B': 'O'},
+			hints:  map[rune]rune{'A': 'T'},
+			want:   []WordState{WordConsistent, WordIncomplete},
+		},
+		{
+			name:   "punctuation inside and around a word",
+			text:   "\"A'B,\" CD",
+			inputs: map[rune]rune{'A': 'I', 'B': 'S'},
+			want:   []WordState{WordConsistent, WordIncomplete},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cells := BuildCells(tt.text, tt.hints)
+			for i, cell := range cells {
+				if input, ok := tt.inputs[cell.Char]; ok {
+					SetInput(cells, i, input)
+				}
+			}
+
+			states := WordStates(cells)
+			if len(states) != len(cells) {
+				t.Fatalf("WordStates() returned %d states for %d cells", len(states), len(cells))
+			}
+			var got []WordState
+			for _, span := range wordSpans(cells) {
+				for i := span[0]; i < span[1]; i++ {
+					if states[i] != states[span[0]] {
+						t.Errorf("cell %d is %v, want %v like the rest of its word", i, states[i], states[span[0]])
+					}
+				}
+				got = append(got, states[span[0]])
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("word states = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWordStates_OutsideWords(t *testing.T) {
+	cells := BuildCells("\"A B\"", nil)
+	SetInput(cells, 1, 'I')
+	SetInput(cells, 3, 'O')
+
+	want := []WordState{WordIncomplete, WordConsistent, WordIncomplete, WordConsistent, WordIncomplete}
+	if got := WordStates(cells); !slices.Equal(got, want) {
+		t.Errorf("WordStates() = %v, want %v: spaces and the quotes around words belong to none", got, want)
+	}
+}
@@ -0,0 +1,136 @@
+// Package puzzlegen turns a plaintext quote into a cryptoquip that can be
+// played and checked entirely offline.
+package puzzlegen
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"unicode"
+)
+
+// localIDPrefix marks game IDs that were generated locally and are unknown
+// to the API.
+const localIDPrefix = "local-"
+
+// Hint reveals the plaintext letter behind one cipher letter.
+type Hint struct {
+	Cipher rune
+	Plain  rune
+}
+
+// Puzzle is a locally generated cryptoquip together with its answer.
+type Puzzle struct {
+	ID            string // local-only game ID, stable for the same quote and author
+	EncryptedText string
+	Solution      string // the quote in upper case, aligned character for character with EncryptedText
+	Author        string
+	Hints         []Hint
+}
+
+// Generate enciphers quote with a random substitution cipher in which no
+// letter maps to itself, and picks up to hints cipher letters to give away.
+//
+// The cipher and hints are seeded from the quote and author, so generating
+// the same quote again yields the same puzzle and a saved session resumes.
+func Generate(quote, author string, hints int) (*Puzzle, error) {
+	solution := strings.ToUpper(strings.Join(strings.Fields(quote), " "))
+	if solution == "" {
+		return nil, errors.New("quote is empty")
+	}
+	for _, r := range solution {
+		if unicode.IsLetter(r) && (r < 'A' || r > 'Z') {
+			return nil, fmt.Errorf("quote contains %q; only the letters A-Z can be enciphered", r)
+		}
+	}
+
+	sum := sha256.Sum256([]byte(solution + "\x00" + author))
+	rng := rand.New(rand.NewPCG(binary.BigEndian.Uint64(sum[:8]), binary.BigEndian.Uint64(sum[8:16])))
+
+	key := derangement(rng)
+	encrypted := []rune(solution)
+	for i, r := range encrypted {
+		if r >= 'A' && r <= 'Z' {
+			encrypted[i] = key[r-'A']
+		}
+	}
+
+	return &Puzzle{
+		ID:            localIDPrefix + hex.EncodeToString(sum[:6]),
+		EncryptedText: string(encrypted),
+		Solution:      solution,
+		Author:        strings.TrimSpace(author),
+		Hints:         pickHints(rng, solution, key, hints),
+	}, nil
+}
+
+// IsLocalID reports whether a game ID was generated by this package.
+func IsLocalID(id string) bool {
+	return strings.HasPrefix(id, localIDPrefix)
+}
+
+// Check reports whether attempt matches the puzzle's solution, ignoring case
+// and runs of whitespace the same way the API does.
+func (p *Puzzle) Check(attempt string) bool {
+	return strings.ToUpper(strings.Join(strings.Fields(attempt), " ")) == p.Solution
+}
+
+// derangement returns a cipher key for A-Z where no letter maps to itself.
+// Sattolo's algorithm shuffles the alphabet into a single cycle, so every
+// letter moves.
+func derangement(rng *rand.Rand) [26]rune {
+	var key [26]rune
+	for i := range key {
+		key[i] = 'A' + rune(i)
+	}
+	for i := len(key) - 1; i > 0; i-- {
+		j := rng.IntN(i)
+		key[i], key[j] = key[j], key[i]
+	}
+	return key
+}
+
+// pickHints chooses up to n distinct letters of the solution to give away.
+func pickHints(rng *rand.Rand, solution string, key [26]rune, n int) []Hint {
+	if n <= 0 {
+		return nil
+	}
+
+	var letters []rune
+	seen := make(map[rune]bool)
+	for _, r := range solution {
+		if r >= 'A' && r <= 'Z' && !seen[r] {
+			seen[r] = true
+			letters = append(letters, r)
+		}
+	}
+	rng.Shuffle(len(letters), func(i, j int) {
+		letters[i], letters[j] = letters[j], letters[i]
+	})
+
+	// Always leave at least one letter to solve
+	n = min(n, len(letters)-1)
+	hints := make([]Hint, 0, max(n, 0))
+	for _, plain := range letters[:max(n, 0)] {
+		hints = append(hints, Hint{Cipher: key[plain-'A'], Plain: plain})
+	}
+	return hints
+}
+
+// ParseQuote splits the contents of a quote file into the quote and its
+// author. A last line starting with "—" or "--" names the author; everything
+// else is the quote.
+func ParseQuote(text string) (quote, author string) {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	last := strings.TrimSpace(lines[len(lines)-1])
+	for _, dash := range []string{"—", "--"} {
+		if len(lines) > 1 && strings.HasPrefix(last, dash) {
+			return strings.Join(lines[:len(lines)-1], "\n"), strings.TrimSpace(strings.TrimPrefix(last, dash))
+		}
+	}
+	return strings.Join(lines, "\n"), ""
+}
@@ -0,0 +1,190 @@
+package puzzlegen
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestDerangement_NoLetterMapsToItself(t *testing.T) {
+	for seed := range uint64(200) {
+		key := derangement(rand.New(rand.NewPCG(seed, seed)))
+
+		used := make(map[rune]bool)
+		for i, c := range key {
+			if c == 'A'+rune(i) {
+				t.Fatalf("seed %d: %c maps to itself", seed, c)
+			}
+			if used[c] {
+				t.Fatalf("seed %d: %c used twice", seed, c)
+			}
+			used[c] = true
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	p, err := Generate("  Hello,   world! ", "Someone", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p.Solution != "HELLO, WORLD!" {
+		t.Errorf("Solution = %q, want %q", p.Solution, "HELLO, WORLD!")
+	}
+	if !IsLocalID(p.ID) {
+		t.Errorf("ID %q should be a local ID", p.ID)
+	}
+
+	plain := []rune(p.Solution)
+	cipher := []rune(p.EncryptedText)
+	if len(plain) != len(cipher) {
+		t.Fatalf("EncryptedText %q does not line up with the solution", p.EncryptedText)
+	}
+
+	forward := make(map[rune]rune)
+	backward := make(map[rune]rune)
+	for i := range plain {
+		if plain[i] < 'A' || plain[i] > 'Z' {
+			if cipher[i] != plain[i] {
+				t.Errorf("non-letter %q was enciphered to %q", plain[i], cipher[i])
+			}
+			continue
+		}
+		if cipher[i] == plain[i] {
+			t.Errorf("%c maps to itself", plain[i])
+		}
+		if c, ok := forward[plain[i]]; ok && c != cipher[i] {
+			t.Errorf("%c enciphered as both %c and %c", plain[i], c, cipher[i])
+		}
+		if p, ok := backward[cipher[i]]; ok && p != plain[i] {
+			t.Errorf("%c deciphers to both %c and %c", cipher[i], p, plain[i])
+		}
+		forward[plain[i]] = cipher[i]
+		backward[cipher[i]] = plain[i]
+	}
+}
+
+func TestGenerate_IsStableForSameQuote(t *testing.T) {
+	a, _ := Generate("The quick brown fox", "Anon", 2)
+	b, _ := Generate("The quick brown fox", "Anon", 2)
+	if a.ID != b.ID || a.EncryptedText != b.EncryptedText {
+		t.Errorf("same quote generated %q/%q and %q/%q", a.ID, a.EncryptedText, b.ID, b.EncryptedText)
+	}
+
+	c, _ := Generate("The quick brown fox", "Someone else", 2)
+	if c.ID == a.ID {
+		t.Error("a different author should give a different game ID")
+	}
+}
+
+func TestGenerate_Hints(t *testing.T) {
+	p, err := Generate("The quick brown fox", "Anon", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Hints) != 3 {
+		t.Fatalf("got %d hints, want 3", len(p.Hints))
+	}
+
+	for _, hint := range p.Hints {
+		var found bool
+		for i, r := range []rune(p.EncryptedText) {
+			if r == hint.Cipher {
+				found = true
+				if got := []rune(p.Solution)[i]; got != hint.Plain {
+					t.Errorf("hint %c = %c, but the solution has %c", hint.Cipher, hint.Plain, got)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("hint cipher letter %c does not appear in the puzzle", hint.Cipher)
+		}
+	}
+
+	// Hints never give away every letter
+	p, _ = Generate("AAB", "", 5)
+	if len(p.Hints) != 1 {
+		t.Errorf("got %d hints for a two-letter quote, want 1", len(p.Hints))
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		quote string
+	}{
+		{name: "empty", quote: "   \n "},
+		{name: "letters outside A-Z", quote: "Café au lait"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Generate(tt.quote, "", 0); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	p, _ := Generate("Hello, world!", "", 0)
+
+	tests := []struct {
+		attempt string
+		want    bool
+	}{
+		{attempt: "HELLO, WORLD!", want: true},
+		{attempt: "hello,  world!", want: true},
+		{attempt: "HELLO, WORLD", want: false},
+		{attempt: "JELLO, WORLD!", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := p.Check(tt.attempt); got != tt.want {
+			t.Errorf("Check(%q) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestParseQuote(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		wantQuote  string
+		wantAuthor string
+	}{
+		{
+			name:       "em dash author",
+			text:       "Be yourself.\n— Oscar Wilde\n",
+			wantQuote:  "Be yourself.",
+			wantAuthor: "Oscar Wilde",
+		},
+		{
+			name:       "double hyphen author",
+			text:       "Line one\nline two\n-- Anon",
+			wantQuote:  "Line one\nline two",
+			wantAuthor: "Anon",
+		},
+		{
+			name:       "no author",
+			text:       "Just a quote\n",
+			wantQuote:  "Just a quote",
+			wantAuthor: "",
+		},
+		{
+			name:       "single dash line is the quote",
+			text:       "-- not an author",
+			wantQuote:  "-- not an author",
+			wantAuthor: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, author := ParseQuote(tt.text)
+			if quote != tt.wantQuote || author != tt.wantAuthor {
+				t.Errorf("ParseQuote() = %q, %q; want %q, %q", quote, author, tt.wantQuote, tt.wantAuthor)
+			}
+		})
+	}
+}
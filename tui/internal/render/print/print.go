@@ -0,0 +1,262 @@
+// Package print renders a puzzle for solving on paper: the cipher text with a
+// blank over each letter to write the answer on, the clues and the
+// difficulty. It writes plain text, Markdown, or HTML ready to print or save
+// as a PDF from a browser. The grid is built and wrapped by word with the
+// same cell logic as the TUI's.
+package print
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// Format is how a printed puzzle is written.
+type Format string
+
+const (
+	Text     Format = "text"
+	Markdown Format = "markdown"
+	HTML     Format = "html" // a standalone page that prints on its own
+)
+
+// Formats lists every format, for flag help.
+var Formats = []Format{Text, Markdown, HTML}
+
+// ParseFormat returns the format named s, accepting "md" for Markdown.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case Text, Markdown, HTML:
+		return f, nil
+	case "md":
+		return Markdown, nil
+	default:
+		return "", fmt.Errorf("unknown format %q: expected text, markdown or html", s)
+	}
+}
+
+const (
+	// DefaultWidth is the columns a text or Markdown grid wraps at, which
+	// fits a printed page in a monospace font.
+	DefaultWidth = 60
+	// cellWidth is the columns one letter takes in a text grid: the letter
+	// and a space, so the blanks don't run together.
+	cellWidth = 2
+)
+
+// sheet is a puzzle laid out for paper, shared by every format.
+type sheet struct {
+	title   string
+	author  string
+	details string // difficulty and category
+	clues   []string
+	cells   []puzzle.Cell
+}
+
+func newSheet(p *api.Puzzle) sheet {
+	hints := make(map[rune]rune, len(p.Hints))
+	clues := make([]string, 0, len(p.Hints))
+	for _, h := range p.Hints {
+		if cipher, plain, ok := h.Letters(); ok {
+			hints[cipher] = plain
+			clues = append(clues, fmt.Sprintf("%c = %c", puzzle.NormalizeLetter(cipher), puzzle.NormalizeLetter(plain)))
+		}
+	}
+
+	title := "Unquote"
+	switch {
+	case p.Date != "":
+		title += " · " + p.Date
+	case p.ID != "":
+		title += " · " + p.ID
+	}
+
+	details := fmt.Sprintf("Difficulty: %s (%d)", puzzle.DifficultyText(p.Difficulty), p.Difficulty)
+	if p.Category != "" {
+		details += " · Category: " + p.Category
+	}
+
+	return sheet{
+		title:   title,
+		author:  p.Author,
+		details: details,
+		clues:   clues,
+		cells:   puzzle.BuildCells(p.EncryptedText, hints),
+	}
+}
+
+// Render writes p to w in format f. Text and Markdown grids wrap at width
+// columns (DefaultWidth when width is 0 or less); HTML leaves wrapping to the
+// browser.
+func Render(w io.Writer, p *api.Puzzle, f Format, width int) error {
+	if width <= 0 {
+		width = DefaultWidth
+	}
+	s := newSheet(p)
+	switch f {
+	case Text:
+		return s.writeText(w, width)
+	case Markdown:
+		return s.writeMarkdown(w, width)
+	case HTML:
+		return s.writeHTML(w)
+	default:
+		return fmt.Errorf("unknown format %q", f)
+	}
+}
+
+// answerMark is what a cell shows on the answer row: a blank to fill in, a
+// clue's letter, or the punctuation itself.
+func answerMark(cell puzzle.Cell) string {
+	switch {
+	case cell.Kind == puzzle.CellPunctuation:
+		return string(cell.Char)
+	case cell.Input != 0:
+		return string(cell.Input)
+	default:
+		return "_"
+	}
+}
+
+// cipherMark is what a cell shows on the cipher row, under its answer.
+// Punctuation is already on the answer row.
+func cipherMark(cell puzzle.Cell) string {
+	if cell.Kind == puzzle.CellPunctuation {
+		return " "
+	}
+	return string(puzzle.NormalizeLetter(cell.Char))
+}
+
+// grid renders the cells as pairs of answer and cipher rows, wrapped by word
+// at width columns, with a blank line between pairs.
+func (s sheet) grid(width int) string {
+	wrapped := ui.WrapWordGroups(ui.GroupCellsByWord(s.cells), width, cellWidth)
+
+	pairs := make([]string, 0, len(wrapped))
+	for _, line := range wrapped {
+		var answers, ciphers strings.Builder
+		for _, cell := range ui.FlattenLine(line) {
+			answers.WriteString(answerMark(cell) + " ")
+			ciphers.WriteString(cipherMark(cell) + " ")
+		}
+		pairs = append(pairs, strings.TrimRight(answers.String(), " ")+"\n"+strings.TrimRight(ciphers.String(), " "))
+	}
+	return strings.Join(pairs, "\n\n")
+}
+
+func (s sheet) writeText(w io.Writer, width int) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n%s\n\n", strings.ToUpper(s.title), s.details)
+	b.WriteString(s.grid(width) + "\n")
+	if s.author != "" {
+		fmt.Fprintf(&b, "\n— %s\n", s.author)
+	}
+	if len(s.clues) > 0 {
+		fmt.Fprintf(&b, "\nClues: %s\n", strings.Join(s.clues, ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (s sheet) writeMarkdown(w io.Writer, width int) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n%s\n\n", s.title, s.details)
+	// A code block keeps the blanks lined up over their letters
+	fmt.Fprintf(&b, "```text\n%s\n```\n", s.grid(width))
+	if s.author != "" {
+		fmt.Fprintf(&b, "\n— %s\n", s.author)
+	}
+	if len(s.clues) > 0 {
+		fmt.Fprintf(&b, "\n**Clues:** %s\n", strings.Join(s.clues, ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// htmlCell is one letter or punctuation mark on the HTML page.
+type htmlCell struct {
+	Answer string // empty for a blank to fill in
+	Cipher string
+	Punct  bool
+}
+
+// htmlWords groups the cells into words, which the page keeps on one line
+// each. Spaces between words are left to the page's layout.
+func (s sheet) htmlWords() [][]htmlCell {
+	var words [][]htmlCell
+	for _, group := range ui.GroupCellsByWord(s.cells) {
+		if len(group.Cells) == 1 && group.Cells[0].Char == ' ' {
+			continue
+		}
+		word := make([]htmlCell, 0, len(group.Cells))
+		for _, cell := range group.Cells {
+			c := htmlCell{Punct: cell.Kind == puzzle.CellPunctuation}
+			if answer := answerMark(cell); answer != "_" {
+				c.Answer = answer
+			}
+			if !c.Punct {
+				c.Cipher = cipherMark(cell)
+			}
+			word = append(word, c)
+		}
+		words = append(words, word)
+	}
+	return words
+}
+
+var htmlPage = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+  @page { margin: 2cm; }
+  body { font-family: Georgia, "Times New Roman", serif; color: #000; background: #fff; max-width: 46rem; margin: 2rem auto; }
+  h1 { font-size: 1.6rem; margin: 0 0 0.3rem; }
+  .details { color: #444; margin: 0 0 2rem; }
+  .grid { font-family: "Courier New", monospace; font-size: 1.3rem; line-height: 1; }
+  .word { display: inline-block; white-space: nowrap; margin: 0 0.9em 1.4em 0; }
+  .cell { display: inline-block; width: 1.3em; text-align: center; vertical-align: top; }
+  .answer { display: block; height: 1.3em; margin: 0 0.1em; border-bottom: 1px solid #000; }
+  .punct .answer { border-bottom: none; }
+  .cipher { display: block; height: 1em; margin-top: 0.3em; font-size: 0.8em; }
+  .author { font-style: italic; margin-top: 1rem; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="details">{{.Details}}</p>
+<div class="grid">
+{{- range .Words}}
+<span class="word">
+{{- range .}}<span class="cell{{if .Punct}} punct{{end}}"><span class="answer">{{.Answer}}</span><span class="cipher">{{.Cipher}}</span></span>{{end -}}
+</span>
+{{- end}}
+</div>
+{{- if .Author}}
+<p class="author">— {{.Author}}</p>
+{{- end}}
+{{- if .Clues}}
+<p class="clues">Clues: {{.Clues}}</p>
+{{- end}}
+</body>
+</html>
+`))
+
+func (s sheet) writeHTML(w io.Writer) error {
+	return htmlPage.Execute(w, struct {
+		Title, Details, Author, Clues string
+		Words                         [][]htmlCell
+	}{
+		Title:   s.title,
+		Details: s.details,
+		Author:  s.author,
+		Clues:   strings.Join(s.clues, ", "),
+		Words:   s.htmlWords(),
+	})
+}
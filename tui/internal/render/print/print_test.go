@@ -0,0 +1,95 @@
+package print
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+)
+
+func testPuzzle() *api.Puzzle {
+	return &api.Puzzle{
+		ID:            "game-0120",
+		Date:          "2026-01-20",
+		EncryptedText: "XLI UYMGO, JSB.",
+		Author:        "Typing Practice",
+		Category:      "Wisdom",
+		Difficulty:    42,
+		Hints:         []api.Hint{{CipherLetter: "x", PlainLetter: "t"}},
+	}
+}
+
+func render(t *testing.T, f Format, width int) string {
+	t.Helper()
+	var b strings.Builder
+	if err := Render(&b, testPuzzle(), f, width); err != nil {
+		t.Fatalf("Render(%s) error: %v", f, err)
+	}
+	return b.String()
+}
+
+func TestRender_Text(t *testing.T) {
+	want := "UNQUOTE · 2026-01-20\n" +
+		"Difficulty: Medium (42) · Category: Wisdom\n\n" +
+		"T _ _   _ _ _ _ _ ,\n" +
+		"X L I   U Y M G O\n\n" +
+		"_ _ _ .\n" +
+		"J S B\n\n" +
+		"— Typing Practice\n\n" +
+		"Clues: X = T\n"
+	if got := render(t, Text, 20); got != want {
+		t.Errorf("text =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRender_Markdown(t *testing.T) {
+	got := render(t, Markdown, 0)
+	for _, want := range []string{
+		"# Unquote · 2026-01-20\n",
+		"```text\nT _ _   _ _ _ _ _ ,   _ _ _ .\nX L I   U Y M G O     J S B\n```\n",
+		"**Clues:** X = T",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRender_HTML(t *testing.T) {
+	p := testPuzzle()
+	p.Author = "<script>alert(1)</script>"
+	var b strings.Builder
+	if err := Render(&b, p, HTML, 0); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+
+	if strings.Count(got, `<span class="word">`) != 3 {
+		t.Errorf("want one span per word:\n%s", got)
+	}
+	for _, want := range []string{
+		"<title>Unquote · 2026-01-20</title>",
+		`<span class="cell"><span class="answer">T</span><span class="cipher">X</span></span>`,
+		`<span class="cell"><span class="answer"></span><span class="cipher">L</span></span>`,
+		`<span class="cell punct"><span class="answer">,</span><span class="cipher"></span></span>`,
+		"&lt;script&gt;",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("html is missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "<script>") {
+		t.Error("the author should be escaped")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"text": Text, "MD": Markdown, "markdown": Markdown, "html": HTML} {
+		if got, err := ParseFormat(in); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("pdf"); err == nil {
+		t.Error("ParseFormat(pdf) should fail")
+	}
+}
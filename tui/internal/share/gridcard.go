@@ -0,0 +1,181 @@
+package share
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"strings"
+
+	"github.com/fogleman/gg"
+	"github.com/golang/freetype/truetype"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+// The grid card shows the solved quote letter by letter, the way the game
+// draws it, with the solve time and streak. It is laid out once as a list
+// of shapes and drawn either to a PNG with gg or to an SVG by hand, so both
+// look the same.
+const (
+	gridCardWidth     = 1200
+	gridCardMinHeight = 628
+	gridCardMargin    = 48
+	gridCardCell      = 40 // width of one letter
+	gridCardLine      = 84 // height of one answer-and-cipher row pair
+	gridCardTop       = 120
+)
+
+// gridCardSurface is the card's background, the brand navy of colorSurface;
+// the theme has no background since the terminal provides it.
+var gridCardSurface = color.RGBA{R: 0x0c, G: 0x0c, B: 0x18, A: 0xff}
+
+// gridFont is a typeface on the grid card.
+type gridFont int
+
+const (
+	gridFontMono gridFont = iota
+	gridFontMonoBold
+	gridFontSerif
+)
+
+// gridShape is one rectangle or line of text on the grid card. Text sits on
+// its baseline at y, aligned on x by anchor.
+type gridShape struct {
+	fill   color.Color
+	text   string  // empty for a rectangle
+	x, y   float64 // rectangle corner, or text anchor point
+	w, h   float64 // rectangle size
+	size   float64 // font size in points
+	anchor float64 // 0 left-aligned, 0.5 centered, 1 right-aligned
+	font   gridFont
+}
+
+// layoutGridCard places everything on the grid card in theme's colors and
+// returns the card's height with the shapes, background first.
+func layoutGridCard(data SessionShareData, theme ui.Theme) (int, []gridShape) {
+	text := func(s string, x, y, size, anchor float64, font gridFont, fill color.Color) gridShape {
+		return gridShape{text: s, x: x, y: y, size: size, anchor: anchor, font: font, fill: fill}
+	}
+	rect := func(x, y, w, h float64, fill color.Color) gridShape {
+		return gridShape{x: x, y: y, w: w, h: h, fill: fill}
+	}
+
+	shapes := []gridShape{
+		text("UNQUOTE", gridCardMargin, 52, 28, 0, gridFontMonoBold, theme.Primary),
+		text(data.PuzzleNumber, gridCardWidth-gridCardMargin, 52, 16, 1, gridFontMono, theme.Muted),
+	}
+
+	perLine := (gridCardWidth - 2*gridCardMargin) / gridCardCell
+	lines := ui.WrapWordGroups(ui.GroupCellsByWord(data.Cells), perLine, 1)
+	for row, line := range lines {
+		top := float64(gridCardTop + row*gridCardLine)
+		for col, cell := range ui.FlattenLine(line) {
+			left := float64(gridCardMargin + col*gridCardCell)
+			center := left + gridCardCell/2
+			if cell.Kind == puzzle.CellPunctuation {
+				if cell.Char != ' ' {
+					shapes = append(shapes, text(string(cell.Char), center, top+32, 28, 0.5, gridFontMonoBold, theme.White))
+				}
+				continue
+			}
+			fill := theme.White
+			if cell.Kind == puzzle.CellHint {
+				fill = theme.Secondary
+			}
+			if cell.Input != 0 {
+				shapes = append(shapes, text(string(cell.Input), center, top+32, 28, 0.5, gridFontMonoBold, fill))
+			}
+			shapes = append(shapes,
+				rect(left+4, top+40, gridCardCell-8, 2, theme.Muted),
+				text(string(puzzle.NormalizeLetter(cell.Char)), center, top+64, 16, 0.5, gridFontMono, theme.Muted),
+			)
+		}
+	}
+
+	bottom := gridCardTop + len(lines)*gridCardLine + 24
+	status, statusFill := "Solved", theme.Success
+	if !data.Solved {
+		status, statusFill = "Unsolved", theme.Warning
+	}
+	if data.Solved && data.CompletionMs > 0 {
+		status += " in " + fmtMs(data.CompletionMs)
+	}
+	shapes = append(shapes, text(status, gridCardMargin, float64(bottom+40), 48, 0, gridFontSerif, statusFill))
+	if data.Streak > 0 {
+		shapes = append(shapes, text(fmt.Sprintf("%d-day streak", data.Streak), gridCardWidth-gridCardMargin, float64(bottom+40), 28, 1, gridFontMonoBold, theme.Warning))
+	}
+
+	height := max(bottom+120, gridCardMinHeight)
+	shapes = append(shapes, text("playunquote.com", gridCardWidth/2, float64(height-24), 16, 0.5, gridFontMono, theme.Muted))
+
+	background := rect(0, 0, gridCardWidth, float64(height), gridCardSurface)
+	return height, append([]gridShape{background}, shapes...)
+}
+
+// GenerateGridCard draws the solved grid, solve time and streak as a PNG-ready
+// image in theme's colors. The card is 1200 wide and grows taller than 628
+// for long quotes.
+func GenerateGridCard(data SessionShareData, theme ui.Theme) image.Image {
+	height, shapes := layoutGridCard(data, theme)
+	dc := gg.NewContext(gridCardWidth, height)
+	for _, s := range shapes {
+		dc.SetColor(s.fill)
+		if s.text == "" {
+			dc.DrawRectangle(s.x, s.y, s.w, s.h)
+			dc.Fill()
+			continue
+		}
+		font := fontSpaceMonoRegular
+		switch s.font {
+		case gridFontMonoBold:
+			font = fontSpaceMonoBold
+		case gridFontSerif:
+			font = fontCormorantGaramondSemi
+		}
+		dc.SetFontFace(truetype.NewFace(font, &truetype.Options{Size: s.size}))
+		dc.DrawStringAnchored(s.text, s.x, s.y, s.anchor, 0)
+	}
+	return dc.Image()
+}
+
+// GridCardSVG draws the same card as GenerateGridCard as an SVG document.
+// The fonts are named rather than embedded, with generic fallbacks.
+func GridCardSVG(data SessionShareData, theme ui.Theme) string {
+	height, shapes := layoutGridCard(data, theme)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		gridCardWidth, height, gridCardWidth, height)
+	for _, s := range shapes {
+		if s.text == "" {
+			fmt.Fprintf(&b, `<rect x="%g" y="%g" width="%g" height="%g" fill="%s"/>`+"\n", s.x, s.y, s.w, s.h, hexColor(s.fill))
+			continue
+		}
+		anchor := "start"
+		switch s.anchor {
+		case 0.5:
+			anchor = "middle"
+		case 1:
+			anchor = "end"
+		}
+		family, weight := `'Space Mono', monospace`, "normal"
+		switch s.font {
+		case gridFontMonoBold:
+			weight = "bold"
+		case gridFontSerif:
+			family, weight = `'Cormorant Garamond', serif`, "600"
+		}
+		fmt.Fprintf(&b, `<text x="%g" y="%g" font-family="%s" font-size="%g" font-weight="%s" text-anchor="%s" fill="%s">%s</text>`+"\n",
+			s.x, s.y, family, s.size, weight, anchor, hexColor(s.fill), html.EscapeString(s.text))
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// hexColor formats c as #rrggbb.
+func hexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
@@ -0,0 +1,76 @@
+package share
+
+import (
+	"encoding/xml"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+	"github.com/bojanrajkovic/unquote/tui/internal/ui"
+)
+
+func solvedGridData(text, answer string) SessionShareData {
+	cells := puzzle.BuildCells(text, map[rune]rune{'X': 'A'})
+	puzzle.RevealSolution(cells, answer)
+	return SessionShareData{PuzzleNumber: "2026-01-20", Cells: cells, CompletionMs: 128000, Streak: 12, Solved: true}
+}
+
+func TestGenerateGridCard_Size(t *testing.T) {
+	img := GenerateGridCard(solvedGridData("XM, MX", "AB, BA"), ui.ActiveTheme())
+	if b := img.Bounds(); b.Dx() != 1200 || b.Dy() != 628 {
+		t.Errorf("short quote card = %dx%d, want 1200x628", b.Dx(), b.Dy())
+	}
+
+	long := strings.Repeat("XM MX ", 40)
+	img = GenerateGridCard(solvedGridData(long, strings.Repeat("AB BA ", 40)), ui.ActiveTheme())
+	if b := img.Bounds(); b.Dx() != 1200 || b.Dy() <= 628 {
+		t.Errorf("long quote card = %dx%d, want it taller than 628", b.Dx(), b.Dy())
+	}
+}
+
+func TestGenerateGridCard_UsesTheme(t *testing.T) {
+	theme := ui.ActiveTheme()
+	theme.Primary = color.RGBA{R: 0xff, A: 0xff}
+	img := GenerateGridCard(solvedGridData("XM, MX", "AB, BA"), theme)
+
+	// The wordmark is drawn in the theme's primary color
+	found := false
+	for y := 20; y < 60 && !found; y++ {
+		for x := 48; x < 200; x++ {
+			if r, g, b, _ := img.At(x, y).RGBA(); r>>8 == 0xff && g == 0 && b == 0 {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		t.Error("the wordmark should be drawn in the theme's primary color")
+	}
+}
+
+func TestGridCardSVG(t *testing.T) {
+	data := solvedGridData("XM, MX", "AB, BA")
+	data.PuzzleNumber = "<2026>"
+	svg := GridCardSVG(data, ui.ActiveTheme())
+
+	if err := xml.Unmarshal([]byte(svg), new(struct{})); err != nil {
+		t.Fatalf("SVG is not well-formed XML: %v\n%s", err, svg)
+	}
+	for _, want := range []string{
+		`width="1200" height="628"`,
+		`>UNQUOTE</text>`,
+		`>&lt;2026&gt;</text>`,
+		`>Solved in 2:08</text>`,
+		`>12-day streak</text>`,
+		`fill="#5f5fff"`, // ANSI 63, the primary color
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG is missing %s", want)
+		}
+	}
+	// Each answer letter, the hint included, is drawn over its cipher letter
+	if strings.Count(svg, `>A</text>`) != 2 || strings.Count(svg, `>B</text>`) != 2 {
+		t.Errorf("SVG should show the solved letters:\n%s", svg)
+	}
+}
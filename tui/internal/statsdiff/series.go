@@ -0,0 +1,75 @@
+package statsdiff
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+)
+
+// dateLayout is the YYYY-MM-DD format used by RecentSolve.Date.
+const dateLayout = "2006-01-02"
+
+// DailySolveMinutes places recent solves on a calendar axis: the returned
+// slice has one entry per day for the `days` days ending on `end` (oldest
+// first), holding the solve time in minutes or NaN for days without a solve.
+// hasData reports whether any solve fell inside the window.
+//
+// If the newest solve is dated after `end` (the server's day can run ahead of
+// the local clock near midnight), the window is shifted to end on that solve.
+// Entries with unparseable dates are skipped; when a date appears more than
+// once the later entry wins.
+func DailySolveMinutes(solves []api.RecentSolve, end time.Time, days int) (points []float64, hasData bool) {
+	if days <= 0 {
+		return nil, false
+	}
+
+	endDay := civilDay(end)
+	byDay := make(map[time.Time]float64, len(solves))
+	for _, s := range solves {
+		d, err := time.Parse(dateLayout, s.Date)
+		if err != nil {
+			continue
+		}
+		byDay[d] = s.CompletionTime / 60000.0
+		if d.After(endDay) {
+			endDay = d
+		}
+	}
+
+	points = make([]float64, days)
+	start := endDay.AddDate(0, 0, -(days - 1))
+	for i := range days {
+		if v, ok := byDay[start.AddDate(0, 0, i)]; ok {
+			points[i] = v
+			hasData = true
+		} else {
+			points[i] = math.NaN()
+		}
+	}
+	return points, hasData
+}
+
+// civilDay returns midnight UTC of t's calendar date in t's own location, so
+// it can be compared with dates parsed from YYYY-MM-DD strings.
+func civilDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// SolveTimesCaption captions the graph of DailySolveMinutes over days days,
+// counting the assisted solves among solves when there are any.
+func SolveTimesCaption(solves []api.RecentSolve, days int) string {
+	caption := fmt.Sprintf("Solve Times (last %d days, minutes", days)
+	assisted := 0
+	for _, s := range solves {
+		if s.AssistLevel != api.AssistNone {
+			assisted++
+		}
+	}
+	if assisted > 0 {
+		caption += fmt.Sprintf("; %d assisted", assisted)
+	}
+	return caption + ")"
+}
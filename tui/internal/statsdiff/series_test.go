@@ -0,0 +1,111 @@
+package statsdiff
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/api"
+)
+
+func TestDailySolveMinutes_PlacesSolvesByDate(t *testing.T) {
+	end := time.Date(2026, 2, 15, 21, 0, 0, 0, time.Local)
+	solves := []api.RecentSolve{
+		{Date: "2026-02-01", CompletionTime: 60000},
+		{Date: "2026-02-10", CompletionTime: 120000},
+		{Date: "2026-02-15", CompletionTime: 180000},
+	}
+
+	points, hasData := DailySolveMinutes(solves, end, 30)
+
+	if !hasData {
+		t.Fatal("hasData: want true")
+	}
+	if len(points) != 30 {
+		t.Fatalf("len(points) = %d, want 30", len(points))
+	}
+
+	// Window is 2026-01-17 .. 2026-02-15; index = days since start.
+	want := map[int]float64{15: 1, 24: 2, 29: 3}
+	for i, p := range points {
+		if v, ok := want[i]; ok {
+			if p != v {
+				t.Errorf("points[%d] = %v, want %v", i, p, v)
+			}
+			continue
+		}
+		if !math.IsNaN(p) {
+			t.Errorf("points[%d] = %v, want NaN (no solve that day)", i, p)
+		}
+	}
+}
+
+func TestDailySolveMinutes_GapsAreNotCollapsed(t *testing.T) {
+	end := time.Date(2026, 2, 15, 12, 0, 0, 0, time.UTC)
+	solves := []api.RecentSolve{
+		{Date: "2026-02-13", CompletionTime: 60000},
+		{Date: "2026-02-15", CompletionTime: 60000},
+	}
+
+	points, _ := DailySolveMinutes(solves, end, 3)
+
+	if points[0] != 1 || !math.IsNaN(points[1]) || points[2] != 1 {
+		t.Errorf("points = %v, want [1 NaN 1]", points)
+	}
+}
+
+func TestDailySolveMinutes_OutsideWindow(t *testing.T) {
+	end := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	solves := []api.RecentSolve{{Date: "2026-01-01", CompletionTime: 60000}}
+
+	points, hasData := DailySolveMinutes(solves, end, 30)
+
+	if hasData {
+		t.Error("hasData: want false for solves older than the window")
+	}
+	for i, p := range points {
+		if !math.IsNaN(p) {
+			t.Errorf("points[%d] = %v, want NaN", i, p)
+		}
+	}
+}
+
+func TestDailySolveMinutes_ServerAheadOfLocalClock(t *testing.T) {
+	end := time.Date(2026, 2, 14, 23, 30, 0, 0, time.UTC)
+	solves := []api.RecentSolve{{Date: "2026-02-15", CompletionTime: 120000}}
+
+	points, hasData := DailySolveMinutes(solves, end, 5)
+
+	if !hasData || points[4] != 2 {
+		t.Errorf("points = %v, want newest solve in last slot", points)
+	}
+}
+
+func TestDailySolveMinutes_SkipsBadDates(t *testing.T) {
+	end := time.Date(2026, 2, 15, 12, 0, 0, 0, time.UTC)
+	solves := []api.RecentSolve{{Date: "not-a-date", CompletionTime: 60000}}
+
+	_, hasData := DailySolveMinutes(solves, end, 30)
+
+	if hasData {
+		t.Error("hasData: want false when all dates are invalid")
+	}
+}
+
+func TestDailySolveMinutes_ZeroDays(t *testing.T) {
+	points, hasData := DailySolveMinutes(nil, time.Now(), 0)
+	if points != nil || hasData {
+		t.Errorf("got (%v, %v), want (nil, false)", points, hasData)
+	}
+}
+
+func TestSolveTimesCaption(t *testing.T) {
+	clean := []api.RecentSolve{{Date: "2026-02-14", CompletionTime: 60_000}}
+	if got := SolveTimesCaption(clean, 30); got != "Solve Times (last 30 days, minutes)" {
+		t.Errorf("SolveTimesCaption(clean) = %q", got)
+	}
+	assisted := append(clean, api.RecentSolve{Date: "2026-02-15", AssistLevel: api.AssistLight, CompletionTime: 90_000})
+	if got := SolveTimesCaption(assisted, 30); got != "Solve Times (last 30 days, minutes; 1 assisted)" {
+		t.Errorf("SolveTimesCaption(assisted) = %q", got)
+	}
+}
@@ -0,0 +1,109 @@
+// Package statsdiff derives comparisons and chart data from a player's solve history.
+package statsdiff
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Comparison describes how one solve stacks up against the player's history.
+type Comparison struct {
+	Percentile *float64      // share of other players this solve beat (0-100), nil if unknown
+	Today      time.Duration // completion time of the solve being compared
+	Average    time.Duration // historical average, excluding the solve itself
+	HasAverage bool          // false when there is no prior solve to compare against
+}
+
+// Compare builds a Comparison for a solve of the given duration.
+//
+// averageMs and solvedCount come from the stats API, which already includes the
+// solve being compared when includesToday is true. In that case the solve is
+// backed out of the average so the player is compared against their history,
+// not against a mean that contains today's time.
+func Compare(today time.Duration, averageMs *float64, solvedCount int, includesToday bool, percentile *float64) Comparison {
+	c := Comparison{Today: today, Percentile: clampPercentile(percentile)}
+	if averageMs == nil || *averageMs <= 0 {
+		return c
+	}
+
+	avg := *averageMs
+	if includesToday {
+		if solvedCount < 2 {
+			// Today is the only solve on record; there is no history yet.
+			return c
+		}
+		n := float64(solvedCount)
+		avg = (avg*n - float64(today.Milliseconds())) / (n - 1)
+		if avg <= 0 {
+			return c
+		}
+	}
+
+	c.Average = time.Duration(avg) * time.Millisecond
+	c.HasAverage = true
+	return c
+}
+
+// SpeedDelta returns how much faster (positive) or slower (negative) today's
+// solve was than the average, as a percentage of solving speed. A 2:45 solve
+// against a 3:15 average is 195/165 - 1 ≈ 18% faster.
+// Returns 0 when there is no average to compare against.
+func (c Comparison) SpeedDelta() float64 {
+	if !c.HasAverage || c.Today <= 0 || c.Average <= 0 {
+		return 0
+	}
+	if c.Today <= c.Average {
+		return (c.Average.Seconds()/c.Today.Seconds() - 1) * 100
+	}
+	return -(c.Today.Seconds()/c.Average.Seconds() - 1) * 100
+}
+
+// Summary renders the one-line comparison, e.g.
+// "Today: 2:45 · Your average: 3:15 · 18% faster than usual".
+// Without an average only today's time is shown.
+func (c Comparison) Summary() string {
+	today := fmt.Sprintf("Today: %s", FormatDuration(c.Today))
+	if !c.HasAverage {
+		return today
+	}
+	return fmt.Sprintf("%s · Your average: %s · %s", today, FormatDuration(c.Average), c.deltaText())
+}
+
+// PercentileText renders the percentile line, e.g. "Faster than 72% of players".
+// Returns "" when the percentile is unknown.
+func (c Comparison) PercentileText() string {
+	if c.Percentile == nil {
+		return ""
+	}
+	return fmt.Sprintf("Faster than %.0f%% of players", *c.Percentile)
+}
+
+func (c Comparison) deltaText() string {
+	delta := c.SpeedDelta()
+	rounded := math.Round(math.Abs(delta))
+	switch {
+	case rounded == 0:
+		return "right on your average"
+	case delta > 0:
+		return fmt.Sprintf("%.0f%% faster than usual", rounded)
+	default:
+		return fmt.Sprintf("%.0f%% slower than usual", rounded)
+	}
+}
+
+// FormatDuration formats a duration as M:SS (e.g. 2m45s → "2:45").
+func FormatDuration(d time.Duration) string {
+	total := int(d.Seconds())
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
+
+// clampPercentile discards percentiles outside 0-100 so a misbehaving server
+// can't render "Faster than 140% of players".
+func clampPercentile(p *float64) *float64 {
+	if p == nil || math.IsNaN(*p) || *p < 0 || *p > 100 {
+		return nil
+	}
+	v := *p
+	return &v
+}
@@ -0,0 +1,192 @@
+package statsdiff
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func ptr(f float64) *float64 { return &f }
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		averageMs     *float64
+		name          string
+		wantAverage   time.Duration
+		today         time.Duration
+		solvedCount   int
+		includesToday bool
+		wantHas       bool
+	}{
+		{
+			name:        "no average",
+			today:       2 * time.Minute,
+			averageMs:   nil,
+			solvedCount: 0,
+			wantHas:     false,
+		},
+		{
+			name:        "zero average",
+			today:       2 * time.Minute,
+			averageMs:   ptr(0),
+			solvedCount: 3,
+			wantHas:     false,
+		},
+		{
+			name:        "historical average used as-is",
+			today:       165 * time.Second,
+			averageMs:   ptr(195000),
+			solvedCount: 5,
+			wantHas:     true,
+			wantAverage: 195 * time.Second,
+		},
+		{
+			name:          "today backed out of average",
+			today:         60 * time.Second,
+			averageMs:     ptr(100000), // (140 + 60) / 2
+			solvedCount:   2,
+			includesToday: true,
+			wantHas:       true,
+			wantAverage:   140 * time.Second,
+		},
+		{
+			name:          "only solve on record has no history",
+			today:         60 * time.Second,
+			averageMs:     ptr(60000),
+			solvedCount:   1,
+			includesToday: true,
+			wantHas:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Compare(tt.today, tt.averageMs, tt.solvedCount, tt.includesToday, nil)
+			if c.HasAverage != tt.wantHas {
+				t.Fatalf("HasAverage = %v, want %v", c.HasAverage, tt.wantHas)
+			}
+			if c.Average != tt.wantAverage {
+				t.Errorf("Average = %v, want %v", c.Average, tt.wantAverage)
+			}
+			if c.Today != tt.today {
+				t.Errorf("Today = %v, want %v", c.Today, tt.today)
+			}
+		})
+	}
+}
+
+func TestSpeedDelta(t *testing.T) {
+	tests := []struct {
+		name    string
+		today   time.Duration
+		average time.Duration
+		want    float64
+		has     bool
+	}{
+		{name: "faster", today: 165 * time.Second, average: 195 * time.Second, has: true, want: 18.18},
+		{name: "slower", today: 195 * time.Second, average: 165 * time.Second, has: true, want: -18.18},
+		{name: "equal", today: time.Minute, average: time.Minute, has: true, want: 0},
+		{name: "twice as fast", today: time.Minute, average: 2 * time.Minute, has: true, want: 100},
+		{name: "no average", today: time.Minute, has: false, want: 0},
+		{name: "zero today", today: 0, average: time.Minute, has: true, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Comparison{Today: tt.today, Average: tt.average, HasAverage: tt.has}
+			got := c.SpeedDelta()
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("SpeedDelta() = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		c    Comparison
+	}{
+		{
+			name: "faster than usual",
+			c:    Comparison{Today: 165 * time.Second, Average: 195 * time.Second, HasAverage: true},
+			want: "Today: 2:45 · Your average: 3:15 · 18% faster than usual",
+		},
+		{
+			name: "slower than usual",
+			c:    Comparison{Today: 195 * time.Second, Average: 165 * time.Second, HasAverage: true},
+			want: "Today: 3:15 · Your average: 2:45 · 18% slower than usual",
+		},
+		{
+			name: "on average",
+			c:    Comparison{Today: 120 * time.Second, Average: 120 * time.Second, HasAverage: true},
+			want: "Today: 2:00 · Your average: 2:00 · right on your average",
+		},
+		{
+			name: "first solve",
+			c:    Comparison{Today: 75 * time.Second},
+			want: "Today: 1:15",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Summary(); got != tt.want {
+				t.Errorf("Summary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercentileText(t *testing.T) {
+	tests := []struct {
+		percentile *float64
+		name       string
+		want       string
+	}{
+		{name: "unknown", percentile: nil, want: ""},
+		{name: "typical", percentile: ptr(72.4), want: "Faster than 72% of players"},
+		{name: "zero", percentile: ptr(0), want: "Faster than 0% of players"},
+		{name: "hundred", percentile: ptr(100), want: "Faster than 100% of players"},
+		{name: "above range discarded", percentile: ptr(140), want: ""},
+		{name: "negative discarded", percentile: ptr(-1), want: ""},
+		{name: "NaN discarded", percentile: ptr(math.NaN()), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Compare(time.Minute, nil, 0, false, tt.percentile)
+			if got := c.PercentileText(); got != tt.want {
+				t.Errorf("PercentileText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare_CopiesPercentile(t *testing.T) {
+	p := ptr(50)
+	c := Compare(time.Minute, nil, 0, false, p)
+	*p = 99
+	if c.Percentile == nil || *c.Percentile != 50 {
+		t.Errorf("Percentile should be copied, got %v", c.Percentile)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		want string
+		d    time.Duration
+	}{
+		{d: 0, want: "0:00"},
+		{d: 165 * time.Second, want: "2:45"},
+		{d: 10 * time.Minute, want: "10:00"},
+		{d: 61*time.Second + 900*time.Millisecond, want: "1:01"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDuration(tt.d); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package storage
+
+import "sync/atomic"
+
+// Backend is where sessions, the crash recovery file, the run marker, the
+// upload journal, favorites and unsent difficulty ratings are kept. Files,
+// the default, keeps them in the XDG state directory; NewMemory keeps them in
+// memory for ephemeral play and tests. Namespace methods and the
+// package-level functions validate their arguments and go to the backend in
+// use.
+type Backend interface {
+	// SaveSession writes session to namespace n as it is.
+	SaveSession(n Namespace, session *GameSession) error
+	// LoadSession returns the session for gameID, or nil, nil when there is none.
+	LoadSession(n Namespace, gameID string) (*GameSession, error)
+	// ListSessions returns the sessions in n that keep accepts.
+	ListSessions(n Namespace, keep func(GameSession) bool) ([]GameSession, error)
+	SessionExists(n Namespace, gameID string) (bool, error)
+	// Quarantined returns the names of n's damaged session files set aside.
+	Quarantined(n Namespace) ([]string, error)
+
+	SaveRecovery(r *Recovery) error
+	// LoadRecovery returns nil, nil when there is no crash recovery to offer.
+	LoadRecovery() (*Recovery, error)
+	ClearRecovery() error
+
+	SaveRunMarker(r *RunMarker) error
+	// LoadRunMarker returns nil, nil when no run has written one.
+	LoadRunMarker() (*RunMarker, error)
+
+	// MarkUploaded marks a daily session uploaded once the server accepted it.
+	MarkUploaded(gameID string) error
+	// ReplayUploads finishes uploads accepted but never marked, returning
+	// how many sessions it marked.
+	ReplayUploads() (int, error)
+
+	// SaveFavorites replaces the bookmarked quotes with favorites.
+	SaveFavorites(favorites []Favorite) error
+	// LoadFavorites returns the bookmarked quotes as saved, or an empty
+	// slice when there are none.
+	LoadFavorites() ([]Favorite, error)
+
+	// SaveRatings replaces the difficulty ratings waiting to be sent.
+	SaveRatings(ratings []Rating) error
+	// LoadRatings returns the ratings waiting to be sent as saved, or an
+	// empty slice when there are none.
+	LoadRatings() ([]Rating, error)
+}
+
+// files is the Backend on the XDG state directory.
+type files struct{}
+
+// Files keeps sessions as JSON files under ~/.local/state/unquote/. It is
+// the backend in use unless Use picks another.
+var Files Backend = files{}
+
+// backendBox lets an interface value sit in an atomic.Pointer.
+type backendBox struct{ Backend }
+
+var current atomic.Pointer[backendBox]
+
+// backend returns the backend in use.
+func backend() Backend {
+	if b := current.Load(); b != nil {
+		return b.Backend
+	}
+	return Files
+}
+
+// Use makes b the backend for every namespace and returns a function that
+// puts the previous one back, for tests:
+//
+//	t.Cleanup(storage.Use(storage.NewMemory()))
+func Use(b Backend) (restore func()) {
+	previous := current.Swap(&backendBox{b})
+	return func() { current.Store(previous) }
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/atomicfile"
+)
+
+// favoritesFileName is the bookmarked quotes in the XDG state directory,
+// next to the namespace directories.
+const favoritesFileName = "favorites.json"
+
+// Favorite is a solved quote the player bookmarked.
+type Favorite struct {
+	AddedAt  time.Time `json:"added_at"`
+	GameID   string    `json:"game_id"`
+	Date     string    `json:"date,omitempty"` // puzzle date (YYYY-MM-DD); empty for custom and pack puzzles
+	Text     string    `json:"text"`           // the solved quote
+	Author   string    `json:"author,omitempty"`
+	Category string    `json:"category,omitempty"`
+}
+
+// favoritesMu serializes ToggleFavorite's read-modify-write, so two quick
+// toggles can't lose each other.
+var favoritesMu sync.Mutex
+
+// LoadFavorites returns the bookmarked quotes, oldest first. Returns an
+// empty slice when there are none.
+func LoadFavorites() ([]Favorite, error) {
+	favorites, err := backend().LoadFavorites()
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(favorites, func(a, b Favorite) int { return a.AddedAt.Compare(b.AddedAt) })
+	return favorites, nil
+}
+
+// IsFavorite reports whether the puzzle with gameID is bookmarked.
+func IsFavorite(gameID string) (bool, error) {
+	favorites, err := backend().LoadFavorites()
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(favorites, func(f Favorite) bool { return f.GameID == gameID }), nil
+}
+
+// ToggleFavorite bookmarks f, or removes the bookmark when its game ID
+// already has one. It reports whether f is a favorite now.
+func ToggleFavorite(f Favorite) (bool, error) {
+	if f.GameID == "" {
+		return false, errors.New("favorite has no game ID")
+	}
+
+	favoritesMu.Lock()
+	defer favoritesMu.Unlock()
+
+	favorites, err := backend().LoadFavorites()
+	if err != nil {
+		return false, err
+	}
+	kept := slices.DeleteFunc(favorites, func(existing Favorite) bool { return existing.GameID == f.GameID })
+	added := len(kept) == len(favorites)
+	if added {
+		kept = append(kept, f)
+	}
+	if err := backend().SaveFavorites(kept); err != nil {
+		return false, err
+	}
+	return added, nil
+}
+
+func (files) SaveFavorites(favorites []Favorite) error {
+	root, err := stateRoot()
+	if err != nil {
+		return fmt.Errorf("opening state root: %w", err)
+	}
+	defer root.Close()
+
+	data, err := json.MarshalIndent(favorites, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling favorites: %w", err)
+	}
+
+	if err := atomicfile.WriteFile(atomicfile.Root(root), favoritesFileName, data, 0o600); err != nil {
+		return fmt.Errorf("writing favorites file: %w", err)
+	}
+	return nil
+}
+
+func (files) LoadFavorites() ([]Favorite, error) {
+	root, err := stateRoot()
+	if err != nil {
+		return nil, fmt.Errorf("opening state root: %w", err)
+	}
+	defer root.Close()
+
+	data, err := root.ReadFile(favoritesFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Favorite{}, nil
+		}
+		return nil, fmt.Errorf("reading favorites file: %w", err)
+	}
+
+	favorites := []Favorite{}
+	if err := json.Unmarshal(data, &favorites); err != nil {
+		return nil, fmt.Errorf("unmarshaling favorites: %w", err)
+	}
+	return favorites, nil
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+func TestToggleFavorite(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", tmpDir)
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	if got, err := LoadFavorites(); err != nil || len(got) != 0 || got == nil {
+		t.Fatalf("LoadFavorites() before any = %v, %v; want an empty slice", got, err)
+	}
+
+	older := Favorite{AddedAt: time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC), GameID: "game-1", Date: "2026-01-15", Text: "IT IS.", Author: "Someone"}
+	newer := Favorite{AddedAt: older.AddedAt.Add(time.Hour), GameID: "game-2", Text: "SO IT GOES."}
+	for _, f := range []Favorite{newer, older} {
+		if added, err := ToggleFavorite(f); err != nil || !added {
+			t.Fatalf("ToggleFavorite(%s) = %v, %v; want added", f.GameID, added, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, appName, favoritesFileName)); err != nil {
+		t.Errorf("favorites file not in the state directory: %v", err)
+	}
+
+	got, err := LoadFavorites()
+	if err != nil || len(got) != 2 {
+		t.Fatalf("LoadFavorites() = %v, %v; want both favorites", got, err)
+	}
+	if got[0].GameID != "game-1" || got[0].Author != "Someone" || got[1].GameID != "game-2" {
+		t.Errorf("LoadFavorites() = %+v, want oldest first", got)
+	}
+	if ok, err := IsFavorite("game-2"); err != nil || !ok {
+		t.Errorf("IsFavorite(game-2) = %v, %v; want true", ok, err)
+	}
+
+	if added, err := ToggleFavorite(Favorite{GameID: "game-2"}); err != nil || added {
+		t.Errorf("toggling again = %v, %v; want removed", added, err)
+	}
+	if ok, _ := IsFavorite("game-2"); ok {
+		t.Error("game-2 still a favorite after removing it")
+	}
+	if _, err := ToggleFavorite(Favorite{}); err == nil {
+		t.Error("ToggleFavorite() without a game ID succeeded, want an error")
+	}
+}
+
+func TestToggleFavorite_Memory(t *testing.T) {
+	useMemory(t)
+
+	if added, err := ToggleFavorite(Favorite{GameID: "game-1", Text: "IT IS."}); err != nil || !added {
+		t.Fatalf("ToggleFavorite() = %v, %v; want added", added, err)
+	}
+	if got, err := LoadFavorites(); err != nil || len(got) != 1 || got[0].Text != "IT IS." {
+		t.Errorf("LoadFavorites() = %+v, %v; want the favorite", got, err)
+	}
+}
+
+func TestLoadFavorites_Corrupt(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", tmpDir)
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, appName), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, appName, favoritesFileName), []byte("[{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFavorites(); err == nil {
+		t.Error("LoadFavorites() on a corrupt file succeeded, want an error")
+	}
+	if _, err := ToggleFavorite(Favorite{GameID: "game-1"}); err == nil {
+		t.Error("ToggleFavorite() over a corrupt file succeeded, want an error rather than losing it")
+	}
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/atomicfile"
+)
+
+// uploadJournal is the write-ahead journal for uploads, in the XDG state
+// directory next to the namespace directories. One JSON entry per line.
+const uploadJournal = "uploads.journal"
+
+// Journal operations. An upload is recorded once the server accepts it and
+// applied once the session file says so too.
+const (
+	journalRecorded = "recorded"
+	journalApplied  = "applied"
+)
+
+// journalEntry is one line of the upload journal.
+type journalEntry struct {
+	Op     string    `json:"op"`
+	GameID string    `json:"game_id"`
+	At     time.Time `json:"at"`
+}
+
+// MarkUploaded marks a daily session uploaded after the server accepted its
+// solve. The acceptance is journaled before the session file changes, so power
+// loss in between leaves ReplayUploads to finish the job instead of the solve
+// being uploaded again. A session that no longer exists is not an error.
+func MarkUploaded(gameID string) error {
+	return backend().MarkUploaded(gameID)
+}
+
+func (files) MarkUploaded(gameID string) error {
+	root, err := stateRoot()
+	if err != nil {
+		return fmt.Errorf("opening state root: %w", err)
+	}
+	defer root.Close()
+
+	return markUploaded(atomicfile.Root(root), gameID)
+}
+
+// markUploaded journals the upload in dir, marks the session and journals that
+// it was applied. A failed journal write doesn't stop the session being marked.
+func markUploaded(dir atomicfile.Dir, gameID string) error {
+	recordErr := appendJournal(dir, journalRecorded, gameID)
+	if _, err := markSession(Files, gameID); err != nil {
+		return errors.Join(recordErr, err)
+	}
+	if recordErr != nil {
+		return recordErr
+	}
+	return appendJournal(dir, journalApplied, gameID)
+}
+
+// ReplayUploads finishes uploads the journal says the server accepted but
+// whose sessions were never marked, then compacts the journal: it is removed
+// once every entry is applied. Returns how many sessions it marked.
+func ReplayUploads() (int, error) {
+	return backend().ReplayUploads()
+}
+
+func (files) ReplayUploads() (int, error) {
+	root, err := stateRoot()
+	if err != nil {
+		return 0, fmt.Errorf("opening state root: %w", err)
+	}
+	defer root.Close()
+
+	entries, err := readJournal(root)
+	if err != nil || len(entries) == 0 {
+		return 0, err
+	}
+	return replayUploads(atomicfile.Root(root), entries)
+}
+
+// replayUploads marks the sessions of recorded entries that were never
+// applied and rewrites the journal in dir with the ones that still failed.
+func replayUploads(dir atomicfile.Dir, entries []journalEntry) (int, error) {
+	var pending []string
+	for _, e := range entries {
+		switch e.Op {
+		case journalRecorded:
+			if !slices.Contains(pending, e.GameID) {
+				pending = append(pending, e.GameID)
+			}
+		case journalApplied:
+			pending = slices.DeleteFunc(pending, func(id string) bool { return id == e.GameID })
+		}
+	}
+
+	marked := 0
+	var remaining bytes.Buffer
+	var markErr error
+	for _, gameID := range pending {
+		changed, err := markSession(Files, gameID)
+		if err != nil {
+			markErr = errors.Join(markErr, err)
+			line, _ := json.Marshal(journalEntry{Op: journalRecorded, GameID: gameID, At: time.Now()})
+			remaining.Write(append(line, '\n'))
+			continue
+		}
+		if changed {
+			marked++
+		}
+	}
+
+	if remaining.Len() == 0 {
+		if err := dir.Remove(uploadJournal); err != nil && !os.IsNotExist(err) {
+			return marked, errors.Join(markErr, fmt.Errorf("removing %s: %w", uploadJournal, err))
+		}
+		if err := dir.Sync(); err != nil {
+			return marked, errors.Join(markErr, fmt.Errorf("syncing directory: %w", err))
+		}
+		return marked, markErr
+	}
+	if err := atomicfile.WriteFile(dir, uploadJournal, remaining.Bytes(), 0o600); err != nil {
+		return marked, errors.Join(markErr, fmt.Errorf("compacting %s: %w", uploadJournal, err))
+	}
+	return marked, markErr
+}
+
+// markSession sets Uploaded on a daily session in b, reporting whether it
+// changed anything: a missing or already uploaded session is left alone.
+func markSession(b Backend, gameID string) (bool, error) {
+	defer lockSession(Daily, gameID)()
+	session, err := b.LoadSession(Daily, gameID)
+	if err != nil || session == nil || session.Uploaded {
+		return false, err
+	}
+	session.Uploaded = true
+	session.SavedAt = time.Now()
+	if err := b.SaveSession(Daily, session); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// appendJournal adds an entry for gameID to the journal in dir and syncs it.
+func appendJournal(dir atomicfile.Dir, op, gameID string) error {
+	line, err := json.Marshal(journalEntry{Op: op, GameID: gameID, At: time.Now()})
+	if err != nil {
+		return fmt.Errorf("marshaling journal entry: %w", err)
+	}
+	if err := atomicfile.Append(dir, uploadJournal, append(line, '\n'), 0o600); err != nil {
+		return fmt.Errorf("writing %s: %w", uploadJournal, err)
+	}
+	return nil
+}
+
+// readJournal reads the upload journal's entries. A missing journal has none,
+// and lines that don't decode, such as one torn by a crash mid-append, are skipped.
+func readJournal(root *os.Root) ([]journalEntry, error) {
+	data, err := root.ReadFile(uploadJournal)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading %s: %w", uploadJournal, err)
+	}
+
+	var entries []journalEntry
+	for line := range bytes.Lines(data) {
+		var e journalEntry
+		if json.Unmarshal(line, &e) != nil || e.GameID == "" {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
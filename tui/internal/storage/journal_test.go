@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/atomicfile"
+)
+
+var errInjected = errors.New("injected failure")
+
+// failingDir wraps the state directory and fails opening or removing files,
+// as a full or read-only disk would.
+type failingDir struct {
+	atomicfile.Dir
+	failOpen, failRemove bool
+}
+
+func (d failingDir) OpenFile(name string, flag int, perm os.FileMode) (atomicfile.File, error) {
+	if d.failOpen {
+		return nil, errInjected
+	}
+	return d.Dir.OpenFile(name, flag, perm)
+}
+
+func (d failingDir) Remove(name string) error {
+	if d.failRemove {
+		return errInjected
+	}
+	return d.Dir.Remove(name)
+}
+
+// openStateDir returns a Dir on the state directory and its path.
+func openStateDir(t *testing.T) (atomicfile.Dir, string) {
+	t.Helper()
+	root, err := stateRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = root.Close() })
+	return atomicfile.Root(root), root.Name()
+}
+
+// saveSolved saves a solved, not yet uploaded daily session.
+func saveSolved(t *testing.T, gameID string) {
+	t.Helper()
+	if err := SaveSession(&GameSession{GameID: gameID, Solved: true, Inputs: map[string]string{}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// pendingUploads returns the game IDs of sessions still waiting for upload.
+func pendingUploads(t *testing.T) []string {
+	t.Helper()
+	sessions, err := ListSolvedSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, s := range sessions {
+		ids = append(ids, s.GameID)
+	}
+	return ids
+}
+
+func TestMarkUploaded(t *testing.T) {
+	setStateHome(t)
+	saveSolved(t, "game-1")
+
+	if err := MarkUploaded("game-1"); err != nil {
+		t.Fatal(err)
+	}
+	if pending := pendingUploads(t); len(pending) != 0 {
+		t.Errorf("pending = %v, want none", pending)
+	}
+
+	// Every entry was applied, so replaying only compacts the journal away
+	marked, err := ReplayUploads()
+	if err != nil || marked != 0 {
+		t.Errorf("ReplayUploads() = %d, %v, want 0, nil", marked, err)
+	}
+	_, path := openStateDir(t)
+	if _, err := os.Stat(filepath.Join(path, uploadJournal)); !os.IsNotExist(err) {
+		t.Errorf("journal left after replay: %v", err)
+	}
+}
+
+func TestReplayUploads_FinishesInterruptedUpload(t *testing.T) {
+	setStateHome(t)
+	saveSolved(t, "game-1")
+	saveSolved(t, "game-2")
+	dir, path := openStateDir(t)
+
+	// Power was lost after the server accepted game-1 but before its session
+	// was marked; game-2 was never uploaded
+	if err := appendJournal(dir, journalRecorded, "game-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	marked, err := ReplayUploads()
+	if err != nil || marked != 1 {
+		t.Fatalf("ReplayUploads() = %d, %v, want 1, nil", marked, err)
+	}
+	if pending := pendingUploads(t); len(pending) != 1 || pending[0] != "game-2" {
+		t.Errorf("pending = %v, want only game-2", pending)
+	}
+	if _, err := os.Stat(filepath.Join(path, uploadJournal)); !os.IsNotExist(err) {
+		t.Errorf("journal left after replay: %v", err)
+	}
+}
+
+func TestReplayUploads_SkipsTornEntry(t *testing.T) {
+	setStateHome(t)
+	saveSolved(t, "game-1")
+	dir, path := openStateDir(t)
+
+	if err := appendJournal(dir, journalRecorded, "game-1"); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(filepath.Join(path, uploadJournal), os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"op":"applied","game_`); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	marked, err := ReplayUploads()
+	if err != nil || marked != 1 {
+		t.Errorf("ReplayUploads() = %d, %v, want 1, nil", marked, err)
+	}
+	if pending := pendingUploads(t); len(pending) != 0 {
+		t.Errorf("pending = %v, want none", pending)
+	}
+}
+
+func TestMarkUploaded_JournalFailure(t *testing.T) {
+	setStateHome(t)
+	saveSolved(t, "game-1")
+	dir, _ := openStateDir(t)
+
+	// The session is still marked when the journal can't be written
+	err := markUploaded(failingDir{Dir: dir, failOpen: true}, "game-1")
+	if !errors.Is(err, errInjected) {
+		t.Errorf("err = %v, want the injected failure", err)
+	}
+	if pending := pendingUploads(t); len(pending) != 0 {
+		t.Errorf("pending = %v, want none", pending)
+	}
+}
+
+func TestReplayUploads_CompactionFailureKeepsJournal(t *testing.T) {
+	setStateHome(t)
+	saveSolved(t, "game-1")
+	dir, path := openStateDir(t)
+	if err := appendJournal(dir, journalRecorded, "game-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := os.OpenRoot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer root.Close()
+	entries, err := readJournal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := replayUploads(failingDir{Dir: dir, failRemove: true}, entries); !errors.Is(err, errInjected) {
+		t.Fatalf("err = %v, want the injected failure", err)
+	}
+	if _, err := os.Stat(filepath.Join(path, uploadJournal)); err != nil {
+		t.Fatalf("journal gone after failed compaction: %v", err)
+	}
+
+	// Replaying again is harmless and finishes the compaction
+	marked, err := ReplayUploads()
+	if err != nil || marked != 0 {
+		t.Errorf("ReplayUploads() = %d, %v, want 0, nil", marked, err)
+	}
+	if _, err := os.Stat(filepath.Join(path, uploadJournal)); !os.IsNotExist(err) {
+		t.Errorf("journal left after replay: %v", err)
+	}
+}
@@ -0,0 +1,215 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// memory is a Backend that writes nothing to disk. Sessions are kept as
+// JSON, as the files backend would write them, so callers never share maps
+// with what is stored and fields round-trip the same way.
+type memory struct {
+	mu        sync.Mutex
+	sessions  map[Namespace]map[string][]byte
+	recovery  []byte
+	run       []byte
+	favorites []byte
+	ratings   []byte
+}
+
+// NewMemory returns an empty Backend kept in memory, for ephemeral play and
+// tests. Everything in it is gone when the process exits.
+func NewMemory() Backend {
+	return &memory{sessions: make(map[Namespace]map[string][]byte)}
+}
+
+func (m *memory) SaveSession(n Namespace, session *GameSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("marshaling session: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sessions[n] == nil {
+		m.sessions[n] = make(map[string][]byte)
+	}
+	m.sessions[n][session.GameID] = data
+	return nil
+}
+
+func (m *memory) LoadSession(n Namespace, gameID string) (*GameSession, error) {
+	m.mu.Lock()
+	data, ok := m.sessions[n][gameID]
+	m.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	var session GameSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("unmarshaling session: %w", err)
+	}
+	return &session, nil
+}
+
+// ListSessions returns the sessions in game ID order, as a directory
+// listing of the files backend would.
+func (m *memory) ListSessions(n Namespace, keep func(GameSession) bool) ([]GameSession, error) {
+	m.mu.Lock()
+	ids := make([]string, 0, len(m.sessions[n]))
+	for id := range m.sessions[n] {
+		ids = append(ids, id)
+	}
+	m.mu.Unlock()
+	slices.Sort(ids)
+
+	result := []GameSession{}
+	for _, id := range ids {
+		session, err := m.LoadSession(n, id)
+		if err != nil {
+			return nil, err
+		}
+		if session != nil && keep(*session) {
+			result = append(result, *session)
+		}
+	}
+	return result, nil
+}
+
+func (m *memory) SessionExists(n Namespace, gameID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.sessions[n][gameID]
+	return ok, nil
+}
+
+// Quarantined is always empty: nothing in memory gets damaged.
+func (m *memory) Quarantined(Namespace) ([]string, error) {
+	return []string{}, nil
+}
+
+func (m *memory) SaveRecovery(r *Recovery) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("marshaling recovery: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recovery = data
+	return nil
+}
+
+func (m *memory) LoadRecovery() (*Recovery, error) {
+	m.mu.Lock()
+	data := m.recovery
+	m.mu.Unlock()
+	if data == nil {
+		return nil, nil
+	}
+
+	var r Recovery
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("unmarshaling recovery: %w", err)
+	}
+	return &r, nil
+}
+
+func (m *memory) ClearRecovery() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recovery = nil
+	return nil
+}
+
+func (m *memory) SaveRunMarker(r *RunMarker) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("marshaling run marker: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.run = data
+	return nil
+}
+
+func (m *memory) LoadRunMarker() (*RunMarker, error) {
+	m.mu.Lock()
+	data := m.run
+	m.mu.Unlock()
+	if data == nil {
+		return nil, nil
+	}
+
+	var r RunMarker
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("unmarshaling run marker: %w", err)
+	}
+	return &r, nil
+}
+
+// MarkUploaded marks the session directly; there is no power loss to guard
+// against when nothing survives the process.
+func (m *memory) MarkUploaded(gameID string) error {
+	_, err := markSession(m, gameID)
+	return err
+}
+
+// ReplayUploads has nothing to replay without a journal.
+func (m *memory) ReplayUploads() (int, error) {
+	return 0, nil
+}
+
+func (m *memory) SaveFavorites(favorites []Favorite) error {
+	data, err := json.Marshal(favorites)
+	if err != nil {
+		return fmt.Errorf("marshaling favorites: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.favorites = data
+	return nil
+}
+
+func (m *memory) LoadFavorites() ([]Favorite, error) {
+	m.mu.Lock()
+	data := m.favorites
+	m.mu.Unlock()
+	favorites := []Favorite{}
+	if data == nil {
+		return favorites, nil
+	}
+
+	if err := json.Unmarshal(data, &favorites); err != nil {
+		return nil, fmt.Errorf("unmarshaling favorites: %w", err)
+	}
+	return favorites, nil
+}
+
+func (m *memory) SaveRatings(ratings []Rating) error {
+	data, err := json.Marshal(ratings)
+	if err != nil {
+		return fmt.Errorf("marshaling ratings: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ratings = data
+	return nil
+}
+
+func (m *memory) LoadRatings() ([]Rating, error) {
+	m.mu.Lock()
+	data := m.ratings
+	m.mu.Unlock()
+	ratings := []Rating{}
+	if data == nil {
+		return ratings, nil
+	}
+
+	if err := json.Unmarshal(data, &ratings); err != nil {
+		return nil, fmt.Errorf("unmarshaling ratings: %w", err)
+	}
+	return ratings, nil
+}
@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"os"
+	"testing"
+)
+
+// useMemory makes an empty memory backend the storage for the rest of t.
+func useMemory(t *testing.T) Backend {
+	t.Helper()
+	b := NewMemory()
+	t.Cleanup(Use(b))
+	return b
+}
+
+func TestMemory_WritesNothingToDisk(t *testing.T) {
+	setStateHome(t)
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	// setStateHome created the namespace directory; start from an empty one
+	if err := os.RemoveAll(stateHome); err != nil {
+		t.Fatal(err)
+	}
+	useMemory(t)
+
+	session := &GameSession{GameID: "game-1", Solved: true, Inputs: map[string]string{"X": "T"}}
+	if err := SaveSession(session); err != nil {
+		t.Fatal(err)
+	}
+	if err := Practice.SaveSession(&GameSession{GameID: "practice-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveRecovery(&Recovery{Namespace: Daily, Session: *session}); err != nil {
+		t.Fatal(err)
+	}
+	if err := MarkUploaded("game-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(stateHome); !os.IsNotExist(err) {
+		t.Errorf("state directory exists after memory-only play: %v", err)
+	}
+}
+
+func TestMemory_SessionRoundTrip(t *testing.T) {
+	useMemory(t)
+
+	session := &GameSession{GameID: "game-1", Inputs: map[string]string{"X": "T"}}
+	if err := SaveSession(session); err != nil {
+		t.Fatal(err)
+	}
+	if session.SavedAt.IsZero() {
+		t.Error("SaveSession should stamp SavedAt")
+	}
+
+	// The stored copy doesn't share the caller's maps
+	session.Inputs["X"] = "Q"
+	loaded, err := LoadSession("game-1")
+	if err != nil || loaded == nil {
+		t.Fatalf("LoadSession() = %v, %v", loaded, err)
+	}
+	if loaded.Inputs["X"] != "T" || !loaded.SavedAt.Equal(session.SavedAt) {
+		t.Errorf("loaded %+v, want the session as saved", loaded)
+	}
+
+	if missing, err := LoadSession("nope"); missing != nil || err != nil {
+		t.Errorf("LoadSession(missing) = %v, %v; want nil, nil", missing, err)
+	}
+	if exists, _ := SessionExists("game-1"); !exists {
+		t.Error("SessionExists() = false for a saved session")
+	}
+	if exists, _ := Practice.SessionExists("game-1"); exists {
+		t.Error("a daily session should not show up in the practice namespace")
+	}
+}
+
+func TestMemory_ListsAndMarksUploaded(t *testing.T) {
+	useMemory(t)
+	for _, s := range []*GameSession{
+		{GameID: "b-solved", Solved: true},
+		{GameID: "a-solved", Solved: true},
+		{GameID: "unfinished"},
+		{GameID: "uploaded", Solved: true, Uploaded: true},
+	} {
+		if err := SaveSession(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	solved, err := ListSolvedSessions()
+	if err != nil || len(solved) != 2 || solved[0].GameID != "a-solved" || solved[1].GameID != "b-solved" {
+		t.Fatalf("ListSolvedSessions() = %+v, %v; want both pending solves by game ID", solved, err)
+	}
+	if unfinished, _ := Daily.ListUnfinishedSessions(); len(unfinished) != 1 {
+		t.Errorf("ListUnfinishedSessions() = %+v, want one", unfinished)
+	}
+
+	if err := MarkUploaded("a-solved"); err != nil {
+		t.Fatal(err)
+	}
+	if solved, _ := ListSolvedSessions(); len(solved) != 1 || solved[0].GameID != "b-solved" {
+		t.Errorf("after MarkUploaded, pending = %+v; want only b-solved", solved)
+	}
+	if marked, err := ReplayUploads(); marked != 0 || err != nil {
+		t.Errorf("ReplayUploads() = %d, %v; want nothing to replay", marked, err)
+	}
+}
+
+func TestMemory_Recovery(t *testing.T) {
+	useMemory(t)
+
+	if r, err := LoadRecovery(); r != nil || err != nil {
+		t.Fatalf("LoadRecovery() = %v, %v; want nil, nil", r, err)
+	}
+	if err := SaveRecovery(&Recovery{Reason: "boom", Namespace: Daily, Session: GameSession{GameID: "game-1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if r, err := LoadRecovery(); err != nil || r == nil || r.Reason != "boom" {
+		t.Errorf("LoadRecovery() = %+v, %v; want the saved recovery", r, err)
+	}
+	if err := ClearRecovery(); err != nil {
+		t.Fatal(err)
+	}
+	if r, _ := LoadRecovery(); r != nil {
+		t.Errorf("LoadRecovery() after clearing = %+v, want nil", r)
+	}
+}
+
+func TestUse_RestoresPreviousBackend(t *testing.T) {
+	first := NewMemory()
+	restore := Use(first)
+	if err := SaveSession(&GameSession{GameID: "game-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	restoreSecond := Use(NewMemory())
+	if exists, _ := SessionExists("game-1"); exists {
+		t.Error("a fresh backend should not see the first one's sessions")
+	}
+	restoreSecond()
+	if exists, _ := SessionExists("game-1"); !exists {
+		t.Error("restoring should bring back the first backend")
+	}
+
+	restore()
+	if backend() != Files {
+		t.Error("restoring the first Use should go back to files")
+	}
+}
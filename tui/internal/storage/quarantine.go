@@ -0,0 +1,220 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/atomicfile"
+)
+
+// corruptDir is the subdirectory of a namespace that damaged session files
+// are moved to, with quarantineLog recording why each one was.
+const (
+	corruptDir    = "corrupt"
+	quarantineLog = "quarantine.log"
+)
+
+// Namespaces lists every session namespace.
+var Namespaces = []Namespace{Daily, Practice, Custom, Duel}
+
+// readSession reads and decodes session file name. A file that doesn't
+// decode is moved to the corrupt/ directory and, when an intact copy can be
+// recovered from it (see recoverSession), replaced by that copy. Returns
+// nil, nil when nothing could be recovered. Read errors, including a missing
+// file, are returned as they are.
+func readSession(root *os.Root, name string) (*GameSession, error) {
+	data, err := root.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var session GameSession
+	decodeErr := json.Unmarshal(data, &session)
+	if decodeErr == nil {
+		return &session, nil
+	}
+
+	recovered := recoverSession(root, name, data)
+	if err := quarantine(root, name, decodeErr, recovered != nil); err != nil {
+		return nil, fmt.Errorf("quarantining session file %q: %w", name, err)
+	}
+	if recovered == nil {
+		return nil, nil
+	}
+	if err := writeSessionFile(root, name, recovered); err != nil {
+		return nil, fmt.Errorf("writing recovered session file %q: %w", name, err)
+	}
+	return recovered, nil
+}
+
+// recoverSession tries to get a session back from a damaged file: first from
+// the temp file a save left behind before it could rename it into place, then
+// by cutting data back to its last complete field. Returns nil when neither
+// yields a session with a game ID.
+func recoverSession(root *os.Root, name string, data []byte) *GameSession {
+	if session, _ := intactTemp(root, name); session != nil {
+		removeTemps(root, name)
+		return session
+	}
+	return repairTruncated(data, strings.TrimSuffix(name, ".json"))
+}
+
+// repairTruncated recovers a session file that was cut off partway through
+// writing. Sessions are written with json.MarshalIndent, so every top-level
+// field starts a line indented by two spaces; closing the object after the
+// last field that was written in full keeps everything up to it. The game ID
+// comes from the file name when it was cut off. Returns nil when not even
+// one field survived.
+func repairTruncated(data []byte, gameID string) *GameSession {
+	fieldStart := []byte("\n  \"")
+	candidate := bytes.TrimRight(data, " \t\r\n,")
+	for {
+		var session GameSession
+		if bytes.Contains(candidate, fieldStart) && json.Unmarshal(append(bytes.Clone(candidate), "\n}"...), &session) == nil {
+			if session.GameID == "" {
+				session.GameID = gameID
+			}
+			return &session
+		}
+		i := bytes.LastIndex(candidate, fieldStart)
+		if i < 0 {
+			return nil
+		}
+		candidate = bytes.TrimRight(candidate[:i], ",")
+	}
+}
+
+// quarantine moves session file name into the corrupt/ directory, under a
+// new name if an earlier copy is already there, and logs why.
+func quarantine(root *os.Root, name string, cause error, recovered bool) error {
+	if err := root.Mkdir(corruptDir, 0o700); err != nil && !errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("creating %s directory: %w", corruptDir, err)
+	}
+
+	dest := path.Join(corruptDir, name)
+	if _, err := root.Stat(dest); err == nil {
+		ext := filepath.Ext(name)
+		dest = path.Join(corruptDir, fmt.Sprintf("%s-%d%s", strings.TrimSuffix(name, ext), time.Now().UnixNano(), ext))
+	}
+	if err := root.Rename(name, dest); err != nil {
+		return fmt.Errorf("moving to %s: %w", corruptDir, err)
+	}
+
+	outcome := "quarantined"
+	if recovered {
+		outcome = "recovered"
+	}
+	entry := fmt.Sprintf("%s %s -> %s: %s (%v)\n", time.Now().UTC().Format(time.RFC3339), name, dest, outcome, cause)
+	log, err := root.OpenFile(path.Join(corruptDir, quarantineLog), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", quarantineLog, err)
+	}
+	defer log.Close()
+	if _, err := log.WriteString(entry); err != nil {
+		return fmt.Errorf("writing %s: %w", quarantineLog, err)
+	}
+	return nil
+}
+
+// promoteTemp finishes a save that wrote session file name's temp file but
+// never renamed it into place. Returns the session, or nil when there is no
+// intact temp file.
+func promoteTemp(root *os.Root, name string) *GameSession {
+	session, tmpName := intactTemp(root, name)
+	if session == nil || root.Rename(tmpName, name) != nil {
+		return nil
+	}
+	removeTemps(root, name)
+	return session
+}
+
+// intactTemp returns the newest session saved in the temp files that saves
+// of session file name left behind, and the name of the file it is in.
+// Returns nil when none of them holds a session with a game ID.
+func intactTemp(root *os.Root, name string) (*GameSession, string) {
+	var newest *GameSession
+	var newestName string
+	for _, tmpName := range tempFiles(root, name) {
+		data, err := root.ReadFile(tmpName)
+		if err != nil {
+			continue
+		}
+		var session GameSession
+		if json.Unmarshal(data, &session) != nil || session.GameID == "" {
+			continue
+		}
+		if newest == nil || session.SavedAt.After(newest.SavedAt) {
+			newest, newestName = &session, tmpName
+		}
+	}
+	return newest, newestName
+}
+
+// removeTemps removes the temp files saves of session file name left behind.
+func removeTemps(root *os.Root, name string) {
+	for _, tmpName := range tempFiles(root, name) {
+		_ = root.Remove(tmpName)
+	}
+}
+
+// tempFiles returns the names of the temp files saves of session file name
+// left in root.
+func tempFiles(root *os.Root, name string) []string {
+	entries, err := fs.ReadDir(root.FS(), ".")
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		if base, ok := atomicfile.TempOf(entry.Name()); ok && base == name {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+// CorruptDir returns the directory the namespace's damaged session files are
+// moved to, with a quarantine.log saying why. It may not exist yet.
+func (n Namespace) CorruptDir() (string, error) {
+	dir, err := n.dir()
+	if err != nil {
+		return "", fmt.Errorf("getting sessions directory: %w", err)
+	}
+	return filepath.Join(dir, corruptDir), nil
+}
+
+// Quarantined returns the names of the namespace's session files moved to
+// its corrupt/ directory, including those a copy was recovered from.
+func (n Namespace) Quarantined() ([]string, error) {
+	return backend().Quarantined(n)
+}
+
+func (files) Quarantined(n Namespace) ([]string, error) {
+	dir, err := n.CorruptDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("reading %s directory: %w", corruptDir, err)
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && entry.Name() != quarantineLog {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
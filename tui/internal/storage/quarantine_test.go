@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+// setStateHome points the state directory at a temp dir and returns the
+// Daily namespace's directory in it.
+func setStateHome(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", tmpDir)
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+	dir, err := Daily.dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// savedSession saves a session with a few fields and returns its file's contents.
+func savedSession(t *testing.T, dir, gameID string) []byte {
+	t.Helper()
+	session := &GameSession{
+		GameID:        gameID,
+		Date:          "2026-10-15",
+		EncryptedText: "XQ ZZ",
+		Inputs:        map[string]string{"X": "T"},
+		ElapsedTime:   42 * time.Second,
+	}
+	if err := SaveSession(session); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, gameID+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestLoadSession_QuarantinesCorruptFile(t *testing.T) {
+	dir := setStateHome(t)
+	writeFile(t, filepath.Join(dir, "broken.json"), []byte("\x00\x00\x00\x00"))
+
+	session, err := LoadSession("broken")
+	if err != nil || session != nil {
+		t.Fatalf("LoadSession() = %v, %v; want nil, nil for an unrecoverable file", session, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "broken.json")); !os.IsNotExist(err) {
+		t.Errorf("corrupt file still in place: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, corruptDir, "broken.json")); err != nil {
+		t.Errorf("corrupt file not moved to %s/: %v", corruptDir, err)
+	}
+	log, err := os.ReadFile(filepath.Join(dir, corruptDir, quarantineLog))
+	if err != nil || !strings.Contains(string(log), "broken.json -> corrupt/broken.json: quarantined") {
+		t.Errorf("quarantine log = %q, %v; want an entry for broken.json", log, err)
+	}
+
+	names, err := Daily.Quarantined()
+	if err != nil || len(names) != 1 || names[0] != "broken.json" {
+		t.Errorf("Quarantined() = %v, %v; want [broken.json]", names, err)
+	}
+}
+
+func TestListSessions_SkipsCorruptFiles(t *testing.T) {
+	dir := setStateHome(t)
+	savedSession(t, dir, "good")
+	writeFile(t, filepath.Join(dir, "bad.json"), []byte("not json"))
+
+	sessions, err := Daily.ListSessions()
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v, want the listing to go on past a corrupt file", err)
+	}
+	if len(sessions) != 1 || sessions[0].GameID != "good" {
+		t.Errorf("ListSessions() = %+v, want only the intact session", sessions)
+	}
+	if names, _ := Daily.Quarantined(); len(names) != 1 {
+		t.Errorf("Quarantined() = %v, want the corrupt file", names)
+	}
+}
+
+func TestLoadSession_RepairsTruncatedFile(t *testing.T) {
+	dir := setStateHome(t)
+	data := savedSession(t, dir, "cut")
+
+	// Cut the file off in the middle of the inputs, after the fields before them
+	cut := bytes.Index(data, []byte(`"inputs"`)) + len(`"inputs": {`)
+	writeFile(t, filepath.Join(dir, "cut.json"), data[:cut])
+
+	session, err := LoadSession("cut")
+	if err != nil || session == nil {
+		t.Fatalf("LoadSession() = %v, %v; want the session recovered", session, err)
+	}
+	if session.GameID != "cut" || session.SavedAt.IsZero() {
+		t.Errorf("session = %+v, want game ID %q from the file name and the fields written before the cut", session, "cut")
+	}
+
+	// The repaired session replaces the file; the damaged one is kept aside
+	var onDisk GameSession
+	repaired, _ := os.ReadFile(filepath.Join(dir, "cut.json"))
+	if err := json.Unmarshal(repaired, &onDisk); err != nil || onDisk.GameID != "cut" {
+		t.Errorf("repaired file = %q, %v; want the recovered session", repaired, err)
+	}
+	log, _ := os.ReadFile(filepath.Join(dir, corruptDir, quarantineLog))
+	if !strings.Contains(string(log), "recovered") {
+		t.Errorf("quarantine log = %q, want the file logged as recovered", log)
+	}
+	if names, _ := Daily.Quarantined(); len(names) != 1 {
+		t.Errorf("Quarantined() = %v, want the damaged original kept", names)
+	}
+}
+
+func TestLoadSession_RecoversFromTempFile(t *testing.T) {
+	dir := setStateHome(t)
+	data := savedSession(t, dir, "temp")
+	writeFile(t, filepath.Join(dir, "temp.json.1234.tmp"), data)
+	writeFile(t, filepath.Join(dir, "temp.json"), []byte{})
+
+	session, err := LoadSession("temp")
+	if err != nil || session == nil || session.ElapsedTime != 42*time.Second || session.Inputs["X"] != "T" {
+		t.Fatalf("LoadSession() = %+v, %v; want the session from the temp file", session, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "temp.json.1234.tmp")); !os.IsNotExist(err) {
+		t.Errorf("temp file left behind: %v", err)
+	}
+}
+
+func TestOrphanedTempFilePromoted(t *testing.T) {
+	dir := setStateHome(t)
+	data := savedSession(t, dir, "orphan")
+	if err := os.Rename(filepath.Join(dir, "orphan.json"), filepath.Join(dir, "orphan.json.1234.tmp")); err != nil {
+		t.Fatal(err)
+	}
+	savedSession(t, dir, "other")
+	if err := os.Rename(filepath.Join(dir, "other.json"), filepath.Join(dir, "other.json.5678.tmp")); err != nil {
+		t.Fatal(err)
+	}
+
+	session, err := LoadSession("orphan")
+	if err != nil || session == nil || session.GameID != "orphan" {
+		t.Fatalf("LoadSession() = %v, %v; want the session a save left in its temp file", session, err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "orphan.json")); !bytes.Equal(got, data) {
+		t.Error("temp file not renamed into place")
+	}
+
+	sessions, err := Daily.ListSessions()
+	if err != nil || len(sessions) != 2 {
+		t.Errorf("ListSessions() = %d sessions, %v; want both, including the orphaned temp file", len(sessions), err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "other.json")); err != nil {
+		t.Errorf("listing didn't promote the orphaned temp file: %v", err)
+	}
+}
+
+func TestQuarantine_KeepsEarlierCopies(t *testing.T) {
+	dir := setStateHome(t)
+	for range 2 {
+		writeFile(t, filepath.Join(dir, "again.json"), []byte("{"))
+		if session, err := LoadSession("again"); err != nil || session != nil {
+			t.Fatalf("LoadSession() = %v, %v; want nil, nil", session, err)
+		}
+	}
+	if names, err := Daily.Quarantined(); err != nil || len(names) != 2 {
+		t.Errorf("Quarantined() = %v, %v; want both copies kept", names, err)
+	}
+}
+
+func TestQuarantined_NoneYet(t *testing.T) {
+	setStateHome(t)
+	if names, err := Practice.Quarantined(); err != nil || len(names) != 0 {
+		t.Errorf("Quarantined() = %v, %v; want none", names, err)
+	}
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/atomicfile"
+)
+
+// ratingsFileName is the difficulty ratings waiting to be sent, in the XDG
+// state directory next to the namespace directories.
+const ratingsFileName = "ratings.json"
+
+// Rating is how hard a puzzle felt to the player, kept until the server has
+// it.
+type Rating struct {
+	RatedAt time.Time `json:"rated_at"`
+	GameID  string    `json:"game_id"`
+	Rating  int       `json:"rating"` // 1 (easy) to 5 (very hard)
+}
+
+// ratingsMu serializes the read-modify-writes of the rating queue.
+var ratingsMu sync.Mutex
+
+// PendingRatings returns the ratings not yet sent, oldest first. Returns an
+// empty slice when there are none.
+func PendingRatings() ([]Rating, error) {
+	ratings, err := backend().LoadRatings()
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(ratings, func(a, b Rating) int { return a.RatedAt.Compare(b.RatedAt) })
+	return ratings, nil
+}
+
+// QueueRating keeps r to send later, replacing any rating already waiting
+// for its game.
+func QueueRating(r Rating) error {
+	if r.GameID == "" {
+		return errors.New("rating has no game ID")
+	}
+
+	ratingsMu.Lock()
+	defer ratingsMu.Unlock()
+
+	ratings, err := backend().LoadRatings()
+	if err != nil {
+		return err
+	}
+	ratings = slices.DeleteFunc(ratings, func(existing Rating) bool { return existing.GameID == r.GameID })
+	return backend().SaveRatings(append(ratings, r))
+}
+
+// RemoveRating drops the rating waiting for gameID, once it has been sent.
+// Removing one that isn't there is not an error.
+func RemoveRating(gameID string) error {
+	ratingsMu.Lock()
+	defer ratingsMu.Unlock()
+
+	ratings, err := backend().LoadRatings()
+	if err != nil {
+		return err
+	}
+	kept := slices.DeleteFunc(ratings, func(r Rating) bool { return r.GameID == gameID })
+	if len(kept) == len(ratings) {
+		return nil
+	}
+	return backend().SaveRatings(kept)
+}
+
+func (files) SaveRatings(ratings []Rating) error {
+	root, err := stateRoot()
+	if err != nil {
+		return fmt.Errorf("opening state root: %w", err)
+	}
+	defer root.Close()
+
+	data, err := json.MarshalIndent(ratings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling ratings: %w", err)
+	}
+
+	if err := atomicfile.WriteFile(atomicfile.Root(root), ratingsFileName, data, 0o600); err != nil {
+		return fmt.Errorf("writing ratings file: %w", err)
+	}
+	return nil
+}
+
+func (files) LoadRatings() ([]Rating, error) {
+	root, err := stateRoot()
+	if err != nil {
+		return nil, fmt.Errorf("opening state root: %w", err)
+	}
+	defer root.Close()
+
+	data, err := root.ReadFile(ratingsFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Rating{}, nil
+		}
+		return nil, fmt.Errorf("reading ratings file: %w", err)
+	}
+
+	ratings := []Rating{}
+	if err := json.Unmarshal(data, &ratings); err != nil {
+		return nil, fmt.Errorf("unmarshaling ratings: %w", err)
+	}
+	return ratings, nil
+}
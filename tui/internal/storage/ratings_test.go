@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+func TestQueueRating(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", tmpDir)
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	if got, err := PendingRatings(); err != nil || len(got) != 0 || got == nil {
+		t.Fatalf("PendingRatings() before any = %v, %v; want an empty slice", got, err)
+	}
+
+	ratedAt := time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)
+	for _, r := range []Rating{
+		{RatedAt: ratedAt.Add(time.Hour), GameID: "game-2", Rating: 2},
+		{RatedAt: ratedAt, GameID: "game-1", Rating: 3},
+		{RatedAt: ratedAt.Add(2 * time.Hour), GameID: "game-1", Rating: 5},
+	} {
+		if err := QueueRating(r); err != nil {
+			t.Fatalf("QueueRating(%s) error: %v", r.GameID, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, appName, ratingsFileName)); err != nil {
+		t.Errorf("ratings file not in the state directory: %v", err)
+	}
+
+	got, err := PendingRatings()
+	if err != nil || len(got) != 2 {
+		t.Fatalf("PendingRatings() = %v, %v; want one rating per game", got, err)
+	}
+	if got[0].GameID != "game-2" || got[1].GameID != "game-1" || got[1].Rating != 5 {
+		t.Errorf("PendingRatings() = %+v, want the latest rating for each game, oldest first", got)
+	}
+
+	if err := RemoveRating("game-2"); err != nil {
+		t.Fatalf("RemoveRating() error: %v", err)
+	}
+	if err := RemoveRating("game-9"); err != nil {
+		t.Errorf("RemoveRating() for a game with no rating = %v, want nil", err)
+	}
+	if got, _ := PendingRatings(); len(got) != 1 || got[0].GameID != "game-1" {
+		t.Errorf("PendingRatings() after removing game-2 = %+v", got)
+	}
+	if err := QueueRating(Rating{Rating: 3}); err == nil {
+		t.Error("QueueRating() without a game ID succeeded, want an error")
+	}
+}
+
+func TestQueueRating_Memory(t *testing.T) {
+	useMemory(t)
+
+	if err := QueueRating(Rating{GameID: "game-1", Rating: 4}); err != nil {
+		t.Fatalf("QueueRating() error: %v", err)
+	}
+	if got, err := PendingRatings(); err != nil || len(got) != 1 || got[0].Rating != 4 {
+		t.Errorf("PendingRatings() = %+v, %v; want the rating", got, err)
+	}
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/atomicfile"
+)
+
+// recoveryFileName is the crash recovery file in the XDG state directory,
+// next to the namespace directories.
+const recoveryFileName = "recovery.json"
+
+// Recovery is the game that was in progress when unquote crashed, saved so
+// the next start can offer it back. The file doubles as the crash marker.
+type Recovery struct {
+	CrashedAt time.Time   `json:"crashed_at"`
+	Reason    string      `json:"reason,omitempty"` // what the panic said, for bug reports
+	Namespace Namespace   `json:"namespace"`
+	Session   GameSession `json:"session"`
+}
+
+// Restorable reports whether the recovered game can be loaded again: a daily
+// or practice puzzle the API can serve by its ID. Custom puzzles need their
+// quote file and duels their room, so they are saved as ordinary sessions instead.
+func (r *Recovery) Restorable() bool {
+	return r.Session.GameID != "" && (r.Namespace == Daily || r.Namespace == Practice)
+}
+
+// stateRoot opens an os.Root handle on unquote's XDG state directory.
+// The caller must defer root.Close().
+func stateRoot() (*os.Root, error) {
+	path, err := xdg.StateFile(filepath.Join(appName, ".keep"))
+	if err != nil {
+		return nil, fmt.Errorf("creating state directory: %w", err)
+	}
+	root, err := os.OpenRoot(filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("opening root: %w", err)
+	}
+	return root, nil
+}
+
+// SaveRecovery writes the crash recovery file, replacing any earlier one.
+func SaveRecovery(r *Recovery) error {
+	return backend().SaveRecovery(r)
+}
+
+func (files) SaveRecovery(r *Recovery) error {
+	root, err := stateRoot()
+	if err != nil {
+		return fmt.Errorf("opening state root: %w", err)
+	}
+	defer root.Close()
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling recovery: %w", err)
+	}
+
+	if err := atomicfile.WriteFile(atomicfile.Root(root), recoveryFileName, data, 0o600); err != nil {
+		return fmt.Errorf("writing recovery file: %w", err)
+	}
+	return nil
+}
+
+// LoadRecovery reads the crash recovery file.
+// Returns nil, nil when unquote didn't crash since the file was last cleared.
+func LoadRecovery() (*Recovery, error) {
+	return backend().LoadRecovery()
+}
+
+func (files) LoadRecovery() (*Recovery, error) {
+	root, err := stateRoot()
+	if err != nil {
+		return nil, fmt.Errorf("opening state root: %w", err)
+	}
+	defer root.Close()
+
+	data, err := root.ReadFile(recoveryFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading recovery file: %w", err)
+	}
+
+	var r Recovery
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("unmarshaling recovery: %w", err)
+	}
+	return &r, nil
+}
+
+// ClearRecovery removes the crash recovery file once it was restored or
+// turned down. A missing file is not an error.
+func ClearRecovery() error {
+	return backend().ClearRecovery()
+}
+
+func (files) ClearRecovery() error {
+	root, err := stateRoot()
+	if err != nil {
+		return fmt.Errorf("opening state root: %w", err)
+	}
+	defer root.Close()
+
+	if err := root.Remove(recoveryFileName); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing recovery file: %w", err)
+	}
+	return nil
+}
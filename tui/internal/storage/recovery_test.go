@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+func TestSaveLoadAndClearRecovery(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", tmpDir)
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	if r, err := LoadRecovery(); err != nil || r != nil {
+		t.Fatalf("LoadRecovery() before a crash = %v, %v; want nil, nil", r, err)
+	}
+
+	want := &Recovery{
+		CrashedAt: time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC),
+		Reason:    "index out of range",
+		Namespace: Practice,
+		Session: GameSession{
+			GameID:      "abc",
+			Date:        "2026-10-15",
+			Inputs:      map[string]string{"X": "T"},
+			ElapsedTime: 42 * time.Second,
+		},
+	}
+	if err := SaveRecovery(want); err != nil {
+		t.Fatalf("SaveRecovery() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, appName, recoveryFileName)); err != nil {
+		t.Errorf("recovery file not in the state directory: %v", err)
+	}
+
+	got, err := LoadRecovery()
+	if err != nil || got == nil {
+		t.Fatalf("LoadRecovery() = %v, %v; want the saved recovery", got, err)
+	}
+	if !got.CrashedAt.Equal(want.CrashedAt) || got.Reason != want.Reason || got.Namespace != want.Namespace ||
+		got.Session.GameID != "abc" || got.Session.Inputs["X"] != "T" || got.Session.ElapsedTime != 42*time.Second {
+		t.Errorf("LoadRecovery() = %+v, want %+v", got, want)
+	}
+
+	if err := ClearRecovery(); err != nil {
+		t.Fatalf("ClearRecovery() error = %v", err)
+	}
+	if r, err := LoadRecovery(); err != nil || r != nil {
+		t.Errorf("LoadRecovery() after clearing = %v, %v; want nil, nil", r, err)
+	}
+	if err := ClearRecovery(); err != nil {
+		t.Errorf("ClearRecovery() without a file = %v, want nil", err)
+	}
+}
+
+func TestLoadRecovery_Corrupt(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", tmpDir)
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, appName), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, appName, recoveryFileName), []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRecovery(); err == nil {
+		t.Error("LoadRecovery() on a corrupt file succeeded, want an error")
+	}
+}
+
+func TestRecovery_Restorable(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace Namespace
+		gameID    string
+		want      bool
+	}{
+		{name: "daily", namespace: Daily, gameID: "abc", want: true},
+		{name: "practice", namespace: Practice, gameID: "abc", want: true},
+		{name: "custom", namespace: Custom, gameID: "local-abc"},
+		{name: "duel", namespace: Duel, gameID: "abc"},
+		{name: "no game ID", namespace: Daily},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Recovery{Namespace: tt.namespace, Session: GameSession{GameID: tt.gameID}}
+			if got := r.Restorable(); got != tt.want {
+				t.Errorf("Restorable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
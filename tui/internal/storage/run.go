@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/atomicfile"
+)
+
+// runFileName is the run marker in the XDG state directory, next to the
+// namespace directories.
+const runFileName = "run.json"
+
+// RunMarker records the latest run of the TUI: written when it starts and
+// again when it shuts down cleanly. A marker without ShutdownAt means that
+// run was killed, or the machine went down, before it could finish; the
+// crash recovery file only covers panics, which get to write it.
+type RunMarker struct {
+	StartedAt  time.Time  `json:"started_at"`
+	ShutdownAt *time.Time `json:"shutdown_at,omitempty"` // nil until the run shut down cleanly
+	PID        int        `json:"pid"`
+}
+
+// Clean reports whether the run shut down cleanly.
+func (r *RunMarker) Clean() bool {
+	return r.ShutdownAt != nil
+}
+
+// SaveRunMarker writes the run marker, replacing the last run's.
+func SaveRunMarker(r *RunMarker) error {
+	return backend().SaveRunMarker(r)
+}
+
+func (files) SaveRunMarker(r *RunMarker) error {
+	root, err := stateRoot()
+	if err != nil {
+		return fmt.Errorf("opening state root: %w", err)
+	}
+	defer root.Close()
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling run marker: %w", err)
+	}
+	if err := atomicfile.WriteFile(atomicfile.Root(root), runFileName, data, 0o600); err != nil {
+		return fmt.Errorf("writing run marker: %w", err)
+	}
+	return nil
+}
+
+// LoadRunMarker reads the run marker. Returns nil, nil before the first run
+// that wrote one.
+func LoadRunMarker() (*RunMarker, error) {
+	return backend().LoadRunMarker()
+}
+
+func (files) LoadRunMarker() (*RunMarker, error) {
+	root, err := stateRoot()
+	if err != nil {
+		return nil, fmt.Errorf("opening state root: %w", err)
+	}
+	defer root.Close()
+
+	data, err := root.ReadFile(runFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading run marker: %w", err)
+	}
+
+	var r RunMarker
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("unmarshaling run marker: %w", err)
+	}
+	return &r, nil
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+func TestSaveLoadRunMarker(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", tmpDir)
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	if r, err := LoadRunMarker(); err != nil || r != nil {
+		t.Fatalf("LoadRunMarker() before any run = %v, %v; want nil, nil", r, err)
+	}
+
+	started := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	r := &RunMarker{StartedAt: started, PID: 4242}
+	if err := SaveRunMarker(r); err != nil {
+		t.Fatalf("SaveRunMarker() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, appName, runFileName)); err != nil {
+		t.Errorf("run marker not in the state directory: %v", err)
+	}
+	got, err := LoadRunMarker()
+	if err != nil || !reflect.DeepEqual(got, r) || got.Clean() {
+		t.Fatalf("LoadRunMarker() while running = %+v, %v; want %+v, not clean", got, err, r)
+	}
+
+	stopped := started.Add(time.Hour)
+	r.ShutdownAt = &stopped
+	if err := SaveRunMarker(r); err != nil {
+		t.Fatalf("SaveRunMarker() error = %v", err)
+	}
+	if got, err := LoadRunMarker(); err != nil || !got.Clean() || !got.ShutdownAt.Equal(stopped) {
+		t.Errorf("LoadRunMarker() after shutdown = %+v, %v; want it clean at %v", got, err, stopped)
+	}
+}
+
+func TestMemory_RunMarker(t *testing.T) {
+	t.Cleanup(Use(NewMemory()))
+
+	if r, err := LoadRunMarker(); err != nil || r != nil {
+		t.Fatalf("LoadRunMarker() = %v, %v; want nil, nil", r, err)
+	}
+	r := &RunMarker{StartedAt: time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC), PID: 1}
+	if err := SaveRunMarker(r); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := LoadRunMarker(); err != nil || !reflect.DeepEqual(got, r) {
+		t.Errorf("LoadRunMarker() = %+v, %v; want %+v", got, err, r)
+	}
+}
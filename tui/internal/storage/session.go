@@ -1,13 +1,20 @@
 package storage
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/adrg/xdg"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/atomicfile"
 )
 
 // appName is the subdirectory name within the XDG state directory
@@ -18,18 +25,154 @@ type GameSession struct {
 	SavedAt        time.Time         `json:"saved_at"`
 	SolvedAt       *time.Time        `json:"solved_at,omitempty"`
 	Inputs         map[string]string `json:"inputs"`
+	Locked         []string          `json:"locked,omitempty"`       // cipher letters the player locked, sorted
+	LetterTimes    LetterTimes       `json:"letter_times,omitempty"` // per cipher letter: when it was first and last assigned
+	Hints          map[string]string `json:"hints,omitempty"`        // puzzle hints, cipher letter to plain letter
 	GameID         string            `json:"game_id"`
+	Date           string            `json:"date,omitempty"`           // puzzle date (YYYY-MM-DD); empty for custom puzzles and older sessions
+	EncryptedText  string            `json:"encrypted_text,omitempty"` // with Author, Category, Difficulty and Hints, lets the puzzle be shown without the API
+	Author         string            `json:"author,omitempty"`
+	Category       string            `json:"category,omitempty"`
+	Note           string            `json:"note,omitempty"`   // the player's note on the solve, e.g. "great pun"
+	Splits         []time.Duration   `json:"splits,omitempty"` // speed run: elapsed time when each word was first filled
 	ElapsedTime    time.Duration     `json:"elapsed_time"`
 	CompletionTime time.Duration     `json:"completion_time"`
+	Target         time.Duration     `json:"target,omitempty"` // speed run target time; 0 when not speed-running
+	Difficulty     int               `json:"difficulty,omitempty"`
 	Solved         bool              `json:"solved"`
 	Uploaded       bool              `json:"uploaded"`
+	AttemptSent    bool              `json:"attempt_sent,omitempty"` // reported to the server as an unsolved attempt
+	Revealed       bool              `json:"revealed,omitempty"`     // player gave up and revealed the answer; never Solved
+	AssistLevel    AssistLevel       `json:"assist_level,omitempty"` // Assists summed up when the session was saved
+	Assists
+}
+
+// Assists is the help a player had on a puzzle, uploaded with its solve so
+// stats can tell clean solves from assisted ones.
+type Assists struct {
+	HintsUsed       int  `json:"hints_used,omitempty"` // letters given on request, beyond the puzzle's own hints
+	AutoCheckUsed   bool `json:"auto_check_used,omitempty"`
+	RevealUsed      bool `json:"reveal_used,omitempty"`
+	SuggestionsUsed bool `json:"suggestions_used,omitempty"` // letters typed while dictionary words were suggested
+	Headless        bool `json:"headless,omitempty"`         // the answer was piped to 'solve --stdin', so its time isn't a solving time
+}
+
+// AssistLevel sums up the help a player had on a puzzle, so history and
+// stats can tell clean solves from assisted ones at a glance.
+type AssistLevel string
+
+const (
+	AssistNone  AssistLevel = ""      // no help
+	AssistLight AssistLevel = "light" // pointers: suggested words, letters filled on request
+	AssistHeavy AssistLevel = "heavy" // answers: wrong letters flagged, part of the answer revealed, or all of it piped in
+)
+
+// Level grades the assists; the heaviest help used decides.
+func (a Assists) Level() AssistLevel {
+	switch {
+	case a.AutoCheckUsed || a.RevealUsed || a.Headless:
+		return AssistHeavy
+	case a.HintsUsed > 0 || a.SuggestionsUsed:
+		return AssistLight
+	default:
+		return AssistNone
+	}
+}
+
+// SolveTime estimates when the session was solved, for recording it after the
+// fact: SolvedAt when set, otherwise SavedAt (older sessions were last saved
+// when solved), otherwise noon UTC on the puzzle's date, which is the same
+// date in nearly every time zone. Reports false when there's nothing to go on.
+func (s GameSession) SolveTime() (time.Time, bool) {
+	switch {
+	case s.SolvedAt != nil && !s.SolvedAt.IsZero():
+		return *s.SolvedAt, true
+	case !s.SavedAt.IsZero():
+		return s.SavedAt, true
+	}
+	day, err := time.Parse(time.DateOnly, s.Date)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return day.Add(12 * time.Hour), true
+}
+
+// LetterTiming records when a cipher letter was first and last given a plain
+// letter, as elapsed time on the puzzle's timer.
+type LetterTiming struct {
+	First time.Duration `json:"first"`
+	Last  time.Duration `json:"last"`
+}
+
+// LetterTimes records a LetterTiming per cipher letter. Hint letters are never
+// assigned, so they never appear.
+type LetterTimes map[string]LetterTiming
+
+const (
+	// MinPause is the shortest pause before placing a letter worth calling
+	// out as time spent stuck.
+	MinPause = time.Second
+	// MinRevision is the shortest span between first and last assigning a
+	// letter worth calling out; quicker fixes are just typos.
+	MinRevision = 5 * time.Second
+)
+
+// LongestPause finds the letter the player was stuck on longest: the biggest
+// gap between placing one new letter and the next, counting from the start.
+// Returns "" and no pause when nothing was placed.
+func (t LetterTimes) LongestPause() (string, time.Duration) {
+	order := slices.SortedFunc(maps.Keys(t), func(a, b string) int {
+		return cmp.Or(cmp.Compare(t[a].First, t[b].First), strings.Compare(a, b))
+	})
+
+	var letter string
+	var longest, previous time.Duration
+	for _, cipher := range order {
+		if gap := t[cipher].First - previous; gap > longest {
+			letter, longest = cipher, gap
+		}
+		previous = t[cipher].First
+	}
+	return letter, longest
 }
 
-// sessionsDir returns the absolute path to the sessions directory (~/.local/state/unquote/sessions/).
+// LongestRevision finds the letter that took longest to settle: the biggest
+// span between first and last assigning it. Returns "" and no span when no
+// letter was changed after it was first placed.
+func (t LetterTimes) LongestRevision() (string, time.Duration) {
+	var letter string
+	var longest time.Duration
+	for cipher, timing := range t {
+		span := timing.Last - timing.First
+		if span > longest || (span == longest && span > 0 && cipher < letter) {
+			letter, longest = cipher, span
+		}
+	}
+	return letter, longest
+}
+
+// Namespace names a directory of sessions under the XDG state directory.
+// Keeping practice games in their own namespace means they never show up in
+// the history or reconciliation that feed player stats.
+type Namespace string
+
+const (
+	// Daily holds sessions for regular play, which count toward stats.
+	Daily Namespace = "sessions"
+	// Practice holds practice-mode sessions, which are never uploaded.
+	Practice Namespace = "practice"
+	// Custom holds sessions for puzzles generated from the player's own quotes,
+	// which the API has never seen.
+	Custom Namespace = "custom"
+	// Duel holds sessions for head-to-head races, which are never uploaded.
+	Duel Namespace = "duel"
+)
+
+// dir returns the absolute path to the namespace's directory (~/.local/state/unquote/<namespace>/).
 // It uses xdg.StateFile to ensure the directory is created.
-func sessionsDir() (string, error) {
+func (n Namespace) dir() (string, error) {
 	// Create a probe file to ensure directory exists, then return the directory
-	probePath := filepath.Join(appName, "sessions", ".keep")
+	probePath := filepath.Join(appName, string(n), ".keep")
 	path, err := xdg.StateFile(probePath)
 	if err != nil {
 		return "", fmt.Errorf("creating sessions directory: %w", err)
@@ -37,10 +180,10 @@ func sessionsDir() (string, error) {
 	return filepath.Dir(path), nil
 }
 
-// sessionsRoot opens an os.Root handle on the sessions directory.
+// root opens an os.Root handle on the namespace's directory.
 // The caller must defer root.Close().
-func sessionsRoot() (*os.Root, error) {
-	dir, err := sessionsDir()
+func (n Namespace) root() (*os.Root, error) {
+	dir, err := n.dir()
 	if err != nil {
 		return nil, fmt.Errorf("getting sessions directory: %w", err)
 	}
@@ -56,78 +199,170 @@ func sessionFileName(gameID string) string {
 	return gameID + ".json"
 }
 
-// SaveSession persists a game session to disk.
-// Uses os.Root to confine file operations to the sessions directory.
+// SaveSession persists a game session to the Daily namespace.
 func SaveSession(session *GameSession) error {
+	return Daily.SaveSession(session)
+}
+
+// SaveSession persists a game session, stamping SavedAt with the current time.
+// The files backend uses os.Root to confine file operations to the namespace's directory.
+func (n Namespace) SaveSession(session *GameSession) error {
+	return n.SaveSessionAt(session, time.Now())
+}
+
+// SaveSessionAt persists a game session saved at the given time, for callers
+// that keep time on their own clock.
+func (n Namespace) SaveSessionAt(session *GameSession, savedAt time.Time) error {
 	if session.GameID == "" {
 		return fmt.Errorf("session has no game ID")
 	}
 
-	root, err := sessionsRoot()
+	session.SavedAt = savedAt
+	defer lockSession(n, session.GameID)()
+	return backend().SaveSession(n, session)
+}
+
+// sessionMus holds a mutex for each game saved in this process, keyed by
+// namespace and game ID. The UI saves on every keystroke, each from its own
+// command, so saves of one game would otherwise overlap.
+var (
+	sessionMus   = map[string]*sync.Mutex{}
+	sessionMusMu sync.Mutex // guards sessionMus
+)
+
+// lockSession locks gameID's session in n against other saves and
+// read-modify-writes of it, returning the unlock.
+func lockSession(n Namespace, gameID string) func() {
+	key := string(n) + "/" + gameID
+	sessionMusMu.Lock()
+	mu, ok := sessionMus[key]
+	if !ok {
+		mu = new(sync.Mutex)
+		sessionMus[key] = mu
+	}
+	sessionMusMu.Unlock()
+
+	mu.Lock()
+	return mu.Unlock
+}
+
+func (files) SaveSession(n Namespace, session *GameSession) error {
+	root, err := n.root()
 	if err != nil {
 		return fmt.Errorf("opening sessions root: %w", err)
 	}
 	defer root.Close()
 
-	session.SavedAt = time.Now()
+	return writeSessionFile(root, sessionFileName(session.GameID), session)
+}
 
+// writeSessionFile writes session to file name as it is, synced to disk
+// before and after the rename so power loss can't leave a half-written file.
+func writeSessionFile(root *os.Root, fileName string, session *GameSession) error {
 	data, err := json.MarshalIndent(session, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshaling session: %w", err)
 	}
 
-	fileName := sessionFileName(session.GameID)
-	tmpName := fileName + ".tmp"
-
-	// Write to temp file then rename for atomicity
-	if err := root.WriteFile(tmpName, data, 0o600); err != nil {
+	if err := atomicfile.WriteFile(atomicfile.Root(root), fileName, data, 0o600); err != nil {
 		return fmt.Errorf("writing session file: %w", err)
 	}
+	return nil
+}
 
-	if err := root.Rename(tmpName, fileName); err != nil {
-		_ = root.Remove(tmpName) // cleanup on failure
-		return fmt.Errorf("renaming session file: %w", err)
+// SetNote sets the player's note on a saved session, or removes it when note
+// is empty. The rest of the session, SavedAt included, is left as saved.
+func (n Namespace) SetNote(gameID, note string) error {
+	if gameID == "" {
+		return fmt.Errorf("game ID is empty")
 	}
-
-	return nil
+	defer lockSession(n, gameID)()
+	session, err := backend().LoadSession(n, gameID)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return fmt.Errorf("no saved session for %s", gameID)
+	}
+	session.Note = note
+	return backend().SaveSession(n, session)
 }
 
-// LoadSession loads a game session from disk.
-// Returns nil, nil if the session file doesn't exist.
+// LoadSession loads a game session from the Daily namespace.
 func LoadSession(gameID string) (*GameSession, error) {
+	return Daily.LoadSession(gameID)
+}
+
+// LoadSession loads a game session.
+// Returns nil, nil if there is no session, or its file was damaged beyond
+// recovery and moved to the corrupt/ directory.
+func (n Namespace) LoadSession(gameID string) (*GameSession, error) {
 	if gameID == "" {
 		return nil, fmt.Errorf("game ID is empty")
 	}
+	return backend().LoadSession(n, gameID)
+}
 
-	root, err := sessionsRoot()
+func (files) LoadSession(n Namespace, gameID string) (*GameSession, error) {
+	root, err := n.root()
 	if err != nil {
 		return nil, fmt.Errorf("opening sessions root: %w", err)
 	}
 	defer root.Close()
 
 	fileName := sessionFileName(gameID)
-	data, err := root.ReadFile(fileName)
+	session, err := readSession(root, fileName)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, nil // no session exists
+			// A save may have died before renaming its temp file into place
+			return promoteTemp(root, fileName), nil
 		}
 		return nil, fmt.Errorf("reading session file: %w", err)
 	}
 
-	var session GameSession
-	if err := json.Unmarshal(data, &session); err != nil {
-		return nil, fmt.Errorf("unmarshaling session: %w", err)
-	}
-
-	return &session, nil
+	return session, nil
 }
 
-// ListSolvedSessions returns all sessions that are solved but not yet uploaded.
+// ListSolvedSessions returns all Daily sessions that are solved but not yet uploaded.
 // These are candidates for reconciliation with the server.
-// Returns an empty slice (not an error) if the sessions directory doesn't exist.
-// os.Root does not expose ReadDir; use os.Open for enumeration, os.OpenRoot for confined reads.
 func ListSolvedSessions() ([]GameSession, error) {
-	dir, err := sessionsDir()
+	return Daily.ListSolvedSessions()
+}
+
+// ListSolvedSessions returns all sessions in the namespace that are solved but not yet uploaded.
+// Returns an empty slice (not an error) if the sessions directory doesn't exist.
+func (n Namespace) ListSolvedSessions() ([]GameSession, error) {
+	return backend().ListSessions(n, func(s GameSession) bool {
+		return s.Solved && !s.Uploaded
+	})
+}
+
+// ListSessions returns every session in the namespace, in no particular order.
+// Returns an empty slice (not an error) if the sessions directory doesn't exist.
+func (n Namespace) ListSessions() ([]GameSession, error) {
+	return backend().ListSessions(n, func(GameSession) bool { return true })
+}
+
+// ListUnfinishedSessions returns all sessions in the namespace that were
+// started but neither solved nor revealed, most recently saved first.
+// Returns an empty slice (not an error) if the sessions directory doesn't exist.
+func (n Namespace) ListUnfinishedSessions() ([]GameSession, error) {
+	sessions, err := backend().ListSessions(n, func(s GameSession) bool {
+		return !s.Solved && !s.Revealed
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(sessions, func(a, b GameSession) int {
+		return b.SavedAt.Compare(a.SavedAt)
+	})
+	return sessions, nil
+}
+
+// ListSessions reads every session file in the namespace's directory,
+// recovering interrupted saves and quarantining damaged files on the way.
+func (files) ListSessions(n Namespace, keep func(GameSession) bool) ([]GameSession, error) {
+	dir, err := n.dir()
 	if err != nil {
 		return nil, fmt.Errorf("getting sessions directory: %w", err)
 	}
@@ -148,45 +383,78 @@ func ListSolvedSessions() ([]GameSession, error) {
 		return nil, fmt.Errorf("reading sessions directory: %w", err)
 	}
 
-	root, err := sessionsRoot()
+	root, err := n.root()
 	if err != nil {
 		return nil, fmt.Errorf("opening sessions root: %w", err)
 	}
 	defer root.Close()
 
-	var result []GameSession
+	names := make(map[string]bool, len(entries))
 	for _, entry := range entries {
-		name := entry.Name()
-		// Skip non-JSON files and the .keep probe file
-		if entry.IsDir() || name == ".keep" || filepath.Ext(name) != ".json" {
-			continue
-		}
+		names[entry.Name()] = true
+	}
 
-		data, err := root.ReadFile(name)
+	var result []GameSession
+	for _, entry := range entries {
+		session, err := readEntry(root, entry, names)
 		if err != nil {
-			return nil, fmt.Errorf("reading session file %q: %w", name, err)
+			return nil, err
 		}
-
-		var session GameSession
-		if err := json.Unmarshal(data, &session); err != nil {
-			return nil, fmt.Errorf("unmarshaling session file %q: %w", name, err)
+		// Damaged files that couldn't be recovered were quarantined
+		if session != nil && keep(*session) {
+			result = append(result, *session)
 		}
+	}
 
-		if session.Solved && !session.Uploaded {
-			result = append(result, session)
+	return result, nil
+}
+
+// readEntry reads the session in one file of the sessions directory,
+// promoting the temp file of a save that died before renaming it into
+// place. names holds every file in the directory. Returns nil for files
+// that aren't sessions and for damaged ones, which are quarantined.
+func readEntry(root *os.Root, entry os.DirEntry, names map[string]bool) (*GameSession, error) {
+	name := entry.Name()
+	switch {
+	case entry.IsDir() || name == ".keep":
+		return nil, nil
+	case isOrphanedTemp(name, names):
+		base, _ := atomicfile.TempOf(name)
+		return promoteTemp(root, base), nil
+	case filepath.Ext(name) == ".json":
+		session, err := readSession(root, name)
+		if err != nil {
+			return nil, fmt.Errorf("reading session file %q: %w", name, err)
 		}
+		return session, nil
+	default:
+		return nil, nil
 	}
+}
 
-	return result, nil
+// isOrphanedTemp reports whether name is the temp file of a session save
+// whose session file isn't in names, because the save died before renaming
+// it into place.
+func isOrphanedTemp(name string, names map[string]bool) bool {
+	base, ok := atomicfile.TempOf(name)
+	return ok && filepath.Ext(base) == ".json" && !names[base]
 }
 
-// SessionExists checks if a session file exists for the given game ID.
+// SessionExists checks if a session file exists in the Daily namespace.
 func SessionExists(gameID string) (bool, error) {
+	return Daily.SessionExists(gameID)
+}
+
+// SessionExists checks if a session exists for the given game ID.
+func (n Namespace) SessionExists(gameID string) (bool, error) {
 	if gameID == "" {
 		return false, fmt.Errorf("game ID is empty")
 	}
+	return backend().SessionExists(n, gameID)
+}
 
-	root, err := sessionsRoot()
+func (files) SessionExists(n Namespace, gameID string) (bool, error) {
+	root, err := n.root()
 	if err != nil {
 		return false, fmt.Errorf("opening sessions root: %w", err)
 	}
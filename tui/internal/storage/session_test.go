@@ -2,6 +2,8 @@ package storage
 
 import (
 	"encoding/json"
+	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -141,6 +143,52 @@ func TestGameSession_SolvedAtNilForLegacySessions(t *testing.T) {
 	}
 }
 
+func TestGameSession_SolveTime(t *testing.T) {
+	solvedAt := time.Date(2026, 1, 15, 21, 30, 0, 0, time.UTC)
+	savedAt := time.Date(2026, 1, 16, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		session GameSession
+		want    time.Time
+		wantOK  bool
+	}{
+		{"solved at", GameSession{SolvedAt: &solvedAt, SavedAt: savedAt, Date: "2026-01-15"}, solvedAt, true},
+		{"legacy session falls back to saved at", GameSession{SavedAt: savedAt, Date: "2026-01-15"}, savedAt, true},
+		{"puzzle date", GameSession{Date: "2026-01-15"}, time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC), true},
+		{"nothing to go on", GameSession{Date: "someday"}, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.session.SolveTime()
+			if !got.Equal(tt.want) || ok != tt.wantOK {
+				t.Errorf("SolveTime() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestAssists_Level(t *testing.T) {
+	tests := []struct {
+		name    string
+		assists Assists
+		want    AssistLevel
+	}{
+		{"none", Assists{}, AssistNone},
+		{"hint", Assists{HintsUsed: 2}, AssistLight},
+		{"suggestions", Assists{SuggestionsUsed: true}, AssistLight},
+		{"auto-check", Assists{AutoCheckUsed: true}, AssistHeavy},
+		{"heaviest wins", Assists{HintsUsed: 1, RevealUsed: true}, AssistHeavy},
+		{"headless", Assists{Headless: true}, AssistHeavy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.assists.Level(); got != tt.want {
+				t.Errorf("Level() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSaveAndLoadSession(t *testing.T) {
 	// Use temp directory for testing
 	tmpDir := t.TempDir()
@@ -176,6 +224,7 @@ func TestSaveAndLoadSession(t *testing.T) {
 				ElapsedTime:    300 * time.Second,
 				Solved:         true,
 				CompletionTime: 300 * time.Second,
+				Assists:        Assists{HintsUsed: 1, AutoCheckUsed: true},
 			},
 		},
 	}
@@ -206,6 +255,9 @@ func TestSaveAndLoadSession(t *testing.T) {
 			if loaded.Solved != tt.session.Solved {
 				t.Errorf("Solved: expected %v, got %v", tt.session.Solved, loaded.Solved)
 			}
+			if loaded.Assists != tt.session.Assists {
+				t.Errorf("Assists: expected %+v, got %+v", tt.session.Assists, loaded.Assists)
+			}
 			if len(loaded.Inputs) != len(tt.session.Inputs) {
 				t.Errorf("Inputs length: expected %d, got %d", len(tt.session.Inputs), len(loaded.Inputs))
 			}
@@ -451,3 +503,192 @@ func TestSessionStoredInCorrectDirectory(t *testing.T) {
 		t.Error("SavedAt should not be zero")
 	}
 }
+
+func TestPracticeNamespaceIsSeparate(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", tmpDir)
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	session := &GameSession{
+		GameID:         "practice-game",
+		Inputs:         map[string]string{"A": "X"},
+		CompletionTime: 60 * time.Second,
+		Solved:         true,
+	}
+	if err := Practice.SaveSession(session); err != nil {
+		t.Fatalf("Practice.SaveSession failed: %v", err)
+	}
+
+	loaded, err := Practice.LoadSession("practice-game")
+	if err != nil || loaded == nil {
+		t.Fatalf("Practice.LoadSession() = %v, %v; want the saved session", loaded, err)
+	}
+
+	// The daily namespace must not see practice sessions
+	exists, err := SessionExists("practice-game")
+	if err != nil {
+		t.Fatalf("SessionExists failed: %v", err)
+	}
+	if exists {
+		t.Error("practice session should not exist in the Daily namespace")
+	}
+
+	solved, err := ListSolvedSessions()
+	if err != nil {
+		t.Fatalf("ListSolvedSessions failed: %v", err)
+	}
+	if len(solved) != 0 {
+		t.Errorf("expected no Daily reconciliation candidates, got %d", len(solved))
+	}
+}
+
+func TestListUnfinishedSessions(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	for _, s := range []*GameSession{
+		{GameID: "solved", Solved: true},
+		{GameID: "revealed", Revealed: true},
+		{GameID: "older", Date: "2026-01-18"},
+	} {
+		if err := SaveSession(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// SaveSession stamps SavedAt; make sure the next one is newer
+	time.Sleep(10 * time.Millisecond)
+	if err := SaveSession(&GameSession{GameID: "newer", Date: "2026-01-19"}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Daily.ListUnfinishedSessions()
+	if err != nil {
+		t.Fatalf("ListUnfinishedSessions failed: %v", err)
+	}
+	if len(result) != 2 || result[0].GameID != "newer" || result[1].GameID != "older" {
+		t.Errorf("ListUnfinishedSessions() = %+v, want newer then older", result)
+	}
+	if result[0].Date != "2026-01-19" {
+		t.Errorf("Date = %q, want it saved", result[0].Date)
+	}
+}
+
+func TestListSessions(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	for _, s := range []*GameSession{
+		{GameID: "solved", Solved: true, Uploaded: true},
+		{GameID: "revealed", Revealed: true},
+		{GameID: "unfinished"},
+	} {
+		if err := SaveSession(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := Daily.ListSessions()
+	if err != nil {
+		t.Fatalf("ListSessions failed: %v", err)
+	}
+	if len(result) != 3 {
+		t.Errorf("ListSessions() returned %d sessions, want all 3", len(result))
+	}
+}
+
+func TestSetNote(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	xdg.Reload()
+	t.Cleanup(xdg.Reload)
+
+	savedAt := time.Date(2026, 1, 15, 8, 0, 0, 0, time.UTC)
+	session := &GameSession{GameID: "note-game", Inputs: map[string]string{"A": "X"}, Solved: true}
+	if err := Custom.SaveSessionAt(session, savedAt); err != nil {
+		t.Fatalf("SaveSessionAt failed: %v", err)
+	}
+
+	if err := Custom.SetNote("note-game", "solved on the train"); err != nil {
+		t.Fatalf("SetNote failed: %v", err)
+	}
+	loaded, err := Custom.LoadSession("note-game")
+	if err != nil || loaded == nil {
+		t.Fatalf("LoadSession() = %v, %v", loaded, err)
+	}
+	if loaded.Note != "solved on the train" || !loaded.Solved || !loaded.SavedAt.Equal(savedAt) {
+		t.Errorf("session = %+v, want the note added and the rest kept", loaded)
+	}
+
+	if err := Custom.SetNote("note-game", ""); err != nil {
+		t.Fatalf("clearing the note failed: %v", err)
+	}
+	if loaded, _ := Custom.LoadSession("note-game"); loaded == nil || loaded.Note != "" {
+		t.Errorf("session = %+v, want the note removed", loaded)
+	}
+
+	if err := Custom.SetNote("missing-game", "hello"); err == nil {
+		t.Error("SetNote on a session that isn't saved should fail")
+	}
+}
+
+// Saves of one game from concurrent commands, as the UI makes on every
+// keystroke, all succeed and leave one whole session and no temp files.
+func TestSaveSession_Concurrent(t *testing.T) {
+	dir := setStateHome(t)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Go(func() {
+			session := &GameSession{GameID: "busy-game", ElapsedTime: time.Duration(i) * time.Second}
+			errs[i] = SaveSession(session)
+		})
+	}
+	wg.Go(func() {
+		// A note may land before any save, on a session that isn't there yet
+		_ = Daily.SetNote("busy-game", "typed fast")
+	})
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("save %d: %v", i, err)
+		}
+	}
+	if session, err := LoadSession("busy-game"); err != nil || session == nil {
+		t.Fatalf("LoadSession() = %v, %v; want one of the saves", session, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != "busy-game.json" {
+			t.Errorf("%s left in the sessions directory", entry.Name())
+		}
+	}
+}
+
+func TestLetterTimes_LongestPause(t *testing.T) {
+	times := LetterTimes{
+		"A": {First: 5 * time.Second, Last: 5 * time.Second},
+		"Q": {First: 70 * time.Second, Last: 70 * time.Second},
+		"B": {First: 8 * time.Second, Last: 90 * time.Second},
+	}
+
+	letter, pause := times.LongestPause()
+	if letter != "Q" || pause != 62*time.Second {
+		t.Errorf("LongestPause() = %s, %v; want Q after 1m2s", letter, pause)
+	}
+
+	letter, span := times.LongestRevision()
+	if letter != "B" || span != 82*time.Second {
+		t.Errorf("LongestRevision() = %s, %v; want B over 1m22s", letter, span)
+	}
+
+	if letter, pause := (LetterTimes{}).LongestPause(); letter != "" || pause != 0 {
+		t.Errorf("LongestPause() with no letters = %q, %v", letter, pause)
+	}
+}
@@ -0,0 +1,19 @@
+// Package storagetest gives tests storage that writes nothing to disk.
+package storagetest
+
+import (
+	"testing"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/storage"
+)
+
+// UseMemory makes an empty in-memory backend the storage for the rest of t
+// and returns it. Its SaveSession stores a session as given, without
+// stamping SavedAt, for setting up older sessions. Tests using it must not
+// run in parallel.
+func UseMemory(t testing.TB) storage.Backend {
+	t.Helper()
+	b := storage.NewMemory()
+	t.Cleanup(storage.Use(b))
+	return b
+}
@@ -0,0 +1,74 @@
+// Package telemetry sets up opt-in OpenTelemetry tracing. Tracing is off
+// unless an OTLP endpoint is configured in the standard OTEL_* environment
+// variables; while off, every span is a no-op and nothing is sent anywhere.
+package telemetry
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/versioninfo"
+)
+
+// serviceName names the TUI in traces, unless OTEL_SERVICE_NAME says otherwise.
+const serviceName = "unquote-tui"
+
+// Environment variables that turn tracing on or off. The exporter reads the
+// rest of the OTEL_EXPORTER_OTLP_* variables (headers, protocol options) itself.
+const (
+	envEndpoint       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	envTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	envSDKDisabled    = "OTEL_SDK_DISABLED"
+	envTracesExporter = "OTEL_TRACES_EXPORTER"
+)
+
+// Enabled reports whether the environment asks for traces: an OTLP endpoint
+// is set, and neither OTEL_SDK_DISABLED nor OTEL_TRACES_EXPORTER=none turns
+// the SDK off.
+func Enabled() bool {
+	if os.Getenv(envEndpoint) == "" && os.Getenv(envTracesEndpoint) == "" {
+		return false
+	}
+	return !strings.EqualFold(os.Getenv(envSDKDisabled), "true") &&
+		!strings.EqualFold(os.Getenv(envTracesExporter), "none")
+}
+
+// Setup installs an OTLP/HTTP trace exporter as the global tracer provider,
+// with W3C trace context propagation, when Enabled. It returns the function
+// that flushes and stops it; when tracing is off, that does nothing. Export
+// errors are dropped rather than printed over the TUI.
+func Setup(ctx context.Context) (shutdown func(context.Context) error, err error) {
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return func(context.Context) error { return nil }, err
+	}
+	// Later options win, so OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", versioninfo.Get().Version),
+		),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		res = resource.Default()
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(error) {}))
+	return provider.Shutdown, nil
+}
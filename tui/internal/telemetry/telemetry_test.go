@@ -0,0 +1,83 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// clearEnv unsets every variable Enabled reads.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{envEndpoint, envTracesEndpoint, envSDKDisabled, envTracesExporter} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		name string
+		want bool
+	}{
+		{name: "nothing set", want: false},
+		{name: "endpoint", env: map[string]string{envEndpoint: "http://collector:4318"}, want: true},
+		{name: "traces endpoint", env: map[string]string{envTracesEndpoint: "http://collector:4318/v1/traces"}, want: true},
+		{name: "sdk disabled", env: map[string]string{envEndpoint: "http://collector:4318", envSDKDisabled: "TRUE"}, want: false},
+		{name: "exporter none", env: map[string]string{envEndpoint: "http://collector:4318", envTracesExporter: "none"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			if got := Enabled(); got != tt.want {
+				t.Errorf("Enabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetup_OffLeavesGlobalsAlone(t *testing.T) {
+	clearEnv(t)
+
+	shutdown, err := Setup(context.Background())
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if _, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); ok {
+		t.Error("tracing off should keep the no-op tracer provider")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() = %v, want nil", err)
+	}
+}
+
+func TestSetup_On(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(envEndpoint, "http://127.0.0.1:1")
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	shutdown, err := Setup(context.Background())
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if _, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); !ok {
+		t.Errorf("tracer provider = %T, want the SDK's", otel.GetTracerProvider())
+	}
+	if fields := otel.GetTextMapPropagator().Fields(); len(fields) == 0 {
+		t.Error("want trace context propagated to the API")
+	}
+	// Nothing was traced, so shutting down has nothing to send
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() = %v, want nil", err)
+	}
+}
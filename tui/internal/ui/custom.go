@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"regexp"
+	"strconv"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Role names a palette color a custom theme can set.
+type Role string
+
+const (
+	RolePrimary   Role = "primary"    // titles, the cursor's letter, selections
+	RoleSecondary Role = "secondary"  // clues and loading text
+	RoleSuccess   Role = "success"    // the solved message
+	RoleError     Role = "error"      // errors
+	RoleMuted     Role = "muted"      // cipher letters, help and other quiet text
+	RoleWhite     Role = "white"      // text on the primary color, such as the header
+	RoleWarning   Role = "warning"    // conflicts and warnings
+	RoleText      Role = "text"       // headings on the terminal's own background
+	RoleCursor    Role = "cursor"     // background of the cell under the cursor
+	RoleTint      Role = "tint"       // background of cells sharing the cursor's cipher letter
+	RoleOnWarning Role = "on_warning" // text on the warning color
+)
+
+// Roles lists every role, in the order themes document them.
+var Roles = []Role{
+	RolePrimary, RoleSecondary, RoleSuccess, RoleError, RoleMuted, RoleWhite,
+	RoleWarning, RoleText, RoleCursor, RoleTint, RoleOnWarning,
+}
+
+// CustomTheme is a custom theme ready to use: the background it was made
+// for, and its colors by role. Roles it leaves out keep the palette's.
+type CustomTheme struct {
+	Background Background
+	Colors     map[Role]color.Color
+}
+
+// hexColor matches the "#RRGGBB" form of a theme color.
+var hexColor = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// ParseCustomTheme checks a theme file's background and colors: known roles,
+// each "#RRGGBB" or an ANSI color number from 0 to 255.
+func ParseCustomTheme(background string, colors map[string]string) (*CustomTheme, error) {
+	bg, ok := ParseBackground(background)
+	if !ok {
+		return nil, fmt.Errorf("background %q: expected light, dark or auto", background)
+	}
+	known := make(map[Role]bool, len(Roles))
+	for _, role := range Roles {
+		known[role] = true
+	}
+
+	theme := &CustomTheme{Background: bg, Colors: make(map[Role]color.Color, len(colors))}
+	for name, value := range colors {
+		if !known[Role(name)] {
+			return nil, fmt.Errorf("unknown color role %q", name)
+		}
+		c, err := parseColor(value)
+		if err != nil {
+			return nil, fmt.Errorf("color %s: %w", name, err)
+		}
+		theme.Colors[Role(name)] = c
+	}
+	return theme, nil
+}
+
+// parseColor parses a theme color: "#RRGGBB" or an ANSI number 0-255.
+func parseColor(s string) (color.Color, error) {
+	if hexColor.MatchString(s) {
+		return lipgloss.Color(s), nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 255 {
+		return lipgloss.Color(s), nil
+	}
+	return nil, fmt.Errorf("%q is not #RRGGBB or an ANSI color from 0 to 255", s)
+}
+
+// PickBackground returns the background to use: setting, unless it is auto
+// and theme was made for a background of its own.
+func PickBackground(setting Background, theme *CustomTheme) Background {
+	if setting == BackgroundAuto && theme != nil {
+		return theme.Background
+	}
+	return setting
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+)
+
+func TestParseCustomTheme(t *testing.T) {
+	theme, err := ParseCustomTheme("light", map[string]string{"primary": "#1F3A93", "muted": "244"})
+	if err != nil {
+		t.Fatalf("ParseCustomTheme: %v", err)
+	}
+	if theme.Background != BackgroundLight || len(theme.Colors) != 2 {
+		t.Errorf("theme = %+v, want light with two colors", theme)
+	}
+
+	tests := []struct {
+		name       string
+		background string
+		colors     map[string]string
+	}{
+		{"unknown role", "", map[string]string{"accent": "#FFFFFF"}},
+		{"short hex", "", map[string]string{"primary": "#FFF"}},
+		{"out of range", "", map[string]string{"primary": "256"}},
+		{"named color", "", map[string]string{"primary": "red"}},
+		{"bad background", "sepia", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCustomTheme(tt.background, tt.colors); err == nil {
+				t.Error("ParseCustomTheme should fail")
+			}
+		})
+	}
+}
+
+func TestPickBackground(t *testing.T) {
+	light := &CustomTheme{Background: BackgroundLight}
+	if got := PickBackground(BackgroundAuto, light); got != BackgroundLight {
+		t.Errorf("auto with a light theme = %q, want light", got)
+	}
+	if got := PickBackground(BackgroundDark, light); got != BackgroundDark {
+		t.Errorf("dark with a light theme = %q, want the setting to win", got)
+	}
+	if got := PickBackground(BackgroundAuto, nil); got != BackgroundAuto {
+		t.Errorf("auto without a theme = %q, want auto", got)
+	}
+}
+
+func TestUsePalette_CustomTheme(t *testing.T) {
+	restorePalette(t)
+
+	theme, err := ParseCustomTheme("", map[string]string{"primary": "#1F3A93"})
+	if err != nil {
+		t.Fatalf("ParseCustomTheme: %v", err)
+	}
+	UsePalette(true, colorprofile.TrueColor, theme)
+	if ColorPrimary != lipgloss.Color("#1F3A93") || ColorText != lipgloss.Color("#FFFFFF") {
+		t.Errorf("primary %v, text %v; want the theme's primary and the palette's text", ColorPrimary, ColorText)
+	}
+	if got := ActiveCellStyle.GetForeground(); got != ColorPrimary {
+		t.Errorf("ActiveCellStyle foreground = %v, want the theme's primary", got)
+	}
+
+	UsePalette(true, colorprofile.ANSI256, theme)
+	if ColorPrimary == lipgloss.Color("#1F3A93") {
+		t.Error("the theme's colors should be converted to the profile")
+	}
+}
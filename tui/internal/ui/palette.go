@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"image/color"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+)
+
+// Background is the terminal background the palette is chosen for, from
+// Config.Background.
+type Background string
+
+const (
+	BackgroundAuto  Background = ""      // ask the terminal, assuming dark when it doesn't answer
+	BackgroundDark  Background = "dark"  // light text on a dark background
+	BackgroundLight Background = "light" // dark text on a light background
+)
+
+// ParseBackground parses a background setting: "light", "dark", or "auto"
+// (or empty) for BackgroundAuto.
+func ParseBackground(s string) (Background, bool) {
+	switch s {
+	case "", "auto":
+		return BackgroundAuto, true
+	case string(BackgroundDark), string(BackgroundLight):
+		return Background(s), true
+	default:
+		return "", false
+	}
+}
+
+// IsDark reports whether b is a dark background, using detected, the
+// terminal's answer, when b is BackgroundAuto.
+func (b Background) IsDark(detected bool) bool {
+	switch b {
+	case BackgroundDark:
+		return true
+	case BackgroundLight:
+		return false
+	default:
+		return detected
+	}
+}
+
+// shades is a color as ANSI, ANSI 256 and true color, for each terminal's
+// color profile.
+type shades [3]string
+
+// paletteColor is a palette color for dark and light backgrounds.
+type paletteColor struct {
+	dark, light shades
+}
+
+// pick returns the color for the background and color profile.
+func (c paletteColor) pick(dark bool, profile colorprofile.Profile) color.Color {
+	complete := lipgloss.Complete(profile)
+	shade := func(s shades) color.Color {
+		return complete(lipgloss.Color(s[0]), lipgloss.Color(s[1]), lipgloss.Color(s[2]))
+	}
+	return lipgloss.LightDark(dark)(shade(c.light), shade(c.dark))
+}
+
+// The palette. The dark shades are the game's original 256 colors; the light
+// ones are darker and more saturated, so they read on white.
+var (
+	palettePrimary   = paletteColor{dark: shades{"12", "63", "#5F5FFF"}, light: shades{"5", "55", "#5F00AF"}}
+	paletteSecondary = paletteColor{dark: shades{"14", "86", "#5FFFD7"}, light: shades{"6", "30", "#008787"}}
+	paletteSuccess   = paletteColor{dark: shades{"10", "42", "#00D787"}, light: shades{"2", "28", "#008700"}}
+	paletteError     = paletteColor{dark: shades{"9", "196", "#FF0000"}, light: shades{"1", "160", "#D70000"}}
+	paletteMuted     = paletteColor{dark: shades{"8", "245", "#8A8A8A"}, light: shades{"8", "243", "#767676"}}
+	paletteWhite     = paletteColor{dark: shades{"15", "15", "#FFFFFF"}, light: shades{"15", "15", "#FFFFFF"}}
+	paletteWarning   = paletteColor{dark: shades{"11", "214", "#FFAF00"}, light: shades{"3", "166", "#D75F00"}}
+	paletteText      = paletteColor{dark: shades{"15", "15", "#FFFFFF"}, light: shades{"0", "16", "#000000"}}
+	paletteCursor    = paletteColor{dark: shades{"15", "15", "#FFFFFF"}, light: shades{"7", "189", "#D7D7FF"}}
+	paletteTint      = paletteColor{dark: shades{"8", "236", "#303030"}, light: shades{"7", "254", "#E4E4E4"}}
+	paletteOnWarning = paletteColor{dark: shades{"0", "16", "#000000"}, light: shades{"0", "16", "#000000"}}
+)
+
+func init() {
+	UsePalette(true, colorprofile.ANSI256, nil)
+}
+
+// paletteRoles ties each palette color to its role in custom themes and the
+// variable it sets.
+var paletteRoles = []struct {
+	role  Role
+	color paletteColor
+	set   *color.Color
+}{
+	{RolePrimary, palettePrimary, &ColorPrimary},
+	{RoleSecondary, paletteSecondary, &ColorSecondary},
+	{RoleSuccess, paletteSuccess, &ColorSuccess},
+	{RoleError, paletteError, &ColorError},
+	{RoleMuted, paletteMuted, &ColorMuted},
+	{RoleWhite, paletteWhite, &ColorWhite},
+	{RoleWarning, paletteWarning, &ColorWarning},
+	{RoleText, paletteText, &ColorText},
+	{RoleCursor, paletteCursor, &colorCursor},
+	{RoleTint, paletteTint, &colorTint},
+	{RoleOnWarning, paletteOnWarning, &colorOnWarning},
+}
+
+// UsePalette sets the colors for a dark or light background, each as close
+// as profile shows, with the colors a custom theme sets in place of the
+// palette's, and rebuilds the styles from them. theme may be nil. Bubble
+// Tea converts colors to the terminal's profile as it draws, so the TUI
+// passes colorprofile.TrueColor; output printed directly passes the profile
+// detected for it. Rendered text keeps the colors it was rendered with.
+func UsePalette(dark bool, profile colorprofile.Profile, theme *CustomTheme) {
+	for _, r := range paletteRoles {
+		*r.set = r.color.pick(dark, profile)
+		if theme != nil && theme.Colors[r.role] != nil {
+			*r.set = profile.Convert(theme.Colors[r.role])
+		}
+	}
+	buildStyles()
+}
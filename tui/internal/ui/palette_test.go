@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+)
+
+// restorePalette puts back the default palette after a test switches it.
+func restorePalette(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() { UsePalette(true, colorprofile.ANSI256, nil) })
+}
+
+func TestParseBackground(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   Background
+		wantOK bool
+	}{
+		{"", BackgroundAuto, true},
+		{"auto", BackgroundAuto, true},
+		{"light", BackgroundLight, true},
+		{"dark", BackgroundDark, true},
+		{"Light", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := ParseBackground(tt.in); got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseBackground(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestBackground_IsDark(t *testing.T) {
+	if !BackgroundAuto.IsDark(true) || BackgroundAuto.IsDark(false) {
+		t.Error("auto should follow the terminal")
+	}
+	if !BackgroundDark.IsDark(false) || BackgroundLight.IsDark(true) {
+		t.Error("a set background should win over the terminal")
+	}
+}
+
+func TestUsePalette(t *testing.T) {
+	restorePalette(t)
+
+	tests := []struct {
+		name    string
+		profile colorprofile.Profile
+		dark    bool
+		primary string
+		text    string
+	}{
+		{"dark 256", colorprofile.ANSI256, true, "63", "15"},
+		{"light 256", colorprofile.ANSI256, false, "55", "16"},
+		{"light true color", colorprofile.TrueColor, false, "#5F00AF", "#000000"},
+		{"dark 16", colorprofile.ANSI, true, "12", "15"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			UsePalette(tt.dark, tt.profile, nil)
+			if ColorPrimary != lipgloss.Color(tt.primary) || ColorText != lipgloss.Color(tt.text) {
+				t.Errorf("primary %v, text %v; want %s and %s", ColorPrimary, ColorText, tt.primary, tt.text)
+			}
+			if got := ActiveCellStyle.GetForeground(); got != ColorPrimary {
+				t.Errorf("ActiveCellStyle foreground = %v, want the styles rebuilt with %v", got, ColorPrimary)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+// Progress is how much of a puzzle's grid is filled in. Hint cells count as
+// filled letters.
+type Progress struct {
+	Filled      int // letters with an input
+	Letters     int // all letters
+	WordsFilled int // words with every letter filled in
+	Words       int // words with at least one letter
+}
+
+// MeasureProgress counts the filled letters and words in cells, splitting
+// words the way GroupCellsByWord does.
+func MeasureProgress(cells []puzzle.Cell) Progress {
+	var p Progress
+	for _, group := range GroupCellsByWord(cells) {
+		letters, filled := 0, 0
+		for _, cell := range group.Cells {
+			if cell.Kind == puzzle.CellPunctuation {
+				continue
+			}
+			letters++
+			if cell.Input != 0 {
+				filled++
+			}
+		}
+		if letters == 0 {
+			continue
+		}
+		p.Letters += letters
+		p.Filled += filled
+		p.Words++
+		if filled == letters {
+			p.WordsFilled++
+		}
+	}
+	return p
+}
+
+// Percent returns the share of letters filled in, rounded down, so 100 means
+// every letter has an input.
+func (p Progress) Percent() int {
+	if p.Letters == 0 {
+		return 0
+	}
+	return p.Filled * 100 / p.Letters
+}
+
+// Summary describes the progress as "12/30 letters · 2/7 words (40%)".
+func (p Progress) Summary() string {
+	return fmt.Sprintf("%d/%d letters · %d/%d words (%d%%)", p.Filled, p.Letters, p.WordsFilled, p.Words, p.Percent())
+}
+
+// progressBarWidth is the width of the progress bar in columns.
+const progressBarWidth = 20
+
+// RenderProgress renders a thin bar of the letters filled in, followed by
+// the summary. The bar is left out when both don't fit in width columns;
+// width 0 means no limit.
+func RenderProgress(p Progress, width int) string {
+	summary := TimerStyle.Render(p.Summary())
+	if width > 0 && progressBarWidth+1+lipgloss.Width(summary) > width {
+		return summary
+	}
+
+	done := 0
+	if p.Letters > 0 {
+		done = p.Filled * progressBarWidth / p.Letters
+	}
+	filled := lipgloss.NewStyle().Foreground(ColorSuccess).Render(strings.Repeat("━", done))
+	rest := lipgloss.NewStyle().Foreground(ColorMuted).Render(strings.Repeat("─", progressBarWidth-done))
+	return filled + rest + " " + summary
+}
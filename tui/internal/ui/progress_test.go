@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
+)
+
+func TestMeasureProgress(t *testing.T) {
+	// "XY-Z, W": the hint fills X, so XY- is one letter short until Y is set
+	cells := puzzle.BuildCells("XY-Z, W", map[rune]rune{'X': 'T'})
+	puzzle.SetInput(cells, 3, 'A')
+
+	got := MeasureProgress(cells)
+	want := Progress{Filled: 2, Letters: 4, WordsFilled: 0, Words: 2}
+	if got != want {
+		t.Errorf("MeasureProgress() = %+v, want %+v", got, want)
+	}
+
+	puzzle.SetInput(cells, 1, 'O')
+	puzzle.SetInput(cells, 6, 'I')
+	got = MeasureProgress(cells)
+	want = Progress{Filled: 4, Letters: 4, WordsFilled: 2, Words: 2}
+	if got != want {
+		t.Errorf("MeasureProgress() after filling = %+v, want %+v", got, want)
+	}
+	if got.Percent() != 100 {
+		t.Errorf("Percent() = %d, want 100", got.Percent())
+	}
+}
+
+func TestMeasureProgress_SkipsWordsWithoutLetters(t *testing.T) {
+	got := MeasureProgress(puzzle.BuildCells("AB - CD", nil))
+	if got.Words != 2 || got.Letters != 4 {
+		t.Errorf("MeasureProgress() = %+v, want 2 words of 4 letters; a lone dash is no word", got)
+	}
+}
+
+func TestProgress_Percent(t *testing.T) {
+	tests := []struct {
+		p    Progress
+		want int
+	}{
+		{Progress{}, 0},
+		{Progress{Filled: 1, Letters: 3}, 33},
+		{Progress{Filled: 2, Letters: 3}, 66},
+		{Progress{Filled: 3, Letters: 3}, 100},
+	}
+	for _, tt := range tests {
+		if got := tt.p.Percent(); got != tt.want {
+			t.Errorf("%+v.Percent() = %d, want %d", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestRenderProgress(t *testing.T) {
+	p := Progress{Filled: 5, Letters: 10, WordsFilled: 1, Words: 3}
+
+	got := ansi.Strip(RenderProgress(p, 0))
+	want := strings.Repeat("━", 10) + strings.Repeat("─", 10) + " 5/10 letters · 1/3 words (50%)"
+	if got != want {
+		t.Errorf("RenderProgress() = %q, want %q", got, want)
+	}
+
+	if got := ansi.Strip(RenderProgress(p, 40)); got != p.Summary() {
+		t.Errorf("RenderProgress() at 40 columns = %q, want just the summary", got)
+	}
+}
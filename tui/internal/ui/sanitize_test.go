@@ -84,3 +84,35 @@ func TestSanitizeString(t *testing.T) {
 		})
 	}
 }
+
+func TestNotifySequence(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "plain message",
+			input: "Solved in 2:45",
+			want:  "\x1b]9;Solved in 2:45\a\a",
+		},
+		{
+			name:  "embedded terminator stripped",
+			input: "Solved\a\x1b]9;pwned",
+			want:  "\x1b]9;Solved]9;pwned\a\a",
+		},
+		{
+			name:  "newlines flattened",
+			input: "Solved\nin 2:45\r\n",
+			want:  "\x1b]9;Solved in 2:45\a\a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NotifySequence(tt.input); got != tt.want {
+				t.Errorf("NotifySequence(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package ui
+
+import "strings"
+
+// Bell is the terminal bell (BEL). Terminals ring, flash, or ignore it
+// depending on the user's settings.
+const Bell = "\a"
+
+// NotifySequence returns an OSC 9 desktop notification carrying msg, followed
+// by a bell for terminals that don't support OSC 9. The message is sanitized
+// and flattened to one line so it cannot terminate the sequence early or
+// inject escape sequences of its own.
+func NotifySequence(msg string) string {
+	text := strings.Join(strings.Fields(SanitizeString(msg)), " ")
+	return "\x1b]9;" + text + "\a" + Bell
+}
@@ -1,96 +1,219 @@
 package ui
 
-import "charm.land/lipgloss/v2"
+import (
+	"image/color"
 
-// Colors
+	"charm.land/lipgloss/v2"
+)
+
+// Colors, set by UsePalette for the terminal's background
 var (
-	ColorPrimary   = lipgloss.Color("63")  // Purple
-	ColorSecondary = lipgloss.Color("86")  // Aqua
-	ColorSuccess   = lipgloss.Color("42")  // Green
-	ColorError     = lipgloss.Color("196") // Red
-	ColorMuted     = lipgloss.Color("245") // Gray
-	ColorWhite     = lipgloss.Color("15")  // White
-	ColorWarning   = lipgloss.Color("214") // Orange
+	ColorPrimary   color.Color // Purple
+	ColorSecondary color.Color // Aqua
+	ColorSuccess   color.Color // Green
+	ColorError     color.Color // Red
+	ColorMuted     color.Color // Gray
+	ColorWhite     color.Color // White, for text on the other colors
+	ColorWarning   color.Color // Orange
+	ColorText      color.Color // Text on the terminal's own background: white on dark, black on light
+)
+
+// Colors only the styles use
+var (
+	colorCursor    color.Color // background of the cell under the cursor
+	colorTint      color.Color // background of cells related to the cursor's
+	colorOnWarning color.Color // text on ColorWarning
+)
+
+// Theme is the palette the styles are drawn from. Renderers outside the
+// terminal, such as share images, take their colors from it so they match
+// the game.
+type Theme struct {
+	Primary   color.Color
+	Secondary color.Color
+	Success   color.Color
+	Error     color.Color
+	Muted     color.Color
+	White     color.Color
+	Warning   color.Color
+}
+
+// ActiveTheme returns the palette the styles use.
+func ActiveTheme() Theme {
+	return Theme{
+		Primary:   ColorPrimary,
+		Secondary: ColorSecondary,
+		Success:   ColorSuccess,
+		Error:     ColorError,
+		Muted:     ColorMuted,
+		White:     ColorWhite,
+		Warning:   ColorWarning,
+	}
+}
+
+// Styles, rebuilt from the colors by UsePalette
+var (
+	// HeaderStyle renders the main title header
+	HeaderStyle lipgloss.Style
+
+	// DifficultyStyle renders the difficulty indicator
+	DifficultyStyle lipgloss.Style
+
+	// HintStyle renders the hint clues
+	HintStyle lipgloss.Style
+
+	// CellStyle renders a single puzzle cell (user input)
+	CellStyle lipgloss.Style
+
+	// ActiveCellStyle renders the currently focused cell
+	ActiveCellStyle lipgloss.Style
+
+	// RelatedCellStyle highlights cells sharing the same cipher letter as the active cell.
+	// Uses background tint without bold to differentiate from ActiveCellStyle.
+	RelatedCellStyle lipgloss.Style
+
+	// DuplicateInputStyle highlights cells where the player's input letter
+	// is also assigned to a different ciphertext letter (conflict warning).
+	DuplicateInputStyle lipgloss.Style
+
+	// RevealedCellStyle renders letters filled in by giving up and revealing the
+	// solution, so a revealed grid never looks like one the player solved.
+	RevealedCellStyle lipgloss.Style
+
+	// ConsistentWordStyle renders the letters of a word that is filled in
+	// without conflicts: a quiet sign the word is settled, short of a check.
+	ConsistentWordStyle lipgloss.Style
+
+	// HintCellStyle renders prefilled hint cells with cyan foreground.
+	// Visually connects to the "Clues:" text above the grid.
+	HintCellStyle lipgloss.Style
+
+	// CipherStyle renders the cipher letter below input
+	CipherStyle lipgloss.Style
+
+	// CompactCellStyle renders one cell of the compact grid, where the input and
+	// cipher letter share a row ("A→X") followed by a separating column
+	CompactCellStyle lipgloss.Style
+
+	// CompactCipherStyle renders the "→X" cipher suffix of a compact grid cell
+	CompactCipherStyle lipgloss.Style
+
+	// AuthorStyle renders the quote author
+	AuthorStyle lipgloss.Style
+
+	// HelpStyle renders the help bar at bottom
+	HelpStyle lipgloss.Style
+
+	// CalloutStyle renders a tutorial step's explanation in a box, so it stands
+	// apart from the puzzle's own status lines
+	CalloutStyle lipgloss.Style
+
+	// StatusBarStyle renders the one-line footer pinned to the bottom of the screen
+	StatusBarStyle lipgloss.Style
+
+	// ErrorStyle renders error messages
+	ErrorStyle lipgloss.Style
+
+	// WarningStyle renders non-blocking warnings, such as conflicting letters
+	WarningStyle lipgloss.Style
+
+	// SuccessStyle renders success messages
+	SuccessStyle lipgloss.Style
+
+	// LoadingStyle renders loading indicator
+	LoadingStyle lipgloss.Style
+
+	// TimerStyle renders the elapsed time display
+	TimerStyle lipgloss.Style
 )
 
-// HeaderStyle renders the main title header
-var HeaderStyle = lipgloss.NewStyle().
-	Bold(true).
-	Foreground(ColorWhite).
-	Background(ColorPrimary).
-	Align(lipgloss.Center).
-	Padding(1, 2)
-
-// DifficultyStyle renders the difficulty indicator
-var DifficultyStyle = lipgloss.NewStyle().
-	Foreground(ColorMuted).
-	Align(lipgloss.Center)
-
-// HintStyle renders the hint clues
-var HintStyle = lipgloss.NewStyle().
-	Foreground(ColorSecondary).
-	Italic(true).
-	PaddingLeft(2)
-
-// CellStyle renders a single puzzle cell (user input)
-var CellStyle = lipgloss.NewStyle().
-	Width(3).
-	Align(lipgloss.Center)
-
-// ActiveCellStyle renders the currently focused cell
-var ActiveCellStyle = CellStyle.
-	Foreground(ColorPrimary).
-	Background(ColorWhite).
-	Bold(true)
-
-// RelatedCellStyle highlights cells sharing the same cipher letter as the active cell.
-// Uses background tint without bold to differentiate from ActiveCellStyle.
-var RelatedCellStyle = CellStyle.
-	Background(lipgloss.Color("236"))
-
-// DuplicateInputStyle highlights cells where the player's input letter
-// is also assigned to a different ciphertext letter (conflict warning).
-var DuplicateInputStyle = CellStyle.
-	Background(ColorWarning).
-	Foreground(lipgloss.Color("16"))
-
-// HintCellStyle renders prefilled hint cells with cyan foreground.
-// Visually connects to the "Clues:" text above the grid.
-var HintCellStyle = CellStyle.
-	Foreground(ColorSecondary)
-
-// CipherStyle renders the cipher letter below input
-var CipherStyle = lipgloss.NewStyle().
-	Width(3).
-	Align(lipgloss.Center).
-	Foreground(ColorMuted)
-
-// AuthorStyle renders the quote author
-var AuthorStyle = lipgloss.NewStyle().
-	Foreground(ColorMuted).
-	Italic(true).
-	Align(lipgloss.Right).
-	PaddingTop(1)
-
-// HelpStyle renders the help bar at bottom
-var HelpStyle = lipgloss.NewStyle().
-	Foreground(ColorMuted).
-	PaddingTop(1)
-
-// ErrorStyle renders error messages
-var ErrorStyle = lipgloss.NewStyle().
-	Foreground(ColorError).
-	Bold(true)
-
-// SuccessStyle renders success messages
-var SuccessStyle = lipgloss.NewStyle().
-	Foreground(ColorSuccess).
-	Bold(true)
-
-// LoadingStyle renders loading indicator
-var LoadingStyle = lipgloss.NewStyle().
-	Foreground(ColorSecondary)
-
-// TimerStyle renders the elapsed time display
-var TimerStyle = lipgloss.NewStyle().
-	Foreground(ColorMuted)
+// buildStyles builds the styles from the current colors.
+func buildStyles() {
+	HeaderStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorWhite).
+		Background(ColorPrimary).
+		Align(lipgloss.Center).
+		Padding(1, 2)
+
+	DifficultyStyle = lipgloss.NewStyle().
+		Foreground(ColorMuted).
+		Align(lipgloss.Center)
+
+	HintStyle = lipgloss.NewStyle().
+		Foreground(ColorSecondary).
+		Italic(true).
+		PaddingLeft(2)
+
+	CellStyle = lipgloss.NewStyle().
+		Width(3).
+		Align(lipgloss.Center)
+
+	ActiveCellStyle = CellStyle.
+		Foreground(ColorPrimary).
+		Background(colorCursor).
+		Bold(true)
+
+	RelatedCellStyle = CellStyle.
+		Background(colorTint)
+
+	DuplicateInputStyle = CellStyle.
+		Background(ColorWarning).
+		Foreground(colorOnWarning)
+
+	RevealedCellStyle = CellStyle.
+		Foreground(ColorWarning).
+		Italic(true)
+
+	ConsistentWordStyle = CellStyle.
+		Foreground(ColorSuccess)
+
+	HintCellStyle = CellStyle.
+		Foreground(ColorSecondary)
+
+	CipherStyle = lipgloss.NewStyle().
+		Width(3).
+		Align(lipgloss.Center).
+		Foreground(ColorMuted)
+
+	CompactCellStyle = lipgloss.NewStyle().
+		Width(4)
+
+	CompactCipherStyle = lipgloss.NewStyle().
+		Foreground(ColorMuted)
+
+	AuthorStyle = lipgloss.NewStyle().
+		Foreground(ColorMuted).
+		Italic(true).
+		Align(lipgloss.Right).
+		PaddingTop(1)
+
+	HelpStyle = lipgloss.NewStyle().
+		Foreground(ColorMuted).
+		PaddingTop(1)
+
+	CalloutStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorSecondary).
+		Padding(0, 1)
+
+	StatusBarStyle = lipgloss.NewStyle().
+		Foreground(ColorMuted)
+
+	ErrorStyle = lipgloss.NewStyle().
+		Foreground(ColorError).
+		Bold(true)
+
+	WarningStyle = lipgloss.NewStyle().
+		Foreground(ColorWarning)
+
+	SuccessStyle = lipgloss.NewStyle().
+		Foreground(ColorSuccess).
+		Bold(true)
+
+	LoadingStyle = lipgloss.NewStyle().
+		Foreground(ColorSecondary)
+
+	TimerStyle = lipgloss.NewStyle().
+		Foreground(ColorMuted)
+}
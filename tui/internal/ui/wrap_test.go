@@ -1,8 +1,11 @@
 package ui
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/bojanrajkovic/unquote/tui/internal/perfbudget"
 	"github.com/bojanrajkovic/unquote/tui/internal/puzzle"
 )
 
@@ -73,3 +76,25 @@ func TestWordWrapText(t *testing.T) {
 		}
 	}
 }
+
+func BenchmarkWrapWordGroups(b *testing.B) {
+	for _, p := range perfbudget.Puzzles {
+		groups := GroupCellsByWord(puzzle.BuildCells(p.Text, nil))
+		for _, width := range perfbudget.Widths {
+			b.Run(fmt.Sprintf("%s/%d", p.Name, width), func(b *testing.B) {
+				for b.Loop() {
+					WrapWordGroups(groups, width, 3)
+				}
+			})
+		}
+	}
+}
+
+func TestWrapWordGroups_Budget(t *testing.T) {
+	groups := GroupCellsByWord(puzzle.BuildCells(perfbudget.Puzzles[len(perfbudget.Puzzles)-1].Text, nil))
+	perfbudget.Check(t, 50*time.Microsecond, func(b *testing.B) {
+		for b.Loop() {
+			WrapWordGroups(groups, 40, 3)
+		}
+	})
+}
@@ -4,6 +4,7 @@ package versioninfo
 import (
 	"fmt"
 	"runtime/debug"
+	"strconv"
 	"strings"
 )
 
@@ -18,12 +19,12 @@ var (
 
 // Info contains version and build information.
 type Info struct {
-	Version   string
-	Branch    string
-	Commit    string
-	Date      string
-	GoVersion string
-	Modified  bool
+	Version   string `json:"version"`
+	Branch    string `json:"branch"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"goVersion"`
+	Modified  bool   `json:"modified"`
 }
 
 // Get returns the current build information by combining
@@ -93,3 +94,47 @@ func (i Info) String() string {
 
 	return strings.Join(parts, "\n")
 }
+
+// IsRelease reports whether this build carries a release version, as opposed
+// to a development or snapshot build.
+func (i Info) IsRelease() bool {
+	_, ok := parseRelease(i.Version)
+	return ok
+}
+
+// UpdateAvailable reports whether latest is a newer release than this build.
+// Builds without a release version (e.g. "dev" or snapshots) never report one.
+func (i Info) UpdateAvailable(latest string) bool {
+	current, ok := parseRelease(i.Version)
+	if !ok {
+		return false
+	}
+	newest, ok := parseRelease(latest)
+	if !ok {
+		return false
+	}
+	for n := range current {
+		if newest[n] != current[n] {
+			return newest[n] > current[n]
+		}
+	}
+	return false
+}
+
+// parseRelease parses a plain "MAJOR.MINOR.PATCH" release version, with or
+// without a leading "v". Pre-release and snapshot versions are rejected.
+func parseRelease(version string) ([3]int, bool) {
+	var parsed [3]int
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) != len(parsed) {
+		return parsed, false
+	}
+	for n, part := range parts {
+		num, err := strconv.Atoi(part)
+		if err != nil || num < 0 {
+			return parsed, false
+		}
+		parsed[n] = num
+	}
+	return parsed, true
+}
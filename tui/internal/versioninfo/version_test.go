@@ -122,3 +122,39 @@ func TestInfoStringOmitsEmptyFields(t *testing.T) {
 		t.Error("String() should not contain 'go:' when GoVersion is empty")
 	}
 }
+
+func TestUpdateAvailable(t *testing.T) {
+	tests := []struct {
+		current string
+		latest  string
+		want    bool
+	}{
+		{"0.8.1", "0.9.0", true},
+		{"0.8.1", "v0.8.2", true},
+		{"v0.9.0", "1.0.0", true},
+		{"0.8.1", "0.8.1", false},
+		{"0.10.0", "0.9.9", false},
+		{"dev", "0.9.0", false},
+		{"0.8.1-snapshot+abc123", "0.9.0", false},
+		{"0.8.1", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := (Info{Version: tt.current}).UpdateAvailable(tt.latest); got != tt.want {
+			t.Errorf("Info{Version: %q}.UpdateAvailable(%q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
+
+func TestIsRelease(t *testing.T) {
+	for version, want := range map[string]bool{
+		"0.8.1":                 true,
+		"v1.0.0":                true,
+		"dev":                   false,
+		"0.8.1-snapshot+abc123": false,
+	} {
+		if got := (Info{Version: version}).IsRelease(); got != want {
+			t.Errorf("Info{Version: %q}.IsRelease() = %v, want %v", version, got, want)
+		}
+	}
+}
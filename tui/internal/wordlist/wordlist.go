@@ -0,0 +1,20 @@
+// Package wordlist embeds a list of common English words for the word
+// suggestion assist.
+package wordlist
+
+import (
+	_ "embed"
+	"strings"
+)
+
+// english holds about 3,000 common English words, one per line in lower
+// case, roughly most frequent first. Contractions are left out.
+//
+//go:embed english.txt
+var english string
+
+// English returns the embedded words in upper case, in the file's order, so
+// more common words come first.
+func English() []string {
+	return strings.Fields(strings.ToUpper(english))
+}
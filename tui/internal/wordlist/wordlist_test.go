@@ -0,0 +1,28 @@
+package wordlist
+
+import (
+	"testing"
+)
+
+func TestEnglish(t *testing.T) {
+	words := English()
+	if len(words) < 3000 {
+		t.Fatalf("%d words, want the whole list", len(words))
+	}
+	if words[0] != "THE" {
+		t.Errorf("first word = %q, want THE, the most common", words[0])
+	}
+
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		for _, r := range word {
+			if r < 'A' || r > 'Z' {
+				t.Fatalf("%q has %q; want only A-Z", word, string(r))
+			}
+		}
+		if seen[word] {
+			t.Errorf("%q is listed twice", word)
+		}
+		seen[word] = true
+	}
+}